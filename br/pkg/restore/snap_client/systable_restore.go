@@ -29,16 +29,19 @@ const (
 
 var statsTables = map[string]map[string]struct{}{
 	"mysql": {
-		"stats_buckets":      {},
-		"stats_extended":     {},
-		"stats_feedback":     {},
-		"stats_fm_sketch":    {},
-		"stats_histograms":   {},
-		"stats_history":      {},
-		"stats_meta":         {},
-		"stats_meta_history": {},
-		"stats_table_locked": {},
-		"stats_top_n":        {},
+		"stats_buckets":             {},
+		"stats_expression_usage":    {},
+		"stats_expressions":         {},
+		"stats_extended":            {},
+		"stats_feedback":            {},
+		"stats_fm_sketch":           {},
+		"stats_histograms":          {},
+		"stats_history":             {},
+		"stats_incremental_analyze": {},
+		"stats_meta":                {},
+		"stats_meta_history":        {},
+		"stats_table_locked":        {},
+		"stats_top_n":               {},
 	},
 }
 
@@ -68,6 +71,10 @@ var unRecoverableTable = map[string]map[string]struct{}{
 
 		// replace into view is not supported now
 		"tidb_mdl_view": {},
+
+		// the refresh states are keyed by the table IDs of the materialized views, which are changed
+		// after restore. The views fall back to the complete refresh without the states.
+		"tidb_mview_refresh": {},
 	},
 	"sys": {
 		// replace into view is not supported now
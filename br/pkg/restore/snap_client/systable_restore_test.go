@@ -115,5 +115,5 @@ func TestCheckSysTableCompatibility(t *testing.T) {
 //
 // The above variables are in the file br/pkg/restore/systable_restore.go
 func TestMonitorTheSystemTableIncremental(t *testing.T) {
-	require.Equal(t, int64(210), session.CurrentBootstrapVersion)
+	require.Equal(t, int64(216), session.CurrentBootstrapVersion)
 }
@@ -40,6 +40,9 @@ const (
 	deleted = "deleted"
 	// Invalid is the bind info's invalid status.
 	Invalid = "invalid"
+	// Rejected is the status of the plan which is verified by the baseline evolution and is not faster
+	// than the binding. It is recorded to avoid verifying the same plan again and again.
+	Rejected = "rejected"
 	// Manual indicates the binding is created by SQL like "create binding for ...".
 	Manual = "manual"
 	// Capture indicates the binding is captured by TiDB automatically.
@@ -48,6 +51,8 @@ const (
 	Builtin = "builtin"
 	// History indicate the binding is created from statement summary by plan digest
 	History = "history"
+	// Evolve indicates the binding is created by the baseline evolution.
+	Evolve = "evolve"
 )
 
 // Binding stores the basic bind hint info.
@@ -58,6 +63,7 @@ type Binding struct {
 	// Status represents the status of the binding. It can only be one of the following values:
 	// 1. deleted: Bindings is deleted, can not be used anymore.
 	// 2. enabled, using: Binding is in the normal active mode.
+	// 3. rejected: Binding is rejected by the baseline evolution, it is only kept as the evolution history.
 	Status     string
 	CreateTime types.Time
 	UpdateTime types.Time
@@ -131,6 +131,9 @@ func (fbc *fuzzyBindingCache) getFromMemory(sctx sessionctx.Context, fuzzyDigest
 		}
 		if bindings != nil {
 			for _, binding := range bindings {
+				if binding.Status == Rejected {
+					continue // rejected bindings are only kept as the evolution history
+				}
 				numWildcards, matched := fuzzyMatchBindingTableName(sctx.GetSessionVars().CurrentDB, tableNames, binding.TableNames)
 				if matched && numWildcards > 0 && sctx != nil && !enableFuzzyBinding {
 					continue // fuzzy binding is disabled, skip this binding
@@ -0,0 +1,419 @@
+// Copyright 2024 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package bindinfo
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/pingcap/errors"
+	"github.com/pingcap/failpoint"
+	"github.com/pingcap/tidb/pkg/bindinfo/internal/logutil"
+	"github.com/pingcap/tidb/pkg/kv"
+	"github.com/pingcap/tidb/pkg/parser"
+	"github.com/pingcap/tidb/pkg/parser/ast"
+	"github.com/pingcap/tidb/pkg/parser/mysql"
+	"github.com/pingcap/tidb/pkg/parser/terror"
+	"github.com/pingcap/tidb/pkg/sessionctx"
+	"github.com/pingcap/tidb/pkg/sessionctx/variable"
+	"github.com/pingcap/tidb/pkg/types"
+	"github.com/pingcap/tidb/pkg/util/hint"
+	utilparser "github.com/pingcap/tidb/pkg/util/parser"
+	"github.com/pingcap/tidb/pkg/util/sqlkiller"
+	"github.com/pingcap/tidb/pkg/util/timeutil"
+	"go.uber.org/zap"
+)
+
+const (
+	// acceptFactor is the factor to decide whether to accept a verified plan. A plan is accepted only
+	// if it runs at least `acceptFactor` times faster than the binding.
+	acceptFactor = 1.5
+	// rejectedBindingTTL is the duration in which a rejected plan is not verified again by the
+	// background evolution.
+	rejectedBindingTTL = 7 * 24 * time.Hour
+	// verifyRuns is the number of times every plan is executed in the verification.
+	verifyRuns = 2
+)
+
+// evolveParams is the parameters of the baseline evolution read from the global variables.
+type evolveParams struct {
+	// maxTime is the time budget of verifying a plan, 0 means no limit.
+	maxTime   time.Duration
+	startTime time.Time
+	endTime   time.Time
+}
+
+func getEvolveParams(sctx sessionctx.Context) (*evolveParams, error) {
+	accessor := sctx.GetSessionVars().GlobalVarsAccessor
+	maxTimeStr, err := accessor.GetGlobalSysVar(variable.TiDBEvolvePlanTaskMaxTime)
+	if err != nil {
+		return nil, err
+	}
+	maxTime, err := strconv.ParseInt(maxTimeStr, 10, 64)
+	if err != nil {
+		return nil, err
+	}
+	params := &evolveParams{}
+	if maxTime > 0 {
+		params.maxTime = time.Duration(maxTime) * time.Second
+	}
+	startTimeStr, err := accessor.GetGlobalSysVar(variable.TiDBEvolvePlanTaskStartTime)
+	if err != nil {
+		return nil, err
+	}
+	params.startTime, err = time.ParseInLocation(variable.FullDayTimeFormat, startTimeStr, time.UTC)
+	if err != nil {
+		return nil, err
+	}
+	endTimeStr, err := accessor.GetGlobalSysVar(variable.TiDBEvolvePlanTaskEndTime)
+	if err != nil {
+		return nil, err
+	}
+	params.endTime, err = time.ParseInLocation(variable.FullDayTimeFormat, endTimeStr, time.UTC)
+	if err != nil {
+		return nil, err
+	}
+	return params, nil
+}
+
+// EvolveGlobalBindings verifies the plans chosen by the optimizer without bindings, and accepts the
+// ones which are faster than the bindings. Only the captured and evolved bindings are evolved unless
+// sqlDigest is specified. The adminEvolve indicates it's called by ADMIN EVOLVE BINDINGS, which
+// ignores the time window of the evolution and verifies the rejected plans again.
+func (h *globalBindingHandle) EvolveGlobalBindings(sctx sessionctx.Context, sqlDigest string, adminEvolve bool) error {
+	params, err := getEvolveParams(sctx)
+	if err != nil {
+		return err
+	}
+	if !adminEvolve && !timeutil.WithinDayTimePeriod(params.startTime, params.endTime, time.Now()) {
+		return nil
+	}
+	found := false
+	for _, binding := range h.GetAllGlobalBindings() {
+		if !binding.IsBindingEnabled() {
+			continue
+		}
+		if sqlDigest != "" {
+			if binding.SQLDigest != sqlDigest {
+				continue
+			}
+		} else if binding.Source != Capture && binding.Source != Evolve {
+			// The manual bindings are specified by users on purpose, they are not evolved automatically.
+			continue
+		}
+		found = true
+		digest := parser.DigestNormalized(binding.OriginalSQL).String()
+		if err := h.evolveBinding(sctx, digest, binding, params.maxTime, adminEvolve); err != nil {
+			logutil.BindLogger().Warn("evolve binding failed", zap.String("bindSQL", binding.BindSQL), zap.Error(err))
+		}
+	}
+	if sqlDigest != "" && !found {
+		return errors.Errorf("can't find any enabled global binding for sql digest '%s'", sqlDigest)
+	}
+	return nil
+}
+
+// evolveBinding verifies the plan chosen by the optimizer for the statement of the binding if it's
+// different from the binding, and records the result in mysql.bind_info.
+func (h *globalBindingHandle) evolveBinding(sctx sessionctx.Context, digest string, binding Binding, maxTime time.Duration, adminEvolve bool) error {
+	stmt, err := parser.New().ParseOneStmt(binding.BindSQL, binding.Charset, binding.Collation)
+	if err != nil {
+		return err
+	}
+	// Only the SELECT statements are verified since the plans are verified by executing them.
+	if _, ok := stmt.(*ast.SelectStmt); !ok || isFuzzyBinding(stmt) {
+		return nil
+	}
+	paramChecker := &paramMarkerChecker{}
+	stmt.Accept(paramChecker)
+	if paramChecker.hasParamMarker {
+		return nil
+	}
+	// The statements with side effects can't be executed for the verification.
+	effectChecker := &sideEffectChecker{}
+	stmt.Accept(effectChecker)
+	if effectChecker.hasSideEffect {
+		return nil
+	}
+	hint.BindHint(stmt, &hint.HintsSet{})
+	sql := utilparser.RestoreWithDefaultDB(stmt, binding.Db, "")
+	if sql == "" {
+		return nil
+	}
+	planHint, err := getHintsForSQL(sctx, sql)
+	if err != nil {
+		return err
+	}
+	candidate := Binding{
+		OriginalSQL: binding.OriginalSQL,
+		Db:          binding.Db,
+		BindSQL:     GenerateBindingSQL(stmt, planHint, true, binding.Db),
+		Status:      Enabled,
+		Charset:     binding.Charset,
+		Collation:   binding.Collation,
+		Source:      Evolve,
+		SQLDigest:   binding.SQLDigest,
+	}
+	if candidate.BindSQL == "" {
+		return nil
+	}
+	// We don't need to pass the `sctx` because the BindSQL is generated by the optimizer.
+	if err := prepareHints(nil, &candidate); err != nil {
+		return err
+	}
+	if candidate.isSame(&binding) || candidate.Hint.ContainTableHint(hint.HintReadFromStorage) {
+		return nil
+	}
+	if !adminEvolve {
+		for _, b := range h.getCache().GetBinding(digest) {
+			if b.Status != Rejected || !b.isSame(&candidate) {
+				continue
+			}
+			if since, err := b.SinceUpdateTime(); err == nil && since < rejectedBindingTTL {
+				return nil
+			}
+		}
+	}
+
+	accepted, reason, err := verifyPlan(sctx, binding.BindSQL, candidate.BindSQL, maxTime)
+	if err != nil {
+		return err
+	}
+	if !accepted {
+		candidate.Status = Rejected
+	}
+	logutil.BindLogger().Info("baseline evolution finished", zap.String("bindSQL", binding.BindSQL),
+		zap.String("candidateSQL", candidate.BindSQL), zap.String("reason", reason))
+	return h.addEvolvedBinding(candidate, reason)
+}
+
+// sideEffectFunctions stores the functions which have side effects or return different results
+// in every run, so the plans of the statements calling them are not verified.
+var sideEffectFunctions = map[string]struct{}{
+	ast.Sleep:           {},
+	ast.GetLock:         {},
+	ast.ReleaseLock:     {},
+	ast.ReleaseAllLocks: {},
+	ast.SetVar:          {},
+	ast.NextVal:         {},
+	ast.SetVal:          {},
+	ast.Rand:            {},
+	ast.RandomBytes:     {},
+	ast.UUID:            {},
+	ast.UUIDShort:       {},
+}
+
+// sideEffectChecker checks whether a statement locks rows, writes to a file or variables, or calls
+// the functions in sideEffectFunctions.
+type sideEffectChecker struct {
+	hasSideEffect bool
+}
+
+func (e *sideEffectChecker) Enter(in ast.Node) (ast.Node, bool) {
+	switch x := in.(type) {
+	case *ast.SelectStmt:
+		if (x.LockInfo != nil && x.LockInfo.LockType != ast.SelectLockNone) || x.SelectIntoOpt != nil {
+			e.hasSideEffect = true
+		}
+	case *ast.VariableExpr:
+		if x.Value != nil {
+			e.hasSideEffect = true
+		}
+	case *ast.FuncCallExpr:
+		if _, ok := sideEffectFunctions[x.FnName.L]; ok {
+			e.hasSideEffect = true
+		}
+		if x.FnName.L == ast.LastInsertId && len(x.Args) > 0 {
+			e.hasSideEffect = true
+		}
+	}
+	return in, e.hasSideEffect
+}
+
+func (*sideEffectChecker) Leave(in ast.Node) (ast.Node, bool) {
+	return in, true
+}
+
+// verifyPlan executes the statements with the plans of the binding and the candidate on the same
+// snapshot, and decides whether to accept the candidate by comparing their execution time. The
+// baseline is given acceptFactor/(1+acceptFactor) of maxTime and the candidate the rest, so the
+// candidate is still able to prove itself when the baseline times out. Every plan is executed
+// twice in the order of baseline, candidate, candidate, baseline, so each of them runs once before
+// and once after the other one warms up the cache, and their faster runs are compared.
+func verifyPlan(sctx sessionctx.Context, baselineSQL, candidateSQL string, maxTime time.Duration) (accepted bool, reason string, err error) {
+	vars := sctx.GetSessionVars()
+	origUsePlanBaselines := vars.UsePlanBaselines
+	vars.UsePlanBaselines = false
+	defer func() {
+		vars.UsePlanBaselines = origUsePlanBaselines
+	}()
+	if _, err = exec(sctx, "BEGIN OPTIMISTIC"); err != nil {
+		return false, "", err
+	}
+	defer func() {
+		_, err1 := exec(sctx, "ROLLBACK")
+		terror.Log(errors.Trace(err1))
+	}()
+
+	baselineLimit := time.Duration(float64(maxTime) / verifyRuns * acceptFactor / (1 + acceptFactor))
+	var baseline, candidate verifyResult
+	if err = baseline.run(sctx, baselineSQL, baselineLimit); err != nil {
+		return false, "", err
+	}
+	candidateLimit := time.Duration(float64(baseline.elapsed) / acceptFactor)
+	candidateErr := candidate.run(sctx, candidateSQL, candidateLimit)
+	if candidateErr == nil {
+		candidateErr = candidate.run(sctx, candidateSQL, candidateLimit)
+	}
+	if candidateErr == nil {
+		if err = baseline.run(sctx, baselineSQL, baselineLimit); err != nil {
+			return false, "", err
+		}
+	}
+	failpoint.Inject("mockEvolveExecTime", func(val failpoint.Value) {
+		times := strings.Split(val.(string), ",")
+		baseline.elapsed, _ = time.ParseDuration(times[0])
+		candidate.elapsed, _ = time.ParseDuration(times[1])
+		baseline.timeout, candidate.timeout, candidateErr = false, false, nil
+	})
+
+	baselineDesc := baseline.elapsed.String()
+	if baseline.timeout {
+		baselineDesc = "more than " + baselineDesc
+	}
+	switch {
+	case candidateErr != nil:
+		return false, fmt.Sprintf("rejected: failed to execute the plan: %v", candidateErr), nil
+	case candidate.timeout:
+		return false, fmt.Sprintf("rejected: the plan didn't finish in %v, the binding took %s", candidateLimit, baselineDesc), nil
+	case float64(candidate.elapsed)*acceptFactor > float64(baseline.elapsed):
+		return false, fmt.Sprintf("rejected: the plan took %v, the binding took %s", candidate.elapsed, baselineDesc), nil
+	}
+	return true, fmt.Sprintf("accepted: the plan took %v, the binding took %s", candidate.elapsed, baselineDesc), nil
+}
+
+// verifyResult is the fastest run of a plan in the verification. The timeout is true only if all
+// the runs time out.
+type verifyResult struct {
+	elapsed time.Duration
+	timeout bool
+	runs    int
+}
+
+// run executes the statement with the time limit and keeps the fastest run.
+func (r *verifyResult) run(sctx sessionctx.Context, sql string, limit time.Duration) error {
+	elapsed, timeout, err := runWithTimeLimit(sctx, sql, limit)
+	if err != nil {
+		return err
+	}
+	switch {
+	case r.runs == 0:
+		r.elapsed, r.timeout = elapsed, timeout
+	case r.timeout && !timeout:
+		r.elapsed, r.timeout = elapsed, false
+	case r.timeout == timeout && elapsed < r.elapsed:
+		r.elapsed = elapsed
+	}
+	r.runs++
+	return nil
+}
+
+// runWithTimeLimit executes the statement and drains its result. The statement is killed if it
+// doesn't finish in the limit, 0 means no limit.
+func runWithTimeLimit(sctx sessionctx.Context, sql string, limit time.Duration) (elapsed time.Duration, timeout bool, err error) {
+	killer := &sctx.GetSessionVars().SQLKiller
+	if limit > 0 {
+		killed := make(chan struct{})
+		timer := time.AfterFunc(limit, func() {
+			killer.SendKillSignal(sqlkiller.MaxExecTimeExceeded)
+			close(killed)
+		})
+		defer func() {
+			if !timer.Stop() {
+				<-killed
+				timeout = true
+				err = nil
+				killer.Reset()
+			}
+		}()
+	}
+	start := time.Now()
+	defer func() {
+		elapsed = time.Since(start)
+	}()
+	ctx := kv.WithInternalSourceType(context.Background(), kv.InternalTxnBindInfo)
+	rs, err := sctx.GetSQLExecutor().ExecuteInternal(ctx, sql)
+	if err != nil {
+		return 0, false, err
+	}
+	if rs == nil {
+		return 0, false, nil
+	}
+	defer terror.Call(rs.Close)
+	chk := rs.NewChunk(nil)
+	for {
+		if err = rs.Next(ctx, chk); err != nil || chk.NumRows() == 0 {
+			return 0, false, err
+		}
+	}
+}
+
+// addEvolvedBinding records the result of the baseline evolution in mysql.bind_info and its reason
+// in mysql.bind_evolve_result. The accepted binding replaces the existing ones except the other
+// rejected plans, and the rejected binding is added besides them.
+func (h *globalBindingHandle) addEvolvedBinding(binding Binding, reason string) (err error) {
+	defer func() {
+		if err == nil {
+			err = h.LoadFromStorageToCache(false)
+		}
+	}()
+
+	return h.callWithSCtx(true, func(sctx sessionctx.Context) error {
+		// Lock mysql.bind_info to synchronize with CreateBinding / AddBinding / DropBinding on other tidb instances.
+		if err = lockBindInfoTable(sctx); err != nil {
+			return err
+		}
+
+		now := types.NewTime(types.FromGoTime(time.Now()), mysql.TypeTimestamp, 3)
+		updateTs := now.String()
+		if binding.Status == Rejected {
+			_, err = exec(sctx, `UPDATE mysql.bind_info SET status = %?, update_time = %? WHERE original_sql = %? AND bind_sql = %? AND status = %? AND update_time < %?`,
+				deleted, updateTs, binding.OriginalSQL, binding.BindSQL, Rejected, updateTs)
+		} else {
+			_, err = exec(sctx, `UPDATE mysql.bind_info SET status = %?, update_time = %? WHERE original_sql = %? AND (status != %? OR bind_sql = %?) AND update_time < %?`,
+				deleted, updateTs, binding.OriginalSQL, Rejected, binding.BindSQL, updateTs)
+		}
+		if err != nil {
+			return err
+		}
+
+		binding.CreateTime = now
+		binding.UpdateTime = now
+		if err = insertBinding(sctx, binding); err != nil {
+			return err
+		}
+		_, err = exec(sctx, `DELETE FROM mysql.bind_evolve_result WHERE sql_digest = %? AND bind_sql = %?`,
+			binding.SQLDigest, binding.BindSQL)
+		if err != nil {
+			return err
+		}
+		_, err = exec(sctx, `INSERT INTO mysql.bind_evolve_result VALUES (%?, %?, %?, %?)`,
+			binding.SQLDigest, binding.BindSQL, reason, updateTs)
+		return err
+	})
+}
@@ -0,0 +1,117 @@
+// Copyright 2024 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package bindinfo_test
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/pingcap/failpoint"
+	"github.com/pingcap/tidb/pkg/testkit"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEvolveBindings(t *testing.T) {
+	store := testkit.CreateMockStore(t)
+	tk := testkit.NewTestKit(t, store)
+
+	tk.MustExec("use test")
+	tk.MustExec("create table t(a int, b int, index idx_a(a))")
+	tk.MustExec("insert into t values (1, 1), (2, 2), (3, 3)")
+	tk.MustExec("create global binding for select * from t where a = 1 using select * from t ignore index(idx_a) where a = 1")
+	rows := tk.MustQuery("show global bindings").Rows()
+	require.Len(t, rows, 1)
+	sqlDigest := rows[0][9].(string)
+
+	// The manual bindings are not evolved unless the sql digest is specified.
+	tk.MustExec("admin evolve bindings")
+	tk.MustQuery("select status, source from mysql.bind_info where source != 'builtin'").Check(testkit.Rows("enabled manual"))
+	tk.MustGetErrMsg("admin evolve bindings for sql digest 'abc'", "can't find any enabled global binding for sql digest 'abc'")
+
+	// The plan is rejected since it isn't 1.5 times faster than the binding.
+	fpName := "github.com/pingcap/tidb/pkg/bindinfo/mockEvolveExecTime"
+	require.NoError(t, failpoint.Enable(fpName, `return("100ms,80ms")`))
+	tk.MustExec(fmt.Sprintf("admin evolve bindings for sql digest '%s'", sqlDigest))
+	require.NoError(t, failpoint.Disable(fpName))
+	rows = tk.MustQuery("select b.bind_sql, b.status, b.source, r.reason from mysql.bind_info b join mysql.bind_evolve_result r on b.sql_digest = r.sql_digest and b.bind_sql = r.bind_sql where b.source = 'evolve'").Rows()
+	require.Len(t, rows, 1)
+	require.Contains(t, rows[0][0].(string), "idx_a")
+	require.Equal(t, "rejected", rows[0][1])
+	require.True(t, strings.HasPrefix(rows[0][3].(string), "rejected: the plan took 80ms"))
+	tk.MustQuery("select * from t where a = 1").Check(testkit.Rows("1 1"))
+	tk.MustQuery("select @@last_plan_from_binding").Check(testkit.Rows("1"))
+	require.False(t, tk.MustUseIndex("select * from t where a = 1", "idx_a"))
+
+	// The plan is accepted and replaces the binding.
+	require.NoError(t, failpoint.Enable(fpName, `return("100ms,20ms")`))
+	tk.MustExec(fmt.Sprintf("admin evolve bindings for sql digest '%s'", sqlDigest))
+	require.NoError(t, failpoint.Disable(fpName))
+	rows = tk.MustQuery("select b.status, b.source, r.reason from mysql.bind_info b join mysql.bind_evolve_result r on b.sql_digest = r.sql_digest and b.bind_sql = r.bind_sql where b.source != 'builtin' and b.status != 'deleted'").Rows()
+	require.Len(t, rows, 1)
+	require.Equal(t, "enabled", rows[0][0])
+	require.Equal(t, "evolve", rows[0][1])
+	require.True(t, strings.HasPrefix(rows[0][2].(string), "accepted: the plan took 20ms"))
+	tk.MustQuery("select * from t where a = 1").Check(testkit.Rows("1 1"))
+	tk.MustQuery("select @@last_plan_from_binding").Check(testkit.Rows("1"))
+	require.True(t, tk.MustUseIndex("select * from t where a = 1", "idx_a"))
+
+	// The evolved bindings are evolved again, and nothing changes since the plan is the same.
+	tk.MustExec("admin evolve bindings")
+	tk.MustQuery("select count(*) from mysql.bind_info where source != 'builtin' and status != 'deleted'").Check(testkit.Rows("1"))
+}
+
+func TestEvolveBindingsWithSideEffects(t *testing.T) {
+	store := testkit.CreateMockStore(t)
+	tk := testkit.NewTestKit(t, store)
+
+	tk.MustExec("use test")
+	tk.MustExec("create table t(a int, b int, index idx_a(a))")
+	tk.MustExec("insert into t values (1, 1), (2, 2), (3, 3)")
+	fpName := "github.com/pingcap/tidb/pkg/bindinfo/mockEvolveExecTime"
+	require.NoError(t, failpoint.Enable(fpName, `return("100ms,20ms")`))
+	defer func() {
+		require.NoError(t, failpoint.Disable(fpName))
+	}()
+
+	// The statements are not verified, so the plans are neither accepted nor rejected.
+	for _, sql := range []string{
+		"select * from %s where a = 1 for update",
+		"select * from %s where a = 1 for update nowait",
+		"select * from t where b in (select b from %s where a = 1 for update)",
+		"select * from %s where a = 1 into outfile '/tmp/evolve_side_effects.txt'",
+		"select a, @v := b from %s where a = 1",
+		"select a, sleep(0) from %s where a = 1",
+		"select a, get_lock('evolve', 0) from %s where a = 1",
+		"select a, rand() from %s where a = 1",
+	} {
+		tk.MustExec(fmt.Sprintf("create global binding for "+sql+" using "+sql, "t", "t ignore index(idx_a)"))
+		rows := tk.MustQuery("show global bindings").Rows()
+		require.Len(t, rows, 1, sql)
+		tk.MustExec(fmt.Sprintf("admin evolve bindings for sql digest '%s'", rows[0][9]))
+		tk.MustQuery("select count(*) from mysql.bind_info where source = 'evolve'").Check(testkit.Rows("0"))
+		tk.MustExec(fmt.Sprintf("drop global binding for sql digest '%s'", rows[0][9]))
+	}
+	// GET_LOCK with constant arguments is folded when the binding is created.
+	tk.MustExec("select release_all_locks()")
+
+	// The plan of the statement reading the variable is verified.
+	sql := "select a, @v from %s where a = 1"
+	tk.MustExec(fmt.Sprintf("create global binding for "+sql+" using "+sql, "t", "t ignore index(idx_a)"))
+	rows := tk.MustQuery("show global bindings").Rows()
+	require.Len(t, rows, 1)
+	tk.MustExec(fmt.Sprintf("admin evolve bindings for sql digest '%s'", rows[0][9]))
+	tk.MustQuery("select count(*) from mysql.bind_info where source = 'evolve'").Check(testkit.Rows("1"))
+}
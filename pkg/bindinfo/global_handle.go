@@ -99,11 +99,16 @@ type GlobalBindingHandle interface {
 	// FlushGlobalBindings flushes the Bindings in temp maps to storage and loads them into cache.
 	FlushGlobalBindings() error
 
-	// Methods for Auto Capture.
+	// Methods for Auto Capture and Evolution.
 
 	// CaptureBaselines is used to automatically capture plan baselines.
 	CaptureBaselines()
 
+	// EvolveGlobalBindings verifies the plans chosen by the optimizer without bindings, and accepts
+	// the ones which are faster than the bindings. Only the bindings of sqlDigest are evolved if
+	// it's not empty.
+	EvolveGlobalBindings(sctx sessionctx.Context, sqlDigest string, adminEvolve bool) error
+
 	variable.Statistics
 }
 
@@ -284,26 +289,28 @@ func (h *globalBindingHandle) CreateGlobalBinding(sctx sessionctx.Context, bindi
 		binding.UpdateTime = now
 
 		// Insert the Bindings to the storage.
-		_, err = exec(sctx, `INSERT INTO mysql.bind_info VALUES (%?,%?, %?, %?, %?, %?, %?, %?, %?, %?, %?)`,
-			binding.OriginalSQL,
-			binding.BindSQL,
-			strings.ToLower(binding.Db),
-			binding.Status,
-			binding.CreateTime.String(),
-			binding.UpdateTime.String(),
-			binding.Charset,
-			binding.Collation,
-			binding.Source,
-			binding.SQLDigest,
-			binding.PlanDigest,
-		)
-		if err != nil {
-			return err
-		}
-		return nil
+		return insertBinding(sctx, binding)
 	})
 }
 
+// insertBinding inserts the binding into mysql.bind_info.
+func insertBinding(sctx sessionctx.Context, binding Binding) error {
+	_, err := exec(sctx, `INSERT INTO mysql.bind_info VALUES (%?,%?, %?, %?, %?, %?, %?, %?, %?, %?, %?)`,
+		binding.OriginalSQL,
+		binding.BindSQL,
+		strings.ToLower(binding.Db),
+		binding.Status,
+		binding.CreateTime.String(),
+		binding.UpdateTime.String(),
+		binding.Charset,
+		binding.Collation,
+		binding.Source,
+		binding.SQLDigest,
+		binding.PlanDigest,
+	)
+	return err
+}
+
 // dropGlobalBinding drops a Bindings to the storage and Bindings int the cache.
 func (h *globalBindingHandle) dropGlobalBinding(sqlDigest string) (deletedRows uint64, err error) {
 	err = h.callWithSCtx(false, func(sctx sessionctx.Context) error {
@@ -390,6 +397,12 @@ func (h *globalBindingHandle) GCGlobalBinding() (err error) {
 		updateTime := time.Now().Add(-(10 * Lease))
 		updateTimeStr := types.NewTime(types.FromGoTime(updateTime), mysql.TypeTimestamp, 3).String()
 		_, err = exec(sctx, `DELETE FROM mysql.bind_info WHERE status = 'deleted' and update_time < %?`, updateTimeStr)
+		if err != nil {
+			return err
+		}
+		// The results of the baseline evolution are kept as long as their bindings.
+		_, err = exec(sctx, `DELETE FROM mysql.bind_evolve_result WHERE update_time < %? AND (sql_digest, bind_sql) NOT IN
+			(SELECT sql_digest, bind_sql FROM mysql.bind_info WHERE sql_digest IS NOT NULL)`, updateTimeStr)
 		return err
 	})
 }
@@ -1080,7 +1080,7 @@ func checkColumnDefaultValue(ctx exprctx.BuildContext, col *table.Column, value
 	hasDefaultValue := true
 	if value != nil && (col.GetType() == mysql.TypeJSON ||
 		col.GetType() == mysql.TypeTinyBlob || col.GetType() == mysql.TypeMediumBlob ||
-		col.GetType() == mysql.TypeLongBlob || col.GetType() == mysql.TypeBlob) {
+		col.GetType() == mysql.TypeLongBlob || col.GetType() == mysql.TypeBlob || col.GetType() == mysql.TypeGeometry) {
 		// In non-strict SQL mode.
 		if !ctx.GetEvalCtx().SQLMode().HasStrictMode() && value == "" {
 			if col.GetType() == mysql.TypeBlob || col.GetType() == mysql.TypeLongBlob {
@@ -1306,6 +1306,10 @@ func columnDefToCol(ctx sessionctx.Context, offset int, colDef *ast.ColumnDef, o
 				}
 			case ast.ColumnOptionFulltext:
 				ctx.GetSessionVars().StmtCtx.AppendWarning(dbterror.ErrTableCantHandleFt.FastGenByArgs())
+			case ast.ColumnOptionSrid:
+				if err = setColumnSRID(col, v); err != nil {
+					return nil, nil, errors.Trace(err)
+				}
 			case ast.ColumnOptionCheck:
 				if !variable.EnableCheckConstraint.Load() {
 					ctx.GetSessionVars().StmtCtx.AppendWarning(errCheckConstraintIsOff)
@@ -2178,6 +2182,16 @@ func BuildTableInfo(
 	}
 	foreignKeyID := tbInfo.MaxForeignKeyID
 	for _, constr := range constraints {
+		indexOption := constr.Option
+		switch constr.Tp {
+		case ast.ConstraintFulltext:
+			if err := checkFulltextIndex(tbInfo, constr.Keys, constr.Option); err != nil {
+				return nil, err
+			}
+			indexOption = indexOptionWithType(constr.Option, model.IndexTypeFulltext)
+		case ast.ConstraintSpatial:
+			indexOption = indexOptionWithType(constr.Option, model.IndexTypeSpatial)
+		}
 		// Build hidden columns if necessary.
 		hiddenCols, err := buildHiddenColumnInfoWithCheck(ctx, constr.Keys, model.NewCIStr(constr.Name), tbInfo, tblColumns)
 		if err != nil {
@@ -2240,11 +2254,6 @@ func BuildTableInfo(
 			}
 		}
 
-		if constr.Tp == ast.ConstraintFulltext {
-			ctx.GetSessionVars().StmtCtx.AppendWarning(dbterror.ErrTableCantHandleFt.FastGenByArgs())
-			continue
-		}
-
 		var (
 			indexName       = constr.Name
 			primary, unique bool
@@ -2332,7 +2341,7 @@ func BuildTableInfo(
 			unique,
 			false,
 			constr.Keys,
-			constr.Option,
+			indexOption,
 			model.StatePublic,
 		)
 		if err != nil {
@@ -3998,6 +4007,9 @@ func (d *ddl) AlterTable(ctx context.Context, sctx sessionctx.Context, stmt *ast
 			return dbterror.ErrOptOnCacheTable.GenWithStackByArgs("Alter Table")
 		}
 	}
+	if err := checkAlterTableForMView(is, tb.Meta(), validSpecs); err != nil {
+		return errors.Trace(err)
+	}
 	if isMultiSchemaChanges(validSpecs) && (sctx.GetSessionVars().EnableRowLevelChecksum || variable.EnableRowLevelChecksum.Load()) {
 		return dbterror.ErrRunMultiSchemaChanges.GenWithStack("Unsupported multi schema change when row level checksum is enabled")
 	}
@@ -4032,16 +4044,10 @@ func (d *ddl) AlterTable(ctx context.Context, sctx sessionctx.Context, stmt *ast
 			err = dbterror.ErrGeneralUnsupportedDDL.GenWithStackByArgs("MERGE FIRST PARTITION")
 		case ast.AlterTableReorganizeLastPartition:
 			err = dbterror.ErrGeneralUnsupportedDDL.GenWithStackByArgs("SPLIT LAST PARTITION")
-		case ast.AlterTableCheckPartitions:
-			err = errors.Trace(dbterror.ErrUnsupportedCheckPartition)
-		case ast.AlterTableRebuildPartition:
-			err = errors.Trace(dbterror.ErrUnsupportedRebuildPartition)
-		case ast.AlterTableOptimizePartition:
-			err = errors.Trace(dbterror.ErrUnsupportedOptimizePartition)
+		case ast.AlterTableRebuildPartition, ast.AlterTableRepairPartition:
+			err = d.RebuildPartitions(sctx, ident, spec)
 		case ast.AlterTableRemovePartitioning:
 			err = d.RemovePartitioning(sctx, ident, spec)
-		case ast.AlterTableRepairPartition:
-			err = errors.Trace(dbterror.ErrUnsupportedRepairPartition)
 		case ast.AlterTableDropColumn:
 			err = d.DropColumn(sctx, ident, spec)
 		case ast.AlterTableDropIndex:
@@ -4090,7 +4096,11 @@ func (d *ddl) AlterTable(ctx context.Context, sctx sessionctx.Context, stmt *ast
 			case ast.ConstraintPrimaryKey:
 				err = d.CreatePrimaryKey(sctx, ident, model.NewCIStr(constr.Name), spec.Constraint.Keys, constr.Option)
 			case ast.ConstraintFulltext:
-				sctx.GetSessionVars().StmtCtx.AppendWarning(dbterror.ErrTableCantHandleFt)
+				err = d.createIndex(sctx, ident, ast.IndexKeyTypeFullText, model.NewCIStr(constr.Name),
+					spec.Constraint.Keys, constr.Option, constr.IfNotExists)
+			case ast.ConstraintSpatial:
+				err = d.createIndex(sctx, ident, ast.IndexKeyTypeSpatial, model.NewCIStr(constr.Name),
+					spec.Constraint.Keys, constr.Option, constr.IfNotExists)
 			case ast.ConstraintCheck:
 				if !variable.EnableCheckConstraint.Load() {
 					sctx.GetSessionVars().StmtCtx.AppendWarning(errCheckConstraintIsOff)
@@ -4916,6 +4926,88 @@ func (d *ddl) ReorganizePartitions(ctx sessionctx.Context, ident ast.Ident, spec
 	return errors.Trace(err)
 }
 
+// RebuildPartitions rebuilds the partitions by reorganizing them into new partitions with the same definitions.
+// The rows are copied into the key ranges of the new partitions, and the old ranges are dropped after that.
+// REPAIR PARTITION is done in the same way, the indexes of the partitions are rebuilt from the rows.
+func (d *ddl) RebuildPartitions(ctx sessionctx.Context, ident ast.Ident, spec *ast.AlterTableSpec) error {
+	schema, t, err := d.getSchemaAndTableByIdent(ctx, ident)
+	if err != nil {
+		return errors.Trace(infoschema.ErrTableNotExists.FastGenByArgs(ident.Schema, ident.Name))
+	}
+
+	meta := t.Meta()
+	pi := meta.GetPartitionInfo()
+	if pi == nil {
+		return dbterror.ErrPartitionMgmtOnNonpartitioned
+	}
+	firstPartIdx, lastPartIdx := 0, len(pi.Definitions)-1
+	if !spec.OnAllPartitions {
+		firstPartIdx, lastPartIdx = len(pi.Definitions), -1
+		for _, name := range spec.PartitionNames {
+			partIdx := pi.FindPartitionDefinitionByName(name.L)
+			if partIdx == -1 {
+				return errors.Trace(table.ErrUnknownPartition.GenWithStackByArgs(name.O, ident.Name.O))
+			}
+			firstPartIdx = min(firstPartIdx, partIdx)
+			lastPartIdx = max(lastPartIdx, partIdx)
+		}
+	}
+	switch pi.Type {
+	case model.PartitionTypeRange, model.PartitionTypeList:
+		// The partitions between the given ones are rebuilt too, so the partitions are replaced in place.
+	case model.PartitionTypeHash, model.PartitionTypeKey:
+		// The rows of HASH and KEY partitions can only be reorganized together.
+		firstPartIdx, lastPartIdx = 0, len(pi.Definitions)-1
+	default:
+		return errors.Trace(dbterror.ErrUnsupportedRebuildPartition)
+	}
+
+	partNames := make([]string, 0, lastPartIdx-firstPartIdx+1)
+	idMap := make(map[int]struct{}, lastPartIdx-firstPartIdx+1)
+	partInfo := &model.PartitionInfo{
+		Type:    pi.Type,
+		Expr:    pi.Expr,
+		Columns: pi.Columns,
+		Enable:  pi.Enable,
+	}
+	for i := firstPartIdx; i <= lastPartIdx; i++ {
+		partNames = append(partNames, pi.Definitions[i].Name.L)
+		idMap[i] = struct{}{}
+		partInfo.Definitions = append(partInfo.Definitions, pi.Definitions[i].Clone())
+	}
+	partInfo.Num = uint64(len(partInfo.Definitions))
+	if err = d.assignPartitionIDs(partInfo.Definitions); err != nil {
+		return errors.Trace(err)
+	}
+	if err = checkReorgPartitionDefs(ctx, model.ActionReorganizePartition, meta, partInfo, firstPartIdx, lastPartIdx, idMap); err != nil {
+		return errors.Trace(err)
+	}
+	if err = handlePartitionPlacement(ctx, partInfo); err != nil {
+		return errors.Trace(err)
+	}
+
+	job := &model.Job{
+		SchemaID:       schema.ID,
+		TableID:        meta.ID,
+		SchemaName:     schema.Name.L,
+		TableName:      meta.Name.L,
+		Type:           model.ActionReorganizePartition,
+		BinlogInfo:     &model.HistoryInfo{},
+		Args:           []any{partNames, partInfo},
+		ReorgMeta:      NewDDLReorgMeta(ctx),
+		CDCWriteSource: ctx.GetSessionVars().CDCWriteSource,
+		SQLMode:        ctx.GetSessionVars().SQLMode,
+	}
+
+	// No preSplitAndScatter here, it will be done by the worker in onReorganizePartition instead.
+	err = d.DoDDLJob(ctx, job)
+	err = d.callHookOnChanged(job, err)
+	if err == nil {
+		ctx.GetSessionVars().StmtCtx.AppendWarning(errors.NewNoStackError("The statistics of related partitions will be outdated after rebuilding partitions. Please use 'ANALYZE TABLE' statement if you want to update it now"))
+	}
+	return errors.Trace(err)
+}
+
 // RemovePartitioning removes partitioning from a table.
 func (d *ddl) RemovePartitioning(ctx sessionctx.Context, ident ast.Ident, spec *ast.AlterTableSpec) error {
 	schema, t, err := d.getSchemaAndTableByIdent(ctx, ident)
@@ -5456,6 +5548,15 @@ func (d *ddl) ExchangeTablePartition(ctx sessionctx.Context, ident ast.Ident, sp
 	}
 
 	ntMeta := nt.Meta()
+	// The rows of the exchanged table are changed without being recorded in the
+	// log tables of its materialized views.
+	is := d.infoCache.GetLatest()
+	if err = checkMViewTableDDL(is, ntMeta, "ALTER TABLE EXCHANGE PARTITION"); err != nil {
+		return errors.Trace(err)
+	}
+	if err = checkMViewBaseTableDDL(is, ntMeta, "ALTER TABLE EXCHANGE PARTITION"); err != nil {
+		return errors.Trace(err)
+	}
 
 	err = checkExchangePartition(ptMeta, ntMeta)
 	if err != nil {
@@ -5743,6 +5844,19 @@ func setColumnComment(ctx sessionctx.Context, col *table.Column, option *ast.Col
 	return errors.Trace(err)
 }
 
+// setColumnSRID sets the SRID of the geometry column. Only the supported spatial reference systems
+// can be used.
+func setColumnSRID(col *table.Column, option *ast.ColumnOption) error {
+	if col.GetType() != mysql.TypeGeometry {
+		return dbterror.ErrWrongUsage.GenWithStackByArgs("SRID", "non-geometry column")
+	}
+	if err := types.CheckSRID(option.Srid); err != nil {
+		return err
+	}
+	col.SetSRID(option.Srid)
+	return nil
+}
+
 // ProcessModifyColumnOptions process column options.
 func ProcessModifyColumnOptions(ctx sessionctx.Context, col *table.Column, options []*ast.ColumnOption) error {
 	var sb strings.Builder
@@ -5802,6 +5916,10 @@ func ProcessModifyColumnOptions(ctx sessionctx.Context, col *table.Column, optio
 			}
 		case ast.ColumnOptionCollate:
 			col.SetCollate(opt.StrValue)
+		case ast.ColumnOptionSrid:
+			if err = setColumnSRID(col, opt); err != nil {
+				return errors.Trace(err)
+			}
 		case ast.ColumnOptionReference:
 			return errors.Trace(dbterror.ErrUnsupportedModifyColumn.GenWithStackByArgs("can't modify with references"))
 		case ast.ColumnOptionFulltext:
@@ -6207,6 +6325,12 @@ func checkColumnWithIndexConstraint(tbInfo *model.TableInfo, originalCol, newCol
 		if !modified {
 			return
 		}
+		if indexInfo.IsFulltextIndex() {
+			return checkIndexInModifiableColumns4Fulltext(columns, indexInfo.Columns)
+		}
+		if indexInfo.IsSpatialIndex() {
+			return checkIndexInModifiableColumns4Spatial(columns, indexInfo.Columns)
+		}
 		err = checkIndexInModifiableColumns(columns, indexInfo.Columns)
 		if err != nil {
 			return
@@ -6260,6 +6384,32 @@ func checkIndexInModifiableColumns(columns []*model.ColumnInfo, idxColumns []*mo
 	return nil
 }
 
+func checkIndexInModifiableColumns4Fulltext(columns []*model.ColumnInfo, idxColumns []*model.IndexColumn) error {
+	for _, ic := range idxColumns {
+		col := model.FindColumnInfo(columns, ic.Name.L)
+		if col == nil {
+			return dbterror.ErrKeyColumnDoesNotExits.GenWithStack("column does not exist: %s", ic.Name)
+		}
+		if err := checkFulltextIndexColumn(col); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func checkIndexInModifiableColumns4Spatial(columns []*model.ColumnInfo, idxColumns []*model.IndexColumn) error {
+	for _, ic := range idxColumns {
+		col := model.FindColumnInfo(columns, ic.Name.L)
+		if col == nil {
+			return dbterror.ErrKeyColumnDoesNotExits.GenWithStack("column does not exist: %s", ic.Name)
+		}
+		if err := checkSpatialIndexColumn(col); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 func isClusteredPKColumn(col *table.Column, tblInfo *model.TableInfo) bool {
 	switch {
 	case tblInfo.PKIsHandle:
@@ -6854,10 +7004,6 @@ func (d *ddl) AlterTableAddStatistics(ctx sessionctx.Context, ident ast.Ident, s
 	if !ctx.GetSessionVars().EnableExtendedStats {
 		return errors.New("Extended statistics feature is not generally available now, and tidb_enable_extended_stats is OFF")
 	}
-	// Not support Cardinality and Dependency statistics type for now.
-	if stats.StatsType == ast.StatsTypeCardinality || stats.StatsType == ast.StatsTypeDependency {
-		return errors.New("Cardinality and Dependency statistics types are not supported now")
-	}
 	_, tbl, err := d.getSchemaAndTableByIdent(ctx, ident)
 	if err != nil {
 		return err
@@ -6887,10 +7033,9 @@ func (d *ddl) AlterTableAddStatistics(ctx sessionctx.Context, ident ast.Ident, s
 	if len(colIDs) != 2 && (stats.StatsType == ast.StatsTypeCorrelation || stats.StatsType == ast.StatsTypeDependency) {
 		return errors.New("Only support Correlation and Dependency statistics types on 2 columns")
 	}
-	if len(colIDs) < 1 && stats.StatsType == ast.StatsTypeCardinality {
+	if len(colIDs) < 2 && stats.StatsType == ast.StatsTypeCardinality {
 		return errors.New("Only support Cardinality statistics type on at least 2 columns")
 	}
-	// TODO: check whether covering index exists for cardinality / dependency types.
 
 	// Call utilities of statistics.Handle to modify system tables instead of doing DML directly,
 	// because locking in Handle can guarantee the correctness of `version` in system tables.
@@ -7151,6 +7296,9 @@ func (d *ddl) dropTableObject(
 				notExistTables = append(notExistTables, fullti.String())
 				continue
 			}
+			if err := checkMViewTableDDL(is, tableInfo.Meta(), "DROP TABLE"); err != nil {
+				return err
+			}
 
 			tempTableType := tableInfo.Meta().TempTableType
 			if config.CheckTableBeforeDrop && tempTableType == model.TempTableNone {
@@ -7239,6 +7387,243 @@ func (d *ddl) DropView(ctx sessionctx.Context, stmt *ast.DropTableStmt) (err err
 	return d.dropTableObject(ctx, stmt.Tables, stmt.IfExists, viewObject)
 }
 
+// CreateTrigger creates a trigger on the table.
+func (d *ddl) CreateTrigger(ctx sessionctx.Context, s *ast.CreateTriggerStmt) error {
+	if s.TriggerName.Schema.L != s.Table.Schema.L {
+		return errors.Trace(dbterror.ErrTrgInWrongSchema)
+	}
+	schema, tb, err := d.getSchemaAndTableByIdent(ctx, ast.Ident{Schema: s.Table.Schema, Name: s.Table.Name})
+	if err != nil {
+		return errors.Trace(err)
+	}
+	tblInfo := tb.Meta()
+	if util.IsMemOrSysDB(schema.Name.L) {
+		return errors.Trace(dbterror.ErrNoTriggersOnSystemSchema)
+	}
+	if tblInfo.IsView() || tblInfo.IsSequence() || tblInfo.TempTableType != model.TempTableNone {
+		return errors.Trace(dbterror.ErrTrgOnViewOrTempTable.GenWithStackByArgs(tblInfo.Name.O))
+	}
+	if _, _, ok := infoschema.FindTrigger(d.GetInfoSchemaWithInterceptor(ctx), schema.Name, s.TriggerName.Name); ok {
+		err = dbterror.ErrTrgAlreadyExists.GenWithStackByArgs()
+		if s.IfNotExists {
+			ctx.GetSessionVars().StmtCtx.AppendNote(err)
+			return nil
+		}
+		return err
+	}
+	var (
+		otherTrigger model.CIStr
+		follows      bool
+	)
+	if s.Order != nil {
+		otherTrigger, follows = s.Order.OtherTrigger, s.Order.Follows
+		if findTriggerOffset(tblInfo.Triggers, otherTrigger, s.Timing, s.Event) < 0 {
+			return errors.Trace(dbterror.ErrReferencedTrgDoesNotExist.GenWithStackByArgs(otherTrigger.O))
+		}
+	}
+
+	sessVars := ctx.GetSessionVars()
+	charsetClient, _ := sessVars.GetSystemVar(variable.CharacterSetClient)
+	collationConnection, _ := sessVars.GetSystemVar(variable.CollationConnection)
+	trigger := &model.TriggerInfo{
+		Name:                s.TriggerName.Name,
+		Timing:              s.Timing,
+		Event:               s.Event,
+		Body:                s.Body.Text(),
+		Definer:             s.Definer,
+		SQLMode:             sessVars.SQLMode,
+		CharsetClient:       charsetClient,
+		CollationConnection: collationConnection,
+		DBCollation:         schema.Collate,
+		Created:             time.Now(),
+	}
+	job := &model.Job{
+		SchemaID:       schema.ID,
+		TableID:        tblInfo.ID,
+		SchemaName:     schema.Name.L,
+		TableName:      tblInfo.Name.L,
+		Type:           model.ActionCreateTrigger,
+		BinlogInfo:     &model.HistoryInfo{},
+		Args:           []any{trigger, otherTrigger, follows},
+		CDCWriteSource: sessVars.CDCWriteSource,
+		SQLMode:        sessVars.SQLMode,
+	}
+	err = d.DoDDLJob(ctx, job)
+	err = d.callHookOnChanged(job, err)
+	return errors.Trace(err)
+}
+
+// DropTrigger drops a trigger.
+func (d *ddl) DropTrigger(ctx sessionctx.Context, s *ast.DropTriggerStmt) error {
+	is := d.GetInfoSchemaWithInterceptor(ctx)
+	schema, ok := is.SchemaByName(s.Trigger.Schema)
+	if !ok {
+		return infoschema.ErrDatabaseNotExists.GenWithStackByArgs(s.Trigger.Schema)
+	}
+	tblInfo, _, ok := infoschema.FindTrigger(is, schema.Name, s.Trigger.Name)
+	if !ok {
+		err := dbterror.ErrTrgDoesNotExist.GenWithStackByArgs()
+		if s.IfExists {
+			ctx.GetSessionVars().StmtCtx.AppendNote(err)
+			return nil
+		}
+		return err
+	}
+	job := &model.Job{
+		SchemaID:       schema.ID,
+		TableID:        tblInfo.ID,
+		SchemaName:     schema.Name.L,
+		TableName:      tblInfo.Name.L,
+		Type:           model.ActionDropTrigger,
+		BinlogInfo:     &model.HistoryInfo{},
+		Args:           []any{s.Trigger.Name},
+		CDCWriteSource: ctx.GetSessionVars().CDCWriteSource,
+		SQLMode:        ctx.GetSessionVars().SQLMode,
+	}
+	err := d.DoDDLJob(ctx, job)
+	err = d.callHookOnChanged(job, err)
+	return errors.Trace(err)
+}
+
+// CreateMaterializedView creates a materialized view. The result of the query is
+// stored in a table, and the changes of the base table are recorded in a log
+// table if the view is refreshed incrementally.
+func (d *ddl) CreateMaterializedView(ctx sessionctx.Context, s *ast.CreateMaterializedViewStmt) error {
+	is := d.GetInfoSchemaWithInterceptor(ctx)
+	schema, ok := is.SchemaByName(s.ViewName.Schema)
+	if !ok {
+		return infoschema.ErrDatabaseNotExists.GenWithStackByArgs(s.ViewName.Schema)
+	}
+	if is.TableExists(schema.Name, s.ViewName.Name) {
+		err := infoschema.ErrTableExists.GenWithStackByArgs(ast.Ident{Schema: schema.Name, Name: s.ViewName.Name})
+		if s.IfNotExists {
+			ctx.GetSessionVars().StmtCtx.AppendNote(err)
+			return nil
+		}
+		return err
+	}
+	if len(s.Cols) != len(s.ColTypes) {
+		return dbterror.ErrViewWrongList
+	}
+	baseTables, err := collectMViewBaseTables(is, s.Select)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if s.RefreshMethod == model.MViewRefreshIncremental && len(baseTables) != 1 {
+		return dbterror.ErrGeneralUnsupportedDDL.GenWithStackByArgs("incremental refresh of materialized view without exactly one base table")
+	}
+
+	// Always Use `format.RestoreNameBackQuotes` to restore `SELECT` statement despite the `ANSI_QUOTES` SQL Mode is enabled or not.
+	restoreFlag := format.RestoreStringSingleQuotes | format.RestoreKeyWordUppercase | format.RestoreNameBackQuotes
+	var sb strings.Builder
+	if err := s.Select.Restore(format.NewRestoreCtx(restoreFlag, &sb)); err != nil {
+		return errors.Trace(err)
+	}
+	sessVars := ctx.GetSessionVars()
+	charsetClient, _ := sessVars.GetSystemVar(variable.CharacterSetClient)
+	collationConnection, _ := sessVars.GetSystemVar(variable.CollationConnection)
+	mvInfo := &model.MaterializedViewInfo{
+		SelectStmt:          sb.String(),
+		RefreshMethod:       s.RefreshMethod,
+		SQLMode:             sessVars.SQLMode,
+		CharsetClient:       charsetClient,
+		CollationConnection: collationConnection,
+	}
+	baseSchemaIDs := make([]int64, 0, len(baseTables))
+	involvingSchemas := []model.InvolvingSchemaInfo{{Database: schema.Name.L, Table: s.ViewName.Name.L}}
+	for _, tbl := range baseTables {
+		mvInfo.BaseTableIDs = append(mvInfo.BaseTableIDs, tbl.ID)
+		baseSchemaIDs = append(baseSchemaIDs, tbl.DBID)
+		if dbInfo, ok := is.SchemaByID(tbl.DBID); ok {
+			involvingSchemas = append(involvingSchemas, model.InvolvingSchemaInfo{Database: dbInfo.Name.L, Table: tbl.Name.L})
+		}
+	}
+
+	tbInfo, err := buildMViewTableInfo(ctx, schema, s)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	genIDs, err := d.genGlobalIDs(2)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	tbInfo.ID = genIDs[0]
+	tbInfo.MaterializedView = mvInfo
+	var logInfo *model.TableInfo
+	if s.RefreshMethod == model.MViewRefreshIncremental {
+		logInfo, err = buildMViewLogTableInfo(ctx, schema, tbInfo.ID, baseTables[0], s.Select)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		logInfo.ID = genIDs[1]
+		mvInfo.LogTableID = logInfo.ID
+		involvingSchemas = append(involvingSchemas, model.InvolvingSchemaInfo{Database: schema.Name.L, Table: logInfo.Name.L})
+	}
+
+	job := &model.Job{
+		SchemaID:            schema.ID,
+		TableID:             tbInfo.ID,
+		SchemaName:          schema.Name.L,
+		TableName:           tbInfo.Name.L,
+		Type:                model.ActionCreateMaterializedView,
+		BinlogInfo:          &model.HistoryInfo{},
+		Args:                []any{tbInfo, logInfo, baseSchemaIDs},
+		CDCWriteSource:      sessVars.CDCWriteSource,
+		InvolvingSchemaInfo: involvingSchemas,
+		SQLMode:             sessVars.SQLMode,
+	}
+	err = d.DoDDLJob(ctx, job)
+	err = d.callHookOnChanged(job, err)
+	return errors.Trace(err)
+}
+
+// DropMaterializedView drops a materialized view and its log table.
+func (d *ddl) DropMaterializedView(ctx sessionctx.Context, s *ast.DropMaterializedViewStmt) error {
+	is := d.GetInfoSchemaWithInterceptor(ctx)
+	schema, ok := is.SchemaByName(s.ViewName.Schema)
+	if !ok {
+		return infoschema.ErrDatabaseNotExists.GenWithStackByArgs(s.ViewName.Schema)
+	}
+	tblInfo, err := is.TableInfoByName(schema.Name, s.ViewName.Name)
+	if err != nil {
+		if infoschema.ErrTableNotExists.Equal(err) {
+			err = infoschema.ErrTableDropExists.GenWithStackByArgs(ast.Ident{Schema: schema.Name, Name: s.ViewName.Name}.String())
+			if s.IfExists {
+				ctx.GetSessionVars().StmtCtx.AppendNote(err)
+				return nil
+			}
+		}
+		return err
+	}
+	if !tblInfo.IsMaterializedView() {
+		return dbterror.ErrWrongObject.GenWithStackByArgs(schema.Name, tblInfo.Name, "MATERIALIZED VIEW")
+	}
+	// The base tables which have been dropped are skipped.
+	baseSchemaIDs := make([]int64, 0, len(tblInfo.MaterializedView.BaseTableIDs))
+	for _, id := range tblInfo.MaterializedView.BaseTableIDs {
+		var schemaID int64
+		if baseTbl, ok := is.TableInfoByID(id); ok {
+			schemaID = baseTbl.DBID
+		}
+		baseSchemaIDs = append(baseSchemaIDs, schemaID)
+	}
+	job := &model.Job{
+		SchemaID:       schema.ID,
+		TableID:        tblInfo.ID,
+		SchemaName:     schema.Name.L,
+		SchemaState:    schema.State,
+		TableName:      tblInfo.Name.L,
+		Type:           model.ActionDropMaterializedView,
+		BinlogInfo:     &model.HistoryInfo{},
+		Args:           []any{baseSchemaIDs},
+		CDCWriteSource: ctx.GetSessionVars().CDCWriteSource,
+		SQLMode:        ctx.GetSessionVars().SQLMode,
+	}
+	err = d.DoDDLJob(ctx, job)
+	err = d.callHookOnChanged(job, err)
+	return errors.Trace(err)
+}
+
 func (d *ddl) TruncateTable(ctx sessionctx.Context, ti ast.Ident) error {
 	schema, tb, err := d.getSchemaAndTableByIdent(ctx, ti)
 	if err != nil {
@@ -7250,6 +7635,9 @@ func (d *ddl) TruncateTable(ctx sessionctx.Context, ti ast.Ident) error {
 	if tb.Meta().TableCacheStatusType != model.TableCacheStatusDisable {
 		return dbterror.ErrOptOnCacheTable.GenWithStackByArgs("Truncate Table")
 	}
+	if err := checkTruncateMViewTable(d.GetInfoSchemaWithInterceptor(ctx), tb.Meta()); err != nil {
+		return errors.Trace(err)
+	}
 	fkCheck := ctx.GetSessionVars().ForeignKeyChecks
 	referredFK := checkTableHasForeignKeyReferred(d.GetInfoSchemaWithInterceptor(ctx), ti.Schema.L, ti.Name.L, []ast.Ident{{Name: ti.Name, Schema: ti.Schema}}, fkCheck)
 	if referredFK != nil {
@@ -7764,15 +8152,22 @@ func (*ddl) addHypoIndexIntoCtx(ctx sessionctx.Context, schemaName, tableName mo
 
 func (d *ddl) createIndex(ctx sessionctx.Context, ti ast.Ident, keyType ast.IndexKeyType, indexName model.CIStr,
 	indexPartSpecifications []*ast.IndexPartSpecification, indexOption *ast.IndexOption, ifNotExists bool) error {
-	// not support Spatial and FullText index
-	if keyType == ast.IndexKeyTypeFullText || keyType == ast.IndexKeyTypeSpatial {
-		return dbterror.ErrUnsupportedIndexType.GenWithStack("FULLTEXT and SPATIAL index is not supported")
-	}
 	unique := keyType == ast.IndexKeyTypeUnique
 	schema, t, err := d.getSchemaAndTableByIdent(ctx, ti)
 	if err != nil {
 		return errors.Trace(err)
 	}
+	fulltextIndex := keyType == ast.IndexKeyTypeFullText
+	if fulltextIndex {
+		if err = checkFulltextIndex(t.Meta(), indexPartSpecifications, indexOption); err != nil {
+			return err
+		}
+		indexOption = indexOptionWithType(indexOption, model.IndexTypeFulltext)
+	}
+	spatialIndex := keyType == ast.IndexKeyTypeSpatial
+	if spatialIndex {
+		indexOption = indexOptionWithType(indexOption, model.IndexTypeSpatial)
+	}
 
 	if t.Meta().TableCacheStatusType != model.TableCacheStatusDisable {
 		return errors.Trace(dbterror.ErrOptOnCacheTable.GenWithStackByArgs("Create Index"))
@@ -7826,7 +8221,15 @@ func (d *ddl) createIndex(ctx sessionctx.Context, ti ast.Ident, keyType ast.Inde
 	// After DDL job is put to the queue, and if the check fail, TiDB will run the DDL cancel logic.
 	// The recover step causes DDL wait a few seconds, makes the unit test painfully slow.
 	// For same reason, decide whether index is global here.
-	indexColumns, _, err := buildIndexColumns(ctx, finalColumns, indexPartSpecifications)
+	var indexColumns []*model.IndexColumn
+	switch {
+	case fulltextIndex:
+		indexColumns, err = buildFulltextIndexColumns(finalColumns, indexPartSpecifications)
+	case spatialIndex:
+		indexColumns, err = buildSpatialIndexColumns(finalColumns, indexPartSpecifications)
+	default:
+		indexColumns, _, err = buildIndexColumns(ctx, finalColumns, indexPartSpecifications)
+	}
 	if err != nil {
 		return errors.Trace(err)
 	}
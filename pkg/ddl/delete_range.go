@@ -295,6 +295,15 @@ func insertJobIntoDeleteRangeTable(ctx context.Context, wrapper DelRangeExecWrap
 				return errors.Trace(err)
 			}
 		}
+	case model.ActionDropMaterializedView:
+		// The table IDs are the materialized view and its log table.
+		var tableIDs []int64
+		if err := job.DecodeArgs(&tableIDs); err != nil {
+			return errors.Trace(err)
+		}
+		if err := doBatchDeleteTablesRange(ctx, wrapper, job.ID, tableIDs, ea, "drop materialized view: table IDs"); err != nil {
+			return errors.Trace(err)
+		}
 	case model.ActionDropTable, model.ActionTruncateTable:
 		tableID := job.TableID
 		// The startKey here is for compatibility with previous versions, old version did not endKey so don't have to deal with.
@@ -64,6 +64,7 @@ import (
 	"github.com/pingcap/tidb/pkg/util/chunk"
 	"github.com/pingcap/tidb/pkg/util/codec"
 	"github.com/pingcap/tidb/pkg/util/dbterror"
+	"github.com/pingcap/tidb/pkg/util/fulltext"
 	tidblogutil "github.com/pingcap/tidb/pkg/util/logutil"
 	decoder "github.com/pingcap/tidb/pkg/util/rowDecoder"
 	"github.com/pingcap/tidb/pkg/util/size"
@@ -157,6 +158,101 @@ func buildIndexColumns(ctx sessionctx.Context, columns []*model.ColumnInfo, inde
 	return idxParts, mvIndex, nil
 }
 
+// checkFulltextIndex checks whether the FULLTEXT index can be created on the table.
+func checkFulltextIndex(tblInfo *model.TableInfo, indexPartSpecifications []*ast.IndexPartSpecification, indexOption *ast.IndexOption) error {
+	if tblInfo.GetPartitionInfo() != nil {
+		return dbterror.ErrFulltextNotSupportedWithPartitioning
+	}
+	for _, ip := range indexPartSpecifications {
+		if ip.Expr != nil {
+			return dbterror.ErrFulltextFunctionalIndex
+		}
+	}
+	if indexOption != nil && !fulltext.IsSupportedParser(indexOption.ParserName.L) {
+		return dbterror.ErrFulltextParserNotFound.GenWithStackByArgs(indexOption.ParserName.O)
+	}
+	return nil
+}
+
+// indexOptionWithType returns a copy of the index option with the index type, e.g. FULLTEXT.
+func indexOptionWithType(indexOption *ast.IndexOption, tp model.IndexType) *ast.IndexOption {
+	opt := &ast.IndexOption{}
+	if indexOption != nil {
+		*opt = *indexOption
+	}
+	opt.Tp = tp
+	return opt
+}
+
+// buildFulltextIndexColumns builds the columns of the FULLTEXT index. Only the CHAR, VARCHAR
+// and TEXT columns can be indexed, and the whole text is tokenized so the prefix length is ignored.
+func buildFulltextIndexColumns(columns []*model.ColumnInfo, indexPartSpecifications []*ast.IndexPartSpecification) ([]*model.IndexColumn, error) {
+	idxParts := make([]*model.IndexColumn, 0, len(indexPartSpecifications))
+	for _, ip := range indexPartSpecifications {
+		if ip.Expr != nil {
+			return nil, dbterror.ErrFulltextFunctionalIndex
+		}
+		col := model.FindColumnInfo(columns, ip.Column.Name.L)
+		if col == nil {
+			return nil, dbterror.ErrKeyColumnDoesNotExits.GenWithStack("column does not exist: %s", ip.Column.Name)
+		}
+		if err := checkFulltextIndexColumn(col); err != nil {
+			return nil, err
+		}
+		idxParts = append(idxParts, &model.IndexColumn{
+			Name:   col.Name,
+			Offset: col.Offset,
+			Length: types.UnspecifiedLength,
+		})
+	}
+	return idxParts, nil
+}
+
+func checkFulltextIndexColumn(col *model.ColumnInfo) error {
+	tp := col.FieldType.GetType()
+	if !(types.IsTypeChar(tp) || types.IsTypeVarchar(tp) || types.IsTypeBlob(tp)) || col.GetCharset() == charset.CharsetBin {
+		return dbterror.ErrBadFtColumn.GenWithStackByArgs(col.Name.O)
+	}
+	return nil
+}
+
+// buildSpatialIndexColumns builds the column of the SPATIAL index, which must be a single NOT NULL
+// geometry column. The whole geometry is indexed so the prefix length isn't allowed.
+func buildSpatialIndexColumns(columns []*model.ColumnInfo, indexPartSpecifications []*ast.IndexPartSpecification) ([]*model.IndexColumn, error) {
+	if len(indexPartSpecifications) != 1 {
+		return nil, dbterror.ErrTooManyKeyParts.GenWithStackByArgs(1)
+	}
+	ip := indexPartSpecifications[0]
+	if ip.Expr != nil {
+		return nil, dbterror.ErrSpatialMustHaveGeomCol
+	}
+	col := model.FindColumnInfo(columns, ip.Column.Name.L)
+	if col == nil {
+		return nil, dbterror.ErrKeyColumnDoesNotExits.GenWithStack("column does not exist: %s", ip.Column.Name)
+	}
+	if ip.Length != types.UnspecifiedLength {
+		return nil, dbterror.ErrIncorrectPrefixKey
+	}
+	if err := checkSpatialIndexColumn(col); err != nil {
+		return nil, err
+	}
+	return []*model.IndexColumn{{
+		Name:   col.Name,
+		Offset: col.Offset,
+		Length: types.UnspecifiedLength,
+	}}, nil
+}
+
+func checkSpatialIndexColumn(col *model.ColumnInfo) error {
+	if col.FieldType.GetType() != mysql.TypeGeometry {
+		return dbterror.ErrSpatialMustHaveGeomCol
+	}
+	if !mysql.HasNotNullFlag(col.GetFlag()) {
+		return dbterror.ErrSpatialCantHaveNull
+	}
+	return nil
+}
+
 // CheckPKOnGeneratedColumn checks the specification of PK is valid.
 func CheckPKOnGeneratedColumn(tblInfo *model.TableInfo, indexPartSpecifications []*ast.IndexPartSpecification) (*model.ColumnInfo, error) {
 	var lastCol *model.ColumnInfo
@@ -204,6 +300,11 @@ func checkIndexColumn(ctx sessionctx.Context, col *model.ColumnInfo, indexColumn
 		return errors.Trace(dbterror.ErrJSONUsedAsKey.GenWithStackByArgs(col.Name.O))
 	}
 
+	// Vector column cannot index.
+	if types.IsTypeVector(col.FieldType.GetType()) {
+		return dbterror.ErrNotSupportedYet.GenWithStackByArgs("using a VECTOR column as index key")
+	}
+
 	// Length must be specified and non-zero for BLOB and TEXT column indexes.
 	if types.IsTypeBlob(col.FieldType.GetType()) {
 		if indexColumnLen == types.UnspecifiedLength {
@@ -308,7 +409,19 @@ func BuildIndexInfo(
 		return nil, errors.Trace(err)
 	}
 
-	idxColumns, mvIndex, err := buildIndexColumns(ctx, allTableColumns, indexPartSpecifications)
+	var (
+		idxColumns []*model.IndexColumn
+		mvIndex    bool
+		err        error
+	)
+	switch {
+	case indexOption != nil && indexOption.Tp == model.IndexTypeFulltext:
+		idxColumns, err = buildFulltextIndexColumns(allTableColumns, indexPartSpecifications)
+	case indexOption != nil && indexOption.Tp == model.IndexTypeSpatial:
+		idxColumns, err = buildSpatialIndexColumns(allTableColumns, indexPartSpecifications)
+	default:
+		idxColumns, mvIndex, err = buildIndexColumns(ctx, allTableColumns, indexPartSpecifications)
+	}
 	if err != nil {
 		return nil, errors.Trace(err)
 	}
@@ -335,6 +448,9 @@ func BuildIndexInfo(
 		} else {
 			idxInfo.Tp = indexOption.Tp
 		}
+		if idxInfo.Tp == model.IndexTypeFulltext {
+			idxInfo.ParserName = indexOption.ParserName
+		}
 	} else {
 		// Use btree as default index type.
 		idxInfo.Tp = model.IndexTypeBtree
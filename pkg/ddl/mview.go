@@ -0,0 +1,443 @@
+// Copyright 2024 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ddl
+
+import (
+	"fmt"
+	"slices"
+
+	"github.com/pingcap/errors"
+	"github.com/pingcap/tidb/pkg/infoschema"
+	"github.com/pingcap/tidb/pkg/meta"
+	"github.com/pingcap/tidb/pkg/parser/ast"
+	"github.com/pingcap/tidb/pkg/parser/model"
+	"github.com/pingcap/tidb/pkg/parser/mysql"
+	"github.com/pingcap/tidb/pkg/sessionctx"
+	statsutil "github.com/pingcap/tidb/pkg/statistics/handle/util"
+	"github.com/pingcap/tidb/pkg/types"
+	"github.com/pingcap/tidb/pkg/util"
+	"github.com/pingcap/tidb/pkg/util/dbterror"
+)
+
+// mviewNodeCollector collects the table names and column names used in the
+// query of a materialized view.
+type mviewNodeCollector struct {
+	tables  []*ast.TableName
+	columns map[string]struct{}
+}
+
+// Enter implements ast.Visitor interface.
+func (c *mviewNodeCollector) Enter(n ast.Node) (ast.Node, bool) {
+	switch x := n.(type) {
+	case *ast.TableName:
+		c.tables = append(c.tables, x)
+	case *ast.ColumnName:
+		c.columns[x.Name.L] = struct{}{}
+	}
+	return n, false
+}
+
+// Leave implements ast.Visitor interface.
+func (*mviewNodeCollector) Leave(n ast.Node) (ast.Node, bool) {
+	return n, true
+}
+
+func collectMViewNodes(sel ast.StmtNode) *mviewNodeCollector {
+	c := &mviewNodeCollector{columns: make(map[string]struct{})}
+	sel.Accept(c)
+	return c
+}
+
+// collectMViewBaseTables returns the tables read by the query of a materialized
+// view. Views, CTEs and the tables in the memory or system schemas are not tracked.
+func collectMViewBaseTables(is infoschema.InfoSchema, sel ast.StmtNode) ([]*model.TableInfo, error) {
+	var baseTables []*model.TableInfo
+	for _, tn := range collectMViewNodes(sel).tables {
+		// The schema of a table name is filled by the preprocessor, except for CTEs.
+		if tn.Schema.L == "" || util.IsMemOrSysDB(tn.Schema.L) {
+			continue
+		}
+		tblInfo, err := is.TableInfoByName(tn.Schema, tn.Name)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		if tblInfo.IsView() {
+			continue
+		}
+		if tblInfo.TempTableType != model.TempTableNone {
+			return nil, dbterror.ErrOptOnTemporaryTable.GenWithStackByArgs("create materialized view")
+		}
+		if tblInfo.IsMViewLog() {
+			return nil, dbterror.ErrWrongObject.GenWithStackByArgs(tn.Schema, tn.Name, "BASE TABLE")
+		}
+		if !slices.ContainsFunc(baseTables, func(t *model.TableInfo) bool { return t.ID == tblInfo.ID }) {
+			baseTables = append(baseTables, tblInfo)
+		}
+	}
+	return baseTables, nil
+}
+
+// mviewColumnType returns the type of a column storing a query result, the
+// flags describing the constraints of the result are removed.
+func mviewColumnType(tp *types.FieldType) *types.FieldType {
+	ntp := tp.Clone()
+	ntp.SetFlag(tp.GetFlag() & (mysql.UnsignedFlag | mysql.BinaryFlag | mysql.ZerofillFlag))
+	switch ntp.GetType() {
+	case mysql.TypeVarString:
+		ntp.SetType(mysql.TypeVarchar)
+	case mysql.TypeNewDecimal:
+		if ntp.GetFlen() > mysql.MaxDecimalWidth {
+			ntp.SetFlen(mysql.MaxDecimalWidth)
+		}
+	}
+	return ntp
+}
+
+// buildMViewTableInfo builds the table storing the result of a materialized view.
+func buildMViewTableInfo(ctx sessionctx.Context, schema *model.DBInfo, s *ast.CreateMaterializedViewStmt) (*model.TableInfo, error) {
+	cols := make([]*ast.ColumnDef, 0, len(s.Cols))
+	for i, name := range s.Cols {
+		cols = append(cols, &ast.ColumnDef{
+			Name: &ast.ColumnName{Name: name},
+			Tp:   mviewColumnType(s.ColTypes[i]),
+		})
+	}
+	tbInfo, err := BuildTableInfoWithStmt(ctx, &ast.CreateTableStmt{Table: s.ViewName, Cols: cols},
+		schema.Charset, schema.Collate, schema.PlacementPolicyRef)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if err = checkDuplicateColumn(tbInfo.Columns); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return tbInfo, nil
+}
+
+// buildMViewLogTableInfo builds the log table of a materialized view refreshed
+// incrementally. The log table copies the columns of the base table used by the
+// query, and has an extra column telling whether the row is inserted or deleted.
+func buildMViewLogTableInfo(ctx sessionctx.Context, schema *model.DBInfo, mvID int64, baseTbl *model.TableInfo, sel ast.StmtNode) (*model.TableInfo, error) {
+	usedCols := collectMViewNodes(sel).columns
+	cols := make([]*ast.ColumnDef, 0, len(usedCols)+1)
+	logInfo := &model.MViewLogInfo{MViewID: mvID, BaseTableID: baseTbl.ID}
+	for _, col := range baseTbl.Columns {
+		if _, ok := usedCols[col.Name.L]; !ok || col.Hidden || col.State != model.StatePublic {
+			continue
+		}
+		cols = append(cols, &ast.ColumnDef{
+			Name: &ast.ColumnName{Name: col.Name},
+			Tp:   mviewColumnType(&col.FieldType),
+		})
+		logInfo.BaseColumnIDs = append(logInfo.BaseColumnIDs, col.ID)
+	}
+	cols = append(cols, &ast.ColumnDef{
+		Name:    &ast.ColumnName{Name: model.NewCIStr(model.MViewLogSignColumn)},
+		Tp:      types.NewFieldType(mysql.TypeTiny),
+		Options: []*ast.ColumnOption{{Tp: ast.ColumnOptionNotNull}},
+	})
+	name := &ast.TableName{
+		Schema: schema.Name,
+		Name:   model.NewCIStr(fmt.Sprintf("%s%d", model.MViewLogTablePrefix, mvID)),
+	}
+	tbInfo, err := BuildTableInfoWithStmt(ctx, &ast.CreateTableStmt{Table: name, Cols: cols},
+		schema.Charset, schema.Collate, schema.PlacementPolicyRef)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	tbInfo.MViewLog = logInfo
+	return tbInfo, nil
+}
+
+func onCreateMaterializedView(d *ddlCtx, t *meta.Meta, job *model.Job) (ver int64, err error) {
+	var (
+		tbInfo        *model.TableInfo
+		logInfo       *model.TableInfo
+		baseSchemaIDs []int64
+	)
+	if err := job.DecodeArgs(&tbInfo, &logInfo, &baseSchemaIDs); err != nil {
+		job.State = model.JobStateCancelled
+		return ver, errors.Trace(err)
+	}
+	if tbInfo.MaterializedView == nil || len(baseSchemaIDs) != len(tbInfo.MaterializedView.BaseTableIDs) {
+		job.State = model.JobStateCancelled
+		return ver, dbterror.ErrInvalidDDLJob.GenWithStackByArgs(job)
+	}
+
+	// The base tables record the view, so the changes of them are written into
+	// the log table.
+	baseTables := make([]schemaIDAndTableInfo, 0, len(baseSchemaIDs))
+	for i, id := range tbInfo.MaterializedView.BaseTableIDs {
+		baseTbl, err := getTableInfo(t, id, baseSchemaIDs[i])
+		if err != nil {
+			job.State = model.JobStateCancelled
+			return ver, errors.Trace(err)
+		}
+		baseTbl.MViewIDs = append(baseTbl.MViewIDs, tbInfo.ID)
+		baseTables = append(baseTables, schemaIDAndTableInfo{schemaID: baseSchemaIDs[i], tblInfo: baseTbl})
+	}
+
+	// Create the tables with a stub job, see onCreateTables.
+	stubJob := job.Clone()
+	stubJob.Args = []any{tbInfo}
+	if tbInfo, err = createTable(d, t, stubJob, false); err != nil {
+		job.State = model.JobStateCancelled
+		return ver, errors.Trace(err)
+	}
+	if logInfo != nil {
+		stubJob.TableID = logInfo.ID
+		stubJob.Args = []any{logInfo}
+		if logInfo, err = createTable(d, t, stubJob, false); err != nil {
+			job.State = model.JobStateCancelled
+			return ver, errors.Trace(err)
+		}
+		job.CtxVars = []any{logInfo.ID}
+	}
+	for _, base := range baseTables {
+		if err = updateTable(t, base.schemaID, base.tblInfo); err != nil {
+			return ver, errors.Trace(err)
+		}
+	}
+
+	ver, err = updateSchemaVersion(d, t, job, baseTables...)
+	if err != nil {
+		return ver, errors.Trace(err)
+	}
+	job.FinishTableJob(model.JobStateDone, model.StatePublic, ver, tbInfo)
+	asyncNotifyEvent(d, statsutil.NewCreateTableEvent(job.SchemaID, tbInfo))
+	if logInfo != nil {
+		asyncNotifyEvent(d, statsutil.NewCreateTableEvent(job.SchemaID, logInfo))
+	}
+	return ver, nil
+}
+
+func onDropMaterializedView(d *ddlCtx, t *meta.Meta, job *model.Job) (ver int64, _ error) {
+	var baseSchemaIDs []int64
+	if err := job.DecodeArgs(&baseSchemaIDs); err != nil {
+		job.State = model.JobStateCancelled
+		return ver, errors.Trace(err)
+	}
+	tblInfo, err := checkTableExistAndCancelNonExistJob(t, job, job.SchemaID)
+	if err != nil {
+		return ver, errors.Trace(err)
+	}
+	if !tblInfo.IsMaterializedView() {
+		job.State = model.JobStateCancelled
+		return ver, dbterror.ErrWrongObject.GenWithStackByArgs(job.SchemaName, tblInfo.Name, "MATERIALIZED VIEW")
+	}
+	var logInfo *model.TableInfo
+	if logID := tblInfo.MaterializedView.LogTableID; logID != 0 {
+		logInfo, err = t.GetTable(job.SchemaID, logID)
+		if err != nil {
+			return ver, errors.Trace(err)
+		}
+		if logInfo != nil {
+			job.CtxVars = []any{logInfo.ID}
+		}
+	}
+	// The view and its log table go through the same states as DROP TABLE.
+	setState := func(state model.SchemaState) error {
+		tblInfo.State = state
+		if logInfo == nil {
+			return nil
+		}
+		logInfo.State = state
+		return updateTable(t, job.SchemaID, logInfo)
+	}
+
+	originalState := job.SchemaState
+	switch tblInfo.State {
+	case model.StatePublic:
+		// public -> write only, the base tables don't record the changes anymore.
+		baseTables, err := removeMViewFromBaseTables(t, tblInfo, baseSchemaIDs)
+		if err != nil {
+			return ver, errors.Trace(err)
+		}
+		if err = setState(model.StateWriteOnly); err != nil {
+			return ver, errors.Trace(err)
+		}
+		ver, err = updateVersionAndTableInfo(d, t, job, tblInfo, originalState != tblInfo.State, baseTables...)
+		if err != nil {
+			return ver, errors.Trace(err)
+		}
+	case model.StateWriteOnly:
+		// write only -> delete only
+		if err = setState(model.StateDeleteOnly); err != nil {
+			return ver, errors.Trace(err)
+		}
+		ver, err = updateVersionAndTableInfo(d, t, job, tblInfo, originalState != tblInfo.State)
+		if err != nil {
+			return ver, errors.Trace(err)
+		}
+	case model.StateDeleteOnly:
+		if err = setState(model.StateNone); err != nil {
+			return ver, errors.Trace(err)
+		}
+		ver, err = updateVersionAndTableInfo(d, t, job, tblInfo, originalState != tblInfo.State)
+		if err != nil {
+			return ver, errors.Trace(err)
+		}
+		droppedTables := []*model.TableInfo{tblInfo}
+		if logInfo != nil {
+			droppedTables = append(droppedTables, logInfo)
+		}
+		tableIDs := make([]int64, 0, len(droppedTables))
+		for _, tbl := range droppedTables {
+			if err = t.DropTableOrView(job.SchemaID, job.SchemaName, tbl.ID, tbl.Name.L); err != nil {
+				return ver, errors.Trace(err)
+			}
+			if err = t.GetAutoIDAccessors(job.SchemaID, tbl.ID).Del(); err != nil {
+				return ver, errors.Trace(err)
+			}
+			tableIDs = append(tableIDs, tbl.ID)
+		}
+
+		// Finish this job.
+		job.FinishTableJob(model.JobStateDone, model.StateNone, ver, tblInfo)
+		job.Args = []any{tableIDs}
+		for _, tbl := range droppedTables {
+			asyncNotifyEvent(d, statsutil.NewDropTableEvent(job.SchemaID, tbl))
+		}
+	default:
+		return ver, errors.Trace(dbterror.ErrInvalidDDLState.GenWithStackByArgs("table", tblInfo.State))
+	}
+	job.SchemaState = tblInfo.State
+	return ver, errors.Trace(err)
+}
+
+// removeMViewFromBaseTables removes the materialized view from its base tables,
+// the base tables which have been dropped are skipped.
+func removeMViewFromBaseTables(t *meta.Meta, tblInfo *model.TableInfo, baseSchemaIDs []int64) ([]schemaIDAndTableInfo, error) {
+	baseTables := make([]schemaIDAndTableInfo, 0, len(baseSchemaIDs))
+	for i, id := range tblInfo.MaterializedView.BaseTableIDs {
+		if i >= len(baseSchemaIDs) || baseSchemaIDs[i] == 0 {
+			continue
+		}
+		baseTbl, err := getTableInfo(t, id, baseSchemaIDs[i])
+		if err != nil {
+			if infoschema.ErrTableNotExists.Equal(err) || infoschema.ErrDatabaseNotExists.Equal(err) {
+				continue
+			}
+			return nil, errors.Trace(err)
+		}
+		idx := slices.Index(baseTbl.MViewIDs, tblInfo.ID)
+		if idx < 0 {
+			continue
+		}
+		baseTbl.MViewIDs = slices.Delete(baseTbl.MViewIDs, idx, idx+1)
+		if len(baseTbl.MViewIDs) == 0 {
+			baseTbl.MViewIDs = nil
+		}
+		baseTables = append(baseTables, schemaIDAndTableInfo{schemaID: baseSchemaIDs[i], tblInfo: baseTbl})
+	}
+	return baseTables, nil
+}
+
+// checkMViewTableDDL returns an error if the table stores the result or the log
+// of a materialized view, the DDL on it breaks the view.
+func checkMViewTableDDL(is infoschema.InfoSchema, tblInfo *model.TableInfo, op string) error {
+	var mvID int64
+	switch {
+	case tblInfo.IsMaterializedView():
+		mvID = tblInfo.ID
+	case tblInfo.IsMViewLog():
+		mvID = tblInfo.MViewLog.MViewID
+	default:
+		return nil
+	}
+	mvName := tblInfo.Name
+	if mvInfo, ok := is.TableInfoByID(mvID); ok {
+		mvName = mvInfo.Name
+	}
+	return dbterror.ErrOptOnMViewTable.GenWithStackByArgs(op, tblInfo.Name.O, mvName.O)
+}
+
+// checkMViewBaseTableDDL returns an error if the table is a base table of a
+// materialized view, the changes of its rows made by the DDL are not recorded in
+// the log table.
+func checkMViewBaseTableDDL(is infoschema.InfoSchema, tblInfo *model.TableInfo, op string) error {
+	for _, id := range tblInfo.MViewIDs {
+		if mvInfo, ok := is.TableInfoByID(id); ok {
+			return dbterror.ErrOptOnMViewTable.GenWithStackByArgs(op, tblInfo.Name.O, mvInfo.Name.O)
+		}
+	}
+	return nil
+}
+
+// checkTruncateMViewTable returns an error if the table is used by a
+// materialized view, TRUNCATE TABLE is not recorded in the log table.
+func checkTruncateMViewTable(is infoschema.InfoSchema, tblInfo *model.TableInfo) error {
+	if err := checkMViewTableDDL(is, tblInfo, "TRUNCATE TABLE"); err != nil {
+		return err
+	}
+	return checkMViewBaseTableDDL(is, tblInfo, "TRUNCATE TABLE")
+}
+
+// checkAlterTableForMView returns an error if ALTER TABLE changes the columns
+// of the tables of a materialized view, or the columns copied to the log table
+// of a materialized view, or changes the rows of a base table of a materialized
+// view by the partition operations. REORGANIZE PARTITION and REBUILD PARTITION
+// only move the rows between the partitions, they are allowed.
+func checkAlterTableForMView(is infoschema.InfoSchema, tblInfo *model.TableInfo, specs []*ast.AlterTableSpec) error {
+	for _, spec := range specs {
+		var colName model.CIStr
+		switch spec.Tp {
+		case ast.AlterTableTruncatePartition, ast.AlterTableDropPartition, ast.AlterTableExchangePartition:
+			op := "ALTER TABLE " + alterTablePartitionOpName(spec.Tp)
+			if err := checkMViewTableDDL(is, tblInfo, op); err != nil {
+				return err
+			}
+			if err := checkMViewBaseTableDDL(is, tblInfo, op); err != nil {
+				return err
+			}
+			continue
+		case ast.AlterTableAddColumns:
+		case ast.AlterTableDropColumn, ast.AlterTableChangeColumn, ast.AlterTableRenameColumn:
+			colName = spec.OldColumnName.Name
+		case ast.AlterTableModifyColumn:
+			colName = spec.NewColumns[0].Name.Name
+		default:
+			continue
+		}
+		if err := checkMViewTableDDL(is, tblInfo, "ALTER TABLE"); err != nil {
+			return err
+		}
+		col := model.FindColumnInfo(tblInfo.Columns, colName.L)
+		if col == nil {
+			continue
+		}
+		for _, id := range tblInfo.MViewIDs {
+			mvInfo, ok := is.TableInfoByID(id)
+			if !ok || mvInfo.MaterializedView == nil {
+				continue
+			}
+			logInfo, ok := is.TableInfoByID(mvInfo.MaterializedView.LogTableID)
+			if ok && logInfo.MViewLog != nil && slices.Contains(logInfo.MViewLog.BaseColumnIDs, col.ID) {
+				return dbterror.ErrOptOnMViewTable.GenWithStackByArgs("ALTER TABLE", tblInfo.Name.O, mvInfo.Name.O)
+			}
+		}
+	}
+	return nil
+}
+
+func alterTablePartitionOpName(tp ast.AlterTableType) string {
+	switch tp {
+	case ast.AlterTableTruncatePartition:
+		return "TRUNCATE PARTITION"
+	case ast.AlterTableDropPartition:
+		return "DROP PARTITION"
+	default:
+		return "EXCHANGE PARTITION"
+	}
+}
@@ -84,7 +84,7 @@ func expectedDeleteRangeCnt(ctx delRangeCntCtx, job *model.Job) (int, error) {
 		return 0, nil
 	}
 	switch job.Type {
-	case model.ActionDropSchema:
+	case model.ActionDropSchema, model.ActionDropMaterializedView:
 		var tableIDs []int64
 		if err := job.DecodeArgs(&tableIDs); err != nil {
 			return 0, errors.Trace(err)
@@ -187,6 +187,25 @@ func SetSchemaDiffForDropTable(diff *model.SchemaDiff, job *model.Job) {
 	}
 }
 
+// SetSchemaDiffForMaterializedView set SchemaDiff for ActionCreateMaterializedView
+// and ActionDropMaterializedView.
+func SetSchemaDiffForMaterializedView(diff *model.SchemaDiff, job *model.Job) {
+	diff.TableID = job.TableID
+	if len(job.CtxVars) == 0 {
+		return
+	}
+	// The log table is created or dropped with the view.
+	if logID, ok := job.CtxVars[0].(int64); ok {
+		opt := &model.AffectedOption{SchemaID: job.SchemaID, OldSchemaID: job.SchemaID}
+		if job.Type == model.ActionCreateMaterializedView {
+			opt.TableID = logID
+		} else {
+			opt.OldTableID = logID
+		}
+		diff.AffectedOpts = append(diff.AffectedOpts, opt)
+	}
+}
+
 // SetSchemaDiffForReorganizePartition set SchemaDiff for ActionReorganizePartition.
 func SetSchemaDiffForReorganizePartition(diff *model.SchemaDiff, job *model.Job) {
 	diff.TableID = job.TableID
@@ -349,6 +368,8 @@ func updateSchemaVersion(d *ddlCtx, t *meta.Meta, job *model.Job, multiInfos ...
 		err = SetSchemaDiffForRecoverSchema(diff, job)
 	case model.ActionFlashbackCluster:
 		SetSchemaDiffForFlashbackCluster(diff, job)
+	case model.ActionCreateMaterializedView, model.ActionDropMaterializedView:
+		SetSchemaDiffForMaterializedView(diff, job)
 	default:
 		diff.TableID = job.TableID
 	}
@@ -368,6 +368,26 @@ func (d *Checker) RenameTable(ctx sessionctx.Context, stmt *ast.RenameTableStmt)
 	return nil
 }
 
+// CreateTrigger implements the DDL interface.
+func (d *Checker) CreateTrigger(ctx sessionctx.Context, stmt *ast.CreateTriggerStmt) error {
+	return d.realDDL.CreateTrigger(ctx, stmt)
+}
+
+// DropTrigger implements the DDL interface.
+func (d *Checker) DropTrigger(ctx sessionctx.Context, stmt *ast.DropTriggerStmt) error {
+	return d.realDDL.DropTrigger(ctx, stmt)
+}
+
+// CreateMaterializedView implements the DDL interface.
+func (d *Checker) CreateMaterializedView(ctx sessionctx.Context, stmt *ast.CreateMaterializedViewStmt) error {
+	return d.realDDL.CreateMaterializedView(ctx, stmt)
+}
+
+// DropMaterializedView implements the DDL interface.
+func (d *Checker) DropMaterializedView(ctx sessionctx.Context, stmt *ast.DropMaterializedViewStmt) error {
+	return d.realDDL.DropMaterializedView(ctx, stmt)
+}
+
 // LockTables implements the DDL interface.
 func (d *Checker) LockTables(ctx sessionctx.Context, stmt *ast.LockTablesStmt) error {
 	return d.realDDL.LockTables(ctx, stmt)
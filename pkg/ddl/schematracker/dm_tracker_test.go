@@ -86,6 +86,16 @@ func TestCreateTableLongIndex(t *testing.T) {
 	tracker := schematracker.NewSchemaTracker(2)
 	tracker.CreateTestDB(nil)
 	execCreate(t, tracker, sql)
+	tblInfo := mustTableByName(t, tracker, "test", "t")
+	require.Len(t, tblInfo.Indices, 1)
+	require.True(t, tblInfo.Indices[0].IsFulltextIndex())
+
+	sql = "alter table test.t add fulltext key b (a) with parser ngram"
+	execAlter(t, tracker, sql)
+	tblInfo = mustTableByName(t, tracker, "test", "t")
+	require.Len(t, tblInfo.Indices, 2)
+	require.True(t, tblInfo.Indices[1].IsFulltextIndex())
+	require.Equal(t, "ngram", tblInfo.Indices[1].ParserName.L)
 }
 
 func execAlter(t *testing.T, tracker schematracker.SchemaTracker, sql string) {
@@ -20,6 +20,7 @@ import (
 	"fmt"
 	"math/rand"
 	"sort"
+	"strconv"
 	"strings"
 	"sync"
 	"sync/atomic"
@@ -2661,10 +2662,10 @@ func TestPartitionErrorCode(t *testing.T) {
 		);`)
 	tk.MustGetDBError("alter table t_part coalesce partition 4;", dbterror.ErrCoalesceOnlyOnHashPartition)
 
-	tk.MustGetErrCode("alter table t_part check partition p0, p1;", errno.ErrUnsupportedDDLOperation)
-	tk.MustGetErrCode("alter table t_part optimize partition p0,p1;", errno.ErrUnsupportedDDLOperation)
-	tk.MustGetErrCode("alter table t_part rebuild partition p0,p1;", errno.ErrUnsupportedDDLOperation)
-	tk.MustGetErrCode("alter table t_part repair partition p1;", errno.ErrUnsupportedDDLOperation)
+	tk.MustExec("alter table t_part check partition p0, p1;")
+	tk.MustExec("alter table t_part optimize partition p0,p1;")
+	tk.MustExec("alter table t_part rebuild partition p0,p1;")
+	tk.MustExec("alter table t_part repair partition p1;")
 
 	// Reduce the impact on DML when executing partition DDL
 	tk1.MustExec("use test")
@@ -2681,6 +2682,96 @@ func TestPartitionErrorCode(t *testing.T) {
 	tk1.MustExec("commit")
 }
 
+func TestCheckRebuildOptimizePartition(t *testing.T) {
+	store := testkit.CreateMockStore(t)
+	tk := testkit.NewTestKit(t, store)
+	tk.MustExec("use test")
+	tk.MustExec(`create table t (a int, b int, key idx_b(b))
+		partition by range(a) (
+		partition p0 values less than (10),
+		partition p1 values less than (20),
+		partition p2 values less than (30))`)
+	tk.MustExec("insert into t values (1, 1), (5, 5), (11, 11), (15, 15), (21, 21), (25, 25)")
+	tk.MustExec("alter table t check partition p0, p1")
+	tk.MustExec("alter table t check partition all")
+	tk.MustGetErrCode("alter table t check partition p3", errno.ErrUnknownPartition)
+	tk.MustExec("create table t_normal (a int)")
+	tk.MustGetErrCode("alter table t_normal check partition p0", errno.ErrPartitionMgmtOnNonpartitioned)
+	tk.MustGetErrCode("alter table t_normal optimize partition p0", errno.ErrPartitionMgmtOnNonpartitioned)
+	tk.MustGetErrCode("alter table t_normal rebuild partition p0", errno.ErrPartitionMgmtOnNonpartitioned)
+	tk.MustGetErrCode("alter table t_normal repair partition p0", errno.ErrPartitionMgmtOnNonpartitioned)
+
+	// Remove an index entry of p1, only the check of p1 fails.
+	tblInfo := external.GetTableByName(t, tk, "test", "t").Meta()
+	rowID := tk.MustQuery("select _tidb_rowid from t where a = 11").Rows()[0][0].(string)
+	handle, err := strconv.ParseInt(rowID, 10, 64)
+	require.NoError(t, err)
+	txn, err := store.Begin()
+	require.NoError(t, err)
+	idx := tables.NewIndex(tblInfo.Partition.Definitions[1].ID, tblInfo, tblInfo.Indices[0])
+	require.NoError(t, idx.Delete(tk.Session().GetTableCtx(), txn, types.MakeDatums(11), kv.IntHandle(handle)))
+	require.NoError(t, txn.Commit(context.Background()))
+	tk.MustExec("alter table t check partition p0, p2")
+	require.Error(t, tk.ExecToErr("alter table t check partition p1"))
+	require.Error(t, tk.ExecToErr("alter table t check partition all"))
+
+	// Rebuilding p1 rebuilds its index from the rows in new key ranges.
+	tk.MustExec("alter table t rebuild partition p1")
+	tk.MustExec("alter table t check partition all")
+	tk.MustExec("admin check table t")
+	newInfo := external.GetTableByName(t, tk, "test", "t").Meta()
+	require.Len(t, newInfo.Partition.Definitions, 3)
+	for i, def := range newInfo.Partition.Definitions {
+		require.Equal(t, tblInfo.Partition.Definitions[i].Name, def.Name)
+		if i == 1 {
+			require.NotEqual(t, tblInfo.Partition.Definitions[i].ID, def.ID)
+		} else {
+			require.Equal(t, tblInfo.Partition.Definitions[i].ID, def.ID)
+		}
+	}
+	tk.MustQuery("select * from t partition(p1)").Sort().Check(testkit.Rows("11 11", "15 15"))
+
+	// Repairing p2 rebuilds its index in the same way.
+	rowID = tk.MustQuery("select _tidb_rowid from t where a = 21").Rows()[0][0].(string)
+	handle, err = strconv.ParseInt(rowID, 10, 64)
+	require.NoError(t, err)
+	txn, err = store.Begin()
+	require.NoError(t, err)
+	idx = tables.NewIndex(newInfo.Partition.Definitions[2].ID, newInfo, newInfo.Indices[0])
+	require.NoError(t, idx.Delete(tk.Session().GetTableCtx(), txn, types.MakeDatums(21), kv.IntHandle(handle)))
+	require.NoError(t, txn.Commit(context.Background()))
+	require.Error(t, tk.ExecToErr("alter table t check partition p2"))
+	tk.MustExec("alter table t repair partition p2")
+	tk.MustExec("alter table t check partition all")
+	tk.MustQuery("select * from t partition(p2)").Sort().Check(testkit.Rows("21 21", "25 25"))
+	newInfo = external.GetTableByName(t, tk, "test", "t").Meta()
+
+	// All the partitions of a HASH partitioned table are rebuilt together.
+	tk.MustExec("create table th (a int primary key, b int) partition by hash(a) partitions 3")
+	tk.MustExec("insert into th values (1, 1), (2, 2), (3, 3), (4, 4)")
+	oldInfo := external.GetTableByName(t, tk, "test", "th").Meta()
+	tk.MustExec("alter table th rebuild partition p0")
+	newInfo = external.GetTableByName(t, tk, "test", "th").Meta()
+	for i, def := range newInfo.Partition.Definitions {
+		require.NotEqual(t, oldInfo.Partition.Definitions[i].ID, def.ID)
+	}
+	tk.MustQuery("select * from th").Sort().Check(testkit.Rows("1 1", "2 2", "3 3", "4 4"))
+	tk.MustExec("admin check table th")
+
+	// Only the given partitions are analyzed by OPTIMIZE PARTITION. The table has no TiFlash replicas,
+	// so there is nothing to compact.
+	tk.MustExec("alter table t optimize partition p0, p1")
+	for _, warn := range tk.Session().GetSessionVars().StmtCtx.GetWarnings() {
+		require.NotContains(t, warn.Err.Error(), "compact")
+	}
+	tk.MustQuery("select p.partition_name, m.count from mysql.stats_meta m join information_schema.partitions p on m.table_id = p.tidb_partition_id " +
+		"where p.table_schema = 'test' and p.table_name = 't' and p.partition_name in ('p0', 'p1')").Sort().Check(testkit.Rows("p0 2", "p1 2"))
+	require.NotEmpty(t, tk.MustQuery("show stats_histograms where table_name = 't' and partition_name = 'p0'").Rows())
+	require.NotEmpty(t, tk.MustQuery("show stats_histograms where table_name = 't' and partition_name = 'p1'").Rows())
+	require.Empty(t, tk.MustQuery("show stats_histograms where table_name = 't' and partition_name = 'p2'").Rows())
+	tk.MustGetErrCode("alter table t optimize partition p3", errno.ErrUnknownPartition)
+}
+
 func TestCommitWhenSchemaChange(t *testing.T) {
 	store := testkit.CreateMockStoreWithSchemaLease(t, time.Second)
 	tk := testkit.NewTestKit(t, store)
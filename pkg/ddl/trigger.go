@@ -0,0 +1,115 @@
+// Copyright 2024 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ddl
+
+import (
+	"github.com/pingcap/errors"
+	"github.com/pingcap/tidb/pkg/meta"
+	"github.com/pingcap/tidb/pkg/parser/model"
+	"github.com/pingcap/tidb/pkg/util/dbterror"
+)
+
+func onCreateTrigger(d *ddlCtx, t *meta.Meta, job *model.Job) (ver int64, err error) {
+	var (
+		trigger      *model.TriggerInfo
+		otherTrigger model.CIStr
+		follows      bool
+	)
+	if err := job.DecodeArgs(&trigger, &otherTrigger, &follows); err != nil {
+		job.State = model.JobStateCancelled
+		return ver, errors.Trace(err)
+	}
+
+	tblInfo, err := GetTableInfoAndCancelFaultJob(t, job, job.SchemaID)
+	if err != nil {
+		return ver, errors.Trace(err)
+	}
+	for _, trg := range tblInfo.Triggers {
+		if trg.Name.L == trigger.Name.L {
+			job.State = model.JobStateCancelled
+			return ver, dbterror.ErrTrgAlreadyExists.GenWithStackByArgs()
+		}
+	}
+
+	// The new trigger is activated after the existing triggers with the same
+	// timing and event, unless the FOLLOWS or PRECEDES clause is specified.
+	offset := len(tblInfo.Triggers)
+	if otherTrigger.L != "" {
+		offset = findTriggerOffset(tblInfo.Triggers, otherTrigger, trigger.Timing, trigger.Event)
+		if offset < 0 {
+			job.State = model.JobStateCancelled
+			return ver, dbterror.ErrReferencedTrgDoesNotExist.GenWithStackByArgs(otherTrigger.O)
+		}
+		if follows {
+			offset++
+		}
+	}
+	triggers := make([]*model.TriggerInfo, 0, len(tblInfo.Triggers)+1)
+	triggers = append(triggers, tblInfo.Triggers[:offset]...)
+	triggers = append(triggers, trigger)
+	tblInfo.Triggers = append(triggers, tblInfo.Triggers[offset:]...)
+
+	ver, err = updateVersionAndTableInfo(d, t, job, tblInfo, true)
+	if err != nil {
+		return ver, errors.Trace(err)
+	}
+	job.FinishTableJob(model.JobStateDone, model.StatePublic, ver, tblInfo)
+	return ver, nil
+}
+
+func onDropTrigger(d *ddlCtx, t *meta.Meta, job *model.Job) (ver int64, err error) {
+	var name model.CIStr
+	if err := job.DecodeArgs(&name); err != nil {
+		job.State = model.JobStateCancelled
+		return ver, errors.Trace(err)
+	}
+
+	tblInfo, err := GetTableInfoAndCancelFaultJob(t, job, job.SchemaID)
+	if err != nil {
+		return ver, errors.Trace(err)
+	}
+	offset := -1
+	for i, trg := range tblInfo.Triggers {
+		if trg.Name.L == name.L {
+			offset = i
+			break
+		}
+	}
+	if offset < 0 {
+		job.State = model.JobStateCancelled
+		return ver, dbterror.ErrTrgDoesNotExist.GenWithStackByArgs()
+	}
+	tblInfo.Triggers = append(tblInfo.Triggers[:offset], tblInfo.Triggers[offset+1:]...)
+	if len(tblInfo.Triggers) == 0 {
+		tblInfo.Triggers = nil
+	}
+
+	ver, err = updateVersionAndTableInfo(d, t, job, tblInfo, true)
+	if err != nil {
+		return ver, errors.Trace(err)
+	}
+	job.FinishTableJob(model.JobStateDone, model.StatePublic, ver, tblInfo)
+	return ver, nil
+}
+
+// findTriggerOffset returns the offset of the trigger with the given name, timing and event, or -1 if it's not found.
+func findTriggerOffset(triggers []*model.TriggerInfo, name model.CIStr, timing model.TriggerTiming, event model.TriggerEvent) int {
+	for i, trg := range triggers {
+		if trg.Name.L == name.L && trg.Timing == timing && trg.Event == event {
+			return i
+		}
+	}
+	return -1
+}
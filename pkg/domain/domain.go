@@ -47,6 +47,7 @@ import (
 	"github.com/pingcap/tidb/pkg/domain/infosync"
 	"github.com/pingcap/tidb/pkg/domain/resourcegroup"
 	"github.com/pingcap/tidb/pkg/errno"
+	"github.com/pingcap/tidb/pkg/eventscheduler"
 	"github.com/pingcap/tidb/pkg/infoschema"
 	infoschema_metrics "github.com/pingcap/tidb/pkg/infoschema/metrics"
 	"github.com/pingcap/tidb/pkg/infoschema/perfschema"
@@ -178,6 +179,7 @@ type Domain struct {
 	logBackupAdvancer        *daemon.OwnerDaemon
 	historicalStatsWorker    *HistoricalStatsWorker
 	ttlJobManager            atomic.Pointer[ttlworker.JobManager]
+	eventScheduler           atomic.Pointer[eventscheduler.Scheduler]
 	runawayManager           *resourcegroup.RunawayManager
 	runawaySyncer            *runawaySyncer
 	resourceGroupsController *rmclient.ResourceGroupsController
@@ -1129,6 +1131,11 @@ func (do *Domain) Close() {
 			logutil.BgLogger().Info("ttlJobManager exited.")
 		}
 	}
+	if eventScheduler := do.eventScheduler.Load(); eventScheduler != nil {
+		logutil.BgLogger().Info("stopping eventScheduler")
+		eventScheduler.Stop()
+		logutil.BgLogger().Info("eventScheduler exited.")
+	}
 	do.releaseServerID(context.Background())
 	close(do.exit)
 	if do.etcdClient != nil {
@@ -1974,6 +1981,7 @@ func (do *Domain) LoadBindInfoLoop(ctxForHandle sessionctx.Context, ctxForEvolve
 
 	owner := do.newOwnerManager(bindinfo.Prompt, bindinfo.OwnerKey)
 	do.globalBindHandleWorkerLoop(owner)
+	do.evolveBindingsLoop(ctxForEvolve, owner)
 	return nil
 }
 
@@ -2020,6 +2028,38 @@ func (do *Domain) globalBindHandleWorkerLoop(owner owner.Manager) {
 	}, "globalBindHandleWorkerLoop")
 }
 
+// evolveBindingsLoop verifies the plans of the captured bindings on the bindinfo owner periodically
+// when tidb_evolve_plan_baselines is on. It runs in its own goroutine since the verification executes
+// the statements and may take a long time.
+func (do *Domain) evolveBindingsLoop(ctx sessionctx.Context, owner owner.Manager) {
+	do.wg.Run(func() {
+		defer func() {
+			logutil.BgLogger().Info("evolveBindingsLoop exited.")
+		}()
+		defer util.Recover(metrics.LabelDomain, "evolveBindingsLoop", nil, false)
+
+		evolveTicker := time.NewTicker(100 * bindinfo.Lease)
+		defer evolveTicker.Stop()
+		for {
+			select {
+			case <-do.exit:
+				return
+			case <-evolveTicker.C:
+				if !owner.IsOwner() {
+					continue
+				}
+				optVal, err := do.GetGlobalVar(variable.TiDBEvolvePlanBaselines)
+				if err != nil || !variable.TiDBOptOn(optVal) {
+					continue
+				}
+				if err := do.BindHandle().EvolveGlobalBindings(ctx, "", false); err != nil {
+					logutil.BgLogger().Warn("evolve bindings failed", zap.Error(err))
+				}
+			}
+		}
+	}, "evolveBindingsLoop")
+}
+
 // SetupPlanReplayerHandle setup plan replayer handle
 func (do *Domain) SetupPlanReplayerHandle(collectorSctx sessionctx.Context, workersSctxs []sessionctx.Context) {
 	ctx := kv.WithInternalSourceType(context.Background(), kv.InternalTxnStats)
@@ -3108,6 +3148,18 @@ func (do *Domain) TTLJobManager() *ttlworker.JobManager {
 	return do.ttlJobManager.Load()
 }
 
+// StartEventScheduler creates and starts the event scheduler
+func (do *Domain) StartEventScheduler() {
+	eventScheduler := eventscheduler.NewScheduler(do.sysSessionPool, do.etcdClient, do.ddl.OwnerManager().IsOwner)
+	do.eventScheduler.Store(eventScheduler)
+	eventScheduler.Start()
+}
+
+// EventScheduler returns the event scheduler on this domain
+func (do *Domain) EventScheduler() *eventscheduler.Scheduler {
+	return do.eventScheduler.Load()
+}
+
 // StopAutoAnalyze stops (*Domain).autoAnalyzeWorker to launch new auto analyze jobs.
 func (do *Domain) StopAutoAnalyze() {
 	do.stopAutoAnalyze.Store(true)
@@ -0,0 +1,170 @@
+// Copyright 2024 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package eventscheduler
+
+import (
+	"context"
+	"encoding/json"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/pingcap/errors"
+	"github.com/pingcap/tidb/pkg/timer/api"
+)
+
+const (
+	// timerKeyPrefix is the prefix of the keys of the event timers.
+	timerKeyPrefix = "/tidb/event/"
+	// timerHookClass is the hook class of the event timers.
+	timerHookClass = "tidb.event"
+)
+
+// The statuses of an event, they are shown in the STATUS column of information_schema.EVENTS.
+const (
+	StatusEnabled           = "ENABLED"
+	StatusDisabled          = "DISABLED"
+	StatusSlavesideDisabled = "SLAVESIDE_DISABLED"
+)
+
+// EventInfo is the definition of an event. It's stored as the data of the event's timer.
+type EventInfo struct {
+	Schema              string `json:"schema"`
+	Name                string `json:"name"`
+	Definer             string `json:"definer"`
+	Body                string `json:"body"`
+	SQLMode             string `json:"sql_mode"`
+	TimeZone            string `json:"time_zone"`
+	CharsetClient       string `json:"character_set_client"`
+	CollationConnection string `json:"collation_connection"`
+	DBCollation         string `json:"database_collation"`
+	// ExecuteAt is the execution time of a one-time event, it's zero for a recurring event.
+	ExecuteAt time.Time `json:"execute_at"`
+	// IntervalValue and IntervalField are the interval of a recurring event as they are written
+	// in the EVERY clause, for example, '1:30' and 'MINUTE_SECOND'.
+	IntervalValue string    `json:"interval_value"`
+	IntervalField string    `json:"interval_field"`
+	Starts        time.Time `json:"starts"`
+	Ends          time.Time `json:"ends"`
+	// Preserve is true if the event is kept after its last execution.
+	Preserve    bool      `json:"preserve"`
+	Status      string    `json:"status"`
+	Comment     string    `json:"comment"`
+	Created     time.Time `json:"created"`
+	LastAltered time.Time `json:"last_altered"`
+}
+
+// IsOneTime returns whether the event is a one-time event created with the AT clause.
+func (e *EventInfo) IsOneTime() bool {
+	return !e.ExecuteAt.IsZero()
+}
+
+// Event is an event loaded from its timer.
+type Event struct {
+	EventInfo
+	// TimerID is the id of the event's timer.
+	TimerID string
+	// LastExecuted is the time when the event was executed most recently, it's zero if the
+	// event has never been executed.
+	LastExecuted time.Time
+}
+
+// eventSummary is stored as the summary data of the event's timer.
+type eventSummary struct {
+	// LastEventID is the id of the timer event which executes the event most recently.
+	LastEventID  string    `json:"last_event_id"`
+	LastExecuted time.Time `json:"last_executed"`
+}
+
+// TimerKey returns the key of the timer of an event.
+func TimerKey(schema, name string) string {
+	return timerKeyPrefix + strings.ToLower(schema) + "/" + strings.ToLower(name)
+}
+
+// NewTimerSpec builds the timer spec of an event. The schedule policy of the timer is built by the caller
+// because it's evaluated from the expressions in the ON SCHEDULE clause.
+func NewTimerSpec(info *EventInfo, policyType api.SchedPolicyType, policyExpr string) (api.TimerSpec, error) {
+	data, err := json.Marshal(info)
+	if err != nil {
+		return api.TimerSpec{}, err
+	}
+	return api.TimerSpec{
+		Key:             TimerKey(info.Schema, info.Name),
+		Data:            data,
+		SchedPolicyType: policyType,
+		SchedPolicyExpr: policyExpr,
+		HookClass:       timerHookClass,
+		Enable:          info.Status == StatusEnabled,
+	}, nil
+}
+
+// UpdateEvent updates the definition and the schedule of an event.
+func UpdateEvent(ctx context.Context, cli api.TimerClient, timerID string, info *EventInfo, policyType api.SchedPolicyType, policyExpr string) error {
+	data, err := json.Marshal(info)
+	if err != nil {
+		return err
+	}
+	return cli.UpdateTimer(ctx, timerID,
+		api.WithSetData(data),
+		api.WithSetSchedExpr(policyType, policyExpr),
+		api.WithSetEnable(info.Status == StatusEnabled),
+	)
+}
+
+// DecodeEvent decodes the event from its timer.
+func DecodeEvent(timer *api.TimerRecord) (*Event, error) {
+	ev := &Event{TimerID: timer.ID}
+	if err := json.Unmarshal(timer.Data, &ev.EventInfo); err != nil {
+		return nil, errors.Annotatef(err, "invalid data of event timer %s", timer.Key)
+	}
+	if len(timer.SummaryData) > 0 {
+		var summary eventSummary
+		if err := json.Unmarshal(timer.SummaryData, &summary); err != nil {
+			return nil, errors.Annotatef(err, "invalid summary of event timer %s", timer.Key)
+		}
+		ev.LastExecuted = summary.LastExecuted
+	}
+	return ev, nil
+}
+
+// LoadEvents loads the events in the schema, or the events in all the schemas if schema is empty.
+// Only the event with the name is loaded if name is not empty. The events are ordered by their keys.
+func LoadEvents(ctx context.Context, cli api.TimerClient, schema, name string) ([]*Event, error) {
+	var opt api.GetTimerOption
+	switch {
+	case schema != "" && name != "":
+		opt = api.WithKey(TimerKey(schema, name))
+	case schema != "":
+		opt = api.WithKeyPrefix(timerKeyPrefix + strings.ToLower(schema) + "/")
+	default:
+		opt = api.WithKeyPrefix(timerKeyPrefix)
+	}
+	timers, err := cli.GetTimers(ctx, opt)
+	if err != nil {
+		return nil, err
+	}
+	events := make([]*Event, 0, len(timers))
+	for _, timer := range timers {
+		ev, err := DecodeEvent(timer)
+		if err != nil {
+			return nil, err
+		}
+		events = append(events, ev)
+	}
+	slices.SortFunc(events, func(a, b *Event) int {
+		return strings.Compare(TimerKey(a.Schema, a.Name), TimerKey(b.Schema, b.Name))
+	})
+	return events, nil
+}
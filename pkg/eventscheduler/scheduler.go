@@ -0,0 +1,316 @@
+// Copyright 2024 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package eventscheduler
+
+import (
+	"context"
+	"encoding/json"
+	"sync"
+	"time"
+
+	"github.com/ngaut/pools"
+	"github.com/pingcap/errors"
+	"github.com/pingcap/tidb/pkg/sessionctx"
+	"github.com/pingcap/tidb/pkg/sessionctx/variable"
+	"github.com/pingcap/tidb/pkg/timer/api"
+	timerrt "github.com/pingcap/tidb/pkg/timer/runtime"
+	"github.com/pingcap/tidb/pkg/timer/tablestore"
+	"github.com/pingcap/tidb/pkg/util"
+	"github.com/pingcap/tidb/pkg/util/logutil"
+	clientv3 "go.etcd.io/etcd/client/v3"
+	"go.uber.org/zap"
+)
+
+const (
+	schedulerLoopInterval = time.Second
+	closeEventRetryDelay  = 5 * time.Second
+)
+
+// ExecuteEvent executes the body of the event with the session.
+// It's registered by the executor package to avoid the cyclic dependency.
+var ExecuteEvent = func(_ context.Context, _ sessionctx.Context, ev *EventInfo) error {
+	return errors.Errorf("cannot execute event %s.%s, the executor is not registered", ev.Schema, ev.Name)
+}
+
+type sessionPool interface {
+	Get() (pools.Resource, error)
+	Put(pools.Resource)
+}
+
+// Scheduler schedules the events of the cluster on top of the timer framework. Every TiDB instance
+// can manage the events with the client of the scheduler, but only the runtime on the DDL owner
+// triggers them, so an event is executed once in the cluster each time it is due.
+type Scheduler struct {
+	pool    sessionPool
+	store   *api.TimerStore
+	cli     api.TimerClient
+	isOwner func() bool
+	rt      *timerrt.TimerGroupRuntime
+
+	ctx    context.Context
+	cancel func()
+	wg     util.WaitGroupWrapper
+}
+
+// NewScheduler creates a new Scheduler.
+func NewScheduler(pool sessionPool, etcd *clientv3.Client, isOwner func() bool) *Scheduler {
+	store := tablestore.NewTableTimerStore(1, pool, "mysql", "tidb_timers", etcd)
+	ctx, cancel := context.WithCancel(context.Background())
+	return &Scheduler{
+		pool:    pool,
+		store:   store,
+		cli:     api.NewDefaultTimerClient(store),
+		isOwner: isOwner,
+		ctx:     ctx,
+		cancel:  cancel,
+	}
+}
+
+// Client returns the timer client to manage the timers of the events.
+func (s *Scheduler) Client() api.TimerClient {
+	return s.cli
+}
+
+// Start starts the scheduler.
+func (s *Scheduler) Start() {
+	s.wg.Run(s.loop)
+}
+
+// Stop stops the scheduler and waits for the running events.
+func (s *Scheduler) Stop() {
+	s.cancel()
+	s.wg.Wait()
+	s.store.Close()
+}
+
+func (s *Scheduler) loop() {
+	ticker := time.NewTicker(schedulerLoopInterval)
+	defer func() {
+		ticker.Stop()
+		s.pause()
+		logutil.BgLogger().Info("event scheduler loop exited")
+	}()
+
+	for {
+		if s.isOwner() && variable.EnableEventScheduler.Load() {
+			s.resume()
+		} else {
+			s.pause()
+		}
+
+		select {
+		case <-s.ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (s *Scheduler) resume() {
+	if s.rt != nil {
+		return
+	}
+
+	logutil.BgLogger().Info("start to trigger events")
+	s.rt = timerrt.NewTimerRuntimeBuilder("event", s.store).
+		SetCond(&api.TimerCond{Key: api.NewOptionalVal(timerKeyPrefix), KeyPrefix: true}).
+		RegisterHookFactory(timerHookClass, func(_ string, cli api.TimerClient) api.Hook {
+			return newEventTimerHook(s.pool, cli)
+		}).
+		Build()
+	s.rt.Start()
+}
+
+func (s *Scheduler) pause() {
+	if rt := s.rt; rt != nil {
+		logutil.BgLogger().Info("stop triggering events")
+		s.rt = nil
+		rt.Stop()
+	}
+}
+
+// eventTimerHook executes the events when their timers are triggered.
+type eventTimerHook struct {
+	pool   sessionPool
+	cli    api.TimerClient
+	ctx    context.Context
+	cancel func()
+	wg     sync.WaitGroup
+
+	mu sync.Mutex
+	// running contains the timer events which are executing the events.
+	running map[string]struct{}
+}
+
+func newEventTimerHook(pool sessionPool, cli api.TimerClient) *eventTimerHook {
+	ctx, cancel := context.WithCancel(context.Background())
+	return &eventTimerHook{
+		pool:    pool,
+		cli:     cli,
+		ctx:     ctx,
+		cancel:  cancel,
+		running: make(map[string]struct{}),
+	}
+}
+
+func (*eventTimerHook) Start() {}
+
+func (h *eventTimerHook) Stop() {
+	h.cancel()
+	h.wg.Wait()
+}
+
+func (*eventTimerHook) OnPreSchedEvent(context.Context, api.TimerShedEvent) (r api.PreSchedEventResult, err error) {
+	return
+}
+
+// OnSchedEvent executes the event in the background and closes the timer event after that.
+// A timer event can be scheduled again if the owner changes before it's closed, so the id
+// of the timer event is saved in the timer summary before the execution, and an event is not
+// executed again for the same timer event.
+func (h *eventTimerHook) OnSchedEvent(ctx context.Context, event api.TimerShedEvent) error {
+	timer := event.Timer()
+	eventID := event.EventID()
+	logger := logutil.BgLogger().With(
+		zap.String("key", timer.Key),
+		zap.String("eventID", eventID),
+		zap.Time("eventStart", timer.EventStart),
+	)
+
+	h.mu.Lock()
+	_, running := h.running[eventID]
+	if !running {
+		h.running[eventID] = struct{}{}
+	}
+	h.mu.Unlock()
+	if running {
+		return nil
+	}
+
+	execute := true
+	var summary eventSummary
+	if len(timer.SummaryData) > 0 {
+		if err := json.Unmarshal(timer.SummaryData, &summary); err != nil {
+			logger.Warn("invalid summary of event timer", zap.ByteString("summary", timer.SummaryData), zap.Error(err))
+		}
+	}
+	if summary.LastEventID == eventID {
+		logger.Warn("event has been executed for the timer event, skip executing it again")
+		execute = false
+	} else {
+		summary = eventSummary{LastEventID: eventID, LastExecuted: timer.EventStart}
+		data, err := json.Marshal(summary)
+		if err == nil {
+			err = h.cli.UpdateTimer(ctx, timer.ID, api.WithSetSummaryData(data))
+		}
+		if err != nil {
+			h.mu.Lock()
+			delete(h.running, eventID)
+			h.mu.Unlock()
+			return err
+		}
+	}
+
+	h.wg.Add(1)
+	go h.executeEvent(logger, timer, eventID, execute)
+	return nil
+}
+
+func (h *eventTimerHook) executeEvent(logger *zap.Logger, timer *api.TimerRecord, eventID string, execute bool) {
+	defer func() {
+		h.mu.Lock()
+		delete(h.running, eventID)
+		h.mu.Unlock()
+		h.wg.Done()
+	}()
+
+	if execute {
+		logger.Info("start to execute event")
+		if err := h.execute(timer); err != nil {
+			logger.Warn("fail to execute event", zap.Error(err))
+		} else {
+			logger.Info("event executed")
+		}
+	}
+
+	for {
+		err := h.closeEvent(timer.ID, eventID)
+		if err == nil || errors.ErrorEqual(err, api.ErrTimerNotExist) || errors.ErrorEqual(err, api.ErrEventIDNotMatch) {
+			return
+		}
+		logger.Error("fail to close timer event", zap.Error(err), zap.Duration("retryAfter", closeEventRetryDelay))
+		select {
+		case <-h.ctx.Done():
+			return
+		case <-time.After(closeEventRetryDelay):
+		}
+	}
+}
+
+func (h *eventTimerHook) execute(timer *api.TimerRecord) (err error) {
+	ev, err := DecodeEvent(timer)
+	if err != nil {
+		return err
+	}
+	resource, err := h.pool.Get()
+	if err != nil {
+		return err
+	}
+	defer h.pool.Put(resource)
+	sctx, ok := resource.(sessionctx.Context)
+	if !ok {
+		return errors.Errorf("%T cannot be casted to sessionctx.Context", resource)
+	}
+	return ExecuteEvent(h.ctx, sctx, &ev.EventInfo)
+}
+
+// closeEvent closes the timer event. If the event will not be scheduled any more, it's dropped,
+// or disabled if ON COMPLETION PRESERVE is specified.
+func (h *eventTimerHook) closeEvent(timerID, eventID string) error {
+	timer, err := h.cli.GetTimerByID(h.ctx, timerID)
+	if err != nil {
+		return err
+	}
+	if timer.EventID != eventID {
+		return api.ErrEventIDNotMatch
+	}
+
+	next := timer.Clone()
+	next.Watermark = timer.EventStart
+	_, hasNext, err := next.NextEventTime()
+	if err != nil {
+		return err
+	}
+	if !hasNext && timer.Enable {
+		ev, err := DecodeEvent(timer)
+		if err != nil {
+			return err
+		}
+		if !ev.Preserve {
+			_, err = h.cli.DeleteTimer(h.ctx, timerID)
+			return err
+		}
+		if err = h.cli.CloseTimerEvent(h.ctx, timerID, eventID, api.WithSetWatermark(timer.EventStart)); err != nil {
+			return err
+		}
+		ev.Status = StatusDisabled
+		data, err := json.Marshal(&ev.EventInfo)
+		if err != nil {
+			return err
+		}
+		return h.cli.UpdateTimer(h.ctx, timerID, api.WithSetEnable(false), api.WithSetData(data))
+	}
+	return h.cli.CloseTimerEvent(h.ctx, timerID, eventID, api.WithSetWatermark(timer.EventStart))
+}
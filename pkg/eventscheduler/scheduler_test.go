@@ -0,0 +1,153 @@
+// Copyright 2024 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package eventscheduler
+
+import (
+	"context"
+	"encoding/json"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/ngaut/pools"
+	"github.com/pingcap/tidb/pkg/sessionctx"
+	"github.com/pingcap/tidb/pkg/timer/api"
+	"github.com/pingcap/tidb/pkg/util/mock"
+	"github.com/stretchr/testify/require"
+)
+
+type mockSessionPool struct{}
+
+func (mockSessionPool) Get() (pools.Resource, error) {
+	return mock.NewContext(), nil
+}
+
+func (mockSessionPool) Put(pools.Resource) {}
+
+type mockTimerEvent struct {
+	eventID string
+	timer   *api.TimerRecord
+}
+
+func (e *mockTimerEvent) EventID() string {
+	return e.eventID
+}
+
+func (e *mockTimerEvent) Timer() *api.TimerRecord {
+	return e.timer
+}
+
+// triggerTimer changes the timer to the trigger state like the timer runtime does.
+func triggerTimer(t *testing.T, cli api.TimerClient, store *api.TimerStore, timerID, eventID string, start time.Time) api.TimerShedEvent {
+	ctx := context.Background()
+	require.NoError(t, store.Update(ctx, timerID, &api.TimerUpdate{
+		EventStatus: api.NewOptionalVal(api.SchedEventTrigger),
+		EventID:     api.NewOptionalVal(eventID),
+		EventStart:  api.NewOptionalVal(start),
+	}))
+	timer, err := cli.GetTimerByID(ctx, timerID)
+	require.NoError(t, err)
+	return &mockTimerEvent{eventID: eventID, timer: timer}
+}
+
+func TestEventTimerHook(t *testing.T) {
+	var mu sync.Mutex
+	executed := make([]string, 0)
+	origExecute := ExecuteEvent
+	ExecuteEvent = func(_ context.Context, _ sessionctx.Context, ev *EventInfo) error {
+		mu.Lock()
+		defer mu.Unlock()
+		executed = append(executed, ev.Name)
+		return nil
+	}
+	defer func() {
+		ExecuteEvent = origExecute
+	}()
+
+	ctx := context.Background()
+	store := api.NewMemoryTimerStore()
+	defer store.Close()
+	cli := api.NewDefaultTimerClient(store)
+	hook := newEventTimerHook(mockSessionPool{}, cli)
+	defer hook.Stop()
+
+	now := time.Now().Truncate(time.Second)
+	at := now.Add(-time.Second)
+	createEvent := func(name string, preserve bool) *api.TimerRecord {
+		info := &EventInfo{Schema: "test", Name: name, Body: "delete from t", ExecuteAt: at, Preserve: preserve, Status: StatusEnabled}
+		spec, err := NewTimerSpec(info, api.SchedEventOnce, at.UTC().Format(time.RFC3339))
+		require.NoError(t, err)
+		timer, err := cli.CreateTimer(ctx, spec)
+		require.NoError(t, err)
+		return timer
+	}
+
+	// A one-time event is dropped after it's executed.
+	timer := createEvent("e1", false)
+	require.NoError(t, hook.OnSchedEvent(ctx, triggerTimer(t, cli, store, timer.ID, "event1", now)))
+	hook.wg.Wait()
+	require.Equal(t, []string{"e1"}, executed)
+	_, err := cli.GetTimerByID(ctx, timer.ID)
+	require.ErrorIs(t, err, api.ErrTimerNotExist)
+
+	// A one-time event with ON COMPLETION PRESERVE is disabled after it's executed.
+	timer = createEvent("e2", true)
+	require.NoError(t, hook.OnSchedEvent(ctx, triggerTimer(t, cli, store, timer.ID, "event2", now)))
+	hook.wg.Wait()
+	require.Equal(t, []string{"e1", "e2"}, executed)
+	events, err := LoadEvents(ctx, cli, "test", "")
+	require.NoError(t, err)
+	require.Len(t, events, 1)
+	require.Equal(t, "e2", events[0].Name)
+	require.Equal(t, StatusDisabled, events[0].Status)
+	require.Equal(t, now.Unix(), events[0].LastExecuted.Unix())
+	timer, err = cli.GetTimerByID(ctx, timer.ID)
+	require.NoError(t, err)
+	require.False(t, timer.Enable)
+	require.Equal(t, api.SchedEventIdle, timer.EventStatus)
+
+	// The event is not executed again if the timer event is scheduled again after the summary is saved,
+	// for example, the owner changes before the timer event is closed.
+	timer = createEvent("e3", true)
+	summary, err := json.Marshal(&eventSummary{LastEventID: "event3", LastExecuted: now})
+	require.NoError(t, err)
+	require.NoError(t, cli.UpdateTimer(ctx, timer.ID, api.WithSetSummaryData(summary)))
+	require.NoError(t, hook.OnSchedEvent(ctx, triggerTimer(t, cli, store, timer.ID, "event3", now)))
+	hook.wg.Wait()
+	require.Equal(t, []string{"e1", "e2"}, executed)
+	timer, err = cli.GetTimerByID(ctx, timer.ID)
+	require.NoError(t, err)
+	require.False(t, timer.Enable)
+	require.Equal(t, api.SchedEventIdle, timer.EventStatus)
+
+	// A recurring event keeps enabled until its end time.
+	info := &EventInfo{Schema: "test", Name: "e4", Body: "delete from t", IntervalValue: "1", IntervalField: "HOUR", Starts: at, Status: StatusEnabled}
+	spec, err := NewTimerSpec(info, api.SchedEventEvery, "3600 SECOND STARTS "+at.UTC().Format(time.RFC3339))
+	require.NoError(t, err)
+	timer, err = cli.CreateTimer(ctx, spec)
+	require.NoError(t, err)
+	require.NoError(t, hook.OnSchedEvent(ctx, triggerTimer(t, cli, store, timer.ID, "event4", now)))
+	hook.wg.Wait()
+	require.Equal(t, []string{"e1", "e2", "e4"}, executed)
+	timer, err = cli.GetTimerByID(ctx, timer.ID)
+	require.NoError(t, err)
+	require.True(t, timer.Enable)
+	require.Equal(t, api.SchedEventIdle, timer.EventStatus)
+	require.Equal(t, now.Unix(), timer.Watermark.Unix())
+	next, ok, err := timer.NextEventTime()
+	require.NoError(t, err)
+	require.True(t, ok)
+	require.Equal(t, at.Add(time.Hour).Unix(), next.Unix())
+}
@@ -818,7 +818,8 @@ func (a *ExecStmt) handleNoDelay(ctx context.Context, e exec.Executor, isPessimi
 	// If the executor doesn't return any result to the client, we execute it without delay.
 	if toCheck.Schema().Len() == 0 {
 		handled = !isExplainAnalyze
-		if isPessimistic {
+		// The statements in a stored procedure handle the pessimistic locks by themselves.
+		if _, isCall := toCheck.(*CallExec); isPessimistic && !isCall {
 			err := a.handlePessimisticDML(ctx, toCheck)
 			return handled, nil, err
 		}
@@ -1395,6 +1396,9 @@ func (a *ExecStmt) FinishExecuteStmt(txnTS uint64, err error, hasMoreResults boo
 	a.LogSlowQuery(txnTS, succ, hasMoreResults)
 	a.SummaryStmt(succ)
 	a.observeStmtFinishedForTopSQL()
+	if succ && sessVars.EnableCardinalityFeedback && !sessVars.InRestrictedSQL {
+		a.collectCardinalityFeedback()
+	}
 	if sessVars.StmtCtx.IsTiFlash.Load() {
 		if succ {
 			executor_metrics.TotalTiFlashQuerySuccCounter.Inc()
@@ -2110,6 +2114,21 @@ func (a *ExecStmt) observeStmtBeginForTopSQL(ctx context.Context) context.Contex
 	return topsql.AttachSQLAndPlanInfo(ctx, sqlDigest, planDigest)
 }
 
+// collectCardinalityFeedback records the estimation errors of the executed plan, which are used to
+// correct the estimation of the later optimizations.
+func (a *ExecStmt) collectCardinalityFeedback() {
+	stmtCtx := a.Ctx.GetSessionVars().StmtCtx
+	if a.Plan == nil || stmtCtx.RuntimeStatsColl == nil {
+		return
+	}
+	_, planDigest := GetPlanDigest(stmtCtx)
+	if planDigest == nil {
+		return
+	}
+	plannercore.CollectCardinalityFeedback(a.Plan, planDigest.String(), stmtCtx.RuntimeStatsColl,
+		a.Ctx.GetSessionVars().CardinalityFeedbackThreshold)
+}
+
 func (a *ExecStmt) observeStmtFinishedForTopSQL() {
 	vars := a.Ctx.GetSessionVars()
 	if vars == nil {
@@ -39,6 +39,8 @@ const (
 	DefPartialResult4FirstRowDurationSize = int64(unsafe.Sizeof(partialResult4FirstRowDuration{}))
 	// DefPartialResult4FirstRowJSONSize is the size of partialResult4FirstRowJSON
 	DefPartialResult4FirstRowJSONSize = int64(unsafe.Sizeof(partialResult4FirstRowJSON{}))
+	// DefPartialResult4FirstRowVectorFloat32Size is the size of partialResult4FirstRowVectorFloat32
+	DefPartialResult4FirstRowVectorFloat32Size = int64(unsafe.Sizeof(partialResult4FirstRowVectorFloat32{}))
 	// DefPartialResult4FirstRowDecimalSize is the size of partialResult4FirstRowDecimal
 	DefPartialResult4FirstRowDecimalSize = int64(unsafe.Sizeof(partialResult4FirstRowDecimal{}))
 	// DefPartialResult4FirstRowEnumSize is the size of partialResult4FirstRowEnum
@@ -104,6 +106,12 @@ type partialResult4FirstRowJSON struct {
 	val types.BinaryJSON
 }
 
+type partialResult4FirstRowVectorFloat32 struct {
+	basePartialResult4FirstRow
+
+	val types.VectorFloat32
+}
+
 type partialResult4FirstRowEnum struct {
 	basePartialResult4FirstRow
 
@@ -579,6 +587,73 @@ func (e *firstRow4JSON) deserializeForSpill(helper *deserializeHelper) (PartialR
 	return pr, memDelta
 }
 
+type firstRow4VectorFloat32 struct {
+	baseAggFunc
+}
+
+func (*firstRow4VectorFloat32) AllocPartialResult() (pr PartialResult, memDelta int64) {
+	return PartialResult(new(partialResult4FirstRowVectorFloat32)), DefPartialResult4FirstRowVectorFloat32Size
+}
+
+func (*firstRow4VectorFloat32) ResetPartialResult(pr PartialResult) {
+	p := (*partialResult4FirstRowVectorFloat32)(pr)
+	p.isNull, p.gotFirstRow = false, false
+}
+
+func (e *firstRow4VectorFloat32) UpdatePartialResult(sctx AggFuncUpdateContext, rowsInGroup []chunk.Row, pr PartialResult) (memDelta int64, err error) {
+	p := (*partialResult4FirstRowVectorFloat32)(pr)
+	if p.gotFirstRow {
+		return memDelta, nil
+	}
+	if len(rowsInGroup) > 0 {
+		input, isNull, err := e.args[0].EvalVectorFloat32(sctx, rowsInGroup[0])
+		if err != nil {
+			return memDelta, err
+		}
+		p.gotFirstRow, p.isNull, p.val = true, isNull, input.Clone()
+		memDelta += int64(input.SerializedSize())
+	}
+	return memDelta, nil
+}
+
+func (*firstRow4VectorFloat32) MergePartialResult(_ AggFuncUpdateContext, src, dst PartialResult) (memDelta int64, err error) {
+	p1, p2 := (*partialResult4FirstRowVectorFloat32)(src), (*partialResult4FirstRowVectorFloat32)(dst)
+	if !p2.gotFirstRow {
+		*p2 = *p1
+	}
+	return memDelta, nil
+}
+
+func (e *firstRow4VectorFloat32) AppendFinalResult2Chunk(_ AggFuncUpdateContext, pr PartialResult, chk *chunk.Chunk) error {
+	p := (*partialResult4FirstRowVectorFloat32)(pr)
+	if p.isNull || !p.gotFirstRow {
+		chk.AppendNull(e.ordinal)
+		return nil
+	}
+	chk.AppendVectorFloat32(e.ordinal, p.val)
+	return nil
+}
+
+func (e *firstRow4VectorFloat32) SerializePartialResult(partialResult PartialResult, chk *chunk.Chunk, spillHelper *SerializeHelper) {
+	pr := (*partialResult4FirstRowVectorFloat32)(partialResult)
+	resBuf := spillHelper.serializePartialResult4FirstRowVectorFloat32(*pr)
+	chk.AppendBytes(e.ordinal, resBuf)
+}
+
+func (e *firstRow4VectorFloat32) DeserializePartialResult(src *chunk.Chunk) ([]PartialResult, int64) {
+	return deserializePartialResultCommon(src, e.ordinal, e.deserializeForSpill)
+}
+
+func (e *firstRow4VectorFloat32) deserializeForSpill(helper *deserializeHelper) (PartialResult, int64) {
+	pr, memDelta := e.AllocPartialResult()
+	result := (*partialResult4FirstRowVectorFloat32)(pr)
+	success := helper.deserializePartialResult4FirstRowVectorFloat32(result)
+	if !success {
+		return nil, 0
+	}
+	return pr, memDelta
+}
+
 type firstRow4Decimal struct {
 	baseAggFunc
 }
@@ -147,6 +147,19 @@ func (s *deserializeHelper) deserializePartialResult4MaxMinJSON(dst *partialResu
 	return false
 }
 
+func (s *deserializeHelper) deserializePartialResult4MaxMinVectorFloat32(dst *partialResult4MaxMinVectorFloat32) bool {
+	if s.readRowIndex < s.totalRowCnt {
+		s.pab.Reset(s.column, s.readRowIndex)
+		dst.isNull = util.DeserializeBool(s.pab)
+		if !dst.isNull {
+			dst.val = util.DeserializeVectorFloat32(s.pab)
+		}
+		s.readRowIndex++
+		return true
+	}
+	return false
+}
+
 func (s *deserializeHelper) deserializePartialResult4MaxMinEnum(dst *partialResult4MaxMinEnum) bool {
 	if s.readRowIndex < s.totalRowCnt {
 		s.pab.Reset(s.column, s.readRowIndex)
@@ -374,6 +387,19 @@ func (s *deserializeHelper) deserializePartialResult4FirstRowJSON(dst *partialRe
 	return false
 }
 
+func (s *deserializeHelper) deserializePartialResult4FirstRowVectorFloat32(dst *partialResult4FirstRowVectorFloat32) bool {
+	if s.readRowIndex < s.totalRowCnt {
+		s.pab.Reset(s.column, s.readRowIndex)
+		s.deserializeBasePartialResult4FirstRow(&dst.basePartialResult4FirstRow)
+		if !dst.isNull && dst.gotFirstRow {
+			dst.val = util.DeserializeVectorFloat32(s.pab)
+		}
+		s.readRowIndex++
+		return true
+	}
+	return false
+}
+
 func (s *deserializeHelper) deserializePartialResult4FirstRowEnum(dst *partialResult4FirstRowEnum) bool {
 	if s.readRowIndex < s.totalRowCnt {
 		s.pab.Reset(s.column, s.readRowIndex)
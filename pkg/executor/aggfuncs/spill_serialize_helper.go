@@ -91,6 +91,15 @@ func (s *SerializeHelper) serializePartialResult4MaxMinJSON(value partialResult4
 	return s.buf
 }
 
+func (s *SerializeHelper) serializePartialResult4MaxMinVectorFloat32(value partialResult4MaxMinVectorFloat32) []byte {
+	s.buf = s.buf[:0]
+	s.buf = util.SerializeBool(value.isNull, s.buf)
+	if !value.isNull {
+		s.buf = util.SerializeVectorFloat32(value.val, s.buf)
+	}
+	return s.buf
+}
+
 func (s *SerializeHelper) serializePartialResult4MaxMinEnum(value partialResult4MaxMinEnum) []byte {
 	s.buf = s.buf[:0]
 	s.buf = util.SerializeBool(value.isNull, s.buf)
@@ -213,6 +222,14 @@ func (s *SerializeHelper) serializePartialResult4FirstRowJSON(value partialResul
 	return s.buf
 }
 
+func (s *SerializeHelper) serializePartialResult4FirstRowVectorFloat32(value partialResult4FirstRowVectorFloat32) []byte {
+	s.buf = s.serializeBasePartialResult4FirstRow(value.basePartialResult4FirstRow)
+	if !value.isNull && value.gotFirstRow {
+		s.buf = util.SerializeVectorFloat32(value.val, s.buf)
+	}
+	return s.buf
+}
+
 func (s *SerializeHelper) serializePartialResult4FirstRowEnum(value partialResult4FirstRowEnum) []byte {
 	s.buf = s.serializeBasePartialResult4FirstRow(value.basePartialResult4FirstRow)
 	s.buf = util.SerializeEnum(&value.val, s.buf)
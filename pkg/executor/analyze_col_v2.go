@@ -29,6 +29,7 @@ import (
 	"github.com/pingcap/tidb/pkg/parser/model"
 	"github.com/pingcap/tidb/pkg/parser/mysql"
 	"github.com/pingcap/tidb/pkg/sessionctx"
+	"github.com/pingcap/tidb/pkg/sessionctx/variable"
 	"github.com/pingcap/tidb/pkg/statistics"
 	"github.com/pingcap/tidb/pkg/table"
 	"github.com/pingcap/tidb/pkg/tablecodec"
@@ -83,6 +84,19 @@ func (e *AnalyzeColumnsExecV2) analyzeColumnsPushDownV2(gp *gp.Pool) *statistics
 			specialIndexes = append(specialIndexes, idx)
 		}
 	}
+	var incremental *incrementalAnalyzeState
+	if e.Incremental {
+		var err error
+		incremental, err = e.prepareIncrementalAnalyze(len(specialIndexes) > 0)
+		if err != nil {
+			return &statistics.AnalyzeResults{Err: err, Job: e.job}
+		}
+		if incremental != nil {
+			ranges = incremental.ranges()
+			// The extended stats can't be merged, they are kept unchanged.
+			collExtStats = collExtStats && !incremental.merge
+		}
+	}
 	samplingStatsConcurrency, err := getBuildSamplingStatsConcurrency(e.ctx)
 	if err != nil {
 		e.memTracker.Release(e.memTracker.BytesConsumed())
@@ -103,12 +117,36 @@ func (e *AnalyzeColumnsExecV2) analyzeColumnsPushDownV2(gp *gp.Pool) *statistics
 	})
 	defer wg.Wait()
 
-	count, hists, topNs, fmSketches, extStats, err := e.buildSamplingStats(gp, ranges, collExtStats, specialIndexesOffsets, idxNDVPushDownCh, samplingStatsConcurrency)
+	// The stats of the expressions can't be merged, they are kept unchanged by the incremental analyze.
+	var exprs []analyzedExpression
+	if variable.EnableExpressionStats.Load() && (incremental == nil || !incremental.merge) {
+		exprs, err = e.loadAnalyzedExpressions()
+		if err != nil {
+			e.memTracker.Release(e.memTracker.BytesConsumed())
+			return &statistics.AnalyzeResults{Err: err, Job: e.job}
+		}
+		if exprs == nil {
+			// It removes the existing stats of the expressions when saving the results.
+			exprs = []analyzedExpression{}
+		}
+	}
+	count, hists, topNs, fmSketches, extStats, exprStats, err := e.buildSamplingStats(gp, ranges, collExtStats, specialIndexesOffsets, idxNDVPushDownCh, samplingStatsConcurrency, exprs)
 	if err != nil {
 		e.memTracker.Release(e.memTracker.BytesConsumed())
 		return &statistics.AnalyzeResults{Err: err, Job: e.job}
 	}
 	cLen := len(e.analyzePB.ColReq.ColumnsInfo)
+	var incrementalMaxHandle *int64
+	if incremental != nil {
+		if incremental.merge {
+			count, err = incremental.mergeStats(e, gp, count, cLen, hists, topNs, fmSketches)
+			if err != nil {
+				e.memTracker.Release(e.memTracker.BytesConsumed())
+				return &statistics.AnalyzeResults{Err: err, Job: e.job}
+			}
+		}
+		incrementalMaxHandle = &incremental.maxHandle
+	}
 	colGroupResult := &statistics.AnalyzeResult{
 		Hist:    hists[cLen:],
 		TopNs:   topNs[cLen:],
@@ -130,15 +168,17 @@ func (e *AnalyzeColumnsExecV2) analyzeColumnsPushDownV2(gp *gp.Pool) *statistics
 	}
 
 	return &statistics.AnalyzeResults{
-		TableID:       e.tableID,
-		Ars:           []*statistics.AnalyzeResult{colResult, colGroupResult},
-		Job:           e.job,
-		StatsVer:      e.StatsVersion,
-		Count:         count,
-		Snapshot:      e.snapshot,
-		ExtStats:      extStats,
-		BaseCount:     e.baseCount,
-		BaseModifyCnt: e.baseModifyCnt,
+		TableID:              e.tableID,
+		Ars:                  []*statistics.AnalyzeResult{colResult, colGroupResult},
+		Job:                  e.job,
+		StatsVer:             e.StatsVersion,
+		Count:                count,
+		Snapshot:             e.snapshot,
+		ExtStats:             extStats,
+		BaseCount:            e.baseCount,
+		BaseModifyCnt:        e.baseModifyCnt,
+		IncrementalMaxHandle: incrementalMaxHandle,
+		ExpressionStats:      exprStats,
 	}
 }
 
@@ -207,17 +247,19 @@ func (e *AnalyzeColumnsExecV2) buildSamplingStats(
 	indexesWithVirtualColOffsets []int,
 	idxNDVPushDownCh chan analyzeIndexNDVTotalResult,
 	samplingStatsConcurrency int,
+	exprs []analyzedExpression,
 ) (
 	count int64,
 	hists []*statistics.Histogram,
 	topns []*statistics.TopN,
 	fmSketches []*statistics.FMSketch,
 	extStats *statistics.ExtendedStatsColl,
+	exprStats []*statistics.ExpressionStats,
 	err error,
 ) {
 	// Open memory tracker and resultHandler.
 	if err = e.open(ranges); err != nil {
-		return 0, nil, nil, nil, nil, err
+		return 0, nil, nil, nil, nil, nil, err
 	}
 	defer func() {
 		if err1 := e.resultHandler.Close(); err1 != nil {
@@ -294,12 +336,12 @@ func (e *AnalyzeColumnsExecV2) buildSamplingStats(
 		if err1 := mergeEg.Wait(); err1 != nil {
 			err = stderrors.Join(err, err1)
 		}
-		return 0, nil, nil, nil, nil, getAnalyzePanicErr(err)
+		return 0, nil, nil, nil, nil, nil, getAnalyzePanicErr(err)
 	}
 	err = mergeEg.Wait()
 	defer e.memTracker.Release(rootRowCollector.Base().MemSize)
 	if err != nil {
-		return 0, nil, nil, nil, nil, err
+		return 0, nil, nil, nil, nil, nil, err
 	}
 
 	// Decode the data from sample collectors.
@@ -311,7 +353,7 @@ func (e *AnalyzeColumnsExecV2) buildSamplingStats(
 		}
 		err = e.decodeSampleDataWithVirtualColumn(rootRowCollector, fieldTps, virtualColIdx, e.schemaForVirtualColEval)
 		if err != nil {
-			return 0, nil, nil, nil, nil, err
+			return 0, nil, nil, nil, nil, nil, err
 		}
 	} else {
 		// If there's no virtual column or we meet error during eval virtual column, we fallback to normal decode otherwise.
@@ -319,7 +361,7 @@ func (e *AnalyzeColumnsExecV2) buildSamplingStats(
 			for i := range sample.Columns {
 				sample.Columns[i], err = tablecodec.DecodeColumnValue(sample.Columns[i].GetBytes(), &e.colsInfo[i].FieldType, sc.TimeZone())
 				if err != nil {
-					return 0, nil, nil, nil, nil, err
+					return 0, nil, nil, nil, nil, nil, err
 				}
 			}
 		}
@@ -329,7 +371,7 @@ func (e *AnalyzeColumnsExecV2) buildSamplingStats(
 	for _, sample := range rootRowCollector.Base().Samples {
 		sample.Handle, err = e.handleCols.BuildHandleByDatums(sample.Columns)
 		if err != nil {
-			return 0, nil, nil, nil, nil, err
+			return 0, nil, nil, nil, nil, nil, err
 		}
 	}
 	colLen := len(e.colsInfo)
@@ -375,7 +417,7 @@ func (e *AnalyzeColumnsExecV2) buildSamplingStats(
 	if indexPushedDownResult.err != nil {
 		close(exitCh)
 		e.samplingBuilderWg.Wait()
-		return 0, nil, nil, nil, nil, indexPushedDownResult.err
+		return 0, nil, nil, nil, nil, nil, indexPushedDownResult.err
 	}
 	for _, offset := range indexesWithVirtualColOffsets {
 		ret := indexPushedDownResult.results[e.indexes[offset].ID]
@@ -420,14 +462,20 @@ func (e *AnalyzeColumnsExecV2) buildSamplingStats(
 		e.memTracker.Release(totalSampleCollectorSize)
 	}()
 	if err != nil {
-		return 0, nil, nil, nil, nil, err
+		return 0, nil, nil, nil, nil, nil, err
 	}
 
 	count = rootRowCollector.Base().Count
+	if exprs != nil {
+		exprStats, err = e.buildExpressionStats(exprs, rootRowCollector)
+		if err != nil {
+			return 0, nil, nil, nil, nil, nil, err
+		}
+	}
 	if needExtStats {
 		extStats, err = statistics.BuildExtendedStats(e.ctx, e.TableID.GetStatisticsID(), e.colsInfo, sampleCollectors)
 		if err != nil {
-			return 0, nil, nil, nil, nil, err
+			return 0, nil, nil, nil, nil, nil, err
 		}
 	}
 
@@ -0,0 +1,142 @@
+// Copyright 2024 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package executor
+
+import (
+	"context"
+
+	"github.com/pingcap/errors"
+	"github.com/pingcap/tidb/pkg/expression"
+	"github.com/pingcap/tidb/pkg/kv"
+	"github.com/pingcap/tidb/pkg/parser/ast"
+	"github.com/pingcap/tidb/pkg/parser/model"
+	"github.com/pingcap/tidb/pkg/parser/mysql"
+	"github.com/pingcap/tidb/pkg/statistics"
+	"github.com/pingcap/tidb/pkg/types"
+	"github.com/pingcap/tidb/pkg/util/chunk"
+	"github.com/pingcap/tidb/pkg/util/collate"
+	"github.com/pingcap/tidb/pkg/util/logutil"
+	"go.uber.org/zap"
+)
+
+// maxExpressionStatsPerTable is the max number of the expressions whose stats are collected for a table.
+// The most recently used ones are chosen.
+const maxExpressionStatsPerTable = 16
+
+// analyzedExpression is an expression used in the filters, whose stats are collected by the analyze.
+type analyzedExpression struct {
+	expr expression.Expression
+	hash int64
+}
+
+// loadAnalyzedExpressions loads the expressions used in the filters on the table from mysql.stats_expression_usage
+// and rebuilds them on the analyzed columns.
+func (e *AnalyzeColumnsExecV2) loadAnalyzedExpressions() ([]analyzedExpression, error) {
+	ctx := kv.WithInternalSourceType(context.Background(), kv.InternalTxnStats)
+	rows, _, err := e.ctx.GetRestrictedSQLExecutor().ExecRestrictedSQL(ctx, nil,
+		"select expr_hash, expr from mysql.stats_expression_usage where table_id = %? order by last_used_at desc limit %?",
+		e.tableInfo.ID, maxExpressionStatsPerTable)
+	if err != nil || len(rows) == 0 {
+		return nil, err
+	}
+	exprCtx := e.ctx.GetExprCtx()
+	// The offsets of the columns in the schema are the ones in the sampled rows.
+	cols := make([]*expression.Column, 0, len(e.colsInfo))
+	names := make(types.NameSlice, 0, len(e.colsInfo))
+	for i, col := range e.colsInfo {
+		cols = append(cols, &expression.Column{
+			RetType:  col.FieldType.Clone(),
+			ID:       col.ID,
+			UniqueID: exprCtx.AllocPlanColumnID(),
+			Index:    i,
+		})
+		names = append(names, &types.FieldName{
+			DBName:  model.NewCIStr(e.DBName),
+			TblName: e.tableInfo.Name,
+			ColName: col.Name,
+		})
+	}
+	schema := expression.NewSchema(cols...)
+	exprs := make([]analyzedExpression, 0, len(rows))
+	for _, row := range rows {
+		hash, sql := row.GetInt64(0), row.GetString(1)
+		expr, err := expression.ParseSimpleExpr(exprCtx, sql, expression.WithInputSchemaAndNames(schema, names, e.tableInfo))
+		if err != nil {
+			// The columns may be dropped or not analyzed this time.
+			logutil.BgLogger().Debug("skip the expression stats", zap.String("expr", sql), zap.Error(err))
+			continue
+		}
+		// The hash is different if the columns are renamed and a different column takes the name.
+		if _, newHash, ok := statistics.ExpressionStatsSQL(expr, e.tableInfo); !ok || newHash != hash {
+			continue
+		}
+		exprs = append(exprs, analyzedExpression{expr: expr, hash: hash})
+	}
+	return exprs, nil
+}
+
+// buildExpressionStats evaluates the expressions on the sampled rows and builds the stats of them.
+func (e *AnalyzeColumnsExecV2) buildExpressionStats(exprs []analyzedExpression, collector statistics.RowSampleCollector) ([]*statistics.ExpressionStats, error) {
+	samples := collector.Base().Samples
+	count := collector.Base().Count
+	evalCtx := e.ctx.GetExprCtx().GetEvalCtx()
+	sc := e.ctx.GetSessionVars().StmtCtx
+	result := make([]*statistics.ExpressionStats, 0, len(exprs))
+	rows := make([]chunk.Row, 0, len(samples))
+	for _, sample := range samples {
+		rows = append(rows, chunk.MutRowFromDatums(sample.Columns).ToRow())
+	}
+exprLoop:
+	for _, item := range exprs {
+		tp := item.expr.GetType(evalCtx)
+		var collator collate.Collator
+		// The collate keys are used for the string values like the columns, see subBuildWorker.
+		if tp.EvalType() == types.ETString && tp.GetType() != mysql.TypeEnum && tp.GetType() != mysql.TypeSet {
+			collator = collate.GetCollator(tp.GetCollate())
+		}
+		sampleItems := make([]*statistics.SampleItem, 0, len(rows))
+		nullSamples := 0
+		for j, row := range rows {
+			val, err := item.expr.Eval(evalCtx, row)
+			if err != nil {
+				sc.AppendWarning(errors.NewNoStackErrorf("Skip the stats of expression %s, reason: %s",
+					item.expr.StringWithCtx(evalCtx), err.Error()))
+				continue exprLoop
+			}
+			if val.IsNull() {
+				nullSamples++
+				continue
+			}
+			if len(val.GetBytes()) > statistics.MaxSampleValueLength {
+				continue
+			}
+			if collator != nil {
+				val.SetBytes(collator.Key(val.GetString()))
+			}
+			sampleItems = append(sampleItems, &statistics.SampleItem{Value: val, Ordinal: j})
+		}
+		var nullCount int64
+		if len(rows) > 0 {
+			nullCount = int64(float64(nullSamples) * float64(count) / float64(len(rows)))
+		}
+		stats, err := statistics.BuildExpressionStats(e.ctx, int(e.opts[ast.AnalyzeOptNumBuckets]), int(e.opts[ast.AnalyzeOptNumTopN]),
+			item.hash, sampleItems, count, nullCount, tp, e.memTracker)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, stats)
+	}
+	return result, nil
+}
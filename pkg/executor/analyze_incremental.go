@@ -0,0 +1,210 @@
+// Copyright 2024 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package executor
+
+import (
+	"context"
+	"math"
+
+	"github.com/pingcap/errors"
+	"github.com/pingcap/tidb/pkg/domain"
+	"github.com/pingcap/tidb/pkg/kv"
+	"github.com/pingcap/tidb/pkg/parser/model"
+	"github.com/pingcap/tidb/pkg/parser/mysql"
+	"github.com/pingcap/tidb/pkg/statistics"
+	"github.com/pingcap/tidb/pkg/statistics/handle/globalstats"
+	"github.com/pingcap/tidb/pkg/types"
+	"github.com/pingcap/tidb/pkg/util/collate"
+	"github.com/pingcap/tidb/pkg/util/ranger"
+	"github.com/pingcap/tidb/pkg/util/sqlexec"
+	"github.com/tiancaiamao/gp"
+)
+
+// incrementalAnalyzeState is used by the incremental analyze. It assumes that the rows are appended to the
+// table with increasing handles, so only the rows whose handle is larger than the max handle analyzed last
+// time are sampled, and their stats are merged into the existing stats.
+type incrementalAnalyzeState struct {
+	// maxHandle is the max handle of the table at the snapshot of this analyze. The rows whose handle is
+	// larger than it are not analyzed, they are left to the next incremental analyze.
+	maxHandle int64
+	// merge indicates whether the stats of the sampled rows are merged into the existing stats. If it's false,
+	// all the rows up to maxHandle are analyzed.
+	merge         bool
+	lastMaxHandle int64
+	oldCount      int64
+	oldStats      *statistics.Table
+}
+
+// prepareIncrementalAnalyze returns nil if the max handle of the table can't be recorded, the table is
+// fully analyzed in the ordinary way in that case.
+func (e *AnalyzeColumnsExecV2) prepareIncrementalAnalyze(hasSpecialIndexes bool) (*incrementalAnalyzeState, error) {
+	// Only the int handles can be compared with the max handle. The unsigned ones are not supported for simplicity.
+	if !e.handleCols.IsInt() || mysql.HasUnsignedFlag(e.handleCols.GetCol(0).RetType.GetFlag()) {
+		e.appendIncrementalFallbackNote("the table doesn't have an int handle")
+		return nil, nil
+	}
+	state := &incrementalAnalyzeState{}
+	maxHandle, err := e.queryMaxHandle()
+	if err != nil {
+		return nil, err
+	}
+	state.maxHandle = maxHandle
+	lastMaxHandle, ok, err := e.queryLastMaxHandle()
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		e.appendIncrementalFallbackNote("the table hasn't been analyzed incrementally")
+		return state, nil
+	}
+	// The index NDVs of the virtual columns and the prefix columns are not collected by the sampling,
+	// they are calculated by scanning the whole indexes.
+	if hasSpecialIndexes {
+		e.appendIncrementalFallbackNote("the table has indexes on virtual columns or prefix columns")
+		return state, nil
+	}
+	oldStats, err := domain.GetDomain(e.ctx).StatsHandle().TableStatsFromStorage(e.tableInfo, e.TableID.GetStatisticsID(), true, 0)
+	if err != nil {
+		return nil, err
+	}
+	if oldStats == nil || !e.hasMergeableStats(oldStats) {
+		e.appendIncrementalFallbackNote("the existing stats can't be merged")
+		return state, nil
+	}
+	oldCount := int64(oldStats.GetAnalyzeRowCount())
+	// Since the last analyze, count grows by the inserted rows minus the deleted rows, and modify_count grows by
+	// all the inserted, deleted and updated rows. If the changes other than appending rows are more than the
+	// appended rows, the existing stats are too stale to be merged.
+	appended := e.baseCount - oldCount
+	if appended <= 0 || e.baseModifyCnt-appended > appended {
+		e.appendIncrementalFallbackNote("the deletes and updates dominate the changes")
+		return state, nil
+	}
+	state.merge = true
+	state.lastMaxHandle = lastMaxHandle
+	state.oldCount = oldCount
+	state.oldStats = oldStats
+	return state, nil
+}
+
+func (e *AnalyzeColumnsExecV2) appendIncrementalFallbackNote(reason string) {
+	name := e.DBName + "." + e.TableName
+	if e.PartitionName != "" {
+		name += "'s partition " + e.PartitionName
+	}
+	e.ctx.GetSessionVars().StmtCtx.AppendNote(errors.NewNoStackErrorf(
+		"Analyze table %s fully instead of incrementally, reason: %s", name, reason))
+}
+
+// queryMaxHandle returns the max handle of the table at the snapshot of the analyze.
+func (e *AnalyzeColumnsExecV2) queryMaxHandle() (int64, error) {
+	handleName := model.ExtraHandleName.O
+	if e.tableInfo.PKIsHandle {
+		handleName = e.tableInfo.GetPkColInfo().Name.O
+	}
+	sql, args := "select max(%n) from %n.%n", []any{handleName, e.DBName, e.TableName}
+	if e.PartitionName != "" {
+		sql += " partition(%n)"
+		args = append(args, e.PartitionName)
+	}
+	ctx := kv.WithInternalSourceType(context.Background(), kv.InternalTxnStats)
+	rows, _, err := e.ctx.GetRestrictedSQLExecutor().ExecRestrictedSQL(ctx,
+		[]sqlexec.OptionFuncAlias{sqlexec.ExecOptionWithSnapshot(e.snapshot)}, sql, args...)
+	if err != nil {
+		return 0, err
+	}
+	if len(rows) == 0 || rows[0].IsNull(0) {
+		// The table is empty, all the rows written later will be analyzed next time.
+		return math.MinInt64, nil
+	}
+	return rows[0].GetInt64(0), nil
+}
+
+// queryLastMaxHandle returns the max handle analyzed last time, ok is false if the last analyze isn't incremental.
+func (e *AnalyzeColumnsExecV2) queryLastMaxHandle() (maxHandle int64, ok bool, err error) {
+	ctx := kv.WithInternalSourceType(context.Background(), kv.InternalTxnStats)
+	rows, _, err := e.ctx.GetRestrictedSQLExecutor().ExecRestrictedSQL(ctx, nil,
+		"select max_handle from mysql.stats_incremental_analyze where table_id = %?", e.TableID.GetStatisticsID())
+	if err != nil || len(rows) == 0 {
+		return 0, false, err
+	}
+	return rows[0].GetInt64(0), true, nil
+}
+
+// hasMergeableStats checks whether all the columns and indexes to be analyzed have the version 2 stats with
+// the FMSketch, which is needed to merge the NDV.
+func (e *AnalyzeColumnsExecV2) hasMergeableStats(oldStats *statistics.Table) bool {
+	for _, col := range e.colsInfo {
+		if col.ID == model.ExtraHandleID {
+			continue
+		}
+		c := oldStats.GetCol(col.ID)
+		if c == nil || c.StatsVer != statistics.Version2 || c.FMSketch == nil {
+			return false
+		}
+	}
+	for _, idx := range e.indexes {
+		i := oldStats.GetIdx(idx.ID)
+		if i == nil || i.StatsVer != statistics.Version2 || i.FMSketch == nil {
+			return false
+		}
+	}
+	return true
+}
+
+// ranges returns the handle ranges to be sampled.
+func (s *incrementalAnalyzeState) ranges() []*ranger.Range {
+	low := types.NewIntDatum(math.MinInt64)
+	if s.merge {
+		low = types.NewIntDatum(s.lastMaxHandle)
+	}
+	return []*ranger.Range{{
+		LowVal:     []types.Datum{low},
+		LowExclude: s.merge,
+		HighVal:    []types.Datum{types.NewIntDatum(s.maxHandle)},
+		Collators:  collate.GetBinaryCollatorSlice(1),
+	}}
+}
+
+// mergeStats merges the stats of the sampled rows into the existing stats, it returns the row count of the
+// merged stats. The colLen stats in the front are the column stats and the rest are the index stats.
+func (s *incrementalAnalyzeState) mergeStats(
+	e *AnalyzeColumnsExecV2,
+	gp *gp.Pool,
+	count int64,
+	colLen int,
+	hists []*statistics.Histogram,
+	topNs []*statistics.TopN,
+	fmSketches []*statistics.FMSketch,
+) (int64, error) {
+	for i, hg := range hists {
+		// The stats of _tidb_rowid are discarded, and the virtual columns may have no stats.
+		if hg == nil || hg.ID == model.ExtraHandleID {
+			continue
+		}
+		isIndex := i >= colLen
+		oldHg, _, oldTopN, oldFms, ok := s.oldStats.GetStatsInfo(hg.ID, isIndex, true)
+		if !ok {
+			return 0, errors.Errorf("the existing stats of %d are missing", hg.ID)
+		}
+		merged, topN, err := globalstats.MergeIncrementalStats(e.ctx, gp, e.opts, isIndex,
+			oldHg, hg, oldTopN, topNs[i], oldFms, fmSketches[i])
+		if err != nil {
+			return 0, err
+		}
+		hists[i], topNs[i] = merged, topN
+	}
+	return s.oldCount + count, nil
+}
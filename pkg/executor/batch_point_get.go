@@ -60,6 +60,7 @@ type BatchPointGetExec struct {
 	txn            kv.Transaction
 	lock           bool
 	waitTime       int64
+	skipLocked     bool
 	inited         uint32
 	values         [][]byte
 	index          int
@@ -220,6 +221,8 @@ func (e *BatchPointGetExec) Next(ctx context.Context, req *chunk.Chunk) error {
 func (e *BatchPointGetExec) initialize(ctx context.Context) error {
 	var handleVals map[string][]byte
 	var indexKeys []kv.Key
+	// handleIdxKeys are the index keys of e.handles, they're only used by SKIP LOCKED.
+	var handleIdxKeys []kv.Key
 	var err error
 	batchGetter := e.batchGetter
 	rc := e.Ctx().GetSessionVars().IsPessimisticReadConsistency()
@@ -320,6 +323,9 @@ func (e *BatchPointGetExec) initialize(ctx context.Context) error {
 			if rc {
 				indexKeys = append(indexKeys, key)
 			}
+			if e.skipLocked {
+				handleIdxKeys = append(handleIdxKeys, key)
+			}
 		}
 
 		// The injection is used to simulate following scenario:
@@ -372,6 +378,7 @@ func (e *BatchPointGetExec) initialize(ctx context.Context) error {
 
 	keys := make([]kv.Key, 0, len(e.handles))
 	newHandles := make([]kv.Handle, 0, len(e.handles))
+	var rowIdxKeys []kv.Key
 	for i, handle := range e.handles {
 		tID := e.tblInfo.ID
 		if e.singlePartID != 0 {
@@ -387,12 +394,15 @@ func (e *BatchPointGetExec) initialize(ctx context.Context) error {
 		key := tablecodec.EncodeRowKeyWithHandle(tID, handle)
 		keys = append(keys, key)
 		newHandles = append(newHandles, handle)
+		if len(handleIdxKeys) > 0 {
+			rowIdxKeys = append(rowIdxKeys, handleIdxKeys[i])
+		}
 	}
 	e.handles = newHandles
 
 	var values map[string][]byte
 	// Lock keys (include exists and non-exists keys) before fetch all values for Repeatable Read Isolation.
-	if e.lock && !rc {
+	if e.lock && !rc && !e.skipLocked {
 		lockKeys := make([]kv.Key, len(keys)+len(indexKeys))
 		copy(lockKeys, keys)
 		copy(lockKeys[len(keys):], indexKeys)
@@ -406,6 +416,13 @@ func (e *BatchPointGetExec) initialize(ctx context.Context) error {
 	if err != nil {
 		return err
 	}
+	var skipped []bool
+	if e.lock && e.skipLocked {
+		skipped, err = e.lockRowsSkipLocked(ctx, keys, rowIdxKeys, indexKeys, values, rc)
+		if err != nil {
+			return err
+		}
+	}
 	handles := make([]kv.Handle, 0, len(values))
 	var existKeys []kv.Key
 	if e.lock && rc {
@@ -413,6 +430,9 @@ func (e *BatchPointGetExec) initialize(ctx context.Context) error {
 	}
 	e.values = make([][]byte, 0, len(values))
 	for i, key := range keys {
+		if len(skipped) > 0 && skipped[i] {
+			continue
+		}
 		val := values[string(key)]
 		if len(val) == 0 {
 			if e.idxInfo != nil && (!e.tblInfo.IsCommonHandle || !e.idxInfo.Primary) &&
@@ -449,7 +469,7 @@ func (e *BatchPointGetExec) initialize(ctx context.Context) error {
 		}
 	}
 	// Lock exists keys only for Read Committed Isolation.
-	if e.lock && rc {
+	if e.lock && rc && !e.skipLocked {
 		err = LockKeys(ctx, e.Ctx(), e.waitTime, existKeys...)
 		if err != nil {
 			return err
@@ -459,6 +479,43 @@ func (e *BatchPointGetExec) initialize(ctx context.Context) error {
 	return nil
 }
 
+// lockRowsSkipLocked locks the rows for SKIP LOCKED after their values are fetched, and returns whether
+// each row is skipped because it's locked by others. The keys of the rows that don't exist are locked
+// too in repeatable read isolation, but they are never skipped.
+func (e *BatchPointGetExec) lockRowsSkipLocked(ctx context.Context, keys, rowIdxKeys, indexKeys []kv.Key,
+	values map[string][]byte, rc bool) ([]bool, error) {
+	locker := newSkipLockedLocker(e.Ctx())
+	rows := make([][]kv.Key, len(keys))
+	lockedIdxKeys := make(map[string]struct{}, len(rowIdxKeys))
+	for i, key := range keys {
+		if rc && len(values[string(key)]) == 0 {
+			continue
+		}
+		rows[i] = []kv.Key{key}
+		if len(rowIdxKeys) > 0 {
+			rows[i] = append(rows[i], rowIdxKeys[i])
+			lockedIdxKeys[string(rowIdxKeys[i])] = struct{}{}
+		}
+	}
+	if !rc {
+		// indexKeys contains the index keys of the handles that don't exist in repeatable read isolation.
+		for _, key := range indexKeys {
+			if _, ok := lockedIdxKeys[string(key)]; !ok {
+				rows = append(rows, []kv.Key{key})
+			}
+		}
+	}
+	locked, err := locker.lockRows(ctx, rows)
+	if err != nil {
+		return nil, err
+	}
+	skipped := make([]bool, len(keys))
+	for i, key := range keys {
+		skipped[i] = len(values[string(key)]) > 0 && !locked[i]
+	}
+	return skipped, locker.finish()
+}
+
 // LockKeys locks the keys for pessimistic transaction.
 func LockKeys(ctx context.Context, sctx sessionctx.Context, lockWaitTime int64, keys ...kv.Key) error {
 	txnCtx := sctx.GetSessionVars().TxnCtx
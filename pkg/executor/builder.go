@@ -210,6 +210,8 @@ func (b *executorBuilder) build(p base.Plan) exec.Executor {
 		return b.buildUnlockStats(v)
 	case *plannercore.IndexAdvise:
 		return b.buildIndexAdvise(v)
+	case *plannercore.RecommendIndexPlan:
+		return b.buildRecommendIndex(v)
 	case *plannercore.PlanReplayer:
 		return b.buildPlanReplayer(v)
 	case *plannercore.PhysicalLimit:
@@ -244,6 +246,8 @@ func (b *executorBuilder) build(p base.Plan) exec.Executor {
 		return b.buildSimple(&v.Inner)
 	case *plannercore.Set:
 		return b.buildSet(v)
+	case *plannercore.CallProcedure:
+		return b.buildCallProcedure(v)
 	case *plannercore.SetConfig:
 		return b.buildSetConfig(v)
 	case *plannercore.PhysicalSort:
@@ -261,10 +265,20 @@ func (b *executorBuilder) build(p base.Plan) exec.Executor {
 	case *plannercore.PhysicalMergeJoin:
 		return b.buildMergeJoin(v)
 	case *plannercore.PhysicalIndexJoin:
+		if v.HashJoinAlternative != nil {
+			return b.buildAdaptiveJoin(v, func(outerExec exec.Executor) exec.Executor {
+				return b.buildIndexLookUpJoinWithOuter(v, outerExec)
+			})
+		}
 		return b.buildIndexLookUpJoin(v)
 	case *plannercore.PhysicalIndexMergeJoin:
 		return b.buildIndexLookUpMergeJoin(v)
 	case *plannercore.PhysicalIndexHashJoin:
+		if v.HashJoinAlternative != nil {
+			return b.buildAdaptiveJoin(&v.PhysicalIndexJoin, func(outerExec exec.Executor) exec.Executor {
+				return b.buildIndexNestedLoopHashJoinWithOuter(v, outerExec)
+			})
+		}
 		return b.buildIndexNestedLoopHashJoin(v)
 	case *plannercore.PhysicalSelection:
 		return b.buildSelection(v)
@@ -278,6 +292,8 @@ func (b *executorBuilder) build(p base.Plan) exec.Executor {
 		return b.buildMemTable(v)
 	case *plannercore.PhysicalTableDual:
 		return b.buildTableDual(v)
+	case *plannercore.PhysicalJSONTable:
+		return b.buildJSONTable(v)
 	case *plannercore.PhysicalApply:
 		return b.buildApply(v)
 	case *plannercore.PhysicalMaxOneRow:
@@ -312,6 +328,8 @@ func (b *executorBuilder) build(p base.Plan) exec.Executor {
 		return b.buildCTETableReader(v)
 	case *plannercore.CompactTable:
 		return b.buildCompactTable(v)
+	case *plannercore.OptimizePartition:
+		return b.buildOptimizePartition(v)
 	case *plannercore.AdminShowBDRRole:
 		return b.buildAdminShowBDRRole(v)
 	default:
@@ -507,7 +525,8 @@ func (b *executorBuilder) buildCheckTable(v *plannercore.CheckTable) exec.Execut
 			break
 		}
 	}
-	if b.ctx.GetSessionVars().FastCheckTable && noMVIndexOrPrefixIndex {
+	// The fast check compares the checksums of the whole table, so it's not used to check some partitions.
+	if b.ctx.GetSessionVars().FastCheckTable && noMVIndexOrPrefixIndex && len(v.PartitionNames) == 0 {
 		e := &FastCheckTableExec{
 			BaseExecutor: exec.NewBaseExecutor(b.ctx, v.Schema(), v.ID()),
 			dbName:       v.DBName,
@@ -541,6 +560,7 @@ func (b *executorBuilder) buildCheckTable(v *plannercore.CheckTable) exec.Execut
 		exitCh:       make(chan struct{}),
 		retCh:        make(chan error, len(readerExecs)),
 		checkIndex:   v.CheckIndex,
+		partitions:   v.PartitionNames,
 	}
 	return e
 }
@@ -881,6 +901,7 @@ func (b *executorBuilder) buildShow(v *plannercore.PhysicalShow) exec.Executor {
 		Extended:              v.Extended,
 		Extractor:             v.Extractor,
 		ImportJobID:           v.ImportJobID,
+		Procedure:             v.Procedure,
 	}
 	if e.Tp == ast.ShowMasterStatus || e.Tp == ast.ShowBinlogStatus {
 		// show master status need start ts.
@@ -939,6 +960,18 @@ func (b *executorBuilder) buildSet(v *plannercore.Set) exec.Executor {
 	return e
 }
 
+func (b *executorBuilder) buildCallProcedure(v *plannercore.CallProcedure) exec.Executor {
+	base := exec.NewBaseExecutor(b.ctx, v.Schema(), v.ID())
+	base.SetInitCap(chunk.ZeroCapacity)
+	return &CallExec{
+		BaseExecutor: base,
+		dbName:       v.DBName,
+		procName:     v.ProcName,
+		params:       v.Params,
+		args:         v.Args,
+	}
+}
+
 func (b *executorBuilder) buildSetConfig(v *plannercore.SetConfig) exec.Executor {
 	return &SetConfigExec{
 		BaseExecutor: exec.NewBaseExecutor(b.ctx, v.Schema(), v.ID()),
@@ -988,6 +1021,11 @@ func (b *executorBuilder) buildInsert(v *plannercore.Insert) exec.Executor {
 	if b.err != nil {
 		return nil
 	}
+	ivs.triggers, b.err = b.buildTableTriggers(ivs.Table)
+	if b.err != nil {
+		return nil
+	}
+	ivs.mviewLogs = buildTableMViewLogs(b.is, ivs.Table)
 
 	if v.IsReplace {
 		return b.buildReplace(ivs)
@@ -1051,6 +1089,10 @@ func (b *executorBuilder) buildLoadData(v *plannercore.LoadData) exec.Executor {
 		b.err = err
 		return nil
 	}
+	worker.triggers, b.err = b.buildTableTriggers(tbl)
+	if b.err != nil {
+		return nil
+	}
 
 	return &LoadDataExec{
 		BaseExecutor:   base,
@@ -1098,6 +1140,15 @@ func (b *executorBuilder) buildIndexAdvise(v *plannercore.IndexAdvise) exec.Exec
 	return e
 }
 
+func (b *executorBuilder) buildRecommendIndex(v *plannercore.RecommendIndexPlan) exec.Executor {
+	e := &RecommendIndexExec{
+		BaseExecutor: exec.NewBaseExecutor(b.ctx, v.Schema(), v.ID()),
+		Action:       v.Action,
+		SQL:          v.SQL,
+	}
+	return e
+}
+
 func (b *executorBuilder) buildPlanReplayer(v *plannercore.PlanReplayer) exec.Executor {
 	if v.Load {
 		e := &PlanReplayerLoadExec{
@@ -1515,17 +1566,28 @@ func extractUsedColumnsInJoinOtherCondition(expr expression.CNFExprs, leftColumn
 	return leftColumnIndex, rightColumnIndex
 }
 
-func (b *executorBuilder) buildHashJoinV2(v *plannercore.PhysicalHashJoin) exec.Executor {
-	leftExec := b.build(v.Children()[0])
-	if b.err != nil {
-		return nil
-	}
-
-	rightExec := b.build(v.Children()[1])
-	if b.err != nil {
-		return nil
+// buildJoinKeyConditionsOnJoinedSchema rebuilds the join key conditions so they can be evaluated on the joined
+// rows, the arguments of the join key conditions are resolved against the left and right child respectively.
+func buildJoinKeyConditionsOnJoinedSchema(ctx expression.BuildContext, conditions []*expression.ScalarFunction, leftColumnSize int) (expression.CNFExprs, error) {
+	ret := make(expression.CNFExprs, 0, len(conditions))
+	for _, cond := range conditions {
+		args := cond.GetArgs()
+		rightCol, ok := args[1].(*expression.Column)
+		if !ok {
+			return nil, errors.New("the right argument of the join key condition should be a column")
+		}
+		rightCol = rightCol.Clone().(*expression.Column)
+		rightCol.Index += leftColumnSize
+		newCond, err := expression.NewFunction(ctx, cond.FuncName.L, cond.GetStaticType(), args[0], rightCol)
+		if err != nil {
+			return nil, err
+		}
+		ret = append(ret, newCond)
 	}
+	return ret, nil
+}
 
+func (b *executorBuilder) buildHashJoinV2(v *plannercore.PhysicalHashJoin, leftExec, rightExec exec.Executor) exec.Executor {
 	e := &join.HashJoinV2Exec{
 		BaseExecutor:          exec.NewBaseExecutor(b.ctx, v.Schema(), v.ID(), leftExec, rightExec),
 		ProbeSideTupleFetcher: &join.ProbeSideTupleFetcherV2{},
@@ -1587,6 +1649,14 @@ func (b *executorBuilder) buildHashJoinV2(v *plannercore.PhysicalHashJoin) exec.
 			e.HashJoinCtxV2.ProbeFilter = v.LeftConditions
 		}
 	}
+	e.HashJoinCtxV2.RuntimeFilters = buildRootRuntimeFilters(v, buildSideExec, e.ProbeSideTupleFetcher.ProbeSideExec)
+	// null aware join only happens in (anti) left outer semi join and anti semi join, the left side is always
+	// the probe side, and the null aware keys are appended after the normal join keys
+	e.HashJoinCtxV2.IsNullAware = len(v.LeftNAJoinKeys) > 0
+	if e.HashJoinCtxV2.IsNullAware {
+		probeKeys = append(slices.Clone(probeKeys), v.LeftNAJoinKeys...)
+		buildKeys = append(slices.Clone(buildKeys), v.RightNAJoinKeys...)
+	}
 	probeKeyColIdx := make([]int, len(probeKeys))
 	buildKeyColIdx := make([]int, len(buildKeys))
 	for i := range buildKeys {
@@ -1610,9 +1680,24 @@ func (b *executorBuilder) buildHashJoinV2(v *plannercore.PhysicalHashJoin) exec.
 	e.LUsed = append(e.LUsed, childrenUsedSchema[0]...)
 	e.RUsed = make([]int, 0, len(childrenUsedSchema[1]))
 	e.RUsed = append(e.RUsed, childrenUsedSchema[1]...)
-	if v.OtherConditions != nil {
-		leftColumnSize := v.Children()[0].Schema().Len()
-		e.LUsedInOtherCondition, e.RUsedInOtherCondition = extractUsedColumnsInJoinOtherCondition(v.OtherConditions, leftColumnSize)
+	leftColumnSize := v.Children()[0].Schema().Len()
+	conditionsOnJoinedRows := v.OtherConditions
+	if e.HashJoinCtxV2.IsNullAware {
+		e.EqualCondition, b.err = buildJoinKeyConditionsOnJoinedSchema(b.ctx.GetExprCtx(), v.EqualConditions, leftColumnSize)
+		if b.err != nil {
+			return nil
+		}
+		e.NAEqualCondition, b.err = buildJoinKeyConditionsOnJoinedSchema(b.ctx.GetExprCtx(), v.NAEqualConditions, leftColumnSize)
+		if b.err != nil {
+			return nil
+		}
+		conditionsOnJoinedRows = make(expression.CNFExprs, 0, len(v.OtherConditions)+len(e.EqualCondition)+len(e.NAEqualCondition))
+		conditionsOnJoinedRows = append(conditionsOnJoinedRows, v.OtherConditions...)
+		conditionsOnJoinedRows = append(conditionsOnJoinedRows, e.EqualCondition...)
+		conditionsOnJoinedRows = append(conditionsOnJoinedRows, e.NAEqualCondition...)
+	}
+	if conditionsOnJoinedRows != nil {
+		e.LUsedInOtherCondition, e.RUsedInOtherCondition = extractUsedColumnsInJoinOtherCondition(conditionsOnJoinedRows, leftColumnSize)
 	}
 	// todo add partition hash join exec
 	executor_metrics.ExecutorCountHashJoinExec.Inc()
@@ -1671,10 +1756,51 @@ func (b *executorBuilder) buildHashJoinV2(v *plannercore.PhysicalHashJoin) exec.
 	return e
 }
 
-func (b *executorBuilder) buildHashJoin(v *plannercore.PhysicalHashJoin) exec.Executor {
-	if join.IsHashJoinV2Enabled() && v.CanUseHashJoinV2() {
-		return b.buildHashJoinV2(v)
+// buildRootRuntimeFilters builds the runtime filters of the hash join, and binds them to the table readers
+// on the probe side, which wait for the filters before sending requests.
+func buildRootRuntimeFilters(v *plannercore.PhysicalHashJoin, buildSideExec, probeSideExec exec.Executor) []*join.RuntimeFilter {
+	rfList := v.RootRuntimeFilters()
+	if len(rfList) == 0 {
+		return nil
+	}
+	result := make([]*join.RuntimeFilter, 0, len(rfList))
+	for _, rf := range rfList {
+		buildKeyIdx := buildSideExec.Schema().ColumnIndex(rf.SrcColumn())
+		target := findRuntimeFilterTarget(probeSideExec, rf.TargetNodeID())
+		if buildKeyIdx < 0 || target == nil {
+			continue
+		}
+		targetCol := rf.TargetColumn().Clone().(*expression.Column)
+		targetCol.Index = target.plans[0].Schema().ColumnIndex(targetCol)
+		target.waitRuntimeFilter = true
+		result = append(result, &join.RuntimeFilter{
+			ID:           rf.ID(),
+			Type:         rf.Type(),
+			BuildKeyIdx:  buildKeyIdx,
+			BuildKeyType: buildSideExec.RetFieldTypes()[buildKeyIdx],
+			TargetCol:    targetCol,
+			Target:       target,
+		})
+	}
+	return result
+}
+
+func findRuntimeFilterTarget(e exec.Executor, scanID int) *TableReaderExecutor {
+	if reader, ok := e.(*TableReaderExecutor); ok {
+		if len(reader.plans) > 0 && reader.plans[0].ID() == scanID {
+			return reader
+		}
+		return nil
 	}
+	for _, child := range e.AllChildren() {
+		if target := findRuntimeFilterTarget(child, scanID); target != nil {
+			return target
+		}
+	}
+	return nil
+}
+
+func (b *executorBuilder) buildHashJoin(v *plannercore.PhysicalHashJoin) exec.Executor {
 	leftExec := b.build(v.Children()[0])
 	if b.err != nil {
 		return nil
@@ -1684,6 +1810,14 @@ func (b *executorBuilder) buildHashJoin(v *plannercore.PhysicalHashJoin) exec.Ex
 	if b.err != nil {
 		return nil
 	}
+	return b.buildHashJoinWithChildren(v, leftExec, rightExec)
+}
+
+// buildHashJoinWithChildren builds the hash join on the executors of its children.
+func (b *executorBuilder) buildHashJoinWithChildren(v *plannercore.PhysicalHashJoin, leftExec, rightExec exec.Executor) exec.Executor {
+	if join.IsHashJoinV2Enabled() && v.CanUseHashJoinV2() {
+		return b.buildHashJoinV2(v, leftExec, rightExec)
+	}
 
 	e := &join.HashJoinV1Exec{
 		BaseExecutor:          exec.NewBaseExecutor(b.ctx, v.Schema(), v.ID(), leftExec, rightExec),
@@ -1748,6 +1882,7 @@ func (b *executorBuilder) buildHashJoin(v *plannercore.PhysicalHashJoin) exec.Ex
 			defaultValues = make([]types.Datum, buildSideExec.Schema().Len())
 		}
 	}
+	e.HashJoinCtxV1.RuntimeFilters = buildRootRuntimeFilters(v, buildSideExec, e.ProbeSideTupleFetcher.ProbeSideExec)
 	probeKeyColIdx := make([]int, len(probeKeys))
 	probeNAKeColIdx := make([]int, len(probeNAKeys))
 	buildKeyColIdx := make([]int, len(buildKeys))
@@ -2010,6 +2145,15 @@ func (b *executorBuilder) buildTableDual(v *plannercore.PhysicalTableDual) exec.
 	return e
 }
 
+func (b *executorBuilder) buildJSONTable(v *plannercore.PhysicalJSONTable) exec.Executor {
+	return &JSONTableExec{
+		BaseExecutor: exec.NewBaseExecutor(b.ctx, v.Schema(), v.ID()),
+		expr:         v.Expr,
+		path:         v.Path,
+		name:         v.Name,
+	}
+}
+
 // `getSnapshotTS` returns for-update-ts if in insert/update/delete/lock statement otherwise the isolation read ts
 // Please notice that in RC isolation, the above two ts are the same
 func (b *executorBuilder) getSnapshotTS() (ts uint64, err error) {
@@ -2224,6 +2368,10 @@ func (b *executorBuilder) buildMemTable(v *plannercore.PhysicalMemTable) exec.Ex
 			strings.ToLower(infoschema.TableCheckConstraints),
 			strings.ToLower(infoschema.TableTiDBCheckConstraints),
 			strings.ToLower(infoschema.TableKeywords),
+			strings.ToLower(infoschema.TableRoutines),
+			strings.ToLower(infoschema.TableEvents),
+			strings.ToLower(infoschema.TableTriggers),
+			strings.ToLower(infoschema.TableMaterializedViews),
 			strings.ToLower(infoschema.TableTiDBIndexUsage),
 			strings.ToLower(infoschema.ClusterTableTiDBIndexUsage):
 			memTracker := memory.NewTracker(v.ID(), -1)
@@ -2617,6 +2765,11 @@ func (b *executorBuilder) buildUpdate(v *plannercore.Update) exec.Executor {
 	if b.err != nil {
 		return nil
 	}
+	updateExec.triggers, b.err = b.buildTblID2TableTriggers(tblID2table)
+	if b.err != nil {
+		return nil
+	}
+	updateExec.mviewLogs = buildTblID2TableMViewLogs(b.is, tblID2table)
 	return updateExec
 }
 
@@ -2669,6 +2822,11 @@ func (b *executorBuilder) buildDelete(v *plannercore.Delete) exec.Executor {
 	if b.err != nil {
 		return nil
 	}
+	deleteExec.triggers, b.err = b.buildTblID2TableTriggers(tblID2table)
+	if b.err != nil {
+		return nil
+	}
+	deleteExec.mviewLogs = buildTblID2TableMViewLogs(b.is, tblID2table)
 	return deleteExec
 }
 
@@ -3155,6 +3313,11 @@ func (b *executorBuilder) buildIndexLookUpJoin(v *plannercore.PhysicalIndexJoin)
 	if b.err != nil {
 		return nil
 	}
+	return b.buildIndexLookUpJoinWithOuter(v, outerExec)
+}
+
+// buildIndexLookUpJoinWithOuter builds the index lookup join on the executor of its outer child.
+func (b *executorBuilder) buildIndexLookUpJoinWithOuter(v *plannercore.PhysicalIndexJoin, outerExec exec.Executor) exec.Executor {
 	outerTypes := exec.RetTypes(outerExec)
 	innerPlan := v.Children()[v.InnerChildIdx]
 	innerTypes := make([]*types.FieldType, innerPlan.Schema().Len())
@@ -3290,6 +3453,40 @@ func (b *executorBuilder) buildIndexLookUpJoin(v *plannercore.PhysicalIndexJoin)
 	return e
 }
 
+// buildAdaptiveJoin builds the index join and its hash join alternative on the same outer side, the
+// AdaptiveJoinExec chooses one of them after reading the outer rows.
+func (b *executorBuilder) buildAdaptiveJoin(v *plannercore.PhysicalIndexJoin, buildIndexJoin func(outerExec exec.Executor) exec.Executor) exec.Executor {
+	outerExec := b.build(v.Children()[1-v.InnerChildIdx])
+	if b.err != nil {
+		return nil
+	}
+	hj := v.HashJoinAlternative
+	buildSideExec := b.build(hj.Children()[hj.InnerChildIdx])
+	if b.err != nil {
+		return nil
+	}
+	outer := join.NewAdaptiveJoinOuterExec(b.ctx, outerExec)
+	indexJoin := buildIndexJoin(outer)
+	if b.err != nil {
+		return nil
+	}
+	leftExec, rightExec := buildSideExec, exec.Executor(outer)
+	if hj.InnerChildIdx == 1 {
+		leftExec, rightExec = outer, buildSideExec
+	}
+	hashJoin := b.buildHashJoinWithChildren(hj, leftExec, rightExec)
+	if b.err != nil {
+		return nil
+	}
+	return &join.AdaptiveJoinExec{
+		BaseExecutor: exec.NewBaseExecutor(b.ctx, v.Schema(), v.ID()),
+		Threshold:    b.ctx.GetSessionVars().AdaptiveJoinThreshold,
+		Outer:        outer,
+		IndexJoin:    indexJoin,
+		HashJoin:     hashJoin,
+	}
+}
+
 func (b *executorBuilder) buildIndexLookUpMergeJoin(v *plannercore.PhysicalIndexMergeJoin) exec.Executor {
 	outerExec := b.build(v.Children()[1-v.InnerChildIdx])
 	if b.err != nil {
@@ -3389,7 +3586,16 @@ func (b *executorBuilder) buildIndexLookUpMergeJoin(v *plannercore.PhysicalIndex
 }
 
 func (b *executorBuilder) buildIndexNestedLoopHashJoin(v *plannercore.PhysicalIndexHashJoin) exec.Executor {
-	joinExec := b.buildIndexLookUpJoin(&(v.PhysicalIndexJoin))
+	outerExec := b.build(v.Children()[1-v.InnerChildIdx])
+	if b.err != nil {
+		return nil
+	}
+	return b.buildIndexNestedLoopHashJoinWithOuter(v, outerExec)
+}
+
+// buildIndexNestedLoopHashJoinWithOuter builds the index nested loop hash join on the executor of its outer child.
+func (b *executorBuilder) buildIndexNestedLoopHashJoinWithOuter(v *plannercore.PhysicalIndexHashJoin, outerExec exec.Executor) exec.Executor {
+	joinExec := b.buildIndexLookUpJoinWithOuter(&(v.PhysicalIndexJoin), outerExec)
 	if b.err != nil {
 		return nil
 	}
@@ -5178,6 +5384,7 @@ func (b *executorBuilder) buildBatchPointGet(plan *plannercore.BatchPointGetPlan
 		desc:               plan.Desc,
 		lock:               plan.Lock,
 		waitTime:           plan.LockWaitTime,
+		skipLocked:         plan.SkipLocked,
 		columns:            plan.Columns,
 		handles:            handles,
 		idxVals:            plan.IndexValues,
@@ -5227,6 +5434,7 @@ func (b *executorBuilder) buildBatchPointGet(plan *plannercore.BatchPointGetPlan
 		// Temporary table should not do any lock operations
 		e.lock = false
 		e.waitTime = 0
+		e.skipLocked = false
 	}
 
 	if e.lock {
@@ -5608,6 +5816,25 @@ func (b *executorBuilder) buildCompactTable(v *plannercore.CompactTable) exec.Ex
 	}
 }
 
+func (b *executorBuilder) buildOptimizePartition(v *plannercore.OptimizePartition) exec.Executor {
+	children := make([]exec.Executor, 0, 2)
+	if v.Compact != nil {
+		compactExec := b.buildCompactTable(v.Compact)
+		if b.err != nil {
+			return nil
+		}
+		children = append(children, compactExec)
+	}
+	analyzeExec := b.buildAnalyze(v.Analyze)
+	if b.err != nil {
+		return nil
+	}
+	children = append(children, analyzeExec)
+	return &OptimizePartitionExec{
+		BaseExecutor: exec.NewBaseExecutor(b.ctx, v.Schema(), v.ID(), children...),
+	}
+}
+
 func (b *executorBuilder) buildAdminShowBDRRole(v *plannercore.AdminShowBDRRole) exec.Executor {
 	return &AdminShowBDRRoleExec{BaseExecutor: exec.NewBaseExecutor(b.ctx, v.Schema(), v.ID())}
 }
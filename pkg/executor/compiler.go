@@ -270,6 +270,11 @@ func getStmtDbLabel(stmtNode ast.StmtNode) map[string]struct{} {
 			dbLabel := x.ViewName.Schema.O
 			dbLabelSet[dbLabel] = struct{}{}
 		}
+	case *ast.CreateMaterializedViewStmt:
+		if x.ViewName != nil {
+			dbLabel := x.ViewName.Schema.O
+			dbLabelSet[dbLabel] = struct{}{}
+		}
 	case *ast.RenameTableStmt:
 		tables := x.TableToTables
 		for _, table := range tables {
@@ -0,0 +1,617 @@
+// Copyright 2024 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package executor
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/pingcap/errors"
+	"github.com/pingcap/tidb/pkg/domain"
+	"github.com/pingcap/tidb/pkg/eventscheduler"
+	"github.com/pingcap/tidb/pkg/expression"
+	"github.com/pingcap/tidb/pkg/infoschema"
+	"github.com/pingcap/tidb/pkg/kv"
+	"github.com/pingcap/tidb/pkg/parser"
+	"github.com/pingcap/tidb/pkg/parser/ast"
+	"github.com/pingcap/tidb/pkg/parser/auth"
+	"github.com/pingcap/tidb/pkg/parser/charset"
+	"github.com/pingcap/tidb/pkg/parser/model"
+	"github.com/pingcap/tidb/pkg/parser/mysql"
+	"github.com/pingcap/tidb/pkg/parser/terror"
+	"github.com/pingcap/tidb/pkg/privilege"
+	"github.com/pingcap/tidb/pkg/sessionctx"
+	"github.com/pingcap/tidb/pkg/sessionctx/variable"
+	"github.com/pingcap/tidb/pkg/timer/api"
+	"github.com/pingcap/tidb/pkg/types"
+	"github.com/pingcap/tidb/pkg/util/dbterror"
+	"github.com/pingcap/tidb/pkg/util/dbterror/exeerrors"
+	"github.com/pingcap/tidb/pkg/util/sqlescape"
+)
+
+const (
+	// maxEventIntervalSeconds and maxEventIntervalMonths limit the interval of a recurring event to 100 years.
+	maxEventIntervalSeconds = 100 * 365 * 24 * 3600
+	maxEventIntervalMonths  = 100 * 12
+)
+
+func init() {
+	eventscheduler.ExecuteEvent = executeEvent
+}
+
+// eventTimerClient returns the timer client of the event scheduler.
+func eventTimerClient(sctx sessionctx.Context) (api.TimerClient, error) {
+	scheduler := domain.GetDomain(sctx).EventScheduler()
+	if scheduler == nil {
+		return nil, errors.New("event scheduler is not started")
+	}
+	return scheduler.Client(), nil
+}
+
+func (e *DDLExec) executeCreateEvent(ctx context.Context, s *ast.CreateEventStmt) error {
+	sessVars := e.Ctx().GetSessionVars()
+	name := s.EventName
+	schema, ok := e.is.SchemaByName(name.Schema)
+	if !ok {
+		return infoschema.ErrDatabaseNotExists.GenWithStackByArgs(name.Schema.O)
+	}
+	if err := checkEventBody(s.Body); err != nil {
+		return err
+	}
+	cli, err := eventTimerClient(e.Ctx())
+	if err != nil {
+		return err
+	}
+	events, err := eventscheduler.LoadEvents(ctx, cli, name.Schema.L, name.Name.L)
+	if err != nil {
+		return err
+	}
+	if len(events) > 0 {
+		err = exeerrors.ErrEventAlreadyExists.GenWithStackByArgs(name.Name.O)
+		if s.IfNotExists {
+			sessVars.StmtCtx.AppendNote(err)
+			return nil
+		}
+		return err
+	}
+
+	now := time.Now()
+	info := &eventscheduler.EventInfo{
+		Schema:      schema.Name.O,
+		Name:        name.Name.O,
+		Body:        s.Body.Text(),
+		DBCollation: schema.Collate,
+		Preserve:    s.Completion == ast.EventCompletionPreserve,
+		Status:      eventStatus(s.Status),
+		Created:     now,
+		LastAltered: now,
+	}
+	if info.Definer, err = eventDefinerOfStmt(sessVars, s.Definer); err != nil {
+		return err
+	}
+	if s.Comment != nil {
+		info.Comment = *s.Comment
+	}
+	if err := setEventCreationContext(ctx, sessVars, info); err != nil {
+		return err
+	}
+	if err := setEventSchedule(e.Ctx(), s.Schedule, info, now); err != nil {
+		return err
+	}
+	if eventExpired(info, now) {
+		if !info.Preserve {
+			sessVars.StmtCtx.AppendNote(exeerrors.ErrEventCannotCreateInThePast.GenWithStackByArgs())
+			return nil
+		}
+		info.Status = eventscheduler.StatusDisabled
+		sessVars.StmtCtx.AppendNote(exeerrors.ErrEventExecTimeInThePast.GenWithStackByArgs())
+	}
+	return createEventTimer(ctx, cli, info)
+}
+
+func (e *DDLExec) executeAlterEvent(ctx context.Context, s *ast.AlterEventStmt) error {
+	sessVars := e.Ctx().GetSessionVars()
+	name := s.EventName
+	cli, err := eventTimerClient(e.Ctx())
+	if err != nil {
+		return err
+	}
+	events, err := eventscheduler.LoadEvents(ctx, cli, name.Schema.L, name.Name.L)
+	if err != nil {
+		return err
+	}
+	if len(events) == 0 {
+		return exeerrors.ErrEventDoesNotExist.GenWithStackByArgs(name.Name.O)
+	}
+
+	ev := events[0]
+	now := time.Now()
+	info := ev.EventInfo
+	info.LastAltered = now
+	if s.Definer != nil {
+		if info.Definer, err = eventDefinerOfStmt(sessVars, s.Definer); err != nil {
+			return err
+		}
+	}
+	if s.Completion != ast.EventCompletionDefault {
+		info.Preserve = s.Completion == ast.EventCompletionPreserve
+	}
+	if s.Status != ast.EventStatusDefault {
+		info.Status = eventStatus(s.Status)
+	}
+	if s.Comment != nil {
+		info.Comment = *s.Comment
+	}
+	if s.Body != nil {
+		if err := checkEventBody(s.Body); err != nil {
+			return err
+		}
+		info.Body = s.Body.Text()
+	}
+	if s.Schedule != nil || s.Body != nil {
+		// The schedule is evaluated in the time zone of the session, and the body is executed
+		// with the sql mode of the session, so they are saved again.
+		if err := setEventCreationContext(ctx, sessVars, &info); err != nil {
+			return err
+		}
+	}
+	if s.Schedule != nil {
+		if err := setEventSchedule(e.Ctx(), s.Schedule, &info, now); err != nil {
+			return err
+		}
+		if eventExpired(&info, now) {
+			if !info.Preserve {
+				return exeerrors.ErrEventCannotAlterInThePast.GenWithStackByArgs()
+			}
+			info.Status = eventscheduler.StatusDisabled
+			sessVars.StmtCtx.AppendNote(exeerrors.ErrEventExecTimeInThePast.GenWithStackByArgs())
+		}
+	}
+
+	if s.RenameTo != nil {
+		schema, ok := e.is.SchemaByName(s.RenameTo.Schema)
+		if !ok {
+			return infoschema.ErrDatabaseNotExists.GenWithStackByArgs(s.RenameTo.Schema.O)
+		}
+		if schema.Name.L == name.Schema.L && s.RenameTo.Name.L == name.Name.L {
+			return exeerrors.ErrEventSameName.GenWithStackByArgs()
+		}
+		info.Schema, info.Name, info.DBCollation = schema.Name.O, s.RenameTo.Name.O, schema.Collate
+		if err := createEventTimer(ctx, cli, &info); err != nil {
+			return err
+		}
+		_, err = cli.DeleteTimer(ctx, ev.TimerID)
+		return err
+	}
+
+	policyType, policyExpr, err := eventSchedPolicy(&info)
+	if err != nil {
+		return err
+	}
+	return eventscheduler.UpdateEvent(ctx, cli, ev.TimerID, &info, policyType, policyExpr)
+}
+
+func (e *DDLExec) executeDropEvent(ctx context.Context, s *ast.DropEventStmt) error {
+	name := s.EventName
+	cli, err := eventTimerClient(e.Ctx())
+	if err != nil {
+		return err
+	}
+	events, err := eventscheduler.LoadEvents(ctx, cli, name.Schema.L, name.Name.L)
+	if err != nil {
+		return err
+	}
+	if len(events) == 0 {
+		err = exeerrors.ErrEventDoesNotExist.GenWithStackByArgs(name.Name.O)
+		if s.IfExists {
+			e.Ctx().GetSessionVars().StmtCtx.AppendNote(err)
+			return nil
+		}
+		return err
+	}
+	_, err = cli.DeleteTimer(ctx, events[0].TimerID)
+	return err
+}
+
+// dropSchemaEvents deletes the events of a dropped database.
+func dropSchemaEvents(sctx sessionctx.Context, dbName string) error {
+	if domain.GetDomain(sctx).EventScheduler() == nil {
+		return nil
+	}
+	cli, err := eventTimerClient(sctx)
+	if err != nil {
+		return err
+	}
+	ctx := context.Background()
+	events, err := eventscheduler.LoadEvents(ctx, cli, dbName, "")
+	if err != nil {
+		return err
+	}
+	for _, ev := range events {
+		if _, err := cli.DeleteTimer(ctx, ev.TimerID); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func createEventTimer(ctx context.Context, cli api.TimerClient, info *eventscheduler.EventInfo) error {
+	policyType, policyExpr, err := eventSchedPolicy(info)
+	if err != nil {
+		return err
+	}
+	spec, err := eventscheduler.NewTimerSpec(info, policyType, policyExpr)
+	if err != nil {
+		return err
+	}
+	_, err = cli.CreateTimer(ctx, spec)
+	if errors.ErrorEqual(err, api.ErrTimerExists) || kv.ErrKeyExists.Equal(err) {
+		return exeerrors.ErrEventAlreadyExists.GenWithStackByArgs(info.Name)
+	}
+	return err
+}
+
+func eventStatus(status ast.EventStatusType) string {
+	switch status {
+	case ast.EventStatusDisable:
+		return eventscheduler.StatusDisabled
+	case ast.EventStatusDisableOnSlave:
+		return eventscheduler.StatusSlavesideDisabled
+	}
+	return eventscheduler.StatusEnabled
+}
+
+// setEventCreationContext saves the session context which is used to evaluate the schedule and execute the body.
+func setEventCreationContext(ctx context.Context, sessVars *variable.SessionVars, info *eventscheduler.EventInfo) error {
+	timeZone, err := sessVars.GetSessionOrGlobalSystemVar(ctx, variable.TimeZone)
+	if err != nil {
+		return err
+	}
+	csClient, err := sessVars.GetSessionOrGlobalSystemVar(ctx, variable.CharacterSetClient)
+	if err != nil {
+		return err
+	}
+	_, collation := sessVars.GetCharsetInfo()
+	info.TimeZone = timeZone
+	info.SQLMode = sqlModeString(sessVars.SQLMode)
+	info.CharsetClient = csClient
+	info.CollationConnection = collation
+	return nil
+}
+
+// setEventSchedule evaluates the ON SCHEDULE clause and sets the schedule of the event.
+func setEventSchedule(sctx sessionctx.Context, sched *ast.EventSchedule, info *eventscheduler.EventInfo, now time.Time) (err error) {
+	info.ExecuteAt, info.Starts, info.Ends = time.Time{}, time.Time{}, time.Time{}
+	info.IntervalValue, info.IntervalField = "", ""
+	if sched.At != nil {
+		info.ExecuteAt, err = evalEventTime(sctx, sched.At, "AT")
+		return err
+	}
+
+	switch sched.Unit {
+	case ast.TimeUnitMicrosecond, ast.TimeUnitSecondMicrosecond, ast.TimeUnitMinuteMicrosecond,
+		ast.TimeUnitHourMicrosecond, ast.TimeUnitDayMicrosecond:
+		return dbterror.ErrNotSupportedYet.GenWithStackByArgs("MICROSECOND")
+	}
+	d, err := expression.EvalSimpleAst(sctx.GetExprCtx(), sched.Every)
+	if err != nil {
+		return err
+	}
+	if d.IsNull() {
+		return exeerrors.ErrEventIntervalNotPositiveOrTooBig.GenWithStackByArgs()
+	}
+	if info.IntervalValue, err = d.ToString(); err != nil {
+		return err
+	}
+	info.IntervalField = sched.Unit.String()
+	if _, _, err = eventInterval(info); err != nil {
+		return err
+	}
+
+	info.Starts = now.In(sctx.GetSessionVars().Location()).Truncate(time.Second)
+	if sched.Starts != nil {
+		if info.Starts, err = evalEventTime(sctx, sched.Starts, "STARTS"); err != nil {
+			return err
+		}
+	}
+	if sched.Ends != nil {
+		if info.Ends, err = evalEventTime(sctx, sched.Ends, "ENDS"); err != nil {
+			return err
+		}
+		if !info.Ends.After(info.Starts) {
+			return exeerrors.ErrEventEndsBeforeStarts.GenWithStackByArgs()
+		}
+	}
+	return nil
+}
+
+// evalEventTime evaluates a time in the ON SCHEDULE clause in the time zone of the session.
+func evalEventTime(sctx sessionctx.Context, expr ast.ExprNode, clause string) (time.Time, error) {
+	d, err := expression.EvalSimpleAst(sctx.GetExprCtx(), expr)
+	if err != nil {
+		return time.Time{}, err
+	}
+	if d.IsNull() {
+		return time.Time{}, types.ErrWrongValue.GenWithStackByArgs(clause, "NULL")
+	}
+	sessVars := sctx.GetSessionVars()
+	str, _ := d.ToString()
+	d, err = d.ConvertTo(sessVars.StmtCtx.TypeCtx(), types.NewFieldType(mysql.TypeDatetime))
+	if err != nil || d.IsNull() || d.GetMysqlTime().IsZero() {
+		return time.Time{}, types.ErrWrongValue.GenWithStackByArgs(clause, str)
+	}
+	t, err := d.GetMysqlTime().GoTime(sessVars.Location())
+	if err != nil {
+		return time.Time{}, err
+	}
+	return t.Truncate(time.Second), nil
+}
+
+// eventInterval converts the interval of a recurring event to seconds or months.
+func eventInterval(info *eventscheduler.EventInfo) (seconds, months int64, err error) {
+	y, m, d, n, _, err := types.ParseDurationValue(info.IntervalField, info.IntervalValue)
+	if err != nil {
+		return 0, 0, exeerrors.ErrEventIntervalNotPositiveOrTooBig.GenWithStackByArgs()
+	}
+	months = y*12 + m
+	seconds = d*24*3600 + n/int64(time.Second)
+	if months < 0 || seconds < 0 || months+seconds == 0 ||
+		months > maxEventIntervalMonths || seconds > maxEventIntervalSeconds {
+		return 0, 0, exeerrors.ErrEventIntervalNotPositiveOrTooBig.GenWithStackByArgs()
+	}
+	return seconds, months, nil
+}
+
+// eventSchedPolicy builds the schedule policy of the event's timer.
+func eventSchedPolicy(info *eventscheduler.EventInfo) (api.SchedPolicyType, string, error) {
+	if info.IsOneTime() {
+		return api.SchedEventOnce, info.ExecuteAt.UTC().Format(time.RFC3339), nil
+	}
+	seconds, months, err := eventInterval(info)
+	if err != nil {
+		return "", "", err
+	}
+	expr := fmt.Sprintf("%d SECOND", seconds)
+	if months > 0 {
+		expr = fmt.Sprintf("%d MONTH", months)
+	}
+	expr += " STARTS " + info.Starts.UTC().Format(time.RFC3339)
+	if !info.Ends.IsZero() {
+		expr += " ENDS " + info.Ends.UTC().Format(time.RFC3339)
+	}
+	return api.SchedEventEvery, expr, nil
+}
+
+// eventExpired checks whether the event will not be executed any more.
+func eventExpired(info *eventscheduler.EventInfo, now time.Time) bool {
+	if info.IsOneTime() {
+		return !info.ExecuteAt.After(now)
+	}
+	return !info.Ends.IsZero() && !info.Ends.After(now)
+}
+
+// eventIsVisible checks whether the current user can see the events in the database.
+func eventIsVisible(sctx sessionctx.Context, db string) bool {
+	checker := privilege.GetPrivilegeManager(sctx)
+	if checker == nil || sctx.GetSessionVars().User == nil {
+		return true
+	}
+	return checker.RequestVerification(sctx.GetSessionVars().ActiveRoles, db, "", "", mysql.EventPriv)
+}
+
+// eventTimeDatum converts a time of the event to a datetime datum, a zero time is converted to NULL.
+func eventTimeDatum(t time.Time, loc *time.Location) types.Datum {
+	if t.IsZero() {
+		return types.NewDatum(nil)
+	}
+	if loc != nil {
+		t = t.In(loc)
+	}
+	return types.NewTimeDatum(types.NewTime(types.FromGoTime(t), mysql.TypeDatetime, 0))
+}
+
+// eventTimeString formats a time of the event as a datetime literal.
+func eventTimeString(t time.Time) string {
+	return types.NewTime(types.FromGoTime(t), mysql.TypeDatetime, 0).String()
+}
+
+// eventScheduleDatums returns the EXECUTE_AT, INTERVAL_VALUE, INTERVAL_FIELD, STARTS and ENDS of the event.
+// The times are shown in the time zone that the event was created with.
+func eventScheduleDatums(ev *eventscheduler.Event) []types.Datum {
+	if ev.IsOneTime() {
+		return []types.Datum{eventTimeDatum(ev.ExecuteAt, nil), {}, {}, {}, {}}
+	}
+	return []types.Datum{
+		{},
+		types.NewStringDatum(ev.IntervalValue),
+		types.NewStringDatum(ev.IntervalField),
+		eventTimeDatum(ev.Starts, nil),
+		eventTimeDatum(ev.Ends, nil),
+	}
+}
+
+// eventType returns the type of the event shown in SHOW EVENTS and information_schema.EVENTS.
+func eventType(ev *eventscheduler.Event) string {
+	if ev.IsOneTime() {
+		return "ONE TIME"
+	}
+	return "RECURRING"
+}
+
+// eventCreateStmt returns the CREATE EVENT statement shown in SHOW CREATE EVENT. The times are shown
+// in the time zone that the event was created with.
+func eventCreateStmt(ev *eventscheduler.Event) string {
+	var sb strings.Builder
+	sb.WriteString("CREATE")
+	if user, host, ok := strings.Cut(ev.Definer, "@"); ok {
+		sqlescape.MustFormatSQL(&sb, " DEFINER=%n@%n", user, host)
+	}
+	sqlescape.MustFormatSQL(&sb, " EVENT %n ON SCHEDULE ", ev.Name)
+	if ev.IsOneTime() {
+		sqlescape.MustFormatSQL(&sb, "AT %?", eventTimeString(ev.ExecuteAt))
+	} else {
+		// The compound intervals like '1:30' MINUTE_SECOND are quoted.
+		if strings.Contains(ev.IntervalField, "_") {
+			sqlescape.MustFormatSQL(&sb, "EVERY %? ", ev.IntervalValue)
+		} else {
+			sb.WriteString("EVERY " + ev.IntervalValue + " ")
+		}
+		sb.WriteString(ev.IntervalField)
+		sqlescape.MustFormatSQL(&sb, " STARTS %?", eventTimeString(ev.Starts))
+		if !ev.Ends.IsZero() {
+			sqlescape.MustFormatSQL(&sb, " ENDS %?", eventTimeString(ev.Ends))
+		}
+	}
+	if ev.Preserve {
+		sb.WriteString(" ON COMPLETION PRESERVE")
+	} else {
+		sb.WriteString(" ON COMPLETION NOT PRESERVE")
+	}
+	switch ev.Status {
+	case eventscheduler.StatusDisabled:
+		sb.WriteString(" DISABLE")
+	case eventscheduler.StatusSlavesideDisabled:
+		sb.WriteString(" DISABLE ON SLAVE")
+	default:
+		sb.WriteString(" ENABLE")
+	}
+	if ev.Comment != "" {
+		sqlescape.MustFormatSQL(&sb, " COMMENT %?", ev.Comment)
+	}
+	sb.WriteString(" DO ")
+	sb.WriteString(ev.Body)
+	return sb.String()
+}
+
+// checkEventBody checks the body of an event before it's saved.
+func checkEventBody(body ast.StmtNode) error {
+	c := &procedureChecker{event: true}
+	return c.checkStmt(body)
+}
+
+// checkEventStmt checks whether the statement is allowed in an event body.
+func (c *procedureChecker) checkEventStmt(stmt ast.StmtNode) error {
+	if !c.event {
+		return nil
+	}
+	switch stmt.(type) {
+	case *ast.CreateEventStmt, *ast.AlterEventStmt, *ast.DropEventStmt:
+		return exeerrors.ErrEventRecursionForbidden.GenWithStackByArgs()
+	}
+	return nil
+}
+
+// executeEvent executes the body of an event with a session of the event scheduler. The body is
+// executed in the schema of the event, with the privileges of the definer and the sql mode and
+// time zone that the event was created with.
+func executeEvent(ctx context.Context, sctx sessionctx.Context, ev *eventscheduler.EventInfo) error {
+	sessVars := sctx.GetSessionVars()
+	// The body is never executed without the privilege checks of a definer.
+	definer := routineDefiner(ev.Definer)
+	if definer == nil {
+		return exeerrors.ErrMalformedDefiner.GenWithStackByArgs()
+	}
+	body, sqlMode, err := parseEventBody(sessVars, ev)
+	if err != nil {
+		return err
+	}
+	origTimeZone, err := sessVars.GetSessionOrGlobalSystemVar(ctx, variable.TimeZone)
+	if err != nil {
+		return err
+	}
+	if err := sessVars.SetSystemVar(variable.TimeZone, ev.TimeZone); err != nil {
+		return err
+	}
+	procCtx := &procedureContext{
+		sctx:     sctx,
+		dbName:   model.NewCIStr(ev.Schema),
+		procName: model.NewCIStr(ev.Name),
+	}
+	restorePrivileges, err := bindDefinerPrivileges(sctx, definer)
+	if err != nil {
+		return err
+	}
+	origProcCtx, origDB, origSQLMode := sessVars.ProcedureContext, sessVars.CurrentDB, sessVars.SQLMode
+	sessVars.ProcedureContext = procCtx
+	sessVars.CurrentDB = ev.Schema
+	sessVars.SQLMode = sqlMode
+	defer func() {
+		// The transaction which is started but not committed by the event is rolled back.
+		sctx.RollbackTxn(ctx)
+		sessVars.SetInTxn(false)
+		sessVars.ProcedureContext = origProcCtx
+		sessVars.CurrentDB = origDB
+		sessVars.SQLMode = origSQLMode
+		restorePrivileges()
+		terror.Log(sessVars.SetSystemVar(variable.TimeZone, origTimeZone))
+	}()
+
+	ctx = kv.WithInternalSourceType(ctx, kv.InternalTxnOthers)
+	err = procCtx.execStmt(ctx, body)
+	if unhandled, ok := err.(*procedureUnhandledError); ok {
+		err = unhandled.err
+	}
+	return err
+}
+
+// parseEventBody parses the body of the event with the sql mode and charset that the event was created with.
+func parseEventBody(sessVars *variable.SessionVars, ev *eventscheduler.EventInfo) (ast.StmtNode, mysql.SQLMode, error) {
+	sqlMode, err := mysql.GetSQLMode(ev.SQLMode)
+	if err != nil {
+		return nil, 0, err
+	}
+	// The body may contain compound statements which can only be parsed in a CREATE statement.
+	sql := "CREATE EVENT " + sqlescape.MustEscapeSQL("%n", ev.Name) + " ON SCHEDULE AT '2000-01-01 00:00:00' DO " + ev.Body
+	p := parser.New()
+	p.SetParserConfig(sessVars.BuildParserConfig())
+	p.SetSQLMode(sqlMode)
+	var cs string
+	if coll, err := charset.GetCollationByName(ev.CollationConnection); err == nil {
+		cs = coll.CharsetName
+	}
+	stmt, err := p.ParseOneStmt(sql, cs, ev.CollationConnection)
+	if err != nil {
+		return nil, 0, err
+	}
+	s, ok := stmt.(*ast.CreateEventStmt)
+	if !ok {
+		return nil, 0, errors.Errorf("invalid definition of event %s", ev.Name)
+	}
+	return s.Body, sqlMode, nil
+}
+
+// eventDefinerOfStmt returns the definer of the event to store. The current user is the definer if
+// it's not specified, and an event can't be created without a definer.
+func eventDefinerOfStmt(sessVars *variable.SessionVars, definer *auth.UserIdentity) (string, error) {
+	if definer != nil && !definer.CurrentUser {
+		return definer.Username + "@" + definer.Hostname, nil
+	}
+	if user := sessVars.User; user != nil {
+		return user.AuthUsername + "@" + user.AuthHostname, nil
+	}
+	return "", exeerrors.ErrMalformedDefiner.GenWithStackByArgs()
+}
+
+// routineDefiner returns the user identity of the definer of an event or a procedure, it's nil if
+// the routine has no definer.
+func routineDefiner(definer string) *auth.UserIdentity {
+	user, host, ok := strings.Cut(definer, "@")
+	if !ok {
+		return nil
+	}
+	return &auth.UserIdentity{Username: user, Hostname: host, AuthUsername: user, AuthHostname: host}
+}
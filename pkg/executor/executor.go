@@ -15,6 +15,7 @@
 package executor
 
 import (
+	"bytes"
 	"cmp"
 	"context"
 	stderrors "errors"
@@ -64,6 +65,7 @@ import (
 	"github.com/pingcap/tidb/pkg/sessionctx/stmtctx"
 	"github.com/pingcap/tidb/pkg/sessionctx/variable"
 	"github.com/pingcap/tidb/pkg/sessiontxn"
+	storeerr "github.com/pingcap/tidb/pkg/store/driver/error"
 	"github.com/pingcap/tidb/pkg/table"
 	"github.com/pingcap/tidb/pkg/table/tables"
 	"github.com/pingcap/tidb/pkg/tablecodec"
@@ -848,6 +850,8 @@ type CheckTableExec struct {
 	exitCh     chan struct{}
 	retCh      chan error
 	checkIndex bool
+	// partitions is not empty if only these partitions are checked.
+	partitions []model.CIStr
 }
 
 // Open implements the Executor Open interface.
@@ -931,7 +935,11 @@ func (e *CheckTableExec) Next(ctx context.Context, _ *chunk.Chunk) error {
 		}
 		idxNames = append(idxNames, idx.Name.O)
 	}
-	greater, idxOffset, err := admin.CheckIndicesCount(e.Ctx(), e.dbName, e.table.Meta().Name.O, idxNames)
+	partitionNames := make([]string, 0, len(e.partitions))
+	for _, name := range e.partitions {
+		partitionNames = append(partitionNames, name.O)
+	}
+	greater, idxOffset, err := admin.CheckIndicesCount(e.Ctx(), e.dbName, e.table.Meta().Name.O, partitionNames, idxNames)
 	if err != nil {
 		// For admin check index statement, for speed up and compatibility, doesn't do below checks.
 		if e.checkIndex {
@@ -1017,6 +1025,9 @@ func (e *CheckTableExec) checkTableRecord(ctx context.Context, idxOffset int) er
 
 	info := e.table.Meta().GetPartitionInfo()
 	for _, def := range info.Definitions {
+		if len(e.partitions) > 0 && !slices.ContainsFunc(e.partitions, func(name model.CIStr) bool { return name.L == def.Name.L }) {
+			continue
+		}
 		pid := def.ID
 		partition := e.table.(table.PartitionedTable).GetPartition(pid)
 		idx := tables.NewIndex(def.ID, e.table.Meta(), idxInfo)
@@ -1122,6 +1133,12 @@ type SelectLockExec struct {
 	// due to issues with chunk handling between the TableReaderExecutor and the
 	// SelectReader result.
 	tblID2PhysTblIDColIdx map[int64]int
+
+	// childResult, childRowIdx and skipLocked are used by SKIP LOCKED, which locks the rows
+	// before returning them. childRowIdx is the index of the next row in childResult to lock.
+	childResult *chunk.Chunk
+	childRowIdx int
+	skipLocked  *skipLockedLocker
 }
 
 // Open implements the Executor Open interface.
@@ -1140,11 +1157,15 @@ func (e *SelectLockExec) Open(ctx context.Context) error {
 			}
 		}
 	}
+	e.childResult, e.childRowIdx, e.skipLocked = nil, 0, nil
 	return e.BaseExecutor.Open(ctx)
 }
 
 // Next implements the Executor Next interface.
 func (e *SelectLockExec) Next(ctx context.Context, req *chunk.Chunk) error {
+	if len(e.tblID2Handle) > 0 && plannercore.IsSelectSkipLockedLockType(e.Lock.LockType) {
+		return e.nextSkipLocked(ctx, req)
+	}
 	req.GrowAndReset(e.MaxChunkSize())
 	err := exec.Next(ctx, e.Children(0), req)
 	if err != nil {
@@ -1158,27 +1179,9 @@ func (e *SelectLockExec) Next(ctx context.Context, req *chunk.Chunk) error {
 	if req.NumRows() > 0 {
 		iter := chunk.NewIterator4Chunk(req)
 		for row := iter.Begin(); row != iter.End(); row = iter.Next() {
-			for tblID, cols := range e.tblID2Handle {
-				for _, col := range cols {
-					handle, err := col.BuildHandle(row)
-					if err != nil {
-						return err
-					}
-					physTblID := tblID
-					if physTblColIdx, ok := e.tblID2PhysTblIDColIdx[tblID]; ok {
-						physTblID = row.GetInt64(physTblColIdx)
-						if physTblID == 0 {
-							// select * from t1 left join t2 on t1.c = t2.c for update
-							// The join right side might be added NULL in left join
-							// In that case, physTblID is 0, so skip adding the lock.
-							//
-							// Note, we can't distinguish whether it's the left join case,
-							// or a bug that TiKV return without correct physical ID column.
-							continue
-						}
-					}
-					e.keys = append(e.keys, tablecodec.EncodeRowKeyWithHandle(physTblID, handle))
-				}
+			e.keys, err = e.appendRowKeys(e.keys, row)
+			if err != nil {
+				return err
 			}
 		}
 		return nil
@@ -1200,6 +1203,92 @@ func (e *SelectLockExec) Next(ctx context.Context, req *chunk.Chunk) error {
 	return doLockKeys(ctx, e.Ctx(), lockCtx, e.keys...)
 }
 
+// nextSkipLocked returns the rows which are locked successfully. Unlike the other lock types,
+// the rows are locked before they are returned, and the rows locked by others are skipped.
+// At most the required rows of req are locked in each call, so a limit above the lock stops
+// locking rows once it's met.
+func (e *SelectLockExec) nextSkipLocked(ctx context.Context, req *chunk.Chunk) error {
+	req.Reset()
+	if e.childResult == nil {
+		e.childResult = exec.TryNewCacheChunk(e.Children(0))
+		e.skipLocked = newSkipLockedLocker(e.Ctx())
+		for id := range e.tblID2Handle {
+			e.UpdateDeltaForTableID(id)
+		}
+	}
+	required := req.RequiredRows()
+	for req.NumRows() < required {
+		if e.childRowIdx >= e.childResult.NumRows() {
+			err := exec.Next(ctx, e.Children(0), e.childResult)
+			if err != nil {
+				return err
+			}
+			e.childRowIdx = 0
+			if e.childResult.NumRows() == 0 {
+				return nil
+			}
+		}
+		end := min(e.childRowIdx+required-req.NumRows(), e.childResult.NumRows())
+		rows := make([][]kv.Key, 0, end-e.childRowIdx)
+		for i := e.childRowIdx; i < end; i++ {
+			keys, err := e.appendRowKeys(nil, e.childResult.GetRow(i))
+			if err != nil {
+				return err
+			}
+			rows = append(rows, keys)
+		}
+		locked, err := e.skipLocked.lockRows(ctx, rows)
+		if err != nil {
+			return err
+		}
+		for i, ok := range locked {
+			if ok {
+				req.AppendRow(e.childResult.GetRow(e.childRowIdx + i))
+			}
+		}
+		e.childRowIdx = end
+	}
+	return nil
+}
+
+// Close implements the Executor Close interface.
+func (e *SelectLockExec) Close() error {
+	err := e.BaseExecutor.Close()
+	if e.skipLocked != nil {
+		if finishErr := e.skipLocked.finish(); err == nil {
+			err = finishErr
+		}
+	}
+	return err
+}
+
+// appendRowKeys appends the keys to lock for the row.
+func (e *SelectLockExec) appendRowKeys(keys []kv.Key, row chunk.Row) ([]kv.Key, error) {
+	for tblID, cols := range e.tblID2Handle {
+		for _, col := range cols {
+			handle, err := col.BuildHandle(row)
+			if err != nil {
+				return nil, err
+			}
+			physTblID := tblID
+			if physTblColIdx, ok := e.tblID2PhysTblIDColIdx[tblID]; ok {
+				physTblID = row.GetInt64(physTblColIdx)
+				if physTblID == 0 {
+					// select * from t1 left join t2 on t1.c = t2.c for update
+					// The join right side might be added NULL in left join
+					// In that case, physTblID is 0, so skip adding the lock.
+					//
+					// Note, we can't distinguish whether it's the left join case,
+					// or a bug that TiKV return without correct physical ID column.
+					continue
+				}
+			}
+			keys = append(keys, tablecodec.EncodeRowKeyWithHandle(physTblID, handle))
+		}
+	}
+	return keys, nil
+}
+
 func newLockCtx(sctx sessionctx.Context, lockWaitTime int64, numKeys int) (*tikvstore.LockCtx, error) {
 	seVars := sctx.GetSessionVars()
 	forUpdateTS, err := sessiontxn.GetTxnManager(sctx).GetStmtForUpdateTS()
@@ -1288,6 +1377,154 @@ func filterTemporaryTableKeys(vars *variable.SessionVars, keys []kv.Key) []kv.Ke
 	return newKeys
 }
 
+// skipLockedLocker locks the rows for SKIP LOCKED. The keys of the rows are locked without waiting,
+// and a row is skipped if any of its keys is locked by others.
+//
+// The keys of a batch of rows are locked in one request. When it fails, it's unknown which rows are
+// locked by others, and the keys locked by the request are rolled back asynchronously with the for
+// update ts of the statement. The rollback may remove the locks acquired later with the same for
+// update ts, so the rows of the failed batch are locked again one by one with a newer for update ts.
+type skipLockedLocker struct {
+	sctx sessionctx.Context
+	// retryTS is the for update ts to lock the rows of the failed batches again.
+	retryTS uint64
+	// skippedKeys are the keys failed to be locked. They can't be locked again in the statement.
+	skippedKeys map[string]struct{}
+	skipped     bool
+}
+
+func newSkipLockedLocker(sctx sessionctx.Context) *skipLockedLocker {
+	return &skipLockedLocker{sctx: sctx}
+}
+
+// lockRows locks the keys of the rows, and returns whether each row is locked. The rows which aren't
+// locked should be skipped.
+func (l *skipLockedLocker) lockRows(ctx context.Context, rows [][]kv.Key) ([]bool, error) {
+	locked := make([]bool, len(rows))
+	candidates := make([]int, 0, len(rows))
+	keys := make([]kv.Key, 0, len(rows))
+	for i, rowKeys := range rows {
+		if len(rowKeys) == 0 {
+			locked[i] = true
+			continue
+		}
+		if l.isSkipped(rowKeys) {
+			continue
+		}
+		candidates = append(candidates, i)
+		keys = append(keys, rowKeys...)
+	}
+	if len(candidates) == 0 {
+		return locked, nil
+	}
+	err := LockKeys(ctx, l.sctx, tikvstore.LockNoWait, keys...)
+	if err == nil {
+		for _, i := range candidates {
+			locked[i] = true
+		}
+		return locked, nil
+	}
+	if !storeerr.ErrLockAcquireFailAndNoWaitSet.Equal(err) {
+		return nil, err
+	}
+	l.skipped = true
+	if len(candidates) == 1 {
+		l.skipKeys(keys)
+		return locked, nil
+	}
+	return locked, l.relockRows(ctx, rows, candidates, keys, locked)
+}
+
+// relockRows locks the rows of a failed batch one by one with the retry ts. The conflicts between the
+// for update ts of the statement and the retry ts aren't checked by the lock requests, so the rows
+// changed after they were read are skipped too, they are still locked though.
+func (l *skipLockedLocker) relockRows(ctx context.Context, rows [][]kv.Key, candidates []int, keys []kv.Key, locked []bool) error {
+	forUpdateTS, err := sessiontxn.GetTxnManager(l.sctx).GetStmtForUpdateTS()
+	if err != nil {
+		return err
+	}
+	if l.retryTS == 0 {
+		version, err := l.sctx.GetStore().CurrentVersion(l.sctx.GetSessionVars().TxnCtx.TxnScope)
+		if err != nil {
+			return err
+		}
+		l.retryTS = version.Ver
+	}
+	readValues, err := l.sctx.GetStore().GetSnapshot(kv.NewVersion(forUpdateTS)).BatchGet(ctx, keys)
+	if err != nil {
+		return err
+	}
+	for _, i := range candidates {
+		// The rows of a join may share the keys.
+		if l.isSkipped(rows[i]) {
+			continue
+		}
+		lockCtx, err := newLockCtx(l.sctx, tikvstore.LockNoWait, len(rows[i]))
+		if err != nil {
+			return err
+		}
+		lockCtx.ForUpdateTS = l.retryTS
+		lockCtx.InitReturnValues(len(rows[i]))
+		err = doLockKeys(ctx, l.sctx, lockCtx, rows[i]...)
+		if err != nil {
+			if !storeerr.ErrLockAcquireFailAndNoWaitSet.Equal(err) && !terror.ErrorEqual(kv.ErrWriteConflict, err) {
+				return err
+			}
+			l.skipKeys(rows[i])
+			continue
+		}
+		locked[i] = true
+		for _, key := range rows[i] {
+			// The keys already locked by the transaction can't be changed by others.
+			if val, ok := lockCtx.GetValueNotLocked(key); ok && !bytes.Equal(val, readValues[string(key)]) {
+				locked[i] = false
+				break
+			}
+		}
+	}
+	return nil
+}
+
+func (l *skipLockedLocker) isSkipped(keys []kv.Key) bool {
+	for _, key := range keys {
+		if _, ok := l.skippedKeys[string(key)]; ok {
+			return true
+		}
+	}
+	return false
+}
+
+func (l *skipLockedLocker) skipKeys(keys []kv.Key) {
+	if l.skippedKeys == nil {
+		l.skippedKeys = make(map[string]struct{})
+	}
+	for _, key := range keys {
+		l.skippedKeys[string(key)] = struct{}{}
+	}
+}
+
+// finish is called after the rows are locked.
+func (l *skipLockedLocker) finish() error {
+	if !l.skipped {
+		return nil
+	}
+	l.skipped, l.skippedKeys = false, nil
+	return onRowsSkipLocked(l.sctx)
+}
+
+// onRowsSkipLocked is called when some rows are skipped by SKIP LOCKED. The keys failed to be locked
+// are rolled back asynchronously, so the for update ts of the transaction is updated, otherwise the
+// following statements which reuse it may lock the keys again and have the locks rolled back.
+func onRowsSkipLocked(sctx sessionctx.Context) error {
+	txnCtx := sctx.GetSessionVars().TxnCtx
+	version, err := sctx.GetStore().CurrentVersion(txnCtx.TxnScope)
+	if err != nil {
+		return err
+	}
+	txnCtx.SetForUpdateTS(version.Ver)
+	return nil
+}
+
 func filterLockTableKeys(stmtCtx *stmtctx.StatementContext, keys []kv.Key) []kv.Key {
 	if len(stmtCtx.LockTableIDs) == 0 {
 		return keys
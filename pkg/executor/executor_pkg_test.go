@@ -34,7 +34,9 @@ import (
 	"github.com/pingcap/tidb/pkg/sessionctx/variable"
 	"github.com/pingcap/tidb/pkg/tablecodec"
 	"github.com/pingcap/tidb/pkg/types"
+	"github.com/pingcap/tidb/pkg/util/chunk"
 	"github.com/pingcap/tidb/pkg/util/collate"
+	"github.com/pingcap/tidb/pkg/util/disk"
 	"github.com/pingcap/tidb/pkg/util/memory"
 	"github.com/pingcap/tidb/pkg/util/mock"
 	"github.com/pingcap/tidb/pkg/util/ranger"
@@ -491,3 +493,43 @@ func TestErrLevelsForResetStmtContext(t *testing.T) {
 		}
 	}
 }
+
+func TestWindowRowBufferRestoredMemUsage(t *testing.T) {
+	fieldTypes := []*types.FieldType{types.NewFieldType(mysql.TypeLonglong)}
+	memTracker := memory.NewTracker(-1, -1)
+	diskTracker := disk.NewTracker(-1, -1)
+	buffer := newWindowRowBuffer(fieldTypes, memTracker, diskTracker)
+	defer buffer.close()
+	for i := 0; i < 4; i++ {
+		chk := chunk.NewChunkWithCapacity(fieldTypes, 4)
+		for j := 0; j < 4; j++ {
+			chk.AppendInt64(0, int64(i*4+j))
+		}
+		require.NoError(t, buffer.add(chk))
+	}
+	require.NoError(t, buffer.spill())
+	require.Equal(t, int64(0), memTracker.BytesConsumed())
+	require.Greater(t, diskTracker.BytesConsumed(), int64(0))
+
+	// A frame covering all the rows restores more chunks than the cache holds, they are all tracked
+	// until the rows are released.
+	rows := &windowRows{buffer: buffer, rowCnt: buffer.numRows()}
+	frameRows, err := rows.getRows(0, rows.numRows())
+	require.NoError(t, err)
+	require.Len(t, frameRows, 16)
+	for i, row := range frameRows {
+		require.Equal(t, int64(i), row.GetInt64(0))
+	}
+	var restoredMemUsage, cachedMemUsage int64
+	for i := 0; i < 4; i++ {
+		chk, err := buffer.inDisk.GetChunk(i)
+		require.NoError(t, err)
+		restoredMemUsage += chk.MemoryUsage()
+		if i >= restoredWindowChunkNum {
+			cachedMemUsage += chk.MemoryUsage()
+		}
+	}
+	require.Equal(t, restoredMemUsage, memTracker.BytesConsumed())
+	rows.releaseRestored()
+	require.Equal(t, cachedMemUsage, memTracker.BytesConsumed())
+}
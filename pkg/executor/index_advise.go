@@ -19,13 +19,19 @@ import (
 	"strings"
 
 	"github.com/pingcap/errors"
+	"github.com/pingcap/tidb/pkg/domain"
 	"github.com/pingcap/tidb/pkg/executor/internal/exec"
+	"github.com/pingcap/tidb/pkg/kv"
 	"github.com/pingcap/tidb/pkg/parser"
 	"github.com/pingcap/tidb/pkg/parser/ast"
+	"github.com/pingcap/tidb/pkg/parser/mysql"
 	"github.com/pingcap/tidb/pkg/planner/core"
+	"github.com/pingcap/tidb/pkg/planner/indexadvisor"
+	"github.com/pingcap/tidb/pkg/privilege"
 	"github.com/pingcap/tidb/pkg/sessionctx"
 	"github.com/pingcap/tidb/pkg/util"
 	"github.com/pingcap/tidb/pkg/util/chunk"
+	"github.com/pingcap/tidb/pkg/util/dbterror/exeerrors"
 )
 
 // IndexAdviseExec represents a index advise executor.
@@ -144,3 +150,176 @@ func (IndexAdviseVarKeyType) String() string {
 
 // IndexAdviseVarKey is a variable key for index advise.
 const IndexAdviseVarKey IndexAdviseVarKeyType = 0
+
+// RecommendIndexExec represents a recommend index executor.
+type RecommendIndexExec struct {
+	exec.BaseExecutor
+
+	Action string
+	SQL    string
+	done   bool
+}
+
+// Next implements the Executor Next interface.
+func (e *RecommendIndexExec) Next(ctx context.Context, req *chunk.Chunk) error {
+	req.Reset()
+	if e.done {
+		return nil
+	}
+	e.done = true
+	if e.Action != "run" {
+		return errors.Errorf("unsupported recommend index action %s", e.Action)
+	}
+
+	var queries []*indexadvisor.Query
+	if e.SQL != "" {
+		_, digest := parser.NormalizeDigest(e.SQL)
+		q := &indexadvisor.Query{
+			SchemaName: e.Ctx().GetSessionVars().CurrentDB,
+			Text:       e.SQL,
+			Digest:     digest.String(),
+			Frequency:  1,
+		}
+		if err := e.checkSelectPriv(q); err != nil {
+			return err
+		}
+		queries = []*indexadvisor.Query{q}
+	} else {
+		for _, q := range indexadvisor.WorkloadFromStmtSummary(indexadvisor.DefaultMaxNumQueries) {
+			// The workload contains the statements of the other users, and the ones referencing
+			// the tables not selectable by the current user are skipped.
+			if err := e.checkSelectPriv(q); err != nil {
+				e.Ctx().GetSessionVars().StmtCtx.AppendWarning(errors.NewNoStackErrorf("skip the statement %s: %v", q.Digest, err))
+				continue
+			}
+			queries = append(queries, q)
+		}
+	}
+	if len(queries) == 0 {
+		return nil
+	}
+
+	sysCtx, err := e.GetSysSession()
+	if err != nil {
+		return err
+	}
+	ctx = kv.WithInternalSourceType(ctx, kv.InternalTxnOthers)
+	defer e.ReleaseSysSession(ctx, sysCtx)
+	is := domain.GetDomain(e.Ctx()).InfoSchema()
+	recommendations, warns, err := indexadvisor.AdviseIndexes(ctx, sysCtx, is, queries, indexadvisor.DefaultMaxNumIndexes)
+	if err != nil {
+		return err
+	}
+	for _, warn := range warns {
+		e.Ctx().GetSessionVars().StmtCtx.AppendWarning(warn)
+	}
+	for _, r := range recommendations {
+		req.AppendString(0, r.Database)
+		req.AppendString(1, r.Table)
+		req.AppendString(2, r.IndexName)
+		req.AppendString(3, strings.Join(r.IndexColumns, ","))
+		req.AppendFloat64(4, r.CostImprovement)
+		req.AppendString(5, strings.Join(r.Digests, ","))
+		req.AppendString(6, r.CreateIndexStmt)
+	}
+	return nil
+}
+
+// checkSelectPriv checks whether the current user has the SELECT privilege on all the tables
+// referenced by the query. The query is explained in a sys session, which bypasses the privilege
+// checks of the user, so they are done here. The query failed to parse is left to the advisor,
+// which skips it with a warning.
+func (e *RecommendIndexExec) checkSelectPriv(q *indexadvisor.Query) error {
+	checker := privilege.GetPrivilegeManager(e.Ctx())
+	if checker == nil {
+		return nil
+	}
+	stmt, err := parser.New().ParseOneStmt(q.Text, "", "")
+	if err != nil {
+		return nil
+	}
+	collector := &queryTableCollector{}
+	stmt.Accept(collector)
+	vars := e.Ctx().GetSessionVars()
+	for _, tn := range collector.tables {
+		dbName := tn.Schema.L
+		if dbName == "" {
+			dbName = strings.ToLower(q.SchemaName)
+		}
+		if !checker.RequestVerification(vars.ActiveRoles, dbName, tn.Name.L, "", mysql.SelectPriv) {
+			user := vars.User
+			u, h := user.Username, user.Hostname
+			if len(user.AuthUsername) > 0 && len(user.AuthHostname) > 0 {
+				u, h = user.AuthUsername, user.AuthHostname
+			}
+			return exeerrors.ErrTableaccessDenied.GenWithStackByArgs("SELECT", u, h, tn.Name.O)
+		}
+	}
+	return nil
+}
+
+// queryTableCollector collects the tables referenced by a query, except the references to the
+// common table expressions.
+type queryTableCollector struct {
+	tables []*ast.TableName
+	// cteScopes is the names of the common table expressions defined by the enclosing statements.
+	cteScopes []map[string]struct{}
+}
+
+func hasWithClause(n ast.Node) bool {
+	switch x := n.(type) {
+	case *ast.SelectStmt:
+		return x.With != nil
+	case *ast.SetOprStmt:
+		return x.With != nil
+	}
+	return false
+}
+
+func (c *queryTableCollector) isCTE(tn *ast.TableName) bool {
+	if tn.Schema.L != "" {
+		return false
+	}
+	for _, scope := range c.cteScopes {
+		if _, ok := scope[tn.Name.L]; ok {
+			return true
+		}
+	}
+	return false
+}
+
+// Enter implements ast.Visitor interface.
+func (c *queryTableCollector) Enter(n ast.Node) (ast.Node, bool) {
+	if hasWithClause(n) {
+		c.cteScopes = append(c.cteScopes, make(map[string]struct{}))
+		return n, false
+	}
+	switch x := n.(type) {
+	case *ast.WithClause:
+		// A common table expression is visible to the ones after it and the statement body,
+		// and also to itself if it is recursive.
+		scope := c.cteScopes[len(c.cteScopes)-1]
+		for _, cte := range x.CTEs {
+			if x.IsRecursive {
+				scope[cte.Name.L] = struct{}{}
+			}
+			cte.Query.Accept(c)
+			scope[cte.Name.L] = struct{}{}
+		}
+		return n, true
+	case *ast.TableName:
+		if !c.isCTE(x) {
+			c.tables = append(c.tables, x)
+		}
+		return n, true
+	}
+	return n, false
+}
+
+// Leave implements ast.Visitor interface.
+func (c *queryTableCollector) Leave(n ast.Node) (ast.Node, bool) {
+	if hasWithClause(n) {
+		c.cteScopes = c.cteScopes[:len(c.cteScopes)-1]
+	}
+	return n, true
+}
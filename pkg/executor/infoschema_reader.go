@@ -37,6 +37,7 @@ import (
 	"github.com/pingcap/tidb/pkg/domain/infosync"
 	"github.com/pingcap/tidb/pkg/domain/resourcegroup"
 	"github.com/pingcap/tidb/pkg/errno"
+	"github.com/pingcap/tidb/pkg/eventscheduler"
 	"github.com/pingcap/tidb/pkg/executor/internal/exec"
 	"github.com/pingcap/tidb/pkg/executor/internal/pdhelper"
 	"github.com/pingcap/tidb/pkg/expression"
@@ -79,6 +80,7 @@ import (
 	"github.com/pingcap/tidb/pkg/util/set"
 	"github.com/pingcap/tidb/pkg/util/stringutil"
 	"github.com/pingcap/tidb/pkg/util/syncutil"
+	"github.com/tikv/client-go/v2/oracle"
 	"github.com/tikv/client-go/v2/tikv"
 	"github.com/tikv/client-go/v2/tikvrpc"
 	"github.com/tikv/client-go/v2/txnkv/txnlock"
@@ -138,6 +140,10 @@ func (e *memtableRetriever) retrieve(ctx context.Context, sctx sessionctx.Contex
 			e.setDataFromIndexes(sctx, dbs)
 		case infoschema.TableViews:
 			e.setDataFromViews(sctx, dbs)
+		case infoschema.TableTriggers:
+			e.setDataFromTriggers(sctx, dbs)
+		case infoschema.TableMaterializedViews:
+			err = e.setDataFromMaterializedViews(ctx, sctx, dbs)
 		case infoschema.TableEngines:
 			e.setDataFromEngines()
 		case infoschema.TableCharacterSets:
@@ -206,6 +212,10 @@ func (e *memtableRetriever) retrieve(ctx context.Context, sctx sessionctx.Contex
 			err = e.setDataFromTiDBCheckConstraints(sctx, dbs)
 		case infoschema.TableKeywords:
 			err = e.setDataFromKeywords()
+		case infoschema.TableRoutines:
+			err = e.setDataFromRoutines(ctx, sctx)
+		case infoschema.TableEvents:
+			err = e.setDataFromEvents(ctx, sctx)
 		case infoschema.TableTiDBIndexUsage:
 			e.setDataFromIndexUsage(sctx, dbs)
 		case infoschema.ClusterTableTiDBIndexUsage:
@@ -1344,6 +1354,165 @@ func (e *memtableRetriever) setDataFromViews(ctx sessionctx.Context, schemas []m
 	e.rows = rows
 }
 
+// setDataFromMaterializedViews sets the rows of MATERIALIZED_VIEWS. The STALENESS
+// of a view is:
+//   - UNUSABLE if a base table of the view is dropped.
+//   - STALE if the view is never refreshed, or the base table of a view refreshed
+//     incrementally is changed after the last refresh, STALE_ROWS is the number
+//     of the changed rows.
+//   - FRESH if the base table of a view refreshed incrementally is not changed
+//     after the last refresh.
+//   - UNKNOWN for the views refreshed completely, the changes of their base tables
+//     are not tracked.
+func (e *memtableRetriever) setDataFromMaterializedViews(ctx context.Context, sctx sessionctx.Context, schemas []model.CIStr) error {
+	type refreshRecord struct {
+		method string
+		tso    uint64
+	}
+	var records map[int64]refreshRecord
+	exec := sctx.GetRestrictedSQLExecutor()
+	kctx := kv.WithInternalSourceType(ctx, kv.InternalTxnOthers)
+	checker := privilege.GetPrivilegeManager(sctx)
+	loc := sctx.GetSessionVars().TimeZone
+	if loc == nil {
+		loc = time.Local
+	}
+	var rows [][]types.Datum
+	for _, schema := range schemas {
+		tables := e.is.SchemaTables(schema)
+		for _, table := range tables {
+			table := table.Meta()
+			if !table.IsMaterializedView() {
+				continue
+			}
+			if checker != nil && !checker.RequestVerification(sctx.GetSessionVars().ActiveRoles, schema.L, table.Name.L, "", mysql.AllPrivMask) {
+				continue
+			}
+			if records == nil {
+				chunkRows, _, err := exec.ExecRestrictedSQL(kctx, nil,
+					`SELECT mview_id, CAST(last_refresh_method AS CHAR), last_refresh_tso FROM mysql.tidb_mview_refresh`)
+				if err != nil {
+					return err
+				}
+				records = make(map[int64]refreshRecord, len(chunkRows))
+				for _, row := range chunkRows {
+					records[row.GetInt64(0)] = refreshRecord{method: row.GetString(1), tso: row.GetUint64(2)}
+				}
+			}
+
+			mvInfo := table.MaterializedView
+			var lastMethod, lastTime, lastTSO, staleRows any
+			record, refreshed := records[table.ID]
+			if refreshed {
+				lastMethod, lastTSO = record.method, record.tso
+				lastTime = types.NewTime(types.FromGoTime(oracle.GetTimeFromTS(record.tso).In(loc)), mysql.TypeDatetime, 6)
+			}
+			staleness := "UNKNOWN"
+			for _, id := range mvInfo.BaseTableIDs {
+				if _, ok := e.is.TableByID(id); !ok {
+					staleness = "UNUSABLE"
+					break
+				}
+			}
+			if staleness != "UNUSABLE" && mvInfo.LogTableID != 0 {
+				logTbl, ok := e.is.TableByID(mvInfo.LogTableID)
+				var logDB *model.DBInfo
+				if ok {
+					logDB, ok = infoschema.SchemaByTable(e.is, logTbl.Meta())
+				}
+				if !ok {
+					staleness = "UNUSABLE"
+				} else {
+					chunkRows, _, err := exec.ExecRestrictedSQL(kctx, nil, `SELECT COUNT(*) FROM %n.%n`, logDB.Name.O, logTbl.Meta().Name.O)
+					if err != nil {
+						return err
+					}
+					cnt := chunkRows[0].GetInt64(0)
+					staleRows = cnt
+					if cnt == 0 {
+						staleness = "FRESH"
+					} else {
+						staleness = "STALE"
+					}
+				}
+			}
+			if !refreshed && staleness != "UNUSABLE" {
+				staleness = "STALE"
+			}
+			row := types.MakeDatums(
+				infoschema.CatalogVal,         // TABLE_CATALOG
+				schema.O,                      // TABLE_SCHEMA
+				table.Name.O,                  // TABLE_NAME
+				mvInfo.SelectStmt,             // VIEW_DEFINITION
+				mvInfo.RefreshMethod.String(), // REFRESH_METHOD
+				lastMethod,                    // LAST_REFRESH_METHOD
+				lastTime,                      // LAST_REFRESH_TIME
+				lastTSO,                       // LAST_REFRESH_TSO
+				staleness,                     // STALENESS
+				staleRows,                     // STALE_ROWS
+			)
+			rows = append(rows, row)
+		}
+	}
+	e.rows = rows
+	return nil
+}
+
+func (e *memtableRetriever) setDataFromTriggers(ctx sessionctx.Context, schemas []model.CIStr) {
+	checker := privilege.GetPrivilegeManager(ctx)
+	loc := ctx.GetSessionVars().TimeZone
+	if loc == nil {
+		loc = time.Local
+	}
+	var rows [][]types.Datum
+	for _, schema := range schemas {
+		tables := e.is.SchemaTables(schema)
+		for _, table := range tables {
+			table := table.Meta()
+			if len(table.Triggers) == 0 {
+				continue
+			}
+			if checker != nil && !checker.RequestVerification(ctx.GetSessionVars().ActiveRoles, schema.L, table.Name.L, "", mysql.TriggerPriv) {
+				continue
+			}
+			// ACTION_ORDER is the position of the trigger among the triggers
+			// with the same timing and event on the table.
+			orders := make(map[[2]int]int, len(table.Triggers))
+			for _, trg := range table.Triggers {
+				key := [2]int{int(trg.Timing), int(trg.Event)}
+				orders[key]++
+				created := types.NewTime(types.FromGoTime(trg.Created.In(loc)), mysql.TypeDatetime, 2)
+				record := types.MakeDatums(
+					infoschema.CatalogVal,      // TRIGGER_CATALOG
+					schema.O,                   // TRIGGER_SCHEMA
+					trg.Name.O,                 // TRIGGER_NAME
+					trg.Event.String(),         // EVENT_MANIPULATION
+					infoschema.CatalogVal,      // EVENT_OBJECT_CATALOG
+					schema.O,                   // EVENT_OBJECT_SCHEMA
+					table.Name.O,               // EVENT_OBJECT_TABLE
+					orders[key],                // ACTION_ORDER
+					nil,                        // ACTION_CONDITION
+					trg.Body,                   // ACTION_STATEMENT
+					"ROW",                      // ACTION_ORIENTATION
+					trg.Timing.String(),        // ACTION_TIMING
+					nil,                        // ACTION_REFERENCE_OLD_TABLE
+					nil,                        // ACTION_REFERENCE_NEW_TABLE
+					"OLD",                      // ACTION_REFERENCE_OLD_ROW
+					"NEW",                      // ACTION_REFERENCE_NEW_ROW
+					created,                    // CREATED
+					sqlModeString(trg.SQLMode), // SQL_MODE
+					trg.Definer.String(),       // DEFINER
+					trg.CharsetClient,          // CHARACTER_SET_CLIENT
+					trg.CollationConnection,    // COLLATION_CONNECTION
+					trg.DBCollation,            // DATABASE_COLLATION
+				)
+				rows = append(rows, record)
+			}
+		}
+	}
+	e.rows = rows
+}
+
 func (e *memtableRetriever) dataForTiKVStoreStatus(ctx context.Context, sctx sessionctx.Context) (err error) {
 	tikvStore, ok := sctx.GetStore().(helper.Storage)
 	if !ok {
@@ -3578,6 +3747,107 @@ func (e *memtableRetriever) setDataFromKeywords() error {
 	return nil
 }
 
+func (e *memtableRetriever) setDataFromRoutines(ctx context.Context, sctx sessionctx.Context) error {
+	defs, err := loadProcedureDefinitions(ctx, sctx, "", "")
+	if err != nil {
+		return err
+	}
+	rows := make([][]types.Datum, 0, len(defs))
+	for _, def := range defs {
+		if !procedureIsVisible(sctx, def.db) {
+			continue
+		}
+		row := types.MakeDatums(
+			def.name,              // SPECIFIC_NAME
+			infoschema.CatalogVal, // ROUTINE_CATALOG
+			def.db,                // ROUTINE_SCHEMA
+			def.name,              // ROUTINE_NAME
+			"PROCEDURE",           // ROUTINE_TYPE
+			"",                    // DATA_TYPE
+			nil,                   // CHARACTER_MAXIMUM_LENGTH
+			nil,                   // CHARACTER_OCTET_LENGTH
+			nil,                   // NUMERIC_PRECISION
+			nil,                   // NUMERIC_SCALE
+			nil,                   // DATETIME_PRECISION
+			nil,                   // CHARACTER_SET_NAME
+			nil,                   // COLLATION_NAME
+			nil,                   // DTD_IDENTIFIER
+			"SQL",                 // ROUTINE_BODY
+			def.body,              // ROUTINE_DEFINITION
+			nil,                   // EXTERNAL_NAME
+			"SQL",                 // EXTERNAL_LANGUAGE
+			"SQL",                 // PARAMETER_STYLE
+			"NO",                  // IS_DETERMINISTIC
+			"CONTAINS SQL",        // SQL_DATA_ACCESS
+			nil,                   // SQL_PATH
+			def.securityType,      // SECURITY_TYPE
+			def.created,           // CREATED
+			def.modified,          // LAST_ALTERED
+			def.sqlMode,           // SQL_MODE
+			def.comment,           // ROUTINE_COMMENT
+			def.definer,           // DEFINER
+			def.charsetClient,     // CHARACTER_SET_CLIENT
+			def.collationConn,     // COLLATION_CONNECTION
+			def.dbCollation,       // DATABASE_COLLATION
+		)
+		rows = append(rows, row)
+	}
+	e.rows = rows
+	return nil
+}
+
+func (e *memtableRetriever) setDataFromEvents(ctx context.Context, sctx sessionctx.Context) error {
+	cli, err := eventTimerClient(sctx)
+	if err != nil {
+		return err
+	}
+	events, err := eventscheduler.LoadEvents(ctx, cli, "", "")
+	if err != nil {
+		return err
+	}
+	loc := sctx.GetSessionVars().Location()
+	rows := make([][]types.Datum, 0, len(events))
+	for _, ev := range events {
+		if !eventIsVisible(sctx, strings.ToLower(ev.Schema)) {
+			continue
+		}
+		onCompletion := "NOT PRESERVE"
+		if ev.Preserve {
+			onCompletion = "PRESERVE"
+		}
+		sched := eventScheduleDatums(ev)
+		row := []types.Datum{
+			types.NewStringDatum(infoschema.CatalogVal),  // EVENT_CATALOG
+			types.NewStringDatum(ev.Schema),              // EVENT_SCHEMA
+			types.NewStringDatum(ev.Name),                // EVENT_NAME
+			types.NewStringDatum(ev.Definer),             // DEFINER
+			types.NewStringDatum(ev.TimeZone),            // TIME_ZONE
+			types.NewStringDatum("SQL"),                  // EVENT_BODY
+			types.NewStringDatum(ev.Body),                // EVENT_DEFINITION
+			types.NewStringDatum(eventType(ev)),          // EVENT_TYPE
+			sched[0],                                     // EXECUTE_AT
+			sched[1],                                     // INTERVAL_VALUE
+			sched[2],                                     // INTERVAL_FIELD
+			types.NewStringDatum(ev.SQLMode),             // SQL_MODE
+			sched[3],                                     // STARTS
+			sched[4],                                     // ENDS
+			types.NewStringDatum(ev.Status),              // STATUS
+			types.NewStringDatum(onCompletion),           // ON_COMPLETION
+			eventTimeDatum(ev.Created, loc),              // CREATED
+			eventTimeDatum(ev.LastAltered, loc),          // LAST_ALTERED
+			eventTimeDatum(ev.LastExecuted, loc),         // LAST_EXECUTED
+			types.NewStringDatum(ev.Comment),             // EVENT_COMMENT
+			types.NewIntDatum(0),                         // ORIGINATOR
+			types.NewStringDatum(ev.CharsetClient),       // CHARACTER_SET_CLIENT
+			types.NewStringDatum(ev.CollationConnection), // COLLATION_CONNECTION
+			types.NewStringDatum(ev.DBCollation),         // DATABASE_COLLATION
+		}
+		rows = append(rows, row)
+	}
+	e.rows = rows
+	return nil
+}
+
 func (e *memtableRetriever) setDataFromIndexUsage(ctx sessionctx.Context, schemas []model.CIStr) {
 	dom := domain.GetDomain(ctx)
 	rows := make([][]types.Datum, 0, 100)
@@ -426,11 +426,19 @@ func (e *InsertExec) doDupRowUpdate(ctx context.Context, handle kv.Handle, oldRo
 	}
 
 	newData := e.row4Update[:len(oldRow)]
-	_, err := updateRecord(ctx, e.Ctx(), handle, oldRow, newData, assignFlag, e.Table, true, e.memTracker, e.fkChecks, e.fkCascades)
+	if err := e.triggers.fire(ctx, e.Ctx(), model.TriggerBefore, model.TriggerUpdate, oldRow, newData, assignFlag); err != nil {
+		return err
+	}
+	changed, err := updateRecord(ctx, e.Ctx(), handle, oldRow, newData, assignFlag, e.Table, true, e.memTracker, e.fkChecks, e.fkCascades)
 	if err != nil {
 		return err
 	}
-	return nil
+	if changed {
+		if err := e.mviewLogs.onUpdateRow(e.Ctx(), oldRow, newData); err != nil {
+			return err
+		}
+	}
+	return e.triggers.fire(ctx, e.Ctx(), model.TriggerAfter, model.TriggerUpdate, oldRow, newData, nil)
 }
 
 // setMessage sets info message(ERR_INSERT_INFO) generated by INSERT statement
@@ -95,6 +95,11 @@ type InsertValues struct {
 	// fkChecks contains the foreign key checkers.
 	fkChecks   []*FKCheckExec
 	fkCascades []*FKCascadeExec
+	// triggers are the triggers of the table, it's nil if the table has no trigger.
+	triggers *tableTriggers
+	// mviewLogs are the log tables the changed rows are written into, it's nil if
+	// no materialized view reading the table is refreshed incrementally.
+	mviewLogs *tableMViewLogs
 }
 
 type defaultVal struct {
@@ -691,6 +696,11 @@ func (e *InsertValues) fillRow(ctx context.Context, row []types.Datum, hasValue
 		}
 	}
 
+	// The BEFORE INSERT triggers can modify the real columns, the generated columns are evaluated after them.
+	if err := e.triggers.fire(ctx, e.Ctx(), model.TriggerBefore, model.TriggerInsert, nil, row, nil); err != nil {
+		return nil, err
+	}
+
 	// Handle exchange partition
 	tbl := e.Table.Meta()
 	if tbl.ExchangePartitionInfo != nil && tbl.GetPartitionInfo() == nil {
@@ -1367,6 +1377,9 @@ func (e *InsertValues) removeRow(
 		return true, nil
 	}
 
+	if err = e.triggers.fire(ctx, e.Ctx(), model.TriggerBefore, model.TriggerDelete, oldRow, nil, nil); err != nil {
+		return false, err
+	}
 	if ph, ok := handle.(kv.PartitionHandle); ok {
 		err = e.Table.(table.PartitionedTable).GetPartition(ph.PartitionID).RemoveRecord(e.Ctx().GetTableCtx(), ph.Handle, oldRow)
 	} else {
@@ -1379,6 +1392,12 @@ func (e *InsertValues) removeRow(
 	if err != nil {
 		return false, err
 	}
+	if err = e.mviewLogs.onDeleteRow(e.Ctx(), oldRow); err != nil {
+		return false, err
+	}
+	if err = e.triggers.fire(ctx, e.Ctx(), model.TriggerAfter, model.TriggerDelete, oldRow, nil, nil); err != nil {
+		return false, err
+	}
 	if inReplace {
 		e.Ctx().GetSessionVars().StmtCtx.AddAffectedRows(1)
 	} else {
@@ -1442,8 +1461,11 @@ func (e *InsertValues) addRecordWithAutoIDHint(
 		// update the TTL metrics if the table is a TTL table
 		vars.TxnCtx.InsertTTLRowsCount++
 	}
+	if err = e.mviewLogs.onInsertRow(e.Ctx(), row); err != nil {
+		return err
+	}
 
-	return nil
+	return e.triggers.fire(ctx, e.Ctx(), model.TriggerAfter, model.TriggerInsert, nil, row, nil)
 }
 
 // CreateSession will be assigned by session package.
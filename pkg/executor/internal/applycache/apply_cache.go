@@ -15,21 +15,55 @@
 package applycache
 
 import (
+	"context"
+	"sync/atomic"
+
 	"github.com/pingcap/tidb/pkg/sessionctx"
+	"github.com/pingcap/tidb/pkg/sessionctx/variable"
+	"github.com/pingcap/tidb/pkg/types"
 	"github.com/pingcap/tidb/pkg/util/chunk"
+	"github.com/pingcap/tidb/pkg/util/disk"
 	"github.com/pingcap/tidb/pkg/util/kvcache"
+	"github.com/pingcap/tidb/pkg/util/logutil"
 	"github.com/pingcap/tidb/pkg/util/mathutil"
 	"github.com/pingcap/tidb/pkg/util/memory"
 	"github.com/pingcap/tidb/pkg/util/syncutil"
+	"go.uber.org/zap"
 )
 
+const spillLogInfo = "memory exceeds quota, spill the apply cache to disk"
+
 // ApplyCache is used in the apply executor. When we get the same value of the outer row.
 // We fetch the inner rows in the cache not to fetch them in the inner executor.
+// The cache is shared by all the workers of the parallel apply.
 type ApplyCache struct {
 	cache       *kvcache.SimpleLRUCache // cache.Get/Put are not thread-safe, so it's protected by the lock above
 	memTracker  *memory.Tracker         // track memory usage.
 	memCapacity int64
 	lock        syncutil.Mutex
+	// inflight records the keys whose inner rows are being fetched, it's protected by lock. The workers
+	// looking up the same key wait for the result rather than fetching the inner rows again.
+	inflight map[string]*applyCacheCall
+
+	// spillEnabled is read by the workers without diskLock, and is only changed under diskLock.
+	spillEnabled atomic.Bool
+	// The fields below are used to spill the evicted items to disk, they are protected by diskLock.
+	diskLock     syncutil.Mutex
+	fieldTypes   []*types.FieldType
+	maxChunkSize int
+	diskTracker  *disk.Tracker
+	inDisk       *chunk.DataInDiskByChunks
+	// spilled maps the keys of the spilled items to the indexes of their chunks in inDisk.
+	spilled map[string][]int
+	// needSpill is set when the memory quota of the query is exceeded, all the items in memory are
+	// spilled when the next item is set.
+	needSpill atomic.Bool
+}
+
+// applyCacheCall is a running fetch of the inner rows of a key.
+type applyCacheCall struct {
+	done  chan struct{}
+	value *chunk.List
 }
 
 type applyCacheKey []byte
@@ -51,10 +85,28 @@ func NewApplyCache(ctx sessionctx.Context) (*ApplyCache, error) {
 		cache:       cache,
 		memCapacity: ctx.GetSessionVars().MemQuotaApplyCache,
 		memTracker:  memory.NewTracker(memory.LabelForApplyCache, -1),
+		inflight:    make(map[string]*applyCacheCall),
 	}
 	return &c, nil
 }
 
+// SetupSpill enables spilling the items evicted from the cache to disk rather than dropping them, and
+// registers the spill action of the cache on the memory tracker of the session. It does nothing if
+// spilling to disk is disabled.
+func (c *ApplyCache) SetupSpill(ctx sessionctx.Context, fieldTypes []*types.FieldType) {
+	diskTracker := ctx.GetSessionVars().StmtCtx.DiskTracker
+	if !variable.EnableTmpStorageOnOOM.Load() || diskTracker == nil {
+		return
+	}
+	c.spillEnabled.Store(true)
+	c.fieldTypes = fieldTypes
+	c.maxChunkSize = ctx.GetSessionVars().MaxChunkSize
+	c.diskTracker = disk.NewTracker(memory.LabelForApplyCache, -1)
+	c.diskTracker.AttachTo(diskTracker)
+	c.spilled = make(map[string][]int)
+	ctx.GetSessionVars().MemTracker.FallbackOldAndSetNewAction(&spillAction{cache: c})
+}
+
 func (c *ApplyCache) get(key applyCacheKey) (value kvcache.Value, ok bool) {
 	c.lock.Lock()
 	defer c.lock.Unlock()
@@ -77,16 +129,72 @@ func (c *ApplyCache) removeOldest() (kvcache.Key, kvcache.Value, bool) {
 func (c *ApplyCache) Get(key applyCacheKey) (*chunk.List, error) {
 	value, hit := c.get(key)
 	if !hit {
-		return nil, nil
+		return c.restore(key)
 	}
 	typedValue := value.(*chunk.List)
 	return typedValue, nil
 }
 
+// Fetch gets a cache item according to cache key. If the item isn't cached and no one is fetching it,
+// the caller should fetch it and call Done with the result. If someone else is fetching it, Fetch
+// waits for the result. It's thread-safe.
+func (c *ApplyCache) Fetch(ctx context.Context, key applyCacheKey) (value *chunk.List, shouldFetch bool, err error) {
+	for {
+		value, err = c.Get(key)
+		if err != nil || value != nil {
+			return value, false, err
+		}
+		c.lock.Lock()
+		call, ok := c.inflight[string(key)]
+		if !ok {
+			c.inflight[string(key)] = &applyCacheCall{done: make(chan struct{})}
+			c.lock.Unlock()
+			return nil, true, nil
+		}
+		c.lock.Unlock()
+		select {
+		case <-call.done:
+		case <-ctx.Done():
+			return nil, false, ctx.Err()
+		}
+		if call.value != nil {
+			return call.value, false, nil
+		}
+		// The fetching failed, try to fetch it by ourselves.
+	}
+}
+
+// Done sets the item fetched after calling Fetch and wakes up the ones waiting for it. The value is nil
+// if the fetching failed. It's thread-safe.
+func (c *ApplyCache) Done(key applyCacheKey, value *chunk.List) error {
+	var err error
+	if value != nil {
+		_, err = c.Set(key, value)
+	}
+	c.lock.Lock()
+	call := c.inflight[string(key)]
+	delete(c.inflight, string(key))
+	c.lock.Unlock()
+	if call != nil {
+		// The value is shared with the waiting ones even if it's not cached.
+		call.value = value
+		close(call.done)
+	}
+	return err
+}
+
 // Set inserts an item to the cache. It's thread-safe.
 func (c *ApplyCache) Set(key applyCacheKey, value *chunk.List) (bool, error) {
+	if c.needSpill.Load() {
+		if err := c.spillAll(); err != nil {
+			return false, err
+		}
+	}
 	mem := applyCacheKVMem(key, value)
 	if mem > c.memCapacity { // ignore this kv pair if its size is too large
+		if c.spillEnabled.Load() {
+			return true, c.spill(key, value)
+		}
 		return false, nil
 	}
 	for mem+c.memTracker.BytesConsumed() > c.memCapacity {
@@ -95,13 +203,132 @@ func (c *ApplyCache) Set(key applyCacheKey, value *chunk.List) (bool, error) {
 			return false, nil
 		}
 		c.memTracker.Consume(-applyCacheKVMem(evictedKey.(applyCacheKey), evictedValue.(*chunk.List)))
+		if c.spillEnabled.Load() {
+			if err := c.spill(evictedKey.(applyCacheKey), evictedValue.(*chunk.List)); err != nil {
+				return false, err
+			}
+		}
 	}
 	c.memTracker.Consume(mem)
 	c.put(key, value)
 	return true, nil
 }
 
+// spillAll spills all the items in memory to disk.
+func (c *ApplyCache) spillAll() error {
+	c.needSpill.Store(false)
+	for {
+		evictedKey, evictedValue, evicted := c.removeOldest()
+		if !evicted {
+			return nil
+		}
+		c.memTracker.Consume(-applyCacheKVMem(evictedKey.(applyCacheKey), evictedValue.(*chunk.List)))
+		if err := c.spill(evictedKey.(applyCacheKey), evictedValue.(*chunk.List)); err != nil {
+			return err
+		}
+	}
+}
+
+// spill writes an item to disk.
+func (c *ApplyCache) spill(key applyCacheKey, value *chunk.List) error {
+	c.diskLock.Lock()
+	defer c.diskLock.Unlock()
+	// The cache may be closed by another worker after spillEnabled is checked.
+	if !c.spillEnabled.Load() {
+		return nil
+	}
+	if _, ok := c.spilled[string(key)]; ok {
+		return nil
+	}
+	if c.inDisk == nil {
+		c.inDisk = chunk.NewDataInDiskByChunks(c.fieldTypes)
+		c.inDisk.GetDiskTracker().AttachTo(c.diskTracker)
+	}
+	chkIdxes := make([]int, 0, value.NumChunks())
+	for i := 0; i < value.NumChunks(); i++ {
+		chk := value.GetChunk(i)
+		if chk.NumRows() == 0 {
+			continue
+		}
+		if err := c.inDisk.Add(chk); err != nil {
+			return err
+		}
+		chkIdxes = append(chkIdxes, c.inDisk.NumChunks()-1)
+	}
+	c.spilled[string(key)] = chkIdxes
+	return nil
+}
+
+// restore reads a spilled item from disk, it returns nil if the item isn't spilled. The restored item
+// isn't put back to memory.
+func (c *ApplyCache) restore(key applyCacheKey) (*chunk.List, error) {
+	if !c.spillEnabled.Load() {
+		return nil, nil
+	}
+	c.diskLock.Lock()
+	defer c.diskLock.Unlock()
+	chkIdxes, ok := c.spilled[string(key)]
+	if !ok {
+		return nil, nil
+	}
+	value := chunk.NewList(c.fieldTypes, c.maxChunkSize, c.maxChunkSize)
+	for _, chkIdx := range chkIdxes {
+		chk, err := c.inDisk.GetChunk(chkIdx)
+		if err != nil {
+			return nil, err
+		}
+		value.Add(chk)
+	}
+	return value, nil
+}
+
+// Close releases the disk resource of the cache.
+func (c *ApplyCache) Close() {
+	c.diskLock.Lock()
+	defer c.diskLock.Unlock()
+	if c.inDisk != nil {
+		c.inDisk.Close()
+		c.inDisk.GetDiskTracker().Detach()
+		c.inDisk = nil
+	}
+	c.spilled = nil
+	c.spillEnabled.Store(false)
+}
+
 // GetMemTracker returns the memory tracker of this apply cache.
 func (c *ApplyCache) GetMemTracker() *memory.Tracker {
 	return c.memTracker
 }
+
+// GetDiskTracker returns the disk tracker of this apply cache, it's nil if spilling is disabled.
+func (c *ApplyCache) GetDiskTracker() *disk.Tracker {
+	return c.diskTracker
+}
+
+// spillAction implements memory.ActionOnExceed for the apply cache. It marks the cache to spill, and the
+// items in memory are spilled when the next item is set.
+type spillAction struct {
+	memory.BaseOOMAction
+	cache *ApplyCache
+}
+
+// GetPriority get the priority of the Action.
+func (*spillAction) GetPriority() int64 {
+	return memory.DefSpillPriority
+}
+
+// Action implements the memory.ActionOnExceed interface.
+func (a *spillAction) Action(t *memory.Tracker) {
+	// Only spill when the cache occupies enough memory, otherwise spilling it doesn't help.
+	if a.cache.memTracker.BytesConsumed() >= t.GetBytesLimit()/10 {
+		if !a.cache.needSpill.Swap(true) {
+			logutil.BgLogger().Info(spillLogInfo, zap.Int64("consumed", t.BytesConsumed()), zap.Int64("quota", t.GetBytesLimit()))
+		}
+		return
+	}
+	if t.CheckExceed() {
+		if fallback := a.GetFallback(); fallback != nil {
+			fallback.Action(t)
+		}
+	}
+}
@@ -15,9 +15,11 @@
 package applycache
 
 import (
+	"context"
 	"strconv"
 	"strings"
 	"sync"
+	"sync/atomic"
 	"testing"
 
 	"github.com/pingcap/tidb/pkg/parser/mysql"
@@ -136,3 +138,104 @@ func TestApplyCacheConcurrent(t *testing.T) {
 	require.NoError(t, err)
 	require.NotNil(t, result)
 }
+
+func TestApplyCacheSpill(t *testing.T) {
+	ctx := mock.NewContext()
+	ctx.GetSessionVars().MemQuotaApplyCache = 100
+	applyCache, err := NewApplyCache(ctx)
+	require.NoError(t, err)
+	fields := []*types.FieldType{types.NewFieldType(mysql.TypeLonglong)}
+	applyCache.SetupSpill(ctx, fields)
+	defer applyCache.Close()
+
+	value := make([]*chunk.List, 3)
+	key := make([][]byte, 3)
+	for i := 0; i < 3; i++ {
+		value[i] = chunk.NewList(fields, 1, 1)
+		srcChunk := chunk.NewChunkWithCapacity(fields, 1)
+		srcChunk.AppendInt64(0, int64(i))
+		value[i].AppendRow(srcChunk.GetRow(0))
+		key[i] = []byte(strings.Repeat(strconv.Itoa(i), 100))
+		ok, err := applyCache.Set(key[i], value[i])
+		require.NoError(t, err)
+		require.True(t, ok)
+	}
+	// key[0] and key[1] are evicted from memory and spilled to disk.
+	require.Equal(t, int64(100), applyCache.GetMemTracker().BytesConsumed())
+	require.Greater(t, applyCache.GetDiskTracker().BytesConsumed(), int64(0))
+	for i := 0; i < 3; i++ {
+		result, err := applyCache.Get(key[i])
+		require.NoError(t, err)
+		require.NotNil(t, result)
+		require.Equal(t, 1, result.Len())
+		require.Equal(t, int64(i), result.GetRow(chunk.RowPtr{}).GetInt64(0))
+	}
+
+	// The item larger than the capacity is spilled directly.
+	large := chunk.NewList(fields, 1, 1)
+	srcChunk := chunk.NewChunkWithCapacity(fields, 1)
+	srcChunk.AppendInt64(0, 3)
+	large.AppendRow(srcChunk.GetRow(0))
+	largeKey := []byte(strings.Repeat("3", 200))
+	ok, err := applyCache.Set(largeKey, large)
+	require.NoError(t, err)
+	require.True(t, ok)
+	result, err := applyCache.Get(largeKey)
+	require.NoError(t, err)
+	require.NotNil(t, result)
+	require.Equal(t, int64(3), result.GetRow(chunk.RowPtr{}).GetInt64(0))
+
+	applyCache.Close()
+	require.Equal(t, int64(0), ctx.GetSessionVars().StmtCtx.DiskTracker.BytesConsumed())
+}
+
+func TestApplyCacheFetch(t *testing.T) {
+	ctx := mock.NewContext()
+	ctx.GetSessionVars().MemQuotaApplyCache = 100
+	applyCache, err := NewApplyCache(ctx)
+	require.NoError(t, err)
+
+	fields := []*types.FieldType{types.NewFieldType(mysql.TypeLonglong)}
+	value := chunk.NewList(fields, 1, 1)
+	srcChunk := chunk.NewChunkWithCapacity(fields, 1)
+	srcChunk.AppendInt64(0, 1)
+	value.AppendRow(srcChunk.GetRow(0))
+	key := []byte("1")
+
+	result, shouldFetch, err := applyCache.Fetch(context.Background(), key)
+	require.NoError(t, err)
+	require.True(t, shouldFetch)
+	require.Nil(t, result)
+
+	// The others wait for the fetching one rather than fetching again.
+	const waiters = 10
+	var wg sync.WaitGroup
+	var fetchedCnt atomic.Int32
+	results := make([]*chunk.List, waiters)
+	for i := 0; i < waiters; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			result, shouldFetch, err := applyCache.Fetch(context.Background(), key)
+			require.NoError(t, err)
+			if shouldFetch {
+				fetchedCnt.Add(1)
+				require.NoError(t, applyCache.Done(key, value))
+				result = value
+			}
+			results[i] = result
+		}(i)
+	}
+	// A failed fetching makes one of the waiting ones fetch it.
+	require.NoError(t, applyCache.Done(key, nil))
+	wg.Wait()
+	require.Equal(t, int32(1), fetchedCnt.Load())
+	for _, result := range results {
+		require.Same(t, value, result)
+	}
+
+	result, shouldFetch, err = applyCache.Fetch(context.Background(), key)
+	require.NoError(t, err)
+	require.False(t, shouldFetch)
+	require.Same(t, value, result)
+}
@@ -291,6 +291,27 @@ func (e *VecGroupChecker) getFirstAndLastRowDatum(
 		} else {
 			lastRowDatum.SetNull()
 		}
+	case types.ETVectorFloat32:
+		firstRowVal, firstRowIsNull, err := item.EvalVectorFloat32(e.ctx, chk.GetRow(0))
+		if err != nil {
+			return err
+		}
+		lastRowVal, lastRowIsNull, err := item.EvalVectorFloat32(e.ctx, chk.GetRow(numRows-1))
+		if err != nil {
+			return err
+		}
+		if !firstRowIsNull {
+			// make a copy to avoid DATA RACE
+			firstRowDatum.SetVectorFloat32(firstRowVal.Clone())
+		} else {
+			firstRowDatum.SetNull()
+		}
+		if !lastRowIsNull {
+			// make a copy to avoid DATA RACE
+			lastRowDatum.SetVectorFloat32(lastRowVal.Clone())
+		} else {
+			lastRowDatum.SetNull()
+		}
 	case types.ETString:
 		firstRowVal, firstRowIsNull, err := item.EvalString(e.ctx, chk.GetRow(0))
 		if err != nil {
@@ -452,6 +473,30 @@ func (e *VecGroupChecker) evalGroupItemsAndResolveGroups(
 			}
 			previousIsNull = isNull
 		}
+	case types.ETVectorFloat32:
+		var previousKey, key types.VectorFloat32
+		if !previousIsNull {
+			previousKey = col.GetVectorFloat32(0)
+		}
+		for i := 1; i < numRows; i++ {
+			isNull := col.IsNull(i)
+			if !isNull {
+				key = col.GetVectorFloat32(i)
+			}
+			if e.sameGroup[i] {
+				if isNull == previousIsNull {
+					if !isNull && previousKey.Compare(key) != 0 {
+						e.sameGroup[i] = false
+					}
+				} else {
+					e.sameGroup[i] = false
+				}
+			}
+			if !isNull {
+				previousKey = key
+			}
+			previousIsNull = isNull
+		}
 	case types.ETString:
 		previousKey := codec.ConvertByCollationStr(col.GetString(0), tp)
 		for i := 1; i < numRows; i++ {
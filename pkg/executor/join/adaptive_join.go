@@ -0,0 +1,223 @@
+// Copyright 2024 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package join
+
+import (
+	"bytes"
+	"context"
+	"strconv"
+
+	"github.com/pingcap/tidb/pkg/executor/internal/exec"
+	"github.com/pingcap/tidb/pkg/sessionctx"
+	"github.com/pingcap/tidb/pkg/util/chunk"
+	"github.com/pingcap/tidb/pkg/util/execdetails"
+	"github.com/pingcap/tidb/pkg/util/memory"
+)
+
+var (
+	_ exec.Executor = &AdaptiveJoinExec{}
+	_ exec.Executor = &AdaptiveJoinOuterExec{}
+)
+
+const (
+	adaptiveJoinStrategyIndexJoin = "index_join"
+	adaptiveJoinStrategyHashJoin  = "hash_join"
+)
+
+// AdaptiveJoinExec chooses the join strategy of an index join at execution time. It reads the outer
+// side first, the index lookup join is used if the outer side has no more rows than the threshold.
+// Otherwise, it switches to the hash join prepared by the planner, which builds the hash table on
+// the inner side and saves the point lookups for every outer row.
+// The outer rows which have been read are returned to the chosen join by the AdaptiveJoinOuterExec.
+type AdaptiveJoinExec struct {
+	exec.BaseExecutor
+
+	Threshold int
+	Outer     *AdaptiveJoinOuterExec
+	IndexJoin exec.Executor
+	HashJoin  exec.Executor
+
+	chosen     exec.Executor
+	memTracker *memory.Tracker
+	stats      *adaptiveJoinRuntimeStats
+}
+
+// Open implements the Executor interface.
+func (e *AdaptiveJoinExec) Open(ctx context.Context) error {
+	e.chosen = nil
+	if err := exec.Open(ctx, e.Outer); err != nil {
+		return err
+	}
+	e.memTracker = memory.NewTracker(e.ID(), -1)
+	e.memTracker.AttachTo(e.Ctx().GetSessionVars().StmtCtx.MemTracker)
+	exceeded, err := e.Outer.bufferRows(ctx, e.Threshold, e.memTracker)
+	if err != nil {
+		return err
+	}
+	strategy := adaptiveJoinStrategyIndexJoin
+	e.chosen = e.IndexJoin
+	if exceeded {
+		strategy = adaptiveJoinStrategyHashJoin
+		e.chosen = e.HashJoin
+	}
+	if e.RuntimeStats() != nil {
+		e.stats = &adaptiveJoinRuntimeStats{strategy: strategy, threshold: e.Threshold}
+	}
+	return exec.Open(ctx, e.chosen)
+}
+
+// Next implements the Executor interface.
+func (e *AdaptiveJoinExec) Next(ctx context.Context, req *chunk.Chunk) error {
+	// The chosen join shares the runtime stats with this executor, so exec.Next is not used.
+	return e.chosen.Next(ctx, req)
+}
+
+// Close implements the Executor interface.
+func (e *AdaptiveJoinExec) Close() error {
+	if e.stats != nil {
+		defer e.Ctx().GetSessionVars().StmtCtx.RuntimeStatsColl.RegisterStats(e.ID(), e.stats)
+	}
+	var err error
+	if e.chosen != nil {
+		err = exec.Close(e.chosen)
+		e.chosen = nil
+	}
+	if closeErr := exec.Close(e.Outer); err == nil {
+		err = closeErr
+	}
+	if e.memTracker != nil {
+		e.memTracker.Detach()
+		e.memTracker = nil
+	}
+	return err
+}
+
+// AdaptiveJoinOuterExec is the outer side shared by the joins of an AdaptiveJoinExec. It returns the
+// rows read by the AdaptiveJoinExec to choose the join strategy, and then the remaining rows of the child.
+type AdaptiveJoinOuterExec struct {
+	exec.BaseExecutor
+
+	opened   bool
+	buffered []*chunk.Chunk
+	drained  bool
+	// memTracker tracks the memory of the buffered chunks, which is released once they are returned.
+	memTracker *memory.Tracker
+}
+
+// NewAdaptiveJoinOuterExec creates a new AdaptiveJoinOuterExec on the outer child.
+func NewAdaptiveJoinOuterExec(ctx sessionctx.Context, outer exec.Executor) *AdaptiveJoinOuterExec {
+	return &AdaptiveJoinOuterExec{
+		BaseExecutor: exec.NewBaseExecutor(ctx, outer.Schema(), 0, outer),
+	}
+}
+
+// Open implements the Executor interface. The child is opened only once although both the
+// AdaptiveJoinExec and the chosen join open it.
+func (e *AdaptiveJoinOuterExec) Open(ctx context.Context) error {
+	if e.opened {
+		return nil
+	}
+	e.opened = true
+	e.buffered, e.drained = nil, false
+	return e.BaseExecutor.Open(ctx)
+}
+
+// bufferRows reads the rows of the child until more than threshold rows are read, it returns whether
+// the threshold is exceeded.
+func (e *AdaptiveJoinOuterExec) bufferRows(ctx context.Context, threshold int, memTracker *memory.Tracker) (bool, error) {
+	e.memTracker = memTracker
+	rows := 0
+	for rows <= threshold {
+		chk := exec.NewFirstChunk(e.Children(0))
+		if err := exec.Next(ctx, e.Children(0), chk); err != nil {
+			return false, err
+		}
+		if chk.NumRows() == 0 {
+			e.drained = true
+			return false, nil
+		}
+		rows += chk.NumRows()
+		memTracker.Consume(chk.MemoryUsage())
+		e.buffered = append(e.buffered, chk)
+	}
+	return true, nil
+}
+
+// Next implements the Executor interface.
+func (e *AdaptiveJoinOuterExec) Next(ctx context.Context, req *chunk.Chunk) error {
+	req.Reset()
+	if len(e.buffered) > 0 {
+		e.memTracker.Consume(-e.buffered[0].MemoryUsage())
+		req.SwapColumns(e.buffered[0])
+		e.buffered = e.buffered[1:]
+		return nil
+	}
+	if e.drained {
+		return nil
+	}
+	return exec.Next(ctx, e.Children(0), req)
+}
+
+// Close implements the Executor interface.
+func (e *AdaptiveJoinOuterExec) Close() error {
+	if !e.opened {
+		return nil
+	}
+	e.opened = false
+	if e.memTracker != nil {
+		for _, chk := range e.buffered {
+			e.memTracker.Consume(-chk.MemoryUsage())
+		}
+		e.memTracker = nil
+	}
+	e.buffered = nil
+	return e.BaseExecutor.Close()
+}
+
+type adaptiveJoinRuntimeStats struct {
+	strategy  string
+	threshold int
+}
+
+func (e *adaptiveJoinRuntimeStats) String() string {
+	buf := bytes.NewBuffer(make([]byte, 0, 32))
+	buf.WriteString("adaptive:{strategy:")
+	buf.WriteString(e.strategy)
+	buf.WriteString(", outer_rows_threshold:")
+	buf.WriteString(strconv.Itoa(e.threshold))
+	buf.WriteString("}")
+	return buf.String()
+}
+
+func (e *adaptiveJoinRuntimeStats) Clone() execdetails.RuntimeStats {
+	newRs := *e
+	return &newRs
+}
+
+func (e *adaptiveJoinRuntimeStats) Merge(rs execdetails.RuntimeStats) {
+	tmp, ok := rs.(*adaptiveJoinRuntimeStats)
+	if !ok {
+		return
+	}
+	// The join may be executed several times, e.g. it's the inner side of an Apply.
+	if tmp.strategy != e.strategy {
+		e.strategy = adaptiveJoinStrategyIndexJoin + "," + adaptiveJoinStrategyHashJoin
+	}
+}
+
+// Tp implements the RuntimeStats interface.
+func (*adaptiveJoinRuntimeStats) Tp() int {
+	return execdetails.TpAdaptiveJoinRuntimeStats
+}
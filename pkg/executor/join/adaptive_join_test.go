@@ -0,0 +1,80 @@
+// Copyright 2024 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package join
+
+import (
+	"context"
+	"testing"
+
+	"github.com/pingcap/tidb/pkg/executor/internal/exec"
+	"github.com/pingcap/tidb/pkg/executor/internal/testutil"
+	"github.com/pingcap/tidb/pkg/expression"
+	"github.com/pingcap/tidb/pkg/parser/mysql"
+	"github.com/pingcap/tidb/pkg/types"
+	"github.com/pingcap/tidb/pkg/util/memory"
+	"github.com/pingcap/tidb/pkg/util/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAdaptiveJoinOuterReleaseMemory(t *testing.T) {
+	ctx := mock.NewContext()
+	ctx.GetSessionVars().InitChunkSize = 32
+	ctx.GetSessionVars().MaxChunkSize = 32
+	schema := expression.NewSchema(&expression.Column{RetType: types.NewFieldType(mysql.TypeLonglong)})
+	newOuter := func() *AdaptiveJoinOuterExec {
+		dataSource := testutil.BuildMockDataSource(testutil.MockDataSourceParameters{
+			DataSchema: schema,
+			Ctx:        ctx,
+			Rows:       100,
+			Ndvs:       []int{0},
+		})
+		dataSource.PrepareChunks()
+		return NewAdaptiveJoinOuterExec(ctx, dataSource)
+	}
+
+	// The memory of the buffered chunks is released once they are returned.
+	outer := newOuter()
+	memTracker := memory.NewTracker(0, -1)
+	require.NoError(t, outer.Open(context.Background()))
+	exceeded, err := outer.bufferRows(context.Background(), 40, memTracker)
+	require.NoError(t, err)
+	require.True(t, exceeded)
+	require.Len(t, outer.buffered, 2)
+	require.Greater(t, memTracker.BytesConsumed(), int64(0))
+	rows := 0
+	chk := exec.NewFirstChunk(outer)
+	for {
+		require.NoError(t, outer.Next(context.Background(), chk))
+		if chk.NumRows() == 0 {
+			break
+		}
+		rows += chk.NumRows()
+		if len(outer.buffered) == 0 {
+			require.Equal(t, int64(0), memTracker.BytesConsumed())
+		}
+	}
+	require.Equal(t, 100, rows)
+	require.NoError(t, outer.Close())
+
+	// The memory of the chunks which are not returned is released on close.
+	outer = newOuter()
+	require.NoError(t, outer.Open(context.Background()))
+	exceeded, err = outer.bufferRows(context.Background(), 1000, memTracker)
+	require.NoError(t, err)
+	require.False(t, exceeded)
+	require.Greater(t, memTracker.BytesConsumed(), int64(0))
+	require.NoError(t, outer.Close())
+	require.Equal(t, int64(0), memTracker.BytesConsumed())
+}
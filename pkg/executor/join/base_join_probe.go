@@ -108,6 +108,7 @@ type baseJoinProbe struct {
 	filterVector                  []bool              // if there is filter before probe, filterVector saves the filter result
 	nullKeyVector                 []bool              // nullKeyVector[i] = true if any of the key is null
 	hashValues                    [][]posAndHashValue // the start address of each matched rows
+	spilledRows                   [][]int             // the logical index of the probe rows that belong to the spilled partitions
 	currentProbeRow               int
 	matchedRowsForCurrentProbeRow int
 	chunkRows                     int
@@ -126,7 +127,7 @@ type baseJoinProbe struct {
 	lUsedInOtherCondition, rUsedInOtherCondition []int
 	// used when construct column from probe side
 	offsetAndLengthArray []offsetAndLength
-	// these 3 variables are used for join that has other condition, should be inited when the join has other condition
+	// these 3 variables are used for join that has other condition or is null aware, should be inited in such case
 	tmpChk        *chunk.Chunk
 	rowIndexInfos []*matchedRowInfo
 	selected      []bool
@@ -216,6 +217,8 @@ func (j *baseJoinProbe) SetChunkForProbe(chk *chunk.Chunk) (err error) {
 	}
 	// generate hash value
 	hash := fnv.New64()
+	spillProbeRows := j.ctx.spillHelper != nil && j.ctx.spillHelper.needSpillProbeRows()
+	hasSpilledRows := false
 	for logicalRowIndex, physicalRowIndex := range j.usedRows {
 		if (j.filterVector != nil && !j.filterVector[physicalRowIndex]) || (j.nullKeyVector != nil && j.nullKeyVector[physicalRowIndex]) {
 			// explicit set the matchedRowsHeaders[logicalRowIndex] to nil to indicate there is no matched rows
@@ -228,8 +231,21 @@ func (j *baseJoinProbe) SetChunkForProbe(chk *chunk.Chunk) (err error) {
 		_, _ = hash.Write(j.serializedKeys[logicalRowIndex])
 		hashValue := hash.Sum64()
 		partIndex := hashValue % uint64(j.ctx.PartitionNumber)
+		if spillProbeRows && j.ctx.spillHelper.spilledPartitions[partIndex] {
+			if !hasSpilledRows {
+				hasSpilledRows = true
+				for i := range j.spilledRows {
+					j.spilledRows[i] = j.spilledRows[i][:0]
+				}
+			}
+			j.spilledRows[partIndex] = append(j.spilledRows[partIndex], logicalRowIndex)
+			continue
+		}
 		j.hashValues[partIndex] = append(j.hashValues[partIndex], posAndHashValue{hashValue: hashValue, pos: logicalRowIndex})
 	}
+	if hasSpilledRows {
+		return j.spillProbeRowsAndResetChunk(chk)
+	}
 	j.currentProbeRow = 0
 	for i := 0; i < j.ctx.PartitionNumber; i++ {
 		for index := range j.hashValues[i] {
@@ -239,6 +255,33 @@ func (j *baseJoinProbe) SetChunkForProbe(chk *chunk.Chunk) (err error) {
 	return
 }
 
+// spillProbeRowsAndResetChunk spills the probe rows that belong to the spilled partitions, and sets the chunk
+// for probe again with the remaining rows.
+func (j *baseJoinProbe) spillProbeRowsAndResetChunk(chk *chunk.Chunk) error {
+	isSpilled := make([]bool, j.chunkRows)
+	for partIndex, rows := range j.spilledRows {
+		if len(rows) == 0 {
+			continue
+		}
+		if err := j.ctx.spillHelper.spillProbeRows(int(j.workID), partIndex, chk, rows); err != nil {
+			return err
+		}
+		for _, logicalRowIndex := range rows {
+			isSpilled[logicalRowIndex] = true
+		}
+	}
+	sel := make([]int, 0, j.chunkRows)
+	for logicalRowIndex, physicalRowIndex := range j.usedRows {
+		if !isSpilled[logicalRowIndex] {
+			sel = append(sel, physicalRowIndex)
+		}
+	}
+	chk.SetSel(sel)
+	// the spilled rows are removed from the chunk, so no rows will be spilled when setting the chunk again
+	j.currentChunk = nil
+	return j.SetChunkForProbe(chk)
+}
+
 func (j *baseJoinProbe) finishLookupCurrentProbeRow() {
 	if j.matchedRowsForCurrentProbeRow > 0 {
 		j.offsetAndLengthArray = append(j.offsetAndLengthArray, offsetAndLength{offset: j.usedRows[j.currentProbeRow], length: j.matchedRowsForCurrentProbeRow})
@@ -276,7 +319,7 @@ func (j *baseJoinProbe) prepareForProbe(chk *chunk.Chunk) (joinedChk *chunk.Chun
 	j.cachedBuildRows = j.cachedBuildRows[:0]
 	j.matchedRowsForCurrentProbeRow = 0
 	joinedChk = chk
-	if j.ctx.OtherCondition != nil {
+	if j.tmpChk != nil {
 		j.tmpChk.Reset()
 		j.rowIndexInfos = j.rowIndexInfos[:0]
 		j.selected = j.selected[:0]
@@ -512,6 +555,7 @@ func NewJoinProbe(ctx *HashJoinCtxV2, workID uint, joinType core.JoinType, keyIn
 	for i := 0; i < ctx.PartitionNumber; i++ {
 		base.hashValues[i] = make([]posAndHashValue, 0, chunk.InitialCapacity)
 	}
+	base.spilledRows = make([][]int, ctx.PartitionNumber)
 	base.serializedKeys = make([][]byte, 0, chunk.InitialCapacity)
 	if base.ctx.ProbeFilter != nil {
 		base.filterVector = make([]bool, 0, chunk.InitialCapacity)
@@ -519,7 +563,8 @@ func NewJoinProbe(ctx *HashJoinCtxV2, workID uint, joinType core.JoinType, keyIn
 	if base.hasNullableKey {
 		base.nullKeyVector = make([]bool, 0, chunk.InitialCapacity)
 	}
-	if base.ctx.OtherCondition != nil {
+	// null aware join always needs to evaluate the join key conditions on the joined rows
+	if base.ctx.OtherCondition != nil || base.ctx.IsNullAware {
 		base.tmpChk = chunk.NewChunkWithCapacity(joinedColumnTypes, chunk.InitialCapacity)
 		base.tmpChk.SetInCompleteChunk(true)
 		base.selected = make([]bool, 0, chunk.InitialCapacity)
@@ -532,6 +577,14 @@ func NewJoinProbe(ctx *HashJoinCtxV2, workID uint, joinType core.JoinType, keyIn
 		return newOuterJoinProbe(base, !rightAsBuildSide, rightAsBuildSide)
 	case core.RightOuterJoin:
 		return newOuterJoinProbe(base, rightAsBuildSide, rightAsBuildSide)
+	case core.SemiJoin:
+		return newSemiJoinProbe(base, false, false)
+	case core.AntiSemiJoin:
+		return newSemiJoinProbe(base, true, false)
+	case core.LeftOuterSemiJoin:
+		return newSemiJoinProbe(base, false, true)
+	case core.AntiLeftOuterSemiJoin:
+		return newSemiJoinProbe(base, true, true)
 	default:
 		panic("unsupported join type")
 	}
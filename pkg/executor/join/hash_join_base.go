@@ -59,6 +59,8 @@ type hashJoinCtxBase struct {
 	IsNullAware   bool
 	memTracker    *memory.Tracker // track memory usage.
 	diskTracker   *disk.Tracker   // track disk usage.
+	// RuntimeFilters are built from the build side, and pushed down to the probe side before it's fetched.
+	RuntimeFilters []*RuntimeFilter
 }
 
 type probeSideTupleFetcherBase struct {
@@ -131,6 +133,10 @@ func wait4BuildSide(isBuildEmpty isBuildSideEmpty, canSkipIfBuildEmpty, needScan
 		if !needScanAfterProbeDone {
 			hashJoinCtx.finished.Store(true)
 		}
+	} else {
+		for _, rf := range hashJoinCtx.RuntimeFilters {
+			rf.Target.PushRuntimeFilter(rf)
+		}
 	}
 	return skipProbe
 }
@@ -161,6 +167,13 @@ func (fetcher *probeSideTupleFetcherBase) getProbeSideResource(shouldLimitProbeF
 // and sends the chunks to multiple channels which will be read by multiple join workers.
 func (fetcher *probeSideTupleFetcherBase) fetchProbeSideChunks(ctx context.Context, maxChunkSize int, isBuildEmpty isBuildSideEmpty, canSkipIfBuildEmpty, needScanAfterProbeDone, shouldLimitProbeFetchSize bool, hashJoinCtx *hashJoinCtxBase) {
 	hasWaitedForBuild := false
+	if len(hashJoinCtx.RuntimeFilters) > 0 {
+		// The runtime filters must be pushed down before the probe side sends its requests.
+		if wait4BuildSide(isBuildEmpty, canSkipIfBuildEmpty, needScanAfterProbeDone, hashJoinCtx) {
+			return
+		}
+		hasWaitedForBuild = true
+	}
 	for {
 		probeSideResource := fetcher.getProbeSideResource(shouldLimitProbeFetchSize, maxChunkSize, hashJoinCtx)
 		if probeSideResource == nil {
@@ -240,6 +253,9 @@ func (w *buildWorkerBase) fetchBuildSideRows(ctx context.Context, hashJoinCtx *h
 		}
 	})
 	sessVars := hashJoinCtx.SessCtx.GetSessionVars()
+	for _, rf := range hashJoinCtx.RuntimeFilters {
+		rf.reset()
+	}
 	failpoint.Inject("issue51998", func(val failpoint.Value) {
 		if val.(bool) {
 			time.Sleep(2 * time.Second)
@@ -265,6 +281,12 @@ func (w *buildWorkerBase) fetchBuildSideRows(ctx context.Context, hashJoinCtx *h
 		if chk.NumRows() == 0 {
 			return
 		}
+		for _, rf := range hashJoinCtx.RuntimeFilters {
+			if err = rf.insert(sessVars.StmtCtx.TypeCtx(), chk); err != nil {
+				errCh <- errors.Trace(err)
+				return
+			}
+		}
 		select {
 		case <-doneCh:
 			return
@@ -0,0 +1,332 @@
+// Copyright 2024 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package join
+
+import (
+	"cmp"
+	"slices"
+	"sync"
+	"sync/atomic"
+
+	"github.com/pingcap/tidb/pkg/parser/mysql"
+	"github.com/pingcap/tidb/pkg/types"
+	"github.com/pingcap/tidb/pkg/util/chunk"
+	"github.com/pingcap/tidb/pkg/util/disk"
+	"github.com/pingcap/tidb/pkg/util/logutil"
+	"github.com/pingcap/tidb/pkg/util/memory"
+	"go.uber.org/zap"
+)
+
+const spillLogInfo = "memory exceeds quota, spill partitions of hash join v2 to disk"
+
+// spilledBuildRowFieldTypes is the schema of the chunks used to save the spilled build rows, each row contains
+// the raw data of the row in row table, the hash value of the join key and whether the join key is valid
+var spilledBuildRowFieldTypes = []*types.FieldType{
+	types.NewFieldType(mysql.TypeLongBlob),
+	types.NewFieldTypeBuilder().SetType(mysql.TypeLonglong).SetFlag(mysql.UnsignedFlag | mysql.NotNullFlag).BuildP(),
+	types.NewFieldTypeBuilder().SetType(mysql.TypeTiny).SetFlag(mysql.NotNullFlag).BuildP(),
+}
+
+// hashJoinSpillHelper spills the build side partitions of hash join v2 to disk when the memory usage exceeds
+// the quota. Once a partition is spilled, all its build rows and the probe rows belong to it are written to
+// disk, and the spilled partitions are restored and probed one by one after the in-memory partitions are done.
+// Partitions are only spilled during the build stage. A restored partition is not split or spilled again, so
+// if it alone exceeds the quota, the action falls back to the next one, e.g. cancelling the query. The null
+// aware joins don't spill.
+type hashJoinSpillHelper struct {
+	hashJoinCtx *HashJoinCtxV2
+	diskTracker *disk.Tracker
+	spillAction *hashJoinSpillAction
+	probeTypes  []*types.FieldType
+
+	// spillTriggered is set once the memory exceeds the quota, the build workers check it after each chunk
+	spillTriggered atomic.Bool
+
+	lock sync.Mutex
+	// needSpill means new partitions need to be spilled, it is protected by lock
+	needSpill bool
+	// buildStageDone is set after all the build rows are split into partitions, no partition can be spilled
+	// after that, it is protected by lock
+	buildStageDone bool
+	// spilledPartitions is written with the lock held during the build stage, and is read only after that
+	spilledPartitions   []bool
+	spilledPartitionIDs []int
+	// restoring is set when the spilled partitions are being restored, the probe rows are not spilled any more
+	restoring bool
+
+	// buildRowsInDisk and probeRowsInDisk are indexed by partition and worker
+	buildRowsInDisk [][]*chunk.DataInDiskByChunks
+	probeRowsInDisk [][]*chunk.DataInDiskByChunks
+}
+
+func newHashJoinSpillHelper(hashJoinCtx *HashJoinCtxV2, diskTracker *disk.Tracker, probeTypes []*types.FieldType) *hashJoinSpillHelper {
+	helper := &hashJoinSpillHelper{
+		hashJoinCtx:       hashJoinCtx,
+		diskTracker:       diskTracker,
+		probeTypes:        probeTypes,
+		spilledPartitions: make([]bool, hashJoinCtx.PartitionNumber),
+		buildRowsInDisk:   make([][]*chunk.DataInDiskByChunks, hashJoinCtx.PartitionNumber),
+		probeRowsInDisk:   make([][]*chunk.DataInDiskByChunks, hashJoinCtx.PartitionNumber),
+	}
+	for i := 0; i < hashJoinCtx.PartitionNumber; i++ {
+		helper.buildRowsInDisk[i] = make([]*chunk.DataInDiskByChunks, hashJoinCtx.Concurrency)
+		helper.probeRowsInDisk[i] = make([]*chunk.DataInDiskByChunks, hashJoinCtx.Concurrency)
+	}
+	helper.spillAction = &hashJoinSpillAction{helper: helper}
+	return helper
+}
+
+// setNeedSpill is called by the spill action, it returns false if no more partitions can be spilled.
+func (h *hashJoinSpillHelper) setNeedSpill(t *memory.Tracker) bool {
+	h.lock.Lock()
+	defer h.lock.Unlock()
+	if h.buildStageDone || len(h.spilledPartitionIDs) == h.hashJoinCtx.PartitionNumber {
+		return false
+	}
+	if !h.needSpill {
+		logutil.BgLogger().Info(spillLogInfo,
+			zap.Int("spilledPartitions", len(h.spilledPartitionIDs)),
+			zap.Int64("consumed", t.BytesConsumed()),
+			zap.Int64("quota", t.GetBytesLimit()))
+		h.needSpill = true
+		h.spillTriggered.Store(true)
+	}
+	return true
+}
+
+func (h *hashJoinSpillHelper) isSpillTriggered() bool {
+	return h.spillTriggered.Load()
+}
+
+// isSpilled returns true if any partition is spilled, it can only be called after the build stage.
+func (h *hashJoinSpillHelper) isSpilled() bool {
+	return len(h.spilledPartitionIDs) > 0
+}
+
+// needSpillProbeRows returns true if the probe rows belong to the spilled partitions need to be spilled, it can
+// only be called after the build stage.
+func (h *hashJoinSpillHelper) needSpillProbeRows() bool {
+	return len(h.spilledPartitionIDs) > 0 && !h.restoring
+}
+
+// choosePartitionsToSpillNoLock chooses the partitions that use the most memory, until at least half of the
+// memory used by the in-memory partitions is released.
+func (h *hashJoinSpillHelper) choosePartitionsToSpillNoLock() {
+	type partitionMemoryUsage struct {
+		partID      int
+		memoryUsage int64
+	}
+	candidates := make([]partitionMemoryUsage, 0, h.hashJoinCtx.PartitionNumber)
+	totalMemoryUsage := int64(0)
+	for partID := range h.hashJoinCtx.hashTableContext.partitionMemoryUsage {
+		if h.spilledPartitions[partID] {
+			continue
+		}
+		memoryUsage := h.hashJoinCtx.hashTableContext.partitionMemoryUsage[partID].Load()
+		if memoryUsage > 0 {
+			candidates = append(candidates, partitionMemoryUsage{partID: partID, memoryUsage: memoryUsage})
+			totalMemoryUsage += memoryUsage
+		}
+	}
+	if len(candidates) == 0 {
+		// no data to spill yet, wait for the next check
+		return
+	}
+	slices.SortFunc(candidates, func(a, b partitionMemoryUsage) int {
+		return cmp.Compare(b.memoryUsage, a.memoryUsage)
+	})
+	releasedMemory := int64(0)
+	for _, candidate := range candidates {
+		h.spilledPartitions[candidate.partID] = true
+		h.spilledPartitionIDs = append(h.spilledPartitionIDs, candidate.partID)
+		releasedMemory += candidate.memoryUsage
+		if releasedMemory*2 >= totalMemoryUsage {
+			break
+		}
+	}
+	h.needSpill = false
+}
+
+// spilledPartitionsOfBuildStage returns the partitions need to be spilled during the build stage, it chooses new
+// partitions to spill if needed. If isFinal is true, no partition can be spilled after this call.
+func (h *hashJoinSpillHelper) spilledPartitionsOfBuildStage(isFinal bool) []int {
+	h.lock.Lock()
+	defer h.lock.Unlock()
+	if h.needSpill {
+		h.choosePartitionsToSpillNoLock()
+	}
+	if isFinal {
+		h.buildStageDone = true
+	}
+	return slices.Clone(h.spilledPartitionIDs)
+}
+
+// spillBuildRows spills the finalized segments of the spilled partitions of a build worker to disk.
+func (h *hashJoinSpillHelper) spillBuildRows(workerID int, isFinal bool) error {
+	htCtx := h.hashJoinCtx.hashTableContext
+	for _, partID := range h.spilledPartitionsOfBuildStage(isFinal) {
+		rt := htCtx.rowTables[workerID][partID]
+		if rt == nil || len(rt.segments) == 0 {
+			continue
+		}
+		remainingSegments := rt.segments[:0]
+		for _, seg := range rt.segments {
+			if !seg.finalized {
+				// the segment is still being appended
+				remainingSegments = append(remainingSegments, seg)
+				continue
+			}
+			if err := h.spillSegment(workerID, partID, seg); err != nil {
+				return err
+			}
+			usedBytes := seg.totalUsedBytes()
+			htCtx.partitionMemoryUsage[partID].Add(-usedBytes)
+			htCtx.memoryTracker.Consume(-usedBytes)
+		}
+		rt.segments = remainingSegments
+	}
+	return nil
+}
+
+// spillRemainingBuildRows is called after all the build workers are done, it spills the build rows that
+// belong to the spilled partitions but are still in memory.
+func (h *hashJoinSpillHelper) spillRemainingBuildRows() error {
+	for workerID := range h.hashJoinCtx.hashTableContext.rowTables {
+		if err := h.spillBuildRows(workerID, true); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (h *hashJoinSpillHelper) spillSegment(workerID int, partID int, seg *rowTableSegment) error {
+	rowCount := int(seg.rowCount())
+	if rowCount == 0 {
+		return nil
+	}
+	chk := chunk.NewChunkWithCapacity(spilledBuildRowFieldTypes, rowCount)
+	validKeyIndex := 0
+	for i := 0; i < rowCount; i++ {
+		end := uint64(len(seg.rawData))
+		if i+1 < rowCount {
+			end = seg.rowStartOffset[i+1]
+		}
+		chk.AppendBytes(0, seg.rawData[seg.rowStartOffset[i]:end])
+		chk.AppendUint64(1, seg.hashValues[i])
+		// validJoinKeyPos is in ascending order
+		if validKeyIndex < len(seg.validJoinKeyPos) && seg.validJoinKeyPos[validKeyIndex] == i {
+			chk.AppendInt64(2, 1)
+			validKeyIndex++
+		} else {
+			chk.AppendInt64(2, 0)
+		}
+	}
+	if h.buildRowsInDisk[partID][workerID] == nil {
+		h.buildRowsInDisk[partID][workerID] = h.newDataInDisk(spilledBuildRowFieldTypes)
+	}
+	return h.buildRowsInDisk[partID][workerID].Add(chk)
+}
+
+// spillProbeRows spills the probe rows that belong to the spilled partition, rows is the logical row index in chk.
+func (h *hashJoinSpillHelper) spillProbeRows(workerID int, partID int, chk *chunk.Chunk, rows []int) error {
+	spilledChk := chunk.NewChunkWithCapacity(h.probeTypes, len(rows))
+	for _, rowIndex := range rows {
+		spilledChk.AppendRow(chk.GetRow(rowIndex))
+	}
+	if h.probeRowsInDisk[partID][workerID] == nil {
+		h.probeRowsInDisk[partID][workerID] = h.newDataInDisk(h.probeTypes)
+	}
+	return h.probeRowsInDisk[partID][workerID].Add(spilledChk)
+}
+
+func (h *hashJoinSpillHelper) newDataInDisk(fieldTypes []*types.FieldType) *chunk.DataInDiskByChunks {
+	inDisk := chunk.NewDataInDiskByChunks(fieldTypes)
+	inDisk.GetDiskTracker().AttachTo(h.diskTracker)
+	return inDisk
+}
+
+// restoreRowTable restores the build rows of the spilled partition from disk.
+func (h *hashJoinSpillHelper) restoreRowTable(partID int) (*rowTable, error) {
+	htCtx := h.hashJoinCtx.hashTableContext
+	rt := newRowTable(h.hashJoinCtx.hashTableMeta)
+	var seg *rowTableSegment
+	finalizeSegment := func() {
+		if seg != nil {
+			seg.finalized = true
+			rt.segments = append(rt.segments, seg)
+			htCtx.memoryTracker.Consume(seg.totalUsedBytes())
+		}
+	}
+	for _, inDisk := range h.buildRowsInDisk[partID] {
+		if inDisk == nil {
+			continue
+		}
+		for chkIdx := 0; chkIdx < inDisk.NumChunks(); chkIdx++ {
+			chk, err := inDisk.GetChunk(chkIdx)
+			if err != nil {
+				return nil, err
+			}
+			for rowIndex := 0; rowIndex < chk.NumRows(); rowIndex++ {
+				if seg == nil || seg.rowCount() >= maxRowTableSegmentSize {
+					finalizeSegment()
+					seg = newRowTableSegment()
+				}
+				row := chk.GetRow(rowIndex)
+				if row.GetInt64(2) != 0 {
+					seg.validJoinKeyPos = append(seg.validJoinKeyPos, len(seg.hashValues))
+				}
+				seg.hashValues = append(seg.hashValues, row.GetUint64(1))
+				seg.rowStartOffset = append(seg.rowStartOffset, uint64(len(seg.rawData)))
+				seg.rawData = append(seg.rawData, row.GetBytes(0)...)
+			}
+		}
+	}
+	finalizeSegment()
+	return rt, nil
+}
+
+func (h *hashJoinSpillHelper) close() {
+	for _, inDisks := range [][][]*chunk.DataInDiskByChunks{h.buildRowsInDisk, h.probeRowsInDisk} {
+		for _, inDisksOfPartition := range inDisks {
+			for _, inDisk := range inDisksOfPartition {
+				if inDisk != nil {
+					inDisk.Close()
+				}
+			}
+		}
+	}
+}
+
+// hashJoinSpillAction implements memory.ActionOnExceed for hash join v2. If the memory quota of a query is
+// exceeded during the build stage, hashJoinSpillAction.Action makes the build workers spill partitions to disk.
+type hashJoinSpillAction struct {
+	memory.BaseOOMAction
+	helper *hashJoinSpillHelper
+}
+
+// Action implements the memory.ActionOnExceed interface.
+func (a *hashJoinSpillAction) Action(t *memory.Tracker) {
+	if a.helper.setNeedSpill(t) {
+		return
+	}
+	if fallback := a.GetFallback(); fallback != nil {
+		fallback.Action(t)
+	}
+}
+
+// GetPriority get the priority of the Action.
+func (*hashJoinSpillAction) GetPriority() int64 {
+	return memory.DefSpillPriority
+}
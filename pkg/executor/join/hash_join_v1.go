@@ -1164,6 +1164,9 @@ func (e *NestedLoopApplyExec) Close() error {
 		runtimeStats.SetConcurrencyInfo(execdetails.NewConcurrencyInfo("concurrency", 0))
 		defer e.Ctx().GetSessionVars().StmtCtx.RuntimeStatsColl.RegisterStats(e.ID(), runtimeStats)
 	}
+	if e.cache != nil {
+		e.cache.Close()
+	}
 	return exec.Close(e.OuterExec)
 }
 
@@ -1193,6 +1196,7 @@ func (e *NestedLoopApplyExec) Open(ctx context.Context) error {
 		e.cacheHitCounter = 0
 		e.cacheAccessCounter = 0
 		e.cache.GetMemTracker().AttachTo(e.memTracker)
+		e.cache.SetupSpill(e.Sctx, exec.RetTypes(e.InnerExec))
 	}
 	return nil
 }
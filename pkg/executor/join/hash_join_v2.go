@@ -23,12 +23,14 @@ import (
 	"sync"
 	"sync/atomic"
 	"time"
+	"unsafe"
 
 	"github.com/pingcap/failpoint"
 	"github.com/pingcap/tidb/pkg/executor/internal/exec"
 	"github.com/pingcap/tidb/pkg/expression"
 	"github.com/pingcap/tidb/pkg/parser/mysql"
 	plannercore "github.com/pingcap/tidb/pkg/planner/core"
+	"github.com/pingcap/tidb/pkg/sessionctx/variable"
 	"github.com/pingcap/tidb/pkg/types"
 	"github.com/pingcap/tidb/pkg/util"
 	"github.com/pingcap/tidb/pkg/util/channel"
@@ -63,11 +65,17 @@ type hashTableContext struct {
 	rowTables     [][]*rowTable
 	hashTable     *hashTableV2
 	memoryTracker *memory.Tracker
+	// partitionMemoryUsage is the memory used by the finalized segments of each partition, it is used to
+	// choose the partitions to spill
+	partitionMemoryUsage []atomic.Int64
+	// nullKeyRows is the build rows that have null keys, only used by null aware join
+	nullKeyRows []unsafe.Pointer
 }
 
 func (htc *hashTableContext) reset() {
 	htc.rowTables = nil
 	htc.hashTable = nil
+	htc.nullKeyRows = nil
 	htc.memoryTracker.Detach()
 }
 
@@ -94,6 +102,7 @@ func (htc *hashTableContext) finalizeCurrentSeg(workerID, partitionID int, build
 	builder.startPosInRawData[partitionID] = builder.startPosInRawData[partitionID][:0]
 	failpoint.Inject("finalizeCurrentSegPanic", nil)
 	seg.finalized = true
+	htc.partitionMemoryUsage[partitionID].Add(seg.totalUsedBytes())
 	htc.memoryTracker.Consume(seg.totalUsedBytes())
 }
 
@@ -137,6 +146,13 @@ type HashJoinCtxV2 struct {
 
 	LUsed, RUsed                                 []int
 	LUsedInOtherCondition, RUsedInOtherCondition []int
+	// EqualCondition and NAEqualCondition are the join key conditions resolved on the joined schema, they
+	// are only used by null aware join, which needs to compare the probe row with build rows of other keys
+	EqualCondition   expression.CNFExprs
+	NAEqualCondition expression.CNFExprs
+
+	// spillHelper is nil if spill is disabled
+	spillHelper *hashJoinSpillHelper
 }
 
 // initHashTableContext create hashTableContext for current HashJoinCtxV2
@@ -151,6 +167,7 @@ func (hCtx *HashJoinCtxV2) initHashTableContext() {
 		partitionNumber: uint64(hCtx.PartitionNumber),
 	}
 	hCtx.hashTableContext.memoryTracker = memory.NewTracker(memory.LabelForHashTableInHashJoinV2, -1)
+	hCtx.hashTableContext.partitionMemoryUsage = make([]atomic.Int64, hCtx.PartitionNumber)
 }
 
 // ProbeSideTupleFetcherV2 reads tuples from ProbeSideExec and send them to ProbeWorkers.
@@ -240,6 +257,11 @@ func (e *HashJoinV2Exec) Close() error {
 		e.ProbeSideTupleFetcher.probeChkResourceCh = nil
 		e.waiterWg.Wait()
 		e.hashTableContext.reset()
+		if e.spillHelper != nil {
+			e.Ctx().GetSessionVars().MemTracker.UnbindActionFromHardLimit(e.spillHelper.spillAction)
+			e.spillHelper.close()
+			e.spillHelper = nil
+		}
 	}
 	for _, w := range e.ProbeWorkers {
 		w.joinChkResourceCh = nil
@@ -316,12 +338,22 @@ func (w *BuildWorkerV2) splitPartitionAndAppendToRowTable(typeCtx types.Context,
 	partitionNumber := w.HashJoinCtx.PartitionNumber
 	hashJoinCtx := w.HashJoinCtx
 
-	builder := createRowTableBuilder(w.BuildKeyColIdx, hashJoinCtx.BuildKeyTypes, partitionNumber, w.HasNullableKey, hashJoinCtx.BuildFilter != nil, hashJoinCtx.needScanRowTableAfterProbeDone)
+	// null aware join needs the rows with null keys to decide whether the join result is NULL
+	keepFilteredRows := hashJoinCtx.needScanRowTableAfterProbeDone || hashJoinCtx.IsNullAware
+	builder := createRowTableBuilder(w.BuildKeyColIdx, hashJoinCtx.BuildKeyTypes, partitionNumber, w.HasNullableKey, hashJoinCtx.BuildFilter != nil, keepFilteredRows)
 
 	for chk := range srcChkCh {
 		start := time.Now()
 		err = builder.processOneChunk(chk, typeCtx, w.HashJoinCtx, int(w.WorkerID))
 		failpoint.Inject("splitPartitionPanic", nil)
+		failpoint.Inject("triggerHashJoinV2Spill", func(val failpoint.Value) {
+			if val.(bool) && hashJoinCtx.spillHelper != nil {
+				hashJoinCtx.spillHelper.setNeedSpill(hashJoinCtx.hashTableContext.memoryTracker)
+			}
+		})
+		if err == nil && hashJoinCtx.spillHelper != nil && hashJoinCtx.spillHelper.isSpillTriggered() {
+			err = hashJoinCtx.spillHelper.spillBuildRows(int(w.WorkerID), false)
+		}
 		cost += int64(time.Since(start))
 		if err != nil {
 			return err
@@ -368,7 +400,7 @@ func (e *HashJoinV2Exec) fetchAndProbeHashTable(ctx context.Context) {
 		e.ProbeSideTupleFetcher.fetchProbeSideChunks(
 			ctx,
 			e.MaxChunkSize(),
-			func() bool { return e.ProbeSideTupleFetcher.hashTableContext.hashTable.isHashTableEmpty() },
+			e.isBuildSideEmpty,
 			e.ProbeSideTupleFetcher.canSkipProbeIfHashTableIsEmpty,
 			e.ProbeSideTupleFetcher.needScanRowTableAfterProbeDone,
 			e.ProbeSideTupleFetcher.shouldLimitProbeFetchSize(),
@@ -398,8 +430,23 @@ func (e *HashJoinV2Exec) handleJoinWorkerPanic(r any) {
 	}
 }
 
+func (e *HashJoinV2Exec) isBuildSideEmpty() bool {
+	if e.spillHelper != nil && e.spillHelper.isSpilled() {
+		return false
+	}
+	return e.hashTableContext.hashTable.isHashTableEmpty()
+}
+
 func (e *HashJoinV2Exec) waitJoinWorkersAndCloseResultChan() {
 	e.workerWg.Wait()
+	e.scanRowTableAfterProbeDone()
+	if e.spillHelper != nil && e.spillHelper.isSpilled() {
+		e.probeSpilledPartitions()
+	}
+	close(e.joinResultCh)
+}
+
+func (e *HashJoinV2Exec) scanRowTableAfterProbeDone() {
 	if e.ProbeWorkers[0] != nil && e.ProbeWorkers[0].JoinProbe.NeedScanRowTable() {
 		for i := uint(0); i < e.Concurrency; i++ {
 			var workerID = i
@@ -409,7 +456,94 @@ func (e *HashJoinV2Exec) waitJoinWorkersAndCloseResultChan() {
 		}
 		e.workerWg.Wait()
 	}
-	close(e.joinResultCh)
+}
+
+// probeSpilledPartitions restores the spilled partitions one by one, and probes the restored hash table with
+// the spilled probe rows of the partition.
+func (e *HashJoinV2Exec) probeSpilledPartitions() {
+	e.spillHelper.restoring = true
+	for _, partID := range e.spillHelper.spilledPartitionIDs {
+		if e.finished.Load() {
+			return
+		}
+		restored := false
+		e.workerWg.RunWithRecover(func() {
+			if err := e.restoreHashTable(partID); err != nil {
+				e.joinResultCh <- &hashjoinWorkerResult{err: err}
+				return
+			}
+			restored = true
+		}, e.handleJoinWorkerPanic)
+		e.workerWg.Wait()
+		if !restored {
+			return
+		}
+		for i := uint(0); i < e.Concurrency; i++ {
+			workerID := i
+			e.workerWg.RunWithRecover(func() {
+				e.ProbeWorkers[workerID].probeSpilledRows(e.spillHelper.probeRowsInDisk[partID][workerID])
+			}, e.ProbeWorkers[workerID].handleProbeWorkerPanic)
+		}
+		e.workerWg.Wait()
+		e.scanRowTableAfterProbeDone()
+	}
+}
+
+// restoreHashTable replaces the hash table with a new one that only contains the rows of the spilled partition.
+func (e *HashJoinV2Exec) restoreHashTable(partID int) error {
+	htCtx := e.hashTableContext
+	// release the previous hash table before restoring
+	htCtx.hashTable = nil
+	htCtx.memoryTracker.Consume(-htCtx.memoryTracker.BytesConsumed())
+	rt, err := e.spillHelper.restoreRowTable(partID)
+	if err != nil {
+		return err
+	}
+	hashTable := &hashTableV2{
+		tables:          make([]*subTable, e.PartitionNumber),
+		partitionNumber: uint64(e.PartitionNumber),
+	}
+	for i := range hashTable.tables {
+		if i == partID {
+			hashTable.tables[i] = newSubTable(rt)
+		} else {
+			hashTable.tables[i] = newSubTable(newRowTable(e.hashTableMeta))
+		}
+	}
+	hashTable.tables[partID].build(0, len(rt.segments))
+	htCtx.hashTable = hashTable
+	return nil
+}
+
+func (w *ProbeWorkerV2) probeSpilledRows(inDisk *chunk.DataInDiskByChunks) {
+	if inDisk == nil {
+		return
+	}
+	ok, joinResult := w.getNewJoinResult()
+	if !ok {
+		return
+	}
+	for i := 0; i < inDisk.NumChunks(); i++ {
+		if w.HashJoinCtx.finished.Load() {
+			break
+		}
+		probeSideResult, err := inDisk.GetChunk(i)
+		if err != nil {
+			joinResult.err = err
+			break
+		}
+		ok, _, joinResult = w.processOneProbeChunk(probeSideResult, joinResult)
+		if !ok {
+			break
+		}
+	}
+	if joinResult == nil {
+		return
+	} else if joinResult.err != nil || (joinResult.chk != nil && joinResult.chk.NumRows() > 0) {
+		w.HashJoinCtx.joinResultCh <- joinResult
+	} else if joinResult.chk != nil && joinResult.chk.NumRows() == 0 {
+		w.joinChkResourceCh <- joinResult.chk
+	}
 }
 
 func (w *ProbeWorkerV2) scanRowTableAfterProbeDone() {
@@ -544,6 +678,12 @@ func (e *HashJoinV2Exec) Next(ctx context.Context, req *chunk.Chunk) (err error)
 	if !e.prepared {
 		e.initHashTableContext()
 		e.hashTableContext.memoryTracker.AttachTo(e.memTracker)
+		// spill is not supported by null aware join yet, because the probe rows with null keys need to be
+		// checked against the build rows of all the partitions, so the memory quota applies to it as before
+		if variable.EnableTmpStorageOnOOM.Load() && !e.IsNullAware {
+			e.spillHelper = newHashJoinSpillHelper(e.HashJoinCtxV2, e.diskTracker, exec.RetTypes(e.ProbeSideTupleFetcher.ProbeSideExec))
+			e.Ctx().GetSessionVars().MemTracker.FallbackOldAndSetNewAction(e.spillHelper.spillAction)
+		}
 		e.buildFinished = make(chan error, 1)
 		e.workerWg.RunWithRecover(func() {
 			defer trace.StartRegion(ctx, "HashJoinHashTableBuilder").End()
@@ -654,6 +794,12 @@ func (e *HashJoinV2Exec) fetchAndBuildHashTable(ctx context.Context) {
 	if !success {
 		return
 	}
+	if e.spillHelper != nil {
+		if err := e.spillHelper.spillRemainingBuildRows(); err != nil {
+			e.buildFinished <- err
+			return
+		}
+	}
 
 	totalSegmentCnt := e.hashTableContext.mergeRowTablesToHashTable(e.hashTableMeta, e.PartitionNumber)
 
@@ -663,7 +809,10 @@ func (e *HashJoinV2Exec) fetchAndBuildHashTable(ctx context.Context) {
 	doneCh = make(chan struct{}, e.Concurrency)
 	buildTaskCh := e.createBuildTasks(totalSegmentCnt, wg, errCh, doneCh)
 	e.buildHashTable(buildTaskCh, wg, errCh, doneCh)
-	waitJobDone(wg, errCh)
+	success = waitJobDone(wg, errCh)
+	if success && e.IsNullAware {
+		e.hashTableContext.nullKeyRows = e.hashTableContext.hashTable.collectNullKeyRows()
+	}
 }
 
 func (e *HashJoinV2Exec) fetchBuildSideRows(ctx context.Context, wg *sync.WaitGroup, errCh chan error, doneCh chan struct{}) chan *chunk.Chunk {
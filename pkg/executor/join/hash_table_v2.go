@@ -198,6 +198,25 @@ func (jht *hashTableV2) isHashTableEmpty() bool {
 	return true
 }
 
+// collectNullKeyRows returns all the rows that are not inserted into the hash table, i.e. the rows with null keys
+func (jht *hashTableV2) collectNullKeyRows() []unsafe.Pointer {
+	ret := make([]unsafe.Pointer, 0)
+	for _, table := range jht.tables {
+		for _, seg := range table.rowData.segments {
+			validKeyIndex := 0
+			for i := 0; i < int(seg.rowCount()); i++ {
+				// validJoinKeyPos is in ascending order
+				if validKeyIndex < len(seg.validJoinKeyPos) && seg.validJoinKeyPos[validKeyIndex] == i {
+					validKeyIndex++
+					continue
+				}
+				ret = append(ret, seg.getRowPointer(i))
+			}
+		}
+	}
+	return ret
+}
+
 func (jht *hashTableV2) totalRowCount() uint64 {
 	ret := uint64(0)
 	for _, table := range jht.tables {
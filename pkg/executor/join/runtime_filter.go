@@ -0,0 +1,190 @@
+// Copyright 2024 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package join
+
+import (
+	"slices"
+
+	"github.com/pingcap/tidb/pkg/expression"
+	"github.com/pingcap/tidb/pkg/parser/ast"
+	"github.com/pingcap/tidb/pkg/parser/mysql"
+	"github.com/pingcap/tidb/pkg/sessionctx/variable"
+	"github.com/pingcap/tidb/pkg/types"
+	"github.com/pingcap/tidb/pkg/util/chunk"
+	"github.com/pingcap/tidb/pkg/util/codec"
+	"github.com/pingcap/tidb/pkg/util/collate"
+)
+
+// maxRuntimeFilterInValues is the max number of distinct join keys of an IN runtime filter. The IN
+// filter is given up if the build side has more distinct join keys than it.
+const maxRuntimeFilterInValues = 1024
+
+// RuntimeFilterTarget is the table scan on the probe side of a hash join, which accepts the runtime
+// filters of the hash join.
+type RuntimeFilterTarget interface {
+	// PushRuntimeFilter is called after the build side of the hash join is finished and before the
+	// probe side is fetched.
+	PushRuntimeFilter(rf *RuntimeFilter)
+}
+
+// RuntimeFilter is built from the join keys of the build side of a hash join executed in TiDB. It's
+// converted to the conditions on a column of the table scan on the probe side, so the rows which can't
+// be joined are filtered out by the coprocessor requests of the scan. Only the IN and MIN_MAX filters are
+// built, since the TiKV coprocessor has no expression to evaluate a bloom filter.
+type RuntimeFilter struct {
+	ID   int
+	Type variable.RuntimeFilterType
+	// BuildKeyIdx is the offset of the join key in the rows of the build side.
+	BuildKeyIdx  int
+	BuildKeyType *types.FieldType
+	// TargetCol is the column of the table scan, its Index is the offset in the output of the scan.
+	TargetCol *expression.Column
+	Target    RuntimeFilterTarget
+
+	collator collate.Collator
+	hasValue bool
+	// minVal and maxVal are used by the MIN_MAX filter.
+	minVal types.Datum
+	maxVal types.Datum
+	// values and valueKeys are used by the IN filter.
+	values     []types.Datum
+	valueKeys  map[string]struct{}
+	inOverflow bool
+}
+
+func (rf *RuntimeFilter) reset() {
+	rf.collator = collate.GetCollator(rf.BuildKeyType.GetCollate())
+	rf.hasValue = false
+	rf.minVal, rf.maxVal = types.Datum{}, types.Datum{}
+	rf.values, rf.valueKeys = nil, nil
+	rf.inOverflow = false
+}
+
+// insert adds the join keys of the build side chunk to the filter. The NULL keys are skipped since
+// they can't be matched by the equal conditions.
+func (rf *RuntimeFilter) insert(tc types.Context, chk *chunk.Chunk) error {
+	if rf.Type == variable.In && rf.inOverflow {
+		return nil
+	}
+	col := chk.Column(rf.BuildKeyIdx)
+	for i := 0; i < chk.NumRows(); i++ {
+		if col.IsNull(i) {
+			continue
+		}
+		d := chk.GetRow(i).GetDatum(rf.BuildKeyIdx, rf.BuildKeyType)
+		switch rf.Type {
+		case variable.MinMax:
+			if !rf.hasValue {
+				d.Copy(&rf.minVal)
+				d.Copy(&rf.maxVal)
+				break
+			}
+			cmp, err := d.Compare(tc, &rf.minVal, rf.collator)
+			if err != nil {
+				return err
+			}
+			if cmp < 0 {
+				d.Copy(&rf.minVal)
+			}
+			cmp, err = d.Compare(tc, &rf.maxVal, rf.collator)
+			if err != nil {
+				return err
+			}
+			if cmp > 0 {
+				d.Copy(&rf.maxVal)
+			}
+		case variable.In:
+			key, err := codec.EncodeKey(tc.Location(), nil, d)
+			if err != nil {
+				return err
+			}
+			if rf.valueKeys == nil {
+				rf.valueKeys = make(map[string]struct{})
+			}
+			if _, ok := rf.valueKeys[string(key)]; ok {
+				continue
+			}
+			if len(rf.values) >= maxRuntimeFilterInValues {
+				rf.inOverflow = true
+				rf.values, rf.valueKeys = nil, nil
+				return nil
+			}
+			rf.valueKeys[string(key)] = struct{}{}
+			rf.values = append(rf.values, *d.Clone())
+		}
+		rf.hasValue = true
+	}
+	return nil
+}
+
+// MinMax returns the min and max join keys of the MIN_MAX filter.
+func (rf *RuntimeFilter) MinMax() (minVal, maxVal types.Datum, ok bool) {
+	if rf.Type != variable.MinMax || !rf.hasValue {
+		return minVal, maxVal, false
+	}
+	return rf.minVal, rf.maxVal, true
+}
+
+// Values returns the sorted distinct join keys of the IN filter.
+func (rf *RuntimeFilter) Values(tc types.Context) ([]types.Datum, bool) {
+	if rf.Type != variable.In || !rf.hasValue || rf.inOverflow {
+		return nil, false
+	}
+	values := slices.Clone(rf.values)
+	var err error
+	slices.SortFunc(values, func(a, b types.Datum) int {
+		cmp, e := a.Compare(tc, &b, rf.collator)
+		if e != nil {
+			err = e
+		}
+		return cmp
+	})
+	return values, err == nil
+}
+
+// Conditions converts the filter to the conditions on the target column.
+func (rf *RuntimeFilter) Conditions(ctx expression.BuildContext) ([]expression.Expression, error) {
+	if !rf.hasValue {
+		return nil, nil
+	}
+	retType := types.NewFieldType(mysql.TypeTiny)
+	switch rf.Type {
+	case variable.MinMax:
+		geCond, err := expression.NewFunction(ctx, ast.GE, retType, rf.TargetCol, &expression.Constant{Value: rf.minVal, RetType: rf.BuildKeyType})
+		if err != nil {
+			return nil, err
+		}
+		leCond, err := expression.NewFunction(ctx, ast.LE, retType, rf.TargetCol, &expression.Constant{Value: rf.maxVal, RetType: rf.BuildKeyType})
+		if err != nil {
+			return nil, err
+		}
+		return []expression.Expression{geCond, leCond}, nil
+	case variable.In:
+		if rf.inOverflow {
+			return nil, nil
+		}
+		args := make([]expression.Expression, 0, len(rf.values)+1)
+		args = append(args, rf.TargetCol)
+		for _, v := range rf.values {
+			args = append(args, &expression.Constant{Value: v, RetType: rf.BuildKeyType})
+		}
+		inCond, err := expression.NewFunction(ctx, ast.In, retType, args...)
+		if err != nil {
+			return nil, err
+		}
+		return []expression.Expression{inCond}, nil
+	}
+	return nil, nil
+}
@@ -0,0 +1,396 @@
+// Copyright 2024 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package join
+
+import (
+	"sync/atomic"
+	"unsafe"
+
+	"github.com/pingcap/tidb/pkg/expression"
+	"github.com/pingcap/tidb/pkg/util/chunk"
+	"github.com/pingcap/tidb/pkg/util/sqlkiller"
+)
+
+type semiJoinMatchStatus uint8
+
+const (
+	semiJoinNotMatched semiJoinMatchStatus = iota
+	semiJoinMatched
+	// semiJoinMatchedNull means no build row is matched, but the result of the join condition is NULL for some
+	// build rows, it only matters for (anti) left outer semi join and null aware anti join
+	semiJoinMatchedNull
+)
+
+type nullAwareScanType uint8
+
+const (
+	nullAwareScanNone nullAwareScanType = iota
+	// nullAwareScanNullKeyRows means the probe row need to be compared with all the build rows that have null keys
+	nullAwareScanNullKeyRows
+	// nullAwareScanAllRows means the probe row has null in the null aware keys, so it need to be compared with all the build rows
+	nullAwareScanAllRows
+)
+
+// semiJoinProbe is used for semi join, anti semi join, left outer semi join and anti left outer semi join. The inner
+// side(right side) is always used to build the hash table, and the probe side only needs to know whether a probe row
+// has matched build rows, so the build side columns are never output.
+type semiJoinProbe struct {
+	baseJoinProbe
+	// isAnti is true for anti semi join and anti left outer semi join
+	isAnti bool
+	// isLeftOuter is true for left outer semi join and anti left outer semi join, all the probe rows are output with
+	// an extra column which shows whether the probe row is matched
+	isLeftOuter bool
+	// matchStatus and outputRows are indexed by logical row index
+	matchStatus []semiJoinMatchStatus
+	outputRows  []bool
+	// joinedRowConditions is the conditions evaluated on the joined rows, if nullableConditions[i] is true, NULL
+	// result of joinedRowConditions[i] makes the joined row NULL, otherwise it is treated as false
+	joinedRowConditions  expression.CNFExprs
+	nullableConditions   []bool
+	hasNullableCondition bool
+	conditionSelected    []bool
+	conditionIsNull      []bool
+	isNull               []bool
+	// the following variables are only used by null aware join, nullAwareScanTypes is indexed by logical row index
+	nullAwareScanTypes []nullAwareScanType
+	isScanning         bool
+	scanRowIter        *rowIter
+	nullKeyRowIndex    int
+}
+
+func newSemiJoinProbe(base baseJoinProbe, isAnti bool, isLeftOuter bool) *semiJoinProbe {
+	probe := &semiJoinProbe{
+		baseJoinProbe: base,
+		isAnti:        isAnti,
+		isLeftOuter:   isLeftOuter,
+	}
+	if base.ctx.IsNullAware {
+		// the probe row may be compared with the build rows whose keys are not equal, so the equal conditions
+		// need to be evaluated as well
+		probe.appendJoinedRowConditions(base.ctx.EqualCondition, false)
+	}
+	for _, cond := range base.ctx.OtherCondition {
+		// same as the joiner used in hash join v1, NULL only matters for the eq condition converted from `[not] in (subq)`
+		probe.appendJoinedRowConditions([]expression.Expression{cond}, isLeftOuter && expression.IsEQCondFromIn(cond))
+	}
+	if base.ctx.IsNullAware {
+		probe.appendJoinedRowConditions(base.ctx.NAEqualCondition, true)
+	}
+	return probe
+}
+
+func (j *semiJoinProbe) appendJoinedRowConditions(conditions []expression.Expression, nullable bool) {
+	for _, cond := range conditions {
+		j.joinedRowConditions = append(j.joinedRowConditions, cond)
+		j.nullableConditions = append(j.nullableConditions, nullable)
+		j.hasNullableCondition = j.hasNullableCondition || nullable
+	}
+}
+
+func (j *semiJoinProbe) SetChunkForProbe(chunk *chunk.Chunk) (err error) {
+	err = j.baseJoinProbe.SetChunkForProbe(chunk)
+	if err != nil {
+		return err
+	}
+	j.matchStatus = j.matchStatus[:0]
+	j.outputRows = j.outputRows[:0]
+	for i := 0; i < j.chunkRows; i++ {
+		j.matchStatus = append(j.matchStatus, semiJoinNotMatched)
+		j.outputRows = append(j.outputRows, false)
+	}
+	if j.ctx.IsNullAware {
+		j.initNullAwareScanTypes()
+	}
+	return nil
+}
+
+func (j *semiJoinProbe) initNullAwareScanTypes() {
+	hashTableCtx := j.ctx.hashTableContext
+	hasNullKeyRows := len(hashTableCtx.nullKeyRows) > 0
+	hasBuildRows := hashTableCtx.hashTable.totalRowCount() > 0
+	j.nullAwareScanTypes = j.nullAwareScanTypes[:0]
+	j.isScanning = false
+	for _, physicalRowIndex := range j.usedRows {
+		scanType := nullAwareScanNone
+		if j.filterVector != nil && !j.filterVector[physicalRowIndex] {
+			// filtered by probe filter, it is never matched
+			scanType = nullAwareScanNone
+		} else if j.nullKeyVector != nil && j.nullKeyVector[physicalRowIndex] {
+			// null in the equal keys means not matched, while null in the null aware keys means the
+			// probe row need to be compared with all the build rows
+			if hasBuildRows && !j.hasNullInEqualKeys(physicalRowIndex) {
+				scanType = nullAwareScanAllRows
+			}
+		} else if hasNullKeyRows {
+			scanType = nullAwareScanNullKeyRows
+		}
+		j.nullAwareScanTypes = append(j.nullAwareScanTypes, scanType)
+	}
+}
+
+func (j *semiJoinProbe) hasNullInEqualKeys(physicalRowIndex int) bool {
+	for _, colIndex := range j.keyIndex[:len(j.ctx.EqualCondition)] {
+		if j.currentChunk.Column(colIndex).IsNull(physicalRowIndex) {
+			return true
+		}
+	}
+	return false
+}
+
+func (*semiJoinProbe) NeedScanRowTable() bool {
+	return false
+}
+
+func (*semiJoinProbe) ScanRowTable(*hashjoinWorkerResult, *sqlkiller.SQLKiller) *hashjoinWorkerResult {
+	panic("should not reach here")
+}
+
+func (*semiJoinProbe) InitForScanRowTable() {
+	panic("should not reach here")
+}
+
+func (*semiJoinProbe) IsScanRowTableDone() bool {
+	panic("should not reach here")
+}
+
+// isMatchStatusDecided returns true if the remaining build rows can not change the join result of the probe row
+func (j *semiJoinProbe) isMatchStatusDecided(probeRowIndex int) bool {
+	status := j.matchStatus[probeRowIndex]
+	// for (anti) left outer semi join, a matched build row overrides the NULL result
+	return status == semiJoinMatched || (status == semiJoinMatchedNull && !j.isLeftOuter)
+}
+
+func (j *semiJoinProbe) probeForInnerSideBuild(joinedChk *chunk.Chunk, remainCap int) {
+	meta := j.ctx.hashTableMeta
+	needEvalJoinedRows := len(j.joinedRowConditions) > 0
+
+	for remainCap > 0 && j.currentProbeRow < j.chunkRows {
+		if j.matchedRowsHeaders[j.currentProbeRow] != 0 && !j.isMatchStatusDecided(j.currentProbeRow) {
+			// hash value match
+			candidateRow := *(*unsafe.Pointer)(unsafe.Pointer(&j.matchedRowsHeaders[j.currentProbeRow]))
+			if isKeyMatched(meta.keyMode, j.serializedKeys[j.currentProbeRow], candidateRow, meta) {
+				// join key match
+				if needEvalJoinedRows {
+					j.appendBuildRowToCachedBuildRowsAndConstructBuildRowsIfNeeded(createMatchRowInfo(j.currentProbeRow, candidateRow), joinedChk, 0, true)
+					j.matchedRowsForCurrentProbeRow++
+				} else {
+					// has no other condition, key match means join match, the remaining build rows can be skipped
+					j.matchStatus[j.currentProbeRow] = semiJoinMatched
+				}
+			} else {
+				if j.ctx.stats != nil {
+					atomic.AddInt64(&j.ctx.stats.hashStat.probeCollision, 1)
+				}
+			}
+			j.matchedRowsHeaders[j.currentProbeRow] = getNextRowAddress(candidateRow)
+		} else if j.ctx.IsNullAware && j.nullAwareScanTypes[j.currentProbeRow] != nullAwareScanNone && !j.isMatchStatusDecided(j.currentProbeRow) {
+			// the build rows with the same key are all handled, for null aware join, the probe row still
+			// needs to be compared with the build rows that may make the join result NULL
+			if !j.appendNextScannedBuildRow(joinedChk) {
+				j.nullAwareScanTypes[j.currentProbeRow] = nullAwareScanNone
+			}
+		} else {
+			// it could be
+			// 1. no match when lookup the hash table
+			// 2. filter by probeFilter
+			// 3. the join result of current row is already decided
+			j.isScanning = false
+			j.finishLookupCurrentProbeRow()
+			j.currentProbeRow++
+		}
+		remainCap--
+	}
+}
+
+// appendNextScannedBuildRow appends the next build row need to be compared with current probe row for null aware
+// join, it returns false if all the build rows are scanned.
+func (j *semiJoinProbe) appendNextScannedBuildRow(joinedChk *chunk.Chunk) bool {
+	hashTableCtx := j.ctx.hashTableContext
+	scanType := j.nullAwareScanTypes[j.currentProbeRow]
+	if !j.isScanning {
+		if scanType == nullAwareScanAllRows {
+			j.scanRowIter = hashTableCtx.hashTable.createRowIter(0, hashTableCtx.hashTable.totalRowCount())
+		} else {
+			j.nullKeyRowIndex = 0
+		}
+		j.isScanning = true
+	}
+	var buildRow unsafe.Pointer
+	if scanType == nullAwareScanAllRows {
+		if j.scanRowIter.isEnd() {
+			j.isScanning = false
+			return false
+		}
+		buildRow = j.scanRowIter.getValue()
+		j.scanRowIter.next()
+	} else {
+		if j.nullKeyRowIndex >= len(hashTableCtx.nullKeyRows) {
+			j.isScanning = false
+			return false
+		}
+		buildRow = hashTableCtx.nullKeyRows[j.nullKeyRowIndex]
+		j.nullKeyRowIndex++
+	}
+	j.appendBuildRowToCachedBuildRowsAndConstructBuildRowsIfNeeded(createMatchRowInfo(j.currentProbeRow, buildRow), joinedChk, 0, true)
+	j.matchedRowsForCurrentProbeRow++
+	return true
+}
+
+func (j *semiJoinProbe) finishLookupLoopForJoinedRows(joinedChk *chunk.Chunk) {
+	if len(j.cachedBuildRows) > 0 {
+		j.batchConstructBuildRows(joinedChk, 0, true)
+	}
+	j.finishLookupCurrentProbeRow()
+	j.appendProbeRowToChunkInternal(joinedChk, j.currentChunk, j.lUsedInOtherCondition, 0, true)
+}
+
+// evalJoinedRows evaluates the conditions on the joined rows, and updates the match status of the probe rows.
+func (j *semiJoinProbe) evalJoinedRows(joinedChk *chunk.Chunk) (err error) {
+	evalCtx := j.ctx.SessCtx.GetExprCtx().GetEvalCtx()
+	vecEnabled := j.ctx.SessCtx.GetSessionVars().EnableVectorizedExpression
+	iter := chunk.NewIterator4Chunk(joinedChk)
+	if !j.hasNullableCondition {
+		j.selected, err = expression.VectorizedFilter(evalCtx, vecEnabled, j.joinedRowConditions, iter, j.selected)
+		if err != nil {
+			return err
+		}
+		for index, result := range j.selected {
+			if result {
+				j.matchStatus[j.rowIndexInfos[index].probeRowIndex] = semiJoinMatched
+			}
+		}
+		return nil
+	}
+	// VectorizedFilterConsiderNull stops evaluating a row once it is filtered by a condition, so each condition
+	// is evaluated separately to tell false from NULL
+	rows := joinedChk.NumRows()
+	j.selected = j.selected[:0]
+	j.isNull = j.isNull[:0]
+	for i := 0; i < rows; i++ {
+		j.selected = append(j.selected, true)
+		j.isNull = append(j.isNull, false)
+	}
+	for condIndex := range j.joinedRowConditions {
+		j.conditionSelected, j.conditionIsNull, err = expression.VectorizedFilterConsiderNull(evalCtx, vecEnabled, j.joinedRowConditions[condIndex:condIndex+1], iter, j.conditionSelected, j.conditionIsNull)
+		if err != nil {
+			return err
+		}
+		for i := 0; i < rows; i++ {
+			if j.conditionSelected[i] {
+				continue
+			}
+			if j.nullableConditions[condIndex] && j.conditionIsNull[i] {
+				j.isNull[i] = true
+			} else {
+				j.selected[i] = false
+			}
+		}
+	}
+	for index, result := range j.selected {
+		if !result {
+			continue
+		}
+		probeRowIndex := j.rowIndexInfos[index].probeRowIndex
+		if !j.isNull[index] {
+			j.matchStatus[probeRowIndex] = semiJoinMatched
+		} else if j.matchStatus[probeRowIndex] == semiJoinNotMatched {
+			j.matchStatus[probeRowIndex] = semiJoinMatchedNull
+		}
+	}
+	return nil
+}
+
+func (j *semiJoinProbe) buildResult(chk *chunk.Chunk, startProbeRow int) {
+	for i := startProbeRow; i < j.currentProbeRow; i++ {
+		if j.isLeftOuter {
+			j.outputRows[i] = true
+		} else if j.isAnti {
+			j.outputRows[i] = j.matchStatus[i] == semiJoinNotMatched
+		} else {
+			j.outputRows[i] = j.matchStatus[i] == semiJoinMatched
+		}
+	}
+	prevRows := chk.NumRows()
+	afterRows := prevRows
+	for index, colIndex := range j.lUsed {
+		dstCol := chk.Column(index)
+		srcCol := j.currentChunk.Column(colIndex)
+		chunk.CopySelectedRowsWithRowIDFunc(dstCol, srcCol, j.outputRows, startProbeRow, j.currentProbeRow, func(i int) int {
+			return j.usedRows[i]
+		})
+		afterRows = dstCol.Rows()
+	}
+	addedRows := afterRows - prevRows
+	if len(j.lUsed) == 0 {
+		for i := startProbeRow; i < j.currentProbeRow; i++ {
+			if j.outputRows[i] {
+				addedRows++
+			}
+		}
+	}
+	if j.isLeftOuter {
+		// the matched column is the last column of the result chunk
+		matchedCol := chk.Column(len(j.lUsed))
+		for i := startProbeRow; i < j.currentProbeRow; i++ {
+			switch {
+			case j.matchStatus[i] == semiJoinMatchedNull:
+				matchedCol.AppendNull()
+			case (j.matchStatus[i] == semiJoinMatched) != j.isAnti:
+				matchedCol.AppendInt64(1)
+			default:
+				matchedCol.AppendInt64(0)
+			}
+		}
+	}
+	chk.SetNumVirtualRows(prevRows + addedRows)
+}
+
+func (j *semiJoinProbe) Probe(joinResult *hashjoinWorkerResult, sqlKiller *sqlkiller.SQLKiller) (ok bool, _ *hashjoinWorkerResult) {
+	if joinResult.chk.IsFull() {
+		return true, joinResult
+	}
+	joinedChk, remainCap, err := j.prepareForProbe(joinResult.chk)
+	if err != nil {
+		joinResult.err = err
+		return false, joinResult
+	}
+	isInCompleteChunk := joinedChk.IsInCompleteChunk()
+	// in case that virtual rows is not maintained correctly
+	joinedChk.SetNumVirtualRows(joinedChk.NumRows())
+	// always set in complete chunk during probe
+	joinedChk.SetInCompleteChunk(true)
+	defer joinedChk.SetInCompleteChunk(isInCompleteChunk)
+
+	startProbeRow := j.currentProbeRow
+	j.probeForInnerSideBuild(joinedChk, remainCap)
+	err = checkSQLKiller(sqlKiller, "killedDuringProbe")
+	if err != nil {
+		joinResult.err = err
+		return false, joinResult
+	}
+	if len(j.joinedRowConditions) > 0 {
+		j.finishLookupLoopForJoinedRows(joinedChk)
+		if joinedChk.NumRows() > 0 {
+			err = j.evalJoinedRows(joinedChk)
+			if err != nil {
+				joinResult.err = err
+				return false, joinResult
+			}
+		}
+	}
+	j.buildResult(joinResult.chk, startProbeRow)
+	return true, joinResult
+}
@@ -0,0 +1,213 @@
+// Copyright 2024 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package join
+
+import (
+	"testing"
+
+	"github.com/pingcap/tidb/pkg/expression"
+	"github.com/pingcap/tidb/pkg/parser/ast"
+	"github.com/pingcap/tidb/pkg/parser/mysql"
+	plannercore "github.com/pingcap/tidb/pkg/planner/core"
+	"github.com/pingcap/tidb/pkg/sessionctx"
+	"github.com/pingcap/tidb/pkg/types"
+	"github.com/pingcap/tidb/pkg/util/chunk"
+	"github.com/pingcap/tidb/pkg/util/codec"
+	"github.com/pingcap/tidb/pkg/util/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// evalSemiJoinOtherCondition evaluates the other conditions in three-valued logic, a null value
+// is only kept for the conditions converted from `[not] in (subq)` of the left outer semi joins.
+func evalSemiJoinOtherCondition(t *testing.T, sessCtx sessionctx.Context, leftRow chunk.Row, rightRow chunk.Row, shallowRow chunk.MutRow,
+	otherConditions expression.CNFExprs, isLeftOuter bool) (matched bool, isNull bool) {
+	shallowRow.ShallowCopyPartialRow(0, leftRow)
+	shallowRow.ShallowCopyPartialRow(leftRow.Len(), rightRow)
+	for _, cond := range otherConditions {
+		ok, null, err := expression.EvalBool(sessCtx.GetExprCtx().GetEvalCtx(), expression.CNFExprs{cond}, shallowRow.ToRow())
+		require.NoError(t, err)
+		if null && isLeftOuter && expression.IsEQCondFromIn(cond) {
+			isNull = true
+			continue
+		}
+		if !ok {
+			return false, false
+		}
+	}
+	return !isNull, isNull
+}
+
+// generate semi join result using nested loop
+func genSemiJoinResult(t *testing.T, sessCtx sessionctx.Context, leftFilter expression.CNFExprs, leftChunks []*chunk.Chunk, rightChunks []*chunk.Chunk,
+	leftKeyIndex []int, rightKeyIndex []int, leftTypes []*types.FieldType, rightTypes []*types.FieldType, leftKeyTypes []*types.FieldType,
+	rightKeyTypes []*types.FieldType, leftUsedColumns []int, otherConditions expression.CNFExprs, resultTypes []*types.FieldType,
+	joinType plannercore.JoinType) []*chunk.Chunk {
+	isAnti := joinType == plannercore.AntiSemiJoin || joinType == plannercore.AntiLeftOuterSemiJoin
+	isLeftOuter := joinType == plannercore.LeftOuterSemiJoin || joinType == plannercore.AntiLeftOuterSemiJoin
+	filterVector := make([]bool, 0)
+	var err error
+	returnChks := make([]*chunk.Chunk, 0, 1)
+	resultChk := chunk.New(resultTypes, sessCtx.GetSessionVars().MaxChunkSize, sessCtx.GetSessionVars().MaxChunkSize)
+	shallowRowTypes := make([]*types.FieldType, 0, len(leftTypes)+len(rightTypes))
+	shallowRowTypes = append(shallowRowTypes, leftTypes...)
+	shallowRowTypes = append(shallowRowTypes, rightTypes...)
+	shallowRow := chunk.MutRowFromTypes(shallowRowTypes)
+	for _, leftChunk := range leftChunks {
+		if leftFilter != nil {
+			filterVector, err = expression.VectorizedFilter(sessCtx.GetExprCtx().GetEvalCtx(), sessCtx.GetSessionVars().EnableVectorizedExpression, leftFilter, chunk.NewIterator4Chunk(leftChunk), filterVector)
+			require.NoError(t, err)
+		}
+		for leftIndex := 0; leftIndex < leftChunk.NumRows(); leftIndex++ {
+			filterIndex := leftIndex
+			if leftChunk.Sel() != nil {
+				filterIndex = leftChunk.Sel()[leftIndex]
+			}
+			leftRow := leftChunk.GetRow(leftIndex)
+			matched, hasNull := false, false
+			if leftFilter == nil || filterVector[filterIndex] {
+				for _, rightChunk := range rightChunks {
+					for rightIndex := 0; rightIndex < rightChunk.NumRows() && !matched; rightIndex++ {
+						rightRow := rightChunk.GetRow(rightIndex)
+						if containsNullKey(leftRow, leftKeyIndex) || containsNullKey(rightRow, rightKeyIndex) {
+							continue
+						}
+						ok, err := codec.EqualChunkRow(sessCtx.GetSessionVars().StmtCtx.TypeCtx(), leftRow, leftKeyTypes, leftKeyIndex,
+							rightRow, rightKeyTypes, rightKeyIndex)
+						require.NoError(t, err)
+						if !ok {
+							continue
+						}
+						if otherConditions == nil {
+							matched = true
+							continue
+						}
+						ok, null := evalSemiJoinOtherCondition(t, sessCtx, leftRow, rightRow, shallowRow, otherConditions, isLeftOuter)
+						matched = ok
+						hasNull = hasNull || null
+					}
+				}
+			}
+			if !isLeftOuter && matched == isAnti {
+				continue
+			}
+			resultChk.AppendRowByColIdxs(leftRow, leftUsedColumns)
+			if isLeftOuter {
+				flagCol := resultChk.Column(len(leftUsedColumns))
+				switch {
+				case !matched && hasNull:
+					flagCol.AppendNull()
+				case matched != isAnti:
+					flagCol.AppendInt64(1)
+				default:
+					flagCol.AppendInt64(0)
+				}
+			}
+			if resultChk.IsFull() {
+				returnChks = append(returnChks, resultChk)
+				resultChk = chunk.New(resultTypes, sessCtx.GetSessionVars().MaxChunkSize, sessCtx.GetSessionVars().MaxChunkSize)
+			}
+		}
+	}
+	if resultChk.NumRows() > 0 {
+		returnChks = append(returnChks, resultChk)
+	}
+	return returnChks
+}
+
+func TestSemiJoinProbeBasic(t *testing.T) {
+	tinyTp := types.NewFieldType(mysql.TypeTiny)
+	tinyTp.AddFlag(mysql.NotNullFlag)
+	intTp := types.NewFieldType(mysql.TypeLonglong)
+	intTp.AddFlag(mysql.NotNullFlag)
+	uintTp := types.NewFieldType(mysql.TypeLonglong)
+	uintTp.AddFlag(mysql.NotNullFlag)
+	uintTp.AddFlag(mysql.UnsignedFlag)
+	stringTp := types.NewFieldType(mysql.TypeVarString)
+	stringTp.AddFlag(mysql.NotNullFlag)
+
+	lTypes := []*types.FieldType{intTp, stringTp, uintTp, stringTp, tinyTp}
+	rTypes := []*types.FieldType{intTp, stringTp, uintTp, stringTp, tinyTp}
+	rTypes1 := []*types.FieldType{uintTp, stringTp, intTp, stringTp, tinyTp}
+
+	partitionNumber := 3
+	simpleFilter := createSimpleFilter(t)
+
+	testCases := []testCase{
+		// normal case
+		{[]int{0}, []int{0}, []*types.FieldType{intTp}, []*types.FieldType{intTp}, lTypes, rTypes, []int{0, 1, 2, 3}, []int{}, nil, nil, nil},
+		// leftUsed is empty
+		{[]int{0}, []int{0}, []*types.FieldType{intTp}, []*types.FieldType{intTp}, lTypes, rTypes, []int{}, []int{}, nil, nil, nil},
+		// leftUsed is part of all columns
+		{[]int{0}, []int{0}, []*types.FieldType{intTp}, []*types.FieldType{intTp}, lTypes, rTypes, []int{0, 2}, []int{}, nil, nil, nil},
+		// int join uint
+		{[]int{0}, []int{0}, []*types.FieldType{intTp}, []*types.FieldType{uintTp}, lTypes, rTypes1, []int{0, 1, 2, 3}, []int{}, nil, nil, nil},
+		// multiple join keys
+		{[]int{0, 1}, []int{0, 1}, []*types.FieldType{intTp, stringTp}, []*types.FieldType{intTp, stringTp}, lTypes, rTypes, []int{0, 1, 2, 3}, []int{}, nil, nil, nil},
+	}
+
+	joinTypes := []plannercore.JoinType{plannercore.SemiJoin, plannercore.AntiSemiJoin, plannercore.LeftOuterSemiJoin, plannercore.AntiLeftOuterSemiJoin}
+	for _, tc := range testCases {
+		for _, joinType := range joinTypes {
+			leftFilters := []expression.CNFExprs{nil}
+			if joinType == plannercore.LeftOuterSemiJoin || joinType == plannercore.AntiLeftOuterSemiJoin {
+				leftFilters = append(leftFilters, simpleFilter)
+			}
+			for _, leftFilter := range leftFilters {
+				testJoinProbe(t, false, tc.leftKeyIndex, tc.rightKeyIndex, tc.leftKeyTypes, tc.rightKeyTypes, tc.leftTypes, tc.rightTypes, true, tc.leftUsed,
+					tc.rightUsed, tc.leftUsedByOtherCondition, tc.rightUsedByOtherCondition, leftFilter, nil, tc.otherCondition, partitionNumber, joinType, 200)
+				testJoinProbe(t, false, tc.leftKeyIndex, tc.rightKeyIndex, toNullableTypes(tc.leftKeyTypes), toNullableTypes(tc.rightKeyTypes),
+					toNullableTypes(tc.leftTypes), toNullableTypes(tc.rightTypes), true, tc.leftUsed, tc.rightUsed, tc.leftUsedByOtherCondition, tc.rightUsedByOtherCondition,
+					leftFilter, nil, tc.otherCondition, partitionNumber, joinType, 200)
+			}
+		}
+	}
+}
+
+func TestSemiJoinProbeOtherCondition(t *testing.T) {
+	intTp := types.NewFieldType(mysql.TypeLonglong)
+	intTp.AddFlag(mysql.NotNullFlag)
+	nullableIntTp := types.NewFieldType(mysql.TypeLonglong)
+	uintTp := types.NewFieldType(mysql.TypeLonglong)
+	uintTp.AddFlag(mysql.NotNullFlag)
+	uintTp.AddFlag(mysql.UnsignedFlag)
+	stringTp := types.NewFieldType(mysql.TypeVarString)
+	stringTp.AddFlag(mysql.NotNullFlag)
+
+	lTypes := []*types.FieldType{intTp, intTp, stringTp, uintTp, stringTp}
+	rTypes := []*types.FieldType{intTp, intTp, stringTp, uintTp, stringTp}
+
+	tinyTp := types.NewFieldType(mysql.TypeTiny)
+	a := &expression.Column{Index: 1, RetType: nullableIntTp}
+	b := &expression.Column{Index: 8, RetType: nullableIntTp}
+	sf, err := expression.NewFunction(mock.NewContext(), ast.GT, tinyTp, a, b)
+	require.NoError(t, err, "error when create other condition")
+	otherCondition := expression.CNFExprs{sf}
+	// `a = b` converted from `a in (select b ...)`, its null result makes the result of left outer semi join null
+	inA := &expression.Column{Index: 1, RetType: nullableIntTp}
+	inB := &expression.Column{Index: 6, RetType: nullableIntTp, InOperand: true}
+	eqCond, err := expression.NewFunction(mock.NewContext(), ast.EQ, tinyTp, inA, inB)
+	require.NoError(t, err, "error when create other condition")
+	otherConditionFromIn := expression.CNFExprs{eqCond, sf}
+
+	joinTypes := []plannercore.JoinType{plannercore.SemiJoin, plannercore.AntiSemiJoin, plannercore.LeftOuterSemiJoin, plannercore.AntiLeftOuterSemiJoin}
+	for _, joinType := range joinTypes {
+		for _, cond := range []expression.CNFExprs{otherCondition, otherConditionFromIn} {
+			testJoinProbe(t, false, []int{0}, []int{0}, []*types.FieldType{intTp}, []*types.FieldType{intTp}, lTypes, rTypes, true, []int{1, 2, 4}, []int{}, []int{1}, []int{1, 3}, nil, nil, cond, 3, joinType, 200)
+			testJoinProbe(t, false, []int{0}, []int{0}, []*types.FieldType{intTp}, []*types.FieldType{intTp}, lTypes, rTypes, true, []int{}, []int{}, []int{1}, []int{1, 3}, nil, nil, cond, 3, joinType, 200)
+			testJoinProbe(t, false, []int{0}, []int{0}, []*types.FieldType{nullableIntTp}, []*types.FieldType{nullableIntTp}, toNullableTypes(lTypes), toNullableTypes(rTypes), true, []int{1, 2, 4}, []int{}, []int{1}, []int{1, 3}, nil, nil, cond, 3, joinType, 200)
+			testJoinProbe(t, true, []int{0}, []int{0}, []*types.FieldType{nullableIntTp}, []*types.FieldType{nullableIntTp}, toNullableTypes(lTypes), toNullableTypes(rTypes), true, []int{1, 2, 4}, []int{}, []int{1}, []int{1, 3}, nil, nil, cond, 3, joinType, 200)
+		}
+	}
+}
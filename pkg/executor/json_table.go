@@ -0,0 +1,184 @@
+// Copyright 2024 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package executor
+
+import (
+	"context"
+
+	"github.com/pingcap/tidb/pkg/executor/internal/exec"
+	"github.com/pingcap/tidb/pkg/expression"
+	"github.com/pingcap/tidb/pkg/parser/ast"
+	"github.com/pingcap/tidb/pkg/parser/mysql"
+	plannercore "github.com/pingcap/tidb/pkg/planner/core"
+	"github.com/pingcap/tidb/pkg/types"
+	"github.com/pingcap/tidb/pkg/util/chunk"
+	"github.com/pingcap/tidb/pkg/util/dbterror/exeerrors"
+)
+
+var _ exec.Executor = &JSONTableExec{}
+
+// JSONTableExec represents a JSON_TABLE executor. All the rows are generated from the JSON document
+// when it's opened, which happens once for every outer row if it's the inner side of an Apply.
+type JSONTableExec struct {
+	exec.BaseExecutor
+
+	expr expression.Expression
+	path *plannercore.JSONTablePath
+	name string
+
+	rows   [][]types.Datum
+	cursor int
+}
+
+// Open implements the Executor Open interface.
+func (e *JSONTableExec) Open(ctx context.Context) error {
+	if err := e.BaseExecutor.Open(ctx); err != nil {
+		return err
+	}
+	e.rows = e.rows[:0]
+	e.cursor = 0
+	doc, isNull, err := e.expr.EvalJSON(e.Ctx().GetExprCtx().GetEvalCtx(), chunk.Row{})
+	if err != nil || isNull {
+		return err
+	}
+	e.rows, err = e.evalPath(e.path, doc, e.rows)
+	return err
+}
+
+// Next implements the Executor Next interface.
+func (e *JSONTableExec) Next(_ context.Context, req *chunk.Chunk) error {
+	req.GrowAndReset(e.MaxChunkSize())
+	for ; e.cursor < len(e.rows) && !req.IsFull(); e.cursor++ {
+		row := e.rows[e.cursor]
+		for i := range row {
+			req.AppendDatum(i, &row[i])
+		}
+	}
+	return nil
+}
+
+// Close implements the Executor Close interface.
+func (e *JSONTableExec) Close() error {
+	e.rows = nil
+	return e.BaseExecutor.Close()
+}
+
+// evalPath appends the rows generated by the path against the JSON value. A row of the path is joined with
+// the rows of every nested path in turn, and the nested columns are NULL if none of the nested paths has any row.
+func (e *JSONTableExec) evalPath(p *plannercore.JSONTablePath, value types.BinaryJSON, rows [][]types.Datum) ([][]types.Datum, error) {
+	for i, v := range value.ExtractAll(p.Path) {
+		row := make([]types.Datum, e.Schema().Len())
+		for _, col := range p.Columns {
+			d, err := e.evalColumn(col, v, i+1)
+			if err != nil {
+				return nil, err
+			}
+			row[col.Offset] = d
+		}
+		start := len(rows)
+		for _, nested := range p.Nested {
+			var err error
+			if rows, err = e.evalPath(nested, v, rows); err != nil {
+				return nil, err
+			}
+		}
+		if len(rows) == start {
+			rows = append(rows, row)
+			continue
+		}
+		for _, r := range rows[start:] {
+			for _, col := range p.Columns {
+				r[col.Offset] = row[col.Offset]
+			}
+		}
+	}
+	return rows, nil
+}
+
+func (e *JSONTableExec) evalColumn(col *plannercore.JSONTableColumn, row types.BinaryJSON, ordinality int) (types.Datum, error) {
+	tp := e.Schema().Columns[col.Offset].RetType
+	typeCtx := e.Ctx().GetSessionVars().StmtCtx.TypeCtx()
+	switch col.Tp {
+	case ast.JSONTableColumnOrdinality:
+		return types.NewUintDatum(uint64(ordinality)), nil
+	case ast.JSONTableColumnExists:
+		exists := int64(0)
+		if len(row.ExtractAll(col.Path)) > 0 {
+			exists = 1
+		}
+		d := types.NewIntDatum(exists)
+		return d.ConvertTo(typeCtx, tp)
+	}
+	v, found := row.Extract([]types.JSONPathExpression{col.Path})
+	if !found {
+		return e.onResponse(col, col.OnEmpty, exeerrors.ErrMissingJSONTableValue.GenWithStackByArgs(col.Name))
+	}
+	d, err := e.convertValue(col, v)
+	if err != nil {
+		return e.onResponse(col, col.OnError, err)
+	}
+	return d, nil
+}
+
+func (e *JSONTableExec) onResponse(col *plannercore.JSONTableColumn, resp plannercore.JSONTableOnResponse, err error) (types.Datum, error) {
+	switch resp.Tp {
+	case ast.JSONTableOnResponseError:
+		return types.Datum{}, err
+	case ast.JSONTableOnResponseDefault:
+		return e.convertValue(col, resp.Default)
+	}
+	return types.Datum{}, nil
+}
+
+// convertValue converts the JSON value to the type of the column. The JSON strings are unquoted, and the
+// arrays and objects can only be stored in the JSON column.
+func (e *JSONTableExec) convertValue(col *plannercore.JSONTableColumn, v types.BinaryJSON) (types.Datum, error) {
+	tp := e.Schema().Columns[col.Offset].RetType
+	if tp.GetType() == mysql.TypeJSON {
+		return types.NewJSONDatum(v), nil
+	}
+	var d types.Datum
+	switch v.TypeCode {
+	case types.JSONTypeCodeObject, types.JSONTypeCodeArray:
+		return d, exeerrors.ErrWrongJSONTableValue.GenWithStackByArgs(col.Name, e.name)
+	case types.JSONTypeCodeLiteral:
+		switch v.Value[0] {
+		case types.JSONLiteralNil:
+			return d, nil
+		case types.JSONLiteralTrue:
+			d = types.NewIntDatum(1)
+		default:
+			d = types.NewIntDatum(0)
+		}
+		if tp.EvalType() == types.ETString {
+			d = types.NewStringDatum(v.String())
+		}
+	case types.JSONTypeCodeInt64:
+		d = types.NewIntDatum(v.GetInt64())
+	case types.JSONTypeCodeUint64:
+		d = types.NewUintDatum(v.GetUint64())
+	case types.JSONTypeCodeFloat64:
+		d = types.NewFloat64Datum(v.GetFloat64())
+	case types.JSONTypeCodeString:
+		d = types.NewStringDatum(string(v.GetString()))
+	default:
+		s, err := v.Unquote()
+		if err != nil {
+			return d, err
+		}
+		d = types.NewStringDatum(s)
+	}
+	return d.ConvertTo(e.Ctx().GetSessionVars().StmtCtx.TypeCtx(), tp)
+}
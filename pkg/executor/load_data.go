@@ -145,6 +145,8 @@ type LoadDataWorker struct {
 	planInfo   planInfo
 
 	table table.Table
+	// triggers are the triggers of the table, it's nil if the table has no trigger.
+	triggers *tableTriggers
 }
 
 func setNonRestrictiveFlags(stmtCtx *stmtctx.StatementContext) {
@@ -302,11 +304,18 @@ func initEncodeCommitWorkers(e *LoadDataWorker) (*encodeWorker, *commitWorker, e
 		exprWarnings:   exprWarnings,
 		killer:         &e.UserSctx.GetSessionVars().SQLKiller,
 	}
-	enc.resetBatch()
 	com := &commitWorker{
 		InsertValues: insertValues,
 		controller:   e.controller,
 	}
+	if insertValues.triggers != nil {
+		// The triggers execute statements in the session, so they can't be activated by the encode worker
+		// and the commit worker at the same time. The encode worker commits the rows one by one, so the
+		// BEFORE and AFTER triggers of a row are activated before the next row, as INSERT does.
+		insertValues.maxRowsInBatch = 1
+		enc.committer = com
+	}
+	enc.resetBatch()
 	return enc, com, nil
 }
 
@@ -332,6 +341,8 @@ func createInsertValues(e *LoadDataWorker) (insertVal *InsertValues, err error)
 		insertColumns:  insertColumns,
 		rowLen:         len(insertColumns),
 		hasExtraHandle: hasExtraHandle,
+		triggers:       e.triggers,
+		mviewLogs:      buildTableMViewLogs(sessiontxn.GetTxnManager(e.UserSctx).GetTxnInfoSchema(), e.table),
 	}
 	if len(insertColumns) > 0 {
 		ret.initEvalBuffer()
@@ -350,6 +361,9 @@ type encodeWorker struct {
 	exprWarnings []contextutil.SQLWarn
 	killer       *sqlkiller.SQLKiller
 	rows         [][]types.Datum
+	// committer commits the rows in the encode worker if it's not nil, instead of sending them to the
+	// commit worker.
+	committer *commitWorker
 }
 
 // commitTask is used for passing data from processStream goroutine to commitWork goroutine.
@@ -417,6 +431,13 @@ func (w *encodeWorker) processOneStream(
 		if w.curBatchCnt == 0 {
 			return
 		}
+		if w.committer != nil {
+			if err = w.committer.commitOneTask(ctx, commitTask{cnt: w.curBatchCnt, rows: w.rows}); err != nil {
+				return
+			}
+			w.resetBatch()
+			continue
+		}
 
 	TrySendTask:
 		select {
@@ -0,0 +1,484 @@
+// Copyright 2024 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package executor
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/pingcap/errors"
+	"github.com/pingcap/failpoint"
+	"github.com/pingcap/tidb/pkg/domain"
+	"github.com/pingcap/tidb/pkg/infoschema"
+	"github.com/pingcap/tidb/pkg/kv"
+	"github.com/pingcap/tidb/pkg/parser"
+	"github.com/pingcap/tidb/pkg/parser/ast"
+	"github.com/pingcap/tidb/pkg/parser/model"
+	"github.com/pingcap/tidb/pkg/parser/mysql"
+	plannercore "github.com/pingcap/tidb/pkg/planner/core"
+	"github.com/pingcap/tidb/pkg/sessionctx"
+	"github.com/pingcap/tidb/pkg/table"
+	"github.com/pingcap/tidb/pkg/types"
+	"github.com/pingcap/tidb/pkg/util/chunk"
+	"github.com/pingcap/tidb/pkg/util/dbterror/exeerrors"
+	"github.com/pingcap/tidb/pkg/util/dbterror/plannererrors"
+	"github.com/pingcap/tidb/pkg/util/sqlescape"
+	"github.com/pingcap/tidb/pkg/util/sqlexec"
+)
+
+// mviewRefreshTable is the system table recording the last refresh of the materialized views.
+const mviewRefreshTable = "tidb_mview_refresh"
+
+func (e *DDLExec) executeCreateMaterializedView(ctx context.Context, s *ast.CreateMaterializedViewStmt) error {
+	ret := &plannercore.PreprocessorReturn{}
+	err := plannercore.Preprocess(ctx, e.Ctx(), s.Select, plannercore.WithPreprocessorReturn(ret))
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if ret.IsStaleness {
+		return exeerrors.ErrViewInvalid.GenWithStackByArgs(s.ViewName.Schema.L, s.ViewName.Name.L)
+	}
+
+	e.Ctx().GetSessionVars().ClearRelatedTableForMDL()
+	dom := domain.GetDomain(e.Ctx())
+	if s.IfNotExists && dom.InfoSchema().TableExists(s.ViewName.Schema, s.ViewName.Name) {
+		// The DDL only appends a note, the existing table must not be refreshed.
+		return dom.DDL().CreateMaterializedView(e.Ctx(), s)
+	}
+	if err := dom.DDL().CreateMaterializedView(e.Ctx(), s); err != nil {
+		return err
+	}
+
+	// Fill the view with the result of the query.
+	tbl, err := dom.InfoSchema().TableByName(ctx, s.ViewName.Schema, s.ViewName.Name)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	sysCtx, err := e.GetSysSession()
+	if err != nil {
+		return err
+	}
+	defer e.ReleaseSysSession(ctx, sysCtx)
+	return refreshMaterializedView(sysCtx, s.ViewName.Schema, tbl.Meta(), model.MViewRefreshComplete)
+}
+
+func (e *DDLExec) executeDropMaterializedView(s *ast.DropMaterializedViewStmt) error {
+	var mvID int64
+	tbl, err := e.is.TableByName(context.Background(), s.ViewName.Schema, s.ViewName.Name)
+	if err == nil {
+		mvID = tbl.Meta().ID
+	}
+	if err := domain.GetDomain(e.Ctx()).DDL().DropMaterializedView(e.Ctx(), s); err != nil || mvID == 0 {
+		return err
+	}
+	ctx := kv.WithInternalSourceType(context.Background(), kv.InternalTxnOthers)
+	_, _, err = e.Ctx().GetRestrictedSQLExecutor().ExecRestrictedSQL(ctx, nil, `DELETE FROM %n.%n WHERE mview_id = %?`,
+		mysql.SystemDB, mviewRefreshTable, mvID)
+	return err
+}
+
+func (e *SimpleExec) executeRefreshMaterializedView(ctx context.Context, s *ast.RefreshMaterializedViewStmt) error {
+	dbName := s.ViewName.Schema
+	if dbName.L == "" {
+		dbName = model.NewCIStr(e.Ctx().GetSessionVars().CurrentDB)
+	}
+	tbl, err := e.is.TableByName(ctx, dbName, s.ViewName.Name)
+	if err != nil {
+		return err
+	}
+	tblInfo := tbl.Meta()
+	if !tblInfo.IsMaterializedView() {
+		return exeerrors.ErrWrongObject.GenWithStackByArgs(dbName.O, tblInfo.Name.O, "MATERIALIZED VIEW")
+	}
+	method := tblInfo.MaterializedView.RefreshMethod
+	if s.HasMethod {
+		method = s.Method
+	}
+	if method == model.MViewRefreshIncremental && tblInfo.MaterializedView.LogTableID == 0 {
+		return plannererrors.ErrMViewNotIncremental.GenWithStackByArgs("the view is not created with REFRESH INCREMENTAL")
+	}
+
+	sysCtx, err := e.GetSysSession()
+	if err != nil {
+		return err
+	}
+	defer e.ReleaseSysSession(ctx, sysCtx)
+	return refreshMaterializedView(sysCtx, dbName, tblInfo, method)
+}
+
+// refreshMaterializedView refreshes the materialized view in a transaction of
+// the system session, the changes of the view and its log table are committed
+// atomically.
+//
+// The transaction is optimistic, so all its statements read the snapshot of its
+// start ts, while the DML statements of a pessimistic transaction read the latest
+// data. The purge of the log table only deletes the changes applied by the refresh,
+// the changes committed after the start ts are left to the next refresh. The
+// concurrent refreshes of a view conflict on its row of mysql.tidb_mview_refresh,
+// only one of them can be committed.
+func refreshMaterializedView(sctx sessionctx.Context, dbName model.CIStr, tblInfo *model.TableInfo, method model.MViewRefreshMethod) error {
+	ctx := kv.WithInternalSourceType(context.Background(), kv.InternalTxnOthers)
+	sqlExecutor := sctx.GetSQLExecutor()
+	if _, err := sqlExecutor.ExecuteInternal(ctx, "BEGIN OPTIMISTIC"); err != nil {
+		return err
+	}
+	err := doRefreshMaterializedView(ctx, sctx, dbName, tblInfo, method)
+	if err != nil {
+		if _, rollbackErr := sqlExecutor.ExecuteInternal(ctx, "ROLLBACK"); rollbackErr != nil {
+			return rollbackErr
+		}
+		return err
+	}
+	_, err = sqlExecutor.ExecuteInternal(ctx, "COMMIT")
+	return err
+}
+
+func doRefreshMaterializedView(ctx context.Context, sctx sessionctx.Context, dbName model.CIStr, tblInfo *model.TableInfo, method model.MViewRefreshMethod) error {
+	mvInfo := tblInfo.MaterializedView
+	if method == model.MViewRefreshIncremental {
+		// The incremental refresh applies the changes to the result of the last
+		// refresh, it falls back to the complete refresh if there is no such result.
+		rows, err := execMViewRefreshSQL(ctx, sctx, `SELECT 1 FROM %n.%n WHERE mview_id = %?`,
+			mysql.SystemDB, mviewRefreshTable, tblInfo.ID)
+		if err != nil {
+			return err
+		}
+		if len(rows) == 0 {
+			method = model.MViewRefreshComplete
+		}
+	}
+
+	var logDB, logName model.CIStr
+	if mvInfo.LogTableID != 0 {
+		is := sctx.GetDomainInfoSchema().(infoschema.InfoSchema)
+		logTbl, ok := is.TableByID(mvInfo.LogTableID)
+		if !ok {
+			return infoschema.ErrTableNotExists.GenWithStackByArgs(dbName.O, fmt.Sprintf("%s%d", model.MViewLogTablePrefix, tblInfo.ID))
+		}
+		dbInfo, ok := infoschema.SchemaByTable(is, logTbl.Meta())
+		if !ok {
+			return errors.Errorf("Can not get the schema of table %d", mvInfo.LogTableID)
+		}
+		logDB, logName = dbInfo.Name, logTbl.Meta().Name
+	}
+	var stmts []string
+	var err error
+	if method == model.MViewRefreshComplete {
+		stmts, err = buildCompleteRefreshSQLs(dbName, tblInfo)
+	} else {
+		stmts, err = buildIncrementalRefreshSQLs(dbName, tblInfo, logDB, logName)
+	}
+	if err != nil {
+		return err
+	}
+	for _, sql := range stmts {
+		if _, err := execMViewRefreshSQL(ctx, sctx, sql); err != nil {
+			return err
+		}
+	}
+	failpoint.InjectCall("beforePurgeMViewLog")
+	// The changes recorded in the log table are contained in the new result.
+	if logName.L != "" {
+		if _, err := execMViewRefreshSQL(ctx, sctx, `DELETE FROM %n.%n`, logDB.O, logName.O); err != nil {
+			return err
+		}
+	}
+	_, err = execMViewRefreshSQL(ctx, sctx, `REPLACE INTO %n.%n VALUES (%?, %?, @@tidb_current_ts, NOW(6))`,
+		mysql.SystemDB, mviewRefreshTable, tblInfo.ID, method.String())
+	return err
+}
+
+func execMViewRefreshSQL(ctx context.Context, sctx sessionctx.Context, sql string, args ...any) ([]chunk.Row, error) {
+	rs, err := sctx.GetSQLExecutor().ExecuteInternal(ctx, sql, args...)
+	if err != nil || rs == nil {
+		return nil, err
+	}
+	defer func() {
+		_ = rs.Close()
+	}()
+	return sqlexec.DrainRecordSet(ctx, rs, sctx.GetSessionVars().MaxChunkSize)
+}
+
+// buildCompleteRefreshSQLs builds the statements replacing the rows of the view
+// with the result of its query.
+func buildCompleteRefreshSQLs(dbName model.CIStr, tblInfo *model.TableInfo) ([]string, error) {
+	var sb strings.Builder
+	sqlescape.MustFormatSQL(&sb, "INSERT INTO %n.%n (", dbName.O, tblInfo.Name.O)
+	for i, col := range tblInfo.Columns {
+		if i > 0 {
+			sb.WriteString(", ")
+		}
+		sqlescape.MustFormatSQL(&sb, "%n", col.Name.O)
+	}
+	sb.WriteString(") ")
+	sb.WriteString(tblInfo.MaterializedView.SelectStmt)
+	return []string{
+		sqlescape.MustEscapeSQL("DELETE FROM %n.%n", dbName.O, tblInfo.Name.O),
+		sb.String(),
+	}, nil
+}
+
+// buildIncrementalRefreshSQLs builds the statements applying the changes recorded
+// in the log table to the rows of the view. The changes are aggregated by the
+// GROUP BY items of the query, the aggregates of the changes are added to the
+// existing groups, the new groups are inserted, and the groups without any rows
+// are deleted.
+func buildIncrementalRefreshSQLs(dbName model.CIStr, tblInfo *model.TableInfo, logDB, logName model.CIStr) ([]string, error) {
+	stmt, err := parser.New().ParseOneStmt(tblInfo.MaterializedView.SelectStmt, "", "")
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	query, err := plannercore.ParseMViewAggQuery(stmt)
+	if err != nil {
+		return nil, err
+	}
+	if len(query.Fields) != len(tblInfo.Columns) {
+		return nil, errors.Errorf("the columns of materialized view %s don't match its query", tblInfo.Name.O)
+	}
+
+	// The delta query aggregates the changes in the log table, the i-th field
+	// is named di.
+	sign := sqlescape.MustEscapeSQL("%n", model.MViewLogSignColumn)
+	var delta strings.Builder
+	delta.WriteString("SELECT ")
+	for i, field := range query.Fields {
+		if i > 0 {
+			delta.WriteString(", ")
+		}
+		var arg string
+		if field.Expr != nil {
+			if arg, err = plannercore.RestoreMViewExpr(field.Expr); err != nil {
+				return nil, errors.Trace(err)
+			}
+		}
+		switch field.Kind {
+		case plannercore.MViewGroupKey:
+			fmt.Fprintf(&delta, "(%s)", arg)
+		case plannercore.MViewCountAll:
+			fmt.Fprintf(&delta, "IFNULL(SUM(%s), 0)", sign)
+		case plannercore.MViewCount:
+			fmt.Fprintf(&delta, "IFNULL(SUM(IF((%s) IS NULL, 0, %s)), 0)", arg, sign)
+		case plannercore.MViewSum:
+			fmt.Fprintf(&delta, "SUM(%s * (%s))", sign, arg)
+		}
+		fmt.Fprintf(&delta, " AS d%d", i)
+	}
+	sqlescape.MustFormatSQL(&delta, " FROM %n.%n", logDB.O, logName.O)
+	if query.Where != nil {
+		where, err := plannercore.RestoreMViewExpr(query.Where)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		fmt.Fprintf(&delta, " WHERE %s", where)
+	}
+	for i, item := range query.GroupBy {
+		expr, err := plannercore.RestoreMViewExpr(item)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		if i == 0 {
+			delta.WriteString(" GROUP BY ")
+		} else {
+			delta.WriteString(", ")
+		}
+		delta.WriteString(expr)
+	}
+
+	mvName := sqlescape.MustEscapeSQL("%n.%n", dbName.O, tblInfo.Name.O)
+	colName := func(i int) string {
+		return sqlescape.MustEscapeSQL("%n", tblInfo.Columns[i].Name.O)
+	}
+	// groupCond matches the rows of the view with the rows of the delta query.
+	var groupCond []string
+	for i, field := range query.Fields {
+		if field.Kind == plannercore.MViewGroupKey {
+			groupCond = append(groupCond, fmt.Sprintf("m.%s <=> d.d%d", colName(i), i))
+		}
+	}
+
+	// The sums are assigned before the counts, so they see the old counts.
+	var assigns []string
+	for i, field := range query.Fields {
+		if field.Kind == plannercore.MViewSum {
+			cnt := colName(field.CountOffset)
+			assigns = append(assigns, fmt.Sprintf("m.%s = IF(m.%s + d.d%d = 0, NULL, IFNULL(m.%s, 0) + IFNULL(d.d%d, 0))",
+				colName(i), cnt, field.CountOffset, colName(i), i))
+		}
+	}
+	for i, field := range query.Fields {
+		if field.Kind == plannercore.MViewCountAll || field.Kind == plannercore.MViewCount {
+			assigns = append(assigns, fmt.Sprintf("m.%s = m.%s + d.d%d", colName(i), colName(i), i))
+		}
+	}
+
+	if len(groupCond) == 0 {
+		// The query without GROUP BY always returns one row.
+		return []string{
+			fmt.Sprintf("UPDATE %s AS m, (%s) AS d SET %s", mvName, delta.String(), strings.Join(assigns, ", ")),
+		}, nil
+	}
+
+	cond := strings.Join(groupCond, " AND ")
+	var insert strings.Builder
+	fmt.Fprintf(&insert, "INSERT INTO %s (", mvName)
+	for i := range query.Fields {
+		if i > 0 {
+			insert.WriteString(", ")
+		}
+		insert.WriteString(colName(i))
+	}
+	insert.WriteString(") SELECT ")
+	for i, field := range query.Fields {
+		if i > 0 {
+			insert.WriteString(", ")
+		}
+		if field.Kind == plannercore.MViewSum {
+			fmt.Fprintf(&insert, "IF(d.d%d = 0, NULL, d.d%d)", field.CountOffset, i)
+		} else {
+			fmt.Fprintf(&insert, "d.d%d", i)
+		}
+	}
+	fmt.Fprintf(&insert, " FROM (%s) AS d WHERE d.d%d > 0 AND NOT EXISTS (SELECT 1 FROM %s AS m WHERE %s)",
+		delta.String(), query.CountAllOffset, mvName, cond)
+
+	return []string{
+		fmt.Sprintf("UPDATE %s AS m JOIN (%s) AS d ON %s SET %s", mvName, delta.String(), cond, strings.Join(assigns, ", ")),
+		insert.String(),
+		fmt.Sprintf("DELETE FROM %s WHERE %s = 0", mvName, colName(query.CountAllOffset)),
+	}, nil
+}
+
+// mviewLog is the log table of a materialized view, the changed rows of the base
+// table are written into it.
+type mviewLog struct {
+	tbl table.Table
+	// offsets are the offsets of the base table columns copied to the log table,
+	// -1 means the column is not found and NULL is written.
+	offsets []int
+}
+
+// tableMViewLogs is the log tables of the materialized views reading a table.
+type tableMViewLogs struct {
+	logs []mviewLog
+}
+
+// buildTableMViewLogs returns the log tables of the materialized views which read
+// the table and are refreshed incrementally, it's nil if there is no such view.
+func buildTableMViewLogs(is infoschema.InfoSchema, tbl table.Table) *tableMViewLogs {
+	tblInfo := tbl.Meta()
+	if len(tblInfo.MViewIDs) == 0 || is == nil {
+		return nil
+	}
+	var logs []mviewLog
+	for _, id := range tblInfo.MViewIDs {
+		mv, ok := is.TableByID(id)
+		if !ok || !mv.Meta().IsMaterializedView() || mv.Meta().MaterializedView.LogTableID == 0 {
+			continue
+		}
+		logTbl, ok := is.TableByID(mv.Meta().MaterializedView.LogTableID)
+		if !ok || !logTbl.Meta().IsMViewLog() || logTbl.Meta().MViewLog.BaseTableID != tblInfo.ID {
+			continue
+		}
+		colIDs := logTbl.Meta().MViewLog.BaseColumnIDs
+		offsets := make([]int, 0, len(colIDs))
+		for _, colID := range colIDs {
+			offset := -1
+			for _, col := range tblInfo.Columns {
+				if col.ID == colID {
+					offset = col.Offset
+					break
+				}
+			}
+			offsets = append(offsets, offset)
+		}
+		logs = append(logs, mviewLog{tbl: logTbl, offsets: offsets})
+	}
+	if len(logs) == 0 {
+		return nil
+	}
+	return &tableMViewLogs{logs: logs}
+}
+
+func buildTblID2TableMViewLogs(is infoschema.InfoSchema, tblID2Table map[int64]table.Table) map[int64]*tableMViewLogs {
+	var tblID2Logs map[int64]*tableMViewLogs
+	for tid, tbl := range tblID2Table {
+		logs := buildTableMViewLogs(is, tbl)
+		if logs == nil {
+			continue
+		}
+		if tblID2Logs == nil {
+			tblID2Logs = make(map[int64]*tableMViewLogs)
+		}
+		tblID2Logs[tid] = logs
+	}
+	return tblID2Logs
+}
+
+func (l *tableMViewLogs) addRow(sctx sessionctx.Context, row []types.Datum, sign int64) error {
+	// The row IDs reserved by the statement belong to the base table, the log
+	// tables allocate their own ones.
+	stmtCtx := sctx.GetSessionVars().StmtCtx
+	baseRowID, maxRowID := stmtCtx.BaseRowID, stmtCtx.MaxRowID
+	stmtCtx.BaseRowID, stmtCtx.MaxRowID = 0, 0
+	defer func() {
+		stmtCtx.BaseRowID, stmtCtx.MaxRowID = baseRowID, maxRowID
+	}()
+	for _, log := range l.logs {
+		logRow := make([]types.Datum, 0, len(log.offsets)+1)
+		for _, offset := range log.offsets {
+			if offset < 0 || offset >= len(row) {
+				logRow = append(logRow, types.Datum{})
+				continue
+			}
+			logRow = append(logRow, row[offset])
+		}
+		logRow = append(logRow, types.NewIntDatum(sign))
+		if _, err := log.tbl.AddRecord(sctx.GetTableCtx(), logRow); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// onInsertRow records a row inserted into the base table. It's a no-op if the
+// receiver is nil.
+func (l *tableMViewLogs) onInsertRow(sctx sessionctx.Context, row []types.Datum) error {
+	if l == nil {
+		return nil
+	}
+	return l.addRow(sctx, row, 1)
+}
+
+// onDeleteRow records a row deleted from the base table. It's a no-op if the
+// receiver is nil.
+func (l *tableMViewLogs) onDeleteRow(sctx sessionctx.Context, row []types.Datum) error {
+	if l == nil {
+		return nil
+	}
+	return l.addRow(sctx, row, -1)
+}
+
+// onUpdateRow records a row updated in the base table as the deletion of the old
+// row and the insertion of the new row. It's a no-op if the receiver is nil.
+func (l *tableMViewLogs) onUpdateRow(sctx sessionctx.Context, oldRow, newRow []types.Datum) error {
+	if l == nil {
+		return nil
+	}
+	if err := l.addRow(sctx, oldRow, -1); err != nil {
+		return err
+	}
+	return l.addRow(sctx, newRow, 1)
+}
@@ -0,0 +1,49 @@
+// Copyright 2024 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package executor
+
+import (
+	"context"
+
+	"github.com/pingcap/tidb/pkg/executor/internal/exec"
+	"github.com/pingcap/tidb/pkg/util/chunk"
+)
+
+var _ exec.Executor = &OptimizePartitionExec{}
+
+// OptimizePartitionExec represents an executor for "ALTER TABLE [NAME] OPTIMIZE PARTITION" statement.
+// TiKV compacts its data by itself, so only the TiFlash replicas of the partitions are compacted, and
+// then the partitions are analyzed. Its children are the compact executor, if the table has TiFlash
+// replicas, and the analyze executor.
+type OptimizePartitionExec struct {
+	exec.BaseExecutor
+
+	done bool
+}
+
+// Next implements the Executor Next interface.
+func (e *OptimizePartitionExec) Next(ctx context.Context, req *chunk.Chunk) error {
+	req.Reset()
+	if e.done {
+		return nil
+	}
+	e.done = true
+	for _, child := range e.AllChildren() {
+		if err := exec.Next(ctx, child, req); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -129,6 +129,7 @@ func (e *ParallelNestedLoopApplyExec) Open(ctx context.Context) error {
 			return err
 		}
 		e.cache.GetMemTracker().AttachTo(e.memTracker)
+		e.cache.SetupSpill(e.Ctx(), exec.RetTypes(e.innerExecs[0]))
 	}
 	return nil
 }
@@ -176,6 +177,9 @@ func (e *ParallelNestedLoopApplyExec) Close() error {
 	// Wait all workers to finish before Close() is called.
 	// Otherwise we may got data race.
 	err := exec.Close(e.outerExec)
+	if e.cache != nil {
+		e.cache.Close()
+	}
 
 	if e.RuntimeStats() != nil {
 		runtimeStats := join.NewJoinRuntimeStats()
@@ -280,6 +284,7 @@ func (e *ParallelNestedLoopApplyExec) handleWorkerPanic(ctx context.Context, wg
 // fetchAllInners reads all data from the inner table and stores them in a List.
 func (e *ParallelNestedLoopApplyExec) fetchAllInners(ctx context.Context, id int) (err error) {
 	var key []byte
+	var fetched bool
 	for _, col := range e.corCols[id] {
 		*col.Data = e.outerRow[id].GetDatum(col.Index, col.RetType)
 		if e.useCache {
@@ -293,15 +298,29 @@ func (e *ParallelNestedLoopApplyExec) fetchAllInners(ctx context.Context, id int
 	if e.useCache { // look up the cache
 		atomic.AddInt64(&e.cacheAccessCounter, 1)
 		failpoint.Inject("parallelApplyGetCachePanic", nil)
-		value, err := e.cache.Get(key)
+		// The cache is shared by the workers, if another worker is fetching the inner rows of the same
+		// key, wait for its result.
+		var value *chunk.List
+		var shouldFetch bool
+		value, shouldFetch, err = e.cache.Fetch(ctx, key)
 		if err != nil {
 			return err
 		}
-		if value != nil {
+		if !shouldFetch {
 			e.innerList[id] = value
 			atomic.AddInt64(&e.cacheHitCounter, 1)
 			return nil
 		}
+		defer func() {
+			// Wake up the waiting workers even if the fetching fails or panics.
+			var value *chunk.List
+			if fetched {
+				value = e.innerList[id]
+			}
+			if doneErr := e.cache.Done(key, value); doneErr != nil && err == nil {
+				err = doneErr
+			}
+		}()
 	}
 
 	err = exec.Open(ctx, e.innerExecs[id])
@@ -338,11 +357,9 @@ func (e *ParallelNestedLoopApplyExec) fetchAllInners(ctx context.Context, id int
 		}
 	}
 
-	if e.useCache { // update the cache
+	if e.useCache { // the cache is updated when the fetching is done
 		failpoint.Inject("parallelApplySetCachePanic", nil)
-		if _, err := e.cache.Set(key, e.innerList[id]); err != nil {
-			return err
-		}
+		fetched = true
 	}
 	return nil
 }
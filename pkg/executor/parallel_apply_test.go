@@ -460,6 +460,34 @@ func TestApplyCacheRatio(t *testing.T) {
 	require.False(t, checkRatio(""))
 }
 
+func TestParallelApplySharedCache(t *testing.T) {
+	store := testkit.CreateMockStore(t)
+	tk := testkit.NewTestKit(t, store)
+	tk.MustExec("use test")
+	tk.MustExec("drop table if exists t1, t2")
+	tk.MustExec("create table t1(a int, b int)")
+	tk.MustExec("create table t2(a int, b int)")
+	values := make([]string, 0, 200)
+	for i := 0; i < 200; i++ {
+		values = append(values, fmt.Sprintf("(%d, %d)", i%20, i))
+	}
+	tk.MustExec("insert into t1 values " + strings.Join(values, ","))
+	tk.MustExec("insert into t2 values " + strings.Join(values[:50], ","))
+	sql := "select t1.b, (select count(*) from t2 where t2.a > t1.a) from t1"
+	tk.MustExec("set tidb_mem_quota_apply_cache = 0")
+	expected := tk.MustQuery(sql).Sort().Rows()
+
+	tk.MustExec("set tidb_enable_parallel_apply = true")
+	tk.MustExec("set tidb_executor_concurrency = 4")
+	tk.MustExec("set tidb_mem_quota_apply_cache = 33554432")
+	checkApplyPlan(t, tk, sql, 4)
+	tk.MustQuery(sql).Sort().Check(expected)
+	// The cached inner rows are spilled to disk when the cache is full.
+	tk.MustExec("set tidb_mem_quota_apply_cache = 1")
+	tk.MustQuery(sql).Sort().Check(expected)
+	tk.MustExec("set tidb_mem_quota_apply_cache = default")
+}
+
 func TestApplyGoroutinePanic(t *testing.T) {
 	store := testkit.CreateMockStore(t)
 	tk := testkit.NewTestKit(t, store)
@@ -26,6 +26,8 @@ import (
 	"github.com/pingcap/tidb/pkg/planner/core"
 	"github.com/pingcap/tidb/pkg/sessionctx"
 	"github.com/pingcap/tidb/pkg/util/chunk"
+	"github.com/pingcap/tidb/pkg/util/disk"
+	"github.com/pingcap/tidb/pkg/util/memory"
 )
 
 type dataInfo struct {
@@ -68,17 +70,32 @@ type PipelinedWindowExec struct {
 	// expectedCmpResult is used to decide if one value is included in the frame.
 	expectedCmpResult int64
 
-	// rows keeps rows starting from curStartRow
-	rows                     []chunk.Row
+	// rowBuffer buffers the child chunks of data, which can be spilled to disk.
+	rowBuffer *windowRowBuffer
+	// partition is the rows of the current partition in rowBuffer.
+	partition                windowRows
 	rowCnt                   uint64
 	whole                    bool
 	isRangeFrame             bool
 	emptyFrame               bool
 	initializedSlidingWindow bool
+
+	memTracker  *memory.Tracker
+	diskTracker *disk.Tracker
+	spillAction *windowSpillAction
 }
 
 // Close implements the Executor Close interface.
 func (e *PipelinedWindowExec) Close() error {
+	if e.spillAction != nil {
+		e.spillAction.SetFinished()
+		e.spillAction = nil
+	}
+	if e.rowBuffer != nil {
+		e.rowBuffer.close()
+		e.rowBuffer = nil
+	}
+	e.data = nil
 	return errors.Trace(e.BaseExecutor.Close())
 }
 
@@ -96,7 +113,14 @@ func (e *PipelinedWindowExec) Open(ctx context.Context) (err error) {
 			e.slidingWindowFuncs[i] = slidingWindowAggFunc
 		}
 	}
-	e.rows = make([]chunk.Row, 0)
+	if e.memTracker == nil {
+		e.memTracker = memory.NewTracker(e.ID(), -1)
+		e.memTracker.AttachTo(e.Ctx().GetSessionVars().StmtCtx.MemTracker)
+		e.diskTracker = disk.NewTracker(e.ID(), -1)
+		e.diskTracker.AttachTo(e.Ctx().GetSessionVars().StmtCtx.DiskTracker)
+	}
+	e.rowBuffer, e.spillAction = openWindowRowBuffer(e.Ctx(), exec.RetTypes(e.Children(0)), e.memTracker, e.diskTracker)
+	e.partition = windowRows{buffer: e.rowBuffer}
 	return e.BaseExecutor.Open(ctx)
 }
 
@@ -162,6 +186,16 @@ func (e *PipelinedWindowExec) Next(ctx context.Context, chk *chunk.Chunk) (err e
 		}
 	}
 	if len(e.data) > 0 {
+		// The rows of the first chunk in rowBuffer aren't accessed anymore, so its columns can be
+		// returned directly.
+		childChk, err := e.rowBuffer.getChunk(e.rowBuffer.firstChunkIdx)
+		if err != nil {
+			return err
+		}
+		if err = e.copyChk(childChk, e.data[0].chk); err != nil {
+			return err
+		}
+		e.rowBuffer.releaseFirstChunk()
 		chk.SwapColumns(e.data[0].chk)
 		e.data = e.data[1:]
 		e.dataIdx--
@@ -171,7 +205,7 @@ func (e *PipelinedWindowExec) Next(ctx context.Context, chk *chunk.Chunk) (err e
 
 func (e *PipelinedWindowExec) getRowsInPartition(ctx context.Context) (err error) {
 	e.newPartition = true
-	if len(e.rows) == 0 {
+	if e.rowCnt+e.rowToConsume == e.rowStart {
 		// if getRowsInPartition is called for the first time, we ignore it as a new partition
 		e.newPartition = false
 	}
@@ -198,9 +232,6 @@ func (e *PipelinedWindowExec) getRowsInPartition(ctx context.Context) (err error
 	}
 	begin, end := e.groupChecker.GetNextGroup()
 	e.rowToConsume += uint64(end - begin)
-	for i := begin; i < end; i++ {
-		e.rows = append(e.rows, e.childResult.GetRow(i))
-	}
 	return
 }
 
@@ -219,15 +250,11 @@ func (e *PipelinedWindowExec) fetchChild(ctx context.Context) (eof bool, err err
 
 	// TODO: reuse chunks
 	resultChk := e.AllocPool.Alloc(e.RetFieldTypes(), 0, numRows)
-	err = e.copyChk(childResult, resultChk)
-	if err != nil {
-		return false, err
-	}
 	e.accumulated += uint64(numRows)
 	e.data = append(e.data, dataInfo{chk: resultChk, remaining: uint64(numRows), accumulated: e.accumulated})
 
 	e.childResult = childResult
-	return false, nil
+	return false, e.rowBuffer.add(childResult)
 }
 
 func (e *PipelinedWindowExec) copyChk(src, dst *chunk.Chunk) error {
@@ -240,12 +267,12 @@ func (e *PipelinedWindowExec) copyChk(src, dst *chunk.Chunk) error {
 	return nil
 }
 
-func (e *PipelinedWindowExec) getRow(i uint64) chunk.Row {
-	return e.rows[i-e.rowStart]
+func (e *PipelinedWindowExec) getRow(i uint64) (chunk.Row, error) {
+	return e.partition.getRow(i)
 }
 
-func (e *PipelinedWindowExec) getRows(start, end uint64) []chunk.Row {
-	return e.rows[start-e.rowStart : end-e.rowStart]
+func (e *PipelinedWindowExec) getRows(start, end uint64) ([]chunk.Row, error) {
+	return e.partition.getRows(start, end)
 }
 
 // finish is called upon a whole partition is consumed
@@ -261,9 +288,16 @@ func (e *PipelinedWindowExec) getStart(ctx sessionctx.Context) (uint64, error) {
 		var start uint64
 		for start = max(e.lastStartRow, e.stagedStartRow); start < e.rowCnt; start++ {
 			var res int64
-			var err error
+			startRow, err := e.getRow(start)
+			if err != nil {
+				return 0, err
+			}
+			curRow, err := e.getRow(e.curRowIdx)
+			if err != nil {
+				return 0, err
+			}
 			for i := range e.orderByCols {
-				res, _, err = e.start.CmpFuncs[i](ctx.GetExprCtx().GetEvalCtx(), e.start.CompareCols[i], e.start.CalcFuncs[i], e.getRow(start), e.getRow(e.curRowIdx))
+				res, _, err = e.start.CmpFuncs[i](ctx.GetExprCtx().GetEvalCtx(), e.start.CompareCols[i], e.start.CalcFuncs[i], startRow, curRow)
 				if err != nil {
 					return 0, err
 				}
@@ -301,9 +335,16 @@ func (e *PipelinedWindowExec) getEnd(ctx sessionctx.Context) (uint64, error) {
 		var end uint64
 		for end = max(e.lastEndRow, e.stagedEndRow); end < e.rowCnt; end++ {
 			var res int64
-			var err error
+			curRow, err := e.getRow(e.curRowIdx)
+			if err != nil {
+				return 0, err
+			}
+			endRow, err := e.getRow(end)
+			if err != nil {
+				return 0, err
+			}
 			for i := range e.orderByCols {
-				res, _, err = e.end.CmpFuncs[i](ctx.GetExprCtx().GetEvalCtx(), e.end.CalcFuncs[i], e.end.CompareCols[i], e.getRow(e.curRowIdx), e.getRow(end))
+				res, _, err = e.end.CmpFuncs[i](ctx.GetExprCtx().GetEvalCtx(), e.end.CalcFuncs[i], e.end.CompareCols[i], curRow, endRow)
 				if err != nil {
 					return 0, err
 				}
@@ -380,11 +421,18 @@ func (e *PipelinedWindowExec) produce(ctx sessionctx.Context, chk *chunk.Chunk,
 			}
 		} else {
 			e.emptyFrame = false
+			var getRow func(uint64) chunk.Row
 			for i, wf := range e.windowFuncs {
 				slidingWindowAggFunc := e.slidingWindowFuncs[i]
 				if e.lastStartRow != start || e.lastEndRow != end {
 					if slidingWindowAggFunc != nil && e.initializedSlidingWindow {
-						err = slidingWindowAggFunc.Slide(ctx.GetExprCtx().GetEvalCtx(), e.getRow, e.lastStartRow, e.lastEndRow, start-e.lastStartRow, end-e.lastEndRow, e.partialResults[i])
+						if getRow == nil {
+							getRow, err = e.partition.rowGetterForSlide(e.lastStartRow, e.lastEndRow, start-e.lastStartRow, end-e.lastEndRow)
+							if err != nil {
+								return
+							}
+						}
+						err = slidingWindowAggFunc.Slide(ctx.GetExprCtx().GetEvalCtx(), getRow, e.lastStartRow, e.lastEndRow, start-e.lastStartRow, end-e.lastEndRow, e.partialResults[i])
 					} else {
 						// For MinMaxSlidingWindowAggFuncs, it needs the absolute value of each start of window, to compare
 						// whether elements inside deque are out of current window.
@@ -394,7 +442,12 @@ func (e *PipelinedWindowExec) produce(ctx sessionctx.Context, chk *chunk.Chunk,
 						}
 						// TODO(zhifeng): track memory usage here
 						wf.ResetPartialResult(e.partialResults[i])
-						_, err = wf.UpdatePartialResult(ctx.GetExprCtx().GetEvalCtx(), e.getRows(start, end), e.partialResults[i])
+						var rows []chunk.Row
+						rows, err = e.getRows(start, end)
+						if err != nil {
+							return
+						}
+						_, err = wf.UpdatePartialResult(ctx.GetExprCtx().GetEvalCtx(), rows, e.partialResults[i])
 					}
 				}
 				if err != nil {
@@ -407,6 +460,7 @@ func (e *PipelinedWindowExec) produce(ctx sessionctx.Context, chk *chunk.Chunk,
 			}
 			e.initializedSlidingWindow = true
 		}
+		e.partition.releaseRestored()
 		e.curRowIdx++
 		e.lastStartRow, e.lastEndRow = start, end
 
@@ -417,7 +471,6 @@ func (e *PipelinedWindowExec) produce(ctx sessionctx.Context, chk *chunk.Chunk,
 	if extend > e.rowStart {
 		numDrop := extend - e.rowStart
 		e.dropped += numDrop
-		e.rows = e.rows[numDrop:]
 		e.rowStart = extend
 	}
 	return
@@ -452,7 +505,7 @@ func (e *PipelinedWindowExec) reset() {
 	e.whole = false
 	numDrop := e.rowCnt - e.rowStart
 	e.dropped += numDrop
-	e.rows = e.rows[numDrop:]
+	e.partition.reset(e.partition.start + e.rowCnt)
 	e.rowStart = 0
 	e.rowCnt = 0
 	e.initializedSlidingWindow = false
@@ -31,6 +31,7 @@ import (
 	"github.com/pingcap/tidb/pkg/parser/mysql"
 	plannercore "github.com/pingcap/tidb/pkg/planner/core"
 	"github.com/pingcap/tidb/pkg/sessionctx"
+	storeerr "github.com/pingcap/tidb/pkg/store/driver/error"
 	"github.com/pingcap/tidb/pkg/table"
 	"github.com/pingcap/tidb/pkg/table/tables"
 	"github.com/pingcap/tidb/pkg/tablecodec"
@@ -149,6 +150,7 @@ type PointGetExecutor struct {
 	done             bool
 	lock             bool
 	lockWaitTime     int64
+	skipLocked       bool
 	rowDecoder       *rowcodec.ChunkDecoder
 
 	columns []*model.ColumnInfo
@@ -207,10 +209,12 @@ func (e *PointGetExecutor) Init(p *plannercore.PointGetPlan) {
 	if e.tblInfo.TempTableType == model.TempTableNone {
 		e.lock = p.Lock
 		e.lockWaitTime = p.LockWaitTime
+		e.skipLocked = p.SkipLocked
 	} else {
 		// Temporary table should not do any lock operations
 		e.lock = false
 		e.lockWaitTime = 0
+		e.skipLocked = false
 	}
 	e.rowDecoder = decoder
 	e.partitionDefIdx = p.PartitionIdx
@@ -262,14 +266,22 @@ func (e *PointGetExecutor) Close() error {
 }
 
 // Next implements the Executor interface.
-func (e *PointGetExecutor) Next(ctx context.Context, req *chunk.Chunk) error {
+func (e *PointGetExecutor) Next(ctx context.Context, req *chunk.Chunk) (err error) {
 	req.Reset()
 	if e.done {
 		return nil
 	}
 	e.done = true
 
-	var err error
+	if e.skipLocked {
+		defer func() {
+			// The row is skipped if it's locked by others.
+			if err != nil && storeerr.ErrLockAcquireFailAndNoWaitSet.Equal(err) {
+				req.Reset()
+				err = onRowsSkipLocked(e.Ctx())
+			}
+		}()
+	}
 	tblID := GetPhysID(e.tblInfo, e.partitionDefIdx)
 	if e.lock {
 		e.UpdateDeltaForTableID(tblID)
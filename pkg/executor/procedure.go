@@ -0,0 +1,1363 @@
+// Copyright 2024 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package executor
+
+import (
+	"context"
+	"strconv"
+	"strings"
+
+	"github.com/pingcap/errors"
+	"github.com/pingcap/tidb/pkg/domain"
+	"github.com/pingcap/tidb/pkg/executor/internal/exec"
+	"github.com/pingcap/tidb/pkg/expression"
+	"github.com/pingcap/tidb/pkg/extension"
+	"github.com/pingcap/tidb/pkg/infoschema"
+	"github.com/pingcap/tidb/pkg/kv"
+	"github.com/pingcap/tidb/pkg/parser"
+	"github.com/pingcap/tidb/pkg/parser/ast"
+	"github.com/pingcap/tidb/pkg/parser/auth"
+	"github.com/pingcap/tidb/pkg/parser/charset"
+	"github.com/pingcap/tidb/pkg/parser/format"
+	"github.com/pingcap/tidb/pkg/parser/model"
+	"github.com/pingcap/tidb/pkg/parser/mysql"
+	"github.com/pingcap/tidb/pkg/parser/opcode"
+	"github.com/pingcap/tidb/pkg/parser/terror"
+	plannerutil "github.com/pingcap/tidb/pkg/planner/util"
+	"github.com/pingcap/tidb/pkg/privilege"
+	"github.com/pingcap/tidb/pkg/privilege/privileges"
+	"github.com/pingcap/tidb/pkg/sessionctx"
+	"github.com/pingcap/tidb/pkg/sessionctx/stmtctx"
+	"github.com/pingcap/tidb/pkg/sessionctx/variable"
+	"github.com/pingcap/tidb/pkg/types"
+	"github.com/pingcap/tidb/pkg/util/chunk"
+	"github.com/pingcap/tidb/pkg/util/dbterror/exeerrors"
+	"github.com/pingcap/tidb/pkg/util/sqlescape"
+	"github.com/pingcap/tidb/pkg/util/sqlexec"
+)
+
+// routinesTable is the system table which stores the stored routines.
+const routinesTable = "routines"
+
+// ProcedureResultVarKeyType is a dummy type to avoid naming collision in context.
+type ProcedureResultVarKeyType int
+
+// String defines a Stringer function for debugging and pretty printing.
+func (ProcedureResultVarKeyType) String() string {
+	return "procedure_result_var"
+}
+
+// ProcedureResultVarKey is a variable key for the result sets of a stored procedure.
+const ProcedureResultVarKey ProcedureResultVarKeyType = 0
+
+// ProcedureResultInfo contains the result sets produced by the statements of a stored procedure.
+type ProcedureResultInfo struct {
+	ResultSets []sqlexec.RecordSet
+}
+
+// procedureDefinition is a stored procedure loaded from the mysql.routines table.
+type procedureDefinition struct {
+	db            string
+	name          string
+	definer       string
+	securityType  string
+	sqlMode       string
+	paramList     string
+	body          string
+	comment       string
+	charsetClient string
+	collationConn string
+	dbCollation   string
+	created       types.Time
+	modified      types.Time
+}
+
+// loadProcedureDefinitions loads the stored procedures in the database, or all the stored procedures
+// if dbName is empty. Only the procedure with the name is loaded if procName is not empty.
+func loadProcedureDefinitions(ctx context.Context, sctx sessionctx.Context, dbName, procName string) ([]*procedureDefinition, error) {
+	var sql strings.Builder
+	sqlescape.MustFormatSQL(&sql, `SELECT db, specific_name, definer, security_type, sql_mode, param_list, body, comment, character_set_client, collation_connection, db_collation, created, modified FROM %n.%n WHERE type = 'PROCEDURE'`,
+		mysql.SystemDB, routinesTable)
+	if dbName != "" {
+		sqlescape.MustFormatSQL(&sql, " AND db = %?", strings.ToLower(dbName))
+	}
+	if procName != "" {
+		sqlescape.MustFormatSQL(&sql, " AND name = %?", strings.ToLower(procName))
+	}
+	sql.WriteString(" ORDER BY db, name")
+	ctx = kv.WithInternalSourceType(ctx, kv.InternalTxnOthers)
+	rows, _, err := sctx.GetRestrictedSQLExecutor().ExecRestrictedSQL(ctx, nil, sql.String())
+	if err != nil {
+		return nil, err
+	}
+	defs := make([]*procedureDefinition, 0, len(rows))
+	for _, row := range rows {
+		def := &procedureDefinition{
+			db:            row.GetString(0),
+			name:          row.GetString(1),
+			definer:       row.GetString(2),
+			securityType:  row.GetEnum(3).String(),
+			sqlMode:       row.GetString(4),
+			paramList:     string(row.GetBytes(5)),
+			body:          string(row.GetBytes(6)),
+			charsetClient: row.GetString(8),
+			collationConn: row.GetString(9),
+			dbCollation:   row.GetString(10),
+			created:       row.GetTime(11),
+			modified:      row.GetTime(12),
+		}
+		if !row.IsNull(7) {
+			def.comment = row.GetString(7)
+		}
+		defs = append(defs, def)
+	}
+	return defs, nil
+}
+
+// createStmt returns the CREATE PROCEDURE statement of the procedure.
+func (d *procedureDefinition) createStmt() string {
+	var sb strings.Builder
+	sb.WriteString("CREATE")
+	if user, host, ok := strings.Cut(d.definer, "@"); ok {
+		sqlescape.MustFormatSQL(&sb, " DEFINER=%n@%n", user, host)
+	}
+	sqlescape.MustFormatSQL(&sb, " PROCEDURE %n(", d.name)
+	sb.WriteString(d.paramList)
+	sb.WriteString(")\n")
+	if d.securityType == "INVOKER" {
+		sb.WriteString("    SQL SECURITY INVOKER\n")
+	}
+	sb.WriteString(d.body)
+	return sb.String()
+}
+
+// procedureIsVisible checks whether the current user can see the procedures in the database.
+func procedureIsVisible(sctx sessionctx.Context, db string) bool {
+	checker := privilege.GetPrivilegeManager(sctx)
+	if checker == nil || sctx.GetSessionVars().User == nil {
+		return true
+	}
+	activeRoles := sctx.GetSessionVars().ActiveRoles
+	if checker.RequestVerification(activeRoles, "", "", "", mysql.SelectPriv) {
+		return true
+	}
+	for _, priv := range []mysql.PrivilegeType{mysql.CreateRoutinePriv, mysql.AlterRoutinePriv, mysql.ExecutePriv} {
+		if checker.RequestVerification(activeRoles, db, "", "", priv) {
+			return true
+		}
+	}
+	return false
+}
+
+// parse parses the definition with the sql mode that the procedure was created with.
+func (d *procedureDefinition) parse(sessVars *variable.SessionVars) (*ast.ProcedureInfo, mysql.SQLMode, error) {
+	sqlMode, err := mysql.GetSQLMode(d.sqlMode)
+	if err != nil {
+		return nil, 0, err
+	}
+	sql := "CREATE PROCEDURE " + sqlescape.MustEscapeSQL("%n", d.name) + "(" + d.paramList + ") " + d.body
+	p := parser.New()
+	p.SetParserConfig(sessVars.BuildParserConfig())
+	p.SetSQLMode(sqlMode)
+	var cs string
+	if coll, err := charset.GetCollationByName(d.collationConn); err == nil {
+		cs = coll.CharsetName
+	}
+	stmt, err := p.ParseOneStmt(sql, cs, d.collationConn)
+	if err != nil {
+		return nil, 0, err
+	}
+	info, ok := stmt.(*ast.ProcedureInfo)
+	if !ok {
+		return nil, 0, errors.Errorf("invalid definition of procedure %s", d.name)
+	}
+	return info, sqlMode, nil
+}
+
+func (e *SimpleExec) executeCreateProcedure(ctx context.Context, s *ast.ProcedureInfo) error {
+	sessVars := e.Ctx().GetSessionVars()
+	if sessVars.ProcedureContext != nil {
+		return exeerrors.ErrSpNoRecursiveCreate.GenWithStackByArgs("PROCEDURE")
+	}
+	name := s.ProcedureName
+	schema, ok := e.is.SchemaByName(name.Schema)
+	if !ok {
+		return infoschema.ErrDatabaseNotExists.GenWithStackByArgs(name.Schema.O)
+	}
+	if err := checkProcedure(s); err != nil {
+		return err
+	}
+	var definer string
+	if user := sessVars.User; user != nil {
+		definer = user.AuthUsername + "@" + user.AuthHostname
+	}
+	csClient, err := sessVars.GetSessionOrGlobalSystemVar(ctx, variable.CharacterSetClient)
+	if err != nil {
+		return err
+	}
+	_, collation := sessVars.GetCharsetInfo()
+	sqlMode, _ := sessVars.GetSystemVar(variable.SQLModeVar)
+	ctx = kv.WithInternalSourceType(ctx, kv.InternalTxnOthers)
+	exec := e.Ctx().GetRestrictedSQLExecutor()
+	rows, _, err := exec.ExecRestrictedSQL(ctx, nil, `SELECT 1 FROM %n.%n WHERE db = %? AND name = %? AND type = 'PROCEDURE'`,
+		mysql.SystemDB, routinesTable, name.Schema.L, name.Name.L)
+	if err != nil {
+		return err
+	}
+	if len(rows) == 0 {
+		_, _, err = exec.ExecRestrictedSQL(ctx, nil,
+			`INSERT INTO %n.%n (db, name, type, specific_name, param_list, body, definer, security_type, sql_mode, character_set_client, collation_connection, db_collation) VALUES (%?, %?, 'PROCEDURE', %?, %?, %?, %?, %?, %?, %?, %?, %?)`,
+			mysql.SystemDB, routinesTable, name.Schema.L, name.Name.L, name.Name.O, s.ProcedureParamStr, s.ProcedureBody.Text(),
+			definer, s.Security.String(), sqlMode, csClient, collation, schema.Collate)
+		if !kv.ErrKeyExists.Equal(err) {
+			return err
+		}
+	}
+	// The procedure exists, or it's created by another session concurrently.
+	err = exeerrors.ErrSpAlreadyExists.GenWithStackByArgs("PROCEDURE", name.Name.O)
+	if s.IfNotExists {
+		sessVars.StmtCtx.AppendNote(err)
+		return nil
+	}
+	return err
+}
+
+func (e *SimpleExec) executeDropProcedure(ctx context.Context, s *ast.DropProcedureStmt) error {
+	name := s.ProcedureName
+	ctx = kv.WithInternalSourceType(ctx, kv.InternalTxnOthers)
+	exec := e.Ctx().GetRestrictedSQLExecutor()
+	rows, _, err := exec.ExecRestrictedSQL(ctx, nil, `SELECT 1 FROM %n.%n WHERE db = %? AND name = %? AND type = 'PROCEDURE'`,
+		mysql.SystemDB, routinesTable, name.Schema.L, name.Name.L)
+	if err != nil {
+		return err
+	}
+	if len(rows) == 0 {
+		err = exeerrors.ErrSpDoesNotExist.GenWithStackByArgs("PROCEDURE", name.Schema.O+"."+name.Name.O)
+		if s.IfExists {
+			e.Ctx().GetSessionVars().StmtCtx.AppendNote(err)
+			return nil
+		}
+		return err
+	}
+	_, _, err = exec.ExecRestrictedSQL(ctx, nil, `DELETE FROM %n.%n WHERE db = %? AND name = %? AND type = 'PROCEDURE'`,
+		mysql.SystemDB, routinesTable, name.Schema.L, name.Name.L)
+	return err
+}
+
+// dropSchemaProcedures deletes the stored procedures of a dropped database.
+func dropSchemaProcedures(sctx sessionctx.Context, dbName string) error {
+	ctx := kv.WithInternalSourceType(context.Background(), kv.InternalTxnOthers)
+	_, _, err := sctx.GetRestrictedSQLExecutor().ExecRestrictedSQL(ctx, nil, `DELETE FROM %n.%n WHERE db = %?`,
+		mysql.SystemDB, routinesTable, dbName)
+	return err
+}
+
+// procedureChecker checks the parameters and the body of a stored procedure.
+type procedureChecker struct {
+	// labels are the labels of the enclosing blocks and loops.
+	labels []ast.LabelInfo
+	// scopes are the variables and cursors declared in the enclosing blocks.
+	scopes []*procedureCheckScope
+	// trigger and tblInfo are set when checking the body of a trigger.
+	trigger *ast.CreateTriggerStmt
+	tblInfo *model.TableInfo
+	// event is set when checking the body of an event.
+	event bool
+}
+
+type procedureCheckScope struct {
+	vars    map[string]struct{}
+	cursors map[string]struct{}
+}
+
+func newProcedureCheckScope() *procedureCheckScope {
+	return &procedureCheckScope{
+		vars:    make(map[string]struct{}),
+		cursors: make(map[string]struct{}),
+	}
+}
+
+// checkProcedure checks the procedure definition before it is stored or executed.
+func checkProcedure(proc *ast.ProcedureInfo) error {
+	c := &procedureChecker{}
+	params := newProcedureCheckScope()
+	for _, param := range proc.ProcedureParam {
+		name := strings.ToLower(param.ParamName)
+		if _, ok := params.vars[name]; ok {
+			return exeerrors.ErrSpDupParam.GenWithStackByArgs(param.ParamName)
+		}
+		params.vars[name] = struct{}{}
+	}
+	c.scopes = append(c.scopes, params)
+	return c.checkStmt(proc.ProcedureBody)
+}
+
+func (c *procedureChecker) checkStmts(stmts []ast.StmtNode) error {
+	for _, stmt := range stmts {
+		if err := c.checkStmt(stmt); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (c *procedureChecker) checkStmt(stmt ast.StmtNode) error {
+	switch x := stmt.(type) {
+	case *ast.ProcedureBlock:
+		return c.checkBlock(x)
+	case *ast.ProcedureLabelBlock:
+		return c.checkLabel(x)
+	case *ast.ProcedureLabelLoop:
+		return c.checkLabel(x)
+	case *ast.ProcedureWhileStmt:
+		if err := c.checkNode(x.Condition); err != nil {
+			return err
+		}
+		return c.checkStmts(x.Body)
+	case *ast.ProcedureRepeatStmt:
+		if err := c.checkNode(x.Condition); err != nil {
+			return err
+		}
+		return c.checkStmts(x.Body)
+	case *ast.ProcedureLoopStmt:
+		return c.checkStmts(x.Body)
+	case *ast.ProcedureIfInfo:
+		return c.checkIfBlock(x.IfBody)
+	case *ast.SimpleCaseStmt:
+		if err := c.checkNode(x.Condition); err != nil {
+			return err
+		}
+		for _, when := range x.WhenCases {
+			if err := c.checkNode(when.Expr); err != nil {
+				return err
+			}
+			if err := c.checkStmts(when.ProcedureStmts); err != nil {
+				return err
+			}
+		}
+		return c.checkStmts(x.ElseCases)
+	case *ast.SearchCaseStmt:
+		for _, when := range x.WhenCases {
+			if err := c.checkNode(when.Expr); err != nil {
+				return err
+			}
+			if err := c.checkStmts(when.ProcedureStmts); err != nil {
+				return err
+			}
+		}
+		return c.checkStmts(x.ElseCases)
+	case *ast.ProcedureJump:
+		return c.checkJump(x)
+	case *ast.ProcedureOpenCur:
+		return c.checkCursor(x.CurName)
+	case *ast.ProcedureCloseCur:
+		return c.checkCursor(x.CurName)
+	case *ast.ProcedureFetchInto:
+		if err := c.checkCursor(x.CurName); err != nil {
+			return err
+		}
+		for _, name := range x.Variables {
+			if !c.hasVariable(name) {
+				return exeerrors.ErrSpUndeclaredVar.GenWithStackByArgs(name)
+			}
+		}
+	case *ast.SetStmt:
+		return c.checkSet(x)
+	default:
+		if err := c.checkEventStmt(stmt); err != nil {
+			return err
+		}
+		return c.checkTriggerStmt(stmt)
+	}
+	return nil
+}
+
+func (c *procedureChecker) checkIfBlock(block *ast.ProcedureIfBlock) error {
+	if err := c.checkNode(block.IfExpr); err != nil {
+		return err
+	}
+	if err := c.checkStmts(block.ProcedureIfStmts); err != nil {
+		return err
+	}
+	switch x := block.ProcedureElseStmt.(type) {
+	case *ast.ProcedureElseIfBlock:
+		return c.checkIfBlock(x.ProcedureIfStmt)
+	case *ast.ProcedureElseBlock:
+		return c.checkStmts(x.ProcedureIfStmts)
+	}
+	return nil
+}
+
+func (c *procedureChecker) checkBlock(block *ast.ProcedureBlock) error {
+	scope := newProcedureCheckScope()
+	c.scopes = append(c.scopes, scope)
+	defer func() {
+		c.scopes = c.scopes[:len(c.scopes)-1]
+	}()
+	var (
+		hasCursor  bool
+		hasHandler bool
+		conditions = make(map[string]struct{})
+		handlers   []*ast.ProcedureErrorControl
+	)
+	for _, decl := range block.ProcedureVars {
+		switch x := decl.(type) {
+		case *ast.ProcedureDecl:
+			if hasCursor || hasHandler {
+				return exeerrors.ErrSpVarcondAfterCurshndlr.GenWithStackByArgs()
+			}
+			if err := c.checkNode(x.DeclDefault); err != nil {
+				return err
+			}
+			for _, name := range x.DeclNames {
+				if _, ok := scope.vars[name]; ok {
+					return exeerrors.ErrSpDupVar.GenWithStackByArgs(name)
+				}
+				scope.vars[name] = struct{}{}
+			}
+		case *ast.ProcedureCursor:
+			if hasHandler {
+				return exeerrors.ErrSpCursorAfterHandler.GenWithStackByArgs()
+			}
+			hasCursor = true
+			if err := c.checkNode(x.Selectstring); err != nil {
+				return err
+			}
+			if _, ok := scope.cursors[x.CurName]; ok {
+				return exeerrors.ErrSpDupCurs.GenWithStackByArgs(x.CurName)
+			}
+			scope.cursors[x.CurName] = struct{}{}
+		case *ast.ProcedureErrorControl:
+			hasHandler = true
+			for _, cond := range x.ErrorCon {
+				key := procedureConditionKey(cond)
+				if _, ok := conditions[key]; ok {
+					return exeerrors.ErrSpDupHandler.GenWithStackByArgs()
+				}
+				conditions[key] = struct{}{}
+			}
+			handlers = append(handlers, x)
+		}
+	}
+	// The handlers can't jump to the labels outside of them.
+	labels := c.labels
+	c.labels = nil
+	for _, handler := range handlers {
+		if err := c.checkStmt(handler.Operate); err != nil {
+			return err
+		}
+	}
+	c.labels = labels
+	return c.checkStmts(block.ProcedureProcStmts)
+}
+
+func (c *procedureChecker) checkLabel(label ast.LabelInfo) error {
+	if end, isErr := label.GetErrorStatus(); isErr {
+		return exeerrors.ErrSpLabelMismatch.GenWithStackByArgs(end)
+	}
+	name := label.GetLabelName()
+	for _, l := range c.labels {
+		if strings.EqualFold(l.GetLabelName(), name) {
+			return exeerrors.ErrSpLabelRedefine.GenWithStackByArgs(name)
+		}
+	}
+	c.labels = append(c.labels, label)
+	defer func() {
+		c.labels = c.labels[:len(c.labels)-1]
+	}()
+	return c.checkStmt(label.GetBlock())
+}
+
+func (c *procedureChecker) checkJump(jump *ast.ProcedureJump) error {
+	op := "ITERATE"
+	if jump.IsLeave {
+		op = "LEAVE"
+	}
+	for i := len(c.labels) - 1; i >= 0; i-- {
+		label := c.labels[i]
+		if !strings.EqualFold(label.GetLabelName(), jump.Name) {
+			continue
+		}
+		if !jump.IsLeave && label.IsBlock() {
+			break
+		}
+		return nil
+	}
+	return exeerrors.ErrSpLilabelMismatch.GenWithStackByArgs(op, jump.Name)
+}
+
+func (c *procedureChecker) checkCursor(name string) error {
+	for i := len(c.scopes) - 1; i >= 0; i-- {
+		if _, ok := c.scopes[i].cursors[name]; ok {
+			return nil
+		}
+	}
+	return exeerrors.ErrSpCursorMismatch.GenWithStackByArgs(name)
+}
+
+func (c *procedureChecker) hasVariable(name string) bool {
+	for i := len(c.scopes) - 1; i >= 0; i-- {
+		if _, ok := c.scopes[i].vars[name]; ok {
+			return true
+		}
+	}
+	return false
+}
+
+func procedureConditionKey(cond ast.ErrNode) string {
+	switch x := cond.(type) {
+	case *ast.ProcedureErrorVal:
+		return "code:" + strconv.FormatUint(x.ErrorNum, 10)
+	case *ast.ProcedureErrorState:
+		return "state:" + x.CodeStatus
+	case *ast.ProcedureErrorCon:
+		return "class:" + strconv.Itoa(x.ErrorCon)
+	}
+	return ""
+}
+
+// CallExec executes the CALL statement.
+type CallExec struct {
+	exec.BaseExecutor
+
+	dbName   model.CIStr
+	procName model.CIStr
+	params   []expression.Expression
+	args     []ast.ExprNode
+	done     bool
+
+	// callSC is the statement context of the CALL statement.
+	callSC *stmtctx.StatementContext
+	// lastSC and lastWarnCnt record the warnings which have been moved to callSC.
+	lastSC       *stmtctx.StatementContext
+	lastWarnCnt  int
+	affectedRows uint64
+}
+
+// Next implements the Executor Next interface.
+func (e *CallExec) Next(ctx context.Context, req *chunk.Chunk) error {
+	req.Reset()
+	if e.done {
+		return nil
+	}
+	e.done = true
+
+	sessVars := e.Ctx().GetSessionVars()
+	var parent *procedureContext
+	if sessVars.ProcedureContext != nil {
+		parent, _ = sessVars.ProcedureContext.(*procedureContext)
+	}
+	if err := e.checkRecursion(ctx, parent); err != nil {
+		return err
+	}
+	defs, err := loadProcedureDefinitions(ctx, e.Ctx(), e.dbName.L, e.procName.L)
+	if err != nil {
+		return err
+	}
+	if len(defs) == 0 {
+		return exeerrors.ErrSpDoesNotExist.GenWithStackByArgs("PROCEDURE", e.dbName.O+"."+e.procName.O)
+	}
+	proc, sqlMode, err := defs[0].parse(sessVars)
+	if err != nil {
+		return err
+	}
+	if err := checkProcedure(proc); err != nil {
+		return err
+	}
+	if len(proc.ProcedureParam) != len(e.params) {
+		return exeerrors.ErrSpWrongNoOfArgs.GenWithStackByArgs("PROCEDURE", e.dbName.O+"."+e.procName.O, len(proc.ProcedureParam), len(e.params))
+	}
+
+	procCtx := &procedureContext{
+		sctx:     e.Ctx(),
+		call:     e,
+		parent:   parent,
+		dbName:   e.dbName,
+		procName: e.procName,
+	}
+	params, err := e.bindParams(proc, procCtx)
+	if err != nil {
+		return err
+	}
+	procCtx.scopes = append(procCtx.scopes, params)
+
+	// A procedure with SQL SECURITY DEFINER is executed with the privileges of its definer.
+	if defs[0].securityType == "DEFINER" && privilege.GetPrivilegeManager(e.Ctx()) != nil {
+		definer := routineDefiner(defs[0].definer)
+		if definer == nil {
+			return exeerrors.ErrMalformedDefiner.GenWithStackByArgs()
+		}
+		restorePrivileges, err := bindDefinerPrivileges(e.Ctx(), definer)
+		if err != nil {
+			return err
+		}
+		defer restorePrivileges()
+	}
+
+	e.callSC = sessVars.StmtCtx
+	origProcCtx, origDB, origSQLMode := sessVars.ProcedureContext, sessVars.CurrentDB, sessVars.SQLMode
+	origStartTime, origDurationCompile := sessVars.StartTime, sessVars.DurationCompile
+	sessVars.ProcedureContext = procCtx
+	sessVars.CurrentDB = e.dbName.O
+	sessVars.SQLMode = sqlMode
+	defer func() {
+		e.collectWarnings()
+		last := sessVars.StmtCtx
+		if last != e.callSC {
+			if last.LastInsertID > 0 {
+				e.callSC.LastInsertID = last.LastInsertID
+			} else {
+				e.callSC.LastInsertID = last.PrevLastInsertID
+			}
+			e.callSC.SetAffectedRows(e.affectedRows)
+		}
+		sessVars.StmtCtx = e.callSC
+		sessVars.ProcedureContext = origProcCtx
+		sessVars.CurrentDB = origDB
+		sessVars.SQLMode = origSQLMode
+		sessVars.StartTime, sessVars.DurationCompile = origStartTime, origDurationCompile
+	}()
+
+	err = procCtx.execStmt(ctx, proc.ProcedureBody)
+	if err != nil {
+		if unhandled, ok := err.(*procedureUnhandledError); ok {
+			err = unhandled.err
+		}
+		return err
+	}
+	if err := e.writeBackParams(proc, procCtx, params); err != nil {
+		return err
+	}
+	if parent == nil && len(procCtx.results) > 0 {
+		e.Ctx().SetValue(ProcedureResultVarKey, &ProcedureResultInfo{ResultSets: procCtx.results})
+	}
+	return nil
+}
+
+// bindDefinerPrivileges makes the session check the privileges of the definer by a privilege manager
+// authenticated as the definer. It returns a function to restore the user and the privileges.
+func bindDefinerPrivileges(sctx sessionctx.Context, definer *auth.UserIdentity) (func(), error) {
+	extensions, err := extension.GetExtensions()
+	if err != nil {
+		return nil, err
+	}
+	pm := privileges.NewUserPrivileges(domain.GetDomain(sctx).PrivilegeHandle(), extensions)
+	pm.AuthSuccess(definer.Username, definer.Hostname)
+	sessVars := sctx.GetSessionVars()
+	origPM := privilege.GetPrivilegeManager(sctx)
+	origUser, origRoles := sessVars.User, sessVars.ActiveRoles
+	sessVars.User = definer
+	sessVars.ActiveRoles = pm.GetDefaultRoles(definer.Username, definer.Hostname)
+	privilege.BindPrivilegeManager(sctx, pm)
+	return func() {
+		sessVars.User = origUser
+		sessVars.ActiveRoles = origRoles
+		privilege.BindPrivilegeManager(sctx, origPM)
+	}, nil
+}
+
+// checkRecursion checks whether the recursion depth of the procedure exceeds max_sp_recursion_depth.
+func (e *CallExec) checkRecursion(ctx context.Context, parent *procedureContext) error {
+	depth := 0
+	for p := parent; p != nil; p = p.parent {
+		if p.dbName.L == e.dbName.L && p.procName.L == e.procName.L {
+			depth++
+		}
+	}
+	if depth == 0 {
+		return nil
+	}
+	val, err := e.Ctx().GetSessionVars().GetSessionOrGlobalSystemVar(ctx, variable.MaxSpRecursionDepth)
+	if err != nil {
+		return err
+	}
+	maxDepth, err := strconv.Atoi(val)
+	if err != nil {
+		return err
+	}
+	if depth > maxDepth {
+		return exeerrors.ErrSpRecursionLimit.GenWithStackByArgs(maxDepth, e.procName.O)
+	}
+	return nil
+}
+
+// bindParams evaluates the arguments and binds them to the parameters of the procedure.
+func (e *CallExec) bindParams(proc *ast.ProcedureInfo, procCtx *procedureContext) (*procedureScope, error) {
+	sessVars := e.Ctx().GetSessionVars()
+	evalCtx := e.Ctx().GetExprCtx().GetEvalCtx()
+	scope := newProcedureScope()
+	for i, param := range proc.ProcedureParam {
+		if param.Paramstatus != ast.MODE_IN && !procCtx.isVariableArg(e.args[i]) {
+			return nil, exeerrors.ErrSpNotVarArg.GenWithStackByArgs(i+1, e.dbName.O+"."+e.procName.O)
+		}
+		v := &procedureVariable{tp: procedureVariableType(sessVars, param.ParamType)}
+		if param.Paramstatus != ast.MODE_OUT {
+			val, err := e.params[i].Eval(evalCtx, chunk.Row{})
+			if err != nil {
+				return nil, err
+			}
+			if err := v.set(sessVars.StmtCtx.TypeCtx(), val); err != nil {
+				return nil, err
+			}
+		}
+		scope.vars[strings.ToLower(param.ParamName)] = v
+	}
+	return scope, nil
+}
+
+// writeBackParams writes the values of the OUT and INOUT parameters back to the arguments.
+func (e *CallExec) writeBackParams(proc *ast.ProcedureInfo, procCtx *procedureContext, params *procedureScope) error {
+	sessVars := e.Ctx().GetSessionVars()
+	for i, param := range proc.ProcedureParam {
+		if param.Paramstatus == ast.MODE_IN {
+			continue
+		}
+		v := params.vars[strings.ToLower(param.ParamName)]
+		switch arg := e.args[i].(type) {
+		case *ast.VariableExpr:
+			name := strings.ToLower(arg.Name)
+			if v.val.IsNull() {
+				sessVars.UnsetUserVar(name)
+			} else {
+				sessVars.SetUserVarVal(name, *v.val.Clone())
+				sessVars.SetUserVarType(name, v.tp.Clone())
+			}
+		case *ast.ColumnNameExpr:
+			target := procCtx.parent.lookupVariable(arg.Name.Name.L)
+			if err := target.set(sessVars.StmtCtx.TypeCtx(), v.val); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// collectWarnings moves the warnings of the statements in the procedure to the CALL statement.
+func (e *CallExec) collectWarnings() {
+	sc := e.Ctx().GetSessionVars().StmtCtx
+	if sc == e.callSC {
+		return
+	}
+	if sc != e.lastSC {
+		e.lastSC, e.lastWarnCnt = sc, 0
+	}
+	warns := sc.GetWarnings()
+	if len(warns) > e.lastWarnCnt {
+		e.callSC.AppendWarnings(warns[e.lastWarnCnt:])
+		e.lastWarnCnt = len(warns)
+	}
+}
+
+// procedureVariable is a local variable or a parameter of a stored procedure.
+type procedureVariable struct {
+	tp  *types.FieldType
+	val types.Datum
+}
+
+func (v *procedureVariable) set(tc types.Context, val types.Datum) error {
+	converted, err := val.ConvertTo(tc, v.tp)
+	if err != nil {
+		return err
+	}
+	converted.Copy(&v.val)
+	return nil
+}
+
+// procedureVariableType fills the unspecified attributes of the declared type of a variable.
+func procedureVariableType(sessVars *variable.SessionVars, declared *types.FieldType) *types.FieldType {
+	tp := declared.Clone()
+	if types.IsString(tp.GetType()) && tp.GetCharset() == "" {
+		cs, co := sessVars.GetCharsetInfo()
+		tp.SetCharset(cs)
+		tp.SetCollate(co)
+	}
+	defaultFlen, defaultDecimal := mysql.GetDefaultFieldLengthAndDecimal(tp.GetType())
+	if tp.GetFlen() == types.UnspecifiedLength {
+		tp.SetFlen(defaultFlen)
+	}
+	if tp.GetDecimal() == types.UnspecifiedLength {
+		tp.SetDecimal(defaultDecimal)
+	}
+	return tp
+}
+
+// procedureCursor is a cursor declared in a stored procedure.
+type procedureCursor struct {
+	stmt       ast.StmtNode
+	open       bool
+	rows       []chunk.Row
+	fieldTypes []*types.FieldType
+	pos        int
+}
+
+// procedureScope contains the variables, cursors and handlers declared in a block.
+type procedureScope struct {
+	vars     map[string]*procedureVariable
+	cursors  map[string]*procedureCursor
+	handlers []*ast.ProcedureErrorControl
+	// inHandler is true when a handler of the scope is being executed,
+	// the handlers of the scope are not active at that time.
+	inHandler bool
+}
+
+func newProcedureScope() *procedureScope {
+	return &procedureScope{
+		vars:    make(map[string]*procedureVariable),
+		cursors: make(map[string]*procedureCursor),
+	}
+}
+
+// procedureContext is the runtime context of a stored procedure or a trigger.
+type procedureContext struct {
+	sctx sessionctx.Context
+	// call is the CALL statement which executes the procedure, it's nil for a trigger or an event.
+	call *CallExec
+	// trigger is the activated trigger, it's nil for a procedure.
+	trigger  *triggerContext
+	parent   *procedureContext
+	dbName   model.CIStr
+	procName model.CIStr
+	scopes   []*procedureScope
+	// results are the result sets produced by the procedure and the procedures it calls.
+	results []sqlexec.RecordSet
+}
+
+var _ variable.ProcedureContext = &procedureContext{}
+
+// GetProcedureVariable implements the variable.ProcedureContext interface.
+func (c *procedureContext) GetProcedureVariable(name string) (types.Datum, *types.FieldType, bool) {
+	v := c.lookupVariable(name)
+	if v == nil {
+		return types.Datum{}, nil, false
+	}
+	return v.val, v.tp.Clone(), true
+}
+
+// GetTriggerColumn implements the variable.ProcedureContext interface.
+func (c *procedureContext) GetTriggerColumn(row, name string) (types.Datum, *types.FieldType, bool) {
+	if c.trigger == nil {
+		return types.Datum{}, nil, false
+	}
+	return c.trigger.getColumn(row, name)
+}
+
+func (c *procedureContext) lookupVariable(name string) *procedureVariable {
+	for i := len(c.scopes) - 1; i >= 0; i-- {
+		if v, ok := c.scopes[i].vars[name]; ok {
+			return v
+		}
+	}
+	return nil
+}
+
+func (c *procedureContext) lookupCursor(name string) *procedureCursor {
+	for i := len(c.scopes) - 1; i >= 0; i-- {
+		if cur, ok := c.scopes[i].cursors[name]; ok {
+			return cur
+		}
+	}
+	return nil
+}
+
+// isVariableArg checks whether the argument can be used as an OUT or INOUT argument.
+func (c *procedureContext) isVariableArg(arg ast.ExprNode) bool {
+	switch x := arg.(type) {
+	case *ast.VariableExpr:
+		return !x.IsSystem && x.Value == nil
+	case *ast.ColumnNameExpr:
+		return c.parent != nil && x.Name.Table.L == "" && c.parent.lookupVariable(x.Name.Name.L) != nil
+	}
+	return false
+}
+
+func (c *procedureContext) root() *procedureContext {
+	root := c
+	for root.parent != nil {
+		root = root.parent
+	}
+	return root
+}
+
+// procedureJumpSignal is raised by the LEAVE and ITERATE statements, and is caught by the labeled block or loop.
+type procedureJumpSignal struct {
+	label string
+	leave bool
+}
+
+func (s *procedureJumpSignal) Error() string {
+	return "procedure jump to label " + s.label
+}
+
+// procedureExitSignal is raised by an EXIT handler, and is caught by the block which declares the handler.
+type procedureExitSignal struct {
+	scope *procedureScope
+}
+
+func (*procedureExitSignal) Error() string {
+	return "procedure exit handler"
+}
+
+// procedureUnhandledError wraps an error which is not handled by any handler of the procedure.
+type procedureUnhandledError struct {
+	err error
+}
+
+func (e *procedureUnhandledError) Error() string {
+	return e.err.Error()
+}
+
+func (c *procedureContext) execStmts(ctx context.Context, stmts []ast.StmtNode) error {
+	for _, stmt := range stmts {
+		if err := c.execStmt(ctx, stmt); err != nil {
+			if err = c.handleError(ctx, err); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func (c *procedureContext) execStmt(ctx context.Context, stmt ast.StmtNode) error {
+	switch x := stmt.(type) {
+	case *ast.ProcedureBlock:
+		return c.execBlock(ctx, x)
+	case *ast.ProcedureLabelBlock:
+		err := c.execBlock(ctx, x.Block)
+		if jump, ok := err.(*procedureJumpSignal); ok && jump.leave && strings.EqualFold(jump.label, x.LabelName) {
+			return nil
+		}
+		return err
+	case *ast.ProcedureLabelLoop:
+		return c.execLoop(ctx, x.LabelName, x.Block)
+	case *ast.ProcedureWhileStmt, *ast.ProcedureRepeatStmt, *ast.ProcedureLoopStmt:
+		return c.execLoop(ctx, "", x)
+	case *ast.ProcedureIfInfo:
+		return c.execIfBlock(ctx, x.IfBody)
+	case *ast.SimpleCaseStmt:
+		for _, when := range x.WhenCases {
+			cond := &ast.BinaryOperationExpr{Op: opcode.EQ, L: x.Condition, R: when.Expr}
+			matched, err := c.evalCondition(ctx, cond)
+			if err != nil {
+				return err
+			}
+			if matched {
+				return c.execStmts(ctx, when.ProcedureStmts)
+			}
+		}
+		return c.execCaseElse(ctx, x.ElseCases)
+	case *ast.SearchCaseStmt:
+		for _, when := range x.WhenCases {
+			matched, err := c.evalCondition(ctx, when.Expr)
+			if err != nil {
+				return err
+			}
+			if matched {
+				return c.execStmts(ctx, when.ProcedureStmts)
+			}
+		}
+		return c.execCaseElse(ctx, x.ElseCases)
+	case *ast.ProcedureJump:
+		return &procedureJumpSignal{label: x.Name, leave: x.IsLeave}
+	case *ast.ProcedureOpenCur:
+		return c.openCursor(ctx, x.CurName)
+	case *ast.ProcedureCloseCur:
+		cur := c.lookupCursor(x.CurName)
+		if !cur.open {
+			return exeerrors.ErrSpCursorNotOpen.GenWithStackByArgs()
+		}
+		cur.open, cur.rows = false, nil
+		return nil
+	case *ast.ProcedureFetchInto:
+		return c.fetchCursor(x)
+	case *ast.SetStmt:
+		return c.execSet(ctx, x)
+	}
+	return c.execSQL(ctx, stmt)
+}
+
+func (c *procedureContext) execBlock(ctx context.Context, block *ast.ProcedureBlock) error {
+	sessVars := c.sctx.GetSessionVars()
+	scope := newProcedureScope()
+	c.scopes = append(c.scopes, scope)
+	defer func() {
+		c.scopes = c.scopes[:len(c.scopes)-1]
+	}()
+	for _, decl := range block.ProcedureVars {
+		switch x := decl.(type) {
+		case *ast.ProcedureDecl:
+			tp := procedureVariableType(sessVars, x.DeclType)
+			var val types.Datum
+			if x.DeclDefault != nil {
+				var err error
+				if val, err = c.evalExpr(ctx, x.DeclDefault); err != nil {
+					return err
+				}
+			}
+			for _, name := range x.DeclNames {
+				v := &procedureVariable{tp: tp}
+				if err := v.set(sessVars.StmtCtx.TypeCtx(), val); err != nil {
+					return err
+				}
+				scope.vars[name] = v
+			}
+		case *ast.ProcedureCursor:
+			scope.cursors[x.CurName] = &procedureCursor{stmt: x.Selectstring}
+		case *ast.ProcedureErrorControl:
+			scope.handlers = append(scope.handlers, x)
+		}
+	}
+	err := c.execStmts(ctx, block.ProcedureProcStmts)
+	if exit, ok := err.(*procedureExitSignal); ok && exit.scope == scope {
+		return nil
+	}
+	return err
+}
+
+func (c *procedureContext) execLoop(ctx context.Context, label string, loop ast.StmtNode) error {
+	for {
+		var (
+			cond ast.ExprNode
+			body []ast.StmtNode
+			// until is true for the REPEAT statement, whose condition is checked after the body.
+			until bool
+		)
+		switch x := loop.(type) {
+		case *ast.ProcedureWhileStmt:
+			cond, body = x.Condition, x.Body
+		case *ast.ProcedureRepeatStmt:
+			cond, body, until = x.Condition, x.Body, true
+		case *ast.ProcedureLoopStmt:
+			// The LOOP statement has no condition, it only ends by LEAVE or an error.
+			body = x.Body
+		}
+		if cond != nil && !until {
+			ok, err := c.evalCondition(ctx, cond)
+			if err != nil || !ok {
+				return err
+			}
+		}
+		err := c.execStmts(ctx, body)
+		if jump, ok := err.(*procedureJumpSignal); ok && label != "" && strings.EqualFold(jump.label, label) {
+			if jump.leave {
+				return nil
+			}
+			continue
+		}
+		if err != nil {
+			return err
+		}
+		if cond != nil && until {
+			ok, err := c.evalCondition(ctx, cond)
+			if err != nil || ok {
+				return err
+			}
+		}
+	}
+}
+
+func (c *procedureContext) execIfBlock(ctx context.Context, block *ast.ProcedureIfBlock) error {
+	ok, err := c.evalCondition(ctx, block.IfExpr)
+	if err != nil {
+		return err
+	}
+	if ok {
+		return c.execStmts(ctx, block.ProcedureIfStmts)
+	}
+	switch x := block.ProcedureElseStmt.(type) {
+	case *ast.ProcedureElseIfBlock:
+		return c.execIfBlock(ctx, x.ProcedureIfStmt)
+	case *ast.ProcedureElseBlock:
+		return c.execStmts(ctx, x.ProcedureIfStmts)
+	}
+	return nil
+}
+
+func (c *procedureContext) execCaseElse(ctx context.Context, stmts []ast.StmtNode) error {
+	if stmts == nil {
+		return exeerrors.ErrSpCaseNotFound.GenWithStackByArgs()
+	}
+	return c.execStmts(ctx, stmts)
+}
+
+func (c *procedureContext) evalExpr(_ context.Context, expr ast.ExprNode) (types.Datum, error) {
+	return plannerutil.EvalAstExprWithPlanCtx(c.sctx.GetPlanCtx(), expr)
+}
+
+func (c *procedureContext) evalCondition(ctx context.Context, expr ast.ExprNode) (bool, error) {
+	val, err := c.evalExpr(ctx, expr)
+	if err != nil || val.IsNull() {
+		return false, err
+	}
+	b, err := val.ToBool(c.sctx.GetSessionVars().StmtCtx.TypeCtx())
+	return b != 0, err
+}
+
+func (c *procedureContext) execSet(ctx context.Context, stmt *ast.SetStmt) error {
+	sessVars := c.sctx.GetSessionVars()
+	others := make([]*ast.VariableAssignment, 0, len(stmt.Variables))
+	for _, assign := range stmt.Variables {
+		var v *procedureVariable
+		if assign.IsSystem && !assign.IsGlobal && assign.ExtendValue == nil {
+			name := strings.ToLower(assign.Name)
+			if c.trigger != nil {
+				if row, col, ok := strings.Cut(name, "."); ok && (row == "new" || row == "old") {
+					val, err := c.evalExpr(ctx, assign.Value)
+					if err != nil {
+						return err
+					}
+					if err := c.trigger.setColumn(c.sctx, row, col, val); err != nil {
+						return err
+					}
+					continue
+				}
+			}
+			v = c.lookupVariable(name)
+		}
+		if v == nil {
+			others = append(others, assign)
+			continue
+		}
+		val, err := c.evalExpr(ctx, assign.Value)
+		if err != nil {
+			return err
+		}
+		if err := v.set(sessVars.StmtCtx.TypeCtx(), val); err != nil {
+			return err
+		}
+	}
+	if len(others) == 0 {
+		return nil
+	}
+	if len(others) < len(stmt.Variables) {
+		stmt = &ast.SetStmt{Variables: others}
+	}
+	return c.execSQL(ctx, stmt)
+}
+
+// execSQL executes a SQL statement in the procedure, the result set of a query is sent to the client.
+func (c *procedureContext) execSQL(ctx context.Context, stmt ast.StmtNode) error {
+	rows, fields, err := c.executeStmt(ctx, stmt)
+	if err != nil || fields == nil {
+		return err
+	}
+	fieldTypes := make([]*types.FieldType, 0, len(fields))
+	for _, field := range fields {
+		fieldTypes = append(fieldTypes, &field.Column.FieldType)
+	}
+	rs := &sqlexec.SimpleRecordSet{
+		ResultFields: fields,
+		Rows:         make([][]any, 0, len(rows)),
+		MaxChunkSize: c.sctx.GetSessionVars().MaxChunkSize,
+	}
+	for _, row := range rows {
+		datums := row.GetDatumRow(fieldTypes)
+		values := make([]any, 0, len(datums))
+		for _, d := range datums {
+			values = append(values, d.GetValue())
+		}
+		rs.Rows = append(rs.Rows, values)
+	}
+	root := c.root()
+	root.results = append(root.results, rs)
+	return nil
+}
+
+// executeStmt executes a statement with the session and returns all the rows of the result.
+func (c *procedureContext) executeStmt(ctx context.Context, stmt ast.StmtNode) ([]chunk.Row, []*ast.ResultField, error) {
+	// The parser doesn't set the flags of the statements in a block, they are set before the execution.
+	ast.SetFlag(stmt)
+	if c.trigger != nil {
+		return c.trigger.executeStmt(ctx, c.sctx, stmt)
+	}
+	if c.call != nil {
+		c.call.collectWarnings()
+	}
+	if stmt.Text() == "" {
+		var sb strings.Builder
+		if err := stmt.Restore(format.NewRestoreCtx(format.DefaultRestoreFlags, &sb)); err != nil {
+			return nil, nil, err
+		}
+		stmt.SetText(nil, sb.String())
+	}
+	rs, err := c.sctx.GetSQLExecutor().ExecuteStmt(ctx, stmt)
+	if err != nil {
+		return nil, nil, err
+	}
+	if rs == nil {
+		c.recordAffectedRows()
+		return nil, nil, nil
+	}
+	rows, err := sqlexec.DrainRecordSet(ctx, rs, c.sctx.GetSessionVars().MaxChunkSize)
+	if closeErr := rs.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return nil, nil, err
+	}
+	c.recordAffectedRows()
+	return rows, rs.Fields(), nil
+}
+
+// recordAffectedRows records the affected rows of the last statement for the CALL statement.
+func (c *procedureContext) recordAffectedRows() {
+	if c.call != nil {
+		c.call.affectedRows = c.sctx.GetSessionVars().StmtCtx.AffectedRows()
+	}
+}
+
+func (c *procedureContext) openCursor(ctx context.Context, name string) error {
+	cur := c.lookupCursor(name)
+	if cur.open {
+		return exeerrors.ErrSpCursorAlreadyOpen.GenWithStackByArgs()
+	}
+	rows, fields, err := c.executeStmt(ctx, cur.stmt)
+	if err != nil {
+		return err
+	}
+	if fields == nil {
+		return exeerrors.ErrSpBadCursorQuery.GenWithStackByArgs()
+	}
+	cur.fieldTypes = make([]*types.FieldType, 0, len(fields))
+	for _, field := range fields {
+		cur.fieldTypes = append(cur.fieldTypes, &field.Column.FieldType)
+	}
+	cur.open, cur.rows, cur.pos = true, rows, 0
+	return nil
+}
+
+func (c *procedureContext) fetchCursor(stmt *ast.ProcedureFetchInto) error {
+	cur := c.lookupCursor(stmt.CurName)
+	if !cur.open {
+		return exeerrors.ErrSpCursorNotOpen.GenWithStackByArgs()
+	}
+	if len(stmt.Variables) != len(cur.fieldTypes) {
+		return exeerrors.ErrSpWrongNoOfFetchArgs.GenWithStackByArgs()
+	}
+	if cur.pos >= len(cur.rows) {
+		return exeerrors.ErrSpFetchNoData.GenWithStackByArgs()
+	}
+	row := cur.rows[cur.pos]
+	cur.pos++
+	tc := c.sctx.GetSessionVars().StmtCtx.TypeCtx()
+	for i, name := range stmt.Variables {
+		if err := c.lookupVariable(name).set(tc, row.GetDatum(i, cur.fieldTypes[i])); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// handleError finds the handler of the error and executes it. It returns nil if the
+// error is handled by a CONTINUE handler.
+func (c *procedureContext) handleError(ctx context.Context, err error) error {
+	switch err.(type) {
+	case *procedureJumpSignal, *procedureExitSignal, *procedureUnhandledError:
+		return err
+	}
+	if !isProcedureHandleableError(err) {
+		return &procedureUnhandledError{err: err}
+	}
+	code, state := procedureErrorCodeAndState(err)
+	for i := len(c.scopes) - 1; i >= 0; i-- {
+		scope := c.scopes[i]
+		if scope.inHandler {
+			continue
+		}
+		if handler := findProcedureHandler(scope.handlers, code, state); handler != nil {
+			return c.execHandler(ctx, i, handler)
+		}
+	}
+	return &procedureUnhandledError{err: err}
+}
+
+// execHandler executes the handler declared in the i-th scope.
+func (c *procedureContext) execHandler(ctx context.Context, i int, handler *ast.ProcedureErrorControl) error {
+	scope := c.scopes[i]
+	// The handler can only access the variables declared in the scope of the handler and the outer scopes.
+	scopes := c.scopes
+	c.scopes = append([]*procedureScope(nil), scopes[:i+1]...)
+	scope.inHandler = true
+	err := c.execStmts(ctx, []ast.StmtNode{handler.Operate})
+	scope.inHandler = false
+	c.scopes = scopes
+	if err != nil {
+		return err
+	}
+	if handler.ControlHandle == ast.PROCEDUR_EXIT {
+		return &procedureExitSignal{scope: scope}
+	}
+	return nil
+}
+
+func isProcedureHandleableError(err error) bool {
+	return !exeerrors.ErrQueryInterrupted.Equal(err) &&
+		!exeerrors.ErrMemoryExceedForQuery.Equal(err) &&
+		!exeerrors.ErrMemoryExceedForInstance.Equal(err) &&
+		!exeerrors.ErrMaxExecTimeExceeded.Equal(err)
+}
+
+func procedureErrorCodeAndState(err error) (uint16, string) {
+	if te, ok := errors.Cause(err).(*terror.Error); ok {
+		sqlErr := terror.ToSQLError(te)
+		return sqlErr.Code, sqlErr.State
+	}
+	return mysql.ErrUnknown, mysql.DefaultMySQLState
+}
+
+// findProcedureHandler finds the most specific handler for the error: the handlers
+// for the error code take precedence over the handlers for the SQLSTATE value, which
+// take precedence over the handlers for the condition classes.
+func findProcedureHandler(handlers []*ast.ProcedureErrorControl, code uint16, state string) *ast.ProcedureErrorControl {
+	var found *ast.ProcedureErrorControl
+	bestPriority := 0
+	for _, handler := range handlers {
+		for _, cond := range handler.ErrorCon {
+			priority := 0
+			switch x := cond.(type) {
+			case *ast.ProcedureErrorVal:
+				if x.ErrorNum == uint64(code) {
+					priority = 3
+				}
+			case *ast.ProcedureErrorState:
+				if x.CodeStatus == state {
+					priority = 2
+				}
+			case *ast.ProcedureErrorCon:
+				class := state
+				if len(class) > 2 {
+					class = class[:2]
+				}
+				switch x.ErrorCon {
+				case ast.PROCEDUR_SQLWARNING:
+					if class == "01" {
+						priority = 1
+					}
+				case ast.PROCEDUR_NOT_FOUND:
+					if class == "02" {
+						priority = 1
+					}
+				case ast.PROCEDUR_SQLEXCEPTION:
+					if class != "00" && class != "01" && class != "02" {
+						priority = 1
+					}
+				}
+			}
+			if priority > bestPriority {
+				found, bestPriority = handler, priority
+			}
+		}
+	}
+	return found
+}
@@ -36,6 +36,7 @@ import (
 	"github.com/pingcap/tidb/pkg/disttask/importinto"
 	"github.com/pingcap/tidb/pkg/domain"
 	"github.com/pingcap/tidb/pkg/domain/infosync"
+	"github.com/pingcap/tidb/pkg/eventscheduler"
 	"github.com/pingcap/tidb/pkg/executor/importer"
 	"github.com/pingcap/tidb/pkg/executor/internal/exec"
 	"github.com/pingcap/tidb/pkg/expression"
@@ -116,6 +117,8 @@ type ShowExec struct {
 	Extended    bool // Used for `show extended columns from ...`
 
 	ImportJobID *int64
+
+	Procedure *ast.TableName // Used for showing create procedure.
 }
 
 type showTableRegionRowItem struct {
@@ -193,6 +196,10 @@ func (e *ShowExec) fetchAll(ctx context.Context) error {
 		return e.fetchShowCreateView()
 	case ast.ShowCreateDatabase:
 		return e.fetchShowCreateDatabase()
+	case ast.ShowCreateProcedure:
+		return e.fetchShowCreateProcedure(ctx)
+	case ast.ShowCreateEvent:
+		return e.fetchShowCreateEvent(ctx)
 	case ast.ShowCreatePlacementPolicy:
 		return e.fetchShowCreatePlacementPolicy()
 	case ast.ShowCreateResourceGroup:
@@ -208,7 +215,7 @@ func (e *ShowExec) fetchAll(ctx context.Context) error {
 	case ast.ShowIndex:
 		return e.fetchShowIndex()
 	case ast.ShowProcedureStatus:
-		return e.fetchShowProcedureStatus()
+		return e.fetchShowProcedureStatus(ctx)
 	case ast.ShowPumpStatus:
 		return e.fetchShowPumpOrDrainerStatus(node.PumpNode)
 	case ast.ShowStatus:
@@ -230,7 +237,7 @@ func (e *ShowExec) fetchAll(ctx context.Context) error {
 	case ast.ShowProcessList:
 		return e.fetchShowProcessList()
 	case ast.ShowEvents:
-		// empty result
+		return e.fetchShowEvents(ctx)
 	case ast.ShowStatsExtended:
 		return e.fetchShowStatsExtended()
 	case ast.ShowStatsMeta:
@@ -1074,6 +1081,9 @@ func constructResultOfShowCreateTable(ctx sessionctx.Context, dbName *model.CISt
 				buf.WriteString(table.OptionalFsp(&col.FieldType))
 			}
 		}
+		if srid, ok := col.GetSRID(); ok {
+			fmt.Fprintf(buf, " /*!80003 SRID %d */", srid)
+		}
 		if ddl.IsAutoRandomColumnID(tableInfo, col.ID) {
 			s, r := tableInfo.AutoRandomBits, tableInfo.AutoRandomRangeBits
 			if r == 0 || r == autoid.AutoRandomRangeBitsDefault {
@@ -1132,6 +1142,10 @@ func constructResultOfShowCreateTable(ctx sessionctx.Context, dbName *model.CISt
 			buf.WriteString("  PRIMARY KEY ")
 		} else if idxInfo.Unique {
 			fmt.Fprintf(buf, "  UNIQUE KEY %s ", stringutil.Escape(idxInfo.Name.O, sqlMode))
+		} else if idxInfo.IsFulltextIndex() {
+			fmt.Fprintf(buf, "  FULLTEXT KEY %s ", stringutil.Escape(idxInfo.Name.O, sqlMode))
+		} else if idxInfo.IsSpatialIndex() {
+			fmt.Fprintf(buf, "  SPATIAL KEY %s ", stringutil.Escape(idxInfo.Name.O, sqlMode))
 		} else {
 			fmt.Fprintf(buf, "  KEY %s ", stringutil.Escape(idxInfo.Name.O, sqlMode))
 		}
@@ -1150,6 +1164,9 @@ func constructResultOfShowCreateTable(ctx sessionctx.Context, dbName *model.CISt
 			cols = append(cols, colInfo)
 		}
 		fmt.Fprintf(buf, "(%s)", strings.Join(cols, ","))
+		if idxInfo.IsFulltextIndex() && idxInfo.ParserName.L != "" {
+			fmt.Fprintf(buf, ` /*!50100 WITH PARSER %s */`, stringutil.Escape(idxInfo.ParserName.O, sqlMode))
+		}
 		if idxInfo.Invisible {
 			fmt.Fprintf(buf, ` /*!80000 INVISIBLE */`)
 		}
@@ -1849,11 +1866,108 @@ func (e *ShowExec) fetchShowPrivileges() error {
 	return nil
 }
 
-func (*ShowExec) fetchShowTriggers() error {
+func (e *ShowExec) fetchShowTriggers() error {
+	checker := privilege.GetPrivilegeManager(e.Ctx())
+	if checker != nil && e.Ctx().GetSessionVars().User != nil {
+		if !checker.DBIsVisible(e.Ctx().GetSessionVars().ActiveRoles, e.DBName.O) {
+			return e.dbAccessDenied()
+		}
+	}
+	if !e.is.SchemaExists(e.DBName) {
+		return exeerrors.ErrBadDB.GenWithStackByArgs(e.DBName)
+	}
+	loc := e.Ctx().GetSessionVars().Location()
+	activeRoles := e.Ctx().GetSessionVars().ActiveRoles
+	for _, tbl := range e.is.SchemaTables(e.DBName) {
+		tblInfo := tbl.Meta()
+		if len(tblInfo.Triggers) == 0 {
+			continue
+		}
+		if checker != nil && !checker.RequestVerification(activeRoles, e.DBName.L, tblInfo.Name.L, "", mysql.TriggerPriv) {
+			continue
+		}
+		for _, trg := range tblInfo.Triggers {
+			created := types.NewTime(types.FromGoTime(trg.Created.In(loc)), mysql.TypeDatetime, 2)
+			e.appendRow([]any{trg.Name.O, trg.Event.String(), tblInfo.Name.O, trg.Body, trg.Timing.String(), created,
+				sqlModeString(trg.SQLMode), trg.Definer.String(), trg.CharsetClient, trg.CollationConnection, trg.DBCollation})
+		}
+	}
+	return nil
+}
+
+func (e *ShowExec) fetchShowProcedureStatus(ctx context.Context) error {
+	defs, err := loadProcedureDefinitions(ctx, e.Ctx(), "", "")
+	if err != nil {
+		return err
+	}
+	for _, def := range defs {
+		if !procedureIsVisible(e.Ctx(), def.db) {
+			continue
+		}
+		e.appendRow([]any{def.db, def.name, "PROCEDURE", def.definer, def.modified, def.created,
+			def.securityType, def.comment, def.charsetClient, def.collationConn, def.dbCollation})
+	}
+	return nil
+}
+
+func (e *ShowExec) fetchShowCreateProcedure(ctx context.Context) error {
+	name := e.Procedure
+	if !procedureIsVisible(e.Ctx(), name.Schema.L) {
+		return e.dbAccessDeniedFor(name.Schema.O)
+	}
+	defs, err := loadProcedureDefinitions(ctx, e.Ctx(), name.Schema.L, name.Name.L)
+	if err != nil {
+		return err
+	}
+	if len(defs) == 0 {
+		return exeerrors.ErrSpDoesNotExist.GenWithStackByArgs("PROCEDURE", name.Schema.O+"."+name.Name.O)
+	}
+	def := defs[0]
+	e.appendRow([]any{def.name, def.sqlMode, def.createStmt(), def.charsetClient, def.collationConn, def.dbCollation})
+	return nil
+}
+
+func (e *ShowExec) fetchShowEvents(ctx context.Context) error {
+	if !eventIsVisible(e.Ctx(), e.DBName.L) {
+		return e.dbAccessDenied()
+	}
+	cli, err := eventTimerClient(e.Ctx())
+	if err != nil {
+		return err
+	}
+	events, err := eventscheduler.LoadEvents(ctx, cli, e.DBName.L, "")
+	if err != nil {
+		return err
+	}
+	for _, ev := range events {
+		row := []any{ev.Schema, ev.Name, ev.TimeZone, ev.Definer, eventType(ev)}
+		for _, d := range eventScheduleDatums(ev) {
+			row = append(row, d.GetValue())
+		}
+		row = append(row, ev.Status, 0, ev.CharsetClient, ev.CollationConnection, ev.DBCollation)
+		e.appendRow(row)
+	}
 	return nil
 }
 
-func (*ShowExec) fetchShowProcedureStatus() error {
+func (e *ShowExec) fetchShowCreateEvent(ctx context.Context) error {
+	name := e.Procedure
+	if !eventIsVisible(e.Ctx(), name.Schema.L) {
+		return e.dbAccessDeniedFor(name.Schema.O)
+	}
+	cli, err := eventTimerClient(e.Ctx())
+	if err != nil {
+		return err
+	}
+	events, err := eventscheduler.LoadEvents(ctx, cli, name.Schema.L, name.Name.L)
+	if err != nil {
+		return err
+	}
+	if len(events) == 0 {
+		return exeerrors.ErrEventDoesNotExist.GenWithStackByArgs(name.Name.O)
+	}
+	ev := events[0]
+	e.appendRow([]any{ev.Name, ev.SQLMode, ev.TimeZone, eventCreateStmt(ev), ev.CharsetClient, ev.CollationConnection, ev.DBCollation})
 	return nil
 }
 
@@ -1955,6 +2069,10 @@ func (e *ShowExec) getTable() (table.Table, error) {
 }
 
 func (e *ShowExec) dbAccessDenied() error {
+	return e.dbAccessDeniedFor(e.DBName.O)
+}
+
+func (e *ShowExec) dbAccessDeniedFor(db string) error {
 	user := e.Ctx().GetSessionVars().User
 	u := user.Username
 	h := user.Hostname
@@ -1962,7 +2080,7 @@ func (e *ShowExec) dbAccessDenied() error {
 		u = user.AuthUsername
 		h = user.AuthHostname
 	}
-	return exeerrors.ErrDBaccessDenied.GenWithStackByArgs(u, h, e.DBName)
+	return exeerrors.ErrDBaccessDenied.GenWithStackByArgs(u, h, db)
 }
 
 func (e *ShowExec) tableAccessDenied(access string, table string) error {
@@ -200,6 +200,12 @@ func (e *SimpleExec) Next(ctx context.Context, _ *chunk.Chunk) (err error) {
 		err = e.executeAlterRange(x)
 	case *ast.DropQueryWatchStmt:
 		err = e.executeDropQueryWatch(x)
+	case *ast.ProcedureInfo:
+		err = e.executeCreateProcedure(ctx, x)
+	case *ast.DropProcedureStmt:
+		err = e.executeDropProcedure(ctx, x)
+	case *ast.RefreshMaterializedViewStmt:
+		err = e.executeRefreshMaterializedView(ctx, x)
 	}
 	e.done = true
 	return err
@@ -2779,6 +2785,12 @@ func (e *SimpleExec) autoNewTxn() bool {
 	// Administrative statements. TODO: ANALYZE TABLE, CACHE INDEX, CHECK TABLE, FLUSH, LOAD INDEX INTO CACHE, OPTIMIZE TABLE, REPAIR TABLE, RESET (but not RESET PERSIST).
 	case *ast.FlushStmt:
 		return true
+	// Statements that create or drop stored routines.
+	case *ast.ProcedureInfo, *ast.DropProcedureStmt:
+		return true
+	// Statements that refresh materialized views.
+	case *ast.RefreshMaterializedViewStmt:
+		return true
 	}
 	return false
 }
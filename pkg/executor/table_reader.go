@@ -19,6 +19,7 @@ import (
 	"cmp"
 	"context"
 	"slices"
+	"sync"
 	"time"
 	"unsafe"
 
@@ -31,12 +32,14 @@ import (
 	"github.com/pingcap/tidb/pkg/executor/internal/builder"
 	"github.com/pingcap/tidb/pkg/executor/internal/exec"
 	internalutil "github.com/pingcap/tidb/pkg/executor/internal/util"
+	"github.com/pingcap/tidb/pkg/executor/join"
 	"github.com/pingcap/tidb/pkg/expression"
 	exprctx "github.com/pingcap/tidb/pkg/expression/context"
 	"github.com/pingcap/tidb/pkg/infoschema"
 	isctx "github.com/pingcap/tidb/pkg/infoschema/context"
 	"github.com/pingcap/tidb/pkg/kv"
 	"github.com/pingcap/tidb/pkg/parser/model"
+	"github.com/pingcap/tidb/pkg/parser/mysql"
 	planctx "github.com/pingcap/tidb/pkg/planner/context"
 	plannercore "github.com/pingcap/tidb/pkg/planner/core"
 	"github.com/pingcap/tidb/pkg/planner/core/base"
@@ -45,6 +48,7 @@ import (
 	"github.com/pingcap/tidb/pkg/table"
 	"github.com/pingcap/tidb/pkg/types"
 	"github.com/pingcap/tidb/pkg/util/chunk"
+	"github.com/pingcap/tidb/pkg/util/collate"
 	"github.com/pingcap/tidb/pkg/util/logutil"
 	"github.com/pingcap/tidb/pkg/util/memory"
 	"github.com/pingcap/tidb/pkg/util/ranger"
@@ -191,6 +195,15 @@ type TableReaderExecutor struct {
 	// If dummy flag is set, this is not a real TableReader, it just provides the KV ranges for UnionScan.
 	// Used by the temporary table, cached table.
 	dummy bool
+
+	// waitRuntimeFilter indicates that the hash joins above push runtime filters down to this reader, so
+	// the requests are sent by the first Next instead of Open.
+	waitRuntimeFilter bool
+	runtimeFilterMu   sync.Mutex
+	runtimeFilters    []*join.RuntimeFilter
+	// baseExecutors is the DAG executors without the conditions of the runtime filters.
+	baseExecutors  []*tipb.Executor
+	requestPending bool
 }
 
 // Table implements the dataSourceExecutor interface.
@@ -262,12 +275,11 @@ func (e *TableReaderExecutor) Open(ctx context.Context) error {
 
 	e.resultHandler = &tableResultHandler{}
 
-	firstPartRanges, secondPartRanges := distsql.SplitRangesAcrossInt64Boundary(e.ranges, e.keepOrder, e.desc, e.table.Meta() != nil && e.table.Meta().IsCommonHandle)
-
 	// Treat temporary table as dummy table, avoid sending distsql request to TiKV.
 	// Calculate the kv ranges here, UnionScan rely on this kv ranges.
 	// cached table and temporary table are similar
 	if e.dummy {
+		firstPartRanges, secondPartRanges := distsql.SplitRangesAcrossInt64Boundary(e.ranges, e.keepOrder, e.desc, e.table.Meta() != nil && e.table.Meta().IsCommonHandle)
 		if e.desc && len(secondPartRanges) != 0 {
 			// TiKV support reverse scan and the `resultHandler` process the range order.
 			// While in UnionScan, it doesn't use reverse scan and reverse the final result rows manually.
@@ -294,6 +306,23 @@ func (e *TableReaderExecutor) Open(ctx context.Context) error {
 		return nil
 	}
 
+	if e.waitRuntimeFilter {
+		if e.baseExecutors == nil || e.corColInFilter {
+			e.baseExecutors = e.dagPB.Executors
+		} else {
+			e.dagPB.Executors = e.baseExecutors
+		}
+		e.runtimeFilterMu.Lock()
+		e.runtimeFilters = e.runtimeFilters[:0]
+		e.runtimeFilterMu.Unlock()
+		e.requestPending = true
+		return nil
+	}
+	return e.sendRequests(ctx, e.ranges)
+}
+
+func (e *TableReaderExecutor) sendRequests(ctx context.Context, ranges []*ranger.Range) error {
+	firstPartRanges, secondPartRanges := distsql.SplitRangesAcrossInt64Boundary(ranges, e.keepOrder, e.desc, e.table.Meta() != nil && e.table.Meta().IsCommonHandle)
 	firstResult, err := e.buildResp(ctx, firstPartRanges)
 	if err != nil {
 		return err
@@ -311,6 +340,81 @@ func (e *TableReaderExecutor) Open(ctx context.Context) error {
 	return nil
 }
 
+// PushRuntimeFilter implements the join.RuntimeFilterTarget interface.
+func (e *TableReaderExecutor) PushRuntimeFilter(rf *join.RuntimeFilter) {
+	e.runtimeFilterMu.Lock()
+	defer e.runtimeFilterMu.Unlock()
+	e.runtimeFilters = append(e.runtimeFilters, rf)
+}
+
+// applyRuntimeFilters adds the conditions of the runtime filters to the Selection above the table scan
+// in the DAG request. The filters on the integer handle also narrow the ranges to scan.
+func (e *TableReaderExecutor) applyRuntimeFilters() ([]*ranger.Range, error) {
+	e.runtimeFilterMu.Lock()
+	rfs := slices.Clone(e.runtimeFilters)
+	e.runtimeFilterMu.Unlock()
+	ranges := e.ranges
+	var conds []expression.Expression
+	for _, rf := range rfs {
+		rfConds, err := rf.Conditions(e.ectx)
+		if err != nil {
+			return nil, err
+		}
+		conds = append(conds, rfConds...)
+		ranges = e.intersectRuntimeFilterRanges(rf, ranges)
+	}
+	selIdx := slices.IndexFunc(e.dagPB.Executors, func(pbExec *tipb.Executor) bool {
+		return pbExec.Tp == tipb.ExecType_TypeSelection
+	})
+	if len(conds) == 0 || selIdx < 0 {
+		return ranges, nil
+	}
+	client := e.buildPBCtx.GetClient()
+	evalCtx := e.ectx.GetEvalCtx()
+	conds, _ = expression.PushDownExprsWithExtraInfo(expression.NewPushDownContext(evalCtx, client, false, nil, nil, 0), conds, e.storeType, false)
+	if len(conds) == 0 {
+		return ranges, nil
+	}
+	pbConds, err := expression.ExpressionsToPBList(evalCtx, conds, client)
+	if err != nil {
+		return nil, err
+	}
+	sel := *e.dagPB.Executors[selIdx].Selection
+	sel.Conditions = append(slices.Clip(sel.Conditions), pbConds...)
+	selExec := *e.dagPB.Executors[selIdx]
+	selExec.Selection = &sel
+	execs := slices.Clone(e.dagPB.Executors)
+	execs[selIdx] = &selExec
+	e.dagPB.Executors = execs
+	return ranges, nil
+}
+
+// intersectRuntimeFilterRanges narrows the ranges by the runtime filter if its target column is the
+// integer handle.
+func (e *TableReaderExecutor) intersectRuntimeFilterRanges(rf *join.RuntimeFilter, ranges []*ranger.Range) []*ranger.Range {
+	tblInfo := e.table.Meta()
+	if tblInfo == nil || !tblInfo.PKIsHandle || !mysql.HasPriKeyFlag(rf.TargetCol.RetType.GetFlag()) {
+		return ranges
+	}
+	var rfRanges ranger.Ranges
+	collators := collate.GetBinaryCollatorSlice(1)
+	if minVal, maxVal, ok := rf.MinMax(); ok {
+		rfRanges = append(rfRanges, &ranger.Range{LowVal: []types.Datum{minVal}, HighVal: []types.Datum{maxVal}, Collators: collators})
+	} else if values, ok := rf.Values(e.rctx.TypeCtx); ok {
+		for _, v := range values {
+			rfRanges = append(rfRanges, &ranger.Range{LowVal: []types.Datum{v}, HighVal: []types.Datum{v}, Collators: collators})
+		}
+	}
+	if len(rfRanges) == 0 {
+		return ranges
+	}
+	// The conditions of the runtime filter are still evaluated if the intersection is failed or empty.
+	if result := ranger.Ranges(ranges).IntersectRanges(e.rctx.TypeCtx, rfRanges); len(result) > 0 {
+		return result
+	}
+	return ranges
+}
+
 // Next fills data into the chunk passed by its caller.
 // The task was actually done by tableReaderHandler.
 func (e *TableReaderExecutor) Next(ctx context.Context, req *chunk.Chunk) error {
@@ -319,6 +423,16 @@ func (e *TableReaderExecutor) Next(ctx context.Context, req *chunk.Chunk) error
 		req.Reset()
 		return nil
 	}
+	if e.requestPending {
+		e.requestPending = false
+		ranges, err := e.applyRuntimeFilters()
+		if err != nil {
+			return err
+		}
+		if err = e.sendRequests(ctx, ranges); err != nil {
+			return err
+		}
+	}
 
 	logutil.Eventf(ctx, "table scan table: %s, range: %v", stringutil.MemoizeStr(func() string {
 		var tableName string
@@ -3131,3 +3131,68 @@ func TestAnalyzePartitionVerify(t *testing.T) {
 		}
 	}
 }
+
+func TestIncrementalAnalyze(t *testing.T) {
+	store := testkit.CreateMockStore(t)
+	tk := testkit.NewTestKit(t, store)
+	h := domain.GetDomain(tk.Session()).StatsHandle()
+	tk.MustExec("use test")
+	tk.MustExec("create table t(a int primary key, b int, index idx(b))")
+	tk.MustExec("insert into t with recursive c(n) as (select 1 union all select n + 1 from c where n < 100) select n, n % 10 from c")
+	analyzehelper.TriggerPredicateColumnsCollection(t, tk, store, "t", "a", "b")
+	require.NoError(t, h.DumpStatsDeltaToKV(true))
+	hasNote := func(reason string) bool {
+		for _, row := range tk.MustQuery("show warnings").Rows() {
+			if strings.Contains(row[2].(string), "fully instead of incrementally, reason: "+reason) {
+				return true
+			}
+		}
+		return false
+	}
+	checkStats := func(count, ndvA, ndvB string) {
+		tk.MustQuery("show stats_meta where table_name = 't'").CheckAt([]int{5}, testkit.Rows(count))
+		tk.MustQuery("show stats_histograms where table_name = 't' and is_index = 0").Sort().CheckAt([]int{3, 6},
+			testkit.Rows("a "+ndvA, "b "+ndvB))
+	}
+
+	// The first incremental analyze is a full one, which records the max handle.
+	tk.MustExec("analyze incremental table t")
+	require.True(t, hasNote("the table hasn't been analyzed incrementally"))
+	tk.MustQuery("select max_handle from mysql.stats_incremental_analyze").Check(testkit.Rows("100"))
+	checkStats("100", "100", "10")
+
+	// Only the appended rows are sampled and merged into the existing stats.
+	tk.MustExec("insert into t with recursive c(n) as (select 101 union all select n + 1 from c where n < 200) select n, n % 20 from c")
+	require.NoError(t, h.DumpStatsDeltaToKV(true))
+	tk.MustExec("analyze incremental table t")
+	require.False(t, hasNote(""))
+	tk.MustQuery("select max_handle from mysql.stats_incremental_analyze").Check(testkit.Rows("200"))
+	checkStats("200", "200", "20")
+	tk.MustQuery("show stats_histograms where table_name = 't' and is_index = 1").CheckAt([]int{3, 6}, testkit.Rows("idx 20"))
+
+	// It falls back to a full analyze if the updates dominate the changes.
+	tk.MustExec("update t set b = b + 100 where a <= 150")
+	tk.MustExec("insert into t values (201, 1)")
+	require.NoError(t, h.DumpStatsDeltaToKV(true))
+	tk.MustExec("analyze incremental table t")
+	require.True(t, hasNote("the deletes and updates dominate the changes"))
+	tk.MustQuery("select max_handle from mysql.stats_incremental_analyze").Check(testkit.Rows("201"))
+	checkStats("201", "201", "40")
+
+	// A full analyze removes the max handle, and the auto analyze is incremental if it's enabled.
+	tk.MustExec("analyze table t")
+	tk.MustQuery("select count(*) from mysql.stats_incremental_analyze").Check(testkit.Rows("0"))
+	tk.MustExec("set global tidb_enable_auto_analyze_incremental = on")
+	defer tk.MustExec("set global tidb_enable_auto_analyze_incremental = default")
+	_, _, err := tk.Session().GetRestrictedSQLExecutor().ExecRestrictedSQL(
+		kv.WithInternalSourceType(context.Background(), kv.InternalTxnStats), nil, "analyze table test.t")
+	require.NoError(t, err)
+	tk.MustQuery("select max_handle from mysql.stats_incremental_analyze").Check(testkit.Rows("201"))
+
+	// Only the whole table with the version 2 stats can be analyzed incrementally.
+	tk.MustGetErrMsg("analyze incremental table t index idx", "the incremental analyze feature has already been removed in TiDB v7.5.0, so this will have no effect")
+	tk.MustExec("create table t2(a varchar(10) primary key clustered, b int)")
+	tk.MustExec("insert into t2 values ('a', 1)")
+	tk.MustExec("analyze incremental table t2")
+	require.True(t, hasNote("the table doesn't have an int handle"))
+}
@@ -0,0 +1,236 @@
+// Copyright 2024 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package eventtest
+
+import (
+	"context"
+	"testing"
+
+	"github.com/pingcap/tidb/pkg/errno"
+	"github.com/pingcap/tidb/pkg/eventscheduler"
+	"github.com/pingcap/tidb/pkg/kv"
+	"github.com/pingcap/tidb/pkg/parser/auth"
+	"github.com/pingcap/tidb/pkg/parser/terror"
+	"github.com/pingcap/tidb/pkg/privilege"
+	"github.com/pingcap/tidb/pkg/testkit"
+	"github.com/pingcap/tidb/pkg/util/dbterror/exeerrors"
+	"github.com/pingcap/tidb/pkg/util/dbterror/plannererrors"
+	"github.com/stretchr/testify/require"
+)
+
+func newEventTestKit(t *testing.T, store kv.Storage) *testkit.TestKit {
+	tk := testkit.NewTestKit(t, store)
+	require.NoError(t, tk.Session().Auth(&auth.UserIdentity{Username: "root", Hostname: "%"}, nil, nil, nil))
+	tk.MustExec("use test")
+	tk.MustExec("set @@time_zone = '+00:00'")
+	return tk
+}
+
+func TestCreateAlterDropEvent(t *testing.T) {
+	store, dom := testkit.CreateMockStoreAndDomain(t)
+	tk := newEventTestKit(t, store)
+	tk.MustExec("create table t (a int)")
+	tk.MustExec("create event e1 on schedule every 1 hour starts '2030-01-01 00:00:00' comment 'hourly' do insert into t values (1)")
+	tk.MustGetErrCode("create event e1 on schedule every 1 hour do insert into t values (1)", errno.ErrEventAlreadyExists)
+	tk.MustExec("create event if not exists e1 on schedule every 1 hour do insert into t values (1)")
+	tk.MustQuery("show warnings").Check(testkit.Rows("Note 1537 Event 'e1' already exists"))
+	tk.MustGetErrCode("create event db_not_exists.e1 on schedule every 1 hour do insert into t values (1)", errno.ErrBadDB)
+
+	// A one-time event in the past is dropped immediately, or disabled with ON COMPLETION PRESERVE.
+	tk.MustExec("create event e2 on schedule at '2000-01-01 00:00:00' do insert into t values (2)")
+	tk.MustQuery("show warnings").Check(testkit.Rows("Note 1588 Event execution time is in the past and ON COMPLETION NOT PRESERVE is set. The event was dropped immediately after creation."))
+	tk.MustExec("create event e3 on schedule at '2000-01-01 00:00:00' on completion preserve do insert into t values (3)")
+	tk.MustQuery("show warnings").Check(testkit.Rows("Note 1544 Event execution time is in the past. Event has been disabled"))
+
+	tk.MustGetErrCode("create event e4 on schedule every 0 hour do set @x = 1", errno.ErrEventIntervalNotPositiveOrTooBig)
+	tk.MustGetErrCode("create event e4 on schedule every -1 day do set @x = 1", errno.ErrEventIntervalNotPositiveOrTooBig)
+	tk.MustGetErrCode("create event e4 on schedule every 1 microsecond do set @x = 1", errno.ErrNotSupportedYet)
+	tk.MustGetErrCode("create event e4 on schedule every 1 day starts '2030-01-02 00:00:00' ends '2030-01-01 00:00:00' do set @x = 1", errno.ErrEventEndsBeforeStarts)
+	tk.MustGetErrCode("create event e4 on schedule at null do set @x = 1", errno.ErrTruncatedWrongValue)
+	tk.MustGetErrCode("create event e4 on schedule every 1 day do drop event e1", errno.ErrEventRecursionForbidden)
+	tk.MustGetErrCode("create event e4 on schedule every 1 day do begin if @x > 0 then create event e5 on schedule every 1 day do set @x = 1; end if; end", errno.ErrEventRecursionForbidden)
+
+	tk.MustQuery("show events").CheckAt([]int{0, 1, 2, 3, 4, 5, 6, 7, 8, 9, 10}, [][]any{
+		{"test", "e1", "+00:00", "root@%", "RECURRING", "<nil>", "1", "HOUR", "2030-01-01 00:00:00", "<nil>", "ENABLED"},
+		{"test", "e3", "+00:00", "root@%", "ONE TIME", "2000-01-01 00:00:00", "<nil>", "<nil>", "<nil>", "<nil>", "DISABLED"},
+	})
+	tk.MustQuery("show events where name = 'e3'").CheckAt([]int{1}, testkit.Rows("e3"))
+	tk.MustQuery("select event_name, event_definition, event_type, interval_value, interval_field, starts, status, on_completion, event_comment " +
+		"from information_schema.events where event_schema = 'test'").Check(testkit.Rows(
+		"e1 insert into t values (1) RECURRING 1 HOUR 2030-01-01 00:00:00 ENABLED NOT PRESERVE hourly",
+		"e3 insert into t values (3) ONE TIME <nil> <nil> <nil> DISABLED PRESERVE ",
+	))
+
+	// The definition and the schedule of the timer are updated by ALTER EVENT.
+	tk.MustExec("alter event e1 on schedule every 2 day starts '2031-01-01 00:00:00' on completion preserve disable comment 'daily' do insert into t values (2)")
+	tk.MustQuery("select event_name, event_definition, interval_value, interval_field, starts, status, on_completion, event_comment " +
+		"from information_schema.events where event_name = 'e1'").Check(testkit.Rows(
+		"e1 insert into t values (2) 2 DAY 2031-01-01 00:00:00 DISABLED PRESERVE daily",
+	))
+	cli := dom.EventScheduler().Client()
+	events, err := eventscheduler.LoadEvents(context.Background(), cli, "test", "e1")
+	require.NoError(t, err)
+	require.Len(t, events, 1)
+	timer, err := cli.GetTimerByID(context.Background(), events[0].TimerID)
+	require.NoError(t, err)
+	require.False(t, timer.Enable)
+	require.Equal(t, "172800 SECOND STARTS 2031-01-01T00:00:00Z", timer.SchedPolicyExpr)
+	tk.MustExec("alter event e1 enable")
+	tk.MustQuery("select status, interval_value from information_schema.events where event_name = 'e1'").Check(testkit.Rows("ENABLED 2"))
+	tk.MustGetErrCode("alter event e1 on schedule at '2000-01-01 00:00:00' on completion not preserve", errno.ErrEventCannotAlterInThePast)
+	tk.MustGetErrCode("alter event e_not_exists enable", errno.ErrEventDoesNotExist)
+
+	tk.MustGetErrCode("alter event e1 rename to e1", errno.ErrEventSameName)
+	tk.MustGetErrCode("alter event e1 rename to e3", errno.ErrEventAlreadyExists)
+	tk.MustExec("alter event e1 rename to e4")
+	tk.MustQuery("select event_name, event_definition, status from information_schema.events where event_schema = 'test'").Check(testkit.Rows(
+		"e3 insert into t values (3) DISABLED",
+		"e4 insert into t values (2) ENABLED",
+	))
+
+	tk.MustExec("drop event e4")
+	tk.MustGetErrCode("drop event e4", errno.ErrEventDoesNotExist)
+	tk.MustExec("drop event if exists e4")
+	tk.MustQuery("show warnings").Check(testkit.Rows("Note 1539 Unknown event 'e4'"))
+	tk.MustQuery("show events").CheckAt([]int{1}, testkit.Rows("e3"))
+
+	// The events are dropped with the database.
+	tk.MustExec("create database db1")
+	tk.MustExec("create event db1.e on schedule every 1 hour do set @x = 1")
+	tk.MustQuery("show events from db1").CheckAt([]int{0, 1}, testkit.Rows("db1 e"))
+	tk.MustExec("drop database db1")
+	tk.MustQuery("select count(*) from information_schema.events where event_schema = 'db1'").Check(testkit.Rows("0"))
+}
+
+func TestShowCreateEvent(t *testing.T) {
+	store := testkit.CreateMockStore(t)
+	tk := newEventTestKit(t, store)
+	tk.MustExec("create table t (a int)")
+	tk.MustExec("create event e1 on schedule every 1 hour starts '2030-01-01 00:00:00' comment 'it''s hourly' do insert into t values (1)")
+	tk.MustExec("create event e2 on schedule every '1:30' minute_second starts '2030-01-01 00:00:00' ends '2031-01-01 00:00:00' " +
+		"on completion preserve disable do begin insert into t values (1); insert into t values (2); end")
+	tk.MustExec("create definer = 'root'@'%' event e3 on schedule at '2030-01-01 00:00:00' do insert into t values (3)")
+
+	tk.MustQuery("show create event e1").CheckAt([]int{0, 2, 3}, [][]any{
+		{"e1", "+00:00", "CREATE DEFINER=`root`@`%` EVENT `e1` ON SCHEDULE EVERY 1 HOUR STARTS '2030-01-01 00:00:00' ON COMPLETION NOT PRESERVE ENABLE COMMENT 'it\\'s hourly' DO insert into t values (1)"},
+	})
+	tk.MustQuery("show create event test.e2").CheckAt([]int{0, 3}, [][]any{
+		{"e2", "CREATE DEFINER=`root`@`%` EVENT `e2` ON SCHEDULE EVERY '1:30' MINUTE_SECOND STARTS '2030-01-01 00:00:00' ENDS '2031-01-01 00:00:00' ON COMPLETION PRESERVE DISABLE DO begin insert into t values (1); insert into t values (2); end"},
+	})
+	tk.MustQuery("show create event e3").CheckAt([]int{0, 3}, [][]any{
+		{"e3", "CREATE DEFINER=`root`@`%` EVENT `e3` ON SCHEDULE AT '2030-01-01 00:00:00' ON COMPLETION NOT PRESERVE ENABLE DO insert into t values (3)"},
+	})
+	require.ErrorContains(t, tk.QueryToErr("show create event e_not_exists"), "Unknown event 'e_not_exists'")
+
+	// The statement creates the same event again.
+	createStmt := tk.MustQuery("show create event e2").Rows()[0][3].(string)
+	tk.MustExec("drop event e2")
+	tk.MustExec(createStmt)
+	tk.MustQuery("show create event e2").CheckAt([]int{3}, [][]any{{createStmt}})
+
+	tk.MustExec("use mysql")
+	require.ErrorContains(t, tk.QueryToErr("show create event e1"), "Unknown event 'e1'")
+}
+
+func TestEventPrivilege(t *testing.T) {
+	store := testkit.CreateMockStore(t)
+	tk := newEventTestKit(t, store)
+	tk.MustExec("create user 'u'@'%'")
+	tk.MustExec("create event e1 on schedule every 1 hour do set @x = 1")
+
+	tk1 := testkit.NewTestKit(t, store)
+	require.NoError(t, tk1.Session().Auth(&auth.UserIdentity{Username: "u", Hostname: "%"}, nil, nil, nil))
+	tk1.MustGetErrCode("create event test.e2 on schedule every 1 hour do set @x = 1", errno.ErrDBaccessDenied)
+	tk1.MustGetErrCode("drop event test.e1", errno.ErrDBaccessDenied)
+	require.ErrorContains(t, tk1.QueryToErr("show events from test"), "Access denied for user 'u'@'%' to database 'test'")
+	require.ErrorContains(t, tk1.QueryToErr("show create event test.e1"), "Access denied for user 'u'@'%' to database 'test'")
+	tk1.MustQuery("select event_name from information_schema.events").Check(testkit.Rows())
+
+	tk.MustExec("grant event on test.* to 'u'@'%'")
+	tk1.MustExec("create event test.e2 on schedule every 1 hour do set @x = 1")
+	tk1.MustGetErrCode("create definer = 'root'@'%' event test.e3 on schedule every 1 hour do set @x = 1", errno.ErrSpecificAccessDenied)
+	tk1.MustQuery("select event_name, definer from information_schema.events").Check(testkit.Rows("e1 root@%", "e2 u@%"))
+	tk1.MustQuery("show create event test.e2").CheckAt([]int{0}, testkit.Rows("e2"))
+	tk1.MustExec("drop event test.e1")
+}
+
+func TestExecuteEvent(t *testing.T) {
+	store, dom := testkit.CreateMockStoreAndDomain(t)
+	tk := newEventTestKit(t, store)
+	tk.MustExec("create table t (a int primary key)")
+	tk.MustExec("create event e1 on schedule every 1 hour do begin declare x int default 1; insert into t values (x); insert into t values (x + 1); end")
+	tk.MustExec("create event e2 on schedule every 1 hour do begin insert into t values (10); insert into t values (1); end")
+
+	ctx := context.Background()
+	events, err := eventscheduler.LoadEvents(ctx, dom.EventScheduler().Client(), "test", "")
+	require.NoError(t, err)
+	require.Len(t, events, 2)
+
+	// The body is executed in the schema of the event by the session of the event scheduler.
+	tk1 := testkit.NewTestKit(t, store)
+	sessVars := tk1.Session().GetSessionVars()
+	require.NoError(t, eventscheduler.ExecuteEvent(ctx, tk1.Session(), &events[0].EventInfo))
+	tk.MustQuery("select a from t order by a").Check(testkit.Rows("1", "2"))
+	require.Equal(t, "", sessVars.CurrentDB)
+	require.Nil(t, sessVars.ProcedureContext)
+	require.Nil(t, sessVars.User)
+
+	// The statements executed before the failed one are kept as they are autocommitted.
+	err = eventscheduler.ExecuteEvent(ctx, tk1.Session(), &events[1].EventInfo)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "Duplicate entry")
+	tk.MustQuery("select a from t order by a").Check(testkit.Rows("1", "2", "10"))
+	require.False(t, sessVars.InTxn())
+}
+
+func TestExecuteEventWithDefiner(t *testing.T) {
+	store, dom := testkit.CreateMockStoreAndDomain(t)
+	tk := newEventTestKit(t, store)
+	tk.MustExec("create table t (a int)")
+	tk.MustExec("create user 'u'@'%'")
+	tk.MustExec("grant event on test.* to 'u'@'%'")
+
+	tk1 := testkit.NewTestKit(t, store)
+	require.NoError(t, tk1.Session().Auth(&auth.UserIdentity{Username: "u", Hostname: "%"}, nil, nil, nil))
+	tk1.MustExec("create event test.e on schedule every 1 hour do insert into test.t values (1)")
+
+	// An event can't be created without a definer.
+	tk2 := testkit.NewTestKit(t, store)
+	tk2.MustGetErrCode("create event test.e2 on schedule every 1 hour do insert into test.t values (2)", errno.ErrMalformedDefiner)
+
+	ctx := context.Background()
+	events, err := eventscheduler.LoadEvents(ctx, dom.EventScheduler().Client(), "test", "e")
+	require.NoError(t, err)
+	require.Len(t, events, 1)
+	require.Equal(t, "u@%", events[0].Definer)
+
+	// The body is executed with the privileges of the definer rather than the ones of the session.
+	pm := privilege.GetPrivilegeManager(tk2.Session())
+	err = eventscheduler.ExecuteEvent(ctx, tk2.Session(), &events[0].EventInfo)
+	require.Error(t, err)
+	require.True(t, terror.ErrorEqual(err, plannererrors.ErrTableaccessDenied), err.Error())
+	require.Same(t, pm, privilege.GetPrivilegeManager(tk2.Session()))
+	require.Nil(t, tk2.Session().GetSessionVars().User)
+	tk.MustExec("grant insert on test.t to 'u'@'%'")
+	require.NoError(t, eventscheduler.ExecuteEvent(ctx, tk2.Session(), &events[0].EventInfo))
+	tk.MustQuery("select a from t").Check(testkit.Rows("1"))
+
+	// An event without a definer is never executed.
+	info := events[0].EventInfo
+	info.Definer = ""
+	err = eventscheduler.ExecuteEvent(ctx, tk2.Session(), &info)
+	require.True(t, terror.ErrorEqual(err, exeerrors.ErrMalformedDefiner), err.Error())
+	tk.MustQuery("select a from t").Check(testkit.Rows("1"))
+}
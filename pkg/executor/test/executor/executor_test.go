@@ -54,6 +54,7 @@ import (
 	"github.com/pingcap/tidb/pkg/sessionctx/stmtctx"
 	"github.com/pingcap/tidb/pkg/sessionctx/variable"
 	"github.com/pingcap/tidb/pkg/sessiontxn"
+	storeerr "github.com/pingcap/tidb/pkg/store/driver/error"
 	"github.com/pingcap/tidb/pkg/store/mockstore"
 	"github.com/pingcap/tidb/pkg/table/tables"
 	"github.com/pingcap/tidb/pkg/tablecodec"
@@ -2947,3 +2948,72 @@ func TestIssue48756(t *testing.T) {
 		"Warning 1105 ",
 	))
 }
+
+func TestSelectForUpdateSkipLocked(t *testing.T) {
+	store := testkit.CreateMockStore(t)
+	tk := testkit.NewTestKit(t, store)
+	tk.MustExec("use test")
+	tk.MustExec("create table t (id int primary key, v int, key(v))")
+	tk.MustExec("insert into t values (1, 60), (2, 50), (3, 40), (4, 30), (5, 20), (6, 10)")
+	tk1 := testkit.NewTestKit(t, store)
+	tk1.MustExec("use test")
+	tk2 := testkit.NewTestKit(t, store)
+	tk2.MustExec("use test")
+	mustLockNoWait := func(id int, locked bool) {
+		tk2.MustExec("begin pessimistic")
+		err := tk2.ExecToErr(fmt.Sprintf("select * from t where id = %d for update nowait", id))
+		if locked {
+			require.True(t, storeerr.ErrLockAcquireFailAndNoWaitSet.Equal(err), "%v", err)
+		} else {
+			require.NoError(t, err)
+		}
+		tk2.MustExec("rollback")
+	}
+
+	tk1.MustExec("begin pessimistic")
+	tk1.MustQuery("select * from t where id in (1, 3) for update").Check(testkit.Rows("1 60", "3 40"))
+
+	// The locked rows are skipped instead of failing the statement, and the rows beyond the limit
+	// aren't locked.
+	tk.MustExec("begin pessimistic")
+	tk.MustQuery("select id from t order by id limit 2 for update skip locked").Check(testkit.Rows("2", "4"))
+	mustLockNoWait(2, true)
+	mustLockNoWait(4, true)
+	mustLockNoWait(5, false)
+	mustLockNoWait(6, false)
+	tk.MustQuery("select * from t where id = 2 for update").Check(testkit.Rows("2 50"))
+	tk.MustExec("rollback")
+
+	// The rows are sorted below the lock, so only the rows that can be returned are locked.
+	plan := tk.MustQuery("explain format = 'brief' select id from t use index() order by v desc limit 2 for update skip locked").Rows()
+	planIdx := func(op string) int {
+		for i, row := range plan {
+			if strings.Contains(row[0].(string), op) {
+				return i
+			}
+		}
+		return -1
+	}
+	require.Less(t, planIdx("Limit"), planIdx("SelectLock"), "%v", plan)
+	require.Less(t, planIdx("SelectLock"), planIdx("Sort"), "%v", plan)
+	tk.MustExec("begin pessimistic")
+	tk.MustQuery("select id from t use index() order by v desc limit 2 for update skip locked").Check(testkit.Rows("2", "4"))
+	mustLockNoWait(5, false)
+	mustLockNoWait(6, false)
+	tk.MustExec("rollback")
+
+	tk.MustExec("begin pessimistic")
+	tk.MustQuery("select id from t where v < 45 order by v limit 5 for update skip locked").Check(testkit.Rows("6", "5", "4"))
+	// The rows locked by the transaction itself aren't skipped.
+	tk.MustQuery("select id from t for update skip locked").Check(testkit.Rows("2", "4", "5", "6"))
+	tk.MustExec("rollback")
+
+	// Point get and batch point get.
+	tk.MustExec("begin pessimistic")
+	tk.MustQuery("select * from t where id = 1 for update skip locked").Check(testkit.Rows())
+	tk.MustQuery("select * from t where id in (1, 2, 3, 4) for update skip locked").Sort().Check(testkit.Rows("2 50", "4 30"))
+	mustLockNoWait(2, true)
+	mustLockNoWait(5, false)
+	tk.MustExec("rollback")
+	tk1.MustExec("rollback")
+}
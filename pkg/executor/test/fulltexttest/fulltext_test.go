@@ -0,0 +1,100 @@
+// Copyright 2024 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package fulltexttest
+
+import (
+	"testing"
+
+	"github.com/pingcap/tidb/pkg/errno"
+	"github.com/pingcap/tidb/pkg/testkit"
+)
+
+func TestFulltextIndexDDL(t *testing.T) {
+	store := testkit.CreateMockStore(t)
+	tk := testkit.NewTestKit(t, store)
+	tk.MustExec("use test")
+
+	tk.MustExec("create table t (id int primary key, a varchar(100), b text, fulltext key ft (a, b))")
+	tk.MustExec("alter table t add fulltext index ft_ngram (b) with parser ngram")
+	tk.MustQuery("show warnings").Check(testkit.Rows())
+	tk.MustQuery("show create table t").Check(testkit.Rows("t CREATE TABLE `t` (\n" +
+		"  `id` int(11) NOT NULL,\n" +
+		"  `a` varchar(100) DEFAULT NULL,\n" +
+		"  `b` text DEFAULT NULL,\n" +
+		"  PRIMARY KEY (`id`) /*T![clustered_index] CLUSTERED */,\n" +
+		"  FULLTEXT KEY `ft` (`a`,`b`),\n" +
+		"  FULLTEXT KEY `ft_ngram` (`b`) /*!50100 WITH PARSER `ngram` */\n" +
+		") ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_bin"))
+
+	tk.MustGetErrCode("alter table t add fulltext index ft_id (id)", errno.ErrBadFtColumn)
+	tk.MustGetErrCode("alter table t add fulltext index ft_x (a) with parser mecab", errno.ErrFunctionNotDefined)
+	tk.MustGetErrCode("create table t_bin (a varbinary(100), fulltext key (a))", errno.ErrBadFtColumn)
+	tk.MustGetErrCode("create table t_part (id int, a text, fulltext key (a)) partition by hash(id) partitions 2", errno.ErrFulltextNotSupportedWithPartitioning)
+	tk.MustExec("create table t_part (id int, a text, fulltext key (a))")
+	tk.MustGetErrCode("alter table t_part partition by hash(id) partitions 2", errno.ErrFulltextNotSupportedWithPartitioning)
+	tk.MustGetErrCode("alter table t modify column b int", errno.ErrBadFtColumn)
+
+	tk.MustExec("alter table t drop index ft")
+	tk.MustExec("admin check table t")
+}
+
+func TestMatchAgainst(t *testing.T) {
+	store := testkit.CreateMockStore(t)
+	tk := testkit.NewTestKit(t, store)
+	tk.MustExec("use test")
+	tk.MustExec("create table t (id int primary key, title varchar(100), body text, fulltext key ft (title, body))")
+	tk.MustExec("insert into t values (1, 'TiDB', 'TiDB is a distributed SQL database'), " +
+		"(2, 'MySQL', 'MySQL is a popular database'), " +
+		"(3, 'Raft', 'Raft is a consensus algorithm'), " +
+		"(4, 'Spanner', 'Google Spanner is a distributed database')")
+
+	tk.MustQuery("select id from t where match(title, body) against('distributed') order by id").Check(testkit.Rows("1", "4"))
+	tk.MustQuery("select id from t where match(body, title) against('tidb database') order by match(title, body) against('tidb database') desc, id").
+		Check(testkit.Rows("1", "2", "4"))
+	tk.MustQuery("select id, match(title, body) against('consensus') > 0 from t order by id").Check(testkit.Rows("1 0", "2 0", "3 1", "4 0"))
+	tk.MustQuery("select id from t where match(title, body) against('+database -mysql' in boolean mode) order by id").Check(testkit.Rows("1", "4"))
+	tk.MustQuery("select id from t where match(title, body) against('data*' in boolean mode) order by id").Check(testkit.Rows("1", "2", "4"))
+	tk.MustQuery(`select id from t where match(title, body) against('"distributed database"' in boolean mode)`).Check(testkit.Rows("4"))
+	tk.MustQuery("select id from t where match(title, body) against('+distributed +(sql google)' in boolean mode) order by id").Check(testkit.Rows("1", "4"))
+	tk.MustQuery("select id from t where match(title, body) against('the')").Check(testkit.Rows())
+
+	// The rows are found by the fulltext index.
+	tk.MustHavePlan("select /*+ use_index_merge(t, ft) */ id from t where match(title, body) against('+database +distributed' in boolean mode)", "IndexMerge")
+	tk.MustQuery("select /*+ use_index_merge(t, ft) */ id from t where match(title, body) against('+database +distributed' in boolean mode) order by id").Check(testkit.Rows("1", "4"))
+	tk.MustQuery("select /*+ use_index_merge(t, ft) */ id from t where match(title, body) against('raft popular') order by id").Check(testkit.Rows("2", "3"))
+	tk.MustQuery("select /*+ use_index_merge(t, ft) */ id from t where match(title, body) against('consen*' in boolean mode)").Check(testkit.Rows("3"))
+
+	// The index is maintained by the DML.
+	tk.MustExec("update t set body = 'Raft is a distributed consensus algorithm' where id = 3")
+	tk.MustExec("delete from t where id = 4")
+	tk.MustQuery("select /*+ use_index_merge(t, ft) */ id from t where match(title, body) against('distributed') order by id").Check(testkit.Rows("1", "3"))
+	tk.MustExec("admin check table t")
+
+	tk.MustGetErrCode("select * from t where match(body) against('tidb')", errno.ErrFtMatchingKeyNotFound)
+	tk.MustGetErrCode("select * from t where match(title, body) against('tidb' with query expansion)", errno.ErrNotSupportedYet)
+}
+
+func TestMatchAgainstNgram(t *testing.T) {
+	store := testkit.CreateMockStore(t)
+	tk := testkit.NewTestKit(t, store)
+	tk.MustExec("use test")
+	tk.MustExec("create table t (id int primary key, content text, fulltext key ft (content) with parser ngram)")
+	tk.MustExec("insert into t values (1, '分布式数据库'), (2, '关系型数据库'), (3, '分布式系统')")
+
+	tk.MustQuery("select id from t where match(content) against('数据库') order by id").Check(testkit.Rows("1", "2"))
+	tk.MustQuery("select id from t where match(content) against('+分布式 -系统' in boolean mode)").Check(testkit.Rows("1"))
+	tk.MustQuery("select /*+ use_index_merge(t, ft) */ id from t where match(content) against('+分布式' in boolean mode) order by id").Check(testkit.Rows("1", "3"))
+	tk.MustQuery("select /*+ use_index_merge(t, ft) */ id from t where match(content) against('关系*' in boolean mode)").Check(testkit.Rows("2"))
+}
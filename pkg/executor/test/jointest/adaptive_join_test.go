@@ -0,0 +1,81 @@
+// Copyright 2024 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package jointest
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/pingcap/tidb/pkg/testkit"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAdaptiveJoin(t *testing.T) {
+	store := testkit.CreateMockStore(t)
+	tk := testkit.NewTestKit(t, store)
+	tk.MustExec("use test")
+	tk.MustExec("create table t1(a int, b int, key(a))")
+	tk.MustExec("create table t2(id int primary key, c int)")
+	// The outer side is estimated to have a few rows by the pseudo stats.
+	tk.MustExec("insert into t1 with recursive s(n) as (select 1 union all select n + 1 from s where n < 100) select 1, n from s")
+	tk.MustExec("insert into t2 with recursive s(n) as (select 1 union all select n + 1 from s where n < 100) select n, n * 10 from s")
+	query := "select sum(t2.c) from t1 join t2 on t1.b = t2.id where t1.a = 1"
+	strategyOf := func(query string) string {
+		for _, row := range tk.MustQuery("explain analyze " + query).Rows() {
+			info := fmt.Sprintf("%v", row[5])
+			if idx := strings.Index(info, "adaptive:{strategy:"); idx >= 0 {
+				return strings.SplitN(info[idx+len("adaptive:{strategy:"):], ",", 2)[0]
+			}
+		}
+		return ""
+	}
+
+	tk.MustQuery(query).Check(testkit.Rows("50500"))
+	require.Equal(t, "", strategyOf(query))
+
+	tk.MustExec("set @@tidb_opt_enable_adaptive_join = on")
+	tk.MustQuery(query).Check(testkit.Rows("50500"))
+	require.Equal(t, "index_join", strategyOf(query))
+
+	// The join switches to the hash join once the outer rows exceed the threshold.
+	tk.MustExec("set @@tidb_adaptive_join_outer_rows_threshold = 10")
+	tk.MustQuery(query).Check(testkit.Rows("50500"))
+	require.Equal(t, "hash_join", strategyOf(query))
+	tk.MustQuery("select t1.b, t2.c from t1 join t2 on t1.b = t2.id where t1.a = 1 and t1.b > 98").Sort().Check(testkit.Rows("99 990", "100 1000"))
+
+	// The hash join alternative is shown by EXPLAIN.
+	alternativeOf := func(query string) string {
+		for _, row := range tk.MustQuery("explain format = 'brief' " + query).Rows() {
+			info := fmt.Sprintf("%v", row[4])
+			if idx := strings.Index(info, "hash join alternative build:"); idx >= 0 {
+				return info[idx+len("hash join alternative build:"):]
+			}
+		}
+		return ""
+	}
+	require.True(t, strings.HasPrefix(alternativeOf(query), "TableReader"), alternativeOf(query))
+
+	// The columns of the hash join are resolved on the outer side of the index join, which is the right
+	// child of the right outer join and has more columns than the join keys.
+	rightJoin := "select t1.a, t2.c, t1.b from t2 right join t1 on t1.b = t2.id and t2.c > 500 where t1.a = 1 and t1.b > 40 and t1.b < 60"
+	require.NotEqual(t, "", alternativeOf(rightJoin))
+	rows := tk.MustQuery(rightJoin).Sort().Rows()
+	require.Len(t, rows, 19)
+	require.Equal(t, "hash_join", strategyOf(rightJoin))
+	tk.MustExec("set @@tidb_opt_enable_adaptive_join = off")
+	require.Equal(t, "", alternativeOf(rightJoin))
+	tk.MustQuery(rightJoin).Sort().Check(rows)
+}
@@ -655,3 +655,113 @@ func TestKillDuringBuild(t *testing.T) {
 	err = tk.QueryToErr("select /*+ HASH_JOIN_BUILD(t) */ * from t left outer join t1 on t.c1 = t1.c1")
 	require.Equal(t, exeerrors.ErrQueryInterrupted, err)
 }
+
+func TestRootRuntimeFilter(t *testing.T) {
+	store := testkit.CreateMockStore(t)
+	tk := testkit.NewTestKit(t, store)
+	tk.MustExec("use test")
+	tk.MustExec("create table fact (id int primary key, d1 int, d2 varchar(10), v int)")
+	tk.MustExec("create table dim1 (id int primary key, a int)")
+	tk.MustExec("create table dim2 (k varchar(10), b int)")
+	values := make([]string, 0, 100)
+	for i := 0; i < 100; i++ {
+		values = append(values, fmt.Sprintf("(%d, %d, 'k%d', %d)", i, i%10, i%5, i))
+	}
+	tk.MustExec("insert into fact values " + strings.Join(values, ","))
+	tk.MustExec("insert into dim1 values (1, 1), (2, 2), (3, 3), (4, 4), (5, 5)")
+	tk.MustExec("insert into dim2 values ('k1', 1), ('k2', 2), (null, 3)")
+	oldEnableV2 := join.IsHashJoinV2Enabled()
+	defer join.SetEnableHashJoinV2(oldEnableV2)
+
+	queries := []string{
+		"select /*+ hash_join(fact, dim1), hash_join_build(dim1) */ fact.id from fact join dim1 on fact.d1 = dim1.id where dim1.a > 2",
+		"select /*+ hash_join(fact, dim1), hash_join_build(dim1) */ fact.id from fact join dim1 on fact.id = dim1.id",
+		"select /*+ hash_join(fact, dim1, dim2), hash_join_build(dim1, dim2) */ fact.id from fact join dim1 on fact.d1 = dim1.id join dim2 on fact.d2 = dim2.k where dim2.b < 3",
+		"select /*+ hash_join(fact, dim1), hash_join_build(dim1) */ fact.id, dim1.a from fact left join dim1 on fact.d1 = dim1.id",
+		"select /*+ hash_join(fact, dim1), hash_join_build(dim1) */ fact.id from fact where fact.d1 in (select id from dim1 where a < 3)",
+	}
+	for _, enableV2 := range []bool{false, true} {
+		join.SetEnableHashJoinV2(enableV2)
+		for _, rfType := range []string{"IN", "MIN_MAX", "IN,MIN_MAX"} {
+			tk.MustExec(fmt.Sprintf("set tidb_runtime_filter_type = '%s'", rfType))
+			for _, query := range queries {
+				tk.MustExec("set tidb_runtime_filter_mode = OFF")
+				expected := tk.MustQuery(query).Sort().Rows()
+				tk.MustExec("set tidb_runtime_filter_mode = LOCAL")
+				tk.MustQuery(query).Sort().Check(expected)
+			}
+		}
+	}
+
+	// The rows which can't be joined are filtered out by the coprocessor.
+	tk.MustExec("set tidb_runtime_filter_type = 'IN'")
+	rows := tk.MustQuery("explain analyze " + queries[0]).Rows()
+	found := false
+	for _, row := range rows {
+		if strings.Contains(row[0].(string), "Selection") && strings.Contains(row[6].(string), "runtime filter") {
+			require.Equal(t, "30", row[2])
+			found = true
+		}
+	}
+	require.True(t, found)
+}
+
+func TestHashJoinV2Spill(t *testing.T) {
+	store := testkit.CreateMockStore(t)
+	tk := testkit.NewTestKit(t, store)
+	tk.MustExec("use test")
+	tk.MustExec("create table probe (a int, b int)")
+	tk.MustExec("create table build (a int, b int)")
+	probeValues := make([]string, 0, 1000)
+	buildValues := make([]string, 0, 1000)
+	for i := 0; i < 1000; i++ {
+		probeValues = append(probeValues, fmt.Sprintf("(%d, %d)", i%300, i))
+		if i%50 == 0 {
+			buildValues = append(buildValues, fmt.Sprintf("(null, %d)", i))
+		} else {
+			buildValues = append(buildValues, fmt.Sprintf("(%d, %d)", i%400+100, i))
+		}
+	}
+	tk.MustExec("insert into probe values " + strings.Join(probeValues, ","))
+	tk.MustExec("insert into build values " + strings.Join(buildValues, ","))
+	tk.MustExec("set @@tidb_max_chunk_size = 32")
+	oldEnableV2 := join.IsHashJoinV2Enabled()
+	join.SetEnableHashJoinV2(true)
+	defer join.SetEnableHashJoinV2(oldEnableV2)
+
+	queries := []string{
+		// inner join
+		"select /*+ hash_join(probe, build), hash_join_build(build) */ probe.b, build.b from probe join build on probe.a = build.a",
+		"select /*+ hash_join(probe, build), hash_join_build(build) */ probe.b, build.b from probe join build on probe.a = build.a and probe.b < build.b",
+		// outer joins that use the inner side and the outer side to build
+		"select /*+ hash_join(probe, build), hash_join_build(build) */ probe.b, build.b from probe left join build on probe.a = build.a",
+		"select /*+ hash_join(probe, build), hash_join_build(build) */ probe.b, build.b from probe right join build on probe.a = build.a and probe.b > build.b",
+		// semi joins
+		"select /*+ hash_join(probe, build), hash_join_build(build) */ b from probe where exists (select 1 from build where build.a = probe.a)",
+		"select /*+ hash_join(probe, build), hash_join_build(build) */ b from probe where exists (select 1 from build where build.a = probe.a and build.b > probe.b)",
+		"select /*+ hash_join(probe, build), hash_join_build(build) */ b, exists (select 1 from build where build.a = probe.a) from probe",
+		// anti semi joins
+		"select /*+ hash_join(probe, build), hash_join_build(build) */ b from probe where not exists (select 1 from build where build.a = probe.a)",
+		"select /*+ hash_join(probe, build), hash_join_build(build) */ b, not exists (select 1 from build where build.a = probe.a and build.b < probe.b) from probe",
+	}
+	fpName := "github.com/pingcap/tidb/pkg/executor/join/triggerHashJoinV2Spill"
+	for _, query := range queries {
+		expected := tk.MustQuery(query).Sort().Rows()
+		require.NoError(t, failpoint.Enable(fpName, "return(true)"))
+		tk.MustQuery(query).Sort().Check(expected)
+		require.NoError(t, failpoint.Disable(fpName))
+		require.Greater(t, tk.Session().GetSessionVars().StmtCtx.DiskTracker.MaxConsumed(), int64(0), query)
+	}
+
+	// The null aware anti joins don't spill.
+	for _, query := range []string{
+		"select /*+ hash_join(probe, build), hash_join_build(build) */ b from probe where a not in (select a from build)",
+		"select /*+ hash_join(probe, build), hash_join_build(build) */ b, a not in (select a from build where build.b > probe.b) from probe",
+	} {
+		expected := tk.MustQuery(query).Sort().Rows()
+		require.NoError(t, failpoint.Enable(fpName, "return(true)"))
+		tk.MustQuery(query).Sort().Check(expected)
+		require.NoError(t, failpoint.Disable(fpName))
+		require.Equal(t, int64(0), tk.Session().GetSessionVars().StmtCtx.DiskTracker.MaxConsumed(), query)
+	}
+}
@@ -0,0 +1,72 @@
+// Copyright 2024 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package jointest
+
+import (
+	"testing"
+
+	"github.com/pingcap/tidb/pkg/errno"
+	"github.com/pingcap/tidb/pkg/testkit"
+)
+
+func TestLateralDerivedTable(t *testing.T) {
+	store := testkit.CreateMockStore(t)
+	tk := testkit.NewTestKit(t, store)
+	tk.MustExec("use test")
+	tk.MustExec("create table c (id int primary key, name varchar(10))")
+	tk.MustExec("create table o (id int primary key, cid int, amount int, key(cid))")
+	tk.MustExec("insert into c values (1, 'a'), (2, 'b'), (3, 'c')")
+	tk.MustExec("insert into o values (1, 1, 10), (2, 1, 30), (3, 1, 20), (4, 1, 40), (5, 2, 5)")
+
+	// The top N rows per group can't be decorrelated.
+	sql := "select c.id, dt.amount from c, lateral (select amount from o where o.cid = c.id order by amount desc limit 2) dt order by c.id, dt.amount desc"
+	tk.MustQuery(sql).Check(testkit.Rows("1 40", "1 30", "2 5"))
+	tk.MustHavePlan(sql, "Apply")
+	tk.MustQuery("select c.id, dt.amount from c left join lateral (select amount from o where o.cid = c.id order by amount limit 1) dt on true order by c.id").
+		Check(testkit.Rows("1 10", "2 5", "3 <nil>"))
+	tk.MustQuery("select c.id, o1.id, dt.amount from c join o o1 on o1.cid = c.id join lateral (select amount from o where o.cid = c.id and o.id > o1.id order by o.id limit 1) dt order by o1.id").
+		Check(testkit.Rows("1 1 30", "1 2 20", "1 3 40"))
+
+	// The selections and aggregations are decorrelated.
+	sql = "select c.id, dt.id from c, lateral (select id from o where o.cid = c.id and amount > 15) dt order by dt.id"
+	tk.MustQuery(sql).Check(testkit.Rows("1 2", "1 3", "1 4"))
+	tk.MustNotHavePlan(sql, "Apply")
+	sql = "select c.id, dt.cnt from c, lateral (select count(*) cnt from o where o.cid = c.id) dt order by c.id"
+	tk.MustQuery(sql).Check(testkit.Rows("1 4", "2 1", "3 0"))
+	tk.MustNotHavePlan(sql, "Apply")
+	sql = "select c.id, dt.s from c join lateral (select cid, sum(amount) s from o where o.cid = c.id group by cid) dt on true order by c.id"
+	tk.MustQuery(sql).Check(testkit.Rows("1 100", "2 5"))
+	tk.MustNotHavePlan(sql, "Apply")
+	tk.MustQuery("select c.id, dt.s from c left join lateral (select sum(amount) s from o where o.cid = c.id group by cid) dt on true order by c.id").
+		Check(testkit.Rows("1 100", "2 5", "3 <nil>"))
+	tk.MustQuery("select c.id, dt.cnt from c left join lateral (select count(*) cnt from o where o.cid = c.id) dt on dt.cnt > 1 order by c.id").
+		Check(testkit.Rows("1 4", "2 <nil>", "3 <nil>"))
+	sql = "select c.id, dt.s from c join lateral (select sum(amount) s from o where o.cid = c.id group by cid) dt on dt.s > 10 order by c.id"
+	tk.MustQuery(sql).Check(testkit.Rows("1 100"))
+	sql = "select c.id, dt.cnt from c join lateral (select count(*) cnt from o where o.cid = c.id) dt on dt.cnt < 2 order by c.id"
+	tk.MustQuery(sql).Check(testkit.Rows("2 1", "3 0"))
+
+	// The correlated subqueries are decorrelated as before.
+	sql = "select c.id, (select count(*) from o where o.cid = c.id) from c order by c.id"
+	tk.MustQuery(sql).Check(testkit.Rows("1 4", "2 1", "3 0"))
+	tk.MustNotHavePlan(sql, "Apply")
+	tk.MustQuery("select c.id, (select sum(amount) from o where o.cid = c.id group by cid) from c order by c.id").Check(testkit.Rows("1 100", "2 5", "3 <nil>"))
+	tk.MustQuery("select c.id from c where (select count(*) from o where o.cid = c.id) < 2 order by c.id").Check(testkit.Rows("2", "3"))
+
+	// The LATERAL derived table can't refer to the outer table of a RIGHT JOIN.
+	tk.MustGetErrCode("select * from c right join lateral (select * from o where o.cid = c.id) dt on true", errno.ErrBadField)
+	tk.MustGetErrCode("select * from lateral (select * from o where o.cid = c.id) dt, c", errno.ErrBadField)
+	tk.MustGetErrCode("select * from c, lateral (select 1)", errno.ErrDerivedMustHaveAlias)
+}
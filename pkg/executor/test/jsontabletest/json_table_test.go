@@ -0,0 +1,81 @@
+// Copyright 2024 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package jsontabletest
+
+import (
+	"testing"
+
+	"github.com/pingcap/tidb/pkg/errno"
+	"github.com/pingcap/tidb/pkg/testkit"
+)
+
+func TestJSONTableColumns(t *testing.T) {
+	store := testkit.CreateMockStore(t)
+	tk := testkit.NewTestKit(t, store)
+	tk.MustExec("use test")
+
+	tk.MustQuery(`select * from json_table('[{"a": 1, "b": "x"}, {"a": "2", "c": true}, {"a": [1]}]', '$[*]' columns (` +
+		`id for ordinality, a int path '$.a', b varchar(10) path '$.b' default '"none"' on empty, has_c int exists path '$.c', j json path '$.a')) as jt`).
+		Check(testkit.Rows("1 1 x 0 1", `2 2 none 1 "2"`, "3 <nil> none 0 [1]"))
+	tk.MustQuery(`select v from json_table('[1, 2.5, "3", null]', '$[*]' columns (v decimal(4, 1) path '$')) jt`).
+		Check(testkit.Rows("1.0", "2.5", "3.0", "<nil>"))
+	tk.MustQuery(`select * from json_table('{"a": [1, 2]}', '$' columns (a json path '$.a', b json path '$.a[*]', c varchar(10) path '$.a[0]')) jt`).
+		Check(testkit.Rows("[1, 2] [1, 2] 1"))
+	tk.MustQuery(`select * from json_table('[{"a": [1]}, {}]', '$[*]' columns (a int path '$.a' default '0' on empty default '-1' on error)) jt`).
+		Check(testkit.Rows("-1", "0"))
+	tk.MustQuery(`select * from json_table(null, '$[*]' columns (a int path '$')) jt`).Check(testkit.Rows())
+
+	tk.MustGetErrCode(`select * from json_table('[{"a": [1]}]', '$[*]' columns (a int path '$.a' error on error)) jt`, errno.ErrWrongJSONTableValue)
+	tk.MustGetErrCode(`select * from json_table('[{}]', '$[*]' columns (a int path '$.a' error on empty)) jt`, errno.ErrMissingJSONTableValue)
+	tk.MustGetErrCode(`select * from json_table('[]', '$[*]' columns (a int path '$'))`, errno.ErrTFMustHaveAlias)
+	tk.MustGetErrCode(`select * from json_table('[]', '$[' columns (a int path '$')) jt`, errno.ErrInvalidJSONPath)
+	tk.MustGetErrCode(`select * from json_table('[]', '$[*]' columns (a int path '$', a int path '$')) jt`, errno.ErrDupFieldName)
+}
+
+func TestJSONTableNestedPath(t *testing.T) {
+	store := testkit.CreateMockStore(t)
+	tk := testkit.NewTestKit(t, store)
+	tk.MustExec("use test")
+
+	tk.MustQuery(`select * from json_table('[{"name": "a", "tags": ["x", "y"], "ids": [1]}, {"name": "b", "tags": []}]', '$[*]' columns (` +
+		`name varchar(10) path '$.name', nested path '$.tags[*]' columns (tag varchar(10) path '$', tag_id for ordinality), ` +
+		`nested '$.ids[*]' columns (id int path '$'))) jt`).
+		Check(testkit.Rows("a x 1 <nil>", "a y 2 <nil>", "a <nil> <nil> 1", "b <nil> <nil> <nil>"))
+	tk.MustQuery(`select * from json_table('{"a": [{"b": [1, 2]}, {"b": [3]}]}', '$' columns (` +
+		`nested path '$.a[*]' columns (i for ordinality, nested path '$.b[*]' columns (b int path '$')))) jt`).
+		Check(testkit.Rows("1 1", "1 2", "2 3"))
+}
+
+func TestJSONTableJoin(t *testing.T) {
+	store := testkit.CreateMockStore(t)
+	tk := testkit.NewTestKit(t, store)
+	tk.MustExec("use test")
+	tk.MustExec("create table t (id int primary key, doc json)")
+	tk.MustExec(`insert into t values (1, '{"items": [{"sku": "a", "qty": 2}, {"sku": "b", "qty": 1}]}'), (2, '{"items": []}'), (3, null)`)
+
+	sql := `select t.id, jt.sku, jt.qty from t, json_table(t.doc, '$.items[*]' columns (sku varchar(10) path '$.sku', qty int path '$.qty')) jt order by t.id, jt.sku`
+	tk.MustQuery(sql).Check(testkit.Rows("1 a 2", "1 b 1"))
+	tk.MustHavePlan(sql, "Apply")
+	tk.MustHavePlan(sql, "JSONTable")
+	tk.MustQuery(`select t.id, jt.sku from t left join json_table(t.doc, '$.items[*]' columns (sku varchar(10) path '$.sku')) jt on true order by t.id, jt.sku`).
+		Check(testkit.Rows("1 a", "1 b", "2 <nil>", "3 <nil>"))
+	tk.MustQuery(`select t.id, sum(jt.qty) from t join json_table(t.doc, '$.items[*]' columns (qty int path '$.qty')) jt group by t.id`).
+		Check(testkit.Rows("1 3"))
+	tk.MustQuery(`select t.id, jt.qty from t join json_table('[{"qty": 2}, {"qty": 3}]', '$[*]' columns (qty int path '$.qty')) jt on t.id = jt.qty order by t.id`).
+		Check(testkit.Rows("2 2", "3 3"))
+
+	// JSON_TABLE can't refer to the outer table of a RIGHT JOIN.
+	tk.MustGetErrCode(`select * from t right join json_table(t.doc, '$' columns (a int path '$.a')) jt on true`, errno.ErrBadField)
+}
@@ -0,0 +1,244 @@
+// Copyright 2024 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mviewtest
+
+import (
+	"testing"
+
+	"github.com/pingcap/tidb/pkg/errno"
+	"github.com/pingcap/tidb/pkg/testkit"
+	"github.com/pingcap/tidb/pkg/testkit/testfailpoint"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCreateDropMaterializedView(t *testing.T) {
+	store := testkit.CreateMockStore(t)
+	tk := testkit.NewTestKit(t, store)
+	tk.MustExec("use test")
+	tk.MustExec("create table t (a int, b int)")
+	tk.MustExec("create table t2 (a int)")
+	tk.MustExec("insert into t values (1, 10), (1, 20), (2, null)")
+
+	tk.MustExec("create materialized view mv (a, cnt, s, cnt_b) refresh incremental as select a, count(*), sum(b), count(b) from t group by a")
+	tk.MustQuery("select * from mv order by a").Check(testkit.Rows("1 2 30 2", "2 1 <nil> 0"))
+	tk.MustGetErrCode("create materialized view mv as select * from t", errno.ErrTableExists)
+	tk.MustExec("create materialized view if not exists mv as select * from t")
+	tk.MustQuery("show warnings").Check(testkit.Rows("Note 1050 Table 'test.mv' already exists"))
+	tk.MustGetErrCode("create materialized view mv2 (x) as select a, b from t", errno.ErrViewWrongList)
+
+	// The query of a view refreshed incrementally must be an aggregation on a single table.
+	tk.MustGetErrCode("create materialized view mv2 refresh incremental as select a, max(b) from t group by a", errno.ErrMViewNotIncremental)
+	tk.MustGetErrCode("create materialized view mv2 refresh incremental as select a, sum(b), count(*) from t group by a", errno.ErrMViewNotIncremental)
+	tk.MustGetErrCode("create materialized view mv2 refresh incremental as select a, count(*) from t join t2 using (a) group by a", errno.ErrMViewNotIncremental)
+
+	// The view and its log table can only be changed by the refresh.
+	tk.MustGetErrCode("insert into mv values (3, 1, 1, 1)", errno.ErrNonUpdatableTable)
+	tk.MustGetErrCode("update mv set cnt = 0", errno.ErrNonUpdatableTable)
+	tk.MustGetErrCode("delete from mv", errno.ErrNonUpdatableTable)
+	tk.MustGetErrCode("drop table mv", errno.ErrOptOnMViewTable)
+	tk.MustGetErrCode("truncate table mv", errno.ErrOptOnMViewTable)
+	tk.MustGetErrCode("truncate table t", errno.ErrOptOnMViewTable)
+	tk.MustGetErrCode("alter table t drop column b", errno.ErrOptOnMViewTable)
+	tk.MustExec("alter table t add column c int")
+	tk.MustGetErrCode("drop materialized view t", errno.ErrWrongObject)
+
+	// The partition operations changing the rows of the base tables are not recorded in the log table.
+	tk.MustExec("create table pt (a int, b int) partition by range (a) (partition p0 values less than (10), partition p1 values less than (20))")
+	tk.MustExec("create table nt (a int, b int)")
+	tk.MustExec("create materialized view mv_p refresh incremental as select a, count(*) from pt group by a")
+	tk.MustExec("create materialized view mv_n refresh incremental as select a, count(*) from nt group by a")
+	tk.MustGetErrCode("alter table pt truncate partition p0", errno.ErrOptOnMViewTable)
+	tk.MustGetErrCode("alter table pt drop partition p0", errno.ErrOptOnMViewTable)
+	tk.MustGetErrCode("alter table pt exchange partition p0 with table nt", errno.ErrOptOnMViewTable)
+	// REORGANIZE PARTITION and REBUILD PARTITION only move the rows between the partitions.
+	tk.MustExec("insert into pt values (1, 1), (11, 1), (11, 2)")
+	tk.MustExec("alter table pt reorganize partition p0, p1 into (partition p2 values less than (5), partition p3 values less than (20))")
+	tk.MustExec("alter table pt rebuild partition p2, p3")
+	tk.MustExec("refresh materialized view mv_p")
+	tk.MustQuery("select * from mv_p order by a").Check(testkit.Rows("1 1", "11 2"))
+	tk.MustExec("delete from pt")
+	tk.MustExec("alter table pt reorganize partition p2, p3 into (partition p0 values less than (10), partition p1 values less than (20))")
+	tk.MustExec("drop materialized view mv_p")
+	tk.MustGetErrCode("alter table pt exchange partition p0 with table nt", errno.ErrOptOnMViewTable)
+	tk.MustExec("drop materialized view mv_n")
+	tk.MustExec("alter table pt exchange partition p0 with table nt")
+	tk.MustExec("alter table pt truncate partition p0")
+
+	tk.MustExec("drop materialized view mv")
+	tk.MustGetErrCode("select * from mv", errno.ErrNoSuchTable)
+	tk.MustQuery("select count(*) from information_schema.tables where table_schema = 'test' and table_name like '\\_tidb\\_mlog\\_%'").Check(testkit.Rows("0"))
+	tk.MustQuery("select count(*) from mysql.tidb_mview_refresh").Check(testkit.Rows("0"))
+	tk.MustGetErrCode("drop materialized view mv", errno.ErrBadTable)
+	tk.MustExec("drop materialized view if exists mv")
+	tk.MustExec("truncate table t")
+	tk.MustExec("alter table t drop column b")
+}
+
+func TestRefreshMaterializedView(t *testing.T) {
+	store := testkit.CreateMockStore(t)
+	tk := testkit.NewTestKit(t, store)
+	tk.MustExec("use test")
+	tk.MustExec("create table t (a int, b int, c varchar(10))")
+	tk.MustExec("insert into t values (1, 10, 'x'), (1, 20, 'y'), (2, null, 'z')")
+	tk.MustExec("create materialized view mv_c as select a, b from t where b > 10")
+	tk.MustExec("create materialized view mv_i (a, cnt, s, cnt_b) refresh incremental as select a, count(*), sum(b), count(b) from t where c <> 'w' group by a")
+	tk.MustExec("create materialized view mv_g (cnt, s, cnt_b) refresh incremental as select count(*), sum(b), count(b) from t")
+	tk.MustQuery("select * from mv_c").Check(testkit.Rows("1 20"))
+	tk.MustQuery("select * from mv_g").Check(testkit.Rows("3 30 2"))
+
+	// The changes of the base table are applied by the refresh.
+	tk.MustExec("insert into t values (3, 5, 'x'), (3, 6, 'w'), (2, 7, 'x')")
+	tk.MustExec("update t set b = b + 1 where a = 1")
+	tk.MustExec("delete from t where b = 21")
+	tk.MustQuery("select * from mv_c").Check(testkit.Rows("1 20"))
+	tk.MustQuery("select * from mv_i order by a").Check(testkit.Rows("1 2 30 2", "2 1 <nil> 0"))
+	tk.MustExec("refresh materialized view mv_c")
+	tk.MustQuery("select * from mv_c").Check(testkit.Rows("1 11"))
+	tk.MustExec("refresh materialized view mv_i")
+	tk.MustQuery("select * from mv_i order by a").Check(testkit.Rows("1 1 11 1", "2 2 7 1", "3 1 5 1"))
+	tk.MustExec("refresh materialized view mv_g")
+	tk.MustQuery("select * from mv_g").Check(testkit.Rows("5 29 4"))
+
+	// The groups without rows are deleted, and the sums without values are NULL.
+	tk.MustExec("delete from t where a in (1, 3)")
+	tk.MustExec("update t set b = null")
+	tk.MustExec("insert into t (a, b, c) values (4, 1, 'x') on duplicate key update b = 2")
+	tk.MustExec("replace into t values (5, 2, 'x')")
+	tk.MustExec("refresh materialized view mv_i")
+	tk.MustQuery("select * from mv_i order by a").Check(testkit.Rows("2 2 <nil> 0", "4 1 1 1", "5 1 2 1"))
+	tk.MustExec("refresh materialized view mv_g incremental")
+	tk.MustQuery("select * from mv_g").Check(testkit.Rows("4 3 2"))
+	tk.MustExec("delete from t")
+	tk.MustExec("refresh materialized view mv_i")
+	tk.MustQuery("select * from mv_i").Check(testkit.Rows())
+	tk.MustExec("refresh materialized view mv_g")
+	tk.MustQuery("select * from mv_g").Check(testkit.Rows("0 <nil> 0"))
+
+	// The complete refresh can be used for all the views.
+	tk.MustExec("insert into t values (6, 1, 'x')")
+	tk.MustExec("refresh materialized view mv_i complete")
+	tk.MustQuery("select * from mv_i").Check(testkit.Rows("6 1 1 1"))
+	tk.MustGetErrCode("refresh materialized view mv_c incremental", errno.ErrMViewNotIncremental)
+	tk.MustGetErrCode("refresh materialized view t", errno.ErrWrongObject)
+	tk.MustGetErrCode("refresh materialized view mv_not_exists", errno.ErrNoSuchTable)
+
+	// The refresh is executed in its own transaction.
+	tk.MustExec("begin")
+	tk.MustExec("insert into t values (6, 2, 'x')")
+	tk.MustExec("refresh materialized view mv_i")
+	tk.MustQuery("select * from mv_i").Check(testkit.Rows("6 2 3 2"))
+}
+
+func TestRefreshMaterializedViewWithConcurrentDML(t *testing.T) {
+	store := testkit.CreateMockStore(t)
+	tk := testkit.NewTestKit(t, store)
+	tk.MustExec("use test")
+	tk.MustExec("create table t (a int, b int)")
+	tk.MustExec("insert into t values (1, 1)")
+	tk.MustExec("create materialized view mv refresh incremental as select a, count(*), sum(b), count(b) from t group by a")
+	tk.MustExec("insert into t values (1, 2), (2, 3)")
+
+	// The changes committed after the refresh reads the log table are not purged.
+	tk2 := testkit.NewTestKit(t, store)
+	tk2.MustExec("use test")
+	inserted := false
+	testfailpoint.EnableCall(t, "github.com/pingcap/tidb/pkg/executor/beforePurgeMViewLog", func() {
+		if !inserted {
+			inserted = true
+			tk2.MustExec("insert into t values (1, 4), (3, 5)")
+		}
+	})
+	tk.MustExec("refresh materialized view mv")
+	require.True(t, inserted)
+	tk.MustQuery("select * from mv order by a").Check(testkit.Rows("1 2 3 2", "2 1 3 1"))
+	tk.MustExec("refresh materialized view mv")
+	tk.MustQuery("select * from mv order by a").Check(testkit.Rows("1 3 7 3", "2 1 3 1", "3 1 5 1"))
+	tk.MustQuery("select a, count(*), sum(b), count(b) from t group by a order by a").Check(testkit.Rows("1 3 7 3", "2 1 3 1", "3 1 5 1"))
+}
+
+func TestMaterializedViewStaleness(t *testing.T) {
+	store := testkit.CreateMockStore(t)
+	tk := testkit.NewTestKit(t, store)
+	tk.MustExec("use test")
+	tk.MustExec("create table t (a int, b int)")
+	tk.MustExec("create table t2 (a int)")
+	tk.MustExec("create materialized view mv_c as select a, b from t")
+	tk.MustExec("create materialized view mv_i refresh incremental as select a, count(*) from t group by a")
+	tk.MustExec("create materialized view mv_j as select t.a from t join t2 on t.a = t2.a")
+
+	sql := "select table_name, refresh_method, last_refresh_method, last_refresh_tso > 0, staleness, stale_rows " +
+		"from information_schema.materialized_views where table_schema = 'test' order by table_name"
+	tk.MustQuery(sql).Check(testkit.Rows(
+		"mv_c COMPLETE COMPLETE 1 UNKNOWN <nil>",
+		"mv_i INCREMENTAL COMPLETE 1 FRESH 0",
+		"mv_j COMPLETE COMPLETE 1 UNKNOWN <nil>",
+	))
+	tk.MustExec("insert into t values (1, 1), (2, 2)")
+	tk.MustExec("update t set b = 3 where a = 1")
+	tk.MustQuery(sql).Check(testkit.Rows(
+		"mv_c COMPLETE COMPLETE 1 UNKNOWN <nil>",
+		"mv_i INCREMENTAL COMPLETE 1 STALE 4",
+		"mv_j COMPLETE COMPLETE 1 UNKNOWN <nil>",
+	))
+	tk.MustExec("refresh materialized view mv_i")
+	tk.MustExec("drop table t2")
+	tk.MustQuery(sql).Check(testkit.Rows(
+		"mv_c COMPLETE COMPLETE 1 UNKNOWN <nil>",
+		"mv_i INCREMENTAL INCREMENTAL 1 FRESH 0",
+		"mv_j COMPLETE COMPLETE 1 UNUSABLE <nil>",
+	))
+	tk.MustQuery("select view_definition from information_schema.materialized_views where table_name = 'mv_i'").Check(
+		testkit.Rows("SELECT `a` AS `a`,COUNT(1) AS `count(*)` FROM `test`.`t` GROUP BY `a`"))
+	tk.MustQuery("select count(*) from information_schema.materialized_views where last_refresh_time is null").Check(testkit.Rows("0"))
+}
+
+func TestMaterializedViewRewrite(t *testing.T) {
+	store := testkit.CreateMockStore(t)
+	tk := testkit.NewTestKit(t, store)
+	tk.MustExec("use test")
+	tk.MustExec("create table t (a int, b int)")
+	tk.MustExec("insert into t values (1, 1), (1, 2), (2, 3)")
+	tk.MustExec("create materialized view mv refresh incremental as select a, count(*), sum(b), count(b) from t group by a")
+	tk.MustExec("insert into t values (2, 4)")
+
+	query := "select a, count(*), sum(b), count(b) from t group by a"
+	tk.MustQuery(query + " order by a").Check(testkit.Rows("1 2 3 2", "2 2 7 2"))
+	require := func(usesMView bool) {
+		rows := tk.MustQuery("explain format = 'brief' " + query).Rows()
+		found := false
+		for _, row := range rows {
+			if s, ok := row[3].(string); ok && s == "table:mv" {
+				found = true
+			}
+		}
+		if found != usesMView {
+			t.Fatalf("expect reading the materialized view: %v, plan: %v", usesMView, rows)
+		}
+	}
+	require(false)
+
+	// The rewritten query reads the stale data of the view.
+	tk.MustExec("set @@tidb_opt_enable_mview_rewrite = 1")
+	require(true)
+	tk.MustQuery(query).Sort().Check(testkit.Rows("1 2 3 2", "2 1 3 1"))
+	tk.MustQuery("select a, count(*) as c, sum(b), count(b) from t group by a").Sort().Check(testkit.Rows("1 2 3 2", "2 1 3 1"))
+	tk.MustExec("refresh materialized view mv")
+	tk.MustQuery(query).Sort().Check(testkit.Rows("1 2 3 2", "2 2 7 2"))
+
+	// The queries different from the query of the view are not rewritten.
+	tk.MustQuery("select a, count(*) from t group by a order by a").Check(testkit.Rows("1 2", "2 2"))
+	tk.MustQuery("select a, count(*), sum(b), count(b) from t where a > 1 group by a").Check(testkit.Rows("2 2 7 2"))
+	tk.MustExec("set @@tidb_opt_enable_mview_rewrite = default")
+}
@@ -0,0 +1,229 @@
+// Copyright 2024 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package proceduretest
+
+import (
+	"testing"
+
+	"github.com/pingcap/tidb/pkg/errno"
+	"github.com/pingcap/tidb/pkg/executor"
+	"github.com/pingcap/tidb/pkg/parser/auth"
+	"github.com/pingcap/tidb/pkg/testkit"
+	"github.com/stretchr/testify/require"
+)
+
+func newProcedureTestKit(t *testing.T) *testkit.TestKit {
+	store := testkit.CreateMockStore(t)
+	tk := testkit.NewTestKit(t, store)
+	require.NoError(t, tk.Session().Auth(&auth.UserIdentity{Username: "root", Hostname: "%"}, nil, nil, nil))
+	tk.MustExec("use test")
+	return tk
+}
+
+// procedureResults returns the result sets produced by the last CALL statement.
+func procedureResults(tk *testkit.TestKit) []*testkit.Result {
+	v := tk.Session().Value(executor.ProcedureResultVarKey)
+	if v == nil {
+		return nil
+	}
+	tk.Session().SetValue(executor.ProcedureResultVarKey, nil)
+	info := v.(*executor.ProcedureResultInfo)
+	results := make([]*testkit.Result, 0, len(info.ResultSets))
+	for _, rs := range info.ResultSets {
+		results = append(results, tk.ResultSetToResult(rs, "procedure result"))
+	}
+	return results
+}
+
+func TestCreateDropProcedure(t *testing.T) {
+	tk := newProcedureTestKit(t)
+	tk.MustExec("create procedure p1(in a int, out b varchar(20)) begin set b = concat('v', a); end")
+	tk.MustGetErrCode("create procedure p1() select 1", errno.ErrSpAlreadyExists)
+	tk.MustExec("create procedure if not exists p1() select 1")
+	tk.MustQuery("show warnings").Check(testkit.Rows("Note 1304 PROCEDURE p1 already exists"))
+	tk.MustGetErrCode("create procedure db_not_exists.p1() select 1", errno.ErrBadDB)
+
+	rows := tk.MustQuery("show create procedure p1").Rows()
+	require.Len(t, rows, 1)
+	require.Equal(t, "p1", rows[0][0])
+	require.Equal(t, "CREATE DEFINER=`root`@`%` PROCEDURE `p1`(in a int, out b varchar(20))\nbegin set b = concat('v', a); end", rows[0][2])
+	require.ErrorContains(t, tk.QueryToErr("show create procedure p2"), "PROCEDURE test.p2 does not exist")
+
+	tk.MustQuery("show procedure status where db = 'test'").CheckAt([]int{0, 1, 2, 3, 6},
+		testkit.Rows("test p1 PROCEDURE root@% DEFINER"))
+	tk.MustQuery("show procedure status like 'p2'").Check(testkit.Rows())
+	tk.MustQuery("select routine_schema, routine_name, routine_type, routine_definition, definer from information_schema.routines where routine_schema = 'test'").
+		Check(testkit.Rows("test p1 PROCEDURE begin set b = concat('v', a); end root@%"))
+
+	tk.MustExec("drop procedure p1")
+	tk.MustGetErrCode("drop procedure p1", errno.ErrSpDoesNotExist)
+	tk.MustExec("drop procedure if exists p1")
+	tk.MustQuery("show warnings").Check(testkit.Rows("Note 1305 PROCEDURE test.p1 does not exist"))
+	tk.MustQuery("show procedure status where db = 'test'").Check(testkit.Rows())
+
+	// The procedures are dropped with the database.
+	tk.MustExec("create database db1")
+	tk.MustExec("create procedure db1.p1() select 1")
+	tk.MustExec("drop database db1")
+	tk.MustExec("create database db1")
+	tk.MustQuery("show procedure status where db = 'db1'").Check(testkit.Rows())
+	tk.MustGetErrCode("call db1.p1()", errno.ErrSpDoesNotExist)
+}
+
+func TestProcedureSQLSecurity(t *testing.T) {
+	tk := newProcedureTestKit(t)
+	tk.MustExec("create table t (a int)")
+	tk.MustExec("insert into t values (1)")
+	tk.MustExec("create procedure p_definer() select a from t")
+	tk.MustExec("create procedure p_invoker() sql security invoker select a from t")
+	tk.MustQuery("show create procedure p_invoker").CheckAt([]int{2},
+		[][]any{{"CREATE DEFINER=`root`@`%` PROCEDURE `p_invoker`()\n    SQL SECURITY INVOKER\nselect a from t"}})
+	tk.MustQuery("select routine_name, security_type from information_schema.routines where routine_schema = 'test' order by routine_name").
+		Check(testkit.Rows("p_definer DEFINER", "p_invoker INVOKER"))
+
+	// The procedure with SQL SECURITY DEFINER reads the table with the privileges of root.
+	tk.MustExec("create user 'u1'@'%'")
+	tk.MustExec("grant execute on test.* to 'u1'@'%'")
+	tk1 := testkit.NewTestKit(t, tk.Session().GetStore())
+	require.NoError(t, tk1.Session().Auth(&auth.UserIdentity{Username: "u1", Hostname: "%"}, nil, nil, nil))
+	tk1.MustExec("use test")
+	tk1.MustGetErrCode("select a from t", errno.ErrTableaccessDenied)
+	tk1.MustExec("call p_definer()")
+	results := procedureResults(tk1)
+	require.Len(t, results, 1)
+	results[0].Check(testkit.Rows("1"))
+	tk1.MustGetErrCode("call p_invoker()", errno.ErrTableaccessDenied)
+	// The privileges of the caller are restored after the CALL.
+	tk1.MustGetErrCode("select a from t", errno.ErrTableaccessDenied)
+	tk1.MustQuery("select current_user()").Check(testkit.Rows("u1@%"))
+
+	tk.MustExec("create user 'u2'@'%'")
+	tk2 := testkit.NewTestKit(t, tk.Session().GetStore())
+	require.NoError(t, tk2.Session().Auth(&auth.UserIdentity{Username: "u2", Hostname: "%"}, nil, nil, nil))
+	require.ErrorContains(t, tk2.QueryToErr("show create procedure test.p_definer"), "Access denied for user 'u2'@'%' to database 'test'")
+}
+
+func TestCallProcedure(t *testing.T) {
+	tk := newProcedureTestKit(t)
+	tk.MustExec("create table t (a int primary key, b varchar(20))")
+	tk.MustExec(`create procedure p_fill(in n int, inout total int, out msg varchar(20))
+begin
+  declare i int default 0;
+  while i < n do
+    set i = i + 1;
+    insert into t values (i, concat('r', i));
+    set total = total + i;
+  end while;
+  set msg = 'done';
+end`)
+	tk.MustExec("set @total = 10")
+	tk.MustExec("call p_fill(3, @total, @msg)")
+	require.Nil(t, procedureResults(tk))
+	tk.MustQuery("select @total, @msg").Check(testkit.Rows("16 done"))
+	tk.MustQuery("select * from t").Check(testkit.Rows("1 r1", "2 r2", "3 r3"))
+	tk.MustGetErrCode("call p_fill(1)", errno.ErrSpWrongNoOfArgs)
+	tk.MustGetErrCode("call p_fill(1, 2, @msg)", errno.ErrSpNotVarArg)
+
+	// A procedure returns a result set for each query.
+	tk.MustExec("create procedure p_query(in x int) begin select a, b from t where a <= x order by a; select count(*) from t; end")
+	tk.MustExec("call p_query(2)")
+	results := procedureResults(tk)
+	require.Len(t, results, 2)
+	results[0].Check(testkit.Rows("1 r1", "2 r2"))
+	results[1].Check(testkit.Rows("3"))
+
+	tk.MustExec(`create procedure p_flow(in n int, out res varchar(100))
+begin
+  declare i int default 0;
+  set res = '';
+  lbl: loop
+    set i = i + 1;
+    if i > n then leave lbl; end if;
+    if i % 2 = 0 then iterate lbl; end if;
+    case i when 1 then set res = concat(res, 'one,'); when 3 then set res = concat(res, 'three,'); else set res = concat(res, 'other,'); end case;
+  end loop lbl;
+  repeat set i = i - 1; until i <= 0 end repeat;
+  set res = concat(res, i);
+end`)
+	tk.MustExec("call p_flow(6, @res)")
+	tk.MustQuery("select @res").Check(testkit.Rows("one,three,other,0"))
+
+	tk.MustExec("create procedure p_case(in x int) case when x = 1 then select 1; end case")
+	tk.MustGetErrCode("call p_case(2)", errno.ErrSpCaseNotFound)
+
+	// The OUT parameters of a nested CALL are written back to the local variables.
+	tk.MustExec("create procedure p_outer(out res varchar(100)) begin declare s varchar(100); call p_flow(3, s); set res = concat('[', s, ']'); end")
+	tk.MustExec("call p_outer(@res)")
+	tk.MustQuery("select @res").Check(testkit.Rows("[one,three,0]"))
+
+	tk.MustExec("create procedure p_recursive(in n int) begin if n > 0 then call p_recursive(n - 1); end if; end")
+	tk.MustGetErrCode("call p_recursive(1)", errno.ErrSpRecursionLimit)
+	tk.MustExec("set @@max_sp_recursion_depth = 3")
+	tk.MustExec("call p_recursive(3)")
+	tk.MustGetErrCode("call p_recursive(4)", errno.ErrSpRecursionLimit)
+}
+
+func TestProcedureCursorAndHandler(t *testing.T) {
+	tk := newProcedureTestKit(t)
+	tk.MustExec("create table t (a int primary key, b varchar(20))")
+	tk.MustExec("insert into t values (1, 'r1'), (2, 'r2'), (3, 'r3')")
+	tk.MustExec(`create procedure p_cursor(out total int, out names varchar(100))
+begin
+  declare done int default 0;
+  declare v_a int;
+  declare v_b varchar(20);
+  declare cur cursor for select a, b from t order by a;
+  declare continue handler for not found set done = 1;
+  set total = 0, names = '';
+  open cur;
+  read_loop: loop
+    fetch cur into v_a, v_b;
+    if done = 1 then leave read_loop; end if;
+    set total = total + v_a;
+    set names = concat(names, v_b);
+  end loop read_loop;
+  close cur;
+end`)
+	tk.MustExec("call p_cursor(@total, @names)")
+	tk.MustQuery("select @total, @names").Check(testkit.Rows("6 r1r2r3"))
+	tk.MustGetErrCode("create procedure p_bad() begin declare c cursor for select 1; open c; fetch c into x; end", errno.ErrSpUndeclaredVar)
+
+	tk.MustExec(`create procedure p_exit(out res varchar(20))
+begin
+  declare exit handler for 1062 set res = 'duplicate';
+  set res = 'ok';
+  insert into t values (1, 'dup');
+  set res = 'unreachable';
+end`)
+	tk.MustExec("call p_exit(@res)")
+	tk.MustQuery("select @res").Check(testkit.Rows("duplicate"))
+
+	tk.MustExec(`create procedure p_continue()
+begin
+  declare continue handler for sqlstate '23000' set @dup = @dup + 1;
+  insert into t values (1, 'dup');
+  insert into t values (2, 'dup');
+  insert into t values (10, 'r10');
+end`)
+	tk.MustExec("set @dup = 0")
+	tk.MustExec("call p_continue()")
+	tk.MustQuery("select @dup").Check(testkit.Rows("2"))
+	tk.MustQuery("select b from t where a = 10").Check(testkit.Rows("r10"))
+
+	// The errors which are not handled are returned by the CALL statement.
+	tk.MustExec("create procedure p_error() begin insert into t values (20, 'r20'); insert into t values (1, 'dup'); end")
+	tk.MustGetErrCode("call p_error()", errno.ErrDupEntry)
+	tk.MustQuery("select b from t where a = 20").Check(testkit.Rows("r20"))
+}
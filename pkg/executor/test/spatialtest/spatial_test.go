@@ -0,0 +1,104 @@
+// Copyright 2024 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package spatialtest
+
+import (
+	"testing"
+
+	"github.com/pingcap/tidb/pkg/errno"
+	"github.com/pingcap/tidb/pkg/testkit"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSpatialIndexDDL(t *testing.T) {
+	store := testkit.CreateMockStore(t)
+	tk := testkit.NewTestKit(t, store)
+	tk.MustExec("use test")
+
+	tk.MustExec("create table t (id int primary key, g geometry not null srid 0, p point not null, spatial key sp (g))")
+	tk.MustExec("alter table t add spatial index sp_p (p)")
+	createTable := tk.MustQuery("show create table t").Rows()[0][1].(string)
+	require.Contains(t, createTable, "/*!80003 SRID 0 */")
+	require.Contains(t, createTable, "SPATIAL KEY `sp` (`g`)")
+	require.Contains(t, createTable, "SPATIAL KEY `sp_p` (`p`)")
+
+	tk.MustExec("create table t_null (g geometry)")
+	tk.MustGetErrCode("alter table t_null add spatial index sp (g)", errno.ErrSpatialCantHaveNull)
+	tk.MustGetErrCode("create table t_int (id int not null, spatial key (id))", errno.ErrSpatialMustHaveGeomCol)
+	tk.MustGetErrCode("create table t_multi (g geometry not null, p point not null, spatial key (g, p))", errno.ErrTooManyKeyParts)
+	tk.MustGetErrCode("create table t_srid (id int srid 0)", errno.ErrWrongUsage)
+	tk.MustGetErrCode("create table t_srid (g geometry srid 3857)", errno.ErrSRSNotFound)
+
+	tk.MustExec("alter table t drop index sp")
+	tk.MustExec("admin check table t")
+}
+
+func TestSpatialFunctions(t *testing.T) {
+	store := testkit.CreateMockStore(t)
+	tk := testkit.NewTestKit(t, store)
+	tk.MustExec("use test")
+
+	tk.MustQuery("select st_astext(st_geomfromtext('point(1 2)')), st_srid(st_geomfromtext('POINT(1 2)', 4326))").Check(testkit.Rows("POINT(1 2) 4326"))
+	tk.MustQuery("select st_astext(st_geomfromwkb(st_asbinary(st_geomfromtext('LINESTRING(0 0, 1 1.5)'))))").Check(testkit.Rows("LINESTRING(0 0,1 1.5)"))
+	tk.MustQuery("select st_x(point(1, 2)), st_y(point(1, 2)), st_geometrytype(linestring(point(0, 0), point(1, 1)))").Check(testkit.Rows("1 2 LINESTRING"))
+	tk.MustQuery("select st_contains(st_geomfromtext('POLYGON((0 0,10 0,10 10,0 10,0 0))'), point(5, 5)), " +
+		"st_within(point(5, 5), st_geomfromtext('POLYGON((0 0,10 0,10 10,0 10,0 0))')), " +
+		"st_intersects(st_geomfromtext('LINESTRING(0 0,2 2)'), st_geomfromtext('LINESTRING(0 2,2 0)')), " +
+		"st_disjoint(point(0, 0), point(1, 1))").Check(testkit.Rows("1 1 1 1"))
+	tk.MustQuery("select st_distance(point(0, 0), point(3, 4)), round(st_distance_sphere(point(0, 0), point(0, 90), 2), 6)").Check(testkit.Rows("5 3.141593"))
+	tk.MustQuery("select st_astext(st_geomfromtext(null)), st_contains(null, point(0, 0))").Check(testkit.Rows("<nil> <nil>"))
+
+	tk.MustGetErrCode("select st_geomfromtext('POINT(1)')", errno.ErrGISInvalidData)
+	tk.MustGetErrCode("select st_geomfromtext('POINT(1 2)', 3857)", errno.ErrSRSNotFound)
+	tk.MustGetErrCode("select st_geomfromtext('POINT(100 2)', 4326)", errno.ErrLatitudeOutOfRange)
+	tk.MustGetErrCode("select st_intersects(st_geomfromtext('POINT(1 2)', 4326), point(1, 2))", errno.ErrGISDifferentSRIDs)
+	tk.MustGetErrCode("select st_distance(st_geomfromtext('POINT(1 2)', 4326), st_geomfromtext('POINT(1 2)', 4326))", errno.ErrGISUnsupportedArgument)
+	tk.MustGetErrCode("select st_distance_sphere(point(0, 0), point(1, 1), 0)", errno.ErrNonPositiveRadius)
+
+	tk.MustExec("create table t (id int primary key, g geometry, p point srid 4326)")
+	tk.MustExec("insert into t values (1, st_geomfromtext('POLYGON((0 0,1 0,1 1,0 0))'), st_geomfromtext('POINT(10 20)', 4326))")
+	tk.MustQuery("select st_astext(g), st_astext(p), st_srid(p) from t").Check(testkit.Rows("POLYGON((0 0,1 0,1 1,0 0)) POINT(10 20) 4326"))
+	tk.MustGetErrCode("insert into t (id, g) values (2, 'abc')", errno.ErrCantCreateGeometryObject)
+	tk.MustGetErrCode("insert into t (id, p) values (2, st_geomfromtext('LINESTRING(0 0,1 1)', 4326))", errno.ErrCantCreateGeometryObject)
+	tk.MustGetErrCode("insert into t (id, p) values (2, point(1, 2))", errno.ErrWrongSRIDForColumn)
+}
+
+func TestSpatialIndex(t *testing.T) {
+	store := testkit.CreateMockStore(t)
+	tk := testkit.NewTestKit(t, store)
+	tk.MustExec("use test")
+	tk.MustExec("create table t (id int primary key, g geometry not null srid 0, spatial key sp (g))")
+	tk.MustExec("insert into t values (1, point(1, 1)), (2, point(-5, 3)), " +
+		"(3, st_geomfromtext('LINESTRING(-10 -10, 10 10)')), " +
+		"(4, st_geomfromtext('POLYGON((100 100,200 100,200 200,100 200,100 100))')), " +
+		"(5, st_geomfromtext('MULTIPOINT(150 150, -150 -150)'))")
+
+	query := "select /*+ use_index_merge(t, sp) */ id from t where st_intersects(g, st_geomfromtext('POLYGON((0 0,2 0,2 2,0 2,0 0))')) order by id"
+	tk.MustHavePlan(query, "IndexMerge")
+	tk.MustQuery(query).Check(testkit.Rows("1", "3"))
+	tk.MustQuery("select /*+ use_index_merge(t, sp) */ id from t where st_contains(st_geomfromtext('POLYGON((-6 -6,6 -6,6 6,-6 6,-6 -6))'), g) order by id").Check(testkit.Rows("1", "2"))
+	tk.MustQuery("select /*+ use_index_merge(t, sp) */ id from t where st_within(point(150, 150), g) order by id").Check(testkit.Rows("4", "5"))
+	tk.MustQuery("select /*+ use_index_merge(t, sp) */ id from t where st_intersects(g, point(150, 150)) order by id").Check(testkit.Rows("4", "5"))
+	tk.MustQuery("select /*+ use_index_merge(t, sp) */ id from t where st_intersects(g, point(-150, -150))").Check(testkit.Rows("5"))
+
+	// The index is maintained by the DML.
+	tk.MustExec("update t set g = point(150, 160) where id = 1")
+	tk.MustExec("delete from t where id = 5")
+	tk.MustQuery("select /*+ use_index_merge(t, sp) */ id from t where st_intersects(g, st_geomfromtext('POLYGON((140 140,160 140,160 160,140 160,140 140))')) order by id").Check(testkit.Rows("1", "4"))
+	tk.MustExec("admin check table t")
+
+	// The index isn't used if the SRID of the constant differs from the column.
+	tk.MustNotHavePlan("select /*+ use_index_merge(t, sp) */ id from t where st_intersects(g, st_geomfromtext('POINT(1 1)', 4326))", "IndexMerge")
+}
@@ -0,0 +1,218 @@
+// Copyright 2024 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package triggertest
+
+import (
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/pingcap/tidb/pkg/errno"
+	"github.com/pingcap/tidb/pkg/executor"
+	"github.com/pingcap/tidb/pkg/parser/auth"
+	"github.com/pingcap/tidb/pkg/testkit"
+	"github.com/stretchr/testify/require"
+)
+
+func newTriggerTestKit(t *testing.T) *testkit.TestKit {
+	store := testkit.CreateMockStore(t)
+	tk := testkit.NewTestKit(t, store)
+	require.NoError(t, tk.Session().Auth(&auth.UserIdentity{Username: "root", Hostname: "%"}, nil, nil, nil))
+	tk.MustExec("use test")
+	return tk
+}
+
+func TestCreateDropTrigger(t *testing.T) {
+	tk := newTriggerTestKit(t)
+	tk.MustExec("create table t (a int primary key, b varchar(20))")
+	tk.MustExec("create view v as select * from t")
+	tk.MustExec("create trigger tr_bi before insert on t for each row set new.b = upper(new.b)")
+	tk.MustGetErrCode("create trigger tr_bi before update on t for each row set new.b = 'x'", errno.ErrTrgAlreadyExists)
+	tk.MustExec("create trigger if not exists tr_bi before update on t for each row set new.b = 'x'")
+	tk.MustQuery("show warnings").Check(testkit.Rows("Note 1359 Trigger already exists"))
+	tk.MustGetErrCode("create trigger mysql.tr_x before insert on test.t for each row set new.b = 'x'", errno.ErrTrgInWrongSchema)
+	tk.MustGetErrCode("create trigger tr_x before insert on v for each row set new.b = 'x'", errno.ErrTrgOnViewOrTempTable)
+	tk.MustGetErrCode("create trigger tr_x before insert on t_not_exists for each row set new.b = 'x'", errno.ErrNoSuchTable)
+	tk.MustGetErrCode("create trigger tr_x before insert on t for each row follows tr_not_exists set new.b = 'x'", errno.ErrReferencedTrgDoesNotExist)
+	tk.MustGetErrCode("create trigger tr_x after insert on t for each row precedes tr_bi set @x = 1", errno.ErrReferencedTrgDoesNotExist)
+
+	// The statements in the body are checked when the trigger is created.
+	tk.MustGetErrCode("create trigger tr_x before insert on t for each row select 1", errno.ErrSpNoRetset)
+	tk.MustGetErrCode("create trigger tr_x before delete on t for each row set @x = new.a", errno.ErrTrgNoSuchRowInTrg)
+	tk.MustGetErrCode("create trigger tr_x before insert on t for each row set @x = old.a", errno.ErrTrgNoSuchRowInTrg)
+	tk.MustGetErrCode("create trigger tr_x after insert on t for each row set new.b = 'x'", errno.ErrTrgCantChangeRow)
+	tk.MustGetErrCode("create trigger tr_x before update on t for each row set old.b = 'x'", errno.ErrTrgCantChangeRow)
+	tk.MustGetErrCode("create trigger tr_x before insert on t for each row set new.c = 'x'", errno.ErrBadField)
+	tk.MustGetErrCode("create trigger tr_x before insert on t for each row begin if new.c > 0 then set @x = 1; end if; end", errno.ErrBadField)
+
+	tk.MustQuery("show triggers").CheckAt([]int{0, 1, 2, 3, 4, 7},
+		[][]any{{"tr_bi", "INSERT", "t", "set new.b = upper(new.b)", "BEFORE", "root@%"}})
+	tk.MustQuery("show triggers like 'v'").Check(testkit.Rows())
+	tk.MustQuery("select trigger_schema, trigger_name, event_manipulation, event_object_table, action_order, action_statement, action_timing, definer " +
+		"from information_schema.triggers where trigger_schema = 'test'").
+		Check(testkit.Rows("test tr_bi INSERT t 1 set new.b = upper(new.b) BEFORE root@%"))
+
+	tk.MustExec("drop trigger tr_bi")
+	tk.MustGetErrCode("drop trigger tr_bi", errno.ErrTrgDoesNotExist)
+	tk.MustExec("drop trigger if exists tr_bi")
+	tk.MustQuery("show warnings").Check(testkit.Rows("Note 1360 Trigger does not exist"))
+	tk.MustQuery("show triggers").Check(testkit.Rows())
+
+	// The triggers are dropped with the table.
+	tk.MustExec("create trigger tr_bi before insert on t for each row set new.b = upper(new.b)")
+	tk.MustExec("drop table t")
+	tk.MustExec("create table t (a int primary key, b varchar(20))")
+	tk.MustQuery("select trigger_name from information_schema.triggers where trigger_schema = 'test'").Check(testkit.Rows())
+	tk.MustExec("create trigger tr_bi before insert on t for each row set new.b = upper(new.b)")
+}
+
+func TestInsertTrigger(t *testing.T) {
+	tk := newTriggerTestKit(t)
+	tk.MustExec("create table t (a int primary key, b varchar(20), c int)")
+	tk.MustExec("create table log (id int auto_increment primary key, msg varchar(100))")
+	tk.MustExec("create trigger tr_bi before insert on t for each row set new.b = concat(new.b, '!'), new.c = new.a * 10")
+	tk.MustExec("create trigger tr_ai after insert on t for each row insert into log (msg) values (concat('insert ', new.a, ' ', new.b))")
+	tk.MustExec("insert into t (a, b) values (1, 'x'), (2, 'y')")
+	// The rows inserted by the trigger are not counted.
+	require.Equal(t, uint64(2), tk.Session().AffectedRows())
+	tk.MustQuery("select * from t order by a").Check(testkit.Rows("1 x! 10", "2 y! 20"))
+	tk.MustQuery("select msg from log order by id").Check(testkit.Rows("insert 1 x!", "insert 2 y!"))
+
+	// The triggers are activated in the order of FOLLOWS and PRECEDES.
+	tk.MustExec("create trigger tr_bi2 before insert on t for each row precedes tr_bi set new.b = concat(new.b, '?')")
+	tk.MustExec("create trigger tr_bi3 before insert on t for each row follows tr_bi2 set new.b = concat(new.b, '#')")
+	tk.MustExec("insert into t (a, b) values (3, 'z')")
+	tk.MustQuery("select b, c from t where a = 3").Check(testkit.Rows("z?#! 30"))
+	tk.MustQuery("select trigger_name, action_order from information_schema.triggers " +
+		"where trigger_schema = 'test' and event_object_table = 't' and action_timing = 'BEFORE' order by action_order").
+		Check(testkit.Rows("tr_bi2 1", "tr_bi3 2", "tr_bi 3"))
+	tk.MustExec("drop trigger tr_bi2")
+	tk.MustExec("drop trigger tr_bi3")
+
+	// INSERT ... ON DUPLICATE KEY UPDATE activates the update triggers for the duplicated rows.
+	tk.MustExec("create trigger tr_au after update on t for each row insert into log (msg) values (concat('update ', old.b, ' -> ', new.b))")
+	tk.MustExec("delete from log")
+	tk.MustExec("insert into t (a, b) values (1, 'dup'), (4, 'w') on duplicate key update b = 'updated'")
+	tk.MustQuery("select * from t where a in (1, 4) order by a").Check(testkit.Rows("1 updated 10", "4 w! 40"))
+	tk.MustQuery("select msg from log order by id").Check(testkit.Rows("update x! -> updated", "insert 4 w!"))
+
+	// REPLACE activates the delete triggers for the replaced rows.
+	tk.MustExec("create trigger tr_ad after delete on t for each row insert into log (msg) values (concat('delete ', old.a, ' ', old.b))")
+	tk.MustExec("delete from log")
+	tk.MustExec("replace into t (a, b) values (2, 'r')")
+	tk.MustQuery("select * from t where a = 2").Check(testkit.Rows("2 r! 20"))
+	tk.MustQuery("select msg from log order by id").Check(testkit.Rows("delete 2 y!", "insert 2 r!"))
+
+	// The table of the activating statement can't be modified in the trigger.
+	tk.MustExec("create table t2 (a int primary key)")
+	tk.MustExec("create trigger tr_t2 after insert on t2 for each row insert into t2 values (new.a + 100)")
+	tk.MustGetErrCode("insert into t2 values (1)", errno.ErrCantUpdateUsedTableInSfOrTrg)
+	tk.MustQuery("select * from t2").Check(testkit.Rows())
+
+	// An error in the trigger fails the activating statement.
+	tk.MustExec("drop trigger tr_t2")
+	tk.MustExec("insert into log values (1000, 'x')")
+	tk.MustExec("create trigger tr_t2 before insert on t2 for each row insert into log values (1000, 'dup')")
+	tk.MustGetErrCode("insert into t2 values (1)", errno.ErrDupEntry)
+	tk.MustQuery("select * from t2").Check(testkit.Rows())
+}
+
+func TestLoadDataTrigger(t *testing.T) {
+	tk := newTriggerTestKit(t)
+	loadData := func(sql, data string) {
+		var readerBuilder executor.LoadDataReaderBuilder = func(_ string) (io.ReadCloser, error) {
+			return io.NopCloser(strings.NewReader(data)), nil
+		}
+		tk.Session().SetValue(executor.LoadDataReaderBuilderKey, readerBuilder)
+		tk.MustExec(sql)
+	}
+	tk.MustExec("create table t (a int primary key, b varchar(20), c int)")
+	tk.MustExec("create table log (id int auto_increment primary key, msg varchar(100))")
+	tk.MustExec("create trigger tr_bi before insert on t for each row set new.b = concat(new.b, '!'), new.c = new.a * 10")
+	tk.MustExec("create trigger tr_ai after insert on t for each row insert into log (msg) values (concat('insert ', new.a, ' ', new.b))")
+
+	// LOAD DATA activates the insert triggers for each row.
+	loadData("load data local infile '/tmp/t.csv' into table t fields terminated by ',' (a, b)", "1,x\n2,y\n")
+	tk.MustQuery("select * from t order by a").Check(testkit.Rows("1 x! 10", "2 y! 20"))
+	tk.MustQuery("select msg from log order by id").Check(testkit.Rows("insert 1 x!", "insert 2 y!"))
+
+	// LOAD DATA ... REPLACE activates the delete triggers for the replaced rows.
+	tk.MustExec("create trigger tr_ad after delete on t for each row insert into log (msg) values (concat('delete ', old.a, ' ', old.b))")
+	tk.MustExec("delete from log")
+	loadData("load data local infile '/tmp/t.csv' replace into table t fields terminated by ',' (a, b)", "2,r\n3,z\n")
+	tk.MustQuery("select * from t order by a").Check(testkit.Rows("1 x! 10", "2 r! 20", "3 z! 30"))
+	tk.MustQuery("select msg from log order by id").Check(testkit.Rows("delete 2 y!", "insert 2 r!", "insert 3 z!"))
+}
+
+func TestUpdateDeleteTrigger(t *testing.T) {
+	tk := newTriggerTestKit(t)
+	tk.MustExec("create table t (a int primary key, b int, history varchar(100))")
+	tk.MustExec("create table log (id int auto_increment primary key, msg varchar(100))")
+	tk.MustExec("insert into t (a, b) values (1, 10), (2, 20), (3, 30)")
+	tk.MustExec(`create trigger tr_bu before update on t for each row
+begin
+  declare diff int default new.b - old.b;
+  if diff > 5 then
+    set new.history = concat(ifnull(old.history, ''), '+', diff);
+  else
+    set new.b = old.b;
+  end if;
+end`)
+	tk.MustExec("update t set b = b + a * 3")
+	tk.MustQuery("select * from t order by a").Check(testkit.Rows("1 10 <nil>", "2 26 +6", "3 39 +9"))
+
+	tk.MustExec("create trigger tr_bd before delete on t for each row insert into log (msg) values (concat('before ', old.a))")
+	tk.MustExec("create trigger tr_ad after delete on t for each row insert into log (msg) values (concat('after ', old.a, ' ', old.b))")
+	tk.MustExec("delete from t where a < 3")
+	require.Equal(t, uint64(2), tk.Session().AffectedRows())
+	tk.MustQuery("select * from t").Check(testkit.Rows("3 39 +9"))
+	tk.MustQuery("select msg from log order by id").Check(testkit.Rows("before 1", "after 1 10", "before 2", "after 2 26"))
+
+	// The triggers of each table are activated by the multi-table statements.
+	tk.MustExec("create table t2 (a int primary key, b int)")
+	tk.MustExec("insert into t2 values (3, 1)")
+	tk.MustExec("create trigger tr_t2 after update on t2 for each row insert into log (msg) values (concat('t2 ', new.b))")
+	tk.MustExec("delete from log")
+	tk.MustExec("update t, t2 set t.b = t.b + 10, t2.b = t2.b + 1 where t.a = t2.a")
+	tk.MustQuery("select * from t").Check(testkit.Rows("3 49 +9+10"))
+	tk.MustQuery("select msg from log order by id").Check(testkit.Rows("t2 2"))
+}
+
+func TestBeforeUpdateTriggerWithGeneratedColumns(t *testing.T) {
+	tk := newTriggerTestKit(t)
+	tk.MustExec(`create table t (a int primary key, b int, c int,
+  s int as (b * 2) stored, v int as (b + c) virtual, vs int as (s + 1) virtual,
+  key idx_s (s), key idx_v (v), key idx_vs (vs), key idx_expr ((b + 10)))`)
+	tk.MustExec("insert into t (a, b, c) values (1, 1, 1), (2, 2, 2)")
+	// The trigger modifies the base column of the generated columns which is not assigned by the statement.
+	tk.MustExec("create trigger tr_bu before update on t for each row set new.b = new.c * 10")
+	tk.MustExec("update t set c = c + 1")
+	tk.MustQuery("select a, b, c, s, v, vs, b + 10 from t order by a").Check(testkit.Rows("1 20 2 40 22 41 30", "2 30 3 60 33 61 40"))
+	tk.MustExec("admin check table t")
+	tk.MustQuery("select a from t use index (idx_v) where v = 22").Check(testkit.Rows("1"))
+	tk.MustQuery("select a from t use index (idx_expr) where b + 10 = 40").Check(testkit.Rows("2"))
+
+	// The duplicate row updated by INSERT ... ON DUPLICATE KEY UPDATE.
+	tk.MustExec("insert into t (a, b, c) values (1, 0, 0) on duplicate key update c = 5")
+	tk.MustQuery("select a, b, c, s, v, vs from t where a = 1").Check(testkit.Rows("1 50 5 100 55 101"))
+	tk.MustExec("admin check table t")
+
+	// The multi-table update.
+	tk.MustExec("create table t2 (a int primary key, c int)")
+	tk.MustExec("insert into t2 values (2, 7)")
+	tk.MustExec("update t, t2 set t.c = t2.c where t.a = t2.a")
+	tk.MustQuery("select a, b, c, s, v, vs from t where a = 2").Check(testkit.Rows("2 70 7 140 77 141"))
+	tk.MustExec("admin check table t")
+}
@@ -0,0 +1,556 @@
+// Copyright 2024 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package executor
+
+import (
+	"cmp"
+	"context"
+	"slices"
+	"strings"
+
+	"github.com/pingcap/errors"
+	"github.com/pingcap/tidb/pkg/domain"
+	"github.com/pingcap/tidb/pkg/executor/internal/exec"
+	"github.com/pingcap/tidb/pkg/expression"
+	"github.com/pingcap/tidb/pkg/infoschema"
+	"github.com/pingcap/tidb/pkg/parser"
+	"github.com/pingcap/tidb/pkg/parser/ast"
+	"github.com/pingcap/tidb/pkg/parser/model"
+	"github.com/pingcap/tidb/pkg/parser/mysql"
+	"github.com/pingcap/tidb/pkg/parser/terror"
+	"github.com/pingcap/tidb/pkg/planner"
+	plannercore "github.com/pingcap/tidb/pkg/planner/core"
+	"github.com/pingcap/tidb/pkg/sessionctx"
+	"github.com/pingcap/tidb/pkg/sessiontxn"
+	"github.com/pingcap/tidb/pkg/table"
+	"github.com/pingcap/tidb/pkg/types"
+	"github.com/pingcap/tidb/pkg/util/chunk"
+	"github.com/pingcap/tidb/pkg/util/dbterror"
+	"github.com/pingcap/tidb/pkg/util/dbterror/exeerrors"
+	"github.com/pingcap/tidb/pkg/util/dbterror/plannererrors"
+	"github.com/pingcap/tidb/pkg/util/sqlescape"
+)
+
+func (e *DDLExec) executeCreateTrigger(s *ast.CreateTriggerStmt) error {
+	if e.Ctx().GetSessionVars().ProcedureContext != nil {
+		return exeerrors.ErrSpNoRecursiveCreate.GenWithStackByArgs("TRIGGER")
+	}
+	if _, ok := e.getLocalTemporaryTable(s.Table.Schema, s.Table.Name); ok {
+		return dbterror.ErrTrgOnViewOrTempTable.GenWithStackByArgs(s.Table.Name.O)
+	}
+	if err := checkTrigger(s, s.Table.TableInfo); err != nil {
+		return err
+	}
+	return domain.GetDomain(e.Ctx()).DDL().CreateTrigger(e.Ctx(), s)
+}
+
+func (e *DDLExec) executeDropTrigger(s *ast.DropTriggerStmt) error {
+	return domain.GetDomain(e.Ctx()).DDL().DropTrigger(e.Ctx(), s)
+}
+
+// checkTrigger checks the body of a trigger before it is created.
+func checkTrigger(s *ast.CreateTriggerStmt, tblInfo *model.TableInfo) error {
+	c := &procedureChecker{trigger: s, tblInfo: tblInfo}
+	return c.checkStmt(s.Body)
+}
+
+// checkNode checks the references to the NEW and OLD rows in the node of a trigger body.
+func (c *procedureChecker) checkNode(node ast.Node) error {
+	if c.trigger == nil || node == nil {
+		return nil
+	}
+	v := &triggerRowChecker{checker: c}
+	node.Accept(v)
+	return v.err
+}
+
+// checkSet checks the assignments to the NEW and OLD rows in the SET statement of a trigger body.
+func (c *procedureChecker) checkSet(stmt *ast.SetStmt) error {
+	if c.trigger == nil {
+		return nil
+	}
+	for _, assign := range stmt.Variables {
+		if assign.IsSystem && !assign.IsGlobal && assign.ExtendValue == nil {
+			if row, col, ok := strings.Cut(strings.ToLower(assign.Name), "."); ok {
+				if err := c.checkTriggerRow(row, col, true); err != nil {
+					return err
+				}
+			}
+		}
+		if err := c.checkNode(assign.Value); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// checkTriggerStmt checks whether the statement is allowed in a trigger body.
+func (c *procedureChecker) checkTriggerStmt(stmt ast.StmtNode) error {
+	if c.trigger == nil {
+		return nil
+	}
+	switch stmt.(type) {
+	case *ast.InsertStmt, *ast.UpdateStmt, *ast.DeleteStmt, *ast.DoStmt:
+		return c.checkNode(stmt)
+	case *ast.SelectStmt, *ast.SetOprStmt, *ast.ShowStmt, *ast.ExplainStmt:
+		return exeerrors.ErrSpNoRetset.GenWithStackByArgs("trigger")
+	case *ast.BeginStmt, *ast.CommitStmt, *ast.RollbackStmt, *ast.SavepointStmt, *ast.ReleaseSavepointStmt, ast.DDLNode:
+		return exeerrors.ErrCommitNotAllowedInSfOrTrg.GenWithStackByArgs()
+	case *ast.CallStmt:
+		// The statements in a procedure are executed as standalone statements, which
+		// can't be executed inside the statement that activates the trigger.
+		return exeerrors.ErrStmtNotAllowedInSfOrTrg.GenWithStackByArgs("CALL")
+	}
+	return exeerrors.ErrStmtNotAllowedInSfOrTrg.GenWithStackByArgs(strings.ToUpper(ast.GetStmtLabel(stmt)))
+}
+
+// checkTriggerRow checks the reference to a column of the NEW or OLD row.
+func (c *procedureChecker) checkTriggerRow(row, col string, assign bool) error {
+	timing, event := c.trigger.Timing, c.trigger.Event
+	switch row {
+	case "new":
+		if event == model.TriggerDelete {
+			return exeerrors.ErrTrgNoSuchRowInTrg.GenWithStackByArgs("NEW", "on "+event.String())
+		}
+		if assign && timing == model.TriggerAfter {
+			return exeerrors.ErrTrgCantChangeRow.GenWithStackByArgs("NEW", "after ")
+		}
+	case "old":
+		if event == model.TriggerInsert {
+			return exeerrors.ErrTrgNoSuchRowInTrg.GenWithStackByArgs("OLD", "on "+event.String())
+		}
+		if assign {
+			return exeerrors.ErrTrgCantChangeRow.GenWithStackByArgs("OLD", "")
+		}
+	default:
+		return nil
+	}
+	if model.FindColumnInfo(c.tblInfo.Columns, col) == nil {
+		return plannererrors.ErrUnknownColumn.GenWithStackByArgs(col, strings.ToUpper(row))
+	}
+	return nil
+}
+
+// triggerRowChecker visits a node in the trigger body and checks the column references of the NEW and OLD rows.
+type triggerRowChecker struct {
+	checker *procedureChecker
+	err     error
+}
+
+// Enter implements the ast.Visitor interface.
+func (v *triggerRowChecker) Enter(in ast.Node) (ast.Node, bool) {
+	if x, ok := in.(*ast.ColumnNameExpr); ok && x.Name.Schema.L == "" {
+		v.err = v.checker.checkTriggerRow(x.Name.Table.L, x.Name.Name.L, false)
+	}
+	return in, v.err != nil
+}
+
+// Leave implements the ast.Visitor interface.
+func (v *triggerRowChecker) Leave(in ast.Node) (ast.Node, bool) {
+	return in, v.err == nil
+}
+
+// tableTriggers are the triggers of a table which is modified by a DML statement.
+type tableTriggers struct {
+	tbl      table.Table
+	dbName   model.CIStr
+	triggers []*model.TriggerInfo
+	// bodies caches the parsed trigger bodies, a body is parsed when the trigger is activated for the first time.
+	bodies map[string]ast.StmtNode
+	// genCols caches the generated columns and their expressions, they are built when a BEFORE UPDATE
+	// trigger is activated for the first time.
+	genCols []generatedColumn
+	// genColsBuilt indicates whether genCols is built.
+	genColsBuilt bool
+}
+
+// generatedColumn is a generated column and its expression evaluated on the row of the table.
+type generatedColumn struct {
+	col  *table.Column
+	expr expression.Expression
+}
+
+// buildTableTriggers builds the triggers of a table which is modified by a DML statement.
+// It returns nil if the table has no trigger.
+func (b *executorBuilder) buildTableTriggers(tbl table.Table) (*tableTriggers, error) {
+	tblInfo := tbl.Meta()
+	if err := checkTriggerUsedTable(b.ctx, tblInfo); err != nil {
+		return nil, err
+	}
+	if len(tblInfo.Triggers) == 0 {
+		return nil, nil
+	}
+	dbInfo, ok := infoschema.SchemaByTable(b.is, tblInfo)
+	if !ok {
+		return nil, errors.Errorf("Can not get the schema of table %d", tblInfo.ID)
+	}
+	return &tableTriggers{
+		tbl:      tbl,
+		dbName:   dbInfo.Name,
+		triggers: tblInfo.Triggers,
+	}, nil
+}
+
+func (b *executorBuilder) buildTblID2TableTriggers(tblID2Table map[int64]table.Table) (map[int64]*tableTriggers, error) {
+	var tblID2Triggers map[int64]*tableTriggers
+	for tid, tbl := range tblID2Table {
+		triggers, err := b.buildTableTriggers(tbl)
+		if err != nil {
+			return nil, err
+		}
+		if triggers == nil {
+			continue
+		}
+		if tblID2Triggers == nil {
+			tblID2Triggers = make(map[int64]*tableTriggers)
+		}
+		tblID2Triggers[tid] = triggers
+	}
+	return tblID2Triggers, nil
+}
+
+// checkTriggerUsedTable checks whether the table is modified by the statements which activate the
+// triggers being executed, a trigger can't modify such tables.
+func checkTriggerUsedTable(sctx sessionctx.Context, tblInfo *model.TableInfo) error {
+	procCtx, _ := sctx.GetSessionVars().ProcedureContext.(*procedureContext)
+	for c := procCtx; c != nil; c = c.parent {
+		if c.trigger != nil && c.trigger.tbl.Meta().ID == tblInfo.ID {
+			return exeerrors.ErrCantUpdateUsedTableInSfOrTrg.GenWithStackByArgs(tblInfo.Name.O)
+		}
+	}
+	return nil
+}
+
+// fire activates the triggers of the timing and event for a row. The BEFORE triggers can modify
+// the new row, and the modified columns are marked in assigned if it's not nil.
+// It's a no-op if t is nil, so the callers don't need to check whether the table has triggers.
+func (t *tableTriggers) fire(ctx context.Context, sctx sessionctx.Context, timing model.TriggerTiming, event model.TriggerEvent,
+	oldRow, newRow []types.Datum, assigned []bool) error {
+	if t == nil {
+		return nil
+	}
+	fired := false
+	for _, trigger := range t.triggers {
+		if trigger.Timing != timing || trigger.Event != event {
+			continue
+		}
+		fired = true
+		trgCtx := &triggerContext{
+			tbl:      t.tbl,
+			info:     trigger,
+			oldRow:   oldRow,
+			newRow:   newRow,
+			assigned: assigned,
+		}
+		if err := t.fireTrigger(ctx, sctx, trgCtx); err != nil {
+			return err
+		}
+	}
+	// The generated columns of the new row are composed before the BEFORE UPDATE triggers, so they are
+	// evaluated again with the columns modified by the triggers. The BEFORE INSERT triggers are fired
+	// before the generated columns are evaluated.
+	if fired && timing == model.TriggerBefore && event == model.TriggerUpdate {
+		return t.evalGeneratedColumns(sctx, newRow)
+	}
+	return nil
+}
+
+// evalGeneratedColumns evaluates the generated columns of the row in the order of their offsets, so the
+// generated columns depending on the other ones see the new values of them.
+func (t *tableTriggers) evalGeneratedColumns(sctx sessionctx.Context, row []types.Datum) error {
+	if !t.genColsBuilt {
+		genCols, err := buildGeneratedColumns(sctx, t.tbl)
+		if err != nil {
+			return err
+		}
+		t.genCols, t.genColsBuilt = genCols, true
+	}
+	if len(t.genCols) == 0 {
+		return nil
+	}
+	evalCtx := sctx.GetExprCtx().GetEvalCtx()
+	sc := sctx.GetSessionVars().StmtCtx
+	mutRow := chunk.MutRowFromDatums(row)
+	for _, genCol := range t.genCols {
+		offset := genCol.col.Offset
+		val, err := genCol.expr.Eval(evalCtx, mutRow.ToRow())
+		if err = sc.HandleTruncate(err); err != nil {
+			return err
+		}
+		casted, err := table.CastValue(sctx, val, genCol.col.ToInfo(), false, false)
+		if err = sc.HandleTruncate(err); err != nil {
+			return err
+		}
+		if err = genCol.col.HandleBadNull(sc.ErrCtx(), &casted, 0); err != nil {
+			return err
+		}
+		casted.Copy(&row[offset])
+		mutRow.SetDatum(offset, casted)
+	}
+	return nil
+}
+
+// buildGeneratedColumns builds the expressions of the generated columns of the table.
+func buildGeneratedColumns(sctx sessionctx.Context, tbl table.Table) ([]generatedColumn, error) {
+	tblInfo := tbl.Meta()
+	cols := tbl.WritableCols()
+	names := make(types.NameSlice, 0, len(cols))
+	exprCols := make([]*expression.Column, 0, len(cols))
+	for _, col := range cols {
+		name := &types.FieldName{OrigTblName: tblInfo.Name, OrigColName: col.Name, TblName: tblInfo.Name, ColName: col.Name}
+		names = append(names, name)
+		exprCols = append(exprCols, &expression.Column{
+			RetType:  col.FieldType.Clone(),
+			ID:       col.ID,
+			UniqueID: sctx.GetSessionVars().AllocPlanColumnID(),
+			Index:    col.Offset,
+			OrigName: name.String(),
+			IsHidden: col.Hidden,
+		})
+	}
+	schema := expression.NewSchema(exprCols...)
+	var genCols []generatedColumn
+	for _, col := range cols {
+		if col.GeneratedExpr == nil {
+			continue
+		}
+		expr, err := expression.BuildSimpleExpr(sctx.GetExprCtx(), col.GeneratedExpr.Internal(),
+			expression.WithInputSchemaAndNames(schema, names, tblInfo), expression.WithAllowCastArray(true))
+		if err != nil {
+			return nil, err
+		}
+		genCols = append(genCols, generatedColumn{col: col, expr: expr})
+	}
+	slices.SortFunc(genCols, func(a, b generatedColumn) int {
+		return cmp.Compare(a.col.Offset, b.col.Offset)
+	})
+	return genCols, nil
+}
+
+func (t *tableTriggers) fireTrigger(ctx context.Context, sctx sessionctx.Context, trgCtx *triggerContext) error {
+	trigger := trgCtx.info
+	body, err := t.parseBody(sctx, trigger)
+	if err != nil {
+		return err
+	}
+	sessVars := sctx.GetSessionVars()
+	sc := sessVars.StmtCtx
+	parent, _ := sessVars.ProcedureContext.(*procedureContext)
+	procCtx := &procedureContext{
+		sctx:     sctx,
+		trigger:  trgCtx,
+		parent:   parent,
+		dbName:   t.dbName,
+		procName: trigger.Name,
+	}
+
+	origProcCtx, origDB, origSQLMode := sessVars.ProcedureContext, sessVars.CurrentDB, sessVars.SQLMode
+	origInHandleTrigger, origBatchCheck := sc.InHandleTrigger, sc.BatchCheck
+	origLastInsertID, origInsertID := sc.LastInsertID, sc.InsertID
+	sessVars.ProcedureContext = procCtx
+	sessVars.CurrentDB = t.dbName.O
+	sessVars.SQLMode = trigger.SQLMode
+	// The statements in the trigger body check their own rows, so the batch check of the
+	// activating statement is disabled.
+	sc.InHandleTrigger, sc.BatchCheck = true, false
+	defer func() {
+		sessVars.ProcedureContext = origProcCtx
+		sessVars.CurrentDB = origDB
+		sessVars.SQLMode = origSQLMode
+		sc.InHandleTrigger, sc.BatchCheck = origInHandleTrigger, origBatchCheck
+		sc.LastInsertID, sc.InsertID = origLastInsertID, origInsertID
+	}()
+
+	err = procCtx.execStmt(ctx, body)
+	if unhandled, ok := err.(*procedureUnhandledError); ok {
+		err = unhandled.err
+	}
+	return err
+}
+
+// parseBody parses the body of the trigger with the sql mode and charset that the trigger was created with.
+func (t *tableTriggers) parseBody(sctx sessionctx.Context, trigger *model.TriggerInfo) (ast.StmtNode, error) {
+	if body, ok := t.bodies[trigger.Name.L]; ok {
+		return body, nil
+	}
+	// The body may contain compound statements which can only be parsed in a CREATE statement.
+	sql := "CREATE TRIGGER " + sqlescape.MustEscapeSQL("%n", trigger.Name.O) + " " + trigger.Timing.String() + " " +
+		trigger.Event.String() + " ON " + sqlescape.MustEscapeSQL("%n", t.tbl.Meta().Name.O) + " FOR EACH ROW " + trigger.Body
+	p := parser.New()
+	p.SetParserConfig(sctx.GetSessionVars().BuildParserConfig())
+	p.SetSQLMode(trigger.SQLMode)
+	stmt, err := p.ParseOneStmt(sql, trigger.CharsetClient, trigger.CollationConnection)
+	if err != nil {
+		return nil, err
+	}
+	s, ok := stmt.(*ast.CreateTriggerStmt)
+	if !ok {
+		return nil, errors.Errorf("invalid definition of trigger %s", trigger.Name.O)
+	}
+	if t.bodies == nil {
+		t.bodies = make(map[string]ast.StmtNode)
+	}
+	t.bodies[trigger.Name.L] = s.Body
+	return s.Body, nil
+}
+
+// triggerContext is the runtime context of an activated trigger.
+type triggerContext struct {
+	tbl    table.Table
+	info   *model.TriggerInfo
+	oldRow []types.Datum
+	newRow []types.Datum
+	// assigned marks the columns of the new row which are assigned in the trigger, it can be nil.
+	assigned []bool
+}
+
+func (t *triggerContext) row(name string) []types.Datum {
+	switch name {
+	case "new":
+		return t.newRow
+	case "old":
+		return t.oldRow
+	}
+	return nil
+}
+
+func (t *triggerContext) getColumn(row, name string) (types.Datum, *types.FieldType, bool) {
+	data := t.row(row)
+	if data == nil {
+		return types.Datum{}, nil, false
+	}
+	col := table.FindColLowerCase(t.tbl.Cols(), name)
+	if col == nil || col.Offset >= len(data) {
+		return types.Datum{}, nil, false
+	}
+	return data[col.Offset], col.FieldType.Clone(), true
+}
+
+// setColumn sets a column of the new row in a BEFORE trigger.
+func (t *triggerContext) setColumn(sctx sessionctx.Context, row, name string, val types.Datum) error {
+	if row == "old" {
+		return exeerrors.ErrTrgCantChangeRow.GenWithStackByArgs("OLD", "")
+	}
+	if t.newRow == nil {
+		return exeerrors.ErrTrgNoSuchRowInTrg.GenWithStackByArgs("NEW", "on "+t.info.Event.String())
+	}
+	if t.info.Timing == model.TriggerAfter {
+		return exeerrors.ErrTrgCantChangeRow.GenWithStackByArgs("NEW", "after ")
+	}
+	col := table.FindColLowerCase(t.tbl.Cols(), name)
+	if col == nil || col.Offset >= len(t.newRow) {
+		return plannererrors.ErrUnknownColumn.GenWithStackByArgs(name, "NEW")
+	}
+	if col.IsGenerated() {
+		return plannererrors.ErrBadGeneratedColumn.GenWithStackByArgs(col.Name.O, t.tbl.Meta().Name.O)
+	}
+	casted, err := table.CastValue(sctx, val, col.ToInfo(), false, false)
+	if err != nil {
+		return err
+	}
+	if !mysql.HasAutoIncrementFlag(col.GetFlag()) {
+		if err := col.HandleBadNull(sctx.GetSessionVars().StmtCtx.ErrCtx(), &casted, 0); err != nil {
+			return err
+		}
+	}
+	casted.Copy(&t.newRow[col.Offset])
+	if t.assigned != nil {
+		t.assigned[col.Offset] = true
+	}
+	return nil
+}
+
+// executeStmt executes a statement in the trigger body. Unlike the statements in a procedure, it's
+// executed inside the statement which activates the trigger, so it shares the statement context
+// and its changes are committed or rolled back with the activating statement.
+func (t *triggerContext) executeStmt(ctx context.Context, sctx sessionctx.Context, stmt ast.StmtNode) ([]chunk.Row, []*ast.ResultField, error) {
+	if err := plannercore.Preprocess(ctx, sctx, stmt); err != nil {
+		return nil, nil, err
+	}
+	is := sessiontxn.GetTxnManager(sctx).GetTxnInfoSchema()
+	p, names, err := planner.OptimizeForTrigger(ctx, sctx.GetPlanCtx(), stmt, is)
+	if err != nil {
+		return nil, nil, err
+	}
+	b := newExecutorBuilder(sctx, is)
+	e := b.build(p)
+	if b.err != nil {
+		return nil, nil, b.err
+	}
+	fkTrigger, hasFK := e.(WithForeignKeyTrigger)
+	if hasFK && fkTrigger.HasFKCascades() {
+		return nil, nil, dbterror.ErrNotSupportedYet.GenWithStackByArgs("foreign key cascade in trigger")
+	}
+
+	if err := exec.Open(ctx, e); err != nil {
+		terror.Log(exec.Close(e))
+		return nil, nil, err
+	}
+	var rows []chunk.Row
+	for {
+		chk := exec.NewFirstChunk(e)
+		if err = exec.Next(ctx, e, chk); err != nil || chk.NumRows() == 0 {
+			break
+		}
+		for i := 0; i < chk.NumRows(); i++ {
+			rows = append(rows, chk.GetRow(i))
+		}
+	}
+	if closeErr := exec.Close(e); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return nil, nil, err
+	}
+	if hasFK {
+		for _, fkCheck := range fkTrigger.GetFKChecks() {
+			if err := fkCheck.doCheck(ctx); err != nil {
+				return nil, nil, err
+			}
+		}
+	}
+
+	switch stmt.(type) {
+	case *ast.SelectStmt, *ast.SetOprStmt:
+	default:
+		return nil, nil, nil
+	}
+	retTypes := exec.RetTypes(e)
+	fields := make([]*ast.ResultField, 0, len(names))
+	for i, name := range names {
+		fields = append(fields, &ast.ResultField{
+			Column:       &model.ColumnInfo{Name: name.ColName, FieldType: *retTypes[i]},
+			ColumnAsName: name.ColName,
+		})
+	}
+	return rows, fields, nil
+}
+
+// sqlModeString formats the sql mode saved with a trigger, the modes are listed in the order of their bits.
+func sqlModeString(mode mysql.SQLMode) string {
+	names := make([]string, 0, 8)
+	for bit := mysql.SQLMode(1); bit != 0 && bit <= mode; bit <<= 1 {
+		if mode&bit == 0 {
+			continue
+		}
+		for name, m := range mysql.Str2SQLMode {
+			if m == bit {
+				names = append(names, name)
+				break
+			}
+		}
+	}
+	return strings.Join(names, ",")
+}
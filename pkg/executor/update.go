@@ -72,6 +72,11 @@ type UpdateExec struct {
 	fkChecks map[int64][]*FKCheckExec
 	// fkCascades contains the foreign key cascade. the map is tableID -> []*FKCascadeExec
 	fkCascades map[int64][]*FKCascadeExec
+	// triggers contains the triggers of the updated tables. the map is tableID -> *tableTriggers
+	triggers map[int64]*tableTriggers
+	// mviewLogs contains the log tables of the materialized views reading the
+	// updated tables. the map is tableID -> *tableMViewLogs
+	mviewLogs map[int64]*tableMViewLogs
 }
 
 // prepare `handles`, `tableUpdatable`, `changed` to avoid re-computations.
@@ -202,6 +207,13 @@ func (e *UpdateExec) exec(ctx context.Context, _ *expression.Schema, row, newDat
 		newTableData := newData[content.Start:content.End]
 		flags := bAssignFlag[content.Start:content.End]
 
+		// The BEFORE UPDATE triggers can modify the new row, the generated columns
+		// are evaluated again after them.
+		triggers := e.triggers[content.TblID]
+		if err := triggers.fire(ctx, e.Ctx(), model.TriggerBefore, model.TriggerUpdate, oldData, newTableData, flags); err != nil {
+			return err
+		}
+
 		// Update row
 		fkChecks := e.fkChecks[content.TblID]
 		fkCascades := e.fkCascades[content.TblID]
@@ -213,6 +225,14 @@ func (e *UpdateExec) exec(ctx context.Context, _ *expression.Schema, row, newDat
 				memDelta += int64(handle.ExtraMemSize())
 			}
 			totalMemDelta += memDelta
+			if changed {
+				if err := e.mviewLogs[content.TblID].onUpdateRow(e.Ctx(), oldData, newTableData); err != nil {
+					return err
+				}
+			}
+			if err := triggers.fire(ctx, e.Ctx(), model.TriggerAfter, model.TriggerUpdate, oldData, newTableData, nil); err != nil {
+				return err
+			}
 			continue
 		}
 
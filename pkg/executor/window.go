@@ -26,6 +26,8 @@ import (
 	"github.com/pingcap/tidb/pkg/planner/core"
 	"github.com/pingcap/tidb/pkg/sessionctx"
 	"github.com/pingcap/tidb/pkg/util/chunk"
+	"github.com/pingcap/tidb/pkg/util/disk"
+	"github.com/pingcap/tidb/pkg/util/memory"
 )
 
 // WindowExec is the executor for window functions.
@@ -35,19 +37,55 @@ type WindowExec struct {
 	groupChecker *vecgroupchecker.VecGroupChecker
 	// childResult stores the child chunk
 	childResult *chunk.Chunk
+	// childResultStart is the number of the first row of childResult in rowBuffer.
+	childResultStart uint64
 	// executed indicates the child executor is drained or something unexpected happened.
 	executed bool
-	// resultChunks stores the chunks to return
+	// resultChunks stores the chunks to return, their columns of the child rows are filled
+	// from rowBuffer when they are returned.
 	resultChunks []*chunk.Chunk
 	// remainingRowsInChunk indicates how many rows the resultChunks[i] is not prepared.
 	remainingRowsInChunk []int
+	// rowBuffer buffers the child chunks of resultChunks, which can be spilled to disk.
+	rowBuffer *windowRowBuffer
+	// groupRows is the rows of the current partition.
+	groupRows windowRows
 
 	numWindowFuncs int
 	processor      windowProcessor
+
+	memTracker  *memory.Tracker
+	diskTracker *disk.Tracker
+	spillAction *windowSpillAction
+}
+
+// Open implements the Executor Open interface.
+func (e *WindowExec) Open(ctx context.Context) error {
+	e.executed = false
+	e.resultChunks = nil
+	e.remainingRowsInChunk = nil
+	if e.memTracker == nil {
+		e.memTracker = memory.NewTracker(e.ID(), -1)
+		e.memTracker.AttachTo(e.Ctx().GetSessionVars().StmtCtx.MemTracker)
+		e.diskTracker = disk.NewTracker(e.ID(), -1)
+		e.diskTracker.AttachTo(e.Ctx().GetSessionVars().StmtCtx.DiskTracker)
+	}
+	e.rowBuffer, e.spillAction = openWindowRowBuffer(e.Ctx(), exec.RetTypes(e.Children(0)), e.memTracker, e.diskTracker)
+	e.groupRows = windowRows{buffer: e.rowBuffer}
+	return e.BaseExecutor.Open(ctx)
 }
 
 // Close implements the Executor Close interface.
 func (e *WindowExec) Close() error {
+	if e.spillAction != nil {
+		e.spillAction.SetFinished()
+		e.spillAction = nil
+	}
+	if e.rowBuffer != nil {
+		e.rowBuffer.close()
+		e.rowBuffer = nil
+	}
+	e.resultChunks = nil
 	return errors.Trace(e.BaseExecutor.Close())
 }
 
@@ -62,6 +100,16 @@ func (e *WindowExec) Next(ctx context.Context, chk *chunk.Chunk) error {
 		}
 	}
 	if len(e.resultChunks) > 0 {
+		// The rows of the first chunk in rowBuffer aren't accessed anymore, so its columns can be
+		// returned directly.
+		childChk, err := e.rowBuffer.getChunk(e.rowBuffer.firstChunkIdx)
+		if err != nil {
+			return err
+		}
+		if err = e.copyChk(childChk, e.resultChunks[0]); err != nil {
+			return err
+		}
+		e.rowBuffer.releaseFirstChunk()
 		chk.SwapColumns(e.resultChunks[0])
 		e.resultChunks[0] = nil // GC it. TODO: Reuse it.
 		e.resultChunks = e.resultChunks[1:]
@@ -75,7 +123,7 @@ func (e *WindowExec) preparedChunkAvailable() bool {
 }
 
 func (e *WindowExec) consumeOneGroup(ctx context.Context) error {
-	var groupRows []chunk.Row
+	e.groupRows.reset(e.rowBuffer.numRows())
 	if e.groupChecker.IsExhausted() {
 		eof, err := e.fetchChild(ctx)
 		if err != nil {
@@ -83,7 +131,7 @@ func (e *WindowExec) consumeOneGroup(ctx context.Context) error {
 		}
 		if eof {
 			e.executed = true
-			return e.consumeGroupRows(groupRows)
+			return e.consumeGroupRows()
 		}
 		_, err = e.groupChecker.SplitIntoGroups(e.childResult)
 		if err != nil {
@@ -91,9 +139,8 @@ func (e *WindowExec) consumeOneGroup(ctx context.Context) error {
 		}
 	}
 	begin, end := e.groupChecker.GetNextGroup()
-	for i := begin; i < end; i++ {
-		groupRows = append(groupRows, e.childResult.GetRow(i))
-	}
+	e.groupRows.reset(e.childResultStart + uint64(begin))
+	e.groupRows.rowCnt = uint64(end - begin)
 
 	for meetLastGroup := end == e.childResult.NumRows(); meetLastGroup; {
 		meetLastGroup = false
@@ -103,7 +150,7 @@ func (e *WindowExec) consumeOneGroup(ctx context.Context) error {
 		}
 		if eof {
 			e.executed = true
-			return e.consumeGroupRows(groupRows)
+			return e.consumeGroupRows()
 		}
 
 		isFirstGroupSameAsPrev, err := e.groupChecker.SplitIntoGroups(e.childResult)
@@ -113,33 +160,28 @@ func (e *WindowExec) consumeOneGroup(ctx context.Context) error {
 
 		if isFirstGroupSameAsPrev {
 			begin, end = e.groupChecker.GetNextGroup()
-			for i := begin; i < end; i++ {
-				groupRows = append(groupRows, e.childResult.GetRow(i))
-			}
+			e.groupRows.rowCnt += uint64(end - begin)
 			meetLastGroup = end == e.childResult.NumRows()
 		}
 	}
-	return e.consumeGroupRows(groupRows)
+	return e.consumeGroupRows()
 }
 
-func (e *WindowExec) consumeGroupRows(groupRows []chunk.Row) (err error) {
-	remainingRowsInGroup := len(groupRows)
+func (e *WindowExec) consumeGroupRows() (err error) {
+	remainingRowsInGroup := int(e.groupRows.numRows())
 	if remainingRowsInGroup == 0 {
 		return nil
 	}
+	err = e.processor.consumeGroupRows(e.Ctx(), &e.groupRows)
+	if err != nil {
+		return errors.Trace(err)
+	}
 	for i := 0; i < len(e.resultChunks); i++ {
 		remained := min(e.remainingRowsInChunk[i], remainingRowsInGroup)
 		e.remainingRowsInChunk[i] -= remained
 		remainingRowsInGroup -= remained
 
-		// TODO: Combine these three methods.
-		// The old implementation needs the processor has these three methods
-		// but now it does not have to.
-		groupRows, err = e.processor.consumeGroupRows(e.Ctx(), groupRows)
-		if err != nil {
-			return errors.Trace(err)
-		}
-		_, err = e.processor.appendResult2Chunk(e.Ctx(), groupRows, e.resultChunks[i], remained)
+		err = e.processor.appendResult2Chunk(e.Ctx(), &e.groupRows, e.resultChunks[i], remained)
 		if err != nil {
 			return errors.Trace(err)
 		}
@@ -164,15 +206,12 @@ func (e *WindowExec) fetchChild(ctx context.Context) (eof bool, err error) {
 	}
 
 	resultChk := e.AllocPool.Alloc(e.RetFieldTypes(), 0, numRows)
-	err = e.copyChk(childResult, resultChk)
-	if err != nil {
-		return false, err
-	}
 	e.resultChunks = append(e.resultChunks, resultChk)
 	e.remainingRowsInChunk = append(e.remainingRowsInChunk, numRows)
 
 	e.childResult = childResult
-	return false, nil
+	e.childResultStart = e.rowBuffer.numRows()
+	return false, e.rowBuffer.add(childResult)
 }
 
 func (e *WindowExec) copyChk(src, dst *chunk.Chunk) error {
@@ -189,10 +228,10 @@ func (e *WindowExec) copyChk(src, dst *chunk.Chunk) error {
 type windowProcessor interface {
 	// consumeGroupRows updates the result for an window function using the input rows
 	// which belong to the same partition.
-	consumeGroupRows(ctx sessionctx.Context, rows []chunk.Row) ([]chunk.Row, error)
+	consumeGroupRows(ctx sessionctx.Context, rows *windowRows) error
 	// appendResult2Chunk appends the final results to chunk.
 	// It is called when there are no more rows in current partition.
-	appendResult2Chunk(ctx sessionctx.Context, rows []chunk.Row, chk *chunk.Chunk, remained int) ([]chunk.Row, error)
+	appendResult2Chunk(ctx sessionctx.Context, rows *windowRows, chk *chunk.Chunk, remained int) error
 	// resetPartialResult resets the partial result to the original state for a specific window function.
 	resetPartialResult()
 }
@@ -202,30 +241,38 @@ type aggWindowProcessor struct {
 	partialResults []aggfuncs.PartialResult
 }
 
-func (p *aggWindowProcessor) consumeGroupRows(ctx sessionctx.Context, rows []chunk.Row) ([]chunk.Row, error) {
-	for i, windowFunc := range p.windowFuncs {
-		// @todo Add memory trace
-		_, err := windowFunc.UpdatePartialResult(ctx.GetExprCtx().GetEvalCtx(), rows, p.partialResults[i])
+func (p *aggWindowProcessor) consumeGroupRows(ctx sessionctx.Context, rows *windowRows) error {
+	// The rows are consumed in batches, so only a part of them need to be restored if they are spilled.
+	batchSize := uint64(ctx.GetSessionVars().MaxChunkSize)
+	for start := uint64(0); start < rows.numRows(); start += batchSize {
+		batch, err := rows.getRows(start, min(start+batchSize, rows.numRows()))
 		if err != nil {
-			return nil, err
+			return err
 		}
+		for i, windowFunc := range p.windowFuncs {
+			// @todo Add memory trace
+			_, err = windowFunc.UpdatePartialResult(ctx.GetExprCtx().GetEvalCtx(), batch, p.partialResults[i])
+			if err != nil {
+				return err
+			}
+		}
+		rows.releaseRestored()
 	}
-	rows = rows[:0]
-	return rows, nil
+	return nil
 }
 
-func (p *aggWindowProcessor) appendResult2Chunk(ctx sessionctx.Context, rows []chunk.Row, chk *chunk.Chunk, remained int) ([]chunk.Row, error) {
+func (p *aggWindowProcessor) appendResult2Chunk(ctx sessionctx.Context, _ *windowRows, chk *chunk.Chunk, remained int) error {
 	for remained > 0 {
 		for i, windowFunc := range p.windowFuncs {
 			// TODO: We can extend the agg func interface to avoid the `for` loop  here.
 			err := windowFunc.AppendFinalResult2Chunk(ctx.GetExprCtx().GetEvalCtx(), p.partialResults[i], chk)
 			if err != nil {
-				return nil, err
+				return err
 			}
 		}
 		remained--
 	}
-	return rows, nil
+	return nil
 }
 
 func (p *aggWindowProcessor) resetPartialResult() {
@@ -288,88 +335,31 @@ func (p *rowFrameWindowProcessor) getEndOffset(numRows uint64) uint64 {
 	return 0
 }
 
-func (*rowFrameWindowProcessor) consumeGroupRows(_ sessionctx.Context, rows []chunk.Row) ([]chunk.Row, error) {
-	return rows, nil
+func (*rowFrameWindowProcessor) consumeGroupRows(sessionctx.Context, *windowRows) error {
+	return nil
 }
 
-func (p *rowFrameWindowProcessor) appendResult2Chunk(ctx sessionctx.Context, rows []chunk.Row, chk *chunk.Chunk, remained int) ([]chunk.Row, error) {
-	numRows := uint64(len(rows))
+func (p *rowFrameWindowProcessor) appendResult2Chunk(ctx sessionctx.Context, rows *windowRows, chk *chunk.Chunk, remained int) error {
+	numRows := rows.numRows()
 	var (
-		err                      error
-		initializedSlidingWindow bool
-		start                    uint64
-		end                      uint64
-		lastStart                uint64
-		lastEnd                  uint64
-		shiftStart               uint64
-		shiftEnd                 uint64
+		start     uint64
+		end       uint64
+		lastStart uint64
+		lastEnd   uint64
 	)
-	slidingWindowAggFuncs := make([]aggfuncs.SlidingWindowAggFunc, len(p.windowFuncs))
-	for i, windowFunc := range p.windowFuncs {
-		if slidingWindowAggFunc, ok := windowFunc.(aggfuncs.SlidingWindowAggFunc); ok {
-			slidingWindowAggFuncs[i] = slidingWindowAggFunc
-		}
-	}
+	frame := newWindowFrameUpdater(p.windowFuncs, p.partialResults, rows)
 	for ; remained > 0; lastStart, lastEnd = start, end {
 		start = p.getStartOffset(numRows)
 		end = p.getEndOffset(numRows)
 		p.curRowIdx++
 		remained--
-		shiftStart = start - lastStart
-		shiftEnd = end - lastEnd
-		if start >= end {
-			for i, windowFunc := range p.windowFuncs {
-				slidingWindowAggFunc := slidingWindowAggFuncs[i]
-				if slidingWindowAggFunc != nil && initializedSlidingWindow {
-					err = slidingWindowAggFunc.Slide(ctx.GetExprCtx().GetEvalCtx(), func(u uint64) chunk.Row {
-						return rows[u]
-					}, lastStart, lastEnd, shiftStart, shiftEnd, p.partialResults[i])
-					if err != nil {
-						return nil, err
-					}
-				}
-				err = windowFunc.AppendFinalResult2Chunk(ctx.GetExprCtx().GetEvalCtx(), p.partialResults[i], chk)
-				if err != nil {
-					return nil, err
-				}
-			}
-			continue
-		}
-
-		for i, windowFunc := range p.windowFuncs {
-			slidingWindowAggFunc := slidingWindowAggFuncs[i]
-			if slidingWindowAggFunc != nil && initializedSlidingWindow {
-				err = slidingWindowAggFunc.Slide(ctx.GetExprCtx().GetEvalCtx(), func(u uint64) chunk.Row {
-					return rows[u]
-				}, lastStart, lastEnd, shiftStart, shiftEnd, p.partialResults[i])
-			} else {
-				// For MinMaxSlidingWindowAggFuncs, it needs the absolute value of each start of window, to compare
-				// whether elements inside deque are out of current window.
-				if minMaxSlidingWindowAggFunc, ok := windowFunc.(aggfuncs.MaxMinSlidingWindowAggFunc); ok {
-					// Store start inside MaxMinSlidingWindowAggFunc.windowInfo
-					minMaxSlidingWindowAggFunc.SetWindowStart(start)
-				}
-				_, err = windowFunc.UpdatePartialResult(ctx.GetExprCtx().GetEvalCtx(), rows[start:end], p.partialResults[i])
-			}
-			if err != nil {
-				return nil, err
-			}
-			err = windowFunc.AppendFinalResult2Chunk(ctx.GetExprCtx().GetEvalCtx(), p.partialResults[i], chk)
-			if err != nil {
-				return nil, err
-			}
-			if slidingWindowAggFunc == nil {
-				windowFunc.ResetPartialResult(p.partialResults[i])
-			}
-		}
-		if !initializedSlidingWindow {
-			initializedSlidingWindow = true
+		if err := frame.appendResult(ctx, chk, start, end, lastStart, lastEnd); err != nil {
+			return err
 		}
+		rows.releaseRestored()
 	}
-	for i, windowFunc := range p.windowFuncs {
-		windowFunc.ResetPartialResult(p.partialResults[i])
-	}
-	return rows, nil
+	frame.resetPartialResult()
+	return nil
 }
 
 func (p *rowFrameWindowProcessor) resetPartialResult() {
@@ -389,16 +379,23 @@ type rangeFrameWindowProcessor struct {
 	expectedCmpResult int64
 }
 
-func (p *rangeFrameWindowProcessor) getStartOffset(ctx sessionctx.Context, rows []chunk.Row) (uint64, error) {
+func (p *rangeFrameWindowProcessor) getStartOffset(ctx sessionctx.Context, rows *windowRows) (uint64, error) {
 	if p.start.UnBounded {
 		return 0, nil
 	}
-	numRows := uint64(len(rows))
+	numRows := rows.numRows()
+	curRow, err := rows.getRow(p.curRowIdx)
+	if err != nil {
+		return 0, err
+	}
 	for ; p.lastStartOffset < numRows; p.lastStartOffset++ {
 		var res int64
-		var err error
+		startRow, err := rows.getRow(p.lastStartOffset)
+		if err != nil {
+			return 0, err
+		}
 		for i := range p.orderByCols {
-			res, _, err = p.start.CmpFuncs[i](ctx.GetExprCtx().GetEvalCtx(), p.start.CompareCols[i], p.start.CalcFuncs[i], rows[p.lastStartOffset], rows[p.curRowIdx])
+			res, _, err = p.start.CmpFuncs[i](ctx.GetExprCtx().GetEvalCtx(), p.start.CompareCols[i], p.start.CalcFuncs[i], startRow, curRow)
 			if err != nil {
 				return 0, err
 			}
@@ -415,16 +412,23 @@ func (p *rangeFrameWindowProcessor) getStartOffset(ctx sessionctx.Context, rows
 	return p.lastStartOffset, nil
 }
 
-func (p *rangeFrameWindowProcessor) getEndOffset(ctx sessionctx.Context, rows []chunk.Row) (uint64, error) {
-	numRows := uint64(len(rows))
+func (p *rangeFrameWindowProcessor) getEndOffset(ctx sessionctx.Context, rows *windowRows) (uint64, error) {
+	numRows := rows.numRows()
 	if p.end.UnBounded {
 		return numRows, nil
 	}
+	curRow, err := rows.getRow(p.curRowIdx)
+	if err != nil {
+		return 0, err
+	}
 	for ; p.lastEndOffset < numRows; p.lastEndOffset++ {
 		var res int64
-		var err error
+		endRow, err := rows.getRow(p.lastEndOffset)
+		if err != nil {
+			return 0, err
+		}
 		for i := range p.orderByCols {
-			res, _, err = p.end.CmpFuncs[i](ctx.GetExprCtx().GetEvalCtx(), p.end.CalcFuncs[i], p.end.CompareCols[i], rows[p.curRowIdx], rows[p.lastEndOffset])
+			res, _, err = p.end.CmpFuncs[i](ctx.GetExprCtx().GetEvalCtx(), p.end.CalcFuncs[i], p.end.CompareCols[i], curRow, endRow)
 			if err != nil {
 				return 0, err
 			}
@@ -441,94 +445,140 @@ func (p *rangeFrameWindowProcessor) getEndOffset(ctx sessionctx.Context, rows []
 	return p.lastEndOffset, nil
 }
 
-func (p *rangeFrameWindowProcessor) appendResult2Chunk(ctx sessionctx.Context, rows []chunk.Row, chk *chunk.Chunk, remained int) ([]chunk.Row, error) {
+func (p *rangeFrameWindowProcessor) appendResult2Chunk(ctx sessionctx.Context, rows *windowRows, chk *chunk.Chunk, remained int) error {
 	var (
-		err                      error
-		initializedSlidingWindow bool
-		start                    uint64
-		end                      uint64
-		lastStart                uint64
-		lastEnd                  uint64
-		shiftStart               uint64
-		shiftEnd                 uint64
+		err       error
+		start     uint64
+		end       uint64
+		lastStart uint64
+		lastEnd   uint64
 	)
-	slidingWindowAggFuncs := make([]aggfuncs.SlidingWindowAggFunc, len(p.windowFuncs))
-	for i, windowFunc := range p.windowFuncs {
-		if slidingWindowAggFunc, ok := windowFunc.(aggfuncs.SlidingWindowAggFunc); ok {
-			slidingWindowAggFuncs[i] = slidingWindowAggFunc
-		}
-	}
+	frame := newWindowFrameUpdater(p.windowFuncs, p.partialResults, rows)
 	for ; remained > 0; lastStart, lastEnd = start, end {
 		start, err = p.getStartOffset(ctx, rows)
 		if err != nil {
-			return nil, err
+			return err
 		}
 		end, err = p.getEndOffset(ctx, rows)
 		if err != nil {
-			return nil, err
+			return err
 		}
 		p.curRowIdx++
 		remained--
-		shiftStart = start - lastStart
-		shiftEnd = end - lastEnd
-		if start >= end {
-			for i, windowFunc := range p.windowFuncs {
-				slidingWindowAggFunc := slidingWindowAggFuncs[i]
-				if slidingWindowAggFunc != nil && initializedSlidingWindow {
-					err = slidingWindowAggFunc.Slide(ctx.GetExprCtx().GetEvalCtx(), func(u uint64) chunk.Row {
-						return rows[u]
-					}, lastStart, lastEnd, shiftStart, shiftEnd, p.partialResults[i])
-					if err != nil {
-						return nil, err
-					}
-				}
-				err = windowFunc.AppendFinalResult2Chunk(ctx.GetExprCtx().GetEvalCtx(), p.partialResults[i], chk)
+		if err = frame.appendResult(ctx, chk, start, end, lastStart, lastEnd); err != nil {
+			return err
+		}
+		rows.releaseRestored()
+	}
+	frame.resetPartialResult()
+	return nil
+}
+
+func (*rangeFrameWindowProcessor) consumeGroupRows(sessionctx.Context, *windowRows) error {
+	return nil
+}
+
+func (p *rangeFrameWindowProcessor) resetPartialResult() {
+	p.curRowIdx = 0
+	p.lastStartOffset = 0
+	p.lastEndOffset = 0
+}
+
+// windowFrameUpdater updates the partial results of the window functions when the frame moves, and
+// appends the results of the frames to the chunk.
+type windowFrameUpdater struct {
+	windowFuncs              []aggfuncs.AggFunc
+	slidingWindowAggFuncs    []aggfuncs.SlidingWindowAggFunc
+	partialResults           []aggfuncs.PartialResult
+	rows                     *windowRows
+	initializedSlidingWindow bool
+}
+
+func newWindowFrameUpdater(windowFuncs []aggfuncs.AggFunc, partialResults []aggfuncs.PartialResult, rows *windowRows) *windowFrameUpdater {
+	slidingWindowAggFuncs := make([]aggfuncs.SlidingWindowAggFunc, len(windowFuncs))
+	for i, windowFunc := range windowFuncs {
+		if slidingWindowAggFunc, ok := windowFunc.(aggfuncs.SlidingWindowAggFunc); ok {
+			slidingWindowAggFuncs[i] = slidingWindowAggFunc
+		}
+	}
+	return &windowFrameUpdater{
+		windowFuncs:           windowFuncs,
+		slidingWindowAggFuncs: slidingWindowAggFuncs,
+		partialResults:        partialResults,
+		rows:                  rows,
+	}
+}
+
+// appendResult appends the results of the frame [start, end) to the chunk, the last frame is [lastStart, lastEnd).
+func (f *windowFrameUpdater) appendResult(ctx sessionctx.Context, chk *chunk.Chunk, start, end, lastStart, lastEnd uint64) (err error) {
+	shiftStart := start - lastStart
+	shiftEnd := end - lastEnd
+	var getRow func(uint64) chunk.Row
+	if f.initializedSlidingWindow {
+		for _, slidingWindowAggFunc := range f.slidingWindowAggFuncs {
+			if slidingWindowAggFunc != nil {
+				getRow, err = f.rows.rowGetterForSlide(lastStart, lastEnd, shiftStart, shiftEnd)
 				if err != nil {
-					return nil, err
+					return err
 				}
+				break
 			}
-			continue
 		}
-
-		for i, windowFunc := range p.windowFuncs {
-			slidingWindowAggFunc := slidingWindowAggFuncs[i]
-			if slidingWindowAggFunc != nil && initializedSlidingWindow {
-				err = slidingWindowAggFunc.Slide(ctx.GetExprCtx().GetEvalCtx(), func(u uint64) chunk.Row {
-					return rows[u]
-				}, lastStart, lastEnd, shiftStart, shiftEnd, p.partialResults[i])
-			} else {
-				if minMaxSlidingWindowAggFunc, ok := windowFunc.(aggfuncs.MaxMinSlidingWindowAggFunc); ok {
-					minMaxSlidingWindowAggFunc.SetWindowStart(start)
+	}
+	if start >= end {
+		for i, windowFunc := range f.windowFuncs {
+			slidingWindowAggFunc := f.slidingWindowAggFuncs[i]
+			if slidingWindowAggFunc != nil && f.initializedSlidingWindow {
+				err = slidingWindowAggFunc.Slide(ctx.GetExprCtx().GetEvalCtx(), getRow, lastStart, lastEnd, shiftStart, shiftEnd, f.partialResults[i])
+				if err != nil {
+					return err
 				}
-				_, err = windowFunc.UpdatePartialResult(ctx.GetExprCtx().GetEvalCtx(), rows[start:end], p.partialResults[i])
 			}
+			err = windowFunc.AppendFinalResult2Chunk(ctx.GetExprCtx().GetEvalCtx(), f.partialResults[i], chk)
 			if err != nil {
-				return nil, err
+				return err
 			}
-			err = windowFunc.AppendFinalResult2Chunk(ctx.GetExprCtx().GetEvalCtx(), p.partialResults[i], chk)
-			if err != nil {
-				return nil, err
+		}
+		return nil
+	}
+
+	var frameRows []chunk.Row
+	for i, windowFunc := range f.windowFuncs {
+		slidingWindowAggFunc := f.slidingWindowAggFuncs[i]
+		if slidingWindowAggFunc != nil && f.initializedSlidingWindow {
+			err = slidingWindowAggFunc.Slide(ctx.GetExprCtx().GetEvalCtx(), getRow, lastStart, lastEnd, shiftStart, shiftEnd, f.partialResults[i])
+		} else {
+			// For MinMaxSlidingWindowAggFuncs, it needs the absolute value of each start of window, to compare
+			// whether elements inside deque are out of current window.
+			if minMaxSlidingWindowAggFunc, ok := windowFunc.(aggfuncs.MaxMinSlidingWindowAggFunc); ok {
+				// Store start inside MaxMinSlidingWindowAggFunc.windowInfo
+				minMaxSlidingWindowAggFunc.SetWindowStart(start)
 			}
-			if slidingWindowAggFunc == nil {
-				windowFunc.ResetPartialResult(p.partialResults[i])
+			if frameRows == nil {
+				frameRows, err = f.rows.getRows(start, end)
+				if err != nil {
+					return err
+				}
 			}
+			_, err = windowFunc.UpdatePartialResult(ctx.GetExprCtx().GetEvalCtx(), frameRows, f.partialResults[i])
+		}
+		if err != nil {
+			return err
 		}
-		if !initializedSlidingWindow {
-			initializedSlidingWindow = true
+		err = windowFunc.AppendFinalResult2Chunk(ctx.GetExprCtx().GetEvalCtx(), f.partialResults[i], chk)
+		if err != nil {
+			return err
+		}
+		if slidingWindowAggFunc == nil {
+			windowFunc.ResetPartialResult(f.partialResults[i])
 		}
 	}
-	for i, windowFunc := range p.windowFuncs {
-		windowFunc.ResetPartialResult(p.partialResults[i])
-	}
-	return rows, nil
-}
-
-func (*rangeFrameWindowProcessor) consumeGroupRows(_ sessionctx.Context, rows []chunk.Row) ([]chunk.Row, error) {
-	return rows, nil
+	f.initializedSlidingWindow = true
+	return nil
 }
 
-func (p *rangeFrameWindowProcessor) resetPartialResult() {
-	p.curRowIdx = 0
-	p.lastStartOffset = 0
-	p.lastEndOffset = 0
+func (f *windowFrameUpdater) resetPartialResult() {
+	for i, windowFunc := range f.windowFuncs {
+		windowFunc.ResetPartialResult(f.partialResults[i])
+	}
 }
@@ -0,0 +1,360 @@
+// Copyright 2024 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package executor
+
+import (
+	"sort"
+	"sync/atomic"
+
+	"github.com/pingcap/failpoint"
+	"github.com/pingcap/tidb/pkg/sessionctx"
+	"github.com/pingcap/tidb/pkg/sessionctx/variable"
+	"github.com/pingcap/tidb/pkg/types"
+	"github.com/pingcap/tidb/pkg/util/chunk"
+	"github.com/pingcap/tidb/pkg/util/disk"
+	"github.com/pingcap/tidb/pkg/util/logutil"
+	"github.com/pingcap/tidb/pkg/util/memory"
+	"go.uber.org/zap"
+)
+
+const windowSpillLogInfo = "memory exceeds quota, spill buffered rows of window to disk"
+
+// restoredWindowChunkNum is the number of the chunks restored from disk that are cached in windowRowBuffer.
+// The frames usually access the rows around the current row, so a few chunks are enough.
+const restoredWindowChunkNum = 2
+
+type restoredWindowChunk struct {
+	chkIdx   int
+	chk      *chunk.Chunk
+	memUsage int64
+}
+
+// windowRowBuffer buffers the child chunks of the window executors until the results of their rows are
+// returned. The rows are numbered by the order they are added to the buffer and can be accessed by their
+// numbers. When the memory quota of the query is exceeded, the buffered chunks are spilled to disk, and
+// they are restored when their rows are accessed.
+type windowRowBuffer struct {
+	fieldTypes  []*types.FieldType
+	memTracker  *memory.Tracker
+	diskTracker *disk.Tracker
+
+	// firstChunkIdx is the number of the chunks released from the buffer, chunks[i] is the
+	// (firstChunkIdx+i)-th chunk added to the buffer.
+	firstChunkIdx int
+	// chunks are the buffered chunks, a chunk is nil if it's spilled to disk.
+	chunks []*chunk.Chunk
+	// rowOffsets[i] is the number of the first row of chunks[i], the last element is the number of
+	// all the rows added to the buffer.
+	rowOffsets []uint64
+	memUsages  []int64
+	// diskChunkIdxes[i] is the index of chunks[i] in inDisk if it's spilled.
+	diskChunkIdxes []int
+	// spilledChunkNum is the number of the spilled chunks which are not released yet.
+	spilledChunkNum int
+	inDisk          *chunk.DataInDiskByChunks
+	restored        [restoredWindowChunkNum]restoredWindowChunk
+	nextRestored    int
+	// pinned are the restored chunks evicted from restored. The rows got before may still reference them,
+	// so they are tracked until releasePinned is called.
+	pinned []restoredWindowChunk
+	// lastChunkPos caches the position of the chunk found by the last lookup.
+	lastChunkPos int
+
+	needSpill atomic.Bool
+}
+
+func newWindowRowBuffer(fieldTypes []*types.FieldType, memTracker *memory.Tracker, diskTracker *disk.Tracker) *windowRowBuffer {
+	b := &windowRowBuffer{
+		fieldTypes:  fieldTypes,
+		memTracker:  memTracker,
+		diskTracker: diskTracker,
+		rowOffsets:  []uint64{0},
+	}
+	b.resetRestored()
+	return b
+}
+
+// openWindowRowBuffer creates the row buffer of a window executor, and registers its spill action if
+// spilling to disk is enabled.
+func openWindowRowBuffer(sctx sessionctx.Context, fieldTypes []*types.FieldType, memTracker *memory.Tracker, diskTracker *disk.Tracker) (*windowRowBuffer, *windowSpillAction) {
+	buffer := newWindowRowBuffer(fieldTypes, memTracker, diskTracker)
+	if !variable.EnableTmpStorageOnOOM.Load() {
+		return buffer, nil
+	}
+	action := &windowSpillAction{buffer: buffer}
+	sctx.GetSessionVars().MemTracker.FallbackOldAndSetNewAction(action)
+	return buffer, action
+}
+
+func (b *windowRowBuffer) resetRestored() {
+	for i := range b.restored {
+		b.memTracker.Consume(-b.restored[i].memUsage)
+		b.restored[i] = restoredWindowChunk{chkIdx: -1}
+	}
+	b.releasePinned()
+}
+
+// releasePinned releases the restored chunks evicted from the cache, the rows got before mustn't be
+// accessed anymore.
+func (b *windowRowBuffer) releasePinned() {
+	for i := range b.pinned {
+		b.memTracker.Consume(-b.pinned[i].memUsage)
+		b.pinned[i] = restoredWindowChunk{}
+	}
+	b.pinned = b.pinned[:0]
+}
+
+// numRows returns the number of all the rows added to the buffer.
+func (b *windowRowBuffer) numRows() uint64 {
+	return b.rowOffsets[len(b.rowOffsets)-1]
+}
+
+// add adds a chunk to the buffer, and spills the buffered chunks if needed.
+func (b *windowRowBuffer) add(chk *chunk.Chunk) error {
+	memUsage := chk.MemoryUsage()
+	b.chunks = append(b.chunks, chk)
+	b.rowOffsets = append(b.rowOffsets, b.numRows()+uint64(chk.NumRows()))
+	b.memUsages = append(b.memUsages, memUsage)
+	b.diskChunkIdxes = append(b.diskChunkIdxes, -1)
+	b.memTracker.Consume(memUsage)
+	failpoint.Inject("triggerWindowSpill", func(val failpoint.Value) {
+		if val.(bool) {
+			b.needSpill.Store(true)
+		}
+	})
+	if b.needSpill.Load() {
+		return b.spill()
+	}
+	return nil
+}
+
+// spill writes all the buffered chunks in memory to disk.
+func (b *windowRowBuffer) spill() error {
+	b.needSpill.Store(false)
+	if b.inDisk == nil {
+		b.inDisk = chunk.NewDataInDiskByChunks(b.fieldTypes)
+		b.inDisk.GetDiskTracker().AttachTo(b.diskTracker)
+	}
+	for i, chk := range b.chunks {
+		if chk == nil || chk.NumRows() == 0 {
+			continue
+		}
+		if err := b.inDisk.Add(chk); err != nil {
+			return err
+		}
+		b.chunks[i] = nil
+		b.diskChunkIdxes[i] = b.inDisk.NumChunks() - 1
+		b.spilledChunkNum++
+		b.memTracker.Consume(-b.memUsages[i])
+	}
+	return nil
+}
+
+// getChunk returns the chkIdx-th chunk added to the buffer.
+func (b *windowRowBuffer) getChunk(chkIdx int) (*chunk.Chunk, error) {
+	pos := chkIdx - b.firstChunkIdx
+	if chk := b.chunks[pos]; chk != nil {
+		return chk, nil
+	}
+	for _, restored := range b.restored {
+		if restored.chkIdx == chkIdx {
+			return restored.chk, nil
+		}
+	}
+	chk, err := b.inDisk.GetChunk(b.diskChunkIdxes[pos])
+	if err != nil {
+		return nil, err
+	}
+	evicted := &b.restored[b.nextRestored]
+	if evicted.chk != nil {
+		// A wide frame may access more chunks than the cache holds, so the evicted chunk is still tracked
+		// until the rows referencing it are done.
+		b.pinned = append(b.pinned, *evicted)
+	}
+	*evicted = restoredWindowChunk{chkIdx: chkIdx, chk: chk, memUsage: chk.MemoryUsage()}
+	b.memTracker.Consume(evicted.memUsage)
+	b.nextRestored = (b.nextRestored + 1) % restoredWindowChunkNum
+	return chk, nil
+}
+
+// locate returns the position in chunks of the chunk containing the row.
+func (b *windowRowBuffer) locate(rowIdx uint64) int {
+	pos := b.lastChunkPos
+	if pos < len(b.chunks) && b.rowOffsets[pos] <= rowIdx && rowIdx < b.rowOffsets[pos+1] {
+		return pos
+	}
+	pos = sort.Search(len(b.chunks), func(i int) bool {
+		return b.rowOffsets[i+1] > rowIdx
+	})
+	b.lastChunkPos = pos
+	return pos
+}
+
+// getRow returns the row numbered rowIdx.
+func (b *windowRowBuffer) getRow(rowIdx uint64) (chunk.Row, error) {
+	pos := b.locate(rowIdx)
+	chk, err := b.getChunk(b.firstChunkIdx + pos)
+	if err != nil {
+		return chunk.Row{}, err
+	}
+	return chk.GetRow(int(rowIdx - b.rowOffsets[pos])), nil
+}
+
+// appendRows appends the rows numbered in [start, end) to rows.
+func (b *windowRowBuffer) appendRows(rows []chunk.Row, start, end uint64) ([]chunk.Row, error) {
+	for start < end {
+		pos := b.locate(start)
+		chk, err := b.getChunk(b.firstChunkIdx + pos)
+		if err != nil {
+			return nil, err
+		}
+		chkEnd := min(end, b.rowOffsets[pos+1])
+		for i := start; i < chkEnd; i++ {
+			rows = append(rows, chk.GetRow(int(i-b.rowOffsets[pos])))
+		}
+		start = chkEnd
+	}
+	return rows, nil
+}
+
+// releaseFirstChunk releases the first chunk in the buffer, its rows mustn't be accessed anymore.
+func (b *windowRowBuffer) releaseFirstChunk() {
+	if b.chunks[0] != nil {
+		b.memTracker.Consume(-b.memUsages[0])
+	} else {
+		b.spilledChunkNum--
+	}
+	for i := range b.restored {
+		if b.restored[i].chkIdx == b.firstChunkIdx {
+			b.memTracker.Consume(-b.restored[i].memUsage)
+			b.restored[i] = restoredWindowChunk{chkIdx: -1}
+		}
+	}
+	b.chunks[0] = nil
+	b.chunks = b.chunks[1:]
+	b.rowOffsets = b.rowOffsets[1:]
+	b.memUsages = b.memUsages[1:]
+	b.diskChunkIdxes = b.diskChunkIdxes[1:]
+	b.firstChunkIdx++
+	b.lastChunkPos = 0
+	if b.spilledChunkNum == 0 && b.inDisk != nil {
+		// All the spilled chunks are released, so the disk file can be removed.
+		b.closeDisk()
+	}
+}
+
+func (b *windowRowBuffer) closeDisk() {
+	b.resetRestored()
+	b.inDisk.Close()
+	b.inDisk.GetDiskTracker().Detach()
+	b.inDisk = nil
+}
+
+func (b *windowRowBuffer) close() {
+	if b.inDisk != nil {
+		b.closeDisk()
+	}
+	b.chunks = nil
+	b.memTracker.ReplaceBytesUsed(0)
+}
+
+// windowRows is the rows of a partition buffered in windowRowBuffer. The rows are numbered from 0 in
+// the partition.
+type windowRows struct {
+	buffer *windowRowBuffer
+	// start is the number of the first row of the partition in the buffer.
+	start uint64
+	// rowCnt is the number of rows of the partition.
+	rowCnt uint64
+	// rows, removedRows and addedRows are reused to access the rows.
+	rows        []chunk.Row
+	removedRows []chunk.Row
+	addedRows   []chunk.Row
+}
+
+func (r *windowRows) reset(start uint64) {
+	r.start = start
+	r.rowCnt = 0
+}
+
+func (r *windowRows) numRows() uint64 {
+	return r.rowCnt
+}
+
+// releaseRestored releases the chunks restored from disk which are not cached anymore. It's called after
+// the result of a row is appended, the rows got before mustn't be accessed after it.
+func (r *windowRows) releaseRestored() {
+	r.buffer.releasePinned()
+}
+
+func (r *windowRows) getRow(idx uint64) (chunk.Row, error) {
+	return r.buffer.getRow(r.start + idx)
+}
+
+// getRows returns the rows in [start, end) of the partition. The returned slice is only valid before the
+// next call.
+func (r *windowRows) getRows(start, end uint64) ([]chunk.Row, error) {
+	var err error
+	r.rows, err = r.buffer.appendRows(r.rows[:0], r.start+start, r.start+end)
+	return r.rows, err
+}
+
+// rowGetterForSlide returns the function to get the rows used by SlidingWindowAggFunc.Slide, which
+// accesses the rows in [lastStart, lastStart+shiftStart) and [lastEnd, lastEnd+shiftEnd).
+func (r *windowRows) rowGetterForSlide(lastStart, lastEnd, shiftStart, shiftEnd uint64) (func(uint64) chunk.Row, error) {
+	var err error
+	r.removedRows, err = r.buffer.appendRows(r.removedRows[:0], r.start+lastStart, r.start+lastStart+shiftStart)
+	if err != nil {
+		return nil, err
+	}
+	r.addedRows, err = r.buffer.appendRows(r.addedRows[:0], r.start+lastEnd, r.start+lastEnd+shiftEnd)
+	if err != nil {
+		return nil, err
+	}
+	return func(u uint64) chunk.Row {
+		if u >= lastEnd && u < lastEnd+shiftEnd {
+			return r.addedRows[u-lastEnd]
+		}
+		return r.removedRows[u-lastStart]
+	}, nil
+}
+
+// windowSpillAction implements memory.ActionOnExceed for the window executors. It marks the buffer to
+// spill, and the buffered chunks are spilled when the next chunk is added.
+type windowSpillAction struct {
+	memory.BaseOOMAction
+	buffer *windowRowBuffer
+}
+
+// GetPriority get the priority of the Action.
+func (*windowSpillAction) GetPriority() int64 {
+	return memory.DefSpillPriority
+}
+
+// Action implements the memory.ActionOnExceed interface.
+func (a *windowSpillAction) Action(t *memory.Tracker) {
+	// Only spill when the buffered rows occupy enough memory, otherwise spilling them doesn't help.
+	if a.buffer.memTracker.BytesConsumed() >= t.GetBytesLimit()/10 {
+		if !a.buffer.needSpill.Swap(true) {
+			logutil.BgLogger().Info(windowSpillLogInfo, zap.Int64("consumed", t.BytesConsumed()), zap.Int64("quota", t.GetBytesLimit()))
+		}
+		return
+	}
+	if t.CheckExceed() {
+		if fallback := a.GetFallback(); fallback != nil {
+			fallback.Action(t)
+		}
+	}
+}
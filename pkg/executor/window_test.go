@@ -17,10 +17,13 @@ package executor_test
 import (
 	"context"
 	"fmt"
+	"strings"
 	"testing"
 
+	"github.com/pingcap/failpoint"
 	"github.com/pingcap/tidb/pkg/parser/mysql"
 	"github.com/pingcap/tidb/pkg/testkit"
+	"github.com/stretchr/testify/require"
 )
 
 func TestWindowFunctions(t *testing.T) {
@@ -237,6 +240,44 @@ func TestWindowFunctionsDataReference(t *testing.T) {
 		Check(testkit.Rows("1 1", "2 1", "3 1"))
 }
 
+func TestWindowFunctionsSpill(t *testing.T) {
+	store := testkit.CreateMockStore(t)
+	tk := testkit.NewTestKit(t, store)
+	tk.MustExec("use test")
+	tk.MustExec("drop table if exists t")
+	tk.MustExec("create table t(a int, b int, c int)")
+	values := make([]string, 0, 300)
+	for i := 0; i < 300; i++ {
+		values = append(values, fmt.Sprintf("(%d, %d, %d)", i%7, i, i%11))
+	}
+	tk.MustExec("insert into t values " + strings.Join(values, ","))
+	tk.MustExec("set @@tidb_window_concurrency = 1")
+	tk.Session().GetSessionVars().MaxChunkSize = 32
+
+	sqls := []string{
+		"select a, b, sum(c) over (partition by a) from t",
+		"select a, b, row_number() over (partition by a order by b), rank() over (partition by a order by c) from t",
+		"select a, b, sum(c) over (partition by a order by b rows between 3 preceding and 2 following) from t",
+		"select a, b, max(c) over (partition by a order by b rows between unbounded preceding and current row) from t",
+		"select a, b, count(c) over (partition by a order by b range between 10 preceding and 10 following) from t",
+		"select a, b, lead(c, 2) over (partition by a order by b), first_value(c) over (order by b rows between 5 preceding and current row) from t",
+		"select b, avg(c) over (order by b rows between 40 preceding and 40 following) from t",
+	}
+	for _, pipelined := range []string{"0", "1"} {
+		tk.MustExec("set @@tidb_enable_pipelined_window_function = " + pipelined)
+		expected := make([][][]any, 0, len(sqls))
+		for _, sql := range sqls {
+			expected = append(expected, tk.MustQuery(sql).Sort().Rows())
+		}
+		require.NoError(t, failpoint.Enable("github.com/pingcap/tidb/pkg/executor/triggerWindowSpill", "return(true)"))
+		for i, sql := range sqls {
+			tk.MustQuery(sql).Sort().Check(expected[i])
+			require.Greater(t, tk.Session().GetSessionVars().StmtCtx.DiskTracker.MaxConsumed(), int64(0), sql)
+		}
+		require.NoError(t, failpoint.Disable("github.com/pingcap/tidb/pkg/executor/triggerWindowSpill"))
+	}
+}
+
 func TestSlidingWindowFunctions(t *testing.T) {
 	store := testkit.CreateMockStore(t)
 	tk := testkit.NewTestKit(t, store)
@@ -154,6 +154,8 @@ func newReturnFieldTypeForBaseBuiltinFunc(funcName string, retType types.EvalTyp
 		fieldType = types.NewFieldTypeBuilder().SetType(mysql.TypeDuration).SetFlag(mysql.BinaryFlag).SetFlen(mysql.MaxDurationWidthWithFsp).SetDecimal(types.MaxFsp).BuildP()
 	case types.ETJson:
 		fieldType = types.NewFieldTypeBuilder().SetType(mysql.TypeJSON).SetFlag(mysql.BinaryFlag).SetFlen(mysql.MaxBlobWidth).SetCharset(mysql.DefaultCharset).SetCollate(mysql.DefaultCollationName).BuildP()
+	case types.ETVectorFloat32:
+		fieldType = types.NewFieldTypeBuilder().SetType(mysql.TypeTiDBVectorFloat32).SetFlag(mysql.BinaryFlag).SetFlen(types.UnspecifiedLength).BuildP()
 	}
 	if mysql.HasBinaryFlag(fieldType.GetFlag()) && fieldType.GetType() != mysql.TypeJSON {
 		fieldType.SetCharset(charset.CharsetBin)
@@ -202,6 +204,8 @@ func newBaseBuiltinFuncWithTp(ctx BuildContext, funcName string, args []Expressi
 			args[i] = WrapWithCastAsDuration(ctx, args[i])
 		case types.ETJson:
 			args[i] = WrapWithCastAsJSON(ctx, args[i])
+		case types.ETVectorFloat32:
+			args[i] = WrapWithCastAsVectorFloat32(ctx, args[i])
 		}
 	}
 
@@ -256,6 +260,8 @@ func newBaseBuiltinFuncWithFieldTypes(ctx BuildContext, funcName string, args []
 			args[i] = HandleBinaryLiteral(ctx, args[i], ec, funcName, false)
 		case types.ETJson:
 			args[i] = WrapWithCastAsJSON(ctx, args[i])
+		case types.ETVectorFloat32:
+			args[i] = WrapWithCastAsVectorFloat32(ctx, args[i])
 		// https://github.com/pingcap/tidb/issues/44196
 		// For decimal/datetime/timestamp/duration types, it is necessary to ensure that decimal are consistent with the output type,
 		// so adding a cast function here.
@@ -330,6 +336,10 @@ func (*baseBuiltinFunc) vecEvalJSON(EvalContext, *chunk.Chunk, *chunk.Column) er
 	return errors.Errorf("baseBuiltinFunc.vecEvalJSON() should never be called, please contact the TiDB team for help")
 }
 
+func (*baseBuiltinFunc) vecEvalVectorFloat32(EvalContext, *chunk.Chunk, *chunk.Column) error {
+	return errors.Errorf("baseBuiltinFunc.vecEvalVectorFloat32() should never be called, please contact the TiDB team for help")
+}
+
 func (*baseBuiltinFunc) evalInt(EvalContext, chunk.Row) (int64, bool, error) {
 	return 0, false, errors.Errorf("baseBuiltinFunc.evalInt() should never be called, please contact the TiDB team for help")
 }
@@ -358,6 +368,10 @@ func (*baseBuiltinFunc) evalJSON(EvalContext, chunk.Row) (types.BinaryJSON, bool
 	return types.BinaryJSON{}, false, errors.Errorf("baseBuiltinFunc.evalJSON() should never be called, please contact the TiDB team for help")
 }
 
+func (*baseBuiltinFunc) evalVectorFloat32(EvalContext, chunk.Row) (types.VectorFloat32, bool, error) {
+	return types.ZeroVectorFloat32, false, errors.Errorf("baseBuiltinFunc.evalVectorFloat32() should never be called, please contact the TiDB team for help")
+}
+
 func (*baseBuiltinFunc) vectorized() bool {
 	return false
 }
@@ -476,6 +490,9 @@ type vecBuiltinFunc interface {
 
 	// vecEvalJSON evaluates this builtin function in a vectorized manner.
 	vecEvalJSON(ctx EvalContext, input *chunk.Chunk, result *chunk.Column) error
+
+	// vecEvalVectorFloat32 evaluates this builtin function in a vectorized manner.
+	vecEvalVectorFloat32(ctx EvalContext, input *chunk.Chunk, result *chunk.Column) error
 }
 
 // builtinFunc stands for a particular function signature.
@@ -497,6 +514,8 @@ type builtinFunc interface {
 	evalDuration(ctx EvalContext, row chunk.Row) (val types.Duration, isNull bool, err error)
 	// evalJSON evaluates JSON representation of builtinFunc by given row.
 	evalJSON(ctx EvalContext, row chunk.Row) (val types.BinaryJSON, isNull bool, err error)
+	// evalVectorFloat32 evaluates VectorFloat32 representation of builtinFunc by given row.
+	evalVectorFloat32(ctx EvalContext, row chunk.Row) (val types.VectorFloat32, isNull bool, err error)
 	// getArgs returns the arguments expressions.
 	getArgs() []Expression
 	// equal check if this function equals to another function.
@@ -910,6 +929,48 @@ var funcs = map[string]functionClass{
 	ast.JSONKeys:          &jsonKeysFunctionClass{baseFunctionClass{ast.JSONKeys, 1, 2}},
 	ast.JSONLength:        &jsonLengthFunctionClass{baseFunctionClass{ast.JSONLength, 1, 2}},
 
+	// vector functions (tidb extension)
+	ast.VecDims:                 &vecDimsFunctionClass{baseFunctionClass{ast.VecDims, 1, 1}},
+	ast.VecL1Distance:           &vecL1DistanceFunctionClass{baseFunctionClass{ast.VecL1Distance, 2, 2}},
+	ast.VecL2Distance:           &vecL2DistanceFunctionClass{baseFunctionClass{ast.VecL2Distance, 2, 2}},
+	ast.VecNegativeInnerProduct: &vecNegativeInnerProductFunctionClass{baseFunctionClass{ast.VecNegativeInnerProduct, 2, 2}},
+	ast.VecCosineDistance:       &vecCosineDistanceFunctionClass{baseFunctionClass{ast.VecCosineDistance, 2, 2}},
+	ast.VecL2Norm:               &vecL2NormFunctionClass{baseFunctionClass{ast.VecL2Norm, 1, 1}},
+	ast.VecNorm:                 &vecL2NormFunctionClass{baseFunctionClass{ast.VecNorm, 1, 1}},
+	ast.VecFromText:             &vecFromTextFunctionClass{baseFunctionClass{ast.VecFromText, 1, 1}},
+	ast.VecAsText:               &vecAsTextFunctionClass{baseFunctionClass{ast.VecAsText, 1, 1}},
+
+	// spatial functions
+	ast.STGeomFromText:       &geomFromTextFunctionClass{baseFunctionClass{ast.STGeomFromText, 1, 2}, mysql.GeometryTypeGeometry},
+	ast.STGeometryFromText:   &geomFromTextFunctionClass{baseFunctionClass{ast.STGeometryFromText, 1, 2}, mysql.GeometryTypeGeometry},
+	ast.STPointFromText:      &geomFromTextFunctionClass{baseFunctionClass{ast.STPointFromText, 1, 2}, mysql.GeometryTypePoint},
+	ast.STLineFromText:       &geomFromTextFunctionClass{baseFunctionClass{ast.STLineFromText, 1, 2}, mysql.GeometryTypeLineString},
+	ast.STLineStringFromText: &geomFromTextFunctionClass{baseFunctionClass{ast.STLineStringFromText, 1, 2}, mysql.GeometryTypeLineString},
+	ast.STPolyFromText:       &geomFromTextFunctionClass{baseFunctionClass{ast.STPolyFromText, 1, 2}, mysql.GeometryTypePolygon},
+	ast.STPolygonFromText:    &geomFromTextFunctionClass{baseFunctionClass{ast.STPolygonFromText, 1, 2}, mysql.GeometryTypePolygon},
+	ast.STGeomFromWKB:        &geomFromWKBFunctionClass{baseFunctionClass{ast.STGeomFromWKB, 1, 2}},
+	ast.STGeometryFromWKB:    &geomFromWKBFunctionClass{baseFunctionClass{ast.STGeometryFromWKB, 1, 2}},
+	ast.Point:                &pointFunctionClass{baseFunctionClass{ast.Point, 2, 2}},
+	ast.LineString:           &geomFromComponentsFunctionClass{baseFunctionClass{ast.LineString, 2, -1}, mysql.GeometryTypeLineString},
+	ast.Polygon:              &geomFromComponentsFunctionClass{baseFunctionClass{ast.Polygon, 1, -1}, mysql.GeometryTypePolygon},
+	ast.STAsText:             &stAsTextFunctionClass{baseFunctionClass{ast.STAsText, 1, 1}},
+	ast.STAsWKT:              &stAsTextFunctionClass{baseFunctionClass{ast.STAsWKT, 1, 1}},
+	ast.STAsBinary:           &stAsBinaryFunctionClass{baseFunctionClass{ast.STAsBinary, 1, 1}},
+	ast.STAsWKB:              &stAsBinaryFunctionClass{baseFunctionClass{ast.STAsWKB, 1, 1}},
+	ast.STSRID:               &stSRIDFunctionClass{baseFunctionClass{ast.STSRID, 1, 2}},
+	ast.STX:                  &stCoordinateFunctionClass{baseFunctionClass{ast.STX, 1, 1}, true},
+	ast.STY:                  &stCoordinateFunctionClass{baseFunctionClass{ast.STY, 1, 1}, false},
+	ast.STGeometryType:       &stGeometryTypeFunctionClass{baseFunctionClass{ast.STGeometryType, 1, 1}},
+	ast.STContains:           &spatialRelationFunctionClass{baseFunctionClass{ast.STContains, 2, 2}, spatialRelationContains},
+	ast.STWithin:             &spatialRelationFunctionClass{baseFunctionClass{ast.STWithin, 2, 2}, spatialRelationWithin},
+	ast.STIntersects:         &spatialRelationFunctionClass{baseFunctionClass{ast.STIntersects, 2, 2}, spatialRelationIntersects},
+	ast.STDisjoint:           &spatialRelationFunctionClass{baseFunctionClass{ast.STDisjoint, 2, 2}, spatialRelationDisjoint},
+	ast.STDistance:           &stDistanceFunctionClass{baseFunctionClass{ast.STDistance, 2, 2}},
+	ast.STDistanceSphere:     &stDistanceSphereFunctionClass{baseFunctionClass{ast.STDistanceSphere, 2, 3}},
+
+	// fulltext search function (tidb internal)
+	ast.FTSMatchAgainst: &ftsMatchAgainstFunctionClass{baseFunctionClass{ast.FTSMatchAgainst, 4, -1}},
+
 	// TiDB internal function.
 	ast.TiDBDecodeKey: &tidbDecodeKeyFunctionClass{baseFunctionClass{ast.TiDBDecodeKey, 1, 1}},
 	// This function is used to show tidb-server version info.
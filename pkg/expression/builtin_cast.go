@@ -29,6 +29,7 @@ import (
 	"strings"
 	gotime "time"
 
+	"github.com/pingcap/errors"
 	"github.com/pingcap/tidb/pkg/parser/ast"
 	"github.com/pingcap/tidb/pkg/parser/charset"
 	"github.com/pingcap/tidb/pkg/parser/model"
@@ -106,6 +107,13 @@ var (
 	_ builtinFunc = &builtinCastJSONAsTimeSig{}
 	_ builtinFunc = &builtinCastJSONAsDurationSig{}
 	_ builtinFunc = &builtinCastJSONAsJSONSig{}
+
+	_ builtinFunc = &builtinCastStringAsVectorFloat32Sig{}
+	_ builtinFunc = &builtinCastJSONAsVectorFloat32Sig{}
+	_ builtinFunc = &builtinCastVectorFloat32AsVectorFloat32Sig{}
+	_ builtinFunc = &builtinCastVectorFloat32AsStringSig{}
+	_ builtinFunc = &builtinCastVectorFloat32AsUnsupportedSig{}
+	_ builtinFunc = &builtinCastUnsupportedAsVectorFloat32Sig{}
 )
 
 type castAsIntFunctionClass struct {
@@ -152,6 +160,9 @@ func (c *castAsIntFunctionClass) getFunction(ctx BuildContext, args []Expression
 	case types.ETString:
 		sig = &builtinCastStringAsIntSig{bf}
 		sig.setPbCode(tipb.ScalarFuncSig_CastStringAsInt)
+	case types.ETVectorFloat32:
+		sig = &builtinCastVectorFloat32AsUnsupportedSig{bf.baseBuiltinFunc}
+		sig.setPbCode(tipb.ScalarFuncSig_Unspecified)
 	default:
 		panic("unsupported types.EvalType in castAsIntFunctionClass")
 	}
@@ -208,6 +219,9 @@ func (c *castAsRealFunctionClass) getFunction(ctx BuildContext, args []Expressio
 	case types.ETString:
 		sig = &builtinCastStringAsRealSig{bf}
 		sig.setPbCode(tipb.ScalarFuncSig_CastStringAsReal)
+	case types.ETVectorFloat32:
+		sig = &builtinCastVectorFloat32AsUnsupportedSig{bf.baseBuiltinFunc}
+		sig.setPbCode(tipb.ScalarFuncSig_Unspecified)
 	default:
 		panic("unsupported types.EvalType in castAsRealFunctionClass")
 	}
@@ -263,6 +277,9 @@ func (c *castAsDecimalFunctionClass) getFunction(ctx BuildContext, args []Expres
 	case types.ETString:
 		sig = &builtinCastStringAsDecimalSig{bf}
 		sig.setPbCode(tipb.ScalarFuncSig_CastStringAsDecimal)
+	case types.ETVectorFloat32:
+		sig = &builtinCastVectorFloat32AsUnsupportedSig{bf.baseBuiltinFunc}
+		sig.setPbCode(tipb.ScalarFuncSig_Unspecified)
 	default:
 		panic("unsupported types.EvalType in castAsDecimalFunctionClass")
 	}
@@ -325,6 +342,9 @@ func (c *castAsStringFunctionClass) getFunction(ctx BuildContext, args []Express
 	case types.ETJson:
 		sig = &builtinCastJSONAsStringSig{bf}
 		sig.setPbCode(tipb.ScalarFuncSig_CastJsonAsString)
+	case types.ETVectorFloat32:
+		sig = &builtinCastVectorFloat32AsStringSig{bf}
+		sig.setPbCode(tipb.ScalarFuncSig_CastVectorFloat32AsString)
 	case types.ETString:
 		// When cast from binary to some other charsets, we should check if the binary is valid or not.
 		// so we build a from_binary function to do this check.
@@ -374,6 +394,9 @@ func (c *castAsTimeFunctionClass) getFunction(ctx BuildContext, args []Expressio
 	case types.ETString:
 		sig = &builtinCastStringAsTimeSig{bf}
 		sig.setPbCode(tipb.ScalarFuncSig_CastStringAsTime)
+	case types.ETVectorFloat32:
+		sig = &builtinCastVectorFloat32AsUnsupportedSig{bf}
+		sig.setPbCode(tipb.ScalarFuncSig_Unspecified)
 	default:
 		panic("unsupported types.EvalType in castAsTimeFunctionClass")
 	}
@@ -417,6 +440,9 @@ func (c *castAsDurationFunctionClass) getFunction(ctx BuildContext, args []Expre
 	case types.ETString:
 		sig = &builtinCastStringAsDurationSig{bf}
 		sig.setPbCode(tipb.ScalarFuncSig_CastStringAsDuration)
+	case types.ETVectorFloat32:
+		sig = &builtinCastVectorFloat32AsUnsupportedSig{bf}
+		sig.setPbCode(tipb.ScalarFuncSig_Unspecified)
 	default:
 		panic("unsupported types.EvalType in castAsDurationFunctionClass")
 	}
@@ -618,12 +644,47 @@ func (c *castAsJSONFunctionClass) getFunction(ctx BuildContext, args []Expressio
 		sig = &builtinCastStringAsJSONSig{bf}
 		sig.getRetTp().AddFlag(mysql.ParseToJSONFlag)
 		sig.setPbCode(tipb.ScalarFuncSig_CastStringAsJson)
+	case types.ETVectorFloat32:
+		sig = &builtinCastVectorFloat32AsUnsupportedSig{bf}
+		sig.setPbCode(tipb.ScalarFuncSig_Unspecified)
 	default:
 		panic("unsupported types.EvalType in castAsJSONFunctionClass")
 	}
 	return sig, nil
 }
 
+type castAsVectorFloat32FunctionClass struct {
+	baseFunctionClass
+
+	tp *types.FieldType
+}
+
+func (c *castAsVectorFloat32FunctionClass) getFunction(ctx BuildContext, args []Expression) (sig builtinFunc, err error) {
+	if err := c.verifyArgs(args); err != nil {
+		return nil, err
+	}
+	bf, err := newBaseBuiltinFunc(ctx, c.funcName, args, c.tp)
+	if err != nil {
+		return nil, err
+	}
+	argTp := args[0].GetType(ctx.GetEvalCtx()).EvalType()
+	switch argTp {
+	case types.ETVectorFloat32:
+		sig = &builtinCastVectorFloat32AsVectorFloat32Sig{bf}
+		sig.setPbCode(tipb.ScalarFuncSig_CastVectorFloat32AsVectorFloat32)
+	case types.ETString:
+		sig = &builtinCastStringAsVectorFloat32Sig{bf}
+		sig.setPbCode(tipb.ScalarFuncSig_CastStringAsVectorFloat32)
+	case types.ETJson:
+		sig = &builtinCastJSONAsVectorFloat32Sig{bf}
+		sig.setPbCode(tipb.ScalarFuncSig_Unspecified)
+	default:
+		sig = &builtinCastUnsupportedAsVectorFloat32Sig{bf}
+		sig.setPbCode(tipb.ScalarFuncSig_Unspecified)
+	}
+	return sig, nil
+}
+
 type builtinCastIntAsIntSig struct {
 	baseBuiltinCastFunc
 }
@@ -1824,6 +1885,155 @@ func (b *builtinCastJSONAsJSONSig) evalJSON(ctx EvalContext, row chunk.Row) (val
 	return b.args[0].EvalJSON(ctx, row)
 }
 
+type builtinCastVectorFloat32AsVectorFloat32Sig struct {
+	baseBuiltinFunc
+}
+
+func (b *builtinCastVectorFloat32AsVectorFloat32Sig) Clone() builtinFunc {
+	newSig := &builtinCastVectorFloat32AsVectorFloat32Sig{}
+	newSig.cloneFrom(&b.baseBuiltinFunc)
+	return newSig
+}
+
+func (b *builtinCastVectorFloat32AsVectorFloat32Sig) evalVectorFloat32(ctx EvalContext, row chunk.Row) (types.VectorFloat32, bool, error) {
+	val, isNull, err := b.args[0].EvalVectorFloat32(ctx, row)
+	if isNull || err != nil {
+		return val, isNull, err
+	}
+	if err = val.CheckDimsFitColumn(b.tp.GetFlen()); err != nil {
+		return types.ZeroVectorFloat32, false, err
+	}
+	return val, false, nil
+}
+
+type builtinCastStringAsVectorFloat32Sig struct {
+	baseBuiltinFunc
+}
+
+func (b *builtinCastStringAsVectorFloat32Sig) Clone() builtinFunc {
+	newSig := &builtinCastStringAsVectorFloat32Sig{}
+	newSig.cloneFrom(&b.baseBuiltinFunc)
+	return newSig
+}
+
+func (b *builtinCastStringAsVectorFloat32Sig) evalVectorFloat32(ctx EvalContext, row chunk.Row) (types.VectorFloat32, bool, error) {
+	val, isNull, err := b.args[0].EvalString(ctx, row)
+	if isNull || err != nil {
+		return types.ZeroVectorFloat32, isNull, err
+	}
+	vec, err := types.ParseVectorFloat32(val)
+	if err != nil {
+		return types.ZeroVectorFloat32, false, err
+	}
+	if err = vec.CheckDimsFitColumn(b.tp.GetFlen()); err != nil {
+		return types.ZeroVectorFloat32, false, err
+	}
+	return vec, false, nil
+}
+
+type builtinCastJSONAsVectorFloat32Sig struct {
+	baseBuiltinFunc
+}
+
+func (b *builtinCastJSONAsVectorFloat32Sig) Clone() builtinFunc {
+	newSig := &builtinCastJSONAsVectorFloat32Sig{}
+	newSig.cloneFrom(&b.baseBuiltinFunc)
+	return newSig
+}
+
+func (b *builtinCastJSONAsVectorFloat32Sig) evalVectorFloat32(ctx EvalContext, row chunk.Row) (types.VectorFloat32, bool, error) {
+	val, isNull, err := b.args[0].EvalJSON(ctx, row)
+	if isNull || err != nil {
+		return types.ZeroVectorFloat32, isNull, err
+	}
+	vec, err := types.ConvertJSONToVectorFloat32(val)
+	if err != nil {
+		return types.ZeroVectorFloat32, false, err
+	}
+	if err = vec.CheckDimsFitColumn(b.tp.GetFlen()); err != nil {
+		return types.ZeroVectorFloat32, false, err
+	}
+	return vec, false, nil
+}
+
+type builtinCastUnsupportedAsVectorFloat32Sig struct {
+	baseBuiltinFunc
+}
+
+func (b *builtinCastUnsupportedAsVectorFloat32Sig) Clone() builtinFunc {
+	newSig := &builtinCastUnsupportedAsVectorFloat32Sig{}
+	newSig.cloneFrom(&b.baseBuiltinFunc)
+	return newSig
+}
+
+func (b *builtinCastUnsupportedAsVectorFloat32Sig) evalVectorFloat32(ctx EvalContext, _ chunk.Row) (types.VectorFloat32, bool, error) {
+	return types.ZeroVectorFloat32, false, errors.Errorf(
+		"cannot cast from %s to vector",
+		types.TypeStr(b.args[0].GetType(ctx).GetType()))
+}
+
+type builtinCastVectorFloat32AsStringSig struct {
+	baseBuiltinFunc
+}
+
+func (b *builtinCastVectorFloat32AsStringSig) Clone() builtinFunc {
+	newSig := &builtinCastVectorFloat32AsStringSig{}
+	newSig.cloneFrom(&b.baseBuiltinFunc)
+	return newSig
+}
+
+func (b *builtinCastVectorFloat32AsStringSig) evalString(ctx EvalContext, row chunk.Row) (string, bool, error) {
+	val, isNull, err := b.args[0].EvalVectorFloat32(ctx, row)
+	if isNull || err != nil {
+		return "", isNull, err
+	}
+	res, err := types.ProduceStrWithSpecifiedTp(val.String(), b.tp, typeCtx(ctx), false)
+	if err != nil {
+		return res, false, err
+	}
+	return padZeroForBinaryType(res, b.tp, ctx)
+}
+
+// builtinCastVectorFloat32AsUnsupportedSig is used for the casts from VECTOR
+// to the types which a vector can not be converted to, e.g. INT or DATETIME.
+type builtinCastVectorFloat32AsUnsupportedSig struct {
+	baseBuiltinFunc
+}
+
+func (b *builtinCastVectorFloat32AsUnsupportedSig) Clone() builtinFunc {
+	newSig := &builtinCastVectorFloat32AsUnsupportedSig{}
+	newSig.cloneFrom(&b.baseBuiltinFunc)
+	return newSig
+}
+
+func (b *builtinCastVectorFloat32AsUnsupportedSig) unsupportedErr() error {
+	return errors.Errorf("cannot cast from vector to %s", types.TypeStr(b.tp.GetType()))
+}
+
+func (b *builtinCastVectorFloat32AsUnsupportedSig) evalInt(EvalContext, chunk.Row) (int64, bool, error) {
+	return 0, false, b.unsupportedErr()
+}
+
+func (b *builtinCastVectorFloat32AsUnsupportedSig) evalReal(EvalContext, chunk.Row) (float64, bool, error) {
+	return 0, false, b.unsupportedErr()
+}
+
+func (b *builtinCastVectorFloat32AsUnsupportedSig) evalDecimal(EvalContext, chunk.Row) (*types.MyDecimal, bool, error) {
+	return nil, false, b.unsupportedErr()
+}
+
+func (b *builtinCastVectorFloat32AsUnsupportedSig) evalTime(EvalContext, chunk.Row) (types.Time, bool, error) {
+	return types.ZeroTime, false, b.unsupportedErr()
+}
+
+func (b *builtinCastVectorFloat32AsUnsupportedSig) evalDuration(EvalContext, chunk.Row) (types.Duration, bool, error) {
+	return types.ZeroDuration, false, b.unsupportedErr()
+}
+
+func (b *builtinCastVectorFloat32AsUnsupportedSig) evalJSON(EvalContext, chunk.Row) (types.BinaryJSON, bool, error) {
+	return types.BinaryJSON{}, false, b.unsupportedErr()
+}
+
 type builtinCastJSONAsIntSig struct {
 	baseBuiltinCastFunc
 }
@@ -2128,6 +2338,8 @@ func BuildCastFunctionWithCheck(ctx BuildContext, expr Expression, tp *types.Fie
 		if expr.GetType(ctx.GetEvalCtx()).GetType() == mysql.TypeBit {
 			tp.SetFlen((expr.GetType(ctx.GetEvalCtx()).GetFlen() + 7) / 8)
 		}
+	case types.ETVectorFloat32:
+		fc = &castAsVectorFloat32FunctionClass{baseFunctionClass{ast.Cast, 1, 1}, tp}
 	}
 	f, err := fc.getFunction(ctx, []Expression{expr})
 	res = &ScalarFunction{
@@ -2258,7 +2470,8 @@ func WrapWithCastAsString(ctx BuildContext, expr Expression) Expression {
 	}
 
 	// Because we can't control the length of cast(float as char) for now, we can't determine the argLen.
-	if exprTp.GetType() == mysql.TypeFloat || exprTp.GetType() == mysql.TypeDouble {
+	// So does the text representation of a vector.
+	if exprTp.GetType() == mysql.TypeFloat || exprTp.GetType() == mysql.TypeDouble || exprTp.GetType() == mysql.TypeTiDBVectorFloat32 {
 		argLen = -1
 	}
 	tp := types.NewFieldType(mysql.TypeVarString)
@@ -2346,6 +2559,17 @@ func WrapWithCastAsJSON(ctx BuildContext, expr Expression) Expression {
 	return BuildCastFunction(ctx, expr, tp)
 }
 
+// WrapWithCastAsVectorFloat32 wraps `expr` with `cast` if the return type of
+// expr is not type VectorFloat32, otherwise, returns `expr` directly.
+func WrapWithCastAsVectorFloat32(ctx BuildContext, expr Expression) Expression {
+	if expr.GetType(ctx.GetEvalCtx()).EvalType() == types.ETVectorFloat32 {
+		return expr
+	}
+	tp := types.NewFieldTypeBuilder().SetType(mysql.TypeTiDBVectorFloat32).SetFlag(mysql.BinaryFlag).SetFlen(types.UnspecifiedLength).BuildP()
+	types.SetBinChsClnFlag(tp)
+	return BuildCastFunction(ctx, expr, tp)
+}
+
 // TryPushCastIntoControlFunctionForHybridType try to push cast into control function for Hybrid Type.
 // If necessary, it will rebuild control function using changed args.
 // When a hybrid type is the output of a control function, the result may be as a numeric type to subsequent calculation
@@ -166,6 +166,9 @@ func (c *coalesceFunctionClass) getFunction(ctx BuildContext, args []Expression)
 	case types.ETJson:
 		sig = &builtinCoalesceJSONSig{bf}
 		sig.setPbCode(tipb.ScalarFuncSig_CoalesceJson)
+	case types.ETVectorFloat32:
+		sig = &builtinCoalesceVectorFloat32Sig{bf}
+		sig.setPbCode(tipb.ScalarFuncSig_CoalesceVectorFloat32)
 	}
 
 	return sig, nil
@@ -328,6 +331,27 @@ func (b *builtinCoalesceJSONSig) evalJSON(ctx EvalContext, row chunk.Row) (res t
 	return res, isNull, err
 }
 
+// builtinCoalesceVectorFloat32Sig is builtin function coalesce signature which return type vector.
+type builtinCoalesceVectorFloat32Sig struct {
+	baseBuiltinFunc
+}
+
+func (b *builtinCoalesceVectorFloat32Sig) Clone() builtinFunc {
+	newSig := &builtinCoalesceVectorFloat32Sig{}
+	newSig.cloneFrom(&b.baseBuiltinFunc)
+	return newSig
+}
+
+func (b *builtinCoalesceVectorFloat32Sig) evalVectorFloat32(ctx EvalContext, row chunk.Row) (res types.VectorFloat32, isNull bool, err error) {
+	for _, a := range b.getArgs() {
+		res, isNull, err = a.EvalVectorFloat32(ctx, row)
+		if err != nil || !isNull {
+			break
+		}
+	}
+	return res, isNull, err
+}
+
 func aggregateType(ctx EvalContext, args []Expression) *types.FieldType {
 	fieldTypes := make([]*types.FieldType, len(args))
 	for i := range fieldTypes {
@@ -446,6 +470,9 @@ func (c *greatestFunctionClass) getFunction(ctx BuildContext, args []Expression)
 	}
 	resFieldType, fieldTimeType, cmpStringMode := resolveType4Extremum(ctx.GetEvalCtx(), args)
 	resTp := resFieldType.EvalType()
+	if resTp == types.ETVectorFloat32 {
+		return nil, ErrNotSupportedYet.GenWithStackByArgs(fmt.Sprintf("%s on vector values", c.funcName))
+	}
 	argTp := resTp
 	if cmpStringMode != GLCmpStringDirectly {
 		// Args are temporal and string mixed, we cast all args as string and parse it to temporal manually to compare.
@@ -759,6 +786,9 @@ func (c *leastFunctionClass) getFunction(ctx BuildContext, args []Expression) (s
 	}
 	resFieldType, fieldTimeType, cmpStringMode := resolveType4Extremum(ctx.GetEvalCtx(), args)
 	resTp := resFieldType.EvalType()
+	if resTp == types.ETVectorFloat32 {
+		return nil, ErrNotSupportedYet.GenWithStackByArgs(fmt.Sprintf("%s on vector values", c.funcName))
+	}
 	argTp := resTp
 	if cmpStringMode != GLCmpStringDirectly {
 		// Args are temporal and string mixed, we cast all args as string and parse it to temporal manually to compare.
@@ -1287,7 +1317,9 @@ func GetAccurateCmpType(ctx EvalContext, lhs, rhs Expression) types.EvalType {
 	lhsFieldType, rhsFieldType := lhs.GetType(ctx), rhs.GetType(ctx)
 	lhsEvalType, rhsEvalType := lhsFieldType.EvalType(), rhsFieldType.EvalType()
 	cmpType := getBaseCmpType(lhsEvalType, rhsEvalType, lhsFieldType, rhsFieldType)
-	if (lhsEvalType.IsStringKind() && lhsFieldType.GetType() == mysql.TypeJSON) || (rhsEvalType.IsStringKind() && rhsFieldType.GetType() == mysql.TypeJSON) {
+	if lhsEvalType == types.ETVectorFloat32 || rhsEvalType == types.ETVectorFloat32 {
+		cmpType = types.ETVectorFloat32
+	} else if (lhsEvalType.IsStringKind() && lhsFieldType.GetType() == mysql.TypeJSON) || (rhsEvalType.IsStringKind() && rhsFieldType.GetType() == mysql.TypeJSON) {
 		cmpType = types.ETJson
 	} else if cmpType == types.ETString && (types.IsTypeTime(lhsFieldType.GetType()) || types.IsTypeTime(rhsFieldType.GetType())) {
 		// date[time] <cmp> date[time]
@@ -1354,6 +1386,8 @@ func GetCmpFunction(ctx BuildContext, lhs, rhs Expression) CompareFunc {
 		return CompareTime
 	case types.ETJson:
 		return CompareJSON
+	case types.ETVectorFloat32:
+		return CompareVectorFloat32
 	}
 	return nil
 }
@@ -1957,6 +1991,30 @@ func (c *compareFunctionClass) generateCmpSigs(ctx BuildContext, args []Expressi
 			sig = &builtinNullEQJSONSig{bf}
 			sig.setPbCode(tipb.ScalarFuncSig_NullEQJson)
 		}
+	case types.ETVectorFloat32:
+		switch c.op {
+		case opcode.LT:
+			sig = &builtinLTVectorFloat32Sig{bf}
+			sig.setPbCode(tipb.ScalarFuncSig_LTVectorFloat32)
+		case opcode.LE:
+			sig = &builtinLEVectorFloat32Sig{bf}
+			sig.setPbCode(tipb.ScalarFuncSig_LEVectorFloat32)
+		case opcode.GT:
+			sig = &builtinGTVectorFloat32Sig{bf}
+			sig.setPbCode(tipb.ScalarFuncSig_GTVectorFloat32)
+		case opcode.GE:
+			sig = &builtinGEVectorFloat32Sig{bf}
+			sig.setPbCode(tipb.ScalarFuncSig_GEVectorFloat32)
+		case opcode.EQ:
+			sig = &builtinEQVectorFloat32Sig{bf}
+			sig.setPbCode(tipb.ScalarFuncSig_EQVectorFloat32)
+		case opcode.NE:
+			sig = &builtinNEVectorFloat32Sig{bf}
+			sig.setPbCode(tipb.ScalarFuncSig_NEVectorFloat32)
+		case opcode.NullEQ:
+			sig = &builtinNullEQVectorFloat32Sig{bf}
+			sig.setPbCode(tipb.ScalarFuncSig_NullEQVectorFloat32)
+		}
 	}
 	return
 }
@@ -2772,6 +2830,123 @@ func (b *builtinNullEQJSONSig) evalInt(ctx EvalContext, row chunk.Row) (val int6
 	return res, false, nil
 }
 
+type builtinLTVectorFloat32Sig struct {
+	baseBuiltinFunc
+}
+
+func (b *builtinLTVectorFloat32Sig) Clone() builtinFunc {
+	newSig := &builtinLTVectorFloat32Sig{}
+	newSig.cloneFrom(&b.baseBuiltinFunc)
+	return newSig
+}
+
+func (b *builtinLTVectorFloat32Sig) evalInt(ctx EvalContext, row chunk.Row) (val int64, isNull bool, err error) {
+	return resOfLT(CompareVectorFloat32(ctx, b.args[0], b.args[1], row, row))
+}
+
+type builtinLEVectorFloat32Sig struct {
+	baseBuiltinFunc
+}
+
+func (b *builtinLEVectorFloat32Sig) Clone() builtinFunc {
+	newSig := &builtinLEVectorFloat32Sig{}
+	newSig.cloneFrom(&b.baseBuiltinFunc)
+	return newSig
+}
+
+func (b *builtinLEVectorFloat32Sig) evalInt(ctx EvalContext, row chunk.Row) (val int64, isNull bool, err error) {
+	return resOfLE(CompareVectorFloat32(ctx, b.args[0], b.args[1], row, row))
+}
+
+type builtinGTVectorFloat32Sig struct {
+	baseBuiltinFunc
+}
+
+func (b *builtinGTVectorFloat32Sig) Clone() builtinFunc {
+	newSig := &builtinGTVectorFloat32Sig{}
+	newSig.cloneFrom(&b.baseBuiltinFunc)
+	return newSig
+}
+
+func (b *builtinGTVectorFloat32Sig) evalInt(ctx EvalContext, row chunk.Row) (val int64, isNull bool, err error) {
+	return resOfGT(CompareVectorFloat32(ctx, b.args[0], b.args[1], row, row))
+}
+
+type builtinGEVectorFloat32Sig struct {
+	baseBuiltinFunc
+}
+
+func (b *builtinGEVectorFloat32Sig) Clone() builtinFunc {
+	newSig := &builtinGEVectorFloat32Sig{}
+	newSig.cloneFrom(&b.baseBuiltinFunc)
+	return newSig
+}
+
+func (b *builtinGEVectorFloat32Sig) evalInt(ctx EvalContext, row chunk.Row) (val int64, isNull bool, err error) {
+	return resOfGE(CompareVectorFloat32(ctx, b.args[0], b.args[1], row, row))
+}
+
+type builtinEQVectorFloat32Sig struct {
+	baseBuiltinFunc
+}
+
+func (b *builtinEQVectorFloat32Sig) Clone() builtinFunc {
+	newSig := &builtinEQVectorFloat32Sig{}
+	newSig.cloneFrom(&b.baseBuiltinFunc)
+	return newSig
+}
+
+func (b *builtinEQVectorFloat32Sig) evalInt(ctx EvalContext, row chunk.Row) (val int64, isNull bool, err error) {
+	return resOfEQ(CompareVectorFloat32(ctx, b.args[0], b.args[1], row, row))
+}
+
+type builtinNEVectorFloat32Sig struct {
+	baseBuiltinFunc
+}
+
+func (b *builtinNEVectorFloat32Sig) Clone() builtinFunc {
+	newSig := &builtinNEVectorFloat32Sig{}
+	newSig.cloneFrom(&b.baseBuiltinFunc)
+	return newSig
+}
+
+func (b *builtinNEVectorFloat32Sig) evalInt(ctx EvalContext, row chunk.Row) (val int64, isNull bool, err error) {
+	return resOfNE(CompareVectorFloat32(ctx, b.args[0], b.args[1], row, row))
+}
+
+type builtinNullEQVectorFloat32Sig struct {
+	baseBuiltinFunc
+}
+
+func (b *builtinNullEQVectorFloat32Sig) Clone() builtinFunc {
+	newSig := &builtinNullEQVectorFloat32Sig{}
+	newSig.cloneFrom(&b.baseBuiltinFunc)
+	return newSig
+}
+
+func (b *builtinNullEQVectorFloat32Sig) evalInt(ctx EvalContext, row chunk.Row) (val int64, isNull bool, err error) {
+	arg0, isNull0, err := b.args[0].EvalVectorFloat32(ctx, row)
+	if err != nil {
+		return 0, true, err
+	}
+	arg1, isNull1, err := b.args[1].EvalVectorFloat32(ctx, row)
+	if err != nil {
+		return 0, true, err
+	}
+	var res int64
+	switch {
+	case isNull0 && isNull1:
+		res = 1
+	case isNull0 != isNull1:
+		return res, false, nil
+	default:
+		if arg0.Compare(arg1) == 0 {
+			res = 1
+		}
+	}
+	return res, false, nil
+}
+
 func resOfLT(val int64, isNull bool, err error) (int64, bool, error) {
 	if isNull || err != nil {
 		return 0, isNull, err
@@ -2995,3 +3170,21 @@ func CompareJSON(sctx EvalContext, lhsArg, rhsArg Expression, lhsRow, rhsRow chu
 	}
 	return int64(types.CompareBinaryJSON(arg0, arg1)), false, nil
 }
+
+// CompareVectorFloat32 compares two VectorFloat32 values.
+func CompareVectorFloat32(sctx EvalContext, lhsArg, rhsArg Expression, lhsRow, rhsRow chunk.Row) (int64, bool, error) {
+	arg0, isNull0, err := lhsArg.EvalVectorFloat32(sctx, lhsRow)
+	if err != nil {
+		return 0, true, err
+	}
+
+	arg1, isNull1, err := rhsArg.EvalVectorFloat32(sctx, rhsRow)
+	if err != nil {
+		return 0, true, err
+	}
+
+	if isNull0 || isNull1 {
+		return compareNull(isNull0, isNull1), true, nil
+	}
+	return int64(arg0.Compare(arg1)), false, nil
+}
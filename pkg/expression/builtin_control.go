@@ -372,6 +372,9 @@ func (c *caseWhenFunctionClass) getFunction(ctx BuildContext, args []Expression)
 	case types.ETJson:
 		sig = &builtinCaseWhenJSONSig{bf}
 		sig.setPbCode(tipb.ScalarFuncSig_CaseWhenJson)
+	case types.ETVectorFloat32:
+		sig = &builtinCaseWhenVectorFloat32Sig{bf}
+		sig.setPbCode(tipb.ScalarFuncSig_CaseWhenVectorFloat32)
 	}
 	return sig, nil
 }
@@ -626,6 +629,36 @@ func (b *builtinCaseWhenJSONSig) evalJSON(ctx EvalContext, row chunk.Row) (ret t
 	return ret, true, nil
 }
 
+type builtinCaseWhenVectorFloat32Sig struct {
+	baseBuiltinFunc
+}
+
+func (b *builtinCaseWhenVectorFloat32Sig) Clone() builtinFunc {
+	newSig := &builtinCaseWhenVectorFloat32Sig{}
+	newSig.cloneFrom(&b.baseBuiltinFunc)
+	return newSig
+}
+
+// evalVectorFloat32 evals a builtinCaseWhenVectorFloat32Sig.
+func (b *builtinCaseWhenVectorFloat32Sig) evalVectorFloat32(ctx EvalContext, row chunk.Row) (ret types.VectorFloat32, isNull bool, err error) {
+	var condition int64
+	args, l := b.getArgs(), len(b.getArgs())
+	for i := 0; i < l-1; i += 2 {
+		condition, isNull, err = args[i].EvalInt(ctx, row)
+		if err != nil {
+			return
+		}
+		if isNull || condition == 0 {
+			continue
+		}
+		return args[i+1].EvalVectorFloat32(ctx, row)
+	}
+	if l%2 == 1 {
+		return args[l-1].EvalVectorFloat32(ctx, row)
+	}
+	return ret, true, nil
+}
+
 type ifFunctionClass struct {
 	baseFunctionClass
 }
@@ -673,6 +706,9 @@ func (c *ifFunctionClass) getFunction(ctx BuildContext, args []Expression) (sig
 	case types.ETJson:
 		sig = &builtinIfJSONSig{bf}
 		sig.setPbCode(tipb.ScalarFuncSig_IfJson)
+	case types.ETVectorFloat32:
+		sig = &builtinIfVectorFloat32Sig{bf}
+		sig.setPbCode(tipb.ScalarFuncSig_IfVectorFloat32)
 	}
 	return sig, nil
 }
@@ -824,6 +860,27 @@ func (b *builtinIfJSONSig) evalJSON(ctx EvalContext, row chunk.Row) (ret types.B
 	return b.args[2].EvalJSON(ctx, row)
 }
 
+type builtinIfVectorFloat32Sig struct {
+	baseBuiltinFunc
+}
+
+func (b *builtinIfVectorFloat32Sig) Clone() builtinFunc {
+	newSig := &builtinIfVectorFloat32Sig{}
+	newSig.cloneFrom(&b.baseBuiltinFunc)
+	return newSig
+}
+
+func (b *builtinIfVectorFloat32Sig) evalVectorFloat32(ctx EvalContext, row chunk.Row) (ret types.VectorFloat32, isNull bool, err error) {
+	arg0, isNull0, err := b.args[0].EvalInt(ctx, row)
+	if err != nil {
+		return ret, true, err
+	}
+	if !isNull0 && arg0 != 0 {
+		return b.args[1].EvalVectorFloat32(ctx, row)
+	}
+	return b.args[2].EvalVectorFloat32(ctx, row)
+}
+
 type ifNullFunctionClass struct {
 	baseFunctionClass
 }
@@ -873,6 +930,9 @@ func (c *ifNullFunctionClass) getFunction(ctx BuildContext, args []Expression) (
 	case types.ETJson:
 		sig = &builtinIfNullJSONSig{bf}
 		sig.setPbCode(tipb.ScalarFuncSig_IfNullJson)
+	case types.ETVectorFloat32:
+		sig = &builtinIfNullVectorFloat32Sig{bf}
+		sig.setPbCode(tipb.ScalarFuncSig_IfNullVectorFloat32)
 	}
 	return sig, nil
 }
@@ -1009,3 +1069,22 @@ func (b *builtinIfNullJSONSig) evalJSON(ctx EvalContext, row chunk.Row) (types.B
 	arg1, isNull, err := b.args[1].EvalJSON(ctx, row)
 	return arg1, isNull || err != nil, err
 }
+
+type builtinIfNullVectorFloat32Sig struct {
+	baseBuiltinFunc
+}
+
+func (b *builtinIfNullVectorFloat32Sig) Clone() builtinFunc {
+	newSig := &builtinIfNullVectorFloat32Sig{}
+	newSig.cloneFrom(&b.baseBuiltinFunc)
+	return newSig
+}
+
+func (b *builtinIfNullVectorFloat32Sig) evalVectorFloat32(ctx EvalContext, row chunk.Row) (types.VectorFloat32, bool, error) {
+	arg0, isNull, err := b.args[0].EvalVectorFloat32(ctx, row)
+	if !isNull {
+		return arg0, err != nil, err
+	}
+	arg1, isNull, err := b.args[1].EvalVectorFloat32(ctx, row)
+	return arg1, isNull || err != nil, err
+}
@@ -0,0 +1,129 @@
+// Copyright 2024 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package expression
+
+import (
+	"github.com/pingcap/tidb/pkg/parser/ast"
+	"github.com/pingcap/tidb/pkg/types"
+	"github.com/pingcap/tidb/pkg/util/chunk"
+	"github.com/pingcap/tidb/pkg/util/fulltext"
+	"github.com/pingcap/tipb/go-tipb"
+)
+
+var (
+	_ functionClass = &ftsMatchAgainstFunctionClass{}
+)
+
+var (
+	_ builtinFunc = &builtinFTSMatchAgainstSig{}
+)
+
+const (
+	// FTSQueryIdx is the index of the search string in the arguments of fts_match_against.
+	FTSQueryIdx = iota
+	// FTSModifierIdx is the index of the search modifier in the arguments of fts_match_against.
+	FTSModifierIdx
+	// FTSParserIdx is the index of the parser name in the arguments of fts_match_against.
+	FTSParserIdx
+	// FTSColumnsIdx is the index of the first searched column in the arguments of fts_match_against.
+	FTSColumnsIdx
+)
+
+// ftsMatchAgainstFunctionClass is the function class of `fts_match_against(query, modifier, parser, col1, col2, ...)`,
+// which is rewritten from `MATCH (col1, col2, ...) AGAINST (query modifier)`. It returns the relevance of the row,
+// and the parser is the one of the FULLTEXT index on the columns. It's not pushed down to the storage layer.
+type ftsMatchAgainstFunctionClass struct {
+	baseFunctionClass
+}
+
+func (c *ftsMatchAgainstFunctionClass) getFunction(ctx BuildContext, args []Expression) (builtinFunc, error) {
+	if err := c.verifyArgs(args); err != nil {
+		return nil, err
+	}
+	argTps := make([]types.EvalType, 0, len(args))
+	argTps = append(argTps, types.ETString, types.ETInt, types.ETString)
+	for range args[FTSColumnsIdx:] {
+		argTps = append(argTps, types.ETString)
+	}
+	bf, err := newBaseBuiltinFuncWithTp(ctx, c.funcName, args, types.ETReal, argTps...)
+	if err != nil {
+		return nil, err
+	}
+	sig := &builtinFTSMatchAgainstSig{baseBuiltinFunc: bf}
+	sig.setPbCode(tipb.ScalarFuncSig_Unspecified)
+	return sig, nil
+}
+
+type builtinFTSMatchAgainstSig struct {
+	baseBuiltinFunc
+	memorizedQuery builtinFuncCache[*fulltext.Query]
+}
+
+func (b *builtinFTSMatchAgainstSig) Clone() builtinFunc {
+	newSig := &builtinFTSMatchAgainstSig{}
+	newSig.cloneFrom(&b.baseBuiltinFunc)
+	return newSig
+}
+
+// canMemorizeQuery returns whether the query can be cached, it's true if the search string,
+// the modifier and the parser are all constants.
+func (b *builtinFTSMatchAgainstSig) canMemorizeQuery() bool {
+	for _, arg := range b.args[:FTSColumnsIdx] {
+		if arg.ConstLevel() < ConstOnlyInContext {
+			return false
+		}
+	}
+	return true
+}
+
+func (b *builtinFTSMatchAgainstSig) evalReal(ctx EvalContext, row chunk.Row) (float64, bool, error) {
+	text, isNull, err := b.args[FTSQueryIdx].EvalString(ctx, row)
+	if isNull || err != nil {
+		return 0, false, err
+	}
+	modifier, _, err := b.args[FTSModifierIdx].EvalInt(ctx, row)
+	if err != nil {
+		return 0, false, err
+	}
+	parser, _, err := b.args[FTSParserIdx].EvalString(ctx, row)
+	if err != nil {
+		return 0, false, err
+	}
+	tk := fulltext.NewTokenizer(parser)
+	buildQuery := func() (*fulltext.Query, error) {
+		return fulltext.ParseQuery(text, ast.FulltextSearchModifier(modifier).IsBooleanMode(), tk), nil
+	}
+	var query *fulltext.Query
+	if b.canMemorizeQuery() {
+		query, err = b.memorizedQuery.getOrInitCache(ctx, buildQuery)
+	} else {
+		query, err = buildQuery()
+	}
+	if err != nil {
+		return 0, false, err
+	}
+
+	texts := make([]string, 0, len(b.args)-FTSColumnsIdx)
+	for _, arg := range b.args[FTSColumnsIdx:] {
+		s, isNull, err := arg.EvalString(ctx, row)
+		if err != nil {
+			return 0, false, err
+		}
+		if !isNull {
+			texts = append(texts, s)
+		}
+	}
+	return query.Relevance(fulltext.NewDocument(tk, texts...)), false, nil
+}
@@ -0,0 +1,53 @@
+// Copyright 2024 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package expression
+
+import (
+	"testing"
+
+	"github.com/pingcap/tidb/pkg/parser/ast"
+	"github.com/pingcap/tidb/pkg/types"
+	"github.com/pingcap/tidb/pkg/util/chunk"
+	"github.com/pingcap/tidb/pkg/util/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFTSMatchAgainst(t *testing.T) {
+	ctx := mock.NewContext()
+	tbl := []struct {
+		args     []any
+		expected float64
+	}{
+		{[]any{"database", ast.FulltextSearchModifierNaturalLanguageMode, "", "TiDB is a distributed database", nil}, 1},
+		{[]any{"database", ast.FulltextSearchModifierNaturalLanguageMode, "", "TiDB", "MySQL"}, 0},
+		{[]any{"database mysql", ast.FulltextSearchModifierNaturalLanguageMode, "", "TiDB database", "MySQL"}, 2},
+		{[]any{"+database -mysql", ast.FulltextSearchModifierBooleanMode, "", "TiDB database", "MySQL"}, 0},
+		{[]any{"+database -mysql", ast.FulltextSearchModifierBooleanMode, "", "TiDB database", "TiDB"}, 1},
+		{[]any{"数据库", ast.FulltextSearchModifierNaturalLanguageMode, "ngram", "分布式数据库"}, 2},
+		{[]any{nil, ast.FulltextSearchModifierNaturalLanguageMode, "", "TiDB"}, 0},
+	}
+	fc := funcs[ast.FTSMatchAgainst]
+	for _, c := range tbl {
+		f, err := fc.getFunction(ctx, datumsToConstants(types.MakeDatums(c.args...)))
+		require.NoError(t, err)
+		r, err := evalBuiltinFunc(f, ctx, chunk.Row{})
+		require.NoError(t, err)
+		require.Equal(t, types.KindFloat64, r.Kind())
+		require.InDelta(t, c.expected, r.GetFloat64(), 1e-9)
+	}
+
+	_, err := fc.getFunction(ctx, datumsToConstants(types.MakeDatums("database", 0, "")))
+	require.Error(t, err)
+}
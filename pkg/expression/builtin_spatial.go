@@ -0,0 +1,721 @@
+// Copyright 2024 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package expression
+
+import (
+	"math"
+
+	"github.com/pingcap/tidb/pkg/parser/charset"
+	"github.com/pingcap/tidb/pkg/parser/mysql"
+	"github.com/pingcap/tidb/pkg/types"
+	"github.com/pingcap/tidb/pkg/util/chunk"
+	"github.com/pingcap/tipb/go-tipb"
+)
+
+var (
+	_ functionClass = &geomFromTextFunctionClass{}
+	_ functionClass = &geomFromWKBFunctionClass{}
+	_ functionClass = &pointFunctionClass{}
+	_ functionClass = &geomFromComponentsFunctionClass{}
+	_ functionClass = &stAsTextFunctionClass{}
+	_ functionClass = &stAsBinaryFunctionClass{}
+	_ functionClass = &stSRIDFunctionClass{}
+	_ functionClass = &stCoordinateFunctionClass{}
+	_ functionClass = &stGeometryTypeFunctionClass{}
+	_ functionClass = &spatialRelationFunctionClass{}
+	_ functionClass = &stDistanceFunctionClass{}
+	_ functionClass = &stDistanceSphereFunctionClass{}
+)
+
+var (
+	_ builtinFunc = &builtinGeomFromTextSig{}
+	_ builtinFunc = &builtinGeomFromWKBSig{}
+	_ builtinFunc = &builtinPointSig{}
+	_ builtinFunc = &builtinGeomFromComponentsSig{}
+	_ builtinFunc = &builtinSTAsTextSig{}
+	_ builtinFunc = &builtinSTAsBinarySig{}
+	_ builtinFunc = &builtinSTSRIDSig{}
+	_ builtinFunc = &builtinSTSetSRIDSig{}
+	_ builtinFunc = &builtinSTCoordinateSig{}
+	_ builtinFunc = &builtinSTGeometryTypeSig{}
+	_ builtinFunc = &builtinSpatialRelationSig{}
+	_ builtinFunc = &builtinSTDistanceSig{}
+	_ builtinFunc = &builtinSTDistanceSphereSig{}
+)
+
+// Spatial functions are not supported by the storage layer, so none of them
+// carries a pushdown signature. The geometries are passed around as strings
+// in the internal format, which is the SRID followed by the WKB.
+
+// setGeometryRetTp sets the return type of the function to the geometry type.
+func setGeometryRetTp(bf *baseBuiltinFunc, geomType byte) {
+	bf.tp.SetType(mysql.TypeGeometry)
+	bf.tp.SetGeometryType(geomType)
+	bf.tp.SetFlen(types.UnspecifiedLength)
+	bf.tp.SetCharset(charset.CharsetBin)
+	bf.tp.SetCollate(charset.CollationBin)
+	bf.tp.AddFlag(mysql.BinaryFlag)
+}
+
+// evalGeometryArg evaluates the argument as a geometry in the internal format.
+func evalGeometryArg(ctx EvalContext, arg Expression, row chunk.Row, funcName string) (types.Geometry, bool, error) {
+	s, isNull, err := arg.EvalString(ctx, row)
+	if isNull || err != nil {
+		return types.Geometry{}, isNull, err
+	}
+	g, err := types.DecodeGeometry([]byte(s))
+	if err != nil {
+		return types.Geometry{}, false, types.ErrGISInvalidData.GenWithStackByArgs(funcName)
+	}
+	return g, false, nil
+}
+
+// evalGeometryArgPair evaluates the first two arguments as geometries in the same spatial reference system.
+func evalGeometryArgPair(ctx EvalContext, args []Expression, row chunk.Row, funcName string) (a, b types.Geometry, isNull bool, err error) {
+	a, isNull, err = evalGeometryArg(ctx, args[0], row, funcName)
+	if isNull || err != nil {
+		return a, b, isNull, err
+	}
+	b, isNull, err = evalGeometryArg(ctx, args[1], row, funcName)
+	if isNull || err != nil {
+		return a, b, isNull, err
+	}
+	if a.SRID != b.SRID {
+		return a, b, false, types.ErrGISDifferentSRIDs.GenWithStackByArgs(funcName, a.SRID, b.SRID)
+	}
+	return a, b, false, nil
+}
+
+// evalSRIDArg evaluates the optional SRID argument, which is 0 if it's absent.
+func evalSRIDArg(ctx EvalContext, args []Expression, idx int, row chunk.Row) (uint32, bool, error) {
+	if len(args) <= idx {
+		return types.SRIDCartesian, false, nil
+	}
+	srid, isNull, err := args[idx].EvalInt(ctx, row)
+	if isNull || err != nil {
+		return 0, isNull, err
+	}
+	if srid < 0 || srid > math.MaxUint32 {
+		return 0, false, types.ErrSRSNotFound.GenWithStackByArgs(srid)
+	}
+	return uint32(srid), false, types.CheckSRID(uint32(srid))
+}
+
+// encodeGeometryResult checks and encodes the geometry built by the function.
+func encodeGeometryResult(g *types.Geometry, geomType byte, funcName string) (string, bool, error) {
+	if !types.IsGeometrySubtype(g.Type, geomType) {
+		return "", false, types.ErrGISInvalidData.GenWithStackByArgs(funcName)
+	}
+	if err := g.CheckGeographicCoordinates(funcName); err != nil {
+		return "", false, err
+	}
+	return string(types.EncodeGeometry(g)), false, nil
+}
+
+type geomFromTextFunctionClass struct {
+	baseFunctionClass
+	geomType byte
+}
+
+func (c *geomFromTextFunctionClass) getFunction(ctx BuildContext, args []Expression) (builtinFunc, error) {
+	if err := c.verifyArgs(args); err != nil {
+		return nil, err
+	}
+	argTps := []types.EvalType{types.ETString}
+	if len(args) == 2 {
+		argTps = append(argTps, types.ETInt)
+	}
+	bf, err := newBaseBuiltinFuncWithTp(ctx, c.funcName, args, types.ETString, argTps...)
+	if err != nil {
+		return nil, err
+	}
+	setGeometryRetTp(&bf, c.geomType)
+	sig := &builtinGeomFromTextSig{bf, c.funcName, c.geomType}
+	sig.setPbCode(tipb.ScalarFuncSig_Unspecified)
+	return sig, nil
+}
+
+// builtinGeomFromTextSig constructs a geometry from the WKT, e.g. ST_GeomFromText and ST_PointFromText.
+type builtinGeomFromTextSig struct {
+	baseBuiltinFunc
+	funcName string
+	geomType byte
+}
+
+func (b *builtinGeomFromTextSig) Clone() builtinFunc {
+	newSig := &builtinGeomFromTextSig{funcName: b.funcName, geomType: b.geomType}
+	newSig.cloneFrom(&b.baseBuiltinFunc)
+	return newSig
+}
+
+func (b *builtinGeomFromTextSig) evalString(ctx EvalContext, row chunk.Row) (string, bool, error) {
+	wkt, isNull, err := b.args[0].EvalString(ctx, row)
+	if isNull || err != nil {
+		return "", isNull, err
+	}
+	srid, isNull, err := evalSRIDArg(ctx, b.args, 1, row)
+	if isNull || err != nil {
+		return "", isNull, err
+	}
+	g, err := types.ParseGeometryWKT(wkt, srid)
+	if err != nil {
+		return "", false, types.ErrGISInvalidData.GenWithStackByArgs(b.funcName)
+	}
+	return encodeGeometryResult(&g, b.geomType, b.funcName)
+}
+
+type geomFromWKBFunctionClass struct {
+	baseFunctionClass
+}
+
+func (c *geomFromWKBFunctionClass) getFunction(ctx BuildContext, args []Expression) (builtinFunc, error) {
+	if err := c.verifyArgs(args); err != nil {
+		return nil, err
+	}
+	argTps := []types.EvalType{types.ETString}
+	if len(args) == 2 {
+		argTps = append(argTps, types.ETInt)
+	}
+	bf, err := newBaseBuiltinFuncWithTp(ctx, c.funcName, args, types.ETString, argTps...)
+	if err != nil {
+		return nil, err
+	}
+	setGeometryRetTp(&bf, mysql.GeometryTypeGeometry)
+	sig := &builtinGeomFromWKBSig{bf, c.funcName}
+	sig.setPbCode(tipb.ScalarFuncSig_Unspecified)
+	return sig, nil
+}
+
+type builtinGeomFromWKBSig struct {
+	baseBuiltinFunc
+	funcName string
+}
+
+func (b *builtinGeomFromWKBSig) Clone() builtinFunc {
+	newSig := &builtinGeomFromWKBSig{funcName: b.funcName}
+	newSig.cloneFrom(&b.baseBuiltinFunc)
+	return newSig
+}
+
+func (b *builtinGeomFromWKBSig) evalString(ctx EvalContext, row chunk.Row) (string, bool, error) {
+	wkb, isNull, err := b.args[0].EvalString(ctx, row)
+	if isNull || err != nil {
+		return "", isNull, err
+	}
+	srid, isNull, err := evalSRIDArg(ctx, b.args, 1, row)
+	if isNull || err != nil {
+		return "", isNull, err
+	}
+	g, err := types.ParseGeometryWKB([]byte(wkb), srid)
+	if err != nil {
+		return "", false, types.ErrGISInvalidData.GenWithStackByArgs(b.funcName)
+	}
+	return encodeGeometryResult(&g, mysql.GeometryTypeGeometry, b.funcName)
+}
+
+type pointFunctionClass struct {
+	baseFunctionClass
+}
+
+func (c *pointFunctionClass) getFunction(ctx BuildContext, args []Expression) (builtinFunc, error) {
+	if err := c.verifyArgs(args); err != nil {
+		return nil, err
+	}
+	bf, err := newBaseBuiltinFuncWithTp(ctx, c.funcName, args, types.ETString, types.ETReal, types.ETReal)
+	if err != nil {
+		return nil, err
+	}
+	setGeometryRetTp(&bf, mysql.GeometryTypePoint)
+	sig := &builtinPointSig{bf}
+	sig.setPbCode(tipb.ScalarFuncSig_Unspecified)
+	return sig, nil
+}
+
+type builtinPointSig struct {
+	baseBuiltinFunc
+}
+
+func (b *builtinPointSig) Clone() builtinFunc {
+	newSig := &builtinPointSig{}
+	newSig.cloneFrom(&b.baseBuiltinFunc)
+	return newSig
+}
+
+func (b *builtinPointSig) evalString(ctx EvalContext, row chunk.Row) (string, bool, error) {
+	x, isNull, err := b.args[0].EvalReal(ctx, row)
+	if isNull || err != nil {
+		return "", isNull, err
+	}
+	y, isNull, err := b.args[1].EvalReal(ctx, row)
+	if isNull || err != nil {
+		return "", isNull, err
+	}
+	g := types.NewPointGeometry(x, y, types.SRIDCartesian)
+	return string(types.EncodeGeometry(&g)), false, nil
+}
+
+// geomFromComponentsFunctionClass is the class of LineString() and Polygon(), which build the geometry
+// from the points and the linestrings respectively.
+type geomFromComponentsFunctionClass struct {
+	baseFunctionClass
+	geomType byte
+}
+
+func (c *geomFromComponentsFunctionClass) getFunction(ctx BuildContext, args []Expression) (builtinFunc, error) {
+	if err := c.verifyArgs(args); err != nil {
+		return nil, err
+	}
+	argTps := make([]types.EvalType, len(args))
+	for i := range argTps {
+		argTps[i] = types.ETString
+	}
+	bf, err := newBaseBuiltinFuncWithTp(ctx, c.funcName, args, types.ETString, argTps...)
+	if err != nil {
+		return nil, err
+	}
+	setGeometryRetTp(&bf, c.geomType)
+	sig := &builtinGeomFromComponentsSig{bf, c.funcName, c.geomType}
+	sig.setPbCode(tipb.ScalarFuncSig_Unspecified)
+	return sig, nil
+}
+
+type builtinGeomFromComponentsSig struct {
+	baseBuiltinFunc
+	funcName string
+	geomType byte
+}
+
+func (b *builtinGeomFromComponentsSig) Clone() builtinFunc {
+	newSig := &builtinGeomFromComponentsSig{funcName: b.funcName, geomType: b.geomType}
+	newSig.cloneFrom(&b.baseBuiltinFunc)
+	return newSig
+}
+
+func (b *builtinGeomFromComponentsSig) evalString(ctx EvalContext, row chunk.Row) (string, bool, error) {
+	result := types.Geometry{Type: b.geomType}
+	// The components are a POINT for LineString() and a LINESTRING for Polygon().
+	componentType := b.geomType - 1
+	for i, arg := range b.args {
+		g, isNull, err := evalGeometryArg(ctx, arg, row, b.funcName)
+		if isNull || err != nil {
+			return "", isNull, err
+		}
+		if g.Type != componentType {
+			return "", false, types.ErrGISInvalidData.GenWithStackByArgs(b.funcName)
+		}
+		if i == 0 {
+			result.SRID = g.SRID
+		} else if g.SRID != result.SRID {
+			return "", false, types.ErrGISDifferentSRIDs.GenWithStackByArgs(b.funcName, result.SRID, g.SRID)
+		}
+		if componentType == mysql.GeometryTypePoint {
+			result.Points = append(result.Points, g.Points...)
+		} else {
+			result.Rings = append(result.Rings, g.Points)
+		}
+	}
+	if err := result.Validate(); err != nil {
+		return "", false, types.ErrGISInvalidData.GenWithStackByArgs(b.funcName)
+	}
+	return string(types.EncodeGeometry(&result)), false, nil
+}
+
+type stAsTextFunctionClass struct {
+	baseFunctionClass
+}
+
+func (c *stAsTextFunctionClass) getFunction(ctx BuildContext, args []Expression) (builtinFunc, error) {
+	if err := c.verifyArgs(args); err != nil {
+		return nil, err
+	}
+	bf, err := newBaseBuiltinFuncWithTp(ctx, c.funcName, args, types.ETString, types.ETString)
+	if err != nil {
+		return nil, err
+	}
+	bf.tp.SetFlen(mysql.MaxBlobWidth)
+	sig := &builtinSTAsTextSig{bf, c.funcName}
+	sig.setPbCode(tipb.ScalarFuncSig_Unspecified)
+	return sig, nil
+}
+
+type builtinSTAsTextSig struct {
+	baseBuiltinFunc
+	funcName string
+}
+
+func (b *builtinSTAsTextSig) Clone() builtinFunc {
+	newSig := &builtinSTAsTextSig{funcName: b.funcName}
+	newSig.cloneFrom(&b.baseBuiltinFunc)
+	return newSig
+}
+
+func (b *builtinSTAsTextSig) evalString(ctx EvalContext, row chunk.Row) (string, bool, error) {
+	g, isNull, err := evalGeometryArg(ctx, b.args[0], row, b.funcName)
+	if isNull || err != nil {
+		return "", isNull, err
+	}
+	return g.WKT(), false, nil
+}
+
+type stAsBinaryFunctionClass struct {
+	baseFunctionClass
+}
+
+func (c *stAsBinaryFunctionClass) getFunction(ctx BuildContext, args []Expression) (builtinFunc, error) {
+	if err := c.verifyArgs(args); err != nil {
+		return nil, err
+	}
+	bf, err := newBaseBuiltinFuncWithTp(ctx, c.funcName, args, types.ETString, types.ETString)
+	if err != nil {
+		return nil, err
+	}
+	bf.tp.SetFlen(mysql.MaxBlobWidth)
+	types.SetBinChsClnFlag(bf.tp)
+	sig := &builtinSTAsBinarySig{bf, c.funcName}
+	sig.setPbCode(tipb.ScalarFuncSig_Unspecified)
+	return sig, nil
+}
+
+type builtinSTAsBinarySig struct {
+	baseBuiltinFunc
+	funcName string
+}
+
+func (b *builtinSTAsBinarySig) Clone() builtinFunc {
+	newSig := &builtinSTAsBinarySig{funcName: b.funcName}
+	newSig.cloneFrom(&b.baseBuiltinFunc)
+	return newSig
+}
+
+func (b *builtinSTAsBinarySig) evalString(ctx EvalContext, row chunk.Row) (string, bool, error) {
+	g, isNull, err := evalGeometryArg(ctx, b.args[0], row, b.funcName)
+	if isNull || err != nil {
+		return "", isNull, err
+	}
+	return string(g.WKB()), false, nil
+}
+
+// stSRIDFunctionClass is the class of ST_SRID, which returns the SRID of the geometry with one argument,
+// and returns the geometry with the new SRID with two arguments.
+type stSRIDFunctionClass struct {
+	baseFunctionClass
+}
+
+func (c *stSRIDFunctionClass) getFunction(ctx BuildContext, args []Expression) (builtinFunc, error) {
+	if err := c.verifyArgs(args); err != nil {
+		return nil, err
+	}
+	if len(args) == 2 {
+		bf, err := newBaseBuiltinFuncWithTp(ctx, c.funcName, args, types.ETString, types.ETString, types.ETInt)
+		if err != nil {
+			return nil, err
+		}
+		setGeometryRetTp(&bf, args[0].GetType(ctx.GetEvalCtx()).GetGeometryType())
+		sig := &builtinSTSetSRIDSig{bf, c.funcName}
+		sig.setPbCode(tipb.ScalarFuncSig_Unspecified)
+		return sig, nil
+	}
+	bf, err := newBaseBuiltinFuncWithTp(ctx, c.funcName, args, types.ETInt, types.ETString)
+	if err != nil {
+		return nil, err
+	}
+	bf.tp.AddFlag(mysql.UnsignedFlag)
+	sig := &builtinSTSRIDSig{bf, c.funcName}
+	sig.setPbCode(tipb.ScalarFuncSig_Unspecified)
+	return sig, nil
+}
+
+type builtinSTSRIDSig struct {
+	baseBuiltinFunc
+	funcName string
+}
+
+func (b *builtinSTSRIDSig) Clone() builtinFunc {
+	newSig := &builtinSTSRIDSig{funcName: b.funcName}
+	newSig.cloneFrom(&b.baseBuiltinFunc)
+	return newSig
+}
+
+func (b *builtinSTSRIDSig) evalInt(ctx EvalContext, row chunk.Row) (int64, bool, error) {
+	g, isNull, err := evalGeometryArg(ctx, b.args[0], row, b.funcName)
+	if isNull || err != nil {
+		return 0, isNull, err
+	}
+	return int64(g.SRID), false, nil
+}
+
+type builtinSTSetSRIDSig struct {
+	baseBuiltinFunc
+	funcName string
+}
+
+func (b *builtinSTSetSRIDSig) Clone() builtinFunc {
+	newSig := &builtinSTSetSRIDSig{funcName: b.funcName}
+	newSig.cloneFrom(&b.baseBuiltinFunc)
+	return newSig
+}
+
+func (b *builtinSTSetSRIDSig) evalString(ctx EvalContext, row chunk.Row) (string, bool, error) {
+	g, isNull, err := evalGeometryArg(ctx, b.args[0], row, b.funcName)
+	if isNull || err != nil {
+		return "", isNull, err
+	}
+	srid, isNull, err := evalSRIDArg(ctx, b.args, 1, row)
+	if isNull || err != nil {
+		return "", isNull, err
+	}
+	g.SetSRID(srid)
+	return encodeGeometryResult(&g, mysql.GeometryTypeGeometry, b.funcName)
+}
+
+// stCoordinateFunctionClass is the class of ST_X and ST_Y.
+type stCoordinateFunctionClass struct {
+	baseFunctionClass
+	isX bool
+}
+
+func (c *stCoordinateFunctionClass) getFunction(ctx BuildContext, args []Expression) (builtinFunc, error) {
+	if err := c.verifyArgs(args); err != nil {
+		return nil, err
+	}
+	bf, err := newBaseBuiltinFuncWithTp(ctx, c.funcName, args, types.ETReal, types.ETString)
+	if err != nil {
+		return nil, err
+	}
+	sig := &builtinSTCoordinateSig{bf, c.funcName, c.isX}
+	sig.setPbCode(tipb.ScalarFuncSig_Unspecified)
+	return sig, nil
+}
+
+type builtinSTCoordinateSig struct {
+	baseBuiltinFunc
+	funcName string
+	isX      bool
+}
+
+func (b *builtinSTCoordinateSig) Clone() builtinFunc {
+	newSig := &builtinSTCoordinateSig{funcName: b.funcName, isX: b.isX}
+	newSig.cloneFrom(&b.baseBuiltinFunc)
+	return newSig
+}
+
+func (b *builtinSTCoordinateSig) evalReal(ctx EvalContext, row chunk.Row) (float64, bool, error) {
+	g, isNull, err := evalGeometryArg(ctx, b.args[0], row, b.funcName)
+	if isNull || err != nil {
+		return 0, isNull, err
+	}
+	if g.Type != mysql.GeometryTypePoint || g.IsEmpty() {
+		return 0, false, types.ErrGISUnsupportedArgument.GenWithStackByArgs(b.funcName)
+	}
+	if b.isX {
+		return g.Points[0].X, false, nil
+	}
+	return g.Points[0].Y, false, nil
+}
+
+type stGeometryTypeFunctionClass struct {
+	baseFunctionClass
+}
+
+func (c *stGeometryTypeFunctionClass) getFunction(ctx BuildContext, args []Expression) (builtinFunc, error) {
+	if err := c.verifyArgs(args); err != nil {
+		return nil, err
+	}
+	bf, err := newBaseBuiltinFuncWithTp(ctx, c.funcName, args, types.ETString, types.ETString)
+	if err != nil {
+		return nil, err
+	}
+	bf.tp.SetFlen(len("GEOMETRYCOLLECTION"))
+	sig := &builtinSTGeometryTypeSig{bf, c.funcName}
+	sig.setPbCode(tipb.ScalarFuncSig_Unspecified)
+	return sig, nil
+}
+
+type builtinSTGeometryTypeSig struct {
+	baseBuiltinFunc
+	funcName string
+}
+
+func (b *builtinSTGeometryTypeSig) Clone() builtinFunc {
+	newSig := &builtinSTGeometryTypeSig{funcName: b.funcName}
+	newSig.cloneFrom(&b.baseBuiltinFunc)
+	return newSig
+}
+
+func (b *builtinSTGeometryTypeSig) evalString(ctx EvalContext, row chunk.Row) (string, bool, error) {
+	g, isNull, err := evalGeometryArg(ctx, b.args[0], row, b.funcName)
+	if isNull || err != nil {
+		return "", isNull, err
+	}
+	return g.TypeName(), false, nil
+}
+
+// spatialRelation is the relation between two geometries tested by a spatial predicate.
+type spatialRelation int
+
+const (
+	spatialRelationContains spatialRelation = iota
+	spatialRelationWithin
+	spatialRelationIntersects
+	spatialRelationDisjoint
+)
+
+// spatialRelationFunctionClass is the class of the spatial predicates, e.g. ST_Contains and ST_Intersects.
+type spatialRelationFunctionClass struct {
+	baseFunctionClass
+	relation spatialRelation
+}
+
+func (c *spatialRelationFunctionClass) getFunction(ctx BuildContext, args []Expression) (builtinFunc, error) {
+	if err := c.verifyArgs(args); err != nil {
+		return nil, err
+	}
+	bf, err := newBaseBuiltinFuncWithTp(ctx, c.funcName, args, types.ETInt, types.ETString, types.ETString)
+	if err != nil {
+		return nil, err
+	}
+	sig := &builtinSpatialRelationSig{bf, c.funcName, c.relation}
+	sig.setPbCode(tipb.ScalarFuncSig_Unspecified)
+	return sig, nil
+}
+
+type builtinSpatialRelationSig struct {
+	baseBuiltinFunc
+	funcName string
+	relation spatialRelation
+}
+
+func (b *builtinSpatialRelationSig) Clone() builtinFunc {
+	newSig := &builtinSpatialRelationSig{funcName: b.funcName, relation: b.relation}
+	newSig.cloneFrom(&b.baseBuiltinFunc)
+	return newSig
+}
+
+func (b *builtinSpatialRelationSig) evalInt(ctx EvalContext, row chunk.Row) (int64, bool, error) {
+	g1, g2, isNull, err := evalGeometryArgPair(ctx, b.args, row, b.funcName)
+	if isNull || err != nil {
+		return 0, isNull, err
+	}
+	var res bool
+	switch b.relation {
+	case spatialRelationContains:
+		res = types.GeometryWithin(&g2, &g1)
+	case spatialRelationWithin:
+		res = types.GeometryWithin(&g1, &g2)
+	case spatialRelationIntersects:
+		res = types.GeometryIntersects(&g1, &g2)
+	case spatialRelationDisjoint:
+		res = !types.GeometryIntersects(&g1, &g2)
+	}
+	if res {
+		return 1, false, nil
+	}
+	return 0, false, nil
+}
+
+type stDistanceFunctionClass struct {
+	baseFunctionClass
+}
+
+func (c *stDistanceFunctionClass) getFunction(ctx BuildContext, args []Expression) (builtinFunc, error) {
+	if err := c.verifyArgs(args); err != nil {
+		return nil, err
+	}
+	bf, err := newBaseBuiltinFuncWithTp(ctx, c.funcName, args, types.ETReal, types.ETString, types.ETString)
+	if err != nil {
+		return nil, err
+	}
+	sig := &builtinSTDistanceSig{bf, c.funcName}
+	sig.setPbCode(tipb.ScalarFuncSig_Unspecified)
+	return sig, nil
+}
+
+type builtinSTDistanceSig struct {
+	baseBuiltinFunc
+	funcName string
+}
+
+func (b *builtinSTDistanceSig) Clone() builtinFunc {
+	newSig := &builtinSTDistanceSig{funcName: b.funcName}
+	newSig.cloneFrom(&b.baseBuiltinFunc)
+	return newSig
+}
+
+// evalReal evaluates the Cartesian distance. The distance on the ellipsoid isn't supported, and
+// ST_Distance_Sphere should be used for the geographic geometries instead.
+func (b *builtinSTDistanceSig) evalReal(ctx EvalContext, row chunk.Row) (float64, bool, error) {
+	g1, g2, isNull, err := evalGeometryArgPair(ctx, b.args, row, b.funcName)
+	if isNull || err != nil {
+		return 0, isNull, err
+	}
+	if g1.SRID != types.SRIDCartesian {
+		return 0, false, types.ErrGISUnsupportedArgument.GenWithStackByArgs(b.funcName)
+	}
+	if g1.IsEmpty() || g2.IsEmpty() {
+		return 0, true, nil
+	}
+	return types.GeometryDistance(&g1, &g2), false, nil
+}
+
+type stDistanceSphereFunctionClass struct {
+	baseFunctionClass
+}
+
+func (c *stDistanceSphereFunctionClass) getFunction(ctx BuildContext, args []Expression) (builtinFunc, error) {
+	if err := c.verifyArgs(args); err != nil {
+		return nil, err
+	}
+	argTps := []types.EvalType{types.ETString, types.ETString}
+	if len(args) == 3 {
+		argTps = append(argTps, types.ETReal)
+	}
+	bf, err := newBaseBuiltinFuncWithTp(ctx, c.funcName, args, types.ETReal, argTps...)
+	if err != nil {
+		return nil, err
+	}
+	sig := &builtinSTDistanceSphereSig{bf, c.funcName}
+	sig.setPbCode(tipb.ScalarFuncSig_Unspecified)
+	return sig, nil
+}
+
+type builtinSTDistanceSphereSig struct {
+	baseBuiltinFunc
+	funcName string
+}
+
+func (b *builtinSTDistanceSphereSig) Clone() builtinFunc {
+	newSig := &builtinSTDistanceSphereSig{funcName: b.funcName}
+	newSig.cloneFrom(&b.baseBuiltinFunc)
+	return newSig
+}
+
+func (b *builtinSTDistanceSphereSig) evalReal(ctx EvalContext, row chunk.Row) (float64, bool, error) {
+	g1, g2, isNull, err := evalGeometryArgPair(ctx, b.args, row, b.funcName)
+	if isNull || err != nil {
+		return 0, isNull, err
+	}
+	radius := types.DefaultSphereRadius
+	if len(b.args) == 3 {
+		radius, isNull, err = b.args[2].EvalReal(ctx, row)
+		if isNull || err != nil {
+			return 0, isNull, err
+		}
+	}
+	if g1.IsEmpty() || g2.IsEmpty() {
+		return 0, true, nil
+	}
+	d, err := types.GeometryDistanceSphere(&g1, &g2, radius, b.funcName)
+	return d, false, err
+}
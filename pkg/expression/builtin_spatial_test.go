@@ -0,0 +1,164 @@
+// Copyright 2024 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package expression
+
+import (
+	"testing"
+
+	"github.com/pingcap/tidb/pkg/parser/ast"
+	"github.com/pingcap/tidb/pkg/parser/mysql"
+	"github.com/pingcap/tidb/pkg/types"
+	"github.com/pingcap/tidb/pkg/util/chunk"
+	"github.com/pingcap/tidb/pkg/util/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func geometryDatum(t *testing.T, wkt string, srid uint32) types.Datum {
+	g, err := types.ParseGeometryWKT(wkt, srid)
+	require.NoError(t, err)
+	return types.NewBytesDatum(types.EncodeGeometry(&g))
+}
+
+func TestSpatialConstructors(t *testing.T) {
+	ctx := mock.NewContext()
+	tbl := []struct {
+		funcName string
+		args     []types.Datum
+		expected string
+		err      bool
+	}{
+		{ast.STGeomFromText, types.MakeDatums("POINT(1 2)"), "POINT(1 2)", false},
+		{ast.STGeomFromText, types.MakeDatums("LINESTRING(0 0,1 1)", 4326), "LINESTRING(0 0,1 1)", false},
+		{ast.STGeomFromText, types.MakeDatums("POINT(1 2)", 3857), "", true},
+		{ast.STGeomFromText, types.MakeDatums("POINT(100 2)", 4326), "", true},
+		{ast.STGeomFromText, types.MakeDatums("POINT(1)"), "", true},
+		{ast.STPointFromText, types.MakeDatums("LINESTRING(0 0,1 1)"), "", true},
+		{ast.STPolyFromText, types.MakeDatums("POLYGON((0 0,1 0,1 1,0 0))"), "POLYGON((0 0,1 0,1 1,0 0))", false},
+		{ast.Point, types.MakeDatums(1.5, -2), "POINT(1.5 -2)", false},
+		{ast.LineString, []types.Datum{geometryDatum(t, "POINT(0 0)", 0), geometryDatum(t, "POINT(1 1)", 0)}, "LINESTRING(0 0,1 1)", false},
+		{ast.LineString, []types.Datum{geometryDatum(t, "POINT(0 0)", 0), geometryDatum(t, "POINT(1 1)", 4326)}, "", true},
+		{ast.Polygon, []types.Datum{geometryDatum(t, "LINESTRING(0 0,1 0,1 1,0 0)", 0)}, "POLYGON((0 0,1 0,1 1,0 0))", false},
+		{ast.Polygon, []types.Datum{geometryDatum(t, "LINESTRING(0 0,1 0,1 1)", 0)}, "", true},
+	}
+	for _, c := range tbl {
+		f, err := funcs[c.funcName].getFunction(ctx, datumsToConstants(c.args))
+		require.NoError(t, err)
+		require.Equal(t, mysql.TypeGeometry, f.getRetTp().GetType())
+		r, err := evalBuiltinFunc(f, ctx, chunk.Row{})
+		if c.err {
+			require.Error(t, err, c.funcName)
+			continue
+		}
+		require.NoError(t, err, c.funcName)
+		g, err := types.DecodeGeometry(r.GetBytes())
+		require.NoError(t, err)
+		require.Equal(t, c.expected, g.WKT())
+	}
+
+	f, err := funcs[ast.STGeomFromText].getFunction(ctx, datumsToConstants(types.MakeDatums(nil)))
+	require.NoError(t, err)
+	r, err := evalBuiltinFunc(f, ctx, chunk.Row{})
+	require.NoError(t, err)
+	require.True(t, r.IsNull())
+}
+
+func TestSpatialAccessors(t *testing.T) {
+	ctx := mock.NewContext()
+	point := geometryDatum(t, "POINT(1 2)", 4326)
+	tbl := []struct {
+		funcName string
+		args     []types.Datum
+		expected any
+	}{
+		{ast.STAsText, []types.Datum{point}, "POINT(1 2)"},
+		{ast.STAsBinary, []types.Datum{point}, string([]byte{1, 1, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0xf0, 0x3f, 0, 0, 0, 0, 0, 0, 0, 0x40})},
+		{ast.STSRID, []types.Datum{point}, int64(4326)},
+		{ast.STX, []types.Datum{point}, 1.0},
+		{ast.STY, []types.Datum{point}, 2.0},
+		{ast.STGeometryType, []types.Datum{geometryDatum(t, "MULTIPOINT(1 2,3 4)", 0)}, "MULTIPOINT"},
+	}
+	for _, c := range tbl {
+		f, err := funcs[c.funcName].getFunction(ctx, datumsToConstants(c.args))
+		require.NoError(t, err)
+		r, err := evalBuiltinFunc(f, ctx, chunk.Row{})
+		require.NoError(t, err, c.funcName)
+		switch expected := c.expected.(type) {
+		case string:
+			require.Equal(t, expected, r.GetString(), c.funcName)
+		case int64:
+			require.Equal(t, expected, r.GetInt64(), c.funcName)
+		case float64:
+			require.Equal(t, expected, r.GetFloat64(), c.funcName)
+		}
+	}
+
+	f, err := funcs[ast.STSRID].getFunction(ctx, datumsToConstants([]types.Datum{point, types.NewIntDatum(0)}))
+	require.NoError(t, err)
+	r, err := evalBuiltinFunc(f, ctx, chunk.Row{})
+	require.NoError(t, err)
+	g, err := types.DecodeGeometry(r.GetBytes())
+	require.NoError(t, err)
+	require.Equal(t, types.SRIDCartesian, g.SRID)
+
+	f, err = funcs[ast.STX].getFunction(ctx, datumsToConstants([]types.Datum{geometryDatum(t, "LINESTRING(0 0,1 1)", 0)}))
+	require.NoError(t, err)
+	_, err = evalBuiltinFunc(f, ctx, chunk.Row{})
+	require.True(t, types.ErrGISUnsupportedArgument.Equal(err))
+}
+
+func TestSpatialRelations(t *testing.T) {
+	ctx := mock.NewContext()
+	square := geometryDatum(t, "POLYGON((0 0,10 0,10 10,0 10,0 0))", 0)
+	inner := geometryDatum(t, "POINT(5 5)", 0)
+	outer := geometryDatum(t, "POINT(20 5)", 0)
+	tbl := []struct {
+		funcName string
+		args     []types.Datum
+		expected float64
+	}{
+		{ast.STContains, []types.Datum{square, inner}, 1},
+		{ast.STContains, []types.Datum{square, outer}, 0},
+		{ast.STWithin, []types.Datum{inner, square}, 1},
+		{ast.STIntersects, []types.Datum{square, outer}, 0},
+		{ast.STDisjoint, []types.Datum{square, outer}, 1},
+		{ast.STDistance, []types.Datum{square, outer}, 10},
+		{ast.STDistance, []types.Datum{square, inner}, 0},
+		{ast.STDistanceSphere, []types.Datum{geometryDatum(t, "POINT(0 0)", 0), geometryDatum(t, "POINT(0 90)", 0), types.NewFloat64Datum(2)}, 3.141592653589793},
+	}
+	for _, c := range tbl {
+		f, err := funcs[c.funcName].getFunction(ctx, datumsToConstants(c.args))
+		require.NoError(t, err)
+		r, err := evalBuiltinFunc(f, ctx, chunk.Row{})
+		require.NoError(t, err, c.funcName)
+		d, err := r.ToFloat64(ctx.GetSessionVars().StmtCtx.TypeCtx())
+		require.NoError(t, err)
+		require.InDelta(t, c.expected, d, 1e-9, c.funcName)
+	}
+
+	f, err := funcs[ast.STIntersects].getFunction(ctx, datumsToConstants([]types.Datum{inner, geometryDatum(t, "POINT(5 5)", 4326)}))
+	require.NoError(t, err)
+	_, err = evalBuiltinFunc(f, ctx, chunk.Row{})
+	require.True(t, types.ErrGISDifferentSRIDs.Equal(err))
+
+	f, err = funcs[ast.STDistance].getFunction(ctx, datumsToConstants([]types.Datum{geometryDatum(t, "POINT(5 5)", 4326), geometryDatum(t, "POINT(5 6)", 4326)}))
+	require.NoError(t, err)
+	_, err = evalBuiltinFunc(f, ctx, chunk.Row{})
+	require.True(t, types.ErrGISUnsupportedArgument.Equal(err))
+
+	f, err = funcs[ast.STContains].getFunction(ctx, datumsToConstants([]types.Datum{types.NewBytesDatum([]byte("abc")), inner}))
+	require.NoError(t, err)
+	_, err = evalBuiltinFunc(f, ctx, chunk.Row{})
+	require.True(t, types.ErrGISInvalidData.Equal(err))
+}
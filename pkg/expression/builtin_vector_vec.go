@@ -0,0 +1,200 @@
+// Copyright 2024 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package expression
+
+import (
+	"math"
+
+	"github.com/pingcap/tidb/pkg/types"
+	"github.com/pingcap/tidb/pkg/util/chunk"
+)
+
+//revive:disable:defer
+func vecEvalVecDistance(ctx EvalContext, args []Expression, bufAllocator columnBufferAllocator, input *chunk.Chunk, result *chunk.Column, distance func(a, b types.VectorFloat32) (float64, error)) error {
+	n := input.NumRows()
+	buf1, err := bufAllocator.get()
+	if err != nil {
+		return err
+	}
+	defer bufAllocator.put(buf1)
+	if err := args[0].VecEvalVectorFloat32(ctx, input, buf1); err != nil {
+		return err
+	}
+	buf2, err := bufAllocator.get()
+	if err != nil {
+		return err
+	}
+	defer bufAllocator.put(buf2)
+	if err := args[1].VecEvalVectorFloat32(ctx, input, buf2); err != nil {
+		return err
+	}
+
+	result.ResizeFloat64(n, false)
+	result.MergeNulls(buf1, buf2)
+	f64s := result.Float64s()
+	for i := 0; i < n; i++ {
+		if result.IsNull(i) {
+			continue
+		}
+		d, err := distance(buf1.GetVectorFloat32(i), buf2.GetVectorFloat32(i))
+		if err != nil {
+			return err
+		}
+		if math.IsNaN(d) {
+			result.SetNull(i, true)
+			continue
+		}
+		f64s[i] = d
+	}
+	return nil
+}
+
+func (b *builtinVecDimsSig) vectorized() bool {
+	return true
+}
+
+func (b *builtinVecDimsSig) vecEvalInt(ctx EvalContext, input *chunk.Chunk, result *chunk.Column) error {
+	n := input.NumRows()
+	buf, err := b.bufAllocator.get()
+	if err != nil {
+		return err
+	}
+	defer b.bufAllocator.put(buf)
+	if err := b.args[0].VecEvalVectorFloat32(ctx, input, buf); err != nil {
+		return err
+	}
+	result.ResizeInt64(n, false)
+	result.MergeNulls(buf)
+	i64s := result.Int64s()
+	for i := 0; i < n; i++ {
+		if result.IsNull(i) {
+			continue
+		}
+		i64s[i] = int64(buf.GetVectorFloat32(i).Len())
+	}
+	return nil
+}
+
+func (b *builtinVecL1DistanceSig) vectorized() bool {
+	return true
+}
+
+func (b *builtinVecL1DistanceSig) vecEvalReal(ctx EvalContext, input *chunk.Chunk, result *chunk.Column) error {
+	return vecEvalVecDistance(ctx, b.args, b.bufAllocator, input, result, types.VectorFloat32.L1Distance)
+}
+
+func (b *builtinVecL2DistanceSig) vectorized() bool {
+	return true
+}
+
+func (b *builtinVecL2DistanceSig) vecEvalReal(ctx EvalContext, input *chunk.Chunk, result *chunk.Column) error {
+	return vecEvalVecDistance(ctx, b.args, b.bufAllocator, input, result, types.VectorFloat32.L2Distance)
+}
+
+func (b *builtinVecNegativeInnerProductSig) vectorized() bool {
+	return true
+}
+
+func (b *builtinVecNegativeInnerProductSig) vecEvalReal(ctx EvalContext, input *chunk.Chunk, result *chunk.Column) error {
+	return vecEvalVecDistance(ctx, b.args, b.bufAllocator, input, result, types.VectorFloat32.NegativeInnerProduct)
+}
+
+func (b *builtinVecCosineDistanceSig) vectorized() bool {
+	return true
+}
+
+func (b *builtinVecCosineDistanceSig) vecEvalReal(ctx EvalContext, input *chunk.Chunk, result *chunk.Column) error {
+	return vecEvalVecDistance(ctx, b.args, b.bufAllocator, input, result, types.VectorFloat32.CosineDistance)
+}
+
+func (b *builtinVecL2NormSig) vectorized() bool {
+	return true
+}
+
+func (b *builtinVecL2NormSig) vecEvalReal(ctx EvalContext, input *chunk.Chunk, result *chunk.Column) error {
+	n := input.NumRows()
+	buf, err := b.bufAllocator.get()
+	if err != nil {
+		return err
+	}
+	defer b.bufAllocator.put(buf)
+	if err := b.args[0].VecEvalVectorFloat32(ctx, input, buf); err != nil {
+		return err
+	}
+	result.ResizeFloat64(n, false)
+	result.MergeNulls(buf)
+	f64s := result.Float64s()
+	for i := 0; i < n; i++ {
+		if result.IsNull(i) {
+			continue
+		}
+		f64s[i] = buf.GetVectorFloat32(i).L2Norm()
+	}
+	return nil
+}
+
+func (b *builtinVecFromTextSig) vectorized() bool {
+	return true
+}
+
+func (b *builtinVecFromTextSig) vecEvalVectorFloat32(ctx EvalContext, input *chunk.Chunk, result *chunk.Column) error {
+	n := input.NumRows()
+	buf, err := b.bufAllocator.get()
+	if err != nil {
+		return err
+	}
+	defer b.bufAllocator.put(buf)
+	if err := b.args[0].VecEvalString(ctx, input, buf); err != nil {
+		return err
+	}
+	result.ReserveVectorFloat32(n)
+	for i := 0; i < n; i++ {
+		if buf.IsNull(i) {
+			result.AppendNull()
+			continue
+		}
+		vec, err := types.ParseVectorFloat32(buf.GetString(i))
+		if err != nil {
+			return err
+		}
+		result.AppendVectorFloat32(vec)
+	}
+	return nil
+}
+
+func (b *builtinVecAsTextSig) vectorized() bool {
+	return true
+}
+
+func (b *builtinVecAsTextSig) vecEvalString(ctx EvalContext, input *chunk.Chunk, result *chunk.Column) error {
+	n := input.NumRows()
+	buf, err := b.bufAllocator.get()
+	if err != nil {
+		return err
+	}
+	defer b.bufAllocator.put(buf)
+	if err := b.args[0].VecEvalVectorFloat32(ctx, input, buf); err != nil {
+		return err
+	}
+	result.ReserveString(n)
+	for i := 0; i < n; i++ {
+		if buf.IsNull(i) {
+			result.AppendNull()
+			continue
+		}
+		result.AppendString(buf.GetVectorFloat32(i).String())
+	}
+	return nil
+}
@@ -141,6 +141,12 @@ func getSignatureByPB(ctx BuildContext, sigCode tipb.ScalarFuncSig, tp *tipb.Fie
 		f = &builtinCastJSONAsDurationSig{base}
 	case tipb.ScalarFuncSig_CastJsonAsJson:
 		f = &builtinCastJSONAsJSONSig{base}
+	case tipb.ScalarFuncSig_CastStringAsVectorFloat32:
+		f = &builtinCastStringAsVectorFloat32Sig{base}
+	case tipb.ScalarFuncSig_CastVectorFloat32AsString:
+		f = &builtinCastVectorFloat32AsStringSig{base}
+	case tipb.ScalarFuncSig_CastVectorFloat32AsVectorFloat32:
+		f = &builtinCastVectorFloat32AsVectorFloat32Sig{base}
 	case tipb.ScalarFuncSig_CoalesceInt:
 		f = &builtinCoalesceIntSig{base}
 	case tipb.ScalarFuncSig_CoalesceReal:
@@ -155,6 +161,8 @@ func getSignatureByPB(ctx BuildContext, sigCode tipb.ScalarFuncSig, tp *tipb.Fie
 		f = &builtinCoalesceDurationSig{base}
 	case tipb.ScalarFuncSig_CoalesceJson:
 		f = &builtinCoalesceJSONSig{base}
+	case tipb.ScalarFuncSig_CoalesceVectorFloat32:
+		f = &builtinCoalesceVectorFloat32Sig{base}
 	case tipb.ScalarFuncSig_LTInt:
 		f = &builtinLTIntSig{base}
 	case tipb.ScalarFuncSig_LTReal:
@@ -169,6 +177,8 @@ func getSignatureByPB(ctx BuildContext, sigCode tipb.ScalarFuncSig, tp *tipb.Fie
 		f = &builtinLTDurationSig{base}
 	case tipb.ScalarFuncSig_LTJson:
 		f = &builtinLTJSONSig{base}
+	case tipb.ScalarFuncSig_LTVectorFloat32:
+		f = &builtinLTVectorFloat32Sig{base}
 	case tipb.ScalarFuncSig_LEInt:
 		f = &builtinLEIntSig{base}
 	case tipb.ScalarFuncSig_LEReal:
@@ -183,6 +193,8 @@ func getSignatureByPB(ctx BuildContext, sigCode tipb.ScalarFuncSig, tp *tipb.Fie
 		f = &builtinLEDurationSig{base}
 	case tipb.ScalarFuncSig_LEJson:
 		f = &builtinLEJSONSig{base}
+	case tipb.ScalarFuncSig_LEVectorFloat32:
+		f = &builtinLEVectorFloat32Sig{base}
 	case tipb.ScalarFuncSig_GTInt:
 		f = &builtinGTIntSig{base}
 	case tipb.ScalarFuncSig_GTReal:
@@ -197,6 +209,8 @@ func getSignatureByPB(ctx BuildContext, sigCode tipb.ScalarFuncSig, tp *tipb.Fie
 		f = &builtinGTDurationSig{base}
 	case tipb.ScalarFuncSig_GTJson:
 		f = &builtinGTJSONSig{base}
+	case tipb.ScalarFuncSig_GTVectorFloat32:
+		f = &builtinGTVectorFloat32Sig{base}
 	case tipb.ScalarFuncSig_GreatestInt:
 		f = &builtinGreatestIntSig{base}
 	case tipb.ScalarFuncSig_GreatestReal:
@@ -251,6 +265,8 @@ func getSignatureByPB(ctx BuildContext, sigCode tipb.ScalarFuncSig, tp *tipb.Fie
 		f = &builtinGEDurationSig{base}
 	case tipb.ScalarFuncSig_GEJson:
 		f = &builtinGEJSONSig{base}
+	case tipb.ScalarFuncSig_GEVectorFloat32:
+		f = &builtinGEVectorFloat32Sig{base}
 	case tipb.ScalarFuncSig_EQInt:
 		f = &builtinEQIntSig{base}
 	case tipb.ScalarFuncSig_EQReal:
@@ -265,6 +281,8 @@ func getSignatureByPB(ctx BuildContext, sigCode tipb.ScalarFuncSig, tp *tipb.Fie
 		f = &builtinEQDurationSig{base}
 	case tipb.ScalarFuncSig_EQJson:
 		f = &builtinEQJSONSig{base}
+	case tipb.ScalarFuncSig_EQVectorFloat32:
+		f = &builtinEQVectorFloat32Sig{base}
 	case tipb.ScalarFuncSig_NEInt:
 		f = &builtinNEIntSig{base}
 	case tipb.ScalarFuncSig_NEReal:
@@ -279,6 +297,8 @@ func getSignatureByPB(ctx BuildContext, sigCode tipb.ScalarFuncSig, tp *tipb.Fie
 		f = &builtinNEDurationSig{base}
 	case tipb.ScalarFuncSig_NEJson:
 		f = &builtinNEJSONSig{base}
+	case tipb.ScalarFuncSig_NEVectorFloat32:
+		f = &builtinNEVectorFloat32Sig{base}
 	case tipb.ScalarFuncSig_NullEQInt:
 		f = &builtinNullEQIntSig{base}
 	case tipb.ScalarFuncSig_NullEQReal:
@@ -293,6 +313,8 @@ func getSignatureByPB(ctx BuildContext, sigCode tipb.ScalarFuncSig, tp *tipb.Fie
 		f = &builtinNullEQDurationSig{base}
 	case tipb.ScalarFuncSig_NullEQJson:
 		f = &builtinNullEQJSONSig{base}
+	case tipb.ScalarFuncSig_NullEQVectorFloat32:
+		f = &builtinNullEQVectorFloat32Sig{base}
 	case tipb.ScalarFuncSig_PlusReal:
 		f = &builtinArithmeticPlusRealSig{base}
 	case tipb.ScalarFuncSig_PlusDecimal:
@@ -556,8 +578,12 @@ func getSignatureByPB(ctx BuildContext, sigCode tipb.ScalarFuncSig, tp *tipb.Fie
 		f = &builtinIfDurationSig{base}
 	case tipb.ScalarFuncSig_IfNullJson:
 		f = &builtinIfNullJSONSig{base}
+	case tipb.ScalarFuncSig_IfNullVectorFloat32:
+		f = &builtinIfNullVectorFloat32Sig{base}
 	case tipb.ScalarFuncSig_IfJson:
 		f = &builtinIfJSONSig{base}
+	case tipb.ScalarFuncSig_IfVectorFloat32:
+		f = &builtinIfVectorFloat32Sig{base}
 	case tipb.ScalarFuncSig_CaseWhenInt:
 		f = &builtinCaseWhenIntSig{base}
 	case tipb.ScalarFuncSig_CaseWhenReal:
@@ -572,6 +598,8 @@ func getSignatureByPB(ctx BuildContext, sigCode tipb.ScalarFuncSig, tp *tipb.Fie
 		f = &builtinCaseWhenDurationSig{base}
 	case tipb.ScalarFuncSig_CaseWhenJson:
 		f = &builtinCaseWhenJSONSig{base}
+	case tipb.ScalarFuncSig_CaseWhenVectorFloat32:
+		f = &builtinCaseWhenVectorFloat32Sig{base}
 	// case tipb.ScalarFuncSig_AesDecrypt:
 	// 	f = &builtinAesDecryptSig{base}
 	// case tipb.ScalarFuncSig_AesEncrypt:
@@ -721,6 +749,22 @@ func getSignatureByPB(ctx BuildContext, sigCode tipb.ScalarFuncSig, tp *tipb.Fie
 		f = &builtinJSONValidOthersSig{base}
 	case tipb.ScalarFuncSig_JsonMemberOfSig:
 		f = &builtinJSONMemberOfSig{base}
+	case tipb.ScalarFuncSig_VecDimsSig:
+		f = &builtinVecDimsSig{base}
+	case tipb.ScalarFuncSig_VecL1DistanceSig:
+		f = &builtinVecL1DistanceSig{base}
+	case tipb.ScalarFuncSig_VecL2DistanceSig:
+		f = &builtinVecL2DistanceSig{base}
+	case tipb.ScalarFuncSig_VecNegativeInnerProductSig:
+		f = &builtinVecNegativeInnerProductSig{base}
+	case tipb.ScalarFuncSig_VecCosineDistanceSig:
+		f = &builtinVecCosineDistanceSig{base}
+	case tipb.ScalarFuncSig_VecL2NormSig:
+		f = &builtinVecL2NormSig{base}
+	case tipb.ScalarFuncSig_VecFromTextSig:
+		f = &builtinVecFromTextSig{base}
+	case tipb.ScalarFuncSig_VecAsTextSig:
+		f = &builtinVecAsTextSig{base}
 	case tipb.ScalarFuncSig_DateFormatSig:
 		f = &builtinDateFormatSig{base}
 	// case tipb.ScalarFuncSig_DateLiteral:
@@ -1149,6 +1193,8 @@ func PBToExpr(ctx BuildContext, expr *tipb.Expr, tps []*types.FieldType) (Expres
 		return convertJSON(expr.Val)
 	case tipb.ExprType_MysqlEnum:
 		return convertEnum(expr.Val, expr.FieldType)
+	case tipb.ExprType_TiDBVectorFloat32:
+		return convertVectorFloat32(expr.Val)
 	}
 	if expr.Tp != tipb.ExprType_ScalarFunc {
 		panic("should be a tipb.ExprType_ScalarFunc")
@@ -1293,6 +1339,14 @@ func convertJSON(val []byte) (*Constant, error) {
 	return &Constant{Value: d, RetType: types.NewFieldType(mysql.TypeJSON)}, nil
 }
 
+func convertVectorFloat32(val []byte) (*Constant, error) {
+	v, _, err := types.ZeroCopyDeserializeVectorFloat32(val)
+	if err != nil {
+		return nil, errors.Errorf("invalid vector % x", val)
+	}
+	return &Constant{Value: types.NewVectorFloat32Datum(v), RetType: types.NewFieldType(mysql.TypeTiDBVectorFloat32)}, nil
+}
+
 func convertEnum(val []byte, tp *tipb.FieldType) (*Constant, error) {
 	_, uVal, err := codec.DecodeUint(val)
 	if err != nil {
@@ -27,6 +27,7 @@ import (
 	"github.com/pingcap/tidb/pkg/parser/charset"
 	"github.com/pingcap/tidb/pkg/parser/mysql"
 	"github.com/pingcap/tidb/pkg/types"
+	"github.com/pingcap/tidb/pkg/util/chunk"
 	"github.com/pingcap/tidb/pkg/util/mock"
 	"github.com/pingcap/tipb/go-tipb"
 	"github.com/stretchr/testify/require"
@@ -1443,6 +1444,62 @@ func TestExprOnlyPushDownToFlash(t *testing.T) {
 	require.Len(t, remained, len(exprs))
 }
 
+func TestVectorExprPushDown(t *testing.T) {
+	ctx := mock.NewContext()
+	client := new(mock.Client)
+	pushDownCtx := NewPushDownContextFromSessionVars(ctx, ctx.GetSessionVars(), client)
+
+	vecColumn := genColumn(mysql.TypeTiDBVectorFloat32, 1)
+	vecConstant := &Constant{
+		Value:   types.NewVectorFloat32Datum(types.CreateVectorFloat32([]float32{1, 2})),
+		RetType: types.NewFieldType(mysql.TypeTiDBVectorFloat32),
+	}
+	exprs := make([]Expression, 0)
+	for _, name := range []string{ast.VecL1Distance, ast.VecL2Distance, ast.VecNegativeInnerProduct, ast.VecCosineDistance} {
+		function, err := NewFunction(ctx, name, types.NewFieldType(mysql.TypeDouble), vecColumn, vecConstant)
+		require.NoError(t, err)
+		exprs = append(exprs, function)
+	}
+	function, err := NewFunction(ctx, ast.VecL2Norm, types.NewFieldType(mysql.TypeDouble), vecColumn)
+	require.NoError(t, err)
+	exprs = append(exprs, function)
+	function, err = NewFunction(ctx, ast.VecDims, types.NewFieldType(mysql.TypeLonglong), vecColumn)
+	require.NoError(t, err)
+	exprs = append(exprs, function)
+	function, err = NewFunction(ctx, ast.VecAsText, types.NewFieldType(mysql.TypeString), vecColumn)
+	require.NoError(t, err)
+	exprs = append(exprs, function)
+
+	// The vector functions are only pushed down to TiFlash.
+	require.True(t, CanExprsPushDown(pushDownCtx, exprs, kv.TiFlash))
+	for _, expr := range exprs {
+		require.False(t, CanExprsPushDown(pushDownCtx, []Expression{expr}, kv.TiKV))
+	}
+	// The other functions don't process the vector values in TiFlash.
+	function, err = NewFunction(ctx, ast.EQ, types.NewFieldType(mysql.TypeLonglong), vecColumn, vecConstant)
+	require.NoError(t, err)
+	require.False(t, CanExprsPushDown(pushDownCtx, []Expression{function}, kv.TiFlash))
+
+	// The pushed down functions are decoded with the same results.
+	pbExprs, err := ExpressionsToPBList(ctx, exprs, client)
+	require.NoError(t, err)
+	fieldTps := []*types.FieldType{types.NewFieldType(mysql.TypeLonglong), vecColumn.RetType}
+	decoded, err := PBToExprs(ctx, pbExprs, fieldTps)
+	require.NoError(t, err)
+	row := chunk.MutRowFromDatums([]types.Datum{types.NewIntDatum(0), types.NewVectorFloat32Datum(types.CreateVectorFloat32([]float32{4, 6}))}).ToRow()
+	for i, expr := range exprs {
+		expected, err := expr.Eval(ctx, row)
+		require.NoError(t, err)
+		actual, err := decoded[i].Eval(ctx, row)
+		require.NoError(t, err)
+		expectedStr, err := expected.ToString()
+		require.NoError(t, err)
+		actualStr, err := actual.ToString()
+		require.NoError(t, err)
+		require.Equal(t, expectedStr, actualStr, expr.StringWithCtx(ctx))
+	}
+}
+
 func TestExprPushDownToTiKV(t *testing.T) {
 	client := new(mock.Client)
 
@@ -121,6 +121,12 @@ func canScalarFuncPushDown(ctx PushDownContext, scalarFunc *ScalarFunction, stor
 
 func canExprPushDown(ctx PushDownContext, expr Expression, storeType kv.StoreType, canEnumPush bool) bool {
 	pc := ctx.PbConverter()
+	if storeType != kv.TiFlash && expr.GetType(ctx.EvalCtx()).GetType() == mysql.TypeTiDBVectorFloat32 {
+		// Only TiFlash can process the vector values.
+		warnErr := errors.NewNoStackError("Expression about '" + expr.StringWithCtx(ctx.EvalCtx()) + "' can not be pushed to " + storeType.Name() + " because it contains vector type.")
+		ctx.AppendWarning(warnErr)
+		return false
+	}
 	if storeType == kv.TiFlash {
 		switch expr.GetType(ctx.EvalCtx()).GetType() {
 		case mysql.TypeEnum, mysql.TypeBit, mysql.TypeSet, mysql.TypeGeometry, mysql.TypeUnspecified:
@@ -256,6 +262,20 @@ func scalarExprSupportedByTiKV(ctx EvalContext, sf *ScalarFunction) bool {
 }
 
 func scalarExprSupportedByFlash(ctx EvalContext, function *ScalarFunction) bool {
+	switch function.FuncName.L {
+	case ast.VecDims, ast.VecL1Distance, ast.VecL2Distance, ast.VecNegativeInnerProduct, ast.VecCosineDistance,
+		ast.VecL2Norm, ast.VecNorm, ast.VecAsText:
+		return true
+	}
+	// The vector values are only processed by the vector functions in TiFlash.
+	if function.RetType.GetType() == mysql.TypeTiDBVectorFloat32 {
+		return false
+	}
+	for _, arg := range function.GetArgs() {
+		if arg.GetType(ctx).GetType() == mysql.TypeTiDBVectorFloat32 {
+			return false
+		}
+	}
 	switch function.FuncName.L {
 	case ast.Floor, ast.Ceil, ast.Ceiling:
 		switch function.Function.PbCode() {
@@ -58,26 +58,50 @@ import (
 )
 
 func TestVector(t *testing.T) {
-	// Currently we only allow parsing Vector type, but not using it.
-
 	store := testkit.CreateMockStore(t)
 
 	tk := testkit.NewTestKit(t, store)
 	tk.MustExec("use test")
 
-	err := tk.ExecToErr("CREATE TABLE c(a VECTOR)")
-	require.ErrorContains(t, err, "vector type is not supported")
-	err = tk.ExecToErr("CREATE TABLE c(a VECTOR(3))")
-	require.ErrorContains(t, err, "vector type is not supported")
-	err = tk.ExecToErr("SELECT CAST('123' AS VECTOR)")
-	require.ErrorContains(t, err, "vector type is not supported")
-
-	tk.MustExec("CREATE TABLE c(pk INT)")
+	err := tk.ExecToErr("CREATE TABLE c(a VECTOR(0))")
+	require.ErrorContains(t, err, "dimensions for type vector must be at least 1")
+	err = tk.ExecToErr("CREATE TABLE c(a VECTOR(16384))")
+	require.ErrorContains(t, err, "vector cannot have more than 16383 dimensions")
+	err = tk.ExecToErr("SELECT CAST('[1]' AS VECTOR(0))")
+	require.ErrorContains(t, err, "dimensions for type vector must be at least 1")
+
+	tk.MustExec("CREATE TABLE c(pk INT PRIMARY KEY, a VECTOR, b VECTOR(3))")
+	tk.MustExec("INSERT INTO c VALUES (1, '[1,2]', '[1,2,3]'), (2, '[]', '[0,0,0]'), (3, NULL, NULL)")
+	tk.MustQuery("SELECT a, b FROM c ORDER BY pk").Check(testkit.Rows("[1,2] [1,2,3]", "[] [0,0,0]", "<nil> <nil>"))
+	err = tk.ExecToErr("INSERT INTO c VALUES (4, '[1]', '[1,2]')")
+	require.ErrorContains(t, err, "vector has 2 dimensions, does not fit VECTOR(3)")
+	err = tk.ExecToErr("INSERT INTO c VALUES (4, 'abc', NULL)")
+	require.ErrorContains(t, err, "Invalid vector text")
+
+	tk.MustQuery("SELECT VEC_DIMS(a), VEC_DIMS(b) FROM c ORDER BY pk").Check(testkit.Rows("2 3", "0 3", "<nil> <nil>"))
+	tk.MustQuery("SELECT VEC_L2_DISTANCE(b, '[1,2,4]'), VEC_COSINE_DISTANCE(b, '[2,4,6]') FROM c ORDER BY pk").
+		Check(testkit.Rows("1 0", "4.58257569495584 <nil>", "<nil> <nil>"))
+	tk.MustQuery("SELECT VEC_NORM(b), VEC_L2_NORM(a) FROM c WHERE pk = 1").Check(testkit.Rows("3.7416573867739413 2.23606797749979"))
+	tk.MustQuery("SELECT VEC_L1_DISTANCE(b, '[0,0,0]'), VEC_NEGATIVE_INNER_PRODUCT(b, '[1,1,1]') FROM c WHERE pk = 1").Check(testkit.Rows("6 -6"))
+	tk.MustQuery("SELECT VEC_AS_TEXT(VEC_FROM_TEXT('[1.5, 2]'))").Check(testkit.Rows("[1.5,2]"))
+	err = tk.ExecToErr("SELECT VEC_L2_DISTANCE(a, b) FROM c WHERE pk = 1")
+	require.ErrorContains(t, err, "vectors have different dimensions: 2 and 3")
+
+	tk.MustQuery("SELECT pk FROM c WHERE b = '[1,2,3]'").Check(testkit.Rows("1"))
+	// TiKV can't process the vector values, so the filters are kept in TiDB.
+	tk.MustQuery("SELECT pk FROM c WHERE VEC_DIMS(b) = 3 ORDER BY pk").Check(testkit.Rows("1", "2"))
+	for _, row := range tk.MustQuery("EXPLAIN FORMAT = 'brief' SELECT pk FROM c WHERE VEC_DIMS(b) = 3").Rows() {
+		if strings.Contains(row[0].(string), "Selection") {
+			require.Equal(t, "root", row[2])
+		}
+	}
+	tk.MustQuery("SELECT pk FROM c WHERE b IS NOT NULL ORDER BY b").Check(testkit.Rows("2", "1"))
+	tk.MustQuery("SELECT CAST(CAST('[1,2]' AS JSON) AS VECTOR), CAST(b AS CHAR) FROM c WHERE pk = 1").Check(testkit.Rows("[1,2] [1,2,3]"))
+	err = tk.ExecToErr("SELECT CAST(b AS SIGNED) FROM c")
+	require.ErrorContains(t, err, "cannot cast from vector to")
 
-	err = tk.ExecToErr("ALTER TABLE c ADD COLUMN a VECTOR")
-	require.ErrorContains(t, err, "vector type is not supported")
-	err = tk.ExecToErr("ALTER TABLE c MODIFY pk VECTOR")
-	require.ErrorContains(t, err, "vector type is not supported")
+	err = tk.ExecToErr("CREATE INDEX idx ON c(b)")
+	require.ErrorContains(t, err, "using a VECTOR column as index key")
 
 	tk.MustExec("DROP TABLE c")
 }
@@ -97,6 +97,8 @@ func (b *Builder) ApplyDiff(m *meta.Meta, diff *model.SchemaDiff) ([]int64, erro
 		return applyReorganizePartition(b, m, diff)
 	case model.ActionExchangeTablePartition:
 		return applyExchangeTablePartition(b, m, diff)
+	case model.ActionCreateMaterializedView, model.ActionDropMaterializedView:
+		return applyMaterializedView(b, m, diff)
 	case model.ActionFlashbackCluster:
 		return []int64{-1}, nil
 	default:
@@ -147,6 +149,58 @@ func applyDropTableOrPartition(b *Builder, m *meta.Meta, diff *model.SchemaDiff)
 	return tblIDs, nil
 }
 
+// applyMaterializedView applies the diff of a materialized view. The view and
+// its log table are created or dropped like normal tables, the other affected
+// tables are the base tables of the view.
+func applyMaterializedView(b *Builder, m *meta.Meta, diff *model.SchemaDiff) ([]int64, error) {
+	tp := model.ActionCreateTable
+	if diff.Type == model.ActionDropMaterializedView {
+		tp = model.ActionDropTable
+	}
+	tblIDs, err := b.ApplyDiff(m, &model.SchemaDiff{
+		Version:  diff.Version,
+		Type:     tp,
+		SchemaID: diff.SchemaID,
+		TableID:  diff.TableID,
+	})
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	for _, opt := range diff.AffectedOpts {
+		var affectedIDs []int64
+		switch {
+		case opt.OldTableID == 0:
+			affectedIDs, err = b.ApplyDiff(m, &model.SchemaDiff{
+				Version:  diff.Version,
+				Type:     model.ActionCreateTable,
+				SchemaID: opt.SchemaID,
+				TableID:  opt.TableID,
+			})
+		case opt.TableID == 0:
+			affectedIDs, err = b.ApplyDiff(m, &model.SchemaDiff{
+				Version:  diff.Version,
+				Type:     model.ActionDropTable,
+				SchemaID: opt.OldSchemaID,
+				TableID:  opt.OldTableID,
+			})
+		default:
+			affectedIDs, err = applyTableUpdate(b, m, &model.SchemaDiff{
+				Version:     diff.Version,
+				Type:        diff.Type,
+				SchemaID:    opt.SchemaID,
+				TableID:     opt.TableID,
+				OldSchemaID: opt.OldSchemaID,
+				OldTableID:  opt.OldTableID,
+			})
+		}
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		tblIDs = append(tblIDs, affectedIDs...)
+	}
+	return tblIDs, nil
+}
+
 func applyReorganizePartition(b *Builder, m *meta.Meta, diff *model.SchemaDiff) ([]int64, error) {
 	tblIDs, err := applyTableUpdate(b, m, diff)
 	if err != nil {
@@ -351,6 +351,19 @@ func AllSchemaNames(is InfoSchema) (names []string) {
 	return
 }
 
+// FindTrigger finds the trigger with the given name in the schema, and returns
+// the trigger and the table which the trigger belongs to.
+func FindTrigger(is context.MetaOnlyInfoSchema, schema, name model.CIStr) (*model.TableInfo, *model.TriggerInfo, bool) {
+	for _, tblInfo := range is.SchemaTableInfos(schema) {
+		for _, trigger := range tblInfo.Triggers {
+			if trigger.Name.L == name.L {
+				return tblInfo, trigger, true
+			}
+		}
+	}
+	return nil, nil, false
+}
+
 func (is *infoSchema) AllSchemas() (schemas []*model.DBInfo) {
 	for _, v := range is.schemaMap {
 		schemas = append(schemas, v.dbInfo)
@@ -1145,8 +1145,13 @@ var PartitionAttribute specialAttributeFilter = func(t *model.TableInfo) bool {
 	return t.GetPartitionInfo() != nil
 }
 
+// TriggerAttribute is the Trigger attribute filter used by ListTablesWithSpecialAttribute.
+var TriggerAttribute specialAttributeFilter = func(t *model.TableInfo) bool {
+	return len(t.Triggers) > 0
+}
+
 func hasSpecialAttributes(t *model.TableInfo) bool {
-	return TTLAttribute(t) || TiFlashAttribute(t) || PlacementPolicyAttribute(t) || PartitionAttribute(t)
+	return TTLAttribute(t) || TiFlashAttribute(t) || PlacementPolicyAttribute(t) || PartitionAttribute(t) || TriggerAttribute(t)
 }
 
 // AllSpecialAttribute marks a model.TableInfo with any special attributes.
@@ -18,7 +18,10 @@ import (
 	"github.com/pingcap/tidb/pkg/parser/format"
 )
 
-var _ StmtNode = &IndexAdviseStmt{}
+var (
+	_ StmtNode = &IndexAdviseStmt{}
+	_ StmtNode = &RecommendIndexStmt{}
+)
 
 // IndexAdviseStmt is used to advise indexes
 type IndexAdviseStmt struct {
@@ -83,3 +86,35 @@ func (n *MaxIndexNumClause) Restore(ctx *format.RestoreCtx) error {
 	}
 	return nil
 }
+
+// RecommendIndexStmt is used to recommend indexes for the workload or a statement.
+type RecommendIndexStmt struct {
+	stmtNode
+
+	// Action is the action of the statement, only "run" is supported now.
+	Action string
+	// SQL is the statement to recommend indexes for. If it's empty, the indexes
+	// are recommended for the statements in the statement summary.
+	SQL string
+}
+
+// Restore implements Node interface.
+func (n *RecommendIndexStmt) Restore(ctx *format.RestoreCtx) error {
+	ctx.WriteKeyWord("RECOMMEND INDEX ")
+	ctx.WriteKeyWord(n.Action)
+	if n.SQL != "" {
+		ctx.WriteKeyWord(" FOR ")
+		ctx.WriteString(n.SQL)
+	}
+	return nil
+}
+
+// Accept implements Node Accept interface.
+func (n *RecommendIndexStmt) Accept(v Visitor) (Node, bool) {
+	newNode, skipChildren := v.Enter(n)
+	if skipChildren {
+		return v.Leave(newNode)
+	}
+	n = newNode.(*RecommendIndexStmt)
+	return v.Leave(n)
+}
@@ -356,6 +356,48 @@ const (
 	JSONKeys          = "json_keys"
 	JSONLength        = "json_length"
 
+	// vector functions (tidb extension)
+	VecDims                 = "vec_dims"
+	VecL1Distance           = "vec_l1_distance"
+	VecL2Distance           = "vec_l2_distance"
+	VecNegativeInnerProduct = "vec_negative_inner_product"
+	VecCosineDistance       = "vec_cosine_distance"
+	VecL2Norm               = "vec_l2_norm"
+	VecNorm                 = "vec_norm"
+	VecFromText             = "vec_from_text"
+	VecAsText               = "vec_as_text"
+
+	// spatial functions
+	STGeomFromText       = "st_geomfromtext"
+	STGeometryFromText   = "st_geometryfromtext"
+	STPointFromText      = "st_pointfromtext"
+	STLineFromText       = "st_linefromtext"
+	STLineStringFromText = "st_linestringfromtext"
+	STPolyFromText       = "st_polyfromtext"
+	STPolygonFromText    = "st_polygonfromtext"
+	STGeomFromWKB        = "st_geomfromwkb"
+	STGeometryFromWKB    = "st_geometryfromwkb"
+	Point                = "point"
+	LineString           = "linestring"
+	Polygon              = "polygon"
+	STAsText             = "st_astext"
+	STAsWKT              = "st_aswkt"
+	STAsBinary           = "st_asbinary"
+	STAsWKB              = "st_aswkb"
+	STSRID               = "st_srid"
+	STX                  = "st_x"
+	STY                  = "st_y"
+	STGeometryType       = "st_geometrytype"
+	STContains           = "st_contains"
+	STWithin             = "st_within"
+	STIntersects         = "st_intersects"
+	STDisjoint           = "st_disjoint"
+	STDistance           = "st_distance"
+	STDistanceSphere     = "st_distance_sphere"
+
+	// fulltext search function (tidb internal), MATCH ... AGAINST is rewritten to it.
+	FTSMatchAgainst = "fts_match_against"
+
 	// TiDB internal function.
 	TiDBDecodeKey       = "tidb_decode_key"
 	TiDBDecodeBase64Key = "tidb_decode_base64_key"
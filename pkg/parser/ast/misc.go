@@ -60,6 +60,7 @@ var (
 	_ StmtNode = &HelpStmt{}
 	_ StmtNode = &PlanReplayerStmt{}
 	_ StmtNode = &CompactTableStmt{}
+	_ StmtNode = &RefreshMaterializedViewStmt{}
 	_ StmtNode = &SetResourceGroupStmt{}
 
 	_ Node = &PrivElem{}
@@ -479,6 +480,46 @@ func (n *CompactTableStmt) Accept(v Visitor) (Node, bool) {
 	return v.Leave(n)
 }
 
+// RefreshMaterializedViewStmt is a statement to bring the stored result of a
+// materialized view up to date.
+type RefreshMaterializedViewStmt struct {
+	stmtNode
+
+	ViewName *TableName
+	// HasMethod is false if the method is not specified, the view is refreshed
+	// with the method it's created with.
+	HasMethod bool
+	Method    model.MViewRefreshMethod
+}
+
+// Restore implements Node interface.
+func (n *RefreshMaterializedViewStmt) Restore(ctx *format.RestoreCtx) error {
+	ctx.WriteKeyWord("REFRESH MATERIALIZED VIEW ")
+	if err := n.ViewName.Restore(ctx); err != nil {
+		return errors.Annotate(err, "An error occurred while restore RefreshMaterializedViewStmt.ViewName")
+	}
+	if n.HasMethod {
+		ctx.WritePlain(" ")
+		ctx.WriteKeyWord(n.Method.String())
+	}
+	return nil
+}
+
+// Accept implements Node Accept interface.
+func (n *RefreshMaterializedViewStmt) Accept(v Visitor) (Node, bool) {
+	newNode, skipChildren := v.Enter(n)
+	if skipChildren {
+		return v.Leave(newNode)
+	}
+	n = newNode.(*RefreshMaterializedViewStmt)
+	node, ok := n.ViewName.Accept(v)
+	if !ok {
+		return n, false
+	}
+	n.ViewName = node.(*TableName)
+	return v.Leave(n)
+}
+
 // PrepareStmt is a statement to prepares a SQL statement which contains placeholders,
 // and it is executed with ExecuteStmt and released with DeallocateStmt.
 // See https://dev.mysql.com/doc/refman/5.7/en/prepare.html
@@ -2476,6 +2517,8 @@ type AdminStmt struct {
 	StatementScope StatementScope
 	LimitSimple    LimitSimple
 	BDRRole        BDRRole
+	// SQLDigest is the digest of the bindings to evolve, it's set by ADMIN EVOLVE BINDINGS FOR SQL DIGEST.
+	SQLDigest string
 }
 
 // Restore implements Node interface.
@@ -2614,6 +2657,10 @@ func (n *AdminStmt) Restore(ctx *format.RestoreCtx) error {
 		ctx.WriteKeyWord("CAPTURE BINDINGS")
 	case AdminEvolveBindings:
 		ctx.WriteKeyWord("EVOLVE BINDINGS")
+		if n.SQLDigest != "" {
+			ctx.WriteKeyWord(" FOR SQL DIGEST ")
+			ctx.WriteString(n.SQLDigest)
+		}
 	case AdminReloadBindings:
 		ctx.WriteKeyWord("RELOAD BINDINGS")
 	case AdminReloadStatistics:
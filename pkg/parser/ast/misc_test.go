@@ -767,6 +767,26 @@ func TestDeniedByBDR(t *testing.T) {
 		{ast.BDRRolePrimary, model.ActionRemovePartitioning, true},
 		{ast.BDRRoleSecondary, model.ActionRemovePartitioning, true},
 		{ast.BDRRoleNone, model.ActionRemovePartitioning, false},
+
+		// Roles for ActionCreateTrigger
+		{ast.BDRRolePrimary, model.ActionCreateTrigger, true},
+		{ast.BDRRoleSecondary, model.ActionCreateTrigger, true},
+		{ast.BDRRoleNone, model.ActionCreateTrigger, false},
+
+		// Roles for ActionDropTrigger
+		{ast.BDRRolePrimary, model.ActionDropTrigger, true},
+		{ast.BDRRoleSecondary, model.ActionDropTrigger, true},
+		{ast.BDRRoleNone, model.ActionDropTrigger, false},
+
+		// Roles for ActionCreateMaterializedView
+		{ast.BDRRolePrimary, model.ActionCreateMaterializedView, true},
+		{ast.BDRRoleSecondary, model.ActionCreateMaterializedView, true},
+		{ast.BDRRoleNone, model.ActionCreateMaterializedView, false},
+
+		// Roles for ActionDropMaterializedView
+		{ast.BDRRolePrimary, model.ActionDropMaterializedView, true},
+		{ast.BDRRoleSecondary, model.ActionDropMaterializedView, true},
+		{ast.BDRRoleNone, model.ActionDropMaterializedView, false},
 	}
 
 	for _, tc := range testCases {
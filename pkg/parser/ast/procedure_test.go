@@ -15,10 +15,13 @@ package ast_test
 
 import (
 	"fmt"
+	"strings"
 	"testing"
 
 	"github.com/pingcap/tidb/pkg/parser"
 	"github.com/pingcap/tidb/pkg/parser/ast"
+	"github.com/pingcap/tidb/pkg/parser/format"
+	"github.com/pingcap/tidb/pkg/parser/model"
 	"github.com/stretchr/testify/require"
 )
 
@@ -99,6 +102,8 @@ func TestProcedure(t *testing.T) {
 		`create procedure proc_2() begin labelname: while id < 10 do set id = id + 1; select 1; end while; end`,
 		`create procedure proc_2() begin labelname: while id < 10 do set id = id + 1; select 1; end while labelname; end`,
 		`create procedure proc_2(id int) begin labelname: REPEAT set id = id + 1; select 1; UNTIL id < 10 end REPEAT labelname; end`,
+		`create procedure proc_2(id int) begin call proc_1(id, @a); call test.proc_3(); end`,
+		`create procedure proc_2(id int) begin labelname: LOOP set id = id + 1; if id > 10 then leave labelname; end if; end LOOP labelname; end`,
 	}
 	for _, testcase := range testcases {
 		stmt, _, err := p.Parse(testcase, "", "")
@@ -111,6 +116,31 @@ func TestProcedure(t *testing.T) {
 	}
 }
 
+func TestProcedureSQLSecurity(t *testing.T) {
+	p := parser.New()
+	for sql, security := range map[string]model.ViewSecurity{
+		"create procedure proc_2() select 1":                      model.SecurityDefiner,
+		"create procedure proc_2() sql security definer select 1": model.SecurityDefiner,
+		"create procedure proc_2() sql security invoker select 1": model.SecurityInvoker,
+	} {
+		stmt, err := p.ParseOneStmt(sql, "", "")
+		require.NoError(t, err)
+		proc := stmt.(*ast.ProcedureInfo)
+		require.Equal(t, security, proc.Security, sql)
+		require.Equal(t, "select 1", proc.ProcedureBody.Text())
+		require.Equal(t, "", proc.ProcedureParamStr)
+	}
+	stmt, err := p.ParseOneStmt("create procedure proc_2(in id int) sql security invoker begin select id; end", "", "")
+	require.NoError(t, err)
+	require.Equal(t, "in id int", stmt.(*ast.ProcedureInfo).ProcedureParamStr)
+	require.Equal(t, "begin select id; end", stmt.(*ast.ProcedureInfo).ProcedureBody.Text())
+	stmt, err = p.ParseOneStmt("create procedure proc_2() sql security invoker begin select 1; end", "", "")
+	require.NoError(t, err)
+	var sb strings.Builder
+	require.NoError(t, stmt.Restore(format.NewRestoreCtx(format.DefaultRestoreFlags, &sb)))
+	require.Equal(t, "CREATE PROCEDURE `proc_2`() SQL SECURITY INVOKER BEGIN SELECT 1; END", sb.String())
+}
+
 func TestShowCreateProcedure(t *testing.T) {
 	p := parser.New()
 	stmt, _, err := p.Parse("show create procedure proc_2", "", "")
@@ -217,6 +247,10 @@ func TestProcedureRestore(t *testing.T) {
 			"CREATE PROCEDURE `proc_2`( IN `id` INT(11)) BEGIN `labelname`: REPEAT SET @@SESSION.`id`=`id`+1;SELECT 1;UNTIL `id`<10 END REPEAT `labelname`; END",
 			"CREATE PROCEDURE `proc_2`( IN `id` INT(11)) BEGIN `labelname`: REPEAT SET @@SESSION.`id`=`id`+1;SELECT 1;UNTIL `id`<10 END REPEAT `labelname`; END",
 		},
+		{
+			"CREATE PROCEDURE `proc_2`( IN `id` INT(11)) BEGIN `labelname`: LOOP SET @@SESSION.`id`=`id`+1;IF `id`>10 THEN LEAVE `labelname`;END IF;END LOOP `labelname`; END",
+			"CREATE PROCEDURE `proc_2`( IN `id` INT(11)) BEGIN `labelname`: LOOP SET @@SESSION.`id`=`id`+1;IF `id`>10 THEN LEAVE `labelname`;END IF;END LOOP `labelname`; END",
+		},
 	}
 	extractNodeFunc := func(node ast.Node) ast.Node {
 		return node.(*ast.ProcedureInfo)
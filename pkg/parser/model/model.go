@@ -232,6 +232,16 @@ func (c *ColumnInfo) SetElems(elems []string) {
 	c.FieldType.SetElems(elems)
 }
 
+// GetSRID returns the SRID of the geometry column, and whether the SRID attribute is set.
+func (c *ColumnInfo) GetSRID() (uint32, bool) {
+	return c.FieldType.GetSRID()
+}
+
+// SetSRID sets the SRID of the geometry column.
+func (c *ColumnInfo) SetSRID(srid uint32) {
+	c.FieldType.SetSRID(srid)
+}
+
 // IsGenerated returns true if the column is generated column.
 func (c *ColumnInfo) IsGenerated() bool {
 	return len(c.GeneratedExprString) != 0
@@ -541,6 +551,18 @@ type TableInfo struct {
 
 	TTLInfo *TTLInfo `json:"ttl_info"`
 
+	// Triggers are the triggers defined on the table, the triggers with the same
+	// timing and event are fired in the order of the slice.
+	Triggers []*TriggerInfo `json:"triggers,omitempty"`
+
+	// MaterializedView is set if the table stores the result of a materialized view.
+	MaterializedView *MaterializedViewInfo `json:"materialized_view,omitempty"`
+	// MViewLog is set if the table records the changes of a base table for
+	// the incremental refresh of a materialized view.
+	MViewLog *MViewLogInfo `json:"mview_log,omitempty"`
+	// MViewIDs are the IDs of the materialized views that read the table.
+	MViewIDs []int64 `json:"mview_ids,omitempty"`
+
 	// Revision is per table schema's version, it will be increased when the schema changed.
 	Revision uint64 `json:"revision"`
 
@@ -761,6 +783,21 @@ func (t *TableInfo) Clone() *TableInfo {
 	if t.TTLInfo != nil {
 		nt.TTLInfo = t.TTLInfo.Clone()
 	}
+	if t.Triggers != nil {
+		nt.Triggers = make([]*TriggerInfo, len(t.Triggers))
+		for i := range t.Triggers {
+			nt.Triggers[i] = t.Triggers[i].Clone()
+		}
+	}
+	if t.MaterializedView != nil {
+		nt.MaterializedView = t.MaterializedView.Clone()
+	}
+	if t.MViewLog != nil {
+		nt.MViewLog = t.MViewLog.Clone()
+	}
+	if t.MViewIDs != nil {
+		nt.MViewIDs = append([]int64(nil), t.MViewIDs...)
+	}
 
 	return &nt
 }
@@ -1012,6 +1049,16 @@ func (t *TableInfo) IsSequence() bool {
 	return t.Sequence != nil
 }
 
+// IsMaterializedView checks if TableInfo stores the result of a materialized view.
+func (t *TableInfo) IsMaterializedView() bool {
+	return t.MaterializedView != nil
+}
+
+// IsMViewLog checks if TableInfo is the log table of a materialized view.
+func (t *TableInfo) IsMViewLog() bool {
+	return t.MViewLog != nil
+}
+
 // IsBaseTable checks to see the table is neither a view or a sequence.
 func (t *TableInfo) IsBaseTable() bool {
 	return t.Sequence == nil && t.View == nil
@@ -1129,6 +1176,152 @@ type SequenceInfo struct {
 
 //revive:enable:exported
 
+// TriggerTiming is the action time of a trigger.
+type TriggerTiming int
+
+// The action times of a trigger.
+const (
+	TriggerBefore TriggerTiming = iota
+	TriggerAfter
+)
+
+// String implements fmt.Stringer interface.
+func (t TriggerTiming) String() string {
+	switch t {
+	case TriggerBefore:
+		return "BEFORE"
+	case TriggerAfter:
+		return "AFTER"
+	default:
+		return ""
+	}
+}
+
+// TriggerEvent is the kind of operation that activates a trigger.
+type TriggerEvent int
+
+// The kinds of operation that activate a trigger.
+const (
+	TriggerInsert TriggerEvent = iota
+	TriggerUpdate
+	TriggerDelete
+)
+
+// String implements fmt.Stringer interface.
+func (e TriggerEvent) String() string {
+	switch e {
+	case TriggerInsert:
+		return "INSERT"
+	case TriggerUpdate:
+		return "UPDATE"
+	case TriggerDelete:
+		return "DELETE"
+	default:
+		return ""
+	}
+}
+
+// TriggerInfo provides meta data describing a row-level trigger of a table.
+type TriggerInfo struct {
+	Name   CIStr         `json:"name"`
+	Timing TriggerTiming `json:"timing"`
+	Event  TriggerEvent  `json:"event"`
+	// Body is the text of the statement executed when the trigger activates.
+	Body    string             `json:"body"`
+	Definer *auth.UserIdentity `json:"definer"`
+	// SQLMode, CharsetClient and CollationConnection are the session environment
+	// when the trigger is created, the body is parsed and executed with them.
+	SQLMode             mysql.SQLMode `json:"sql_mode"`
+	CharsetClient       string        `json:"charset_client"`
+	CollationConnection string        `json:"collation_connection"`
+	DBCollation         string        `json:"db_collation"`
+	Created             time.Time     `json:"created"`
+}
+
+// Clone clones TriggerInfo.
+func (t *TriggerInfo) Clone() *TriggerInfo {
+	nt := *t
+	if t.Definer != nil {
+		definer := *t.Definer
+		nt.Definer = &definer
+	}
+	return &nt
+}
+
+// MViewRefreshMethod is the way to refresh a materialized view.
+type MViewRefreshMethod int
+
+// The ways to refresh a materialized view.
+const (
+	// MViewRefreshComplete recomputes the whole result of the view.
+	MViewRefreshComplete MViewRefreshMethod = iota
+	// MViewRefreshIncremental applies the changes recorded in the log table
+	// of the base table to the stored result.
+	MViewRefreshIncremental
+)
+
+// String implements fmt.Stringer interface.
+func (m MViewRefreshMethod) String() string {
+	switch m {
+	case MViewRefreshComplete:
+		return "COMPLETE"
+	case MViewRefreshIncremental:
+		return "INCREMENTAL"
+	default:
+		return ""
+	}
+}
+
+// MaterializedViewInfo provides meta data describing a materialized view.
+type MaterializedViewInfo struct {
+	// SelectStmt is the query of the view, the table names in it are qualified
+	// with the schema names.
+	SelectStmt    string             `json:"select_stmt"`
+	RefreshMethod MViewRefreshMethod `json:"refresh_method"`
+	BaseTableIDs  []int64            `json:"base_table_ids"`
+	// LogTableID is the ID of the log table of the base table, it's only set
+	// for the views refreshed incrementally.
+	LogTableID int64 `json:"log_table_id,omitempty"`
+	// SQLMode, CharsetClient and CollationConnection are the session environment
+	// when the view is created, the query is parsed and executed with them.
+	SQLMode             mysql.SQLMode `json:"sql_mode"`
+	CharsetClient       string        `json:"charset_client"`
+	CollationConnection string        `json:"collation_connection"`
+}
+
+// Clone clones MaterializedViewInfo.
+func (m *MaterializedViewInfo) Clone() *MaterializedViewInfo {
+	nm := *m
+	nm.BaseTableIDs = append([]int64(nil), m.BaseTableIDs...)
+	return &nm
+}
+
+const (
+	// MViewLogTablePrefix is the name prefix of the log tables of materialized views.
+	MViewLogTablePrefix = "_tidb_mlog_"
+	// MViewLogSignColumn is the column of the log table telling whether the row
+	// is inserted into (1) or deleted from (-1) the base table.
+	MViewLogSignColumn = "_tidb_mlog_sign"
+)
+
+// MViewLogInfo provides meta data describing the log table of a materialized view.
+// Every row of the log table is a row inserted into (sign 1) or deleted from
+// (sign -1) the base table.
+type MViewLogInfo struct {
+	MViewID     int64 `json:"mview_id"`
+	BaseTableID int64 `json:"base_table_id"`
+	// BaseColumnIDs are the IDs of the base table columns copied to the log
+	// table, in the order of the log table columns.
+	BaseColumnIDs []int64 `json:"base_column_ids"`
+}
+
+// Clone clones MViewLogInfo.
+func (m *MViewLogInfo) Clone() *MViewLogInfo {
+	nm := *m
+	nm.BaseColumnIDs = append([]int64(nil), m.BaseColumnIDs...)
+	return &nm
+}
+
 // PartitionType is the type for PartitionInfo
 type PartitionType int
 
@@ -1439,6 +1632,10 @@ func (t IndexType) String() string {
 		return "RTREE"
 	case IndexTypeHypo:
 		return "HYPO"
+	case IndexTypeFulltext:
+		return "FULLTEXT"
+	case IndexTypeSpatial:
+		return "SPATIAL"
 	default:
 		return ""
 	}
@@ -1451,6 +1648,8 @@ const (
 	IndexTypeHash
 	IndexTypeRtree
 	IndexTypeHypo
+	IndexTypeFulltext
+	IndexTypeSpatial
 )
 
 // IndexInfo provides meta data describing a DB index.
@@ -1470,6 +1669,7 @@ type IndexInfo struct {
 	Invisible     bool           `json:"is_invisible"` // Whether the index is invisible.
 	Global        bool           `json:"is_global"`    // Whether the index is global.
 	MVIndex       bool           `json:"mv_index"`     // Whether the index is multivalued index.
+	ParserName    CIStr          `json:"parser_name"`  // The parser of the fulltext index.
 }
 
 // Clone clones IndexInfo.
@@ -1511,6 +1711,16 @@ func (index *IndexInfo) FindColumnByName(nameL string) *IndexColumn {
 	return ret
 }
 
+// IsFulltextIndex checks whether the index is a fulltext index.
+func (index *IndexInfo) IsFulltextIndex() bool {
+	return index.Tp == IndexTypeFulltext
+}
+
+// IsSpatialIndex checks whether the index is a spatial index.
+func (index *IndexInfo) IsSpatialIndex() bool {
+	return index.Tp == IndexTypeSpatial
+}
+
 // IsPublic checks if the index state is public
 func (index *IndexInfo) IsPublic() bool {
 	return index.State == StatePublic
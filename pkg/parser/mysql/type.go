@@ -49,6 +49,18 @@ const (
 	TypeTiDBVectorFloat32 byte = 0xe1
 )
 
+// Geometry types are the subtypes of TypeGeometry, whose values are the same as the geometry types of WKB.
+const (
+	GeometryTypeGeometry byte = iota
+	GeometryTypePoint
+	GeometryTypeLineString
+	GeometryTypePolygon
+	GeometryTypeMultiPoint
+	GeometryTypeMultiLineString
+	GeometryTypeMultiPolygon
+	GeometryTypeGeometryCollection
+)
+
 // Flag information.
 const (
 	NotNullFlag        uint = 1 << 0  /* Field can't be NULL */
@@ -26,6 +26,7 @@
 package parser
 
 import (
+	"math"
 	"strings"
 	"time"
 
@@ -186,6 +187,7 @@ import (
 	kill              "KILL"
 	lag               "LAG"
 	lastValue         "LAST_VALUE"
+	lateral           "LATERAL"
 	lead              "LEAD"
 	leading           "LEADING"
 	leave             "LEAVE"
@@ -321,6 +323,7 @@ import (
 	always                "ALWAYS"
 	any                   "ANY"
 	ascii                 "ASCII"
+	at                    "AT"
 	attribute             "ATTRIBUTE"
 	attributes            "ATTRIBUTES"
 	autoIdCache           "AUTO_ID_CACHE"
@@ -333,6 +336,7 @@ import (
 	backup                "BACKUP"
 	backups               "BACKUPS"
 	bdr                   "BDR"
+	before                "BEFORE"
 	begin                 "BEGIN"
 	bernoulli             "BERNOULLI"
 	binding               "BINDING"
@@ -370,6 +374,8 @@ import (
 	commit                "COMMIT"
 	committed             "COMMITTED"
 	compact               "COMPACT"
+	complete              "COMPLETE"
+	completion            "COMPLETION"
 	compressed            "COMPRESSED"
 	compression           "COMPRESSION"
 	compressionLevel      "COMPRESSION_LEVEL"
@@ -407,12 +413,15 @@ import (
 	do                    "DO"
 	duplicate             "DUPLICATE"
 	dynamic               "DYNAMIC"
+	each                  "EACH"
+	empty                 "EMPTY"
 	enable                "ENABLE"
 	enabled               "ENABLED"
 	encryption            "ENCRYPTION"
 	encryptionKeyFile     "ENCRYPTION_KEYFILE"
 	encryptionMethod      "ENCRYPTION_METHOD"
 	end                   "END"
+	ends                  "ENDS"
 	enforced              "ENFORCED"
 	engine                "ENGINE"
 	engines               "ENGINES"
@@ -422,6 +431,7 @@ import (
 	escape                "ESCAPE"
 	event                 "EVENT"
 	events                "EVENTS"
+	every                 "EVERY"
 	evolve                "EVOLVE"
 	exchange              "EXCHANGE"
 	exclusive             "EXCLUSIVE"
@@ -437,11 +447,15 @@ import (
 	fixed                 "FIXED"
 	flush                 "FLUSH"
 	following             "FOLLOWING"
+	follows               "FOLLOWS"
 	format                "FORMAT"
 	found                 "FOUND"
 	full                  "FULL"
 	function              "FUNCTION"
 	general               "GENERAL"
+	geomCollection        "GEOMCOLLECTION"
+	geometryType          "GEOMETRY"
+	geometryCollection    "GEOMETRYCOLLECTION"
 	global                "GLOBAL"
 	grants                "GRANTS"
 	handler               "HANDLER"
@@ -476,13 +490,16 @@ import (
 	lastBackup            "LAST_BACKUP"
 	less                  "LESS"
 	level                 "LEVEL"
+	lineString            "LINESTRING"
 	list                  "LIST"
 	loadStats             "LOAD_STATS"
 	local                 "LOCAL"
 	location              "LOCATION"
 	locked                "LOCKED"
 	logs                  "LOGS"
+	loop                  "LOOP"
 	master                "MASTER"
+	materialized          "MATERIALIZED"
 	maxConnectionsPerHour "MAX_CONNECTIONS_PER_HOUR"
 	max_idxnum            "MAX_IDXNUM"
 	max_minutes           "MAX_MINUTES"
@@ -501,9 +518,13 @@ import (
 	mode                  "MODE"
 	modify                "MODIFY"
 	month                 "MONTH"
+	multiLineString       "MULTILINESTRING"
+	multiPoint            "MULTIPOINT"
+	multiPolygon          "MULTIPOLYGON"
 	names                 "NAMES"
 	national              "NATIONAL"
 	ncharType             "NCHAR"
+	nested                "NESTED"
 	never                 "NEVER"
 	next                  "NEXT"
 	nextval               "NEXTVAL"
@@ -528,6 +549,7 @@ import (
 	onDuplicate           "ON_DUPLICATE"
 	open                  "OPEN"
 	optional              "OPTIONAL"
+	ordinality            "ORDINALITY"
 	packKeys              "PACK_KEYS"
 	pageSym               "PAGE"
 	parser                "PARSER"
@@ -536,6 +558,7 @@ import (
 	partitions            "PARTITIONS"
 	password              "PASSWORD"
 	passwordLockTime      "PASSWORD_LOCK_TIME"
+	path                  "PATH"
 	pause                 "PAUSE"
 	percent               "PERCENT"
 	per_db                "PER_DB"
@@ -544,6 +567,8 @@ import (
 	plugins               "PLUGINS"
 	point                 "POINT"
 	policy                "POLICY"
+	polygon               "POLYGON"
+	precedes              "PRECEDES"
 	preceding             "PRECEDING"
 	prepare               "PREPARE"
 	preserve              "PRESERVE"
@@ -561,8 +586,10 @@ import (
 	quick                 "QUICK"
 	rateLimit             "RATE_LIMIT"
 	rebuild               "REBUILD"
+	recommend             "RECOMMEND"
 	recover               "RECOVER"
 	redundant             "REDUNDANT"
+	refresh               "REFRESH"
 	reload                "RELOAD"
 	remove                "REMOVE"
 	reorganize            "REORGANIZE"
@@ -626,7 +653,9 @@ import (
 	sqlTsiSecond          "SQL_TSI_SECOND"
 	sqlTsiWeek            "SQL_TSI_WEEK"
 	sqlTsiYear            "SQL_TSI_YEAR"
+	srid                  "SRID"
 	start                 "START"
+	starts                "STARTS"
 	statsAutoRecalc       "STATS_AUTO_RECALC"
 	statsColChoice        "STATS_COL_CHOICE"
 	statsColList          "STATS_COL_LIST"
@@ -828,6 +857,7 @@ import (
 	builtinDateSub
 	builtinExtract
 	builtinGroupConcat
+	builtinJSONTable
 	builtinMax
 	builtinMin
 	builtinNow
@@ -967,6 +997,10 @@ import (
 	CommitStmt                 "COMMIT statement"
 	CreateTableStmt            "CREATE TABLE statement"
 	CreateViewStmt             "CREATE VIEW  statement"
+	CreateTriggerStmt          "CREATE TRIGGER statement"
+	CreateEventStmt            "CREATE EVENT statement"
+	AlterEventStmt             "ALTER EVENT statement"
+	CreateMaterializedViewStmt "CREATE MATERIALIZED VIEW statement"
 	CreateUserStmt             "CREATE User statement"
 	CreateRoleStmt             "CREATE Role statement"
 	CreateDatabaseStmt         "Create Database Statement"
@@ -991,6 +1025,9 @@ import (
 	DropUserStmt               "DROP USER"
 	DropRoleStmt               "DROP ROLE"
 	DropViewStmt               "DROP VIEW statement"
+	DropTriggerStmt            "DROP TRIGGER statement"
+	DropEventStmt              "DROP EVENT statement"
+	DropMaterializedViewStmt   "DROP MATERIALIZED VIEW statement"
 	DropBindingStmt            "DROP BINDING  statement"
 	DropPolicyStmt             "DROP PLACEMENT POLICY statement"
 	DeallocateStmt             "Deallocate prepared statement"
@@ -1031,6 +1068,8 @@ import (
 	RenameUserStmt             "rename user statement"
 	ReplaceIntoStmt            "REPLACE INTO statement"
 	RecoverTableStmt           "recover table statement"
+	RecommendIndexStmt         "RECOMMEND INDEX statement"
+	RefreshMaterializedViewStmt "REFRESH MATERIALIZED VIEW statement"
 	RevokeStmt                 "Revoke statement"
 	RevokeRoleStmt             "Revoke role statement"
 	RollbackStmt               "ROLLBACK statement"
@@ -1204,6 +1243,11 @@ import (
 	IntervalExpr                           "Interval expression"
 	JoinTable                              "join table"
 	JoinType                               "join type"
+	JSONTableColumn                        "JSON_TABLE column definition"
+	JSONTableColumnList                    "JSON_TABLE column definition list"
+	JSONTableColumnsClause                 "JSON_TABLE COLUMNS clause"
+	JSONTableOnEmptyOnErrorOpt             "JSON_TABLE ON EMPTY and ON ERROR clauses or empty"
+	JSONTableOnResponse                    "JSON_TABLE NULL, ERROR or DEFAULT response"
 	KillOrKillTiDB                         "Kill or Kill TiDB"
 	LocationLabelList                      "location label name list"
 	LikeTableWithOrWithoutParen            "LIKE table_name or ( LIKE table_name )"
@@ -1362,6 +1406,9 @@ import (
 	TransactionChar                        "Transaction characteristic"
 	TransactionChars                       "Transaction characteristic list"
 	TrimDirection                          "Trim string direction"
+	TriggerEvent                           "Trigger event"
+	TriggerOrderOpt                        "Trigger order optional"
+	TriggerTiming                          "Trigger action time"
 	SetOprOpt                              "Union/Except/Intersect Option(empty/ALL/DISTINCT)"
 	Username                               "Username"
 	UsernameList                           "UsernameList"
@@ -1437,6 +1484,8 @@ import (
 	BlobType                               "Blob types"
 	TextType                               "Text types"
 	DateAndTimeType                        "Date and Time types"
+	SpatialType                            "Spatial types"
+	GeometryTypeName                       "Geometry type name"
 	OptFieldLen                            "Field length or empty"
 	FieldLen                               "Field length"
 	FieldOpts                              "Field type definition option list"
@@ -1456,12 +1505,23 @@ import (
 	TableOptimizerHints                    "Table level optimizer hints"
 	TableOptimizerHintsOpt                 "Table level optimizer hints option"
 	EnforcedOrNot                          "{ENFORCED|NOT ENFORCED}"
+	EventSchedule                          "Event schedule"
+	EventStartsOpt                         "Event schedule STARTS clause optional"
+	EventEndsOpt                           "Event schedule ENDS clause optional"
+	EventCompletionOpt                     "Event ON COMPLETION clause optional"
+	EventStatusOpt                         "Event ENABLE or DISABLE clause optional"
+	EventCommentOpt                        "Event COMMENT clause optional"
+	AlterEventScheduleOpt                  "ALTER EVENT ON SCHEDULE and ON COMPLETION clauses optional"
+	AlterEventRenameOpt                    "ALTER EVENT RENAME TO clause optional"
+	AlterEventBodyOpt                      "ALTER EVENT DO clause optional"
 	EnforcedOrNotOpt                       "Optional {ENFORCED|NOT ENFORCED}"
 	EnforcedOrNotOrNotNullOpt              "{[ENFORCED|NOT ENFORCED|NOT NULL]}"
 	Match                                  "[MATCH FULL | MATCH PARTIAL | MATCH SIMPLE]"
 	MatchOpt                               "optional MATCH clause"
 	MaxMinutesOpt                          "MAX_MINUTES num(int)"
 	MaxIndexNumOpt                         "MAX_IDXNUM clause"
+	MViewRefreshMethod                     "Materialized view refresh method"
+	MViewRefreshOpt                        "Materialized view refresh option"
 	PerTable                               "Max index number PER_TABLE"
 	PerDB                                  "Max index number PER_DB"
 	BRIETables                             "List of tables or databases for BRIE statements"
@@ -2292,8 +2352,6 @@ AlterTableSpec:
 	}
 |	"CHECK" "PARTITION" AllOrPartitionNameList
 	{
-		yylex.AppendError(yylex.Errorf("The CHECK PARTITIONING clause is parsed but not implement yet."))
-		parser.lastErrorAsWarn()
 		ret := &ast.AlterTableSpec{
 			Tp: ast.AlterTableCheckPartitions,
 		}
@@ -2979,6 +3037,29 @@ RecoverTableStmt:
 		}
 	}
 
+/*******************************************************************
+ *
+ *  Refresh Materialized View Statement
+ *
+ *  Example:
+ *      REFRESH MATERIALIZED VIEW mv;
+ *      REFRESH MATERIALIZED VIEW mv COMPLETE;
+ *
+ *******************************************************************/
+RefreshMaterializedViewStmt:
+	"REFRESH" "MATERIALIZED" "VIEW" TableName
+	{
+		$$ = &ast.RefreshMaterializedViewStmt{ViewName: $4.(*ast.TableName)}
+	}
+|	"REFRESH" "MATERIALIZED" "VIEW" TableName MViewRefreshMethod
+	{
+		$$ = &ast.RefreshMaterializedViewStmt{
+			ViewName:  $4.(*ast.TableName),
+			HasMethod: true,
+			Method:    $5.(model.MViewRefreshMethod),
+		}
+	}
+
 /*******************************************************************
  *
  *  FLASHBACK [CLUSTER | DATABASE | TABLE] TO TIMESTAMP
@@ -3652,6 +3733,15 @@ ColumnOption:
 	{
 		$$ = &ast.ColumnOption{Tp: ast.ColumnOptionAutoRandom, AutoRandOpt: $2.(ast.AutoRandomOption)}
 	}
+|	"SRID" LengthNum
+	{
+		srid := $2.(uint64)
+		if srid > math.MaxUint32 {
+			yylex.AppendError(yylex.Errorf("The SRID %d is out of range", srid))
+			return 1
+		}
+		$$ = &ast.ColumnOption{Tp: ast.ColumnOptionSrid, Srid: uint32(srid)}
+	}
 
 AutoRandomOpt:
 	{
@@ -3759,6 +3849,19 @@ ConstraintElem:
 		}
 		$$ = c
 	}
+|	"SPATIAL" KeyOrIndexOpt IndexName '(' IndexPartSpecificationList ')' IndexOptionList
+	{
+		c := &ast.Constraint{
+			Tp:           ast.ConstraintSpatial,
+			Keys:         $5.([]*ast.IndexPartSpecification),
+			Name:         $3.(*ast.NullString).String,
+			IsEmptyIndex: $3.(*ast.NullString).Empty,
+		}
+		if $7 != nil {
+			c.Option = $7.(*ast.IndexOption)
+		}
+		$$ = c
+	}
 |	KeyOrIndex IfNotExists IndexNameAndTypeOpt '(' IndexPartSpecificationList ')' IndexOptionList
 	{
 		c := &ast.Constraint{
@@ -4937,6 +5040,302 @@ CreateViewStmt:
 		$$ = x
 	}
 
+/*******************************************************************
+ *
+ *  Create Materialized View Statement
+ *
+ *  Example:
+ *      CREATE MATERIALIZED VIEW mv REFRESH INCREMENTAL
+ *          AS SELECT a, COUNT(*), SUM(b), COUNT(b) FROM t GROUP BY a
+ *******************************************************************/
+CreateMaterializedViewStmt:
+	"CREATE" "MATERIALIZED" "VIEW" IfNotExists ViewName ViewFieldList MViewRefreshOpt "AS" CreateViewSelectOpt
+	{
+		startOffset := parser.startOffset(&yyS[yypt])
+		selStmt := $9.(ast.StmtNode)
+		selStmt.SetText(parser.lexer.client, strings.TrimSpace(parser.src[startOffset:]))
+		x := &ast.CreateMaterializedViewStmt{
+			IfNotExists:   $4.(bool),
+			ViewName:      $5.(*ast.TableName),
+			RefreshMethod: $7.(model.MViewRefreshMethod),
+			Select:        selStmt,
+		}
+		if $6 != nil {
+			x.Cols = $6.([]model.CIStr)
+		}
+		$$ = x
+	}
+
+MViewRefreshOpt:
+	/* Empty */
+	{
+		$$ = model.MViewRefreshComplete
+	}
+|	"REFRESH" MViewRefreshMethod
+	{
+		$$ = $2
+	}
+
+MViewRefreshMethod:
+	"COMPLETE"
+	{
+		$$ = model.MViewRefreshComplete
+	}
+|	"INCREMENTAL"
+	{
+		$$ = model.MViewRefreshIncremental
+	}
+
+/*******************************************************************
+ *
+ *  Create Trigger Statement
+ *
+ *  Example:
+ *      CREATE DEFINER = 'root'@'%' TRIGGER trg BEFORE INSERT ON t FOR EACH ROW
+ *          FOLLOWS other_trg SET NEW.c = NEW.a + NEW.b
+ *
+ *  OrReplace and ViewAlgorithm are only used to share the prefix with
+ *  the CREATE VIEW statement, they are not allowed for triggers.
+ *******************************************************************/
+CreateTriggerStmt:
+	"CREATE" OrReplace ViewAlgorithm ViewDefiner "TRIGGER" IfNotExists TableName TriggerTiming TriggerEvent "ON" TableName "FOR" "EACH" "ROW" TriggerOrderOpt ProcedureProcStmt
+	{
+		if $2.(bool) || $3.(model.ViewAlgorithm) != model.AlgorithmUndefined {
+			yylex.AppendError(yylex.Errorf("OR REPLACE and ALGORITHM are not supported for CREATE TRIGGER"))
+			return 1
+		}
+		startOffset := parser.startOffset(&yyS[yypt])
+		body := $16
+		body.SetText(parser.lexer.client, strings.TrimSpace(parser.src[startOffset:parser.yylval.offset]))
+		x := &ast.CreateTriggerStmt{
+			IfNotExists: $6.(bool),
+			Definer:     $4.(*auth.UserIdentity),
+			TriggerName: $7.(*ast.TableName),
+			Timing:      $8.(model.TriggerTiming),
+			Event:       $9.(model.TriggerEvent),
+			Table:       $11.(*ast.TableName),
+			Body:        body,
+		}
+		if $15 != nil {
+			x.Order = $15.(*ast.TriggerOrder)
+		}
+		$$ = x
+	}
+
+/*******************************************************************
+ *
+ *  Create Event Statement
+ *
+ *  Example:
+ *      CREATE EVENT e ON SCHEDULE EVERY 1 HOUR STARTS '2024-01-01 00:00:00'
+ *          ON COMPLETION PRESERVE DISABLE COMMENT 'hourly' DO DELETE FROM t
+ *
+ *  OrReplace and ViewAlgorithm are only used to share the prefix with
+ *  the CREATE VIEW statement, they are not allowed for events.
+ *******************************************************************/
+CreateEventStmt:
+	"CREATE" OrReplace ViewAlgorithm ViewDefiner "EVENT" IfNotExists TableName "ON" "SCHEDULE" EventSchedule EventCompletionOpt EventStatusOpt EventCommentOpt "DO" ProcedureProcStmt
+	{
+		if $2.(bool) || $3.(model.ViewAlgorithm) != model.AlgorithmUndefined {
+			yylex.AppendError(yylex.Errorf("OR REPLACE and ALGORITHM are not supported for CREATE EVENT"))
+			return 1
+		}
+		startOffset := parser.startOffset(&yyS[yypt])
+		body := $15
+		body.SetText(parser.lexer.client, strings.TrimSpace(parser.src[startOffset:parser.yylval.offset]))
+		x := &ast.CreateEventStmt{
+			IfNotExists: $6.(bool),
+			Definer:     $4.(*auth.UserIdentity),
+			EventName:   $7.(*ast.TableName),
+			Schedule:    $10.(*ast.EventSchedule),
+			Completion:  $11.(ast.EventCompletionType),
+			Status:      $12.(ast.EventStatusType),
+			Body:        body,
+		}
+		if $13 != nil {
+			x.Comment = $13.(*string)
+		}
+		$$ = x
+	}
+
+EventSchedule:
+	"AT" Expression
+	{
+		$$ = &ast.EventSchedule{At: $2}
+	}
+|	"EVERY" Expression TimeUnit EventStartsOpt EventEndsOpt
+	{
+		x := &ast.EventSchedule{Every: $2, Unit: $3.(ast.TimeUnitType)}
+		if $4 != nil {
+			x.Starts = $4.(ast.ExprNode)
+		}
+		if $5 != nil {
+			x.Ends = $5.(ast.ExprNode)
+		}
+		$$ = x
+	}
+
+EventStartsOpt:
+	/* EMPTY */
+	{
+		$$ = nil
+	}
+|	"STARTS" Expression
+	{
+		$$ = $2
+	}
+
+EventEndsOpt:
+	/* EMPTY */
+	{
+		$$ = nil
+	}
+|	"ENDS" Expression
+	{
+		$$ = $2
+	}
+
+EventCompletionOpt:
+	/* EMPTY */
+	{
+		$$ = ast.EventCompletionDefault
+	}
+|	"ON" "COMPLETION" "PRESERVE"
+	{
+		$$ = ast.EventCompletionPreserve
+	}
+|	"ON" "COMPLETION" "NOT" "PRESERVE"
+	{
+		$$ = ast.EventCompletionNotPreserve
+	}
+
+EventStatusOpt:
+	/* EMPTY */
+	{
+		$$ = ast.EventStatusDefault
+	}
+|	"ENABLE"
+	{
+		$$ = ast.EventStatusEnable
+	}
+|	"DISABLE"
+	{
+		$$ = ast.EventStatusDisable
+	}
+|	"DISABLE" "ON" "SLAVE"
+	{
+		$$ = ast.EventStatusDisableOnSlave
+	}
+
+EventCommentOpt:
+	/* EMPTY */
+	{
+		$$ = nil
+	}
+|	"COMMENT" stringLit
+	{
+		comment := $2
+		$$ = &comment
+	}
+
+/*******************************************************************
+ *
+ *  Alter Event Statement
+ *
+ *  Example:
+ *      ALTER EVENT e ON SCHEDULE AT CURRENT_TIMESTAMP + INTERVAL 1 DAY
+ *          RENAME TO e2 ENABLE
+ *******************************************************************/
+AlterEventStmt:
+	"ALTER" ViewDefiner "EVENT" TableName AlterEventScheduleOpt AlterEventRenameOpt EventStatusOpt EventCommentOpt AlterEventBodyOpt
+	{
+		x := $5.(*ast.AlterEventStmt)
+		x.Definer = $2.(*auth.UserIdentity)
+		x.EventName = $4.(*ast.TableName)
+		if $6 != nil {
+			x.RenameTo = $6.(*ast.TableName)
+		}
+		x.Status = $7.(ast.EventStatusType)
+		if $8 != nil {
+			x.Comment = $8.(*string)
+		}
+		if $9 != nil {
+			x.Body = $9.(ast.StmtNode)
+		}
+		$$ = x
+	}
+
+AlterEventScheduleOpt:
+	EventCompletionOpt
+	{
+		$$ = &ast.AlterEventStmt{Completion: $1.(ast.EventCompletionType)}
+	}
+|	"ON" "SCHEDULE" EventSchedule EventCompletionOpt
+	{
+		$$ = &ast.AlterEventStmt{Schedule: $3.(*ast.EventSchedule), Completion: $4.(ast.EventCompletionType)}
+	}
+
+AlterEventRenameOpt:
+	/* EMPTY */
+	{
+		$$ = nil
+	}
+|	"RENAME" "TO" TableName
+	{
+		$$ = $3
+	}
+
+AlterEventBodyOpt:
+	/* EMPTY */
+	{
+		$$ = nil
+	}
+|	"DO" ProcedureProcStmt
+	{
+		startOffset := parser.startOffset(&yyS[yypt])
+		body := $2
+		body.SetText(parser.lexer.client, strings.TrimSpace(parser.src[startOffset:parser.yylval.offset]))
+		$$ = body
+	}
+
+TriggerTiming:
+	"BEFORE"
+	{
+		$$ = model.TriggerBefore
+	}
+|	"AFTER"
+	{
+		$$ = model.TriggerAfter
+	}
+
+TriggerEvent:
+	"INSERT"
+	{
+		$$ = model.TriggerInsert
+	}
+|	"UPDATE"
+	{
+		$$ = model.TriggerUpdate
+	}
+|	"DELETE"
+	{
+		$$ = model.TriggerDelete
+	}
+
+TriggerOrderOpt:
+	/* EMPTY */
+	{
+		$$ = nil
+	}
+|	"FOLLOWS" Identifier
+	{
+		$$ = &ast.TriggerOrder{Follows: true, OtherTrigger: model.NewCIStr($2)}
+	}
+|	"PRECEDES" Identifier
+	{
+		$$ = &ast.TriggerOrder{Follows: false, OtherTrigger: model.NewCIStr($2)}
+	}
+
 OrReplace:
 	/* EMPTY */
 	{
@@ -5199,6 +5598,24 @@ DropViewStmt:
 		$$ = &ast.DropTableStmt{IfExists: true, Tables: $5.([]*ast.TableName), IsView: true}
 	}
 
+DropTriggerStmt:
+	"DROP" "TRIGGER" IfExists TableName
+	{
+		$$ = &ast.DropTriggerStmt{IfExists: $3.(bool), Trigger: $4.(*ast.TableName)}
+	}
+
+DropEventStmt:
+	"DROP" "EVENT" IfExists TableName
+	{
+		$$ = &ast.DropEventStmt{IfExists: $3.(bool), EventName: $4.(*ast.TableName)}
+	}
+
+DropMaterializedViewStmt:
+	"DROP" "MATERIALIZED" "VIEW" IfExists TableName
+	{
+		$$ = &ast.DropMaterializedViewStmt{IfExists: $4.(bool), ViewName: $5.(*ast.TableName)}
+	}
+
 DropUserStmt:
 	"DROP" "USER" UsernameList
 	{
@@ -6591,6 +7008,7 @@ UnReservedKeyword:
 |	"ALWAYS"
 |	"AVG"
 |	"BDR"
+|	"BEFORE"
 |	"BEGIN"
 |	"BIT"
 |	"BOOL"
@@ -6608,6 +7026,7 @@ UnReservedKeyword:
 |	"SAN"
 |	"COMMIT"
 |	"COMPACT"
+|	"COMPLETE"
 |	"COMPRESSED"
 |	"CONSISTENCY"
 |	"CONSISTENT"
@@ -6620,6 +7039,7 @@ UnReservedKeyword:
 |	"DO"
 |	"DUPLICATE"
 |	"DYNAMIC"
+|	"EACH"
 |	"ENCRYPTION"
 |	"END"
 |	"ENFORCED"
@@ -6638,6 +7058,7 @@ UnReservedKeyword:
 |	"FIXED"
 |	"FLUSH"
 |	"FOLLOWING"
+|	"FOLLOWS"
 |	"FORMAT"
 |	"FULL"
 |	"GENERAL"
@@ -6660,7 +7081,9 @@ UnReservedKeyword:
 |	"PROXY"
 |	"QUICK"
 |	"REBUILD"
+|	"RECOMMEND"
 |	"REDUNDANT"
+|	"REFRESH"
 |	"REORGANIZE"
 |	"RESOURCE"
 |	"RESTART"
@@ -6709,6 +7132,7 @@ UnReservedKeyword:
 |	"COMPRESSION"
 |	"KEY_BLOCK_SIZE"
 |	"MASTER"
+|	"MATERIALIZED"
 |	"MAX_ROWS"
 |	"MIN_ROWS"
 |	"NATIONAL"
@@ -6764,6 +7188,7 @@ UnReservedKeyword:
 |	"MINUTE"
 |	"PLUGINS"
 |	"PRECEDING"
+|	"PRECEDES"
 |	"QUERY"
 |	"QUERIES"
 |	"SAVEPOINT"
@@ -6922,6 +7347,7 @@ UnReservedKeyword:
 |	"PURGE"
 |	"SKIP"
 |	"LOCKED"
+|	"LOOP"
 |	"CLUSTER"
 |	"CLUSTERED"
 |	"NONCLUSTERED"
@@ -6954,6 +7380,24 @@ UnReservedKeyword:
 |	"COMPRESSION_TYPE"
 |	"ENCRYPTION_METHOD"
 |	"ENCRYPTION_KEYFILE"
+|	"EMPTY"
+|	"NESTED"
+|	"ORDINALITY"
+|	"PATH"
+|	"GEOMETRY"
+|	"LINESTRING"
+|	"POLYGON"
+|	"MULTIPOINT"
+|	"MULTILINESTRING"
+|	"MULTIPOLYGON"
+|	"GEOMETRYCOLLECTION"
+|	"GEOMCOLLECTION"
+|	"SRID"
+|	"AT"
+|	"EVERY"
+|	"STARTS"
+|	"ENDS"
+|	"COMPLETION"
 
 TiDBKeyword:
 	"ADMIN"
@@ -7907,7 +8351,9 @@ FunctionNameConflict:
 |	"MINUTE"
 |	"MONTH"
 |	builtinNow
+|	"LINESTRING"
 |	"POINT"
+|	"POLYGON"
 |	"QUARTER"
 |	"REPEAT"
 |	"REPLACE"
@@ -9710,6 +10156,16 @@ TableFactor:
 		resultNode := $1.(*ast.SubqueryExpr).Query
 		$$ = &ast.TableSource{Source: resultNode, AsName: $2.(model.CIStr)}
 	}
+|	"LATERAL" SubSelect TableAsNameOpt
+	{
+		resultNode := $2.(*ast.SubqueryExpr).Query
+		$$ = &ast.TableSource{Source: resultNode, AsName: $3.(model.CIStr), Lateral: true}
+	}
+|	builtinJSONTable '(' Expression ',' stringLit JSONTableColumnsClause ')' TableAsNameOpt
+	{
+		jt := &ast.JSONTable{Expr: $3, Path: $5, Columns: $6.([]*ast.JSONTableColumn)}
+		$$ = &ast.TableSource{Source: jt, AsName: $8.(model.CIStr)}
+	}
 |	'(' TableRefs ')'
 	{
 		j := $2.(*ast.Join)
@@ -9717,6 +10173,84 @@ TableFactor:
 		$$ = $2
 	}
 
+JSONTableColumnsClause:
+	"COLUMNS" '(' JSONTableColumnList ')'
+	{
+		$$ = $3
+	}
+
+JSONTableColumnList:
+	JSONTableColumn
+	{
+		$$ = []*ast.JSONTableColumn{$1.(*ast.JSONTableColumn)}
+	}
+|	JSONTableColumnList ',' JSONTableColumn
+	{
+		$$ = append($1.([]*ast.JSONTableColumn), $3.(*ast.JSONTableColumn))
+	}
+
+JSONTableColumn:
+	Identifier "FOR" "ORDINALITY"
+	{
+		$$ = &ast.JSONTableColumn{Tp: ast.JSONTableColumnOrdinality, Name: model.NewCIStr($1)}
+	}
+|	Identifier Type "PATH" stringLit JSONTableOnEmptyOnErrorOpt
+	{
+		responses := $5.([]*ast.JSONTableOnResponse)
+		$$ = &ast.JSONTableColumn{
+			Tp:        ast.JSONTableColumnPath,
+			Name:      model.NewCIStr($1),
+			FieldType: $2.(*types.FieldType),
+			Path:      $4,
+			OnEmpty:   responses[0],
+			OnError:   responses[1],
+		}
+	}
+|	Identifier Type "EXISTS" "PATH" stringLit
+	{
+		$$ = &ast.JSONTableColumn{Tp: ast.JSONTableColumnExists, Name: model.NewCIStr($1), FieldType: $2.(*types.FieldType), Path: $5}
+	}
+|	"NESTED" stringLit JSONTableColumnsClause
+	{
+		$$ = &ast.JSONTableColumn{Tp: ast.JSONTableColumnNested, Path: $2, NestedColumns: $3.([]*ast.JSONTableColumn)}
+	}
+|	"NESTED" "PATH" stringLit JSONTableColumnsClause
+	{
+		$$ = &ast.JSONTableColumn{Tp: ast.JSONTableColumnNested, Path: $3, NestedColumns: $4.([]*ast.JSONTableColumn)}
+	}
+
+JSONTableOnEmptyOnErrorOpt:
+	/* empty */
+	{
+		$$ = []*ast.JSONTableOnResponse{nil, nil}
+	}
+|	JSONTableOnResponse "ON" "EMPTY"
+	{
+		$$ = []*ast.JSONTableOnResponse{$1.(*ast.JSONTableOnResponse), nil}
+	}
+|	JSONTableOnResponse "ON" "ERROR"
+	{
+		$$ = []*ast.JSONTableOnResponse{nil, $1.(*ast.JSONTableOnResponse)}
+	}
+|	JSONTableOnResponse "ON" "EMPTY" JSONTableOnResponse "ON" "ERROR"
+	{
+		$$ = []*ast.JSONTableOnResponse{$1.(*ast.JSONTableOnResponse), $4.(*ast.JSONTableOnResponse)}
+	}
+
+JSONTableOnResponse:
+	"NULL"
+	{
+		$$ = &ast.JSONTableOnResponse{Tp: ast.JSONTableOnResponseNull}
+	}
+|	"ERROR"
+	{
+		$$ = &ast.JSONTableOnResponse{Tp: ast.JSONTableOnResponseError}
+	}
+|	"DEFAULT" stringLit
+	{
+		$$ = &ast.JSONTableOnResponse{Tp: ast.JSONTableOnResponseDefault, Default: $2}
+	}
+
 PartitionNameListOpt:
 	/* empty */
 	{
@@ -11185,6 +11719,13 @@ AdminStmt:
 			Tp: ast.AdminEvolveBindings,
 		}
 	}
+|	"ADMIN" "EVOLVE" "BINDINGS" "FOR" "SQL" "DIGEST" stringLit
+	{
+		$$ = &ast.AdminStmt{
+			Tp:        ast.AdminEvolveBindings,
+			SQLDigest: $7,
+		}
+	}
 |	"ADMIN" "RELOAD" "BINDINGS"
 	{
 		$$ = &ast.AdminStmt{
@@ -11490,6 +12031,13 @@ ShowStmt:
 			Procedure: $4.(*ast.TableName),
 		}
 	}
+|	"SHOW" "CREATE" "EVENT" TableName
+	{
+		$$ = &ast.ShowStmt{
+			Tp:        ast.ShowCreateEvent,
+			Procedure: $4.(*ast.TableName),
+		}
+	}
 
 ShowPlacementTarget:
 	DatabaseSym DBName
@@ -12040,6 +12588,7 @@ Statement:
 |	AlterInstanceStmt
 |	AlterRangeStmt
 |	AlterSequenceStmt
+|	AlterEventStmt
 |	AlterPolicyStmt
 |	AlterResourceGroupStmt
 |	AnalyzeTableStmt
@@ -12057,6 +12606,9 @@ Statement:
 |	CreateIndexStmt
 |	CreateTableStmt
 |	CreateViewStmt
+|	CreateTriggerStmt
+|	CreateEventStmt
+|	CreateMaterializedViewStmt
 |	CreateUserStmt
 |	CreateRoleStmt
 |	CreateBindingStmt
@@ -12074,6 +12626,9 @@ Statement:
 |	DropPolicyStmt
 |	DropSequenceStmt
 |	DropViewStmt
+|	DropTriggerStmt
+|	DropEventStmt
+|	DropMaterializedViewStmt
 |	DropUserStmt
 |	DropResourceGroupStmt
 |	DropQueryWatchStmt
@@ -12104,6 +12659,8 @@ Statement:
 |	RenameUserStmt
 |	ReplaceIntoStmt
 |	RecoverTableStmt
+|	RecommendIndexStmt
+|	RefreshMaterializedViewStmt
 |	ReleaseSavepointStmt
 |	RevokeStmt
 |	RevokeRoleStmt
@@ -12606,6 +13163,7 @@ Type:
 	NumericType
 |	StringType
 |	DateAndTimeType
+|	SpatialType
 
 NumericType:
 	IntegerType OptFieldLen FieldOpts
@@ -12994,6 +13552,54 @@ StringType:
 		$$ = tp
 	}
 
+SpatialType:
+	GeometryTypeName
+	{
+		tp := types.NewFieldType(mysql.TypeGeometry)
+		tp.SetGeometryType($1.(byte))
+		tp.SetCharset(charset.CharsetBin)
+		tp.SetCollate(charset.CollationBin)
+		$$ = tp
+	}
+
+GeometryTypeName:
+	"GEOMETRY"
+	{
+		$$ = mysql.GeometryTypeGeometry
+	}
+|	"POINT"
+	{
+		$$ = mysql.GeometryTypePoint
+	}
+|	"LINESTRING"
+	{
+		$$ = mysql.GeometryTypeLineString
+	}
+|	"POLYGON"
+	{
+		$$ = mysql.GeometryTypePolygon
+	}
+|	"MULTIPOINT"
+	{
+		$$ = mysql.GeometryTypeMultiPoint
+	}
+|	"MULTILINESTRING"
+	{
+		$$ = mysql.GeometryTypeMultiLineString
+	}
+|	"MULTIPOLYGON"
+	{
+		$$ = mysql.GeometryTypeMultiPolygon
+	}
+|	"GEOMETRYCOLLECTION"
+	{
+		$$ = mysql.GeometryTypeGeometryCollection
+	}
+|	"GEOMCOLLECTION"
+	{
+		$$ = mysql.GeometryTypeGeometryCollection
+	}
+
 Char:
 	"CHARACTER"
 |	"CHAR"
@@ -15268,6 +15874,27 @@ IndexAdviseStmt:
 		$$ = x
 	}
 
+/*******************************************************************
+ *
+ *  Recommend Index Statement
+ *
+ *  Example:
+ *  RECOMMEND INDEX RUN
+ *  RECOMMEND INDEX RUN FOR 'SELECT * FROM t WHERE a = 1'
+ *******************************************************************/
+RecommendIndexStmt:
+	"RECOMMEND" "INDEX" "RUN"
+	{
+		$$ = &ast.RecommendIndexStmt{Action: "run"}
+	}
+|	"RECOMMEND" "INDEX" "RUN" "FOR" stringLit
+	{
+		$$ = &ast.RecommendIndexStmt{
+			Action: "run",
+			SQL:    $5,
+		}
+	}
+
 MaxMinutesOpt:
 	{
 		$$ = uint64(ast.UnspecifiedSize)
@@ -15601,6 +16228,10 @@ ProcedureStatementStmt:
 |	DeleteFromStmt
 |	AnalyzeTableStmt
 |	TruncateTableStmt
+|	CallStmt
+|	CreateEventStmt
+|	AlterEventStmt
+|	DropEventStmt
 
 ProcedureCursorSelectStmt:
 	SelectStmt
@@ -15991,6 +16622,12 @@ ProcedureUnlabelLoopStmt:
 			Condition: $4.(ast.ExprNode),
 		}
 	}
+|	"LOOP" ProcedureProcStmt1s "END" "LOOP"
+	{
+		$$ = &ast.ProcedureLoopStmt{
+			Body: $2.([]ast.StmtNode),
+		}
+	}
 
 ProcedureLabeledBlock:
 	identifier ':' ProcedureBlockContent ProcedurceLabelOpt
@@ -16070,6 +16707,7 @@ ProcedureProcStmt:
  *	CREATE
  *  [DEFINER = user]
  *  PROCEDURE [IF NOT EXISTS] sp_name ([proc_parameter[,...]])
+ *  [SQL SECURITY { DEFINER | INVOKER }]
  *  routine_body
  *  proc_parameter:
  *  [ IN | OUT | INOUT ] param_name type
@@ -16081,22 +16719,23 @@ ProcedureProcStmt:
  *  Valid SQL routine statement
  ********************************************************************************************/
 CreateProcedureStmt:
-	"CREATE" "PROCEDURE" IfNotExists TableName '(' OptSpPdparams ')' ProcedureProcStmt
+	"CREATE" "PROCEDURE" IfNotExists TableName '(' OptSpPdparams ')' ViewSQLSecurity ProcedureProcStmt
 	{
 		x := &ast.ProcedureInfo{
 			IfNotExists:    $3.(bool),
 			ProcedureName:  $4.(*ast.TableName),
 			ProcedureParam: $6.([]*ast.StoreParameter),
-			ProcedureBody:  $8,
+			Security:       $8.(model.ViewSecurity),
+			ProcedureBody:  $9,
 		}
 		startOffset := parser.startOffset(&yyS[yypt])
-		originStmt := $8
+		originStmt := $9
 		originStmt.SetText(parser.lexer.client, strings.TrimSpace(parser.src[startOffset:parser.yylval.offset]))
-		startOffset = parser.startOffset(&yyS[yypt-3])
+		startOffset = parser.startOffset(&yyS[yypt-4])
 		if parser.src[startOffset] == '(' {
 			startOffset++
 		}
-		endOffset := parser.startOffset(&yyS[yypt-1])
+		endOffset := parser.startOffset(&yyS[yypt-2])
 		x.ProcedureParamStr = strings.TrimSpace(parser.src[startOffset:endOffset])
 		$$ = x
 	}
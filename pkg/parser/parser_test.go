@@ -503,6 +503,7 @@ func TestAdminStmt(t *testing.T) {
 		{"admin flush bindings", true, "ADMIN FLUSH BINDINGS"},
 		{"admin capture bindings", true, "ADMIN CAPTURE BINDINGS"},
 		{"admin evolve bindings", true, "ADMIN EVOLVE BINDINGS"},
+		{"admin evolve bindings for sql digest 'a9a0'", true, "ADMIN EVOLVE BINDINGS FOR SQL DIGEST 'a9a0'"},
 		{"admin reload bindings", true, "ADMIN RELOAD BINDINGS"},
 		// This case would be removed once TiDB PR to remove ADMIN RELOAD STATISTICS is merged.
 		{"admin reload statistics", true, "ADMIN RELOAD STATS_EXTENDED"},
@@ -1195,6 +1196,8 @@ func TestDBAStmt(t *testing.T) {
 		{`SHOW INDEXES IN t where true;`, true, "SHOW INDEX IN `t` WHERE TRUE"},
 		{`SHOW KEYS FROM t FROM test where true;`, true, "SHOW INDEX IN `test`.`t` WHERE TRUE"},
 		{`SHOW EVENTS FROM test_db WHERE definer = 'current_user'`, true, "SHOW EVENTS IN `test_db` WHERE `definer`=_UTF8MB4'current_user'"},
+		{`SHOW CREATE EVENT e`, true, "SHOW CREATE EVENT `e`"},
+		{`SHOW CREATE EVENT test_db.e`, true, "SHOW CREATE EVENT `test_db`.`e`"},
 		{`SHOW PLUGINS`, true, "SHOW PLUGINS"},
 		{`SHOW PROFILES`, true, "SHOW PROFILES"},
 		{`SHOW PROFILE`, true, "SHOW PROFILE"},
@@ -5070,6 +5073,13 @@ func TestSubquery(t *testing.T) {
 		{"select * from ((SELECT 1 a,3 b) UNION (SELECT 2,1) ORDER BY (SELECT 2)) t order by a,b", true, "SELECT * FROM ((SELECT 1 AS `a`,3 AS `b`) UNION (SELECT 2,1) ORDER BY (SELECT 2)) AS `t` ORDER BY `a`,`b`"},
 		{"select (select * from t1 where a != t.a union all (select * from t2 where a != t.a) order by a limit 1) from t1 t", true, "SELECT (SELECT * FROM `t1` WHERE `a`!=`t`.`a` UNION ALL (SELECT * FROM `t2` WHERE `a`!=`t`.`a`) ORDER BY `a` LIMIT 1) FROM `t1` AS `t`"},
 		{"(WITH v0 AS (SELECT TRUE) (SELECT 'abc' EXCEPT (SELECT TRUE)))", true, "WITH `v0` AS (SELECT TRUE) (SELECT _UTF8MB4'abc' EXCEPT (SELECT TRUE))"},
+
+		// for lateral derived table
+		{"select * from t, lateral (select b from t1 where t1.a = t.a limit 3) as dt", true, "SELECT * FROM (`t`) JOIN LATERAL (SELECT `b` FROM `t1` WHERE `t1`.`a`=`t`.`a` LIMIT 3) AS `dt`"},
+		{"select * from t join lateral (select count(*) c from t1 where t1.a = t.a) dt on true", true, "SELECT * FROM `t` JOIN LATERAL (SELECT COUNT(1) AS `c` FROM `t1` WHERE `t1`.`a`=`t`.`a`) AS `dt` ON TRUE"},
+		{"select * from t left join lateral (select b from t1 where t1.a = t.a union select 1) dt on dt.b > 0", true, "SELECT * FROM `t` LEFT JOIN LATERAL (SELECT `b` FROM `t1` WHERE `t1`.`a`=`t`.`a` UNION SELECT 1) AS `dt` ON `dt`.`b`>0"},
+		{"select * from lateral t", false, ""},
+		{"select lateral from t", false, ""},
 	}
 	RunTest(t, table, false)
 
@@ -5700,6 +5710,127 @@ func TestView(t *testing.T) {
 	require.Equal(t, model.CheckOptionCascaded, v.CheckOption)
 }
 
+// runBlockRestoreTest checks the restored SQL of a statement with a BEGIN ... END body. The statements
+// in the block are not visited by CleanNodeText, so the ASTs can't be compared as RunRestoreTest does.
+func runBlockRestoreTest(t *testing.T, sourceSQL, expectSQL string) {
+	p := parser.New()
+	stmt, err := p.ParseOneStmt(sourceSQL, "", "")
+	require.NoErrorf(t, err, "source %v", sourceSQL)
+	var sb strings.Builder
+	require.NoError(t, stmt.Restore(NewRestoreCtx(DefaultRestoreFlags, &sb)))
+	require.Equalf(t, expectSQL, sb.String(), "source %v", sourceSQL)
+	_, err = p.ParseOneStmt(sb.String(), "", "")
+	require.NoErrorf(t, err, "restore %v", sb.String())
+}
+
+func TestTrigger(t *testing.T) {
+	table := []testCase{
+		{"create trigger trg before insert on t for each row set new.a = 1", true, "CREATE DEFINER = CURRENT_USER TRIGGER `trg` BEFORE INSERT ON `t` FOR EACH ROW SET @@SESSION.`new.a`=1"},
+		{"create definer = 'root'@'localhost' trigger if not exists test.trg after update on test.t for each row follows trg0 insert into log values (old.a, new.a)", true, "CREATE DEFINER = `root`@`localhost` TRIGGER IF NOT EXISTS `test`.`trg` AFTER UPDATE ON `test`.`t` FOR EACH ROW FOLLOWS `trg0` INSERT INTO `log` VALUES (`old`.`a`,`new`.`a`)"},
+		{"create or replace trigger trg before insert on t for each row set new.a = 1", false, ""},
+		{"create algorithm = merge trigger trg before insert on t for each row set new.a = 1", false, ""},
+		{"create trigger trg before select on t for each row set new.a = 1", false, ""},
+		{"create trigger trg insert on t for each row set new.a = 1", false, ""},
+		{"create trigger trg before insert on t set new.a = 1", false, ""},
+		{"drop trigger trg", true, "DROP TRIGGER `trg`"},
+		{"drop trigger if exists test.trg", true, "DROP TRIGGER IF EXISTS `test`.`trg`"},
+		{"drop trigger trg1, trg2", false, ""},
+	}
+	RunTest(t, table, false)
+	runBlockRestoreTest(t, "create trigger trg before delete on t for each row precedes trg0 begin declare x int default 0; set x = old.a; insert into log values (x); end",
+		"CREATE DEFINER = CURRENT_USER TRIGGER `trg` BEFORE DELETE ON `t` FOR EACH ROW PRECEDES `trg0` BEGIN DECLARE `x` INT(11) DEFAULT 0;SET @@SESSION.`x`=`old`.`a`;INSERT INTO `log` VALUES (`x`); END")
+
+	p := parser.New()
+	stmt, err := p.ParseOneStmt("create trigger trg after delete on t for each row follows trg0 delete from t2 where a = old.a", "", "")
+	require.NoError(t, err)
+	trg, ok := stmt.(*ast.CreateTriggerStmt)
+	require.True(t, ok)
+	require.True(t, trg.Definer.CurrentUser)
+	require.Equal(t, model.TriggerAfter, trg.Timing)
+	require.Equal(t, model.TriggerDelete, trg.Event)
+	require.Equal(t, "trg", trg.TriggerName.Name.O)
+	require.Equal(t, "t", trg.Table.Name.O)
+	require.True(t, trg.Order.Follows)
+	require.Equal(t, "trg0", trg.Order.OtherTrigger.O)
+	require.Equal(t, "delete from t2 where a = old.a", trg.Body.Text())
+}
+
+func TestEvent(t *testing.T) {
+	table := []testCase{
+		{"create event e on schedule at '2024-01-01 00:00:00' do delete from t", true, "CREATE DEFINER = CURRENT_USER EVENT `e` ON SCHEDULE AT _UTF8MB4'2024-01-01 00:00:00' DO DELETE FROM `t`"},
+		{"create event if not exists test.e on schedule every 1 hour starts current_timestamp() ends current_timestamp() + interval 1 day on completion preserve disable comment 'hourly' do insert into log values (now())", true, "CREATE DEFINER = CURRENT_USER EVENT IF NOT EXISTS `test`.`e` ON SCHEDULE EVERY 1 HOUR STARTS CURRENT_TIMESTAMP() ENDS DATE_ADD(CURRENT_TIMESTAMP(), INTERVAL 1 DAY) ON COMPLETION PRESERVE DISABLE COMMENT 'hourly' DO INSERT INTO `log` VALUES (NOW())"},
+		{"create or replace event e on schedule at now() do delete from t", false, ""},
+		{"create event e on schedule every 1 do delete from t", false, ""},
+		{"create event e on schedule at now() ends now() do delete from t", false, ""},
+		{"create event e do delete from t", false, ""},
+		{"alter event e on schedule every 2 minute", true, "ALTER DEFINER = CURRENT_USER EVENT `e` ON SCHEDULE EVERY 2 MINUTE"},
+		{"alter event test.e on completion preserve rename to test.e2 enable comment '' do delete from t", true, "ALTER DEFINER = CURRENT_USER EVENT `test`.`e` ON COMPLETION PRESERVE RENAME TO `test`.`e2` ENABLE COMMENT '' DO DELETE FROM `t`"},
+		{"alter definer = 'root'@'%' event e on schedule at now() on completion not preserve disable", true, "ALTER DEFINER = `root`@`%` EVENT `e` ON SCHEDULE AT NOW() ON COMPLETION NOT PRESERVE DISABLE"},
+		{"alter event e rename e2", false, ""},
+		{"drop event e", true, "DROP EVENT `e`"},
+		{"drop event if exists test.e", true, "DROP EVENT IF EXISTS `test`.`e`"},
+		{"drop event e1, e2", false, ""},
+		// The new keywords are unreserved.
+		{"create table every (at int, starts int, ends int, completion int)", true, "CREATE TABLE `every` (`at` INT,`starts` INT,`ends` INT,`completion` INT)"},
+	}
+	RunTest(t, table, false)
+	runBlockRestoreTest(t, "create definer = 'root'@'localhost' event e on schedule every 10 second on completion not preserve disable on slave do begin delete from t; insert into t values (1); end",
+		"CREATE DEFINER = `root`@`localhost` EVENT `e` ON SCHEDULE EVERY 10 SECOND ON COMPLETION NOT PRESERVE DISABLE ON SLAVE DO BEGIN DELETE FROM `t`;INSERT INTO `t` VALUES (1); END")
+
+	p := parser.New()
+	stmt, err := p.ParseOneStmt("create event e on schedule every 5 minute starts '2024-01-01' on completion preserve do delete from t where a < now()", "", "")
+	require.NoError(t, err)
+	ev, ok := stmt.(*ast.CreateEventStmt)
+	require.True(t, ok)
+	require.True(t, ev.Definer.CurrentUser)
+	require.Equal(t, "e", ev.EventName.Name.O)
+	require.Nil(t, ev.Schedule.At)
+	require.Equal(t, ast.TimeUnitMinute, ev.Schedule.Unit)
+	require.NotNil(t, ev.Schedule.Starts)
+	require.Nil(t, ev.Schedule.Ends)
+	require.Equal(t, ast.EventCompletionPreserve, ev.Completion)
+	require.Equal(t, ast.EventStatusDefault, ev.Status)
+	require.Nil(t, ev.Comment)
+	require.Equal(t, "delete from t where a < now()", ev.Body.Text())
+
+	stmt, err = p.ParseOneStmt("alter event e do update t set a = a + 1", "", "")
+	require.NoError(t, err)
+	alter, ok := stmt.(*ast.AlterEventStmt)
+	require.True(t, ok)
+	require.Nil(t, alter.Schedule)
+	require.Nil(t, alter.RenameTo)
+	require.Equal(t, ast.EventCompletionDefault, alter.Completion)
+	require.Equal(t, "update t set a = a + 1", alter.Body.Text())
+}
+
+func TestMaterializedView(t *testing.T) {
+	table := []testCase{
+		{"create materialized view mv as select a, count(*) from t group by a", true, "CREATE MATERIALIZED VIEW `mv` REFRESH COMPLETE AS SELECT `a`,COUNT(1) FROM `t` GROUP BY `a`"},
+		{"create materialized view if not exists test.mv (a, cnt, s, cnt_b) refresh incremental as select a, count(*), sum(b), count(b) from t group by a", true, "CREATE MATERIALIZED VIEW IF NOT EXISTS `test`.`mv` (`a`,`cnt`,`s`,`cnt_b`) REFRESH INCREMENTAL AS SELECT `a`,COUNT(1),SUM(`b`),COUNT(`b`) FROM `t` GROUP BY `a`"},
+		{"create materialized view mv refresh complete as (select * from t)", true, "CREATE MATERIALIZED VIEW `mv` REFRESH COMPLETE AS (SELECT * FROM `t`)"},
+		{"create materialized view mv refresh fast as select * from t", false, ""},
+		{"create or replace materialized view mv as select * from t", false, ""},
+		{"drop materialized view mv", true, "DROP MATERIALIZED VIEW `mv`"},
+		{"drop materialized view if exists test.mv", true, "DROP MATERIALIZED VIEW IF EXISTS `test`.`mv`"},
+		{"drop materialized view mv1, mv2", false, ""},
+		{"refresh materialized view mv", true, "REFRESH MATERIALIZED VIEW `mv`"},
+		{"refresh materialized view test.mv complete", true, "REFRESH MATERIALIZED VIEW `test`.`mv` COMPLETE"},
+		{"refresh materialized view mv incremental", true, "REFRESH MATERIALIZED VIEW `mv` INCREMENTAL"},
+		{"refresh materialized view", false, ""},
+		// The new keywords are unreserved.
+		{"create table refresh (complete int, materialized int)", true, "CREATE TABLE `refresh` (`complete` INT,`materialized` INT)"},
+	}
+	RunTest(t, table, false)
+
+	p := parser.New()
+	stmt, err := p.ParseOneStmt("create materialized view mv refresh incremental as select a, count(*) from t group by a", "", "")
+	require.NoError(t, err)
+	mv, ok := stmt.(*ast.CreateMaterializedViewStmt)
+	require.True(t, ok)
+	require.Equal(t, model.MViewRefreshIncremental, mv.RefreshMethod)
+	require.Equal(t, "select a, count(*) from t group by a", mv.Select.Text())
+}
+
 func TestTimestampDiffUnit(t *testing.T) {
 	// Test case for timestampdiff unit.
 	// TimeUnit should be unified to upper case.
@@ -5942,6 +6073,44 @@ func TestAnalyze(t *testing.T) {
 	RunTest(t, table, false)
 }
 
+func TestJSONTable(t *testing.T) {
+	table := []testCase{
+		{"select * from json_table('[{\"a\":1},{\"a\":2}]', '$[*]' columns (id for ordinality, a int path '$.a')) as jt", true, "SELECT * FROM JSON_TABLE(_UTF8MB4'[{\"a\":1},{\"a\":2}]', '$[*]' COLUMNS (`id` FOR ORDINALITY, `a` INT PATH '$.a')) AS `jt`"},
+		{"select jt.* from t, json_table(t.doc, '$.items[*]' columns (name varchar(10) path '$.name' default '\"x\"' on empty error on error, has_tag int exists path '$.tag', nested path '$.tags[*]' columns (tag varchar(10) path '$'), nested '$.ids[*]' columns (id json path '$' null on error))) jt",
+			true, "SELECT `jt`.* FROM (`t`) JOIN JSON_TABLE(`t`.`doc`, '$.items[*]' COLUMNS (`name` VARCHAR(10) PATH '$.name' DEFAULT '\"x\"' ON EMPTY ERROR ON ERROR, `has_tag` INT EXISTS PATH '$.tag', NESTED PATH '$.tags[*]' COLUMNS (`tag` VARCHAR(10) PATH '$'), NESTED PATH '$.ids[*]' COLUMNS (`id` JSON PATH '$' NULL ON ERROR))) AS `jt`"},
+		{"select * from t left join json_table(t.doc, '$' columns (a int path '$.a' null on empty)) jt on true", true, "SELECT * FROM `t` LEFT JOIN JSON_TABLE(`t`.`doc`, '$' COLUMNS (`a` INT PATH '$.a' NULL ON EMPTY)) AS `jt` ON TRUE"},
+		{"select nested, path, ordinality, empty from t", true, "SELECT `nested`,`path`,`ordinality`,`empty` FROM `t`"},
+		{"create table json_table (a int)", true, "CREATE TABLE `json_table` (`a` INT)"},
+
+		{"select * from json_table('[]', '$' columns ()) jt", false, ""},
+		{"select * from json_table('[]', '$') jt", false, ""},
+		{"select * from json_table('[]', concat('$', '') columns (a int path '$')) jt", false, ""},
+		{"select * from json_table('[]', '$' columns (a int path '$' error on error null on empty)) jt", false, ""},
+		{"select * from json_table('[]', '$' columns (a for ordinality path '$')) jt", false, ""},
+	}
+	RunTest(t, table, false)
+}
+
+func TestSpatial(t *testing.T) {
+	table := []testCase{
+		{"create table t (g geometry not null srid 4326, p point, l linestring, po polygon, mp multipoint, ml multilinestring, mpo multipolygon, gc geometrycollection, gc2 geomcollection)", true,
+			"CREATE TABLE `t` (`g` GEOMETRY NOT NULL SRID 4326,`p` POINT,`l` LINESTRING,`po` POLYGON,`mp` MULTIPOINT,`ml` MULTILINESTRING,`mpo` MULTIPOLYGON,`gc` GEOMCOLLECTION,`gc2` GEOMCOLLECTION)"},
+		{"create table t (g geometry not null, p point not null srid 0, spatial key (g), spatial index idx (p))", true,
+			"CREATE TABLE `t` (`g` GEOMETRY NOT NULL,`p` POINT NOT NULL SRID 0,SPATIAL(`g`),SPATIAL `idx`(`p`))"},
+		{"alter table t add spatial index idx (g)", true, "ALTER TABLE `t` ADD SPATIAL `idx`(`g`)"},
+		{"alter table t add column g2 polygon srid 4326", true, "ALTER TABLE `t` ADD COLUMN `g2` POLYGON SRID 4326"},
+		{"select point(1, 2), linestring(point(0, 0), point(1, 1)), polygon(linestring(point(0, 0), point(1, 0), point(0, 1), point(0, 0)))", true,
+			"SELECT POINT(1, 2),LINESTRING(POINT(0, 0), POINT(1, 1)),POLYGON(LINESTRING(POINT(0, 0), POINT(1, 0), POINT(0, 1), POINT(0, 0)))"},
+		{"select geometry, polygon, srid, multipoint from t", true, "SELECT `geometry`,`polygon`,`srid`,`multipoint` FROM `t`"},
+
+		{"create table t (g geometry(10))", false, ""},
+		{"create table t (g geometry srid)", false, ""},
+		{"create table t (g geometry srid 4294967296)", false, ""},
+		{"create table t (g geometry, spatial unique key (g))", false, ""},
+	}
+	RunTest(t, table, false)
+}
+
 func TestTableSample(t *testing.T) {
 	table := []testCase{
 		// positive test cases
@@ -6875,6 +7044,18 @@ func TestIndexAdviseStmt(t *testing.T) {
 	RunTest(t, table, false)
 }
 
+func TestRecommendIndexStmt(t *testing.T) {
+	table := []testCase{
+		{"RECOMMEND INDEX RUN", true, "RECOMMEND INDEX RUN"},
+		{"recommend index run for 'select * from t where a = 1'", true, "RECOMMEND INDEX RUN FOR 'select * from t where a = 1'"},
+		{"RECOMMEND INDEX RUN FOR", false, ""},
+		{"RECOMMEND INDEX", false, ""},
+		{"RECOMMEND INDEX RUN FOR 'select 1' FOR 'select 2'", false, ""},
+		{"create table recommend (recommend int)", true, "CREATE TABLE `recommend` (`recommend` INT)"},
+	}
+	RunTest(t, table, false)
+}
+
 // For BRIE
 func TestBRIE(t *testing.T) {
 	table := []testCase{
@@ -107,6 +107,25 @@ var str2Type = map[string]byte{
 	"year":        mysql.TypeYear,
 }
 
+var geometryType2Str = []string{
+	mysql.GeometryTypeGeometry:           "geometry",
+	mysql.GeometryTypePoint:              "point",
+	mysql.GeometryTypeLineString:         "linestring",
+	mysql.GeometryTypePolygon:            "polygon",
+	mysql.GeometryTypeMultiPoint:         "multipoint",
+	mysql.GeometryTypeMultiLineString:    "multilinestring",
+	mysql.GeometryTypeMultiPolygon:       "multipolygon",
+	mysql.GeometryTypeGeometryCollection: "geomcollection",
+}
+
+// GeometryTypeToStr converts the subtype of the GEOMETRY type to a string.
+func GeometryTypeToStr(tp byte) string {
+	if int(tp) < len(geometryType2Str) {
+		return geometryType2Str[tp]
+	}
+	return geometryType2Str[mysql.GeometryTypeGeometry]
+}
+
 // TypeStr converts tp to a string.
 func TypeStr(tp byte) (r string) {
 	return type2Str[tp]
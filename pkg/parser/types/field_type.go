@@ -58,6 +58,11 @@ type FieldType struct {
 	elems            []string
 	elemsIsBinaryLit []bool
 	array            bool
+	// geometryType is the subtype of the GEOMETRY type, e.g. POINT.
+	geometryType byte
+	// srid is the SRID of the GEOMETRY column, which is only meaningful if hasSRID is true.
+	srid    uint32
+	hasSRID bool
 	// Please keep in mind that jsonFieldType should be updated if you add a new field here.
 }
 
@@ -244,6 +249,27 @@ func (ft *FieldType) ArrayType() *FieldType {
 	return clone
 }
 
+// GetGeometryType returns the subtype of the GEOMETRY type.
+func (ft *FieldType) GetGeometryType() byte {
+	return ft.geometryType
+}
+
+// SetGeometryType sets the subtype of the GEOMETRY type.
+func (ft *FieldType) SetGeometryType(tp byte) {
+	ft.geometryType = tp
+}
+
+// GetSRID returns the SRID of the GEOMETRY column, and whether it's specified.
+func (ft *FieldType) GetSRID() (uint32, bool) {
+	return ft.srid, ft.hasSRID
+}
+
+// SetSRID sets the SRID of the GEOMETRY column.
+func (ft *FieldType) SetSRID(srid uint32) {
+	ft.srid = srid
+	ft.hasSRID = true
+}
+
 // SetElemWithIsBinaryLit sets the element of the FieldType.
 func (ft *FieldType) SetElemWithIsBinaryLit(idx int, element string, isBinaryLit bool) {
 	ft.elems[idx] = element
@@ -297,7 +323,8 @@ func (ft *FieldType) Equal(other *FieldType) bool {
 		ft.charset == other.charset &&
 		ft.collate == other.collate &&
 		flenEqual &&
-		mysql.HasUnsignedFlag(ft.flag) == mysql.HasUnsignedFlag(other.flag)
+		mysql.HasUnsignedFlag(ft.flag) == mysql.HasUnsignedFlag(other.flag) &&
+		ft.geometryType == other.geometryType
 	if !partialEqual {
 		return false
 	}
@@ -342,6 +369,8 @@ func (ft *FieldType) EvalType() EvalType {
 		return ETDuration
 	case mysql.TypeJSON:
 		return ETJson
+	case mysql.TypeTiDBVectorFloat32:
+		return ETVectorFloat32
 	case mysql.TypeEnum, mysql.TypeSet:
 		if ft.flag&mysql.EnumSetAsIntFlag > 0 {
 			return ETInt
@@ -366,6 +395,9 @@ func (ft *FieldType) Init(tp byte) {
 // This is used for showing column type in infoschema.
 func (ft *FieldType) CompactStr() string {
 	ts := TypeToStr(ft.GetType(), ft.charset)
+	if ft.GetType() == mysql.TypeGeometry {
+		ts = GeometryTypeToStr(ft.geometryType)
+	}
 	suffix := ""
 
 	defaultFlen, defaultDecimal := mysql.GetDefaultFieldLengthAndDecimal(ft.GetType())
@@ -473,6 +505,10 @@ func (ft *FieldType) String() string {
 
 // Restore implements Node interface.
 func (ft *FieldType) Restore(ctx *format.RestoreCtx) error {
+	if ft.GetType() == mysql.TypeGeometry {
+		ctx.WriteKeyWord(GeometryTypeToStr(ft.geometryType))
+		return nil
+	}
 	ctx.WriteKeyWord(TypeToStr(ft.GetType(), ft.charset))
 
 	precision := UnspecifiedLength
@@ -646,6 +682,9 @@ type jsonFieldType struct {
 	Elems            []string
 	ElemsIsBinaryLit []bool
 	Array            bool
+	GeometryType     byte   `json:",omitempty"`
+	SRID             uint32 `json:",omitempty"`
+	HasSRID          bool   `json:",omitempty"`
 }
 
 // UnmarshalJSON implements the json.Unmarshaler interface.
@@ -662,6 +701,9 @@ func (ft *FieldType) UnmarshalJSON(data []byte) error {
 		ft.elems = r.Elems
 		ft.elemsIsBinaryLit = r.ElemsIsBinaryLit
 		ft.array = r.Array
+		ft.geometryType = r.GeometryType
+		ft.srid = r.SRID
+		ft.hasSRID = r.HasSRID
 	}
 	return err
 }
@@ -678,6 +720,9 @@ func (ft *FieldType) MarshalJSON() ([]byte, error) {
 	r.Elems = ft.elems
 	r.ElemsIsBinaryLit = ft.elemsIsBinaryLit
 	r.Array = ft.array
+	r.GeometryType = ft.geometryType
+	r.SRID = ft.srid
+	r.HasSRID = ft.hasSRID
 	return json.Marshal(r)
 }
 
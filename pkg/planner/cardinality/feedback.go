@@ -0,0 +1,147 @@
+// Copyright 2024 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cardinality
+
+import (
+	"math"
+	"sync"
+	"time"
+)
+
+// maxFeedbackEntries is the max number of entries kept in the feedback store.
+const maxFeedbackEntries = 10000
+
+// FeedbackKey identifies the operator of a plan that the feedback is collected from, and the filters
+// on the table whose selectivity is corrected by the feedback.
+type FeedbackKey struct {
+	PlanDigest string
+	// Operator is the ID of the DataSource read by the operator, like DataSource_1. It's stable
+	// across the optimizations of the same statement.
+	Operator        string
+	PhysicalTableID int64
+	// Conds is the sorted explain string of the filters.
+	Conds string
+}
+
+// Feedback records the actual row count of an operator whose row count was mis-estimated.
+type Feedback struct {
+	EstRows float64
+	ActRows float64
+	// TableRows is the row count of the table when the feedback is collected.
+	TableRows float64
+	// StatsVersion is the version of the table stats used by the estimation. The feedback expires
+	// once the stats are refreshed.
+	StatsVersion uint64
+	UpdateTime   time.Time
+}
+
+// Selectivity returns the actual selectivity of the filters.
+func (f *Feedback) Selectivity() float64 {
+	if f.TableRows <= 0 {
+		return 1
+	}
+	return math.Min(f.ActRows/f.TableRows, 1)
+}
+
+// QError returns the q-error of the estimation, which is the ratio between the larger one and the
+// smaller one of the estimated and actual row count.
+func QError(estRows, actRows float64) float64 {
+	estRows, actRows = math.Max(estRows, 1), math.Max(actRows, 1)
+	return math.Max(estRows, actRows) / math.Min(estRows, actRows)
+}
+
+// FeedbackStore keeps the cardinality feedback collected from the executed plans.
+type FeedbackStore struct {
+	mu      sync.RWMutex
+	entries map[FeedbackKey]*Feedback
+	// plans maps the digest of a statement to the digest of its last plan which recorded feedback.
+	// The next optimization of the statement is corrected by the feedback of that plan only.
+	plans map[string]string
+}
+
+// GlobalFeedbackStore is the feedback store shared by all the sessions of this instance.
+var GlobalFeedbackStore = NewFeedbackStore()
+
+// NewFeedbackStore creates a new FeedbackStore.
+func NewFeedbackStore() *FeedbackStore {
+	return &FeedbackStore{
+		entries: make(map[FeedbackKey]*Feedback),
+		plans:   make(map[string]string),
+	}
+}
+
+// PlanDigest returns the digest of the plan whose feedback corrects the next optimization of the
+// statement, it returns "" if there's no such plan.
+func (s *FeedbackStore) PlanDigest(stmtDigest string) string {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.plans[stmtDigest]
+}
+
+// Get returns the feedback of the key. The feedback collected with another stats version is
+// removed and nil is returned.
+func (s *FeedbackStore) Get(key FeedbackKey, statsVersion uint64) *Feedback {
+	s.mu.RLock()
+	f, ok := s.entries[key]
+	s.mu.RUnlock()
+	if !ok {
+		return nil
+	}
+	if f.StatsVersion != statsVersion {
+		s.mu.Lock()
+		if cur, ok := s.entries[key]; ok && cur == f {
+			delete(s.entries, key)
+		}
+		s.mu.Unlock()
+		return nil
+	}
+	return f
+}
+
+// Put adds or replaces the feedback of the key, which is collected from a plan of the statement. An
+// arbitrary entry is evicted if the store is full.
+func (s *FeedbackStore) Put(stmtDigest string, key FeedbackKey, f *Feedback) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, ok := s.entries[key]; !ok && len(s.entries) >= maxFeedbackEntries {
+		for k := range s.entries {
+			delete(s.entries, k)
+			break
+		}
+	}
+	s.entries[key] = f
+	if _, ok := s.plans[stmtDigest]; !ok && len(s.plans) >= maxFeedbackEntries {
+		for k := range s.plans {
+			delete(s.plans, k)
+			break
+		}
+	}
+	s.plans[stmtDigest] = key.PlanDigest
+}
+
+// Len returns the number of entries in the store.
+func (s *FeedbackStore) Len() int {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return len(s.entries)
+}
+
+// Reset removes all the entries in the store.
+func (s *FeedbackStore) Reset() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.entries = make(map[FeedbackKey]*Feedback)
+	s.plans = make(map[string]string)
+}
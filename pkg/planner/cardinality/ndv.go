@@ -16,8 +16,10 @@ package cardinality
 
 import (
 	"math"
+	"slices"
 
 	"github.com/pingcap/tidb/pkg/expression"
+	"github.com/pingcap/tidb/pkg/parser/ast"
 	"github.com/pingcap/tidb/pkg/planner/property"
 	"github.com/pingcap/tidb/pkg/statistics"
 	"github.com/pingcap/tidb/pkg/util/logutil"
@@ -68,6 +70,69 @@ func getTotalRowCount(statsTbl *statistics.Table, colHist *statistics.Column) in
 	return totCount
 }
 
+// EstimateColsNDVByExtendedStats estimates the NDV of the columns of a table by the CARDINALITY or DEPENDENCY
+// extended statistics on exactly these columns. colNDVs maps the column UniqueID to its NDV, and rowCount is
+// the row count of the table. The second return value is false if there is no such extended statistics.
+func EstimateColsNDVByExtendedStats(coll *statistics.HistColl, colNDVs map[int64]float64, rowCount float64, cols []*expression.Column) (float64, bool) {
+	if coll == nil || coll.ExtendedStats == nil || len(coll.ExtendedStats.Stats) == 0 || len(cols) < 2 {
+		return 0, false
+	}
+	colID2UniqueID := make(map[int64]int64, len(cols))
+	for _, col := range cols {
+		colID, ok := coll.UniqueID2colInfoID[col.UniqueID]
+		if !ok {
+			return 0, false
+		}
+		colID2UniqueID[colID] = col.UniqueID
+	}
+	names := make([]string, 0, len(coll.ExtendedStats.Stats))
+	for name := range coll.ExtendedStats.Stats {
+		names = append(names, name)
+	}
+	// Stabilize the result.
+	slices.Sort(names)
+	var ndv float64
+	found := false
+	for _, name := range names {
+		item := coll.ExtendedStats.Stats[name]
+		if len(item.ColIDs) != len(colID2UniqueID) {
+			continue
+		}
+		covered := true
+		for _, id := range item.ColIDs {
+			if _, ok := colID2UniqueID[id]; !ok {
+				covered = false
+				break
+			}
+		}
+		if !covered {
+			continue
+		}
+		switch item.Tp {
+		case ast.StatsTypeCardinality:
+			if item.ScalarVals > 0 {
+				// The NDV of the column group is more accurate than the one derived from the dependency.
+				return min(item.ScalarVals, max(rowCount, 1)), true
+			}
+		case ast.StatsTypeDependency:
+			if found || len(item.ColIDs) != 2 {
+				continue
+			}
+			ndvX, ndvY := colNDVs[colID2UniqueID[item.ColIDs[0]]], colNDVs[colID2UniqueID[item.ColIDs[1]]]
+			if ndvX <= 0 || ndvY <= 0 {
+				continue
+			}
+			// The value of the second column is determined by the first column on a fraction of rows,
+			// and the rest rows are assumed to be independent.
+			degree := min(max(item.ScalarVals, 0), 1)
+			independent := max(min(ndvX*ndvY, rowCount), ndvX)
+			ndv = max(ndvX+(1-degree)*(independent-ndvX), ndvY)
+			found = true
+		}
+	}
+	return ndv, found
+}
+
 // EstimateColsNDVWithMatchedLen returns the NDV of a couple of columns.
 // If the columns match any GroupNDV maintained by child operator, we can get an accurate NDV.
 // Otherwise, we simply return the max NDV among the columns, which is a lower bound.
@@ -167,7 +167,7 @@ func Selectivity(
 		idxIDs = append(idxIDs, id)
 		return false
 	})
-	// Stablize the result.
+	// Stabilize the result.
 	slices.Sort(idxIDs)
 	for _, id := range idxIDs {
 		idxStats := coll.GetIdx(id)
@@ -257,6 +257,12 @@ func Selectivity(
 			)
 		}
 	}
+	if factor := getSelectivityFactorByExtendedStats(ctx, coll, usedSets); factor != 1 {
+		ret *= factor
+		if sc.EnableOptimizerDebugTrace {
+			debugtrace.RecordAnyValuesWithNames(ctx, "Extended stats factor", factor)
+		}
+	}
 
 	notCoveredConstants := make(map[int]*expression.Constant)
 	notCoveredDNF := make(map[int]*expression.ScalarFunction)
@@ -319,6 +325,24 @@ func Selectivity(
 		// err != nil, no need to do anything.
 	}
 
+	// Try to cover remaining conditions on the expressions by the stats of the expressions.
+	for i, cond := range notCoveredOtherExpr {
+		sel, ok, err := getSelectivityByExpressionStats(ctx, coll, cond)
+		if err != nil {
+			logutil.BgLogger().Debug("something wrong happened when using the expression stats", zap.Error(err))
+			continue
+		}
+		if !ok {
+			continue
+		}
+		ret *= sel
+		mask &^= 1 << uint64(i)
+		delete(notCoveredOtherExpr, i)
+		if sc.EnableOptimizerDebugTrace {
+			debugtrace.RecordAnyValuesWithNames(ctx, "Expression", remainedExprStrs[i], "Selectivity", sel)
+		}
+	}
+
 	// Try to cover remaining DNF conditions using independence assumption,
 	// i.e., sel(condA or condB) = sel(condA) + sel(condB) - sel(condA) * sel(condB)
 OUTER:
@@ -532,6 +556,126 @@ func CalcTotalSelectivityForMVIdxPath(
 	return totalSelectivity
 }
 
+// getSelectivityFactorByExtendedStats returns the factor to adjust the selectivity, which is computed by assuming
+// the columns are independent, of the equal conditions on the columns covered by the CARDINALITY or DEPENDENCY
+// extended statistics.
+func getSelectivityFactorByExtendedStats(ctx context.PlanContext, coll *statistics.HistColl, usedSets []*StatsNode) float64 {
+	if coll.ExtendedStats == nil || len(coll.ExtendedStats.Stats) == 0 {
+		return 1
+	}
+	tc := ctx.GetSessionVars().StmtCtx.TypeCtx()
+	// colID2Sel maps the column ID in the metadata to the selectivity of the equal condition on it.
+	colID2Sel := make(map[int64]float64, len(usedSets))
+	for _, set := range usedSets {
+		if set.Tp != ColType || set.partCover || set.Selectivity <= 0 || len(set.Ranges) != 1 || !set.Ranges[0].IsPointNonNullable(tc) {
+			continue
+		}
+		if colID, ok := coll.UniqueID2colInfoID[set.ID]; ok {
+			colID2Sel[colID] = set.Selectivity
+		}
+	}
+	if len(colID2Sel) < 2 {
+		return 1
+	}
+	names := make([]string, 0, len(coll.ExtendedStats.Stats))
+	for name := range coll.ExtendedStats.Stats {
+		names = append(names, name)
+	}
+	// Stabilize the result.
+	slices.Sort(names)
+	factor := 1.0
+	// Every column is adjusted by one extended stats at most, and the CARDINALITY stats are preferred
+	// because they are able to cover more columns.
+	for _, tp := range []uint8{ast.StatsTypeCardinality, ast.StatsTypeDependency} {
+		for _, name := range names {
+			item := coll.ExtendedStats.Stats[name]
+			if item.Tp != tp || len(item.ColIDs) < 2 {
+				continue
+			}
+			covered := true
+			for _, id := range item.ColIDs {
+				if _, ok := colID2Sel[id]; !ok {
+					covered = false
+					break
+				}
+			}
+			if !covered {
+				continue
+			}
+			product, minSel := 1.0, 1.0
+			for _, id := range item.ColIDs {
+				product *= colID2Sel[id]
+				minSel = min(minSel, colID2Sel[id])
+			}
+			var combined float64
+			switch tp {
+			case ast.StatsTypeCardinality:
+				if item.ScalarVals <= 0 {
+					continue
+				}
+				// The values of the columns combined are expected to match 1/NDV of the rows.
+				combined = min(minSel, max(product, 1/item.ScalarVals))
+			case ast.StatsTypeDependency:
+				if len(item.ColIDs) != 2 {
+					continue
+				}
+				// The first column determines the second column on a fraction of rows, which is the degree.
+				degree := min(max(item.ScalarVals, 0), 1)
+				selX, selY := colID2Sel[item.ColIDs[0]], colID2Sel[item.ColIDs[1]]
+				combined = min(selX*(degree+(1-degree)*selY), selY)
+			}
+			factor *= combined / product
+			for _, id := range item.ColIDs {
+				delete(colID2Sel, id)
+			}
+		}
+	}
+	return factor
+}
+
+// getSelectivityByExpressionStats estimates the selectivity of the condition comparing an expression with constants
+// by the stats of the expression. ok is false if there are no stats of the expression.
+func getSelectivityByExpressionStats(ctx context.PlanContext, coll *statistics.HistColl, cond expression.Expression) (sel float64, ok bool, err error) {
+	if coll.ExpressionStats == nil || len(coll.ExpressionStats.Stats) == 0 {
+		return 0, false, nil
+	}
+	expr, pos, ok := statistics.ExpressionComparedWithConstants(cond)
+	if !ok {
+		return 0, false, nil
+	}
+	hash, ok := statistics.ExpressionStatsKey(expr)
+	if !ok {
+		return 0, false, nil
+	}
+	item, ok := coll.ExpressionStats.Stats[hash]
+	if !ok {
+		return 0, false, nil
+	}
+	tp := expr.GetType(ctx.GetExprCtx().GetEvalCtx())
+	colStats, err := item.ToColumn(tp)
+	if err != nil {
+		return 0, false, err
+	}
+	// Replace the expression with a column to build the ranges.
+	col := &expression.Column{UniqueID: ctx.GetSessionVars().AllocPlanColumnID(), RetType: tp}
+	sf := cond.(*expression.ScalarFunction)
+	args := slices.Clone(sf.GetArgs())
+	args[pos] = col
+	newCond, err := expression.NewFunction(ctx.GetExprCtx(), sf.FuncName.L, sf.RetType, args...)
+	if err != nil {
+		return 0, false, err
+	}
+	mask, ranges, _, err := getMaskAndRanges(ctx, []expression.Expression{newCond}, ranger.ColumnRangeType, nil, nil, col)
+	if err != nil || mask == 0 {
+		return 0, false, err
+	}
+	cnt, err := GetColumnRowCount(ctx, colStats, ranges, coll.RealtimeCount, coll.ModifyCount, false)
+	if err != nil {
+		return 0, false, err
+	}
+	return cnt / float64(coll.RealtimeCount), true, nil
+}
+
 // StatsNode is used for calculating selectivity.
 type StatsNode struct {
 	// Ranges contains all the Ranges we got.
@@ -18,11 +18,13 @@ import (
 	"math"
 
 	"github.com/pingcap/tidb/pkg/expression"
+	"github.com/pingcap/tidb/pkg/kv"
 	plannercore "github.com/pingcap/tidb/pkg/planner/core"
 	impl "github.com/pingcap/tidb/pkg/planner/implementation"
 	"github.com/pingcap/tidb/pkg/planner/memo"
 	"github.com/pingcap/tidb/pkg/planner/pattern"
 	"github.com/pingcap/tidb/pkg/planner/property"
+	"github.com/pingcap/tidb/pkg/planner/util/optimizetrace"
 	"github.com/pingcap/tidb/pkg/util/dbterror/plannererrors"
 )
 
@@ -54,6 +56,9 @@ var defaultImplementationMap = map[pattern.Operand][]ImplementationRule{
 	pattern.OperandTiKVSingleGather: {
 		&ImplTiKVSingleReadGather{},
 	},
+	pattern.OperandTiKVIndexMergeGather: {
+		&ImplTiKVIndexMergeGather{},
+	},
 	pattern.OperandShow: {
 		&ImplShow{},
 	},
@@ -168,8 +173,9 @@ type ImplTiKVSingleReadGather struct {
 }
 
 // Match implements ImplementationRule Match interface.
-func (*ImplTiKVSingleReadGather) Match(_ *memo.GroupExpr, _ *property.PhysicalProperty) (matched bool) {
-	return true
+func (*ImplTiKVSingleReadGather) Match(expr *memo.GroupExpr, prop *property.PhysicalProperty) (matched bool) {
+	// The MPP table reader can't keep the order of the rows.
+	return expr.Children[0].EngineType != pattern.EngineTiFlash || prop.IsSortItemEmpty()
 }
 
 // OnImplement implements ImplementationRule OnImplement interface.
@@ -180,16 +186,47 @@ func (*ImplTiKVSingleReadGather) OnImplement(expr *memo.GroupExpr, reqProp *prop
 		reader := sg.GetPhysicalIndexReader(logicProp.Schema, logicProp.Stats.ScaleByExpectCnt(reqProp.ExpectedCnt), reqProp)
 		return []memo.Implementation{impl.NewIndexReaderImpl(reader, sg.Source)}, nil
 	}
+	if expr.Children[0].EngineType == pattern.EngineTiFlash {
+		reader := sg.GetPhysicalMPPTableReader(logicProp.Schema, logicProp.Stats.ScaleByExpectCnt(reqProp.ExpectedCnt), reqProp)
+		return []memo.Implementation{impl.NewMPPTableReaderImpl(reader, sg.Source)}, nil
+	}
 	reader := sg.GetPhysicalTableReader(logicProp.Schema, logicProp.Stats.ScaleByExpectCnt(reqProp.ExpectedCnt), reqProp)
 	return []memo.Implementation{impl.NewTableReaderImpl(reader, sg.Source)}, nil
 }
 
+// ImplTiKVIndexMergeGather implements TiKVIndexMergeGather as PhysicalIndexMergeReader.
+type ImplTiKVIndexMergeGather struct {
+}
+
+// Match implements ImplementationRule Match interface.
+func (*ImplTiKVIndexMergeGather) Match(_ *memo.GroupExpr, prop *property.PhysicalProperty) (matched bool) {
+	return prop.IsSortItemEmpty()
+}
+
+// OnImplement implements ImplementationRule OnImplement interface.
+func (*ImplTiKVIndexMergeGather) OnImplement(expr *memo.GroupExpr, reqProp *property.PhysicalProperty) ([]memo.Implementation, error) {
+	g := expr.ExprNode.(*plannercore.TiKVIndexMergeGather)
+	reader, err := g.GetPhysicalIndexMergeReader(reqProp)
+	if err != nil || reader == nil {
+		return nil, err
+	}
+	// The reader is built with all of its children, so its cost is calculated here.
+	cost, err := reader.GetPlanCostVer1(property.RootTaskType, optimizetrace.NewDefaultPlanCostOption())
+	if err != nil {
+		return nil, err
+	}
+	return []memo.Implementation{impl.NewIndexMergeReaderImpl(reader, cost)}, nil
+}
+
 // ImplTableScan implements TableScan as PhysicalTableScan.
 type ImplTableScan struct {
 }
 
 // Match implements ImplementationRule Match interface.
 func (*ImplTableScan) Match(expr *memo.GroupExpr, prop *property.PhysicalProperty) (matched bool) {
+	if expr.Group.EngineType == pattern.EngineTiFlash {
+		return prop.IsSortItemEmpty()
+	}
 	ts := expr.ExprNode.(*plannercore.LogicalTableScan)
 	return prop.IsSortItemEmpty() || (len(prop.SortItems) == 1 && ts.HandleCols != nil && prop.SortItems[0].Col.EqualColumn(ts.HandleCols.GetCol(0)))
 }
@@ -203,6 +240,16 @@ func (*ImplTableScan) OnImplement(expr *memo.GroupExpr, reqProp *property.Physic
 		ts.KeepOrder = true
 		ts.Desc = reqProp.SortItems[0].Desc
 	}
+	if expr.Group.EngineType == pattern.EngineTiFlash {
+		ds := logicalScan.Source
+		ts.StoreType = kv.TiFlash
+		ts.PlanPartInfo = &plannercore.PhysPlanPartInfo{
+			PruningConds:   ds.AllConds,
+			PartitionNames: ds.PartitionNames,
+			Columns:        ds.TblCols,
+			ColumnNames:    ds.OutputNames(),
+		}
+	}
 	tblCols, tblColHists := logicalScan.Source.TblCols, logicalScan.Source.TblColHists
 	return []memo.Implementation{impl.NewTableScanImpl(ts, tblCols, tblColHists)}, nil
 }
@@ -277,6 +324,8 @@ func (*ImplSelection) OnImplement(expr *memo.GroupExpr, reqProp *property.Physic
 		return []memo.Implementation{impl.NewTiDBSelectionImpl(physicalSel)}, nil
 	case pattern.EngineTiKV:
 		return []memo.Implementation{impl.NewTiKVSelectionImpl(physicalSel)}, nil
+	case pattern.EngineTiFlash:
+		return []memo.Implementation{impl.NewTiFlashSelectionImpl(physicalSel)}, nil
 	default:
 		return nil, plannererrors.ErrInternal.GenWithStack("Unsupported EngineType '%s' for Selection.", expr.Group.EngineType.String())
 	}
@@ -529,7 +578,7 @@ func (*ImplUnionAll) Match(_ *memo.GroupExpr, prop *property.PhysicalProperty) (
 
 // OnImplement implements ImplementationRule OnImplement interface.
 func (*ImplUnionAll) OnImplement(expr *memo.GroupExpr, reqProp *property.PhysicalProperty) ([]memo.Implementation, error) {
-	logicalUnion := expr.ExprNode.(*plannercore.LogicalUnionAll)
+	logicalUnion := expr.ExprNode
 	chReqProps := make([]*property.PhysicalProperty, len(expr.Children))
 	for i := range expr.Children {
 		chReqProps[i] = &property.PhysicalProperty{ExpectedCnt: reqProp.ExpectedCnt}
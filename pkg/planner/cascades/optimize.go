@@ -16,9 +16,11 @@ package cascades
 
 import (
 	"container/list"
+	"context"
 	"math"
 
 	"github.com/pingcap/tidb/pkg/expression"
+	plannercore "github.com/pingcap/tidb/pkg/planner/core"
 	"github.com/pingcap/tidb/pkg/planner/core/base"
 	"github.com/pingcap/tidb/pkg/planner/memo"
 	"github.com/pingcap/tidb/pkg/planner/pattern"
@@ -122,6 +124,12 @@ func (*Optimizer) onPhasePreprocessing(_ base.PlanContext, plan base.LogicalPlan
 	if err != nil {
 		return nil, err
 	}
+	// The partitions are pruned by the PartitionProcessor under the static prune mode, while they are
+	// pruned when building the readers under the dynamic prune mode.
+	plan, err = plannercore.PrunePartitionsInStaticMode(context.Background(), plan)
+	if err != nil {
+		return nil, err
+	}
 	return plan, nil
 }
 
@@ -273,6 +281,11 @@ func (opt *Optimizer) implGroup(g *memo.Group, reqPhysProp *property.PhysicalPro
 		}
 		return nil, nil
 	}
+	// The Group has been implemented with a larger cost limit but nothing is found, so it's
+	// impossible to find an Implementation under this cost limit.
+	if bound, ok := g.GetCostLowerBound(reqPhysProp); ok && costLimit <= bound {
+		return nil, nil
+	}
 	// Handle implementation rules for each equivalent GroupExpr.
 	var childImpls []memo.Implementation
 	err := opt.fillGroupStats(g)
@@ -332,6 +345,7 @@ func (opt *Optimizer) implGroup(g *memo.Group, reqPhysProp *property.PhysicalPro
 		}
 	}
 	if groupImpl == nil || groupImpl.GetCost() == math.MaxFloat64 {
+		g.UpdateCostLowerBound(reqPhysProp, costLimit)
 		return nil, nil
 	}
 	g.InsertImpl(reqPhysProp, groupImpl)
@@ -57,6 +57,10 @@ func TestImplGroupZeroCost(t *testing.T) {
 	impl, err := NewOptimizer().implGroup(rootGroup, prop, 0.0)
 	require.NoError(t, err)
 	require.Nil(t, impl)
+	// The cost limit is recorded as the lower bound since nothing is found under it.
+	bound, ok := rootGroup.GetCostLowerBound(prop)
+	require.True(t, ok)
+	require.Equal(t, 0.0, bound)
 }
 
 func TestInitGroupSchema(t *testing.T) {
@@ -237,3 +241,52 @@ func TestAppliedRuleSet(t *testing.T) {
 	require.NoError(t, optimizer.onPhaseExploration(ctx.GetPlanCtx(), group))
 	require.Equal(t, 1, rule.appliedTimes)
 }
+
+func TestEnumerateJoinOrders(t *testing.T) {
+	p := parser.New()
+	ctx := plannercore.MockContext()
+	defer func() {
+		domain.GetDomain(ctx).StatsHandle().Close()
+	}()
+	is := infoschema.MockInfoSchema([]*model.TableInfo{plannercore.MockSignedTable()})
+	domain.GetDomain(ctx).MockInfoCacheAndLoadInfoSchema(is)
+	optimizer := NewOptimizer()
+
+	stmt, err := p.ParseOneStmt("select t1.a, t2.b, t3.c from t t1, t t2, t t3 where t1.a = t2.a and t2.b = t3.b", "", "")
+	require.NoError(t, err)
+	plan, err := plannercore.BuildLogicalPlanForTest(context.Background(), ctx, stmt, is)
+	require.NoError(t, err)
+	logic, ok := plan.(base.LogicalPlan)
+	require.True(t, ok)
+	logic, err = optimizer.onPhasePreprocessing(ctx, logic)
+	require.NoError(t, err)
+
+	rootGroup := memo.Convert2Group(logic)
+	require.NoError(t, optimizer.onPhaseExploration(ctx, rootGroup))
+	var findJoinGroup func(g *memo.Group) *memo.Group
+	findJoinGroup = func(g *memo.Group) *memo.Group {
+		if g.GetFirstElem(pattern.OperandJoin) != nil {
+			return g
+		}
+		for _, child := range g.Equivalents.Front().Value.(*memo.GroupExpr).Children {
+			if joinGroup := findJoinGroup(child); joinGroup != nil {
+				return joinGroup
+			}
+		}
+		return nil
+	}
+	joinGroup := findJoinGroup(rootGroup)
+	require.NotNil(t, joinGroup)
+	// Both `(t1 join t2) join t3` and `t1 join (t2 join t3)` are explored, while `(t1 join t3) join t2`
+	// is skipped since it's a cartesian product.
+	joinCnt := 0
+	for elem := joinGroup.GetFirstElem(pattern.OperandJoin); elem != nil; elem = elem.Next() {
+		if _, ok := elem.Value.(*memo.GroupExpr).ExprNode.(*plannercore.LogicalJoin); ok {
+			joinCnt++
+		}
+	}
+	require.Equal(t, 2, joinCnt)
+
+	_, _, err = optimizer.onPhaseImplementation(ctx, rootGroup)
+	require.NoError(t, err)
+}
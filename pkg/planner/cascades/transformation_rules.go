@@ -16,6 +16,7 @@ package cascades
 
 import (
 	"math"
+	"math/bits"
 
 	"github.com/pingcap/tidb/pkg/expression"
 	"github.com/pingcap/tidb/pkg/expression/aggregation"
@@ -62,6 +63,7 @@ type TransformationRuleBatch map[pattern.Operand][]Transformation
 // Each batch will be applied to the memo independently.
 var DefaultRuleBatches = []TransformationRuleBatch{
 	TiDBLayerOptimizationBatch,
+	JoinReorderBatch,
 	TiKVLayerOptimizationBatch,
 	PostTransformationBatch,
 }
@@ -76,6 +78,7 @@ var TiDBLayerOptimizationBatch = TransformationRuleBatch{
 		NewRulePushSelDownUnionAll(),
 		NewRulePushSelDownWindow(),
 		NewRuleMergeAdjacentSelection(),
+		NewRuleEnumerateIndexMergePaths(),
 	},
 	pattern.OperandAggregation: {
 		NewRuleMergeAggregationProjection(),
@@ -115,6 +118,14 @@ var TiDBLayerOptimizationBatch = TransformationRuleBatch{
 	},
 }
 
+// JoinReorderBatch explores the join orders of the inner joins after the join conditions are pushed
+// down by the TiDBLayerOptimizationBatch.
+var JoinReorderBatch = TransformationRuleBatch{
+	pattern.OperandJoin: {
+		NewRuleEnumerateJoinOrders(),
+	},
+}
+
 // TiKVLayerOptimizationBatch does the optimization related to TiKV layer.
 // For example, rules about pushing down Operators like Selection, Limit,
 // Aggregation into TiKV layer should be inside this batch.
@@ -334,7 +345,11 @@ func (*PushSelDownTiKVSingleGather) OnTransform(old *memo.ExprIter) (newExprs []
 	childGroup := old.Children[0].Children[0].Group
 	var pushed, remained []expression.Expression
 	sctx := sg.SCtx()
-	pushed, remained = expression.PushDownExprs(plannercore.GetPushDownCtx(sctx), sel.Conditions, kv.TiKV)
+	storeType := kv.TiKV
+	if childGroup.EngineType == pattern.EngineTiFlash {
+		storeType = kv.TiFlash
+	}
+	pushed, remained = expression.PushDownExprs(plannercore.GetPushDownCtx(sctx), sel.Conditions, storeType)
 	if len(pushed) == 0 {
 		return nil, false, false, nil
 	}
@@ -377,15 +392,63 @@ func NewRuleEnumeratePaths() Transformation {
 // OnTransform implements Transformation interface.
 func (*EnumeratePaths) OnTransform(old *memo.ExprIter) (newExprs []*memo.GroupExpr, eraseOld bool, eraseAll bool, err error) {
 	ds := old.GetExpr().ExprNode.(*plannercore.DataSource)
-	gathers := ds.Convert2Gathers()
+	gathers, mppGathers := ds.Convert2Gathers()
 	for _, gather := range gathers {
 		expr := memo.Convert2GroupExpr(gather)
 		expr.Children[0].SetEngineType(pattern.EngineTiKV)
 		newExprs = append(newExprs, expr)
 	}
+	for _, gather := range mppGathers {
+		expr := memo.Convert2GroupExpr(gather)
+		expr.Children[0].SetEngineType(pattern.EngineTiFlash)
+		newExprs = append(newExprs, expr)
+	}
 	return newExprs, true, false, nil
 }
 
+// EnumerateIndexMergePaths converts the Selection on a DataSource to the index merge paths.
+type EnumerateIndexMergePaths struct {
+	baseRule
+}
+
+// NewRuleEnumerateIndexMergePaths creates a new Transformation EnumerateIndexMergePaths.
+// The pattern of this rule is: `Selection -> DataSource`.
+func NewRuleEnumerateIndexMergePaths() Transformation {
+	rule := &EnumerateIndexMergePaths{}
+	rule.pattern = pattern.BuildPattern(
+		pattern.OperandSelection,
+		pattern.EngineTiDBOnly,
+		pattern.NewPattern(pattern.OperandDataSource, pattern.EngineTiDBOnly),
+	)
+	return rule
+}
+
+// Match implements Transformation interface.
+func (r *EnumerateIndexMergePaths) Match(expr *memo.ExprIter) bool {
+	return !expr.GetExpr().HasAppliedRule(r)
+}
+
+// OnTransform implements Transformation interface.
+// It transforms `Selection -> DataSource` to `TiKVIndexMergeGather`s, one for each index merge path
+// built from the filters of the Selection. The old expression is kept unless the index merge is
+// hinted, so the other paths are enumerated by EnumeratePaths later.
+func (r *EnumerateIndexMergePaths) OnTransform(old *memo.ExprIter) (newExprs []*memo.GroupExpr, eraseOld bool, eraseAll bool, err error) {
+	old.GetExpr().AddAppliedRule(r)
+	sel := old.GetExpr().ExprNode.(*plannercore.LogicalSelection)
+	ds := old.Children[0].GetExpr().ExprNode.(*plannercore.DataSource)
+	source, paths, err := ds.BuildIndexMergePaths(sel.Conditions)
+	if err != nil || len(paths) == 0 {
+		return nil, false, false, err
+	}
+	schema := old.GetExpr().Group.Prop.Schema
+	for _, path := range paths {
+		gather := plannercore.TiKVIndexMergeGather{Source: source, Path: path}.Init(ds.SCtx(), ds.QueryBlockOffset())
+		gather.SetSchema(schema)
+		newExprs = append(newExprs, memo.NewGroupExpr(gather))
+	}
+	return newExprs, len(source.IndexMergeHints) > 0, false, nil
+}
+
 // PushAggDownGather splits Aggregation to two stages, final and partial1,
 // and pushed the partial Aggregation down to the child of TiKVSingleGather.
 type PushAggDownGather struct {
@@ -824,7 +887,7 @@ func (r *PushLimitDownUnionAll) Match(expr *memo.ExprIter) bool {
 // It will transform `Limit->UnionAll->X` to `Limit->UnionAll->Limit->X`.
 func (r *PushLimitDownUnionAll) OnTransform(old *memo.ExprIter) (newExprs []*memo.GroupExpr, eraseOld bool, eraseAll bool, err error) {
 	limit := old.GetExpr().ExprNode.(*plannercore.LogicalLimit)
-	unionAll := old.Children[0].GetExpr().ExprNode.(*plannercore.LogicalUnionAll)
+	unionAll := old.Children[0].GetExpr().ExprNode
 	unionAllSchema := old.Children[0].Group.Prop.Schema
 
 	newLimit := plannercore.LogicalLimit{
@@ -1084,7 +1147,7 @@ func NewRulePushSelDownUnionAll() Transformation {
 // It will transform `Selection->UnionAll->x` to `UnionAll->Selection->x`.
 func (*PushSelDownUnionAll) OnTransform(old *memo.ExprIter) (newExprs []*memo.GroupExpr, eraseOld bool, eraseAll bool, err error) {
 	sel := old.GetExpr().ExprNode.(*plannercore.LogicalSelection)
-	unionAll := old.Children[0].GetExpr().ExprNode.(*plannercore.LogicalUnionAll)
+	unionAll := old.Children[0].GetExpr().ExprNode
 	childGroups := old.Children[0].GetExpr().Children
 
 	newUnionAllExpr := memo.NewGroupExpr(unionAll)
@@ -1363,7 +1426,7 @@ func (r *PushTopNDownUnionAll) Match(expr *memo.ExprIter) bool {
 // It will transform `TopN->UnionAll->X` to `TopN->UnionAll->TopN->X`.
 func (r *PushTopNDownUnionAll) OnTransform(old *memo.ExprIter) (newExprs []*memo.GroupExpr, eraseOld bool, eraseAll bool, err error) {
 	topN := old.GetExpr().ExprNode.(*plannercore.LogicalTopN)
-	unionAll := old.Children[0].GetExpr().ExprNode.(*plannercore.LogicalUnionAll)
+	unionAll := old.Children[0].GetExpr().ExprNode
 
 	newTopN := plannercore.LogicalTopN{
 		Count:   topN.Count + topN.Offset,
@@ -1406,6 +1469,10 @@ func NewRulePushTopNDownTiKVSingleGather() Transformation {
 // Match implements Transformation interface.
 // Use appliedRuleSet in GroupExpr to avoid re-apply rules.
 func (r *PushTopNDownTiKVSingleGather) Match(expr *memo.ExprIter) bool {
+	if expr.Children[0].GetExpr().Children[0].EngineType != pattern.EngineTiKV {
+		// TODO: push it down to TiFlash when the MPP plans support it.
+		return false
+	}
 	return !expr.GetExpr().HasAppliedRule(r)
 }
 
@@ -1843,6 +1910,10 @@ func NewRulePushLimitDownTiKVSingleGather() Transformation {
 // Match implements Transformation interface.
 // Use appliedRuleSet in GroupExpr to avoid re-apply rules.
 func (r *PushLimitDownTiKVSingleGather) Match(expr *memo.ExprIter) bool {
+	if expr.Children[0].GetExpr().Children[0].EngineType != pattern.EngineTiKV {
+		// TODO: push it down to TiFlash when the MPP plans support it.
+		return false
+	}
 	return !expr.GetExpr().HasAppliedRule(r)
 }
 
@@ -2597,3 +2668,237 @@ func (*MergeAdjacentWindow) OnTransform(old *memo.ExprIter) (newExprs []*memo.Gr
 	newWindowGroupExpr.SetChildren(old.Children[0].GetExpr().Children...)
 	return []*memo.GroupExpr{newWindowGroupExpr}, true, false, nil
 }
+
+// maxJoinReorderInputs is the max number of the join inputs whose join orders are explored by
+// EnumerateJoinOrders, since the number of the join orders grows exponentially.
+const maxJoinReorderInputs = 10
+
+// EnumerateJoinOrders explores the join orders of a tree of inner joins. All the bushy join trees
+// without cartesian products are added into the memo, and the sub-trees which join the same inputs
+// share the same Group.
+type EnumerateJoinOrders struct {
+	baseRule
+}
+
+// NewRuleEnumerateJoinOrders creates a new Transformation EnumerateJoinOrders.
+// The pattern of this rule is: `Join`.
+func NewRuleEnumerateJoinOrders() Transformation {
+	rule := &EnumerateJoinOrders{}
+	rule.pattern = pattern.NewPattern(pattern.OperandJoin, pattern.EngineTiDBOnly)
+	return rule
+}
+
+// Match implements Transformation interface.
+func (r *EnumerateJoinOrders) Match(expr *memo.ExprIter) bool {
+	return !expr.GetExpr().HasAppliedRule(r) && isReorderableJoin(expr.GetExpr().ExprNode.(*plannercore.LogicalJoin))
+}
+
+// isReorderableJoin checks whether the join is an inner join whose join order is not specified by the hints.
+func isReorderableJoin(join *plannercore.LogicalJoin) bool {
+	return join.JoinType == plannercore.InnerJoin && !join.StraightJoin && join.HintInfo == nil &&
+		join.PreferJoinType == 0 && !join.PreferJoinOrder && len(join.NAEQConditions) == 0 &&
+		!join.SCtx().GetSessionVars().StmtCtx.StraightJoinOrder
+}
+
+// OnTransform implements Transformation interface.
+// This rule enumerates the join orders with dynamic programming, the Group of each subset of the join
+// inputs contains all the ways to join the subset by two smaller subsets.
+func (r *EnumerateJoinOrders) OnTransform(old *memo.ExprIter) (newExprs []*memo.GroupExpr, eraseOld bool, eraseAll bool, err error) {
+	joinExpr := old.GetExpr()
+	joinExpr.AddAppliedRule(r)
+	join := joinExpr.ExprNode.(*plannercore.LogicalJoin)
+	e := &joinOrderEnumerator{
+		sctx:     join.SCtx(),
+		qbOffset: join.QueryBlockOffset(),
+		groups:   make(map[uint64]*memo.Group),
+		rule:     r,
+	}
+	if !e.init(joinExpr) {
+		return nil, false, false, nil
+	}
+	return e.enumerate(), false, false, nil
+}
+
+// joinOrderEnumerator flattens a tree of inner joins to the join inputs and the join conditions, and
+// enumerates the join orders of them.
+type joinOrderEnumerator struct {
+	sctx     context.PlanContext
+	qbOffset int
+	rule     *EnumerateJoinOrders
+
+	inputs []*memo.Group
+	conds  []expression.Expression
+	// condMasks is the bitmap of the inputs referenced by each join condition.
+	condMasks []uint64
+	// groups maps the bitmap of a subset of the inputs to the Group which joins them.
+	groups map[uint64]*memo.Group
+	// originalLeft is the bitmap of the inputs joined by the left child of the original join, the
+	// original join is not built again.
+	originalLeft uint64
+}
+
+func (e *joinOrderEnumerator) init(joinExpr *memo.GroupExpr) bool {
+	if _, ok := e.collect(joinExpr); !ok || len(e.inputs) <= 2 {
+		return false
+	}
+	for mask, g := range e.groups {
+		if g == joinExpr.Children[0] {
+			e.originalLeft = mask
+		}
+	}
+	inputOfCol := make(map[int64]int)
+	var cols []*expression.Column
+	for i, input := range e.inputs {
+		for _, col := range input.Prop.Schema.Columns {
+			inputOfCol[col.UniqueID] = i
+		}
+		cols = append(cols, input.Prop.Schema.Columns...)
+	}
+	// The inputs are collected from left to right, so the output columns of the join tree should be
+	// the same as the columns of the inputs.
+	groupCols := joinExpr.Group.Prop.Schema.Columns
+	if len(groupCols) != len(cols) {
+		return false
+	}
+	for i, col := range cols {
+		if !col.EqualColumn(groupCols[i]) {
+			return false
+		}
+	}
+	e.condMasks = make([]uint64, len(e.conds))
+	for i, cond := range e.conds {
+		for _, col := range expression.ExtractColumns(cond) {
+			idx, ok := inputOfCol[col.UniqueID]
+			if !ok {
+				return false
+			}
+			e.condMasks[i] |= 1 << idx
+		}
+		// The conditions on a single input should have been pushed down.
+		if bits.OnesCount64(e.condMasks[i]) < 2 {
+			return false
+		}
+	}
+	return true
+}
+
+// collect collects the inputs and the join conditions of the join tree, it returns the bitmap of the
+// inputs joined by the GroupExpr.
+func (e *joinOrderEnumerator) collect(joinExpr *memo.GroupExpr) (uint64, bool) {
+	var mask uint64
+	for _, child := range joinExpr.Children {
+		var childMask uint64
+		if elem := child.GetFirstElem(pattern.OperandJoin); elem != nil && isReorderableJoin(elem.Value.(*memo.GroupExpr).ExprNode.(*plannercore.LogicalJoin)) {
+			var ok bool
+			if childMask, ok = e.collect(elem.Value.(*memo.GroupExpr)); !ok {
+				return 0, false
+			}
+			// The join orders of the child join tree have been explored since the children are explored
+			// before the parent, so the Group can be reused.
+			e.groups[childMask] = child
+		} else {
+			if len(e.inputs) >= maxJoinReorderInputs {
+				return 0, false
+			}
+			childMask = 1 << len(e.inputs)
+			e.inputs = append(e.inputs, child)
+			e.groups[childMask] = child
+		}
+		mask |= childMask
+	}
+	join := joinExpr.ExprNode.(*plannercore.LogicalJoin)
+	e.conds = append(e.conds, expression.ScalarFuncs2Exprs(join.EqualConditions)...)
+	e.conds = append(e.conds, join.LeftConditions...)
+	e.conds = append(e.conds, join.RightConditions...)
+	e.conds = append(e.conds, join.OtherConditions...)
+	return mask, true
+}
+
+// enumerate builds the Groups for all the subsets of the inputs from the smaller ones to the larger ones,
+// and returns the GroupExprs which join all the inputs.
+func (e *joinOrderEnumerator) enumerate() []*memo.GroupExpr {
+	all := uint64(1)<<len(e.inputs) - 1
+	// The subsets of a bitmap are always smaller than it, so they are built before it.
+	for s := uint64(3); s <= all; s++ {
+		if bits.OnesCount64(s) < 2 {
+			continue
+		}
+		if _, ok := e.groups[s]; ok && s != all {
+			continue
+		}
+		var exprs []*memo.GroupExpr
+		lowest := s & -s
+		for s1 := (s - 1) & s; s1 > 0; s1 = (s1 - 1) & s {
+			// The build side of the hash join can be either side, so only one of (s1, s2) and
+			// (s2, s1) is enumerated.
+			if s1&lowest == 0 {
+				continue
+			}
+			s2 := s ^ s1
+			if s == all && (s1 == e.originalLeft || s2 == e.originalLeft) {
+				continue
+			}
+			g1, g2 := e.groups[s1], e.groups[s2]
+			if g1 == nil || g2 == nil {
+				continue
+			}
+			if expr := e.buildJoin(s, s1, s2, g1, g2); expr != nil {
+				exprs = append(exprs, expr)
+			}
+		}
+		if s == all {
+			return exprs
+		}
+		if len(exprs) == 0 {
+			continue
+		}
+		g := memo.NewGroupWithSchema(exprs[0], e.schemaOf(s))
+		for _, expr := range exprs[1:] {
+			g.Insert(expr)
+		}
+		e.groups[s] = g
+	}
+	return nil
+}
+
+// buildJoin builds the GroupExpr which joins the subsets s1 and s2. The output columns are reordered by
+// a Projection if they are not in the same order as the inputs.
+func (e *joinOrderEnumerator) buildJoin(s, s1, s2 uint64, g1, g2 *memo.Group) *memo.GroupExpr {
+	var conds []expression.Expression
+	for i, mask := range e.condMasks {
+		if mask&s == mask && mask&s1 != 0 && mask&s2 != 0 {
+			conds = append(conds, e.conds[i])
+		}
+	}
+	// Skip the cartesian products.
+	if len(conds) == 0 {
+		return nil
+	}
+	join := plannercore.LogicalJoin{JoinType: plannercore.InnerJoin}.Init(e.sctx, e.qbOffset)
+	joinSchema := expression.MergeSchema(g1.Prop.Schema, g2.Prop.Schema)
+	join.SetSchema(joinSchema)
+	join.AppendJoinConds(join.ExtractOnCondition(conds, g1.Prop.Schema, g2.Prop.Schema, false, false))
+	joinExpr := memo.NewGroupExpr(join)
+	joinExpr.SetChildren(g1, g2)
+	joinExpr.AddAppliedRule(e.rule)
+	if s1 < s2&-s2 {
+		return joinExpr
+	}
+	schema := e.schemaOf(s)
+	proj := plannercore.LogicalProjection{Exprs: expression.Column2Exprs(schema.Columns)}.Init(e.sctx, e.qbOffset)
+	proj.SetSchema(schema)
+	projExpr := memo.NewGroupExpr(proj)
+	projExpr.SetChildren(memo.NewGroupWithSchema(joinExpr, joinSchema))
+	return projExpr
+}
+
+// schemaOf returns the schema of the Group which joins the subset of the inputs.
+func (e *joinOrderEnumerator) schemaOf(s uint64) *expression.Schema {
+	var cols []*expression.Column
+	for i, input := range e.inputs {
+		if s&(1<<i) != 0 {
+			cols = append(cols, input.Prop.Schema.Columns...)
+		}
+	}
+	return expression.NewSchema(cols...)
+}
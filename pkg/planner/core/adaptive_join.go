@@ -0,0 +1,110 @@
+// Copyright 2024 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package core
+
+import (
+	"github.com/pingcap/tidb/pkg/planner/core/base"
+	"github.com/pingcap/tidb/pkg/planner/core/operator/logicalop"
+	"github.com/pingcap/tidb/pkg/planner/property"
+	"github.com/pingcap/tidb/pkg/planner/util/optimizetrace"
+)
+
+// adaptiveJoinCandidates collects the hash joins enumerated for a LogicalJoin, the best one of them is
+// kept as the alternative of the chosen index join, so the executor can switch to it if the outer side
+// of the index join turns out to have much more rows than estimated.
+type adaptiveJoinCandidates struct {
+	// hashJoins are the best hash joins indexed by their InnerChildIdx.
+	hashJoins [2]base.Task
+}
+
+// newAdaptiveJoinCandidates returns nil if the adaptive join can't be used for the plan.
+func newAdaptiveJoinCandidates(p *logicalop.BaseLogicalPlan, prop *property.PhysicalProperty, planCounter *base.PlanCounterTp) *adaptiveJoinCandidates {
+	if _, ok := p.Self().(*LogicalJoin); !ok {
+		return nil
+	}
+	sessVars := p.SCtx().GetSessionVars()
+	// The hash join doesn't keep the order of the outer side. The alternative plan is not cached, so
+	// it's not prepared when the plan cache is used.
+	if !sessVars.EnableAdaptiveJoin || sessVars.StmtCtx.UseCache() || planCounter.IsForce() ||
+		prop.TaskTp != property.RootTaskType || !prop.IsSortItemEmpty() {
+		return nil
+	}
+	return &adaptiveJoinCandidates{}
+}
+
+// add records the task if it's a hash join which builds the hash table on a reader of the inner side.
+func (c *adaptiveJoinCandidates) add(task base.Task, opt *optimizetrace.PhysicalOptimizeOp) error {
+	if c == nil {
+		return nil
+	}
+	hj, ok := task.Plan().(*PhysicalHashJoin)
+	if !ok || hj.UseOuterToBuild || len(hj.NAEqualConditions) > 0 || !isReader(hj.Children()[hj.InnerChildIdx]) {
+		return nil
+	}
+	if best := c.hashJoins[hj.InnerChildIdx]; best != nil {
+		curIsBetter, err := compareTaskCost(task, best, opt)
+		if err != nil || !curIsBetter {
+			return err
+		}
+	}
+	c.hashJoins[hj.InnerChildIdx] = task
+	return nil
+}
+
+// attach sets the hash join alternative of the chosen index join or index hash join, whose inner side
+// is the same as the build side of the hash join.
+func (c *adaptiveJoinCandidates) attach(bestTask base.Task) {
+	if c == nil || bestTask.Invalid() {
+		return
+	}
+	var ij *PhysicalIndexJoin
+	switch x := bestTask.Plan().(type) {
+	case *PhysicalIndexJoin:
+		ij = x
+	case *PhysicalIndexHashJoin:
+		ij = &x.PhysicalIndexJoin
+	default:
+		return
+	}
+	if c.hashJoins[ij.InnerChildIdx] == nil {
+		return
+	}
+	ij.HashJoinAlternative = c.hashJoins[ij.InnerChildIdx].Plan().(*PhysicalHashJoin)
+}
+
+// postOptimizeHashJoinAlternatives applies the post-optimization of the plan tree to the build sides of
+// the hash join alternatives. The build side is a reader, which isn't a child of the index join, so
+// the passes which walk the children of the plans never reach it. The root runtime filters are not
+// generated for the hash join alternative.
+func postOptimizeHashJoinAlternatives(sctx base.PlanContext, plan base.PhysicalPlan) {
+	for _, child := range plan.Children() {
+		postOptimizeHashJoinAlternatives(sctx, child)
+	}
+	var ij *PhysicalIndexJoin
+	switch x := plan.(type) {
+	case *PhysicalIndexJoin:
+		ij = x
+	case *PhysicalIndexHashJoin:
+		ij = &x.PhysicalIndexJoin
+	}
+	if ij == nil || ij.HashJoinAlternative == nil {
+		return
+	}
+	hj := ij.HashJoinAlternative
+	buildSide := hj.children[hj.InnerChildIdx]
+	propagateProbeParents(buildSide, ij.probeParents)
+	disableReuseChunkIfNeeded(sctx, buildSide)
+	tryEnableLateMaterialization(sctx, buildSide)
+}
@@ -0,0 +1,209 @@
+// Copyright 2024 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package core
+
+import (
+	"strconv"
+	"time"
+
+	"github.com/pingcap/tidb/pkg/expression"
+	"github.com/pingcap/tidb/pkg/planner/cardinality"
+	"github.com/pingcap/tidb/pkg/planner/core/base"
+	"github.com/pingcap/tidb/pkg/util/execdetails"
+)
+
+// cardFeedbackInfo is attached to the scan which outputs the rows of a DataSource. It's used to
+// compare the estimated row count of the DataSource with the actual one after execution.
+type cardFeedbackInfo struct {
+	stmtDigest string
+	// key is the key of the feedback without the plan digest, which is only known after the plan is
+	// chosen.
+	key          cardinality.FeedbackKey
+	estRows      float64
+	tableRows    float64
+	statsVersion uint64
+	// applied indicates whether the estimation is corrected by the feedback.
+	applied bool
+}
+
+// applyCardinalityFeedback corrects the row count of the DataSource with the feedback collected from
+// the executed plans, and prepares the info to collect the feedback of this DataSource.
+func (ds *DataSource) applyCardinalityFeedback() {
+	ds.cardFeedback = nil
+	sessVars := ds.SCtx().GetSessionVars()
+	if !sessVars.EnableCardinalityFeedback || sessVars.StmtCtx.CardFeedbackDigest == "" ||
+		len(ds.PushedDownConds) == 0 || expression.ContainCorrelatedColumn(ds.PushedDownConds) {
+		return
+	}
+	info := &cardFeedbackInfo{
+		stmtDigest: sessVars.StmtCtx.CardFeedbackDigest,
+		key: cardinality.FeedbackKey{
+			Operator:        ds.TP() + "_" + strconv.Itoa(ds.ID()),
+			PhysicalTableID: ds.PhysicalTableID,
+			Conds:           string(expression.SortedExplainExpressionList(ds.SCtx().GetExprCtx().GetEvalCtx(), ds.PushedDownConds)),
+		},
+		tableRows:    ds.TableStats.RowCount,
+		statsVersion: ds.TableStats.StatsVersion,
+	}
+	// Only the feedback of the last plan of this statement which recorded feedback is used, the
+	// feedback of the other plans doesn't correct this one.
+	if planDigest := cardinality.GlobalFeedbackStore.PlanDigest(info.stmtDigest); planDigest != "" {
+		key := info.key
+		key.PlanDigest = planDigest
+		if f := cardinality.GlobalFeedbackStore.Get(key, info.statsVersion); f != nil {
+			ds.SetStats(ds.TableStats.Scale(f.Selectivity()))
+			info.applied = true
+		}
+	}
+	info.estRows = ds.StatsInfo().RowCount
+	ds.cardFeedback = info
+}
+
+// CollectCardinalityFeedback records the actual row counts of the readers in the executed plan, whose
+// estimation errors exceed the threshold. They are used to correct the estimation of later optimizations.
+func CollectCardinalityFeedback(p base.Plan, planDigest string, runtimeStatsColl *execdetails.RuntimeStatsColl, threshold float64) {
+	pp, ok := p.(base.PhysicalPlan)
+	if !ok || runtimeStatsColl == nil {
+		return
+	}
+	c := &cardFeedbackCollector{
+		planDigest:       planDigest,
+		runtimeStatsColl: runtimeStatsColl,
+		threshold:        threshold,
+	}
+	c.collect(pp, false)
+}
+
+type cardFeedbackCollector struct {
+	planDigest       string
+	runtimeStatsColl *execdetails.RuntimeStatsColl
+	threshold        float64
+}
+
+// collect walks the plan to find the readers. mayStopEarly indicates whether the parents may stop
+// fetching rows before the reader is drained, the overestimation can't be confirmed in that case.
+func (c *cardFeedbackCollector) collect(p base.PhysicalPlan, mayStopEarly bool) {
+	switch x := p.(type) {
+	case *PhysicalTableReader, *PhysicalIndexReader, *PhysicalIndexLookUpReader:
+		c.record(x, x, mayStopEarly)
+		return
+	case *PhysicalSelection:
+		// The filters which can't be pushed down are kept in the Selection above the reader.
+		if child := x.Children()[0]; !x.hasRFConditions && isReader(child) {
+			c.record(child, x, mayStopEarly)
+			return
+		}
+	case *PhysicalLimit, *PhysicalMergeJoin:
+		mayStopEarly = true
+	case *PhysicalIndexJoin:
+		c.collect(x.Children()[1-x.InnerChildIdx], mayStopEarly)
+		return
+	case *PhysicalIndexHashJoin:
+		c.collect(x.Children()[1-x.InnerChildIdx], mayStopEarly)
+		return
+	case *PhysicalIndexMergeJoin:
+		c.collect(x.Children()[1-x.InnerChildIdx], mayStopEarly)
+		return
+	case *PhysicalApply:
+		c.collect(x.Children()[1-x.InnerChildIdx], mayStopEarly)
+		return
+	}
+	for _, child := range p.Children() {
+		c.collect(child, mayStopEarly)
+	}
+}
+
+func isReader(p base.PhysicalPlan) bool {
+	switch p.(type) {
+	case *PhysicalTableReader, *PhysicalIndexReader, *PhysicalIndexLookUpReader:
+		return true
+	}
+	return false
+}
+
+// record compares the estimated row count of the DataSource read by the reader with the actual row
+// count output by the top operator.
+func (c *cardFeedbackCollector) record(reader, top base.PhysicalPlan, mayStopEarly bool) {
+	var info *cardFeedbackInfo
+	switch x := reader.(type) {
+	case *PhysicalTableReader:
+		info = cardFeedbackOfCopPlans(x.TablePlans)
+	case *PhysicalIndexReader:
+		info = cardFeedbackOfCopPlans(x.IndexPlans)
+	case *PhysicalIndexLookUpReader:
+		if x.PushedLimit == nil && isPlainCopPlans(x.IndexPlans) {
+			info = cardFeedbackOfCopPlans(x.TablePlans)
+		}
+	}
+	if info == nil || !c.runtimeStatsColl.ExistsRootStats(top.ID()) {
+		return
+	}
+	stats := c.runtimeStatsColl.GetBasicRuntimeStats(top.ID())
+	if stats.GetLoop() == 0 {
+		// The reader is not executed.
+		return
+	}
+	actRows := float64(stats.GetActRows())
+	if actRows < info.estRows && mayStopEarly {
+		return
+	}
+	// The corrected estimation is recorded again for this plan, so it's still corrected if this plan
+	// becomes the last plan of the statement which recorded feedback.
+	if !info.applied && cardinality.QError(info.estRows, actRows) <= c.threshold {
+		return
+	}
+	key := info.key
+	key.PlanDigest = c.planDigest
+	cardinality.GlobalFeedbackStore.Put(info.stmtDigest, key, &cardinality.Feedback{
+		EstRows:      info.estRows,
+		ActRows:      actRows,
+		TableRows:    info.tableRows,
+		StatsVersion: info.statsVersion,
+		UpdateTime:   time.Now(),
+	})
+}
+
+// isPlainCopPlans checks whether the cop plans only contain the scan and the selections, so the
+// output of them is decided by the filters only.
+func isPlainCopPlans(plans []base.PhysicalPlan) bool {
+	for _, p := range plans {
+		switch x := p.(type) {
+		case *PhysicalTableScan, *PhysicalIndexScan:
+		case *PhysicalSelection:
+			if x.hasRFConditions {
+				return false
+			}
+		default:
+			return false
+		}
+	}
+	return true
+}
+
+// cardFeedbackOfCopPlans returns the feedback info attached to the scan of the cop plans.
+func cardFeedbackOfCopPlans(plans []base.PhysicalPlan) *cardFeedbackInfo {
+	if len(plans) == 0 || !isPlainCopPlans(plans) {
+		return nil
+	}
+	switch x := plans[0].(type) {
+	case *PhysicalTableScan:
+		if len(x.runtimeFilterList) == 0 {
+			return x.cardFeedback
+		}
+	case *PhysicalIndexScan:
+		return x.cardFeedback
+	}
+	return nil
+}
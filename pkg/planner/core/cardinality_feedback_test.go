@@ -0,0 +1,110 @@
+// Copyright 2024 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package core_test
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/pingcap/tidb/pkg/planner/cardinality"
+	"github.com/pingcap/tidb/pkg/testkit"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCardinalityFeedback(t *testing.T) {
+	store, dom := testkit.CreateMockStoreAndDomain(t)
+	tk := testkit.NewTestKit(t, store)
+	cardinality.GlobalFeedbackStore.Reset()
+	defer cardinality.GlobalFeedbackStore.Reset()
+
+	tk.MustExec("use test")
+	tk.MustExec("create table t(a int, b int)")
+	tk.MustExec("insert into t with recursive c(n) as (select 1 union all select n + 1 from c where n < 1000) select n, n from c")
+	tk.MustExec("analyze table t all columns")
+	require.NoError(t, dom.StatsHandle().Update(context.Background(), dom.InfoSchema()))
+	query := "select * from t where a + b = 2"
+	checkEstRows := func(estRows string, corrected bool) {
+		rows := tk.MustQuery("explain format = 'brief' " + query).Rows()
+		require.Equal(t, "TableReader", rows[0][0])
+		require.Equal(t, estRows, rows[0][1])
+		require.Equal(t, corrected, strings.Contains(rows[len(rows)-1][4].(string), "cardinality feedback"), fmt.Sprintf("%v", rows))
+	}
+
+	// The feedback is not collected if it's disabled.
+	tk.MustQuery(query).Check(testkit.Rows("1 1"))
+	require.Equal(t, 0, cardinality.GlobalFeedbackStore.Len())
+
+	// The selectivity of `a + b = 2` is estimated by the default selection factor, it's corrected
+	// by the feedback.
+	tk.MustExec("set @@tidb_opt_enable_cardinality_feedback = on")
+	checkEstRows("800.00", false)
+	tk.MustQuery(query).Check(testkit.Rows("1 1"))
+	require.Equal(t, 1, cardinality.GlobalFeedbackStore.Len())
+	checkEstRows("1.00", true)
+	tk.MustQuery(query).Check(testkit.Rows("1 1"))
+	checkEstRows("1.00", true)
+
+	// The errors below the threshold are not recorded.
+	require.Len(t, tk.MustQuery("select * from t where a + b > 2").Rows(), 999)
+	require.Equal(t, 1, cardinality.GlobalFeedbackStore.Len())
+	tk.MustExec("set @@tidb_opt_cardinality_feedback_threshold = 1.1")
+	require.Len(t, tk.MustQuery("select * from t where a + b > 2").Rows(), 999)
+	require.Equal(t, 2, cardinality.GlobalFeedbackStore.Len())
+
+	// The feedback expires after the stats are refreshed.
+	tk.MustExec("analyze table t all columns")
+	require.NoError(t, dom.StatsHandle().Update(context.Background(), dom.InfoSchema()))
+	checkEstRows("800.00", false)
+}
+
+func TestCardinalityFeedbackOfDifferentPlans(t *testing.T) {
+	store, dom := testkit.CreateMockStoreAndDomain(t)
+	tk := testkit.NewTestKit(t, store)
+	cardinality.GlobalFeedbackStore.Reset()
+	defer cardinality.GlobalFeedbackStore.Reset()
+
+	tk.MustExec("use test")
+	tk.MustExec("create table t(a int, b int)")
+	tk.MustExec("insert into t with recursive c(n) as (select 1 union all select n + 1 from c where n < 1000) select n, n from c")
+	tk.MustExec("analyze table t all columns")
+	require.NoError(t, dom.StatsHandle().Update(context.Background(), dom.InfoSchema()))
+	tk.MustExec("set @@tidb_opt_enable_cardinality_feedback = on")
+	// Both plans read t with the same filters.
+	query1 := "select * from t where a + b = 2"
+	query2 := "select * from t where a + b = 2 order by a"
+	checkEstRows := func(query, estRows string, corrected bool) {
+		rows := tk.MustQuery("explain format = 'brief' " + query).Rows()
+		for _, row := range rows {
+			if row[0] == "└─TableReader" || row[0] == "TableReader" {
+				require.Equal(t, estRows, row[1], fmt.Sprintf("%v", rows))
+			}
+		}
+		require.Equal(t, corrected, strings.Contains(rows[len(rows)-1][4].(string), "cardinality feedback"), fmt.Sprintf("%v", rows))
+	}
+
+	// The feedback of one plan doesn't correct the other plan.
+	tk.MustQuery(query1).Check(testkit.Rows("1 1"))
+	require.Equal(t, 1, cardinality.GlobalFeedbackStore.Len())
+	checkEstRows(query1, "1.00", true)
+	checkEstRows(query2, "800.00", false)
+
+	// The other plan records its own feedback.
+	tk.MustQuery(query2).Check(testkit.Rows("1 1"))
+	require.Equal(t, 2, cardinality.GlobalFeedbackStore.Len())
+	checkEstRows(query2, "1.00", true)
+	checkEstRows(query1, "1.00", true)
+}
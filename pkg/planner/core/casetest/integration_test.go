@@ -16,15 +16,18 @@ package casetest
 
 import (
 	"context"
+	"fmt"
 	"strings"
 	"testing"
 
 	"github.com/pingcap/failpoint"
 	"github.com/pingcap/tidb/pkg/domain"
 	"github.com/pingcap/tidb/pkg/parser/model"
+	"github.com/pingcap/tidb/pkg/planner"
 	"github.com/pingcap/tidb/pkg/planner/util/coretestsdk"
 	"github.com/pingcap/tidb/pkg/testkit"
 	"github.com/pingcap/tidb/pkg/testkit/testdata"
+	"github.com/pingcap/tidb/pkg/testkit/testfailpoint"
 	"github.com/stretchr/testify/require"
 )
 
@@ -485,3 +488,71 @@ func TestTiFlashExtraColumnPrune(t *testing.T) {
 		tk.MustQuery(tt).Check(testkit.Rows(output[i].Plan...))
 	}
 }
+
+func TestCascadesPlannerCompare(t *testing.T) {
+	store := testkit.CreateMockStore(t)
+	tk := testkit.NewTestKit(t, store)
+	tk.MustExec("use test")
+	tk.MustExec("create table t1(a int, b int)")
+	tk.MustExec("create table t2(a int, b int, key(a))")
+	tk.MustExec("insert into t1 values (1, 1), (2, 2)")
+	tk.MustExec("insert into t2 values (1, 1), (2, 2)")
+	var diff *planner.CascadesPlanDiff
+	testfailpoint.EnableCall(t, "github.com/pingcap/tidb/pkg/planner/cascadesPlanDiff", func(d *planner.CascadesPlanDiff) {
+		diff = d
+	})
+
+	// The chosen plan and the result are not affected, the difference is reported as a warning.
+	tk.MustExec("set @@tidb_enable_cascades_planner_compare = on")
+	tk.MustQuery("select * from t1").Sort().Check(testkit.Rows("1 1", "2 2"))
+	tk.MustQuery("show warnings").Check(testkit.Rows())
+	require.Nil(t, diff)
+
+	// The cascades planner has no implementation rule for the index join.
+	query := "select /*+ inl_join(t2) */ t1.a, t2.b from t1, t2 where t1.a = t2.a"
+	tk.MustQuery(query).Sort().Check(testkit.Rows("1 1", "2 2"))
+	require.NotNil(t, diff)
+	tk.MustQuery("show warnings").Check(testkit.Rows(
+		"Warning 1105 the cascades planner chose a different plan:\n" + strings.Join(diff.Lines(), "\n")))
+	tk.MustExec("set @@tidb_enable_cascades_planner_compare = off")
+	require.Equal(t, testdata.ConvertRowsToStrings(tk.MustQuery("explain format = 'brief' "+query).Rows()), diff.Default)
+	tk.MustExec("set @@tidb_enable_cascades_planner = on")
+	require.Equal(t, testdata.ConvertRowsToStrings(tk.MustQuery("explain format = 'brief' "+query).Rows()), diff.Cascades)
+	require.NotEqual(t, diff.Default, diff.Cascades)
+
+	diff = &planner.CascadesPlanDiff{Default: []string{"a", "b", "c"}, Cascades: []string{"a", "d", "c", "e"}}
+	require.Equal(t, []string{" a", "-b", "+d", " c", "+e"}, diff.Lines())
+}
+
+func TestCascadesPlannerIndexMergeAndMPP(t *testing.T) {
+	store, dom := testkit.CreateMockStoreAndDomain(t)
+	tk := testkit.NewTestKit(t, store)
+	tk.MustExec("use test")
+	tk.MustExec("create table t(a int, b int, key(a), key(b))")
+	tk.MustExec("insert into t values (1, 1), (2, 2)")
+	tk.MustExec("set @@tidb_enable_cascades_planner = on")
+	planOf := func(query string) string {
+		return fmt.Sprintf("%v", tk.MustQuery("explain format = 'brief' "+query).Rows())
+	}
+
+	query := "select /*+ use_index_merge(t) */ * from t where a = 1 or b = 2"
+	tk.MustQuery(query).Sort().Check(testkit.Rows("1 1", "2 2"))
+	tk.MustQuery("show warnings").Check(testkit.Rows())
+	require.Contains(t, planOf(query), "IndexMerge")
+
+	tbl, err := dom.InfoSchema().TableByName(context.Background(), model.NewCIStr("test"), model.NewCIStr("t"))
+	require.NoError(t, err)
+	// Set the hacked TiFlash replica for explain tests.
+	tbl.Meta().TiFlashReplica = &model.TiFlashReplicaInfo{Count: 1, Available: true}
+	tk.MustExec("set @@tidb_isolation_read_engines = 'tiflash'")
+	tk.MustExec("set @@tidb_enforce_mpp = on")
+	query = "select count(*) from t where a > 1 group by b"
+	require.Contains(t, planOf(query), "mpp[tiflash]")
+
+	tk.MustExec("set @@tidb_enable_cascades_planner = off")
+	tk.MustExec("set @@tidb_enable_cascades_planner_compare = on")
+	require.Contains(t, planOf(query), "mpp[tiflash]")
+	for _, row := range tk.MustQuery("show warnings").Rows() {
+		require.False(t, strings.HasPrefix(row[2].(string), "the cascades planner failed"), row[2])
+	}
+}
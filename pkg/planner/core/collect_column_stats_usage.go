@@ -15,6 +15,8 @@
 package core
 
 import (
+	"time"
+
 	"github.com/pingcap/failpoint"
 	"github.com/pingcap/tidb/pkg/domain"
 	"github.com/pingcap/tidb/pkg/expression"
@@ -22,6 +24,7 @@ import (
 	"github.com/pingcap/tidb/pkg/parser/model"
 	"github.com/pingcap/tidb/pkg/planner/core/base"
 	"github.com/pingcap/tidb/pkg/sessionctx/variable"
+	"github.com/pingcap/tidb/pkg/statistics"
 	"github.com/pingcap/tidb/pkg/statistics/asyncload"
 	"github.com/pingcap/tidb/pkg/util/filter"
 	"github.com/pingcap/tidb/pkg/util/intset"
@@ -140,6 +143,27 @@ func (c *columnStatsUsageCollector) collectPredicateColumnsForDataSource(ds *Dat
 	}
 	// We should use `PushedDownConds` here. `AllConds` is used for partition pruning, which doesn't need stats.
 	c.addPredicateColumnsFromExpressions(ds.PushedDownConds)
+	if variable.EnableExpressionStats.Load() {
+		c.collectExpressionsForDataSource(ds)
+	}
+}
+
+// collectExpressionsForDataSource records the expressions compared with constants in the filters, so that the stats
+// of them are collected by the next ANALYZE. See statistics.ExpressionStats for details.
+func (*columnStatsUsageCollector) collectExpressionsForDataSource(ds *DataSource) {
+	now := time.Now()
+	for _, cond := range ds.PushedDownConds {
+		expr, _, ok := statistics.ExpressionComparedWithConstants(cond)
+		if !ok {
+			continue
+		}
+		sql, hash, ok := statistics.ExpressionStatsSQL(expr, ds.TableInfo)
+		if !ok {
+			continue
+		}
+		// Like the predicate columns, the expressions are recorded with the table ID rather than the partition ID.
+		statistics.ExpressionStatsUsage.Insert(statistics.ExpressionItemID{TableID: ds.TableInfo.ID, Hash: hash}, sql, now)
+	}
 }
 
 func (c *columnStatsUsageCollector) collectPredicateColumnsForJoin(p *LogicalJoin) {
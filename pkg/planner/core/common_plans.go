@@ -80,7 +80,7 @@ type ShowNextRowID struct {
 	TableName *ast.TableName
 }
 
-// CheckTable is used for checking table data, built from the 'admin check table' statement.
+// CheckTable is used for checking table data, built from the 'admin check table' and 'alter table check partition' statements.
 type CheckTable struct {
 	baseSchemaProducer
 
@@ -89,6 +89,8 @@ type CheckTable struct {
 	IndexInfos         []*model.IndexInfo
 	IndexLookUpReaders []*PhysicalIndexLookUpReader
 	CheckIndex         bool
+	// PartitionNames is not empty if only some partitions are checked by "ALTER TABLE ... CHECK PARTITION".
+	PartitionNames []model.CIStr
 }
 
 // RecoverIndex is used for backfilling corrupted index data.
@@ -236,6 +238,18 @@ type SetConfig struct {
 	Value    expression.Expression
 }
 
+// CallProcedure represents a plan for the CALL statement.
+type CallProcedure struct {
+	baseSchemaProducer
+
+	DBName   model.CIStr
+	ProcName model.CIStr
+	// Params are the rewritten arguments of the procedure.
+	Params []expression.Expression
+	// Args are the original arguments, which are used to write back the OUT and INOUT parameters.
+	Args []ast.ExprNode
+}
+
 // SQLBindOpType repreents the SQL bind type
 type SQLBindOpType int
 
@@ -507,6 +521,9 @@ type AnalyzeInfo struct {
 	TableID       statistics.AnalyzeTableID
 	StatsVersion  int
 	V2Options     *V2AnalyzeOptions
+	// Incremental indicates that only the rows written since the last analyze are sampled, and
+	// the stats of them are merged into the existing stats.
+	Incremental bool
 }
 
 // V2AnalyzeOptions is used to hold analyze options information.
@@ -640,6 +657,14 @@ type IndexAdvise struct {
 	LineFieldsInfo
 }
 
+// RecommendIndexPlan represents a plan for recommend index stmt.
+type RecommendIndexPlan struct {
+	baseSchemaProducer
+
+	Action string
+	SQL    string
+}
+
 // SplitRegion represents a split regions plan.
 type SplitRegion struct {
 	baseSchemaProducer
@@ -670,6 +695,16 @@ type CompactTable struct {
 	PartitionNames []model.CIStr
 }
 
+// OptimizePartition represents a "ALTER TABLE [NAME] OPTIMIZE PARTITION ..." plan.
+// It compacts the TiFlash replicas of the partitions, and then analyzes them.
+type OptimizePartition struct {
+	baseSchemaProducer
+
+	// Compact is nil if the table has no TiFlash replicas.
+	Compact *CompactTable
+	Analyze *Analyze
+}
+
 // DDL represents a DDL statement plan.
 type DDL struct {
 	baseSchemaProducer
@@ -2866,11 +2866,14 @@ func (la *LogicalApply) ExhaustPhysicalPlans(prop *property.PhysicalProperty) ([
 		columns = append(columns, &colColumn.Column)
 	}
 	cacheHitRatio := 0.0
-	if la.StatsInfo().RowCount != 0 {
-		ndv, _ := cardinality.EstimateColsNDVWithMatchedLen(columns, la.Schema(), la.StatsInfo())
+	// The inner side is executed once for every outer row, so the cache hit ratio depends on the NDV of the
+	// correlated columns in the outer rows.
+	outerChild := la.Children()[0]
+	if outerChild.StatsInfo().RowCount != 0 {
+		ndv, _ := cardinality.EstimateColsNDVWithMatchedLen(columns, outerChild.Schema(), outerChild.StatsInfo())
 		// for example, if there are 100 rows and the number of distinct values of these correlated columns
 		// are 70, then we can assume 30 rows can hit the cache so the cache hit ratio is 1 - (70/100) = 0.3
-		cacheHitRatio = 1 - (ndv / la.StatsInfo().RowCount)
+		cacheHitRatio = 1 - (ndv / outerChild.StatsInfo().RowCount)
 	}
 
 	var canUseCache bool
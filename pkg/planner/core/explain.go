@@ -126,6 +126,9 @@ func (p *PhysicalIndexScan) OperatorInfo(normalized bool) string {
 			// This branch is not needed in fact, we add this to prevent test result changes under planner/cascades/
 			buffer.WriteString(", stats:pseudo")
 		}
+		if p.cardFeedback != nil && p.cardFeedback.applied {
+			buffer.WriteString(", cardinality feedback")
+		}
 	}
 	return buffer.String()
 }
@@ -246,6 +249,9 @@ func (p *PhysicalTableScan) OperatorInfo(normalized bool) string {
 			// This branch is not needed in fact, we add this to prevent test result changes under planner/cascades/
 			buffer.WriteString(", stats:pseudo")
 		}
+		if p.cardFeedback != nil && p.cardFeedback.applied {
+			buffer.WriteString(", cardinality feedback")
+		}
 	}
 	if p.StoreType == kv.TiFlash && p.Table.GetPartitionInfo() != nil && p.IsMPPOrBatchCop && p.SCtx().GetSessionVars().StmtCtx.UseDynamicPartitionPrune() {
 		buffer.WriteString(", PartitionTableScan:true")
@@ -363,6 +369,12 @@ func (p *PhysicalSelection) ExplainInfo() string {
 	if p.TiFlashFineGrainedShuffleStreamCount > 0 {
 		exprStr += fmt.Sprintf(", stream_count: %d", p.TiFlashFineGrainedShuffleStreamCount)
 	}
+	if p.hasRFConditions {
+		if len(exprStr) > 0 {
+			exprStr += ", "
+		}
+		exprStr += "runtime filter"
+	}
 	return exprStr
 }
 
@@ -424,6 +436,11 @@ func (p *PhysicalTableDual) ExplainInfo() string {
 	return str.String()
 }
 
+// ExplainInfo implements Plan interface.
+func (p *PhysicalJSONTable) ExplainInfo() string {
+	return fmt.Sprintf("expr:%s, path:%s", p.Expr.StringWithCtx(p.SCtx().GetExprCtx().GetEvalCtx()), p.Path.Path.String())
+}
+
 // ExplainInfo implements Plan interface.
 func (p *PhysicalSort) ExplainInfo() string {
 	buffer := bytes.NewBufferString("")
@@ -568,6 +585,14 @@ func (p *PhysicalIndexJoin) explainInfo(normalized bool, isIndexMergeJoin bool)
 		buffer.WriteString(", other cond:")
 		buffer.Write(sortedExplainExpressionList(evalCtx, p.OtherConditions))
 	}
+	if hj := p.HashJoinAlternative; hj != nil {
+		buffer.WriteString(", hash join alternative build:")
+		if normalized {
+			buffer.WriteString(hj.Children()[hj.InnerChildIdx].TP())
+		} else {
+			buffer.WriteString(hj.Children()[hj.InnerChildIdx].ExplainID().String())
+		}
+	}
 	return buffer.String()
 }
 
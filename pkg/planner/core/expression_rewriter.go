@@ -1547,6 +1547,10 @@ func (er *expressionRewriter) Leave(originInNode ast.Node) (retNode ast.Node, ok
 		withPlanCtx(func(planCtx *exprRewriterPlanCtx) {
 			er.positionToScalarFunc(planCtx, v)
 		})
+	case *ast.MatchAgainst:
+		withPlanCtx(func(planCtx *exprRewriterPlanCtx) {
+			er.matchAgainstToScalarFunc(planCtx, v)
+		})
 	case *ast.IsNullExpr:
 		er.isNullToExpression(v)
 	case *ast.IsTruthExpr:
@@ -1855,6 +1859,87 @@ func (er *expressionRewriter) positionToScalarFunc(planCtx *exprRewriterPlanCtx,
 	}
 }
 
+// matchAgainstToScalarFunc rewrites `MATCH (col1, col2, ...) AGAINST (expr modifier)` to
+// `fts_match_against(expr, modifier, parser, col1, col2, ...)`. The columns must be the same
+// as the columns of a FULLTEXT index, and the search string must be a constant.
+func (er *expressionRewriter) matchAgainstToScalarFunc(planCtx *exprRewriterPlanCtx, v *ast.MatchAgainst) {
+	intest.AssertNotNil(planCtx)
+	if v.Modifier.WithQueryExpansion() {
+		er.err = plannererrors.ErrNotSupportedYet.GenWithStackByArgs("MATCH ... AGAINST ... WITH QUERY EXPANSION")
+		return
+	}
+	stkLen := len(er.ctxStack)
+	colLen := len(v.ColumnNames)
+	against := er.ctxStack[stkLen-1]
+	if _, ok := against.(*expression.Constant); !ok {
+		er.err = plannererrors.ErrWrongArguments.GenWithStackByArgs("AGAINST")
+		return
+	}
+	cols := er.ctxStack[stkLen-1-colLen : stkLen-1]
+	idxInfo := er.findFulltextIndex(planCtx, cols, er.ctxNameStk[stkLen-1-colLen:stkLen-1])
+	if idxInfo == nil {
+		er.err = plannererrors.ErrFtMatchingKeyNotFound
+		return
+	}
+	args := make([]expression.Expression, 0, expression.FTSColumnsIdx+colLen)
+	args = append(args, against, &expression.Constant{
+		Value:   types.NewIntDatum(int64(v.Modifier)),
+		RetType: types.NewFieldType(mysql.TypeLonglong),
+	}, &expression.Constant{
+		Value:   types.NewStringDatum(idxInfo.ParserName.L),
+		RetType: types.NewFieldType(mysql.TypeVarchar),
+	})
+	args = append(args, cols...)
+	function, err := er.newFunction(ast.FTSMatchAgainst, types.NewFieldType(mysql.TypeDouble), args...)
+	if err != nil {
+		er.err = err
+		return
+	}
+	er.ctxStackPop(colLen + 1)
+	er.ctxStackAppend(function, types.EmptyName)
+}
+
+// findFulltextIndex finds the public FULLTEXT index whose columns are the same as the columns.
+// It returns nil if there is no such index.
+func (er *expressionRewriter) findFulltextIndex(planCtx *exprRewriterPlanCtx, cols []expression.Expression, names []*types.FieldName) *model.IndexInfo {
+	colNames := make(map[string]struct{}, len(cols))
+	for i, col := range cols {
+		if _, ok := col.(*expression.Column); !ok {
+			return nil
+		}
+		name := names[i]
+		if name.OrigTblName.L == "" || name.DBName.L != names[0].DBName.L ||
+			name.OrigTblName.L != names[0].OrigTblName.L || name.TblName.L != names[0].TblName.L {
+			return nil
+		}
+		colNames[name.OrigColName.L] = struct{}{}
+	}
+	dbName := names[0].DBName
+	if dbName.O == "" {
+		dbName = model.NewCIStr(planCtx.builder.ctx.GetSessionVars().CurrentDB)
+	}
+	tbl, err := planCtx.builder.is.TableByName(context.Background(), dbName, names[0].OrigTblName)
+	if err != nil {
+		return nil
+	}
+	for _, idx := range tbl.Meta().Indices {
+		if !idx.IsFulltextIndex() || idx.State != model.StatePublic || len(idx.Columns) != len(colNames) {
+			continue
+		}
+		matched := true
+		for _, idxCol := range idx.Columns {
+			if _, ok := colNames[idxCol.Name.L]; !ok {
+				matched = false
+				break
+			}
+		}
+		if matched {
+			return idx
+		}
+	}
+	return nil
+}
+
 func (er *expressionRewriter) isTrueToScalarFunc(v *ast.IsTruthExpr) {
 	stkLen := len(er.ctxStack)
 	op := ast.IsTruthWithoutNull
@@ -2414,6 +2499,28 @@ func (er *expressionRewriter) clause() clauseCode {
 }
 
 func (er *expressionRewriter) toColumn(v *ast.ColumnName) {
+	// The local variables and parameters of a stored procedure, and the NEW and OLD rows
+	// of a trigger take precedence over the columns.
+	if v.Schema.L == "" && er.planCtx != nil {
+		sessVars := er.planCtx.builder.ctx.GetSessionVars()
+		if procCtx := sessVars.ProcedureContext; procCtx != nil && !sessVars.InRestrictedSQL {
+			var (
+				val types.Datum
+				tp  *types.FieldType
+				ok  bool
+			)
+			if v.Table.L == "" {
+				val, tp, ok = procCtx.GetProcedureVariable(v.Name.L)
+			} else {
+				val, tp, ok = procCtx.GetTriggerColumn(v.Table.L, v.Name.L)
+			}
+			if ok {
+				er.sctx.SetSkipPlanCache("query has stored procedure variables")
+				er.ctxStackAppend(&expression.Constant{Value: val, RetType: tp}, types.EmptyName)
+				return
+			}
+		}
+	}
 	idx, err := expression.FindFieldName(er.names, v)
 	if err != nil {
 		er.err = plannererrors.ErrAmbiguous.GenWithStackByArgs(v.Name, clauseMsg[fieldList])
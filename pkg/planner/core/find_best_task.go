@@ -133,6 +133,19 @@ func findBestTask4LogicalShowDDLJobs(p *LogicalShowDDLJobs, prop *property.Physi
 	return rt, 1, nil
 }
 
+func findBestTask4LogicalJSONTable(p *LogicalJSONTable, prop *property.PhysicalProperty, planCounter *base.PlanCounterTp, opt *optimizetrace.PhysicalOptimizeOp) (base.Task, int64, error) {
+	if !prop.IsSortItemEmpty() || planCounter.Empty() {
+		return base.InvalidTask, 0, nil
+	}
+	jt := PhysicalJSONTable{Expr: p.Expr, Path: p.Path, Name: p.Name}.Init(p.SCtx(), p.StatsInfo(), p.QueryBlockOffset())
+	jt.SetSchema(p.Schema())
+	planCounter.Dec(1)
+	utilfuncp.AppendCandidate4PhysicalOptimizeOp(opt, p, jt, prop)
+	rt := &RootTask{}
+	rt.SetPlan(jt)
+	return rt, 1, nil
+}
+
 // rebuildChildTasks rebuilds the childTasks to make the clock_th combination.
 func rebuildChildTasks(p *logicalop.BaseLogicalPlan, childTasks *[]base.Task, pp base.PhysicalPlan, childCnts []int64, planCounter int64, ts uint64, opt *optimizetrace.PhysicalOptimizeOp) error {
 	// The taskMap of children nodes should be rolled back first.
@@ -183,6 +196,7 @@ func enumeratePhysicalPlans4Task(
 	if _, ok := p.Self().(*LogicalSequence); ok {
 		iteration = iterateChildPlan4LogicalSequence
 	}
+	adaptiveJoin := newAdaptiveJoinCandidates(p, prop, planCounter)
 
 	for _, pp := range physicalPlans {
 		timeStampNow := p.GetLogicalTS4TaskMap()
@@ -238,6 +252,9 @@ func enumeratePhysicalPlans4Task(
 			break
 		}
 		utilfuncp.AppendCandidate4PhysicalOptimizeOp(opt, p, curTask.Plan(), prop)
+		if err := adaptiveJoin.add(curTask, opt); err != nil {
+			return nil, 0, err
+		}
 		// Get the most efficient one.
 		if curIsBetter, err := compareTaskCost(curTask, bestTask, opt); err != nil {
 			return nil, 0, err
@@ -245,6 +262,7 @@ func enumeratePhysicalPlans4Task(
 			bestTask = curTask
 		}
 	}
+	adaptiveJoin.attach(bestTask)
 	return bestTask, cntPlan, nil
 }
 
@@ -758,6 +776,10 @@ func (ds *DataSource) isMatchProp(path *util.AccessPath, prop *property.Physical
 		}
 		return isMatchProp
 	}
+	if path.Index != nil && (path.Index.IsFulltextIndex() || path.Index.IsSpatialIndex()) {
+		// The keys of the fulltext and spatial indexes are the tokens and cells instead of the column values.
+		return false
+	}
 	all, _ := prop.AllSameOrder()
 	// When the prop is empty or `all` is false, `isMatchProp` is better to be `false` because
 	// it needs not to keep order for index scan.
@@ -2003,6 +2025,7 @@ func (ds *DataSource) convertToIndexScan(prop *property.PhysicalProperty,
 			physicalTableID: ds.PhysicalTableID,
 			tblCols:         ds.TblCols,
 			tblColHists:     ds.TblColHists,
+			cardFeedback:    ds.cardFeedback,
 		}.Init(ds.SCtx(), is.QueryBlockOffset())
 		ts.SetSchema(ds.Schema().Clone())
 		// We set `StatsVersion` here and fill other fields in `(*copTask).finishIndexPlan`. Since `copTask.indexPlan` may
@@ -2802,6 +2825,7 @@ func (ds *DataSource) getOriginalPhysicalTableScan(prop *property.PhysicalProper
 		constColsByCond: path.ConstCols,
 		prop:            prop,
 		filterCondition: slices.Clone(path.TableFilters),
+		cardFeedback:    ds.cardFeedback,
 	}.Init(ds.SCtx(), ds.QueryBlockOffset())
 	ts.SetSchema(ds.Schema().Clone())
 	rowCount := path.CountAfterAccess
@@ -2847,6 +2871,9 @@ func (ds *DataSource) getOriginalPhysicalIndexScan(prop *property.PhysicalProper
 		constColsByCond:  path.ConstCols,
 		prop:             prop,
 	}.Init(ds.SCtx(), ds.QueryBlockOffset())
+	if isSingleScan {
+		is.cardFeedback = ds.cardFeedback
+	}
 	rowCount := path.CountAfterAccess
 	is.initSchema(append(path.FullIdxCols, ds.CommonHandleCols...), !isSingleScan)
 
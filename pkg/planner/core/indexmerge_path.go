@@ -15,6 +15,7 @@
 package core
 
 import (
+	"bytes"
 	"cmp"
 	"math"
 	"slices"
@@ -31,13 +32,17 @@ import (
 	"github.com/pingcap/tidb/pkg/planner/context"
 	"github.com/pingcap/tidb/pkg/planner/core/base"
 	"github.com/pingcap/tidb/pkg/planner/core/cost"
+	"github.com/pingcap/tidb/pkg/planner/core/operator/logicalop"
 	"github.com/pingcap/tidb/pkg/planner/util"
 	"github.com/pingcap/tidb/pkg/planner/util/debugtrace"
 	"github.com/pingcap/tidb/pkg/planner/util/fixcontrol"
 	"github.com/pingcap/tidb/pkg/statistics"
 	"github.com/pingcap/tidb/pkg/types"
 	"github.com/pingcap/tidb/pkg/util/chunk"
+	"github.com/pingcap/tidb/pkg/util/collate"
+	"github.com/pingcap/tidb/pkg/util/fulltext"
 	"github.com/pingcap/tidb/pkg/util/logutil"
+	"github.com/pingcap/tidb/pkg/util/plancodec"
 	"github.com/pingcap/tidb/pkg/util/ranger"
 	"go.uber.org/zap"
 	"golang.org/x/exp/maps"
@@ -87,6 +92,18 @@ func (ds *DataSource) generateIndexMergePath() error {
 	if err := ds.generateIndexMerge4MVIndex(regularPathCount, indexMergeConds); err != nil {
 		return err
 	}
+	fulltextPathCount := len(ds.PossibleAccessPaths)
+	ds.generateIndexMerge4FulltextIndex(indexMergeConds)
+	if len(ds.PossibleAccessPaths) > fulltextPathCount {
+		// The fulltext index is not a regular access path, so the warning for the normal index doesn't apply.
+		warningMsg = ""
+	}
+	spatialPathCount := len(ds.PossibleAccessPaths)
+	ds.generateIndexMerge4SpatialIndex(indexMergeConds)
+	if len(ds.PossibleAccessPaths) > spatialPathCount {
+		// The spatial index is not a regular access path either.
+		warningMsg = ""
+	}
 	oldIndexMergeCount := len(ds.PossibleAccessPaths)
 	if err := ds.generateIndexMerge4ComposedIndex(regularPathCount, indexMergeConds); err != nil {
 		return err
@@ -132,6 +149,31 @@ func (ds *DataSource) generateIndexMergePath() error {
 	return nil
 }
 
+// BuildIndexMergePaths builds the IndexMerge AccessPaths of the DataSource under the filters of the
+// Selection above it. It's used by the cascades planner, which keeps the filters in the Selection
+// instead of pushing them down to the DataSource. The paths are built on a copy of the DataSource to
+// leave the DataSource unchanged, the copy is returned as the source of the paths.
+func (ds *DataSource) BuildIndexMergePaths(filters []expression.Expression) (*DataSource, []*util.AccessPath, error) {
+	newDS := *ds
+	newDS.BaseLogicalPlan = logicalop.NewBaseLogicalPlan(ds.SCtx(), plancodec.TypeDataSource, &newDS, ds.QueryBlockOffset())
+	newDS.AllConds = filters
+	newDS.PushedDownConds, _ = expression.PushDownExprs(GetPushDownCtx(ds.SCtx()), filters, kv.UnSpecified)
+	newDS.PossibleAccessPaths = make([]*util.AccessPath, 0, len(ds.PossibleAccessPaths))
+	for _, path := range ds.PossibleAccessPaths {
+		newDS.PossibleAccessPaths = append(newDS.PossibleAccessPaths, path.Clone())
+	}
+	if _, err := newDS.DeriveStats(nil, newDS.Schema(), nil, nil); err != nil {
+		return nil, nil, err
+	}
+	var paths []*util.AccessPath
+	for _, path := range newDS.PossibleAccessPaths {
+		if path.PartialIndexPaths != nil {
+			paths = append(paths, path)
+		}
+	}
+	return &newDS, paths, nil
+}
+
 func (ds *DataSource) generateNormalIndexPartialPaths4DNF(
 	dnfItems []expression.Expression,
 	candidatePaths []*util.AccessPath,
@@ -1072,6 +1114,256 @@ func (*DataSource) buildPartialPathUp4MVIndex(
 	return indexMergePath
 }
 
+// generateIndexMerge4FulltextIndex generates paths for MATCH ... AGAINST on the fulltext index.
+// The fulltext index stores one key for every distinct token of a row, so the rows containing a token
+// are read by a point range on the index, and the same row may be read by several ranges like the MVIndex.
+// The MATCH ... AGAINST filter is always kept as a table filter since the index only finds the candidate rows.
+/*
+	1. select * from t where match(a) against('+tidb +database' in boolean mode)
+		IndexMerge(AND)
+			IndexRangeScan(ft, ["tidb","tidb"])
+			IndexRangeScan(ft, ["database","database"])
+			TableRowIdScan(t)
+	2. select * from t where match(a) against('tidb databas*' in boolean mode)
+		IndexMerge(OR)
+			IndexRangeScan(ft, ["tidb","tidb"])
+			IndexRangeScan(ft, ["databas","databat"))
+			TableRowIdScan(t)
+*/
+func (ds *DataSource) generateIndexMerge4FulltextIndex(filters []expression.Expression) {
+	for _, idx := range ds.TableInfo.Indices {
+		if !idx.IsFulltextIndex() || idx.State != model.StatePublic ||
+			(idx.Invisible && !ds.SCtx().GetSessionVars().OptimizerUseInvisibleIndexes) {
+			continue
+		}
+		if !ds.isInIndexMergeHints(idx.Name.L) {
+			continue
+		}
+		idxCols, ok := PrepareIdxColsAndUnwrapArrayType(ds.TableInfo, idx, ds.TblCols, false)
+		if !ok {
+			continue
+		}
+		for _, filter := range filters {
+			terms, isIntersection, ok := ds.collectFulltextIndexTerms(filter, idxCols)
+			if !ok {
+				continue
+			}
+			partialPaths := make([]*util.AccessPath, 0, len(terms))
+			for _, term := range terms {
+				partialPaths = append(partialPaths, ds.buildPartialPath4FulltextIndex(idx, idxCols, term))
+			}
+			ds.PossibleAccessPaths = append(ds.PossibleAccessPaths, ds.buildPartialPathUp4MVIndex(
+				partialPaths,
+				isIntersection,
+				filters,
+				ds.TableStats.HistColl,
+			))
+			break
+		}
+	}
+}
+
+// collectFulltextIndexTerms returns the terms to look up in the fulltext index if the filter is a MATCH ... AGAINST
+// on the index columns with a constant search string. OK indicates whether the filter can use the index.
+func (ds *DataSource) collectFulltextIndexTerms(filter expression.Expression, idxCols []*expression.Column) (
+	terms []fulltext.IndexTerm, isIntersection bool, ok bool) {
+	sf, ok := filter.(*expression.ScalarFunction)
+	if !ok || sf.FuncName.L != ast.FTSMatchAgainst {
+		return nil, false, false
+	}
+	args := sf.GetArgs()
+	if len(args)-expression.FTSColumnsIdx != len(idxCols) {
+		return nil, false, false
+	}
+	for _, idxCol := range idxCols {
+		found := false
+		for _, arg := range args[expression.FTSColumnsIdx:] {
+			if col, ok := arg.(*expression.Column); ok && col.EqualColumn(idxCol) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return nil, false, false
+		}
+	}
+	exprCtx := ds.SCtx().GetExprCtx()
+	var consts [expression.FTSColumnsIdx]types.Datum
+	for i := range consts {
+		con, ok := args[i].(*expression.Constant)
+		if !ok {
+			return nil, false, false
+		}
+		d, err := con.Eval(exprCtx.GetEvalCtx(), chunk.Row{})
+		if err != nil || d.IsNull() {
+			return nil, false, false
+		}
+		consts[i] = d
+	}
+	if expression.MaybeOverOptimized4PlanCache(exprCtx, args[:expression.FTSColumnsIdx]) {
+		// skip plan cache and try to generate the best plan in this case.
+		exprCtx.SetSkipPlanCache("MATCH ... AGAINST with parameters can affect index selection")
+	}
+	modifier := ast.FulltextSearchModifier(consts[expression.FTSModifierIdx].GetInt64())
+	tk := fulltext.NewTokenizer(consts[expression.FTSParserIdx].GetString())
+	query := fulltext.ParseQuery(consts[expression.FTSQueryIdx].GetString(), modifier.IsBooleanMode(), tk)
+	terms, isIntersection = query.IndexTerms()
+	return terms, isIntersection, len(terms) > 0
+}
+
+// buildPartialPath4FulltextIndex builds a partial path to read the rows containing the term from the fulltext index.
+// The fulltext index has no statistics, so the pseudo count is used.
+func (ds *DataSource) buildPartialPath4FulltextIndex(idx *model.IndexInfo, idxCols []*expression.Column, term fulltext.IndexTerm) *util.AccessPath {
+	partialPath := &util.AccessPath{Index: idx}
+	for i := range idxCols {
+		partialPath.IdxCols = append(partialPath.IdxCols, idxCols[i])
+		partialPath.IdxColLens = append(partialPath.IdxColLens, idx.Columns[i].Length)
+		partialPath.FullIdxCols = append(partialPath.FullIdxCols, idxCols[i])
+		partialPath.FullIdxColLens = append(partialPath.FullIdxColLens, idx.Columns[i].Length)
+	}
+	token := []byte(term.Token)
+	ran := &ranger.Range{
+		LowVal:    []types.Datum{types.NewBytesDatum(token)},
+		HighVal:   []types.Datum{types.NewBytesDatum(token)},
+		Collators: collate.GetBinaryCollatorSlice(1),
+	}
+	if term.Prefix {
+		ran.HighVal = []types.Datum{types.NewBytesDatum(kv.Key(token).PrefixNext())}
+		ran.HighExclude = true
+	}
+	partialPath.Ranges = []*ranger.Range{ran}
+	partialPath.CountAfterAccess = cardinality.PseudoAvgCountPerValue(ds.StatisticTable)
+	partialPath.CountAfterIndex = partialPath.CountAfterAccess
+	return partialPath
+}
+
+// generateIndexMerge4SpatialIndex generates paths for the spatial predicates on the spatial index.
+// The spatial index stores the cells covering the geometry of a row, and a row may intersect the
+// constant geometry only if one of its cells is a descendant or an ancestor of a cell covering the
+// constant. So the descendants are read by a prefix range and the ancestors by point ranges. The
+// spatial predicate is always kept as a table filter since the cells only find the candidate rows.
+// Like MySQL, the index is only used when the column has the same SRID attribute as the constant.
+/*
+	select * from t where st_intersects(g, st_geomfromtext('POINT(1 1)'))
+		IndexMerge(OR)
+			IndexRangeScan(sp, ["\x00","\x00"], ["\x00\x03","\x00\x03"], ..., ["\x00\x03...","\x00\x03..."))
+			TableRowIdScan(t)
+*/
+func (ds *DataSource) generateIndexMerge4SpatialIndex(filters []expression.Expression) {
+	for _, idx := range ds.TableInfo.Indices {
+		if !idx.IsSpatialIndex() || idx.State != model.StatePublic ||
+			(idx.Invisible && !ds.SCtx().GetSessionVars().OptimizerUseInvisibleIndexes) {
+			continue
+		}
+		if !ds.isInIndexMergeHints(idx.Name.L) {
+			continue
+		}
+		idxCols, ok := PrepareIdxColsAndUnwrapArrayType(ds.TableInfo, idx, ds.TblCols, false)
+		if !ok || len(idxCols) != 1 {
+			continue
+		}
+		colSRID, ok := ds.TableInfo.Columns[idx.Columns[0].Offset].GetSRID()
+		if !ok {
+			continue
+		}
+		for _, filter := range filters {
+			cells, ok := ds.collectSpatialIndexCells(filter, idxCols[0], colSRID)
+			if !ok {
+				continue
+			}
+			partialPath := ds.buildPartialPath4SpatialIndex(idx, idxCols, cells)
+			ds.PossibleAccessPaths = append(ds.PossibleAccessPaths, ds.buildPartialPathUp4MVIndex(
+				[]*util.AccessPath{partialPath},
+				false,
+				filters,
+				ds.TableStats.HistColl,
+			))
+			break
+		}
+	}
+}
+
+// collectSpatialIndexCells returns the cells covering the constant geometry if the filter is a spatial
+// predicate between the index column and a constant, which is true only if they intersect.
+// OK indicates whether the filter can use the index.
+func (ds *DataSource) collectSpatialIndexCells(filter expression.Expression, idxCol *expression.Column, colSRID uint32) (
+	cells [][]byte, ok bool) {
+	sf, ok := filter.(*expression.ScalarFunction)
+	if !ok {
+		return nil, false
+	}
+	switch sf.FuncName.L {
+	case ast.STIntersects, ast.STContains, ast.STWithin:
+	default:
+		return nil, false
+	}
+	args := sf.GetArgs()
+	var con *expression.Constant
+	for i, arg := range args {
+		if col, ok := arg.(*expression.Column); ok && col.EqualColumn(idxCol) {
+			con, ok = args[1-i].(*expression.Constant)
+			if !ok {
+				return nil, false
+			}
+			break
+		}
+	}
+	if con == nil {
+		return nil, false
+	}
+	exprCtx := ds.SCtx().GetExprCtx()
+	d, err := con.Eval(exprCtx.GetEvalCtx(), chunk.Row{})
+	if err != nil || d.IsNull() {
+		return nil, false
+	}
+	g, err := types.DecodeGeometry(d.GetBytes())
+	if err != nil || g.SRID != colSRID {
+		return nil, false
+	}
+	if expression.MaybeOverOptimized4PlanCache(exprCtx, []expression.Expression{con}) {
+		// skip plan cache and try to generate the best plan in this case.
+		exprCtx.SetSkipPlanCache("spatial predicates with parameters can affect index selection")
+	}
+	cells = types.GeometryIndexCells(&g)
+	return cells, len(cells) > 0
+}
+
+// buildPartialPath4SpatialIndex builds a partial path to read the rows whose cells are the descendants or
+// the ancestors of the cells. The spatial index has no statistics, so the pseudo count is used.
+func (ds *DataSource) buildPartialPath4SpatialIndex(idx *model.IndexInfo, idxCols []*expression.Column, cells [][]byte) *util.AccessPath {
+	partialPath := &util.AccessPath{Index: idx}
+	partialPath.IdxCols = append(partialPath.IdxCols, idxCols[0])
+	partialPath.IdxColLens = append(partialPath.IdxColLens, idx.Columns[0].Length)
+	partialPath.FullIdxCols = append(partialPath.FullIdxCols, idxCols[0])
+	partialPath.FullIdxColLens = append(partialPath.FullIdxColLens, idx.Columns[0].Length)
+	ancestors := make(map[string]struct{})
+	for _, cell := range cells {
+		partialPath.Ranges = append(partialPath.Ranges, &ranger.Range{
+			LowVal:      []types.Datum{types.NewBytesDatum(cell)},
+			HighVal:     []types.Datum{types.NewBytesDatum(kv.Key(cell).PrefixNext())},
+			HighExclude: true,
+			Collators:   collate.GetBinaryCollatorSlice(1),
+		})
+		for i := 1; i < len(cell); i++ {
+			ancestors[string(cell[:i])] = struct{}{}
+		}
+	}
+	// The cells are at the same level, so the ancestors never overlap the prefix ranges.
+	for ancestor := range ancestors {
+		partialPath.Ranges = append(partialPath.Ranges, &ranger.Range{
+			LowVal:    []types.Datum{types.NewBytesDatum([]byte(ancestor))},
+			HighVal:   []types.Datum{types.NewBytesDatum([]byte(ancestor))},
+			Collators: collate.GetBinaryCollatorSlice(1),
+		})
+	}
+	slices.SortFunc(partialPath.Ranges, func(a, b *ranger.Range) int {
+		return bytes.Compare(a.LowVal[0].GetBytes(), b.LowVal[0].GetBytes())
+	})
+	partialPath.CountAfterAccess = cardinality.PseudoAvgCountPerValue(ds.StatisticTable) * float64(len(cells))
+	partialPath.CountAfterIndex = partialPath.CountAfterAccess
+	return partialPath
+}
+
 // buildPartialPaths4MVIndex builds partial paths by using these accessFilters upon this MVIndex.
 // The accessFilters must be corresponding to these idxCols.
 // OK indicates whether it builds successfully. These partial paths should be ignored if ok==false.
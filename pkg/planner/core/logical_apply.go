@@ -30,6 +30,8 @@ type LogicalApply struct {
 	CorCols []*expression.CorrelatedColumn
 	// NoDecorrelate is from /*+ no_decorrelate() */ hint.
 	NoDecorrelate bool
+	// Lateral indicates the apply is built from a LATERAL derived table rather than a subquery.
+	Lateral bool
 }
 
 // ExtractCorrelatedCols implements LogicalPlan interface.
@@ -19,6 +19,7 @@ import (
 
 	"github.com/pingcap/tidb/pkg/expression"
 	"github.com/pingcap/tidb/pkg/infoschema"
+	"github.com/pingcap/tidb/pkg/kv"
 	"github.com/pingcap/tidb/pkg/parser/ast"
 	"github.com/pingcap/tidb/pkg/parser/model"
 	"github.com/pingcap/tidb/pkg/parser/mysql"
@@ -107,6 +108,10 @@ type DataSource struct {
 	// It's calculated after we generated the access paths and estimated row count for them, and before entering findBestTask.
 	// It considers CountAfterIndex for index paths and CountAfterAccess for table paths and index merge paths.
 	AccessPathMinSelectivity float64
+
+	// cardFeedback is used to collect the cardinality feedback of the filters, it's nil if the
+	// cardinality feedback is disabled.
+	cardFeedback *cardFeedbackInfo
 }
 
 // ExtractCorrelatedCols implements LogicalPlan interface.
@@ -163,11 +168,29 @@ func (ds *DataSource) buildIndexGather(path *util.AccessPath) base.LogicalPlan {
 	return sg
 }
 
-// Convert2Gathers builds logical TiKVSingleGathers from DataSource.
-func (ds *DataSource) Convert2Gathers() (gathers []base.LogicalPlan) {
-	tg := ds.buildTableGather()
-	gathers = append(gathers, tg)
+// Convert2Gathers builds logical TiKVSingleGathers from DataSource. The TiKVSingleGathers which read
+// from TiFlash by MPP are returned as mppGathers, they're only built when MPP is allowed.
+func (ds *DataSource) Convert2Gathers() (gathers, mppGathers []base.LogicalPlan) {
+	hasTiKVPath, hasTiFlashPath := false, false
+	for _, path := range ds.PossibleAccessPaths {
+		if path.StoreType == kv.TiFlash {
+			hasTiFlashPath = true
+		} else {
+			hasTiKVPath = true
+		}
+	}
+	if hasTiFlashPath && ds.SCtx().GetSessionVars().IsMPPAllowed() {
+		mppGathers = append(mppGathers, ds.buildTableGather())
+	}
+	// The table is read from TiKV if it can't be read by MPP.
+	if hasTiKVPath || len(mppGathers) == 0 {
+		gathers = append(gathers, ds.buildTableGather())
+	}
 	for _, path := range ds.PossibleAccessPaths {
+		// The multi-valued indexes can only be read by the index merge, see BuildIndexMergePaths.
+		if path.StoreType == kv.TiFlash || (path.Index != nil && path.Index.MVIndex) {
+			continue
+		}
 		if !path.IsIntHandlePath {
 			path.FullIdxCols, path.FullIdxColLens = expression.IndexInfo2Cols(ds.Columns, ds.Schema().Columns, path.Index)
 			path.IdxCols, path.IdxColLens = expression.IndexInfo2PrefixCols(ds.Columns, ds.Schema().Columns, path.Index)
@@ -178,7 +201,7 @@ func (ds *DataSource) Convert2Gathers() (gathers []base.LogicalPlan) {
 			// TODO: If index columns can not cover the schema, use IndexLookUpGather.
 		}
 	}
-	return gathers
+	return gathers, mppGathers
 }
 
 func detachCondAndBuildRangeForPath(
@@ -0,0 +1,167 @@
+// Copyright 2024 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package core
+
+import (
+	"fmt"
+
+	"github.com/pingcap/tidb/pkg/expression"
+	"github.com/pingcap/tidb/pkg/parser/ast"
+	"github.com/pingcap/tidb/pkg/planner/core/base"
+	"github.com/pingcap/tidb/pkg/planner/core/operator/logicalop"
+	"github.com/pingcap/tidb/pkg/planner/property"
+	"github.com/pingcap/tidb/pkg/planner/util/optimizetrace"
+	"github.com/pingcap/tidb/pkg/types"
+	"github.com/pingcap/tidb/pkg/util/plancodec"
+	"github.com/pingcap/tidb/pkg/util/size"
+)
+
+// JSONTableOnResponse is the behavior of the JSON_TABLE column when the value is missing or invalid.
+type JSONTableOnResponse struct {
+	Tp ast.JSONTableOnResponseType
+	// Default is the value of `DEFAULT json_string`.
+	Default types.BinaryJSON
+}
+
+// JSONTableColumn is a column of JSON_TABLE, except the NESTED PATH.
+type JSONTableColumn struct {
+	Tp   ast.JSONTableColumnType
+	Name string
+	// Offset is the offset of the column in the schema of JSON_TABLE.
+	Offset int
+	// Path is the path of the PATH and EXISTS PATH columns, which is relative to the row.
+	Path    types.JSONPathExpression
+	OnEmpty JSONTableOnResponse
+	OnError JSONTableOnResponse
+}
+
+// JSONTablePath is the row path of JSON_TABLE or a NESTED PATH. Every value matched by the path is
+// a row, and the nested paths are evaluated against it.
+type JSONTablePath struct {
+	Path    types.JSONPathExpression
+	Columns []*JSONTableColumn
+	Nested  []*JSONTablePath
+}
+
+// MemoryUsage return the memory usage of JSONTablePath
+func (p *JSONTablePath) MemoryUsage() (sum int64) {
+	if p == nil {
+		return
+	}
+
+	sum = size.SizeOfPointer + size.SizeOfSlice*2 + int64(cap(p.Columns)+cap(p.Nested))*size.SizeOfPointer
+	for _, col := range p.Columns {
+		sum += int64(len(col.Name)) + size.SizeOfInt*2 + int64(len(col.OnEmpty.Default.Value)+len(col.OnError.Default.Value))
+	}
+	for _, nested := range p.Nested {
+		sum += nested.MemoryUsage()
+	}
+	return
+}
+
+// LogicalJSONTable represents the JSON_TABLE table function. It's the inner side of an Apply
+// if the JSON document refers to the preceding tables in the FROM clause.
+type LogicalJSONTable struct {
+	logicalop.LogicalSchemaProducer
+
+	// Expr is the JSON document.
+	Expr expression.Expression
+	Path *JSONTablePath
+	// Name is the alias of JSON_TABLE, which is used in the error message.
+	Name string
+}
+
+// Init initializes LogicalJSONTable.
+func (p LogicalJSONTable) Init(ctx base.PlanContext, offset int) *LogicalJSONTable {
+	p.BaseLogicalPlan = logicalop.NewBaseLogicalPlan(ctx, plancodec.TypeJSONTable, &p, offset)
+	return &p
+}
+
+// *************************** start implementation of Plan interface ***************************
+
+// ExplainInfo implements Plan interface.
+func (p *LogicalJSONTable) ExplainInfo() string {
+	return fmt.Sprintf("expr:%s, path:%s", p.Expr.StringWithCtx(p.SCtx().GetExprCtx().GetEvalCtx()), p.Path.Path.String())
+}
+
+// *************************** end implementation of Plan interface ***************************
+
+// *************************** start implementation of logicalPlan interface ***************************
+
+// HashCode inherits BaseLogicalPlan.LogicalPlan.<0th> implementation.
+
+// PredicatePushDown inherits BaseLogicalPlan.LogicalPlan.<1st> implementation.
+
+// PruneColumns inherits BaseLogicalPlan.LogicalPlan.<2nd> implementation.
+
+// FindBestTask implements the base.LogicalPlan.<3rd> interface.
+func (p *LogicalJSONTable) FindBestTask(prop *property.PhysicalProperty, planCounter *base.PlanCounterTp, opt *optimizetrace.PhysicalOptimizeOp) (base.Task, int64, error) {
+	return findBestTask4LogicalJSONTable(p, prop, planCounter, opt)
+}
+
+// BuildKeyInfo inherits BaseLogicalPlan.LogicalPlan.<4th> implementation.
+
+// PushDownTopN inherits BaseLogicalPlan.LogicalPlan.<5th> implementation.
+
+// DeriveTopN inherits BaseLogicalPlan.LogicalPlan.<6th> implementation.
+
+// PredicateSimplification inherits BaseLogicalPlan.LogicalPlan.<7th> implementation.
+
+// ConstantPropagation inherits BaseLogicalPlan.LogicalPlan.<8th> implementation.
+
+// PullUpConstantPredicates inherits BaseLogicalPlan.LogicalPlan.<9th> implementation.
+
+// RecursiveDeriveStats inherits BaseLogicalPlan.LogicalPlan.<10th> implementation.
+
+// DeriveStats implement base.LogicalPlan.<11th> interface.
+func (p *LogicalJSONTable) DeriveStats(_ []*property.StatsInfo, selfSchema *expression.Schema, _ []*expression.Schema, _ [][]*expression.Column) (*property.StatsInfo, error) {
+	if p.StatsInfo() != nil {
+		return p.StatsInfo(), nil
+	}
+	// The number of the rows can't be known before the JSON document is evaluated.
+	p.SetStats(getFakeStats(selfSchema))
+	return p.StatsInfo(), nil
+}
+
+// ExtractColGroups inherits BaseLogicalPlan.LogicalPlan.<12th> implementation.
+
+// PreparePossibleProperties inherits BaseLogicalPlan.LogicalPlan.<13th> implementation.
+
+// ExhaustPhysicalPlans inherits BaseLogicalPlan.LogicalPlan.<14th> implementation.
+
+// ExtractCorrelatedCols implements base.LogicalPlan.<15th> interface.
+func (p *LogicalJSONTable) ExtractCorrelatedCols() []*expression.CorrelatedColumn {
+	return expression.ExtractCorColumns(p.Expr)
+}
+
+// MaxOneRow inherits BaseLogicalPlan.LogicalPlan.<16th> implementation.
+
+// Children inherits BaseLogicalPlan.LogicalPlan.<17th> implementation.
+
+// SetChildren inherits BaseLogicalPlan.LogicalPlan.<18th> implementation.
+
+// SetChild inherits BaseLogicalPlan.LogicalPlan.<19th> implementation.
+
+// RollBackTaskMap inherits BaseLogicalPlan.LogicalPlan.<20th> implementation.
+
+// CanPushToCop inherits BaseLogicalPlan.LogicalPlan.<21st> implementation.
+
+// ExtractFD inherits BaseLogicalPlan.LogicalPlan.<22nd> implementation.
+
+// GetBaseLogicalPlan inherits BaseLogicalPlan.LogicalPlan.<23rd> implementation.
+
+// ConvertOuterToInnerJoin inherits BaseLogicalPlan.LogicalPlan.<24th> implementation.
+
+// *************************** end implementation of logicalPlan interface ***************************
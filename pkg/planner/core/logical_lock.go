@@ -93,6 +93,20 @@ func (p *LogicalLock) PushDownTopN(topNLogicalPlan base.LogicalPlan, opt *optimi
 	if topNLogicalPlan != nil {
 		topN = topNLogicalPlan.(*LogicalTopN)
 	}
+	if topN != nil && IsSelectSkipLockedLockType(p.Lock.LockType) {
+		// The rows skipped by SKIP LOCKED are unknown until they are locked, so the limit is kept
+		// above the lock, otherwise fewer rows than the limit may be returned. The rows are sorted
+		// below the lock instead, so the lock stops locking rows once the limit is met.
+		child := p.Children()[0].PushDownTopN(nil, opt)
+		if !topN.isLimit() {
+			sort := LogicalSort{ByItems: topN.ByItems}.Init(p.SCtx(), topN.QueryBlockOffset())
+			sort.SetChildren(child)
+			child = sort
+			topN.ByItems = nil
+		}
+		p.SetChildren(child)
+		return topN.AttachChild(p.Self(), opt)
+	}
 	if topN != nil {
 		p.Children()[0] = p.Children()[0].PushDownTopN(topN, opt)
 	}
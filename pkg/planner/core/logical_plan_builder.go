@@ -449,6 +449,8 @@ func (b *PlanBuilder) buildResultSetNode(ctx context.Context, node ast.ResultSet
 		case *ast.TableName:
 			p, err = b.buildDataSource(ctx, v, &x.AsName)
 			isTableName = true
+		case *ast.JSONTable:
+			p, err = b.buildJSONTable(ctx, v, x.AsName)
 		default:
 			err = plannererrors.ErrUnsupportedType.GenWithStackByArgs(v)
 		}
@@ -946,7 +948,19 @@ func (b *PlanBuilder) buildJoin(ctx context.Context, joinNode *ast.Join) (base.L
 		return nil, err
 	}
 
-	rightPlan, err := b.buildResultSetNode(ctx, joinNode.Right, false)
+	// The LATERAL derived table and JSON_TABLE can refer to the tables on their left side, unless they're
+	// the outer table of a RIGHT JOIN.
+	lateral := false
+	if ts, ok := joinNode.Right.(*ast.TableSource); ok && joinNode.Tp != ast.RightJoin {
+		_, isJSONTable := ts.Source.(*ast.JSONTable)
+		lateral = ts.Lateral || isJSONTable
+	}
+	var rightPlan base.LogicalPlan
+	if lateral {
+		rightPlan, err = b.buildLateralDerivedTable(ctx, joinNode.Right, leftPlan)
+	} else {
+		rightPlan, err = b.buildResultSetNode(ctx, joinNode.Right, false)
+	}
 	if err != nil {
 		return nil, err
 	}
@@ -961,6 +975,16 @@ func (b *PlanBuilder) buildJoin(ctx context.Context, joinNode *ast.Join) (base.L
 	b.handleHelper.mergeAndPush(handleMap1, handleMap2)
 
 	joinPlan := LogicalJoin{StraightJoin: joinNode.StraightJoin || b.inStraightJoin}.Init(b.ctx, b.getSelectOffset())
+	var resultPlan base.LogicalPlan = joinPlan
+	if lateral {
+		// The LATERAL derived table is evaluated for every row of the left side, which is the same as
+		// the correlated subquery, so the join is built as an Apply and decorrelated if possible.
+		b.optFlag = b.optFlag | flagBuildKeyInfo | flagDecorrelate
+		ap := &LogicalApply{LogicalJoin: *joinPlan, Lateral: true}
+		ap.SetTP(plancodec.TypeApply)
+		ap.SetSelf(ap)
+		joinPlan, resultPlan = &ap.LogicalJoin, ap
+	}
 	joinPlan.SetChildren(leftPlan, rightPlan)
 	joinPlan.SetSchema(expression.MergeSchema(leftPlan.Schema(), rightPlan.Schema()))
 	joinPlan.SetOutputNames(make([]*types.FieldName, leftPlan.Schema().Len()+rightPlan.Schema().Len()))
@@ -1028,6 +1052,9 @@ func (b *PlanBuilder) buildJoin(ctx context.Context, joinNode *ast.Join) (base.L
 
 	// Set preferred join algorithm if some join hints is specified by user.
 	joinPlan.setPreferredJoinTypeAndOrder(b.TableHints())
+	if lateral {
+		setIsInApplyForCTE(rightPlan, joinPlan.Schema())
+	}
 
 	// "NATURAL JOIN" doesn't have "ON" or "USING" conditions.
 	//
@@ -1048,11 +1075,11 @@ func (b *PlanBuilder) buildJoin(ctx context.Context, joinNode *ast.Join) (base.L
 		}
 	} else if joinNode.On != nil {
 		b.curClause = onClause
-		onExpr, newPlan, err := b.rewrite(ctx, joinNode.On.Expr, joinPlan, nil, false)
+		onExpr, newPlan, err := b.rewrite(ctx, joinNode.On.Expr, resultPlan, nil, false)
 		if err != nil {
 			return nil, err
 		}
-		if newPlan != joinPlan {
+		if newPlan != resultPlan {
 			return nil, errors.New("ON condition doesn't support subqueries yet")
 		}
 		onCondition := expression.SplitCNFItems(onExpr)
@@ -1060,7 +1087,7 @@ func (b *PlanBuilder) buildJoin(ctx context.Context, joinNode *ast.Join) (base.L
 		// possible decorrelate optimizations. The ON clause is actually treated as a WHERE clause now.
 		if joinPlan.JoinType == InnerJoin {
 			sel := LogicalSelection{Conditions: onCondition}.Init(b.ctx, b.getSelectOffset())
-			sel.SetChildren(joinPlan)
+			sel.SetChildren(resultPlan)
 			return sel, nil
 		}
 		joinPlan.AttachOnConds(onCondition)
@@ -1070,7 +1097,131 @@ func (b *PlanBuilder) buildJoin(ctx context.Context, joinNode *ast.Join) (base.L
 		joinPlan.CartesianJoin = true
 	}
 
-	return joinPlan, nil
+	return resultPlan, nil
+}
+
+// buildLateralDerivedTable builds the LATERAL derived table or JSON_TABLE on the right side of a join. The
+// columns of the left side are visible to it as the outer columns, just like the correlated subquery.
+func (b *PlanBuilder) buildLateralDerivedTable(ctx context.Context, node ast.ResultSetNode, leftPlan base.LogicalPlan) (base.LogicalPlan, error) {
+	b.outerSchemas = append(b.outerSchemas, leftPlan.Schema().Clone())
+	b.outerNames = append(b.outerNames, leftPlan.OutputNames())
+	b.outerBlockExpand = append(b.outerBlockExpand, b.currentBlockExpand)
+	defer func() {
+		b.outerSchemas = b.outerSchemas[0 : len(b.outerSchemas)-1]
+		b.outerNames = b.outerNames[0 : len(b.outerNames)-1]
+		b.currentBlockExpand = b.outerBlockExpand[len(b.outerBlockExpand)-1]
+		b.outerBlockExpand = b.outerBlockExpand[0 : len(b.outerBlockExpand)-1]
+	}()
+	return b.buildResultSetNode(ctx, node, false)
+}
+
+// buildJSONTable builds the JSON_TABLE table function. Each value matched by the row path of the JSON
+// document is a row, and the columns are extracted from it by the column paths.
+func (b *PlanBuilder) buildJSONTable(ctx context.Context, jt *ast.JSONTable, asName model.CIStr) (base.LogicalPlan, error) {
+	mockTablePlan := LogicalTableDual{}.Init(b.ctx, b.getSelectOffset())
+	expr, np, err := b.rewrite(ctx, jt.Expr, mockTablePlan, nil, true)
+	if err != nil {
+		return nil, err
+	}
+	if np != mockTablePlan {
+		return nil, errors.New("JSON_TABLE doesn't support subqueries yet")
+	}
+	if expr.GetType(b.ctx.GetExprCtx().GetEvalCtx()).EvalType() != types.ETJson {
+		expr = expression.BuildCastFunction(b.ctx.GetExprCtx(), expr, types.NewFieldType(mysql.TypeJSON))
+	}
+	p := LogicalJSONTable{Expr: expr, Name: asName.O}.Init(b.ctx, b.getSelectOffset())
+	schema := expression.NewSchema()
+	names := make([]*types.FieldName, 0, len(jt.Columns))
+	p.Path, err = b.buildJSONTablePath(jt.Path, jt.Columns, schema, &names)
+	if err != nil {
+		return nil, err
+	}
+	p.SetSchema(schema)
+	p.SetOutputNames(names)
+	b.handleHelper.pushMap(nil)
+	return p, nil
+}
+
+// buildJSONTablePath builds the row path and its columns, the columns of the nested paths are appended
+// to the schema in the order they're defined.
+func (b *PlanBuilder) buildJSONTablePath(path string, cols []*ast.JSONTableColumn, schema *expression.Schema, names *[]*types.FieldName) (*JSONTablePath, error) {
+	pathExpr, err := types.ParseJSONPathExpr(path)
+	if err != nil {
+		return nil, err
+	}
+	jp := &JSONTablePath{Path: pathExpr}
+	for _, col := range cols {
+		if col.Tp == ast.JSONTableColumnNested {
+			nested, err := b.buildJSONTablePath(col.Path, col.NestedColumns, schema, names)
+			if err != nil {
+				return nil, err
+			}
+			jp.Nested = append(jp.Nested, nested)
+			continue
+		}
+		c := &JSONTableColumn{Tp: col.Tp, Name: col.Name.O, Offset: schema.Len()}
+		var tp *types.FieldType
+		if col.Tp == ast.JSONTableColumnOrdinality {
+			tp = types.NewFieldType(mysql.TypeLonglong)
+			tp.AddFlag(mysql.UnsignedFlag | mysql.NotNullFlag)
+		} else {
+			tp = jsonTableColumnType(col.FieldType)
+			if c.Path, err = types.ParseJSONPathExpr(col.Path); err != nil {
+				return nil, err
+			}
+			if c.OnEmpty, err = buildJSONTableOnResponse(col.OnEmpty); err != nil {
+				return nil, err
+			}
+			if c.OnError, err = buildJSONTableOnResponse(col.OnError); err != nil {
+				return nil, err
+			}
+		}
+		schema.Append(&expression.Column{
+			UniqueID: b.ctx.GetSessionVars().AllocPlanColumnID(),
+			RetType:  tp,
+		})
+		*names = append(*names, &types.FieldName{ColName: col.Name, OrigColName: col.Name})
+		jp.Columns = append(jp.Columns, c)
+	}
+	return jp, nil
+}
+
+func buildJSONTableOnResponse(resp *ast.JSONTableOnResponse) (JSONTableOnResponse, error) {
+	// NULL is the default behavior for both ON EMPTY and ON ERROR.
+	if resp == nil {
+		return JSONTableOnResponse{Tp: ast.JSONTableOnResponseNull}, nil
+	}
+	res := JSONTableOnResponse{Tp: resp.Tp}
+	if resp.Tp == ast.JSONTableOnResponseDefault {
+		var err error
+		if res.Default, err = types.ParseBinaryJSONFromString(resp.Default); err != nil {
+			return res, err
+		}
+	}
+	return res, nil
+}
+
+// jsonTableColumnType fills the default length, decimal, charset and collation of the JSON_TABLE column type.
+func jsonTableColumnType(ft *types.FieldType) *types.FieldType {
+	tp := ft.Clone()
+	flen, decimal := mysql.GetDefaultFieldLengthAndDecimal(tp.GetType())
+	if tp.GetFlen() == types.UnspecifiedLength {
+		tp.SetFlen(flen)
+	}
+	if tp.GetDecimal() == types.UnspecifiedLength {
+		tp.SetDecimal(decimal)
+	}
+	if tp.GetCharset() == "" {
+		chs, coll := types.DefaultCharsetForType(tp.GetType())
+		tp.SetCharset(chs)
+		tp.SetCollate(coll)
+	} else if tp.GetCollate() == "" {
+		coll, err := charset.GetDefaultCollation(tp.GetCharset())
+		if err == nil {
+			tp.SetCollate(coll)
+		}
+	}
+	return tp
 }
 
 // buildUsingClause eliminate the redundant columns and ordering columns based
@@ -2675,6 +2826,16 @@ func (a *havingWindowAndOrderbyExprResolver) Leave(n ast.Node) (node ast.Node, o
 		}
 	case *ast.WindowSpec:
 		a.inWindowSpec = false
+	case *ast.MatchAgainst:
+		// The columns of MATCH ... AGAINST are not ColumnNameExpr, append them to the select
+		// fields here so that they can be found when rewriting the order by / having items.
+		if !a.inAggFunc && (a.curClause == orderByClause || a.curClause == havingClause) {
+			for _, colName := range v.ColumnNames {
+				if _, a.err = a.resolveFromPlan(&ast.ColumnNameExpr{Name: colName}, a.p, false); a.err != nil {
+					return node, false
+				}
+			}
+		}
 	case *ast.PartitionByClause:
 		a.popCurClause()
 	case *ast.OrderByClause:
@@ -4034,6 +4195,7 @@ func (b *PlanBuilder) TableHints() *h.PlanHints {
 }
 
 func (b *PlanBuilder) buildSelect(ctx context.Context, sel *ast.SelectStmt) (p base.LogicalPlan, err error) {
+	sel = b.tryRewriteWithMView(ctx, sel)
 	b.pushSelectOffset(sel.QueryBlockOffset)
 	b.pushTableHints(sel.TableHints, sel.QueryBlockOffset)
 	defer func() {
@@ -5955,6 +6117,9 @@ func (b *PlanBuilder) buildUpdateLists(ctx context.Context, tableList []*ast.Tab
 				if isCTE(tl) || tl.TableInfo.IsView() || tl.TableInfo.IsSequence() {
 					return nil, nil, false, plannererrors.ErrNonUpdatableTable.GenWithStackByArgs(name.TblName.O, "UPDATE")
 				}
+				if err := b.checkMViewTableWrite(tl.TableInfo, "UPDATE"); err != nil {
+					return nil, nil, false, err
+				}
 				foundListItem = true
 			}
 		}
@@ -6249,6 +6414,9 @@ func (b *PlanBuilder) buildDelete(ctx context.Context, ds *ast.DeleteStmt) (base
 			if tn.TableInfo.IsSequence() {
 				return nil, errors.Errorf("delete sequence %s is not supported now", tn.Name.O)
 			}
+			if err := b.checkMViewTableWrite(tn.TableInfo, "DELETE"); err != nil {
+				return nil, err
+			}
 			if sessionVars.User != nil {
 				authErr = plannererrors.ErrTableaccessDenied.FastGenByArgs("DELETE", sessionVars.User.AuthUsername, sessionVars.User.AuthHostname, tb.Name.L)
 			}
@@ -6267,6 +6435,9 @@ func (b *PlanBuilder) buildDelete(ctx context.Context, ds *ast.DeleteStmt) (base
 			if v.TableInfo.IsSequence() {
 				return nil, errors.Errorf("delete sequence %s is not supported now", v.Name.O)
 			}
+			if err := b.checkMViewTableWrite(v.TableInfo, "DELETE"); err != nil {
+				return nil, err
+			}
 			dbName := v.Schema.L
 			if dbName == "" {
 				dbName = b.ctx.GetSessionVars().CurrentDB
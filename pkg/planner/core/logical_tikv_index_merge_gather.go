@@ -0,0 +1,126 @@
+// Copyright 2024 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package core
+
+import (
+	"github.com/pingcap/tidb/pkg/expression"
+	"github.com/pingcap/tidb/pkg/planner/core/base"
+	"github.com/pingcap/tidb/pkg/planner/core/operator/logicalop"
+	"github.com/pingcap/tidb/pkg/planner/property"
+	"github.com/pingcap/tidb/pkg/planner/util"
+	"github.com/pingcap/tidb/pkg/util/plancodec"
+)
+
+// TiKVIndexMergeGather is a leaf logical operator of TiDB layer to gather
+// tuples from TiKV regions by an index merge path. It's equivalent to the
+// Selection and DataSource the index merge path is built from, so all of
+// the filters are applied by it.
+type TiKVIndexMergeGather struct {
+	logicalop.LogicalSchemaProducer
+	// Source is the DataSource whose stats and PossibleAccessPaths are derived
+	// from the filters, see DataSource.BuildIndexMergePaths.
+	Source *DataSource
+	Path   *util.AccessPath
+}
+
+// Init initializes TiKVIndexMergeGather.
+func (g TiKVIndexMergeGather) Init(ctx base.PlanContext, offset int) *TiKVIndexMergeGather {
+	g.BaseLogicalPlan = logicalop.NewBaseLogicalPlan(ctx, plancodec.TypeIndexMerge, &g, offset)
+	return &g
+}
+
+// *************************** start implementation of Plan interface ***************************
+
+// ExplainInfo implements Plan interface.
+func (g *TiKVIndexMergeGather) ExplainInfo() string {
+	return g.Source.ExplainInfo() + ", index merge"
+}
+
+// *************************** end implementation of Plan interface ***************************
+
+// *************************** start implementation of logicalPlan interface ***************************
+
+// HashCode inherits BaseLogicalPlan.LogicalPlan.<0th> implementation.
+
+// PredicatePushDown inherits BaseLogicalPlan.LogicalPlan.<1st> implementation.
+
+// PruneColumns inherits BaseLogicalPlan.LogicalPlan.<2nd> implementation.
+
+// FindBestTask inherits BaseLogicalPlan.LogicalPlan.<3rd> implementation.
+
+// BuildKeyInfo inherits BaseLogicalPlan.LogicalPlan.<4th> implementation.
+
+// PushDownTopN inherits BaseLogicalPlan.LogicalPlan.<5th> implementation.
+
+// DeriveTopN inherits BaseLogicalPlan.LogicalPlan.<6th> implementation.
+
+// PredicateSimplification inherits BaseLogicalPlan.LogicalPlan.<7th> implementation.
+
+// ConstantPropagation inherits BaseLogicalPlan.LogicalPlan.<8th> implementation.
+
+// PullUpConstantPredicates inherits BaseLogicalPlan.LogicalPlan.<9th> implementation.
+
+// RecursiveDeriveStats inherits BaseLogicalPlan.LogicalPlan.<10th> implementation.
+
+// DeriveStats implements base.LogicalPlan.<11th> interface.
+func (g *TiKVIndexMergeGather) DeriveStats(_ []*property.StatsInfo, _ *expression.Schema, _ []*expression.Schema, _ [][]*expression.Column) (*property.StatsInfo, error) {
+	// The stats of the Source are derived from all of the filters.
+	g.SetStats(g.Source.StatsInfo())
+	return g.StatsInfo(), nil
+}
+
+// ExtractColGroups inherits BaseLogicalPlan.LogicalPlan.<12th> implementation.
+
+// PreparePossibleProperties inherits BaseLogicalPlan.LogicalPlan.<13th> implementation.
+
+// ExhaustPhysicalPlans inherits BaseLogicalPlan.LogicalPlan.<14th> implementation.
+
+// ExtractCorrelatedCols inherits BaseLogicalPlan.LogicalPlan.<15th> implementation.
+
+// MaxOneRow inherits BaseLogicalPlan.LogicalPlan.<16th> implementation.
+
+// Children inherits BaseLogicalPlan.LogicalPlan.<17th> implementation.
+
+// SetChildren inherits BaseLogicalPlan.LogicalPlan.<18th> implementation.
+
+// SetChild inherits BaseLogicalPlan.LogicalPlan.<19th> implementation.
+
+// RollBackTaskMap inherits BaseLogicalPlan.LogicalPlan.<20th> implementation.
+
+// CanPushToCop inherits BaseLogicalPlan.LogicalPlan.<21st> implementation.
+
+// ExtractFD inherits BaseLogicalPlan.LogicalPlan.<22nd> implementation.
+
+// GetBaseLogicalPlan inherits BaseLogicalPlan.LogicalPlan.<23rd> implementation.
+
+// ConvertOuterToInnerJoin inherits BaseLogicalPlan.LogicalPlan.<24th> implementation.
+
+// *************************** end implementation of logicalPlan interface ***************************
+
+// GetPhysicalIndexMergeReader returns the physical plan which reads the rows by the index merge path
+// for logical TiKVIndexMergeGather. It's a PhysicalIndexMergeReader, maybe with a PhysicalSelection
+// on it for the filters which can't be pushed down and a PhysicalProjection to prune the extra columns.
+// The returned plan is nil if the index merge path can't satisfy the required property.
+func (g *TiKVIndexMergeGather) GetPhysicalIndexMergeReader(prop *property.PhysicalProperty) (base.PhysicalPlan, error) {
+	if !prop.IsSortItemEmpty() {
+		return nil, nil
+	}
+	rootProp := &property.PhysicalProperty{TaskTp: property.RootTaskType, ExpectedCnt: prop.ExpectedCnt}
+	task, err := g.Source.convertToIndexMergeScan(rootProp, &candidatePath{path: g.Path}, nil)
+	if err != nil || task.Invalid() {
+		return nil, err
+	}
+	return task.Plan(), nil
+}
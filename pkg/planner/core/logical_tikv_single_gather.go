@@ -18,6 +18,7 @@ import (
 	"bytes"
 
 	"github.com/pingcap/tidb/pkg/expression"
+	"github.com/pingcap/tidb/pkg/kv"
 	"github.com/pingcap/tidb/pkg/parser/model"
 	"github.com/pingcap/tidb/pkg/planner/core/base"
 	"github.com/pingcap/tidb/pkg/planner/core/operator/logicalop"
@@ -119,6 +120,12 @@ func (*TiKVSingleGather) PreparePossibleProperties(_ *expression.Schema, childre
 // GetPhysicalIndexReader returns PhysicalIndexReader for logical TiKVSingleGather.
 func (sg *TiKVSingleGather) GetPhysicalIndexReader(schema *expression.Schema, stats *property.StatsInfo, props ...*property.PhysicalProperty) *PhysicalIndexReader {
 	reader := PhysicalIndexReader{}.Init(sg.SCtx(), sg.QueryBlockOffset())
+	reader.PlanPartInfo = &PhysPlanPartInfo{
+		PruningConds:   sg.Source.AllConds,
+		PartitionNames: sg.Source.PartitionNames,
+		Columns:        sg.Source.TblCols,
+		ColumnNames:    sg.Source.OutputNames(),
+	}
 	reader.SetStats(stats)
 	reader.SetSchema(schema)
 	reader.childrenReqProps = props
@@ -139,3 +146,12 @@ func (sg *TiKVSingleGather) GetPhysicalTableReader(schema *expression.Schema, st
 	reader.childrenReqProps = props
 	return reader
 }
+
+// GetPhysicalMPPTableReader returns PhysicalTableReader which reads from TiFlash by MPP for logical
+// TiKVSingleGather, its table plan is set by PhysicalTableReader.SetMPPTablePlan.
+func (sg *TiKVSingleGather) GetPhysicalMPPTableReader(schema *expression.Schema, stats *property.StatsInfo, props ...*property.PhysicalProperty) *PhysicalTableReader {
+	reader := sg.GetPhysicalTableReader(schema, stats, props...)
+	reader.StoreType = kv.TiFlash
+	reader.ReadReqType = MPP
+	return reader
+}
@@ -0,0 +1,435 @@
+// Copyright 2024 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package core
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/pingcap/tidb/pkg/infoschema"
+	"github.com/pingcap/tidb/pkg/parser"
+	"github.com/pingcap/tidb/pkg/parser/ast"
+	"github.com/pingcap/tidb/pkg/parser/format"
+	"github.com/pingcap/tidb/pkg/parser/model"
+	"github.com/pingcap/tidb/pkg/parser/mysql"
+	"github.com/pingcap/tidb/pkg/privilege"
+	"github.com/pingcap/tidb/pkg/util/dbterror/plannererrors"
+)
+
+// MViewAggFieldKind is the kind of a field in the query of a materialized view
+// which is refreshed incrementally.
+type MViewAggFieldKind int
+
+const (
+	// MViewGroupKey is a GROUP BY item.
+	MViewGroupKey MViewAggFieldKind = iota
+	// MViewCountAll is COUNT(*).
+	MViewCountAll
+	// MViewCount is COUNT(expr).
+	MViewCount
+	// MViewSum is SUM(expr).
+	MViewSum
+)
+
+// MViewAggField is a field in the query of a materialized view which is
+// refreshed incrementally.
+type MViewAggField struct {
+	Kind MViewAggFieldKind
+	// Expr is the GROUP BY item or the argument of the aggregate function, it's nil for COUNT(*).
+	Expr ast.ExprNode
+	// CountOffset is the offset of the COUNT(expr) field with the same argument of a SUM(expr) field.
+	CountOffset int
+}
+
+// MViewAggQuery is the query of a materialized view which is refreshed incrementally,
+// the query reads a single table and only contains COUNT and SUM aggregate functions.
+type MViewAggQuery struct {
+	Table   *ast.TableName
+	Where   ast.ExprNode
+	GroupBy []ast.ExprNode
+	Fields  []MViewAggField
+	// CountAllOffset is the offset of the COUNT(*) field.
+	CountAllOffset int
+}
+
+// ParseMViewAggQuery checks whether the query of a materialized view can be
+// refreshed incrementally, and returns the parts of the query used to build the
+// statements of the incremental refresh.
+func ParseMViewAggQuery(stmt ast.StmtNode) (*MViewAggQuery, error) {
+	notIncremental := func(reason string) error {
+		return plannererrors.ErrMViewNotIncremental.GenWithStackByArgs(reason)
+	}
+	sel, ok := stmt.(*ast.SelectStmt)
+	if !ok {
+		return nil, notIncremental("the query is not a single SELECT")
+	}
+	switch {
+	case sel.With != nil:
+		return nil, notIncremental("WITH clause is not supported")
+	case sel.Distinct:
+		return nil, notIncremental("DISTINCT is not supported")
+	case sel.Having != nil:
+		return nil, notIncremental("HAVING clause is not supported")
+	case sel.OrderBy != nil || sel.Limit != nil:
+		return nil, notIncremental("ORDER BY and LIMIT clauses are not supported")
+	case sel.WindowSpecs != nil:
+		return nil, notIncremental("window functions are not supported")
+	case sel.GroupBy != nil && sel.GroupBy.Rollup:
+		return nil, notIncremental("WITH ROLLUP is not supported")
+	}
+	if sel.From == nil || sel.From.TableRefs == nil || sel.From.TableRefs.Right != nil {
+		return nil, notIncremental("the query must read exactly one table")
+	}
+	ts, ok := sel.From.TableRefs.Left.(*ast.TableSource)
+	if !ok {
+		return nil, notIncremental("the query must read exactly one table")
+	}
+	tn, ok := ts.Source.(*ast.TableName)
+	if !ok {
+		return nil, notIncremental("the query must read exactly one table")
+	}
+	q := &MViewAggQuery{Table: tn, Where: sel.Where, CountAllOffset: -1}
+	if err := checkMViewAggArg(sel.Where); err != nil {
+		return nil, err
+	}
+
+	groupKeys := make(map[string]bool)
+	if sel.GroupBy != nil {
+		for _, item := range sel.GroupBy.Items {
+			if _, ok := item.Expr.(*ast.PositionExpr); ok {
+				return nil, notIncremental("GROUP BY position is not supported")
+			}
+			if err := checkMViewAggArg(item.Expr); err != nil {
+				return nil, err
+			}
+			key, err := RestoreMViewExpr(item.Expr)
+			if err != nil {
+				return nil, err
+			}
+			groupKeys[key] = false
+			q.GroupBy = append(q.GroupBy, item.Expr)
+		}
+	}
+
+	counts := make(map[string]int)
+	var sums []int
+	for i, field := range sel.Fields.Fields {
+		if field.WildCard != nil {
+			return nil, notIncremental("wildcard is not supported")
+		}
+		f := MViewAggField{Expr: field.Expr, CountOffset: -1}
+		if agg, ok := field.Expr.(*ast.AggregateFuncExpr); ok {
+			if agg.Distinct || len(agg.Args) != 1 {
+				return nil, notIncremental(fmt.Sprintf("%s with DISTINCT or multiple arguments is not supported", strings.ToUpper(agg.F)))
+			}
+			if err := checkMViewAggArg(agg.Args[0]); err != nil {
+				return nil, err
+			}
+			arg, err := RestoreMViewExpr(agg.Args[0])
+			if err != nil {
+				return nil, err
+			}
+			f.Expr = agg.Args[0]
+			switch strings.ToLower(agg.F) {
+			case ast.AggFuncCount:
+				if v, ok := agg.Args[0].(ast.ValueExpr); ok && v.GetValue() != nil {
+					// COUNT(*) and COUNT(constant) count all the rows.
+					f.Kind, f.Expr = MViewCountAll, nil
+					if q.CountAllOffset < 0 {
+						q.CountAllOffset = i
+					}
+				} else {
+					f.Kind = MViewCount
+					if _, ok := counts[arg]; !ok {
+						counts[arg] = i
+					}
+				}
+			case ast.AggFuncSum:
+				f.Kind = MViewSum
+				sums = append(sums, i)
+			default:
+				return nil, notIncremental(fmt.Sprintf("aggregate function %s is not supported", strings.ToUpper(agg.F)))
+			}
+		} else {
+			key, err := RestoreMViewExpr(field.Expr)
+			if err != nil {
+				return nil, err
+			}
+			if _, ok := groupKeys[key]; !ok {
+				return nil, notIncremental(fmt.Sprintf("field %d is neither a GROUP BY item nor COUNT or SUM", i+1))
+			}
+			f.Kind = MViewGroupKey
+			groupKeys[key] = true
+		}
+		q.Fields = append(q.Fields, f)
+	}
+	for _, selected := range groupKeys {
+		if !selected {
+			return nil, notIncremental("all the GROUP BY items must be selected")
+		}
+	}
+	if q.CountAllOffset < 0 {
+		return nil, notIncremental("COUNT(*) must be selected")
+	}
+	for _, i := range sums {
+		arg, err := RestoreMViewExpr(q.Fields[i].Expr)
+		if err != nil {
+			return nil, err
+		}
+		offset, ok := counts[arg]
+		if !ok {
+			return nil, notIncremental(fmt.Sprintf("COUNT(%s) must be selected with SUM(%s)", arg, arg))
+		}
+		q.Fields[i].CountOffset = offset
+	}
+	return q, nil
+}
+
+// checkMViewAggArg checks an expression evaluated on the rows of the base table.
+func checkMViewAggArg(expr ast.ExprNode) error {
+	if expr == nil {
+		return nil
+	}
+	c := &mviewExprChecker{}
+	expr.Accept(c)
+	if c.reason != "" {
+		return plannererrors.ErrMViewNotIncremental.GenWithStackByArgs(c.reason)
+	}
+	return nil
+}
+
+type mviewExprChecker struct {
+	reason string
+}
+
+// Enter implements ast.Visitor interface.
+func (c *mviewExprChecker) Enter(n ast.Node) (ast.Node, bool) {
+	switch n.(type) {
+	case *ast.SubqueryExpr:
+		c.reason = "subqueries are not supported"
+	case *ast.AggregateFuncExpr:
+		c.reason = "nested aggregate functions are not supported"
+	case *ast.WindowFuncExpr:
+		c.reason = "window functions are not supported"
+	case *ast.VariableExpr:
+		c.reason = "variables are not supported"
+	}
+	return n, c.reason != ""
+}
+
+// Leave implements ast.Visitor interface.
+func (c *mviewExprChecker) Leave(n ast.Node) (ast.Node, bool) {
+	return n, c.reason == ""
+}
+
+type mviewColumnNameCollector struct {
+	names []*ast.ColumnName
+}
+
+// Enter implements ast.Visitor interface.
+func (c *mviewColumnNameCollector) Enter(n ast.Node) (ast.Node, bool) {
+	if name, ok := n.(*ast.ColumnName); ok {
+		c.names = append(c.names, name)
+	}
+	return n, false
+}
+
+// Leave implements ast.Visitor interface.
+func (*mviewColumnNameCollector) Leave(n ast.Node) (ast.Node, bool) {
+	return n, true
+}
+
+// RestoreMViewExpr restores an expression of the query of a materialized view
+// which reads a single table. The schema and table names of the columns are
+// omitted, so the expression can be evaluated on the log table of the view.
+func RestoreMViewExpr(expr ast.ExprNode) (string, error) {
+	c := &mviewColumnNameCollector{}
+	expr.Accept(c)
+	qualifiers := make([][2]model.CIStr, len(c.names))
+	for i, name := range c.names {
+		qualifiers[i] = [2]model.CIStr{name.Schema, name.Table}
+		name.Schema, name.Table = model.CIStr{}, model.CIStr{}
+	}
+	defer func() {
+		for i, name := range c.names {
+			name.Schema, name.Table = qualifiers[i][0], qualifiers[i][1]
+		}
+	}()
+	var sb strings.Builder
+	if err := expr.Restore(format.NewRestoreCtx(format.DefaultRestoreFlags, &sb)); err != nil {
+		return "", err
+	}
+	return sb.String(), nil
+}
+
+// mviewQueryKey returns the text identifying a query when it's matched with the
+// queries of materialized views. The aliases of the fields are ignored.
+func mviewQueryKey(sel *ast.SelectStmt) (string, error) {
+	aliases := make([]model.CIStr, len(sel.Fields.Fields))
+	for i, field := range sel.Fields.Fields {
+		aliases[i] = field.AsName
+		field.AsName = model.CIStr{}
+	}
+	defer func() {
+		for i, field := range sel.Fields.Fields {
+			field.AsName = aliases[i]
+		}
+	}()
+	var sb strings.Builder
+	if err := sel.Restore(format.NewRestoreCtx(format.DefaultRestoreFlags, &sb)); err != nil {
+		return "", err
+	}
+	return sb.String(), nil
+}
+
+// tryRewriteWithMView rewrites the query to read a materialized view whose query
+// is the same. The data of the view may be stale, so it's only enabled by the
+// variable tidb_opt_enable_mview_rewrite.
+func (b *PlanBuilder) tryRewriteWithMView(_ context.Context, sel *ast.SelectStmt) *ast.SelectStmt {
+	sessVars := b.ctx.GetSessionVars()
+	if !sessVars.EnableMViewRewrite || sessVars.InRestrictedSQL || b.isCreateView || b.isForUpdateRead ||
+		sel.With != nil || sel.OrderBy != nil || sel.Limit != nil || sel.LockInfo != nil || sel.SelectIntoOpt != nil ||
+		sel.From == nil || sel.From.TableRefs == nil {
+		return sel
+	}
+	// Find the materialized views of the tables read by the query.
+	tables := collectMViewQueryTables(sel)
+	if len(tables) == 0 {
+		return sel
+	}
+	var key string
+	for _, tn := range tables {
+		tblInfo, err := b.is.TableInfoByName(tn.Schema, tn.Name)
+		if err != nil {
+			return sel
+		}
+		for _, mvID := range tblInfo.MViewIDs {
+			mv, ok := b.is.TableByID(mvID)
+			if !ok || mv.Meta().MaterializedView == nil {
+				continue
+			}
+			mvInfo := mv.Meta()
+			if key == "" {
+				if key, err = mviewQueryKey(sel); err != nil {
+					return sel
+				}
+			}
+			if mviewQueryKeyOf(mvInfo) != key || len(mvInfo.Columns) != len(sel.Fields.Fields) {
+				continue
+			}
+			dbInfo, ok := infoschema.SchemaByTable(b.is, mvInfo)
+			if !ok {
+				continue
+			}
+			pm := privilege.GetPrivilegeManager(b.ctx)
+			if pm != nil && !pm.RequestVerification(sessVars.ActiveRoles, dbInfo.Name.L, mvInfo.Name.L, "", mysql.SelectPriv) {
+				continue
+			}
+			sessVars.StmtCtx.SetSkipPlanCache("query is rewritten to read a materialized view")
+			return buildMViewRewrittenSelect(sel, dbInfo.Name, mvInfo)
+		}
+	}
+	return sel
+}
+
+// mviewQueryKeyOf returns the key of the query of a materialized view, it's empty
+// if the query can't be parsed.
+func mviewQueryKeyOf(mvInfo *model.TableInfo) string {
+	stmt, err := parser.New().ParseOneStmt(mvInfo.MaterializedView.SelectStmt, "", "")
+	if err != nil {
+		return ""
+	}
+	sel, ok := stmt.(*ast.SelectStmt)
+	if !ok {
+		return ""
+	}
+	key, err := mviewQueryKey(sel)
+	if err != nil {
+		return ""
+	}
+	return key
+}
+
+// collectMViewQueryTables returns the tables read by a query, it returns nil if
+// some table is not qualified by the schema, such as a CTE.
+func collectMViewQueryTables(sel *ast.SelectStmt) []*ast.TableName {
+	c := &mviewTableNameCollector{}
+	sel.Accept(c)
+	if c.unqualified {
+		return nil
+	}
+	return c.tables
+}
+
+type mviewTableNameCollector struct {
+	tables      []*ast.TableName
+	unqualified bool
+}
+
+// Enter implements ast.Visitor interface.
+func (c *mviewTableNameCollector) Enter(n ast.Node) (ast.Node, bool) {
+	if tn, ok := n.(*ast.TableName); ok {
+		if tn.Schema.L == "" {
+			c.unqualified = true
+		}
+		c.tables = append(c.tables, tn)
+	}
+	return n, false
+}
+
+// Leave implements ast.Visitor interface.
+func (*mviewTableNameCollector) Leave(n ast.Node) (ast.Node, bool) {
+	return n, true
+}
+
+// buildMViewRewrittenSelect builds the query reading the columns of the materialized
+// view, the output names of the original query are kept.
+func buildMViewRewrittenSelect(sel *ast.SelectStmt, dbName model.CIStr, mvInfo *model.TableInfo) *ast.SelectStmt {
+	fields := make([]*ast.SelectField, 0, len(sel.Fields.Fields))
+	for i, field := range sel.Fields.Fields {
+		name := field.AsName
+		if name.L == "" {
+			if col, ok := field.Expr.(*ast.ColumnNameExpr); ok {
+				name = col.Name.Name
+			} else {
+				name = model.NewCIStr(field.Text())
+			}
+		}
+		fields = append(fields, &ast.SelectField{
+			Expr:   &ast.ColumnNameExpr{Name: &ast.ColumnName{Name: mvInfo.Columns[i].Name}},
+			AsName: name,
+		})
+	}
+	return &ast.SelectStmt{
+		SelectStmtOpts: sel.SelectStmtOpts,
+		Kind:           sel.Kind,
+		Fields:         &ast.FieldList{Fields: fields},
+		From: &ast.TableRefsClause{TableRefs: &ast.Join{Left: &ast.TableSource{
+			Source: &ast.TableName{Schema: dbName, Name: mvInfo.Name},
+		}}},
+		QueryBlockOffset: sel.QueryBlockOffset,
+	}
+}
+
+// checkMViewTableWrite returns an error if the table stores the data or the log
+// of a materialized view, which is only modified by the internal statements
+// refreshing the view.
+func (b *PlanBuilder) checkMViewTableWrite(tblInfo *model.TableInfo, stmt string) error {
+	if (tblInfo.IsMaterializedView() || tblInfo.IsMViewLog()) && !b.ctx.GetSessionVars().InRestrictedSQL {
+		return plannererrors.ErrNonUpdatableTable.GenWithStackByArgs(tblInfo.Name.O, stmt)
+	}
+	return nil
+}
@@ -431,6 +431,7 @@ func postOptimize(ctx context.Context, sctx base.PlanContext, plan base.Physical
 	disableReuseChunkIfNeeded(sctx, plan)
 	tryEnableLateMaterialization(sctx, plan)
 	generateRuntimeFilter(sctx, plan)
+	postOptimizeHashJoinAlternatives(sctx, plan)
 	return plan
 }
 
@@ -976,6 +977,28 @@ func enableParallelApply(sctx base.PlanContext, plan base.PhysicalPlan) base.Phy
 	return plan
 }
 
+// PrunePartitionsInStaticMode prunes the partitions of the partitioned tables in the plan under the
+// static prune mode, it's used by the cascades planner. The predicates are pushed down first so that
+// the partitions can be pruned by them.
+func PrunePartitionsInStaticMode(ctx context.Context, logic base.LogicalPlan) (base.LogicalPlan, error) {
+	if logic.SCtx().GetSessionVars().StmtCtx.UseDynamicPruneMode || !containsPartitionedTable(logic) {
+		return logic, nil
+	}
+	return logicalOptimize(ctx, flagPredicatePushDown|flagPartitionProcessor, logic)
+}
+
+func containsPartitionedTable(p base.LogicalPlan) bool {
+	if ds, ok := p.(*DataSource); ok && ds.TableInfo.GetPartitionInfo() != nil {
+		return true
+	}
+	for _, child := range p.Children() {
+		if containsPartitionedTable(child) {
+			return true
+		}
+	}
+	return false
+}
+
 // LogicalOptimizeTest is just exported for test.
 func LogicalOptimizeTest(ctx context.Context, flag uint64, logic base.LogicalPlan) (base.LogicalPlan, error) {
 	return logicalOptimize(ctx, flag, logic)
@@ -1130,12 +1153,15 @@ func eliminateUnionScanAndLock(sctx base.PlanContext, p base.PhysicalPlan) base.
 		if !lock {
 			return p
 		}
+		skipLocked := IsSelectSkipLockedLockType(physLock.Lock.LockType)
 		if pointGet != nil {
 			pointGet.Lock = lock
 			pointGet.LockWaitTime = waitTime
+			pointGet.SkipLocked = skipLocked
 		} else {
 			batchPointGet.Lock = lock
 			batchPointGet.LockWaitTime = waitTime
+			batchPointGet.SkipLocked = skipLocked
 		}
 	}
 	return transformPhysicalPlan(p, func(p base.PhysicalPlan) base.PhysicalPlan {
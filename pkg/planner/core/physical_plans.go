@@ -55,6 +55,7 @@ var (
 	_ base.PhysicalPlan = &PhysicalTopN{}
 	_ base.PhysicalPlan = &PhysicalMaxOneRow{}
 	_ base.PhysicalPlan = &PhysicalTableDual{}
+	_ base.PhysicalPlan = &PhysicalJSONTable{}
 	_ base.PhysicalPlan = &PhysicalUnionAll{}
 	_ base.PhysicalPlan = &PhysicalSort{}
 	_ base.PhysicalPlan = &NominalSort{}
@@ -273,6 +274,21 @@ func (p *PhysicalTableReader) SetChildren(children ...base.PhysicalPlan) {
 	p.TablePlans = flattenPushDownPlan(p.tablePlan)
 }
 
+// SetMPPTablePlan sets the plan executed by TiFlash for the PhysicalTableReader which reads by MPP,
+// the plan is sent under a pass-through ExchangeSender. It's used by the cascades planner, see
+// MppTask.ConvertToRootTaskImpl for the default planner.
+func (p *PhysicalTableReader) SetMPPTablePlan(tablePlan base.PhysicalPlan) {
+	tryExpandVirtualColumn(tablePlan)
+	setMppOrBatchCopForTableScan(tablePlan)
+	sender := PhysicalExchangeSender{
+		ExchangeType: tipb.ExchangeType_PassThrough,
+	}.Init(p.SCtx(), tablePlan.StatsInfo())
+	sender.SetChildren(tablePlan)
+	p.SetChildren(sender)
+	p.TableScanAndPartitionInfos = nil
+	collectPartitionInfosFromMPPPlan(p, tablePlan)
+}
+
 // ExtractCorrelatedCols implements op.PhysicalPlan interface.
 func (p *PhysicalTableReader) ExtractCorrelatedCols() (corCols []*expression.CorrelatedColumn) {
 	for _, child := range p.TablePlans {
@@ -727,6 +743,10 @@ type PhysicalIndexScan struct {
 	// usedStatsInfo records stats status of this physical table.
 	// It's for printing stats related information when display execution plan.
 	usedStatsInfo *stmtctx.UsedStatsInfoForTable `plan-cache-clone:"shallow"`
+
+	// cardFeedback is set if this scan outputs the rows of the DataSource, it's used to collect the
+	// cardinality feedback after execution.
+	cardFeedback *cardFeedbackInfo `plan-cache-clone:"shallow"`
 }
 
 // Clone implements op.PhysicalPlan interface.
@@ -907,6 +927,10 @@ type PhysicalTableScan struct {
 	// It's for printing stats related information when display execution plan.
 	usedStatsInfo *stmtctx.UsedStatsInfoForTable `plan-cache-clone:"shallow"`
 
+	// cardFeedback is set if this scan outputs the rows of the DataSource, it's used to collect the
+	// cardinality feedback after execution.
+	cardFeedback *cardFeedbackInfo `plan-cache-clone:"shallow"`
+
 	// for runtime filter
 	runtimeFilterList []*RuntimeFilter `plan-cache-clone:"must-nil"` // plan with runtime filter is not cached
 	maxWaitTimeMs     int
@@ -1361,24 +1385,46 @@ type PhysicalHashJoin struct {
 	runtimeFilterList []*RuntimeFilter
 }
 
+// RootRuntimeFilters returns the runtime filters of the hash join executed in TiDB, which are built by the
+// executor and pushed down to the TiKV table scans on the probe side.
+func (p *PhysicalHashJoin) RootRuntimeFilters() []*RuntimeFilter {
+	if p.storeTp == kv.TiFlash {
+		return nil
+	}
+	return p.runtimeFilterList
+}
+
 // CanUseHashJoinV2 returns true if current join is supported by hash join v2
 func (p *PhysicalHashJoin) CanUseHashJoinV2() bool {
 	switch p.JoinType {
-	case LeftOuterJoin, RightOuterJoin, InnerJoin:
-		// null aware join is not supported yet
-		if len(p.LeftNAJoinKeys) > 0 {
-			return false
-		}
-		// cross join is not supported
-		if len(p.LeftJoinKeys) == 0 {
-			return false
-		}
+	case LeftOuterJoin, RightOuterJoin, InnerJoin, SemiJoin, AntiSemiJoin, LeftOuterSemiJoin, AntiLeftOuterSemiJoin:
 		// NullEQ is not supported yet
 		for _, value := range p.IsNullEQ {
 			if value {
 				return false
 			}
 		}
+		isNullAware := len(p.LeftNAJoinKeys) > 0
+		switch p.JoinType {
+		case SemiJoin, LeftOuterSemiJoin:
+			// semi join that uses outer side to build is not supported yet
+			if p.UseOuterToBuild || isNullAware {
+				return false
+			}
+		case AntiSemiJoin, AntiLeftOuterSemiJoin:
+			if p.UseOuterToBuild {
+				return false
+			}
+		default:
+			// null aware join is only used by anti semi join and anti left outer semi join
+			if isNullAware {
+				return false
+			}
+		}
+		// cross join is not supported
+		if len(p.LeftJoinKeys) == 0 && !isNullAware {
+			return false
+		}
 		return true
 	default:
 		return false
@@ -1507,6 +1553,10 @@ type PhysicalIndexJoin struct {
 	// InnerHashKeys indicates the inner keys used to build hash table during
 	// execution. InnerJoinKeys is the prefix of InnerHashKeys.
 	InnerHashKeys []*expression.Column
+	// HashJoinAlternative is the hash join which replaces this index join at execution time if the outer
+	// side has more rows than tidb_adaptive_join_outer_rows_threshold. It's set only when the adaptive
+	// join is enabled. The probe side of it is the outer child of the index join.
+	HashJoinAlternative *PhysicalHashJoin
 }
 
 // MemoryUsage return the memory usage of PhysicalIndexJoin
@@ -2207,12 +2257,10 @@ type PhysicalSelection struct {
 	// Please see https://github.com/pingcap/tidb/issues/36243 for more details.
 	fromDataSource bool
 
-	// todo Since the feature of adding filter operators has not yet been implemented,
-	// the following code for this function will not be used for now.
 	// The flag indicates whether this Selection is used for RuntimeFilter
 	// True: Used for RuntimeFilter
 	// False: Only for normal conditions
-	// hasRFConditions bool
+	hasRFConditions bool
 }
 
 // Clone implements op.PhysicalPlan interface.
@@ -2310,6 +2358,45 @@ func (p *PhysicalTableDual) MemoryUsage() (sum int64) {
 	return
 }
 
+// PhysicalJSONTable is the physical operator of JSON_TABLE.
+type PhysicalJSONTable struct {
+	physicalSchemaProducer
+
+	Expr expression.Expression
+	Path *JSONTablePath
+	Name string
+}
+
+// Clone implements op.PhysicalPlan interface.
+func (p *PhysicalJSONTable) Clone(newCtx base.PlanContext) (base.PhysicalPlan, error) {
+	cloned := new(PhysicalJSONTable)
+	cloned.SetSCtx(newCtx)
+	base, err := p.physicalSchemaProducer.cloneWithSelf(newCtx, cloned)
+	if err != nil {
+		return nil, err
+	}
+	cloned.physicalSchemaProducer = *base
+	cloned.Expr = p.Expr.Clone()
+	cloned.Path = p.Path
+	cloned.Name = p.Name
+	return cloned, nil
+}
+
+// ExtractCorrelatedCols implements op.PhysicalPlan interface.
+func (p *PhysicalJSONTable) ExtractCorrelatedCols() []*expression.CorrelatedColumn {
+	return expression.ExtractCorColumns(p.Expr)
+}
+
+// MemoryUsage return the memory usage of PhysicalJSONTable
+func (p *PhysicalJSONTable) MemoryUsage() (sum int64) {
+	if p == nil {
+		return
+	}
+
+	sum = p.physicalSchemaProducer.MemoryUsage() + p.Expr.MemoryUsage() + p.Path.MemoryUsage() + int64(len(p.Name))
+	return
+}
+
 // PhysicalWindow is the physical operator of window function.
 type PhysicalWindow struct {
 	physicalSchemaProducer
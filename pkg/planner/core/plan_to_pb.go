@@ -262,11 +262,14 @@ func (p *PhysicalTableScan) ToPB(ctx *base.BuildPBContext, storeType kv.StoreTyp
 	}
 
 	var err error
-	tsExec.RuntimeFilterList, err = RuntimeFilterListToPB(ctx, p.runtimeFilterList, ctx.GetClient())
-	if err != nil {
-		return nil, errors.Trace(err)
+	// The runtime filters of the TiKV table scan are pushed down by the executor as selection conditions.
+	if storeType == kv.TiFlash {
+		tsExec.RuntimeFilterList, err = RuntimeFilterListToPB(ctx, p.runtimeFilterList, ctx.GetClient())
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		tsExec.MaxWaitTimeMs = int32(p.maxWaitTimeMs)
 	}
-	tsExec.MaxWaitTimeMs = int32(p.maxWaitTimeMs)
 
 	if p.isPartition {
 		tsExec.TableId = p.physicalTableID
@@ -20,6 +20,7 @@ import (
 	"fmt"
 	"math"
 	"reflect"
+	"slices"
 	"strconv"
 	"strings"
 
@@ -518,6 +519,8 @@ func (b *PlanBuilder) Build(ctx context.Context, node ast.Node) (base.Plan, erro
 		return b.buildUnlockStats(x), nil
 	case *ast.IndexAdviseStmt:
 		return b.buildIndexAdvise(x), nil
+	case *ast.RecommendIndexStmt:
+		return b.buildRecommendIndex(x), nil
 	case *ast.PlanReplayerStmt:
 		return b.buildPlanReplayer(x), nil
 	case *ast.PrepareStmt:
@@ -546,8 +549,21 @@ func (b *PlanBuilder) Build(ctx context.Context, node ast.Node) (base.Plan, erro
 		*ast.GrantStmt, *ast.DropUserStmt, *ast.AlterUserStmt, *ast.AlterRangeStmt, *ast.RevokeStmt, *ast.KillStmt, *ast.DropStatsStmt,
 		*ast.GrantRoleStmt, *ast.RevokeRoleStmt, *ast.SetRoleStmt, *ast.SetDefaultRoleStmt, *ast.ShutdownStmt,
 		*ast.RenameUserStmt, *ast.NonTransactionalDMLStmt, *ast.SetSessionStatesStmt, *ast.SetResourceGroupStmt,
-		*ast.ImportIntoActionStmt, *ast.CalibrateResourceStmt, *ast.AddQueryWatchStmt, *ast.DropQueryWatchStmt:
+		*ast.ImportIntoActionStmt, *ast.CalibrateResourceStmt, *ast.AddQueryWatchStmt, *ast.DropQueryWatchStmt,
+		*ast.ProcedureInfo, *ast.DropProcedureStmt, *ast.RefreshMaterializedViewStmt:
 		return b.buildSimple(ctx, node.(ast.StmtNode))
+	case *ast.CallStmt:
+		return b.buildCallProcedure(ctx, x)
+	case *ast.AlterTableStmt:
+		if len(x.Specs) == 1 {
+			switch x.Specs[0].Tp {
+			case ast.AlterTableCheckPartitions:
+				return b.buildCheckPartition(ctx, x)
+			case ast.AlterTableOptimizePartition:
+				return b.buildOptimizePartition(x)
+			}
+		}
+		return b.buildDDL(ctx, x)
 	case ast.DDLNode:
 		return b.buildDDL(ctx, x)
 	case *ast.CreateBindingStmt:
@@ -1020,7 +1036,8 @@ func isForUpdateReadSelectLock(lock *ast.SelectLockInfo) bool {
 	}
 	return lock.LockType == ast.SelectLockForUpdate ||
 		lock.LockType == ast.SelectLockForUpdateNoWait ||
-		lock.LockType == ast.SelectLockForUpdateWaitN
+		lock.LockType == ast.SelectLockForUpdateWaitN ||
+		lock.LockType == ast.SelectLockForUpdateSkipLocked
 }
 
 // getLatestIndexInfo gets the index info of latest schema version from given table id,
@@ -1217,6 +1234,10 @@ func getPossibleAccessPaths(ctx base.PlanContext, tableHints *hint.PlanHints, in
 		available = removeGlobalIndexPaths(available)
 	}
 
+	// The fulltext and spatial indexes can only be accessed by IndexMerge, see generateIndexMerge4FulltextIndex
+	// and generateIndexMerge4SpatialIndex.
+	available = removeFulltextAndSpatialIndexPaths(available)
+
 	// If we have got "FORCE" or "USE" index hint but got no available index,
 	// we have to use table scan.
 	if len(available) == 0 {
@@ -1309,6 +1330,18 @@ func removeGlobalIndexPaths(paths []*util.AccessPath) []*util.AccessPath {
 	return paths[:i]
 }
 
+func removeFulltextAndSpatialIndexPaths(paths []*util.AccessPath) []*util.AccessPath {
+	i := 0
+	for _, path := range paths {
+		if path.Index != nil && (path.Index.IsFulltextIndex() || path.Index.IsSpatialIndex()) {
+			continue
+		}
+		paths[i] = path
+		i++
+	}
+	return paths[:i]
+}
+
 func (b *PlanBuilder) buildSelectLock(src base.LogicalPlan, lock *ast.SelectLockInfo) (*LogicalLock, error) {
 	var tblID2PhysTblIDCol map[int64]*expression.Column
 	if len(b.partitionedTable) > 0 {
@@ -1455,12 +1488,7 @@ func (b *PlanBuilder) buildAdmin(ctx context.Context, as *ast.AdminStmt) (base.P
 	case ast.AdminCaptureBindings:
 		return &SQLBindPlan{SQLBindOp: OpCaptureBindings}, nil
 	case ast.AdminEvolveBindings:
-		var err error
-		// The 'baseline evolution' only work in the test environment before the feature is GA.
-		if !config.CheckTableBeforeDrop {
-			err = errors.Errorf("Cannot enable baseline evolution feature, it is not generally available now")
-		}
-		return &SQLBindPlan{SQLBindOp: OpEvolveBindings}, err
+		return &SQLBindPlan{SQLBindOp: OpEvolveBindings, SQLDigest: as.SQLDigest}, nil
 	case ast.AdminReloadBindings:
 		return &SQLBindPlan{SQLBindOp: OpReloadBindings}, nil
 	case ast.AdminReloadStatistics:
@@ -1642,7 +1670,9 @@ func tryGetPkHandleCol(tblInfo *model.TableInfo, allColSchema *expression.Schema
 	return nil, nil, false
 }
 
-func (b *PlanBuilder) buildPhysicalIndexLookUpReaders(ctx context.Context, dbName model.CIStr, tbl table.Table, indices []table.Index) ([]base.Plan, []*model.IndexInfo, error) {
+// buildPhysicalIndexLookUpReaders builds the readers to check the indices. Only the partitions in partitionNames
+// are checked if it's not empty.
+func (b *PlanBuilder) buildPhysicalIndexLookUpReaders(ctx context.Context, dbName model.CIStr, tbl table.Table, indices []table.Index, partitionNames []model.CIStr) ([]base.Plan, []*model.IndexInfo, error) {
 	tblInfo := tbl.Meta()
 	// get index information
 	indexInfos := make([]*model.IndexInfo, 0, len(tblInfo.Indices))
@@ -1659,6 +1689,14 @@ func (b *PlanBuilder) buildPhysicalIndexLookUpReaders(ctx context.Context, dbNam
 			// Skip checking clustered index.
 			continue
 		}
+		if idxInfo.IsFulltextIndex() || idxInfo.IsSpatialIndex() {
+			// Skip checking fulltext and spatial indexes, their keys are the tokens and cells instead of the column values.
+			continue
+		}
+		if idxInfo.Global && len(partitionNames) > 0 {
+			// Skip checking global indexes, they contain the rows of all the partitions.
+			continue
+		}
 		if idxInfo.State != model.StatePublic {
 			logutil.Logger(ctx).Info("build physical index lookup reader, the index isn't public",
 				zap.String("index", idxInfo.Name.O),
@@ -1690,6 +1728,9 @@ func (b *PlanBuilder) buildPhysicalIndexLookUpReaders(ctx context.Context, dbNam
 		// For partition tables except global index.
 		if pi := tbl.Meta().GetPartitionInfo(); pi != nil && !idxInfo.Global {
 			for _, def := range pi.Definitions {
+				if len(partitionNames) > 0 && !slices.ContainsFunc(partitionNames, func(name model.CIStr) bool { return name.L == def.Name.L }) {
+					continue
+				}
 				t := tbl.(table.PartitionedTable).GetPartition(def.ID)
 				reader, err := b.buildPhysicalIndexLookUpReader(ctx, dbName, t, idxInfo)
 				if err != nil {
@@ -1743,9 +1784,9 @@ func (b *PlanBuilder) buildAdminCheckTable(ctx context.Context, as *ast.AdminStm
 			return nil, errors.Errorf("index %s state %s isn't public", as.Index, idx.Meta().State)
 		}
 		p.CheckIndex = true
-		readerPlans, indexInfos, err = b.buildPhysicalIndexLookUpReaders(ctx, tblName.Schema, tbl, []table.Index{idx})
+		readerPlans, indexInfos, err = b.buildPhysicalIndexLookUpReaders(ctx, tblName.Schema, tbl, []table.Index{idx}, nil)
 	} else {
-		readerPlans, indexInfos, err = b.buildPhysicalIndexLookUpReaders(ctx, tblName.Schema, tbl, tbl.Indices())
+		readerPlans, indexInfos, err = b.buildPhysicalIndexLookUpReaders(ctx, tblName.Schema, tbl, tbl.Indices(), nil)
 	}
 	if err != nil {
 		return nil, errors.Trace(err)
@@ -1759,6 +1800,93 @@ func (b *PlanBuilder) buildAdminCheckTable(ctx context.Context, as *ast.AdminStm
 	return p, nil
 }
 
+// checkMaintainedPartitions checks the partitions of the "ALTER TABLE ... CHECK | OPTIMIZE PARTITION" statement,
+// and returns their names. It returns nil if all the partitions are maintained.
+func checkMaintainedPartitions(tn *ast.TableName, spec *ast.AlterTableSpec) ([]model.CIStr, error) {
+	pi := tn.TableInfo.GetPartitionInfo()
+	if pi == nil {
+		return nil, dbterror.ErrPartitionMgmtOnNonpartitioned
+	}
+	if spec.OnAllPartitions {
+		return nil, nil
+	}
+	names := make([]model.CIStr, 0, len(spec.PartitionNames))
+	for _, name := range spec.PartitionNames {
+		if pi.FindPartitionDefinitionByName(name.L) < 0 {
+			return nil, table.ErrUnknownPartition.GenWithStackByArgs(name.O, tn.Name.O)
+		}
+		if !slices.ContainsFunc(names, func(n model.CIStr) bool { return n.L == name.L }) {
+			names = append(names, name)
+		}
+	}
+	return names, nil
+}
+
+func (b *PlanBuilder) appendAlterTableVisitInfo(tn *ast.TableName) {
+	var authErr error
+	if b.ctx.GetSessionVars().User != nil {
+		authErr = plannererrors.ErrTableaccessDenied.GenWithStackByArgs("ALTER", b.ctx.GetSessionVars().User.AuthUsername,
+			b.ctx.GetSessionVars().User.AuthHostname, tn.Name.L)
+	}
+	b.visitInfo = appendVisitInfo(b.visitInfo, mysql.AlterPriv, tn.Schema.L, tn.Name.L, "", authErr)
+}
+
+// buildCheckPartition builds a plan for the "ALTER TABLE [NAME] CHECK PARTITION ..." statement.
+// The partitions are checked in the same way as ADMIN CHECK TABLE.
+func (b *PlanBuilder) buildCheckPartition(ctx context.Context, v *ast.AlterTableStmt) (base.Plan, error) {
+	b.appendAlterTableVisitInfo(v.Table)
+	partitionNames, err := checkMaintainedPartitions(v.Table, v.Specs[0])
+	if err != nil {
+		return nil, err
+	}
+	tableInfo := v.Table.TableInfo
+	tbl, ok := b.is.TableByID(tableInfo.ID)
+	if !ok {
+		return nil, infoschema.ErrTableNotExists.FastGenByArgs(v.Table.Schema.O, tableInfo.Name.O)
+	}
+	readerPlans, indexInfos, err := b.buildPhysicalIndexLookUpReaders(ctx, v.Table.Schema, tbl, tbl.Indices(), partitionNames)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	readers := make([]*PhysicalIndexLookUpReader, 0, len(readerPlans))
+	for _, plan := range readerPlans {
+		readers = append(readers, plan.(*PhysicalIndexLookUpReader))
+	}
+	return &CheckTable{
+		DBName:             v.Table.Schema.O,
+		Table:              tbl,
+		IndexInfos:         indexInfos,
+		IndexLookUpReaders: readers,
+		PartitionNames:     partitionNames,
+	}, nil
+}
+
+// buildOptimizePartition builds a plan for the "ALTER TABLE [NAME] OPTIMIZE PARTITION ..." statement.
+func (b *PlanBuilder) buildOptimizePartition(v *ast.AlterTableStmt) (base.Plan, error) {
+	b.appendAlterTableVisitInfo(v.Table)
+	partitionNames, err := checkMaintainedPartitions(v.Table, v.Specs[0])
+	if err != nil {
+		return nil, err
+	}
+	analyze, err := b.buildAnalyze(&ast.AnalyzeTableStmt{
+		TableNames:     []*ast.TableName{v.Table},
+		PartitionNames: partitionNames,
+	})
+	if err != nil {
+		return nil, err
+	}
+	plan := &OptimizePartition{Analyze: analyze.(*Analyze)}
+	// The partitions of a table without TiFlash replicas are only analyzed.
+	if replica := v.Table.TableInfo.TiFlashReplica; replica != nil && replica.Count > 0 {
+		plan.Compact = &CompactTable{
+			ReplicaKind:    ast.CompactReplicaKindTiFlash,
+			TableInfo:      v.Table.TableInfo,
+			PartitionNames: partitionNames,
+		}
+	}
+	return plan, nil
+}
+
 func (b *PlanBuilder) buildCheckIndexSchema(tn *ast.TableName, indexName string) (*expression.Schema, types.NameSlice, error) {
 	schema := expression.NewSchema()
 	var names types.NameSlice
@@ -2191,7 +2319,8 @@ func getModifiedIndexesInfoForAnalyze(
 	idxsInfo := make([]*model.IndexInfo, 0, len(tblInfo.Indices))
 	independentIdxsInfo := make([]*model.IndexInfo, 0)
 	for _, originIdx := range tblInfo.Indices {
-		if originIdx.State != model.StatePublic {
+		// The fulltext and spatial indexes have no statistics since their keys are the tokens and cells instead of the column values.
+		if originIdx.State != model.StatePublic || originIdx.IsFulltextIndex() || originIdx.IsSpatialIndex() {
 			continue
 		}
 		if originIdx.MVIndex {
@@ -2257,11 +2386,8 @@ func (b *PlanBuilder) buildAnalyzeFullSamplingTask(
 	version int,
 	persistOpts bool,
 ) error {
-	// Version 2 doesn't support incremental analyze.
-	// And incremental analyze will be deprecated in the future.
-	if as.Incremental {
-		b.ctx.GetSessionVars().StmtCtx.AppendWarning(errors.NewNoStackErrorf("The version 2 stats would ignore the INCREMENTAL keyword and do full sampling"))
-	}
+	// The auto analyze is executed as a restricted SQL, it's incremental if tidb_enable_auto_analyze_incremental is on.
+	incremental := as.Incremental || (b.ctx.GetSessionVars().InRestrictedSQL && variable.EnableAutoAnalyzeIncremental.Load())
 
 	astOpts, err := handleAnalyzeOptionsV2(as.AnalyzeOpts)
 	if err != nil {
@@ -2304,6 +2430,7 @@ func (b *PlanBuilder) buildAnalyzeFullSamplingTask(
 			PartitionName: partitionNames[i],
 			TableID:       statistics.AnalyzeTableID{TableID: tbl.TableInfo.ID, PartitionID: id},
 			StatsVersion:  version,
+			Incremental:   incremental,
 		}
 		if optsV2, ok := optionsMap[physicalID]; ok {
 			info.V2Options = &optsV2
@@ -2571,6 +2698,14 @@ func (b *PlanBuilder) buildAnalyzeTable(as *ast.AnalyzeTableStmt, opts map[ast.A
 				b.ctx.GetSessionVars().StmtCtx.AppendWarning(errors.NewNoStackErrorf("analyzing multi-valued indexes is not supported, skip %s", idx.Name.L))
 				continue
 			}
+			if idx.IsFulltextIndex() {
+				b.ctx.GetSessionVars().StmtCtx.AppendWarning(errors.NewNoStackErrorf("analyzing fulltext indexes is not supported, skip %s", idx.Name.L))
+				continue
+			}
+			if idx.IsSpatialIndex() {
+				b.ctx.GetSessionVars().StmtCtx.AppendWarning(errors.NewNoStackErrorf("analyzing spatial indexes is not supported, skip %s", idx.Name.L))
+				continue
+			}
 			p.IdxTasks = append(p.IdxTasks, generateIndexTasks(idx, as, tbl.TableInfo, partitionNames, physicalIDs, version)...)
 		}
 		handleCols := BuildHandleColsForAnalyze(b.ctx, tbl.TableInfo, true, nil)
@@ -2647,6 +2782,14 @@ func (b *PlanBuilder) buildAnalyzeIndex(as *ast.AnalyzeTableStmt, opts map[ast.A
 			b.ctx.GetSessionVars().StmtCtx.AppendWarning(errors.NewNoStackErrorf("analyzing multi-valued indexes is not supported, skip %s", idx.Name.L))
 			continue
 		}
+		if idx.IsFulltextIndex() {
+			b.ctx.GetSessionVars().StmtCtx.AppendWarning(errors.NewNoStackErrorf("analyzing fulltext indexes is not supported, skip %s", idx.Name.L))
+			continue
+		}
+		if idx.IsSpatialIndex() {
+			b.ctx.GetSessionVars().StmtCtx.AppendWarning(errors.NewNoStackErrorf("analyzing spatial indexes is not supported, skip %s", idx.Name.L))
+			continue
+		}
 		p.IdxTasks = append(p.IdxTasks, generateIndexTasks(idx, as, tblInfo, names, physicalIDs, version)...)
 	}
 	return p, nil
@@ -2677,6 +2820,14 @@ func (b *PlanBuilder) buildAnalyzeAllIndex(as *ast.AnalyzeTableStmt, opts map[as
 				b.ctx.GetSessionVars().StmtCtx.AppendWarning(errors.NewNoStackErrorf("analyzing multi-valued indexes is not supported, skip %s", idx.Name.L))
 				continue
 			}
+			if idx.IsFulltextIndex() {
+				b.ctx.GetSessionVars().StmtCtx.AppendWarning(errors.NewNoStackErrorf("analyzing fulltext indexes is not supported, skip %s", idx.Name.L))
+				continue
+			}
+			if idx.IsSpatialIndex() {
+				b.ctx.GetSessionVars().StmtCtx.AppendWarning(errors.NewNoStackErrorf("analyzing spatial indexes is not supported, skip %s", idx.Name.L))
+				continue
+			}
 
 			p.IdxTasks = append(p.IdxTasks, generateIndexTasks(idx, as, tblInfo, names, physicalIDs, version)...)
 		}
@@ -2879,10 +3030,11 @@ func (b *PlanBuilder) buildAnalyze(as *ast.AnalyzeTableStmt) (base.Plan, error)
 	if as.NoWriteToBinLog {
 		return nil, dbterror.ErrNotSupportedYet.GenWithStackByArgs("[NO_WRITE_TO_BINLOG | LOCAL]")
 	}
-	if as.Incremental {
+	statsVersion := b.ctx.GetSessionVars().AnalyzeVersion
+	// Only the version 2 stats of the whole table can be analyzed incrementally.
+	if as.Incremental && (as.IndexFlag || statsVersion != statistics.Version2) {
 		return nil, errors.Errorf("the incremental analyze feature has already been removed in TiDB v7.5.0, so this will have no effect")
 	}
-	statsVersion := b.ctx.GetSessionVars().AnalyzeVersion
 	// Require INSERT and SELECT privilege for tables.
 	b.requireInsertAndSelectPriv(as.TableNames)
 
@@ -2977,6 +3129,18 @@ func buildSplitRegionsSchema() (*expression.Schema, types.NameSlice) {
 	return schema.col2Schema(), schema.names
 }
 
+func buildRecommendIndexFields() (*expression.Schema, types.NameSlice) {
+	schema := newColumnsWithNames(7)
+	schema.Append(buildColumnWithName("", "DATABASE", mysql.TypeVarchar, 64))
+	schema.Append(buildColumnWithName("", "TABLE", mysql.TypeVarchar, 64))
+	schema.Append(buildColumnWithName("", "INDEX_NAME", mysql.TypeVarchar, 64))
+	schema.Append(buildColumnWithName("", "INDEX_COLUMNS", mysql.TypeVarchar, 256))
+	schema.Append(buildColumnWithName("", "EST_COST_IMPROVEMENT", mysql.TypeDouble, 22))
+	schema.Append(buildColumnWithName("", "AFFECTED_DIGESTS", mysql.TypeVarchar, 4096))
+	schema.Append(buildColumnWithName("", "CREATE_INDEX_STATEMENT", mysql.TypeVarchar, 1024))
+	return schema.col2Schema(), schema.names
+}
+
 func buildShowDDLJobQueriesFields() (*expression.Schema, types.NameSlice) {
 	schema := newColumnsWithNames(1)
 	schema.Append(buildColumnWithName("", "QUERY", mysql.TypeVarchar, 256))
@@ -3188,6 +3352,7 @@ func (b *PlanBuilder) buildShow(ctx context.Context, show *ast.ShowStmt) (base.P
 			Extended:              show.Extended,
 			Limit:                 show.Limit,
 			ImportJobID:           show.ImportJobID,
+			Procedure:             show.Procedure,
 		},
 	}.Init(b.ctx)
 	isView := false
@@ -3204,6 +3369,10 @@ func (b *PlanBuilder) buildShow(ctx context.Context, show *ast.ShowStmt) (base.P
 			p.Extractor = extractor
 			buildPattern = false
 		}
+	case ast.ShowTriggers, ast.ShowEvents:
+		if p.DBName == "" {
+			return nil, plannererrors.ErrNoDB
+		}
 	case ast.ShowCreateTable, ast.ShowCreateSequence, ast.ShowPlacementForTable, ast.ShowPlacementForPartition:
 		var err error
 		if table, err := b.is.TableByName(ctx, show.Table.Schema, show.Table.Name); err == nil {
@@ -3288,8 +3457,16 @@ func (b *PlanBuilder) buildShow(ctx context.Context, show *ast.ShowStmt) (base.P
 	np = p
 	// If we have ShowPredicateExtractor, we do not buildSelection with Pattern
 	if show.Pattern != nil && buildPattern {
+		patternCol := p.OutputNames()[0].ColName
+		if show.Tp == ast.ShowProcedureStatus || show.Tp == ast.ShowFunctionStatus {
+			// The pattern of SHOW PROCEDURE|FUNCTION STATUS matches the routine name.
+			patternCol = p.OutputNames()[1].ColName
+		} else if show.Tp == ast.ShowTriggers {
+			// The pattern of SHOW TRIGGERS matches the table name.
+			patternCol = p.OutputNames()[2].ColName
+		}
 		show.Pattern.Expr = &ast.ColumnNameExpr{
-			Name: &ast.ColumnName{Name: p.OutputNames()[0].ColName},
+			Name: &ast.ColumnName{Name: patternCol},
 		}
 		np, err = b.buildSelection(ctx, np, show.Pattern, nil)
 		if err != nil {
@@ -3457,7 +3634,64 @@ func (b *PlanBuilder) buildSimple(ctx context.Context, node ast.StmtNode) (base.
 			err := plannererrors.ErrSpecificAccessDenied.GenWithStackByArgs("SUPER or RESOURCE_GROUP_ADMIN or RESOURCE_GROUP_USER")
 			b.visitInfo = appendDynamicVisitInfo(b.visitInfo, []string{"RESOURCE_GROUP_ADMIN", "RESOURCE_GROUP_USER"}, false, err)
 		}
+	case *ast.ProcedureInfo:
+		var err error
+		if user := b.ctx.GetSessionVars().User; user != nil {
+			err = plannererrors.ErrDBaccessDenied.GenWithStackByArgs(user.AuthUsername, user.AuthHostname, raw.ProcedureName.Schema.L)
+		}
+		b.visitInfo = appendVisitInfo(b.visitInfo, mysql.CreateRoutinePriv, raw.ProcedureName.Schema.L, "", "", err)
+	case *ast.DropProcedureStmt:
+		err := b.procedureAccessDeniedErr("ALTER ROUTINE", raw.ProcedureName.Schema, raw.ProcedureName.Name)
+		b.visitInfo = appendVisitInfo(b.visitInfo, mysql.AlterRoutinePriv, raw.ProcedureName.Schema.L, "", "", err)
+	case *ast.RefreshMaterializedViewStmt:
+		// Refreshing a materialized view replaces its data.
+		var insertErr, deleteErr error
+		if user := b.ctx.GetSessionVars().User; user != nil {
+			insertErr = plannererrors.ErrTableaccessDenied.GenWithStackByArgs("INSERT", user.AuthUsername,
+				user.AuthHostname, raw.ViewName.Name.L)
+			deleteErr = plannererrors.ErrTableaccessDenied.GenWithStackByArgs("DELETE", user.AuthUsername,
+				user.AuthHostname, raw.ViewName.Name.L)
+		}
+		b.visitInfo = appendVisitInfo(b.visitInfo, mysql.InsertPriv, raw.ViewName.Schema.L,
+			raw.ViewName.Name.L, "", insertErr)
+		b.visitInfo = appendVisitInfo(b.visitInfo, mysql.DeletePriv, raw.ViewName.Schema.L,
+			raw.ViewName.Name.L, "", deleteErr)
+	}
+	return p, nil
+}
+
+func (b *PlanBuilder) procedureAccessDeniedErr(priv string, db, name model.CIStr) error {
+	user := b.ctx.GetSessionVars().User
+	if user == nil {
+		return nil
+	}
+	return plannererrors.ErrProcaccessDenied.GenWithStackByArgs(priv, user.AuthUsername, user.AuthHostname, db.L+"."+name.L)
+}
+
+func (b *PlanBuilder) buildCallProcedure(ctx context.Context, v *ast.CallStmt) (base.Plan, error) {
+	dbName := v.Procedure.Schema
+	if dbName.L == "" {
+		dbName = model.NewCIStr(b.ctx.GetSessionVars().CurrentDB)
+		if dbName.L == "" {
+			return nil, plannererrors.ErrNoDB
+		}
+	}
+	p := &CallProcedure{
+		DBName:   dbName,
+		ProcName: v.Procedure.FnName,
+		Args:     v.Procedure.Args,
+		Params:   make([]expression.Expression, 0, len(v.Procedure.Args)),
 	}
+	mockTablePlan := LogicalTableDual{}.Init(b.ctx, b.getSelectOffset())
+	for _, arg := range v.Procedure.Args {
+		expr, _, err := b.rewrite(ctx, arg, mockTablePlan, nil, true)
+		if err != nil {
+			return nil, err
+		}
+		p.Params = append(p.Params, expr)
+	}
+	err := b.procedureAccessDeniedErr("EXECUTE", dbName, v.Procedure.FnName)
+	b.visitInfo = appendVisitInfo(b.visitInfo, mysql.ExecutePriv, dbName.L, "", "", err)
 	return p, nil
 }
 
@@ -3684,6 +3918,13 @@ func (b *PlanBuilder) buildInsert(ctx context.Context, insert *ast.InsertStmt) (
 		}
 		return nil, err
 	}
+	stmtName := "INSERT"
+	if insert.IsReplace {
+		stmtName = "REPLACE"
+	}
+	if err := b.checkMViewTableWrite(tableInfo, stmtName); err != nil {
+		return nil, err
+	}
 	// Build Schema with DBName otherwise ColumnRef with DBName cannot match any Column in Schema.
 	schema, names, err := expression.TableInfo2SchemaAndNames(b.ctx.GetExprCtx(), tn.Schema, tableInfo)
 	if err != nil {
@@ -4363,6 +4604,21 @@ func (*PlanBuilder) buildIndexAdvise(node *ast.IndexAdviseStmt) base.Plan {
 	return p
 }
 
+func (b *PlanBuilder) buildRecommendIndex(node *ast.RecommendIndexStmt) base.Plan {
+	p := &RecommendIndexPlan{
+		Action: node.Action,
+		SQL:    node.SQL,
+	}
+	schema, names := buildRecommendIndexFields()
+	p.SetSchema(schema)
+	p.SetOutputNames(names)
+	// The workload in the statement summary contains the statements of all the users. The
+	// SELECT privileges on the tables referenced by the statements are checked by the executor.
+	err := plannererrors.ErrSpecificAccessDenied.GenWithStackByArgs("PROCESS")
+	b.visitInfo = appendVisitInfo(b.visitInfo, mysql.ProcessPriv, "", "", "", err)
+	return p
+}
+
 func (b *PlanBuilder) buildSplitRegion(node *ast.SplitRegionStmt) (base.Plan, error) {
 	if node.Table.TableInfo.TempTableType != model.TempTableNone {
 		return nil, plannererrors.ErrOptOnTemporaryTable.GenWithStackByArgs("split table")
@@ -4790,6 +5046,89 @@ func (b *PlanBuilder) buildDDL(ctx context.Context, node ast.DDLNode) (base.Plan
 			b.visitInfo = appendVisitInfo(b.visitInfo, mysql.SuperPriv, "",
 				"", "", err)
 		}
+	case *ast.CreateMaterializedViewStmt:
+		b.isCreateView = true
+		b.capFlag |= canExpandAST
+		defer func() {
+			b.capFlag &= ^canExpandAST
+			b.isCreateView = false
+		}()
+
+		plan, err := b.Build(ctx, v.Select)
+		if err != nil {
+			return nil, err
+		}
+		schema := plan.Schema()
+		names := plan.OutputNames()
+		if v.Cols == nil {
+			adjustOverlongViewColname(plan.(base.LogicalPlan))
+			v.Cols = make([]model.CIStr, len(schema.Columns))
+			for i, name := range names {
+				v.Cols[i] = name.ColName
+			}
+		}
+		if len(v.Cols) != schema.Len() {
+			return nil, dbterror.ErrViewWrongList
+		}
+		v.ColTypes = make([]*types.FieldType, 0, len(schema.Columns))
+		for _, col := range schema.Columns {
+			v.ColTypes = append(v.ColTypes, col.GetStaticType())
+		}
+		if v.RefreshMethod == model.MViewRefreshIncremental {
+			if _, err := ParseMViewAggQuery(v.Select); err != nil {
+				return nil, err
+			}
+		}
+		if user := b.ctx.GetSessionVars().User; user != nil {
+			authErr = plannererrors.ErrTableaccessDenied.GenWithStackByArgs("CREATE", user.AuthUsername,
+				user.AuthHostname, v.ViewName.Name.L)
+		}
+		b.visitInfo = appendVisitInfo(b.visitInfo, mysql.CreatePriv, v.ViewName.Schema.L,
+			v.ViewName.Name.L, "", authErr)
+	case *ast.DropMaterializedViewStmt:
+		if user := b.ctx.GetSessionVars().User; user != nil {
+			authErr = plannererrors.ErrTableaccessDenied.GenWithStackByArgs("DROP", user.AuthUsername,
+				user.AuthHostname, v.ViewName.Name.L)
+		}
+		b.visitInfo = appendVisitInfo(b.visitInfo, mysql.DropPriv, v.ViewName.Schema.L,
+			v.ViewName.Name.L, "", authErr)
+	case *ast.CreateTriggerStmt:
+		if user := b.ctx.GetSessionVars().User; user != nil {
+			authErr = plannererrors.ErrTableaccessDenied.GenWithStackByArgs("TRIGGER", user.AuthUsername,
+				user.AuthHostname, v.Table.Name.L)
+		}
+		b.visitInfo = appendVisitInfo(b.visitInfo, mysql.TriggerPriv, v.Table.Schema.L,
+			v.Table.Name.L, "", authErr)
+		if v.Definer.CurrentUser && b.ctx.GetSessionVars().User != nil {
+			v.Definer = b.ctx.GetSessionVars().User
+		}
+		if b.ctx.GetSessionVars().User != nil && v.Definer.String() != b.ctx.GetSessionVars().User.String() {
+			err := plannererrors.ErrSpecificAccessDenied.GenWithStackByArgs("SUPER")
+			b.visitInfo = appendVisitInfo(b.visitInfo, mysql.SuperPriv, "",
+				"", "", err)
+		}
+	case *ast.DropTriggerStmt:
+		// The privilege is checked against the table of the trigger. If the trigger
+		// doesn't exist, the schema level privilege is required.
+		tableName := ""
+		if tblInfo, _, ok := infoschema.FindTrigger(b.is, v.Trigger.Schema, v.Trigger.Name); ok {
+			tableName = tblInfo.Name.L
+		}
+		if user := b.ctx.GetSessionVars().User; user != nil {
+			authErr = plannererrors.ErrTableaccessDenied.GenWithStackByArgs("TRIGGER", user.AuthUsername,
+				user.AuthHostname, tableName)
+		}
+		b.visitInfo = appendVisitInfo(b.visitInfo, mysql.TriggerPriv, v.Trigger.Schema.L,
+			tableName, "", authErr)
+	case *ast.CreateEventStmt:
+		b.appendEventVisitInfo(v.EventName.Schema.L, v.Definer)
+	case *ast.AlterEventStmt:
+		b.appendEventVisitInfo(v.EventName.Schema.L, v.Definer)
+		if v.RenameTo != nil && v.RenameTo.Schema.L != v.EventName.Schema.L {
+			b.appendEventVisitInfo(v.RenameTo.Schema.L, nil)
+		}
+	case *ast.DropEventStmt:
+		b.appendEventVisitInfo(v.EventName.Schema.L, nil)
 	case *ast.CreateSequenceStmt:
 		if b.ctx.GetSessionVars().User != nil {
 			authErr = plannererrors.ErrTableaccessDenied.GenWithStackByArgs("CREATE", b.ctx.GetSessionVars().User.AuthUsername,
@@ -4930,6 +5269,27 @@ func (b *PlanBuilder) buildDDL(ctx context.Context, node ast.DDLNode) (base.Plan
 	return p, nil
 }
 
+// appendEventVisitInfo requires the EVENT privilege on the schema of an event. The current user
+// is filled as the definer if it's not specified, and SUPER is required to specify another definer.
+func (b *PlanBuilder) appendEventVisitInfo(schema string, definer *auth.UserIdentity) {
+	user := b.ctx.GetSessionVars().User
+	var authErr error
+	if user != nil {
+		authErr = plannererrors.ErrDBaccessDenied.GenWithStackByArgs(user.AuthUsername, user.AuthHostname, schema)
+	}
+	b.visitInfo = appendVisitInfo(b.visitInfo, mysql.EventPriv, schema, "", "", authErr)
+	if definer == nil || user == nil {
+		return
+	}
+	if definer.CurrentUser {
+		*definer = auth.UserIdentity{Username: user.AuthUsername, Hostname: user.AuthHostname}
+	}
+	if definer.Username != user.AuthUsername || definer.Hostname != user.AuthHostname {
+		err := plannererrors.ErrSpecificAccessDenied.GenWithStackByArgs("SUPER")
+		b.visitInfo = appendVisitInfo(b.visitInfo, mysql.SuperPriv, "", "", "", err)
+	}
+}
+
 const (
 	// TraceFormatRow indicates row tracing format.
 	TraceFormatRow = "row"
@@ -5165,6 +5525,10 @@ func buildShowSchema(s *ast.ShowStmt, isView bool, isSequence bool) (schema *exp
 		ftypes = []byte{mysql.TypeVarchar, mysql.TypeLonglong, mysql.TypeVarchar, mysql.TypeVarchar, mysql.TypeVarchar}
 	case ast.ShowProcedureStatus, ast.ShowFunctionStatus:
 		return buildShowProcedureSchema()
+	case ast.ShowCreateProcedure:
+		names = []string{"Procedure", "sql_mode", "Create Procedure", "character_set_client", "collation_connection", "Database Collation"}
+	case ast.ShowCreateEvent:
+		names = []string{"Event", "sql_mode", "time_zone", "Create Event", "character_set_client", "collation_connection", "Database Collation"}
 	case ast.ShowTriggers:
 		return buildShowTriggerSchema()
 	case ast.ShowEvents:
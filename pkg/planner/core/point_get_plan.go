@@ -92,6 +92,7 @@ type PointGetPlan struct {
 	Lock             bool
 	outputNames      []*types.FieldName
 	LockWaitTime     int64
+	SkipLocked       bool
 	Columns          []*model.ColumnInfo
 	cost             float64
 
@@ -432,6 +433,7 @@ type BatchPointGetPlan struct {
 	Desc          bool
 	Lock          bool
 	LockWaitTime  int64
+	SkipLocked    bool
 	Columns       []*model.ColumnInfo
 	cost          float64
 
@@ -898,6 +900,7 @@ func TryFastPlan(ctx base.PlanContext, node ast.Node) (p base.Plan) {
 				return nil
 			}
 			fp.Lock, fp.LockWaitTime = getLockWaitTime(ctx, x.LockInfo)
+			fp.SkipLocked = fp.Lock && IsSelectSkipLockedLockType(x.LockInfo.LockType)
 			p = fp
 			return
 		}
@@ -916,6 +919,7 @@ func TryFastPlan(ctx base.PlanContext, node ast.Node) (p base.Plan) {
 				return
 			}
 			fp.Lock, fp.LockWaitTime = getLockWaitTime(ctx, x.LockInfo)
+			fp.SkipLocked = fp.Lock && IsSelectSkipLockedLockType(x.LockInfo.LockType)
 			p = fp
 			return
 		}
@@ -932,12 +936,18 @@ func IsSelectForUpdateLockType(lockType ast.SelectLockType) bool {
 	if lockType == ast.SelectLockForUpdate ||
 		lockType == ast.SelectLockForShare ||
 		lockType == ast.SelectLockForUpdateNoWait ||
-		lockType == ast.SelectLockForUpdateWaitN {
+		lockType == ast.SelectLockForUpdateWaitN ||
+		IsSelectSkipLockedLockType(lockType) {
 		return true
 	}
 	return false
 }
 
+// IsSelectSkipLockedLockType checks if the select lock type skips the rows locked by others.
+func IsSelectSkipLockedLockType(lockType ast.SelectLockType) bool {
+	return lockType == ast.SelectLockForUpdateSkipLocked || lockType == ast.SelectLockForShareSkipLocked
+}
+
 func getLockWaitTime(ctx base.PlanContext, lockInfo *ast.SelectLockInfo) (lock bool, waitTime int64) {
 	if lockInfo != nil {
 		if IsSelectForUpdateLockType(lockInfo.LockType) {
@@ -952,7 +962,8 @@ func getLockWaitTime(ctx base.PlanContext, lockInfo *ast.SelectLockInfo) (lock b
 				waitTime = sessVars.LockWaitTimeout
 				if lockInfo.LockType == ast.SelectLockForUpdateWaitN {
 					waitTime = int64(lockInfo.WaitSec * 1000)
-				} else if lockInfo.LockType == ast.SelectLockForUpdateNoWait {
+				} else if lockInfo.LockType == ast.SelectLockForUpdateNoWait || IsSelectSkipLockedLockType(lockInfo.LockType) {
+					// SKIP LOCKED doesn't wait for the locks either, the rows fail to be locked are skipped.
 					waitTime = tikvstore.LockNoWait
 				}
 			}
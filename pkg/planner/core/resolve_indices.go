@@ -263,6 +263,17 @@ func (p *PhysicalIndexJoin) ResolveIndices() (err error) {
 	if err != nil {
 		return err
 	}
+	if hj := p.HashJoinAlternative; hj != nil {
+		// The probe side of the hash join shares the outer child with the index join, which may have been
+		// changed after the hash join is prepared.
+		hj.children[1-hj.InnerChildIdx] = p.children[1-p.InnerChildIdx]
+		if err = hj.children[hj.InnerChildIdx].ResolveIndices(); err != nil {
+			return err
+		}
+		if err = hj.ResolveIndicesItself(); err != nil {
+			return err
+		}
+	}
 	lSchema := p.children[0].Schema()
 	rSchema := p.children[1].Schema()
 	for i := range p.InnerJoinKeys {
@@ -269,6 +269,14 @@ func (s *decorrelateSolver) optimize(ctx context.Context, p base.LogicalPlan, op
 				return s.optimize(ctx, p, opt)
 			}
 		} else if agg, ok := innerPlan.(*LogicalAggregation); ok {
+			noJoinConds := len(apply.EqualConditions)+len(apply.LeftConditions)+len(apply.RightConditions)+len(apply.OtherConditions) == 0
+			scalarAgg := len(agg.GroupByItems) == 0
+			// The aggregation without GROUP BY returns exactly one row for every outer row, e.g. the LATERAL
+			// derived table `(select count(*) from t2 where t2.a = t1.a)`, so the inner join is the same as the outer join.
+			if apply.Lateral && apply.JoinType == InnerJoin && scalarAgg && noJoinConds {
+				apply.JoinType = LeftOuterJoin
+				resetNotNullFlag(apply.Schema(), outerPlan.Schema().Len(), apply.Schema().Len())
+			}
 			if apply.canPullUpAgg() && agg.canPullUp() {
 				innerPlan = agg.Children()[0]
 				apply.JoinType = LeftOuterJoin
@@ -327,8 +335,13 @@ func (s *decorrelateSolver) optimize(ctx context.Context, p base.LogicalPlan, op
 				return agg, planChanged, nil
 			}
 			// We can pull up the equal conditions below the aggregation as the join key of the apply, if only
-			// the equal conditions contain the correlated column of this apply.
-			if sel, ok := agg.Children()[0].(*LogicalSelection); ok && apply.JoinType == LeftOuterJoin {
+			// the equal conditions contain the correlated column of this apply. The LATERAL derived table may be
+			// joined by an inner join, and its ON conditions are kept on the apply, so they must be empty.
+			canPullUpEqConds := apply.JoinType == LeftOuterJoin
+			if apply.Lateral {
+				canPullUpEqConds = noJoinConds && (apply.JoinType == LeftOuterJoin || apply.JoinType == InnerJoin)
+			}
+			if sel, ok := agg.Children()[0].(*LogicalSelection); ok && canPullUpEqConds {
 				var (
 					eqCondWithCorCol []*expression.ScalarFunction
 					remainedExpr     []expression.Expression
@@ -379,6 +392,11 @@ func (s *decorrelateSolver) optimize(ctx context.Context, p base.LogicalPlan, op
 							appendRemoveSelectionTraceStep(agg, sel, opt)
 						}
 						defaultValueMap := s.aggDefaultValueMap(agg)
+						// The group of a LATERAL derived table which doesn't exist is NULL rather than the default
+						// value if there's GROUP BY, and it's filtered out by the inner join.
+						if apply.Lateral && (apply.JoinType != LeftOuterJoin || !scalarAgg) {
+							defaultValueMap = nil
+						}
 						// We should use it directly, rather than building a projection.
 						if len(defaultValueMap) > 0 {
 							proj := LogicalProjection{}.Init(agg.SCtx(), agg.QueryBlockOffset())
@@ -116,6 +116,34 @@ func (rf *RuntimeFilter) assign(targetNode *PhysicalTableScan, targetExpr *expre
 		zap.String("RuntimeFilter", rf.String()))
 }
 
+// ID returns the id of the runtime filter.
+func (rf *RuntimeFilter) ID() int {
+	return rf.id
+}
+
+// Type returns the type of the runtime filter.
+func (rf *RuntimeFilter) Type() RuntimeFilterType {
+	return rf.rfType
+}
+
+// SrcColumn returns the join key of the build side which the runtime filter is built from.
+func (rf *RuntimeFilter) SrcColumn() *expression.Column {
+	return rf.srcExprList[0]
+}
+
+// TargetColumn returns the column of the target table scan which the runtime filter is applied to.
+func (rf *RuntimeFilter) TargetColumn() *expression.Column {
+	return rf.targetExprList[0]
+}
+
+// TargetNodeID returns the plan id of the table scan which the runtime filter is applied to.
+func (rf *RuntimeFilter) TargetNodeID() int {
+	if rf.targetNode == nil {
+		return rf.targetNodeID
+	}
+	return rf.targetNode.ID()
+}
+
 // ExplainInfo explain info of runtime filter
 func (rf *RuntimeFilter) ExplainInfo(isBuildNode bool) string {
 	var builder strings.Builder
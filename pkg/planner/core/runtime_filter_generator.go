@@ -21,6 +21,7 @@ import (
 	"github.com/pingcap/tidb/pkg/parser/mysql"
 	"github.com/pingcap/tidb/pkg/planner/core/base"
 	"github.com/pingcap/tidb/pkg/sessionctx/variable"
+	"github.com/pingcap/tidb/pkg/types"
 	"github.com/pingcap/tidb/pkg/util"
 	"github.com/pingcap/tidb/pkg/util/logutil"
 	"go.uber.org/zap"
@@ -59,7 +60,11 @@ PhysicalPlanTree:
 func (generator *RuntimeFilterGenerator) GenerateRuntimeFilter(plan base.PhysicalPlan) {
 	switch physicalPlan := plan.(type) {
 	case *PhysicalHashJoin:
-		generator.generateRuntimeFilterInterval(physicalPlan)
+		if physicalPlan.storeTp == kv.TiFlash {
+			generator.generateRuntimeFilterInterval(physicalPlan)
+		} else {
+			generator.generateRootRuntimeFilter(physicalPlan)
+		}
 	case *PhysicalTableScan:
 		generator.assignRuntimeFilter(physicalPlan)
 	case *PhysicalTableReader:
@@ -107,6 +112,145 @@ func (generator *RuntimeFilterGenerator) generateRuntimeFilterInterval(hashJoinP
 	}
 }
 
+// generateRootRuntimeFilter generates the runtime filters of a hash join executed in TiDB.
+// The executor builds the filters from the build side, and pushes them down to the coprocessor requests of
+// the TiKV table scan on the probe side before the scan starts. For example:
+/*
+PhysicalPlanTree:
+          HashJoin_1 (with RF1)
+         /                   \
+    HashJoin_2 (with RF2)    TableReader(dim2)
+     /             \
+TableReader(fact)  TableReader(dim1)
+    |
+Selection (runtime filter)
+    |
+TableScan (assign RF1, RF2)
+*/
+func (generator *RuntimeFilterGenerator) generateRootRuntimeFilter(hashJoinPlan *PhysicalHashJoin) {
+	if !generator.matchRFJoinType(hashJoinPlan) {
+		return
+	}
+	probeChild := hashJoinPlan.children[0]
+	if !hashJoinPlan.RightIsBuildSide() {
+		probeChild = hashJoinPlan.children[1]
+	}
+	ectx := hashJoinPlan.SCtx().GetExprCtx().GetEvalCtx()
+	for _, eqPredicate := range hashJoinPlan.EqualConditions {
+		if !generator.matchEQPredicate(ectx, eqPredicate, hashJoinPlan.RightIsBuildSide()) {
+			continue
+		}
+		targetColumn, srcColumn := eqPredicate.GetArgs()[0].(*expression.Column), eqPredicate.GetArgs()[1].(*expression.Column)
+		if !hashJoinPlan.RightIsBuildSide() {
+			targetColumn, srcColumn = srcColumn, targetColumn
+		}
+		if !matchRootRFColumnType(srcColumn, targetColumn) {
+			continue
+		}
+		reader, scan, scanColumn := findRootRFTarget(probeChild, targetColumn)
+		if reader == nil {
+			continue
+		}
+		newRFList, _ := NewRuntimeFilter(generator.rfIDGenerator, eqPredicate, hashJoinPlan)
+		for _, rf := range newRFList {
+			rf.rfMode = variable.RFLocal
+			rf.assign(scan, scanColumn)
+		}
+		supplyRFSelection(reader)
+	}
+}
+
+// matchRootRFColumnType checks whether the values of the src column can be compared with the target
+// column by the coprocessor in the same way as the hash join.
+func matchRootRFColumnType(srcColumn, targetColumn *expression.Column) bool {
+	srcType, targetType := srcColumn.GetStaticType(), targetColumn.GetStaticType()
+	if targetType.Hybrid() || targetType.IsArray() || srcType.EvalType() != targetType.EvalType() {
+		return false
+	}
+	switch srcType.EvalType() {
+	case types.ETInt:
+		return mysql.HasUnsignedFlag(srcType.GetFlag()) == mysql.HasUnsignedFlag(targetType.GetFlag())
+	case types.ETString:
+		return srcType.GetCollate() == targetType.GetCollate()
+	}
+	return true
+}
+
+// findRootRFTarget finds the TiKV table reader on the probe side of a hash join, whose table scan
+// outputs the target column. The runtime filter is only pushed through the operators which filter
+// their input rows independently.
+func findRootRFTarget(plan base.PhysicalPlan, targetColumn *expression.Column) (*PhysicalTableReader, *PhysicalTableScan, *expression.Column) {
+	switch x := plan.(type) {
+	case *PhysicalSelection:
+		return findRootRFTarget(x.children[0], targetColumn)
+	case *PhysicalProjection:
+		idx := x.schema.ColumnIndex(targetColumn)
+		if idx < 0 {
+			return nil, nil, nil
+		}
+		col, ok := x.Exprs[idx].(*expression.Column)
+		if !ok {
+			return nil, nil, nil
+		}
+		return findRootRFTarget(x.children[0], col)
+	case *PhysicalHashJoin:
+		for i, child := range x.children {
+			if !child.Schema().Contains(targetColumn) {
+				continue
+			}
+			preserved := false
+			switch x.JoinType {
+			case InnerJoin:
+				preserved = true
+			case LeftOuterJoin, SemiJoin, AntiSemiJoin, LeftOuterSemiJoin, AntiLeftOuterSemiJoin:
+				preserved = i == 0
+			case RightOuterJoin:
+				preserved = i == 1
+			}
+			if !preserved {
+				return nil, nil, nil
+			}
+			return findRootRFTarget(child, targetColumn)
+		}
+	case *PhysicalTableReader:
+		if x.StoreType != kv.TiKV || !x.schema.Contains(targetColumn) {
+			return nil, nil, nil
+		}
+		scan, ok := x.TablePlans[0].(*PhysicalTableScan)
+		if !ok {
+			return nil, nil, nil
+		}
+		// The filter can't be evaluated before the limit, aggregation or TopN pushed down.
+		for _, p := range x.TablePlans[1:] {
+			if _, ok := p.(*PhysicalSelection); !ok {
+				return nil, nil, nil
+			}
+		}
+		scanColumn := scan.schema.RetrieveColumn(targetColumn)
+		if scanColumn == nil || scanColumn.VirtualExpr != nil {
+			return nil, nil, nil
+		}
+		return x, scan, scanColumn
+	}
+	return nil, nil, nil
+}
+
+// supplyRFSelection makes sure there is a Selection above the table scan of the reader, the executor
+// adds the conditions of the runtime filters to it.
+func supplyRFSelection(reader *PhysicalTableReader) {
+	if sel, ok := reader.tablePlan.(*PhysicalSelection); ok {
+		sel.hasRFConditions = true
+		return
+	}
+	scan := reader.tablePlan
+	// StatsInfo: Just set a placeholder value here, and this value will not be used in subsequent optimizations
+	sel := PhysicalSelection{hasRFConditions: true}.Init(scan.SCtx(), scan.StatsInfo(), scan.QueryBlockOffset())
+	sel.fromDataSource = true
+	sel.SetChildren(scan)
+	reader.tablePlan = sel
+	reader.TablePlans = flattenPushDownPlan(sel)
+}
+
 func (generator *RuntimeFilterGenerator) assignRuntimeFilter(physicalTableScan *PhysicalTableScan) {
 	// match rf for current scan node
 	cacheBuildNodeIDToRFMode := map[int]RuntimeFilterMode{}
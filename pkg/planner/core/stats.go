@@ -35,6 +35,7 @@ import (
 	"github.com/pingcap/tidb/pkg/planner/util/debugtrace"
 	"github.com/pingcap/tidb/pkg/sessionctx"
 	"github.com/pingcap/tidb/pkg/sessionctx/stmtctx"
+	"github.com/pingcap/tidb/pkg/sessionctx/variable"
 	"github.com/pingcap/tidb/pkg/statistics"
 	"github.com/pingcap/tidb/pkg/statistics/asyncload"
 	"github.com/pingcap/tidb/pkg/table"
@@ -111,6 +112,26 @@ func (ds *DataSource) getGroupNDVs(colGroups [][]*expression.Column) []property.
 		}
 		return false
 	})
+	// Use the extended stats for the column groups not matched by any index.
+	for _, g := range colGroups {
+		if slices.ContainsFunc(ndvs, func(ndv property.GroupNDV) bool {
+			return slices.EqualFunc(ndv.Cols, g, func(id int64, col *expression.Column) bool { return id == col.UniqueID })
+		}) {
+			continue
+		}
+		ndv, ok := cardinality.EstimateColsNDVByExtendedStats(tbl, ds.TableStats.ColNDVs, ds.TableStats.RowCount, g)
+		if !ok {
+			continue
+		}
+		cols := make([]int64, 0, len(g))
+		for _, col := range g {
+			cols = append(cols, col.UniqueID)
+		}
+		ndvs = append(ndvs, property.GroupNDV{
+			Cols: cols,
+			NDV:  ndv,
+		})
+	}
 	return ndvs
 }
 
@@ -154,6 +175,12 @@ func (ds *DataSource) initStats(colGroups [][]*expression.Column) {
 	if ds.StatisticTable.Pseudo {
 		tableStats.StatsVersion = statistics.PseudoVersion
 	}
+	if ds.SCtx().GetSessionVars().EnableExtendedStats {
+		tableStats.HistColl.ExtendedStats = ds.StatisticTable.ExtendedStats
+	}
+	if variable.EnableExpressionStats.Load() {
+		tableStats.HistColl.ExpressionStats = ds.StatisticTable.ExpressionStats
+	}
 
 	statsRecord := ds.SCtx().GetSessionVars().StmtCtx.GetUsedStatsInfo(true)
 	name, tblInfo := getTblInfoForUsedStatsByPhysicalID(ds.SCtx(), ds.PhysicalTableID)
@@ -398,6 +425,7 @@ func (ds *DataSource) DeriveStats(_ []*property.StatsInfo, _ *expression.Schema,
 	// TODO: Can we move ds.deriveStatsByFilter after pruning by heuristics? In this way some computation can be avoided
 	// when ds.PossibleAccessPaths are pruned.
 	ds.SetStats(ds.deriveStatsByFilter(ds.PushedDownConds, ds.PossibleAccessPaths))
+	ds.applyCardinalityFeedback()
 	err := ds.derivePathStatsAndTryHeuristics()
 	if err != nil {
 		return nil, err
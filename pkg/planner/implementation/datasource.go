@@ -18,10 +18,10 @@ import (
 	"math"
 
 	"github.com/pingcap/tidb/pkg/expression"
-	"github.com/pingcap/tidb/pkg/kv"
 	"github.com/pingcap/tidb/pkg/parser/model"
 	"github.com/pingcap/tidb/pkg/planner/cardinality"
 	plannercore "github.com/pingcap/tidb/pkg/planner/core"
+	"github.com/pingcap/tidb/pkg/planner/core/base"
 	"github.com/pingcap/tidb/pkg/planner/memo"
 	"github.com/pingcap/tidb/pkg/statistics"
 )
@@ -102,6 +102,23 @@ func (impl *TableReaderImpl) GetCostLimit(costLimit float64, _ ...memo.Implement
 	return costLimit * copIterWorkers
 }
 
+// MPPTableReaderImpl implementation of PhysicalTableReader which reads from TiFlash by MPP.
+type MPPTableReaderImpl struct {
+	TableReaderImpl
+}
+
+// NewMPPTableReaderImpl creates a new MPP table reader Implementation.
+func NewMPPTableReaderImpl(reader *plannercore.PhysicalTableReader, source *plannercore.DataSource) *MPPTableReaderImpl {
+	return &MPPTableReaderImpl{TableReaderImpl: *NewTableReaderImpl(reader, source)}
+}
+
+// AttachChildren implements Implementation AttachChildren interface.
+func (impl *MPPTableReaderImpl) AttachChildren(children ...memo.Implementation) memo.Implementation {
+	reader := impl.plan.(*plannercore.PhysicalTableReader)
+	reader.SetMPPTablePlan(children[0].GetPlan())
+	return impl
+}
+
 // TableScanImpl implementation of PhysicalTableScan.
 type TableScanImpl struct {
 	baseImpl
@@ -124,7 +141,7 @@ func NewTableScanImpl(ts *plannercore.PhysicalTableScan, cols []*expression.Colu
 // CalcCost calculates the cost of the table scan Implementation.
 func (impl *TableScanImpl) CalcCost(outCount float64, _ ...memo.Implementation) float64 {
 	ts := impl.plan.(*plannercore.PhysicalTableScan)
-	width := cardinality.GetTableAvgRowSize(impl.plan.SCtx(), impl.tblColHists, impl.tblCols, kv.TiKV, true)
+	width := cardinality.GetTableAvgRowSize(impl.plan.SCtx(), impl.tblColHists, impl.tblCols, ts.StoreType, true)
 	sessVars := ts.SCtx().GetSessionVars()
 	impl.cost = outCount * sessVars.GetScanFactor(ts.Table) * width
 	if ts.Desc {
@@ -199,3 +216,19 @@ func NewIndexScanImpl(scan *plannercore.PhysicalIndexScan, tblColHists *statisti
 		tblColHists: tblColHists,
 	}
 }
+
+// IndexMergeReaderImpl is the Implementation of PhysicalIndexMergeReader.
+type IndexMergeReaderImpl struct {
+	baseImpl
+}
+
+// NewIndexMergeReaderImpl creates a new IndexMergeReader Implementation. The plan is a complete
+// plan which reads the rows by the index merge, so its cost is calculated in advance.
+func NewIndexMergeReaderImpl(plan base.PhysicalPlan, cost float64) *IndexMergeReaderImpl {
+	return &IndexMergeReaderImpl{baseImpl{plan: plan, cost: cost}}
+}
+
+// CalcCost implements Implementation interface.
+func (impl *IndexMergeReaderImpl) CalcCost(_ float64, _ ...memo.Implementation) float64 {
+	return impl.cost
+}
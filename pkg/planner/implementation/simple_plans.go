@@ -81,6 +81,23 @@ func NewTiKVSelectionImpl(sel *plannercore.PhysicalSelection) *TiKVSelectionImpl
 	return &TiKVSelectionImpl{baseImpl{plan: sel}}
 }
 
+// TiFlashSelectionImpl is the implementation of PhysicalSelection in TiFlash layer.
+type TiFlashSelectionImpl struct {
+	baseImpl
+}
+
+// CalcCost implements Implementation CalcCost interface.
+func (sel *TiFlashSelectionImpl) CalcCost(_ float64, children ...memo.Implementation) float64 {
+	sel.cost = children[0].GetPlan().StatsInfo().RowCount*
+		sel.plan.SCtx().GetSessionVars().GetCopCPUFactor() + children[0].GetCost()
+	return sel.cost
+}
+
+// NewTiFlashSelectionImpl creates a new TiFlashSelectionImpl.
+func NewTiFlashSelectionImpl(sel *plannercore.PhysicalSelection) *TiFlashSelectionImpl {
+	return &TiFlashSelectionImpl{baseImpl{plan: sel}}
+}
+
 // TiDBHashAggImpl is the implementation of PhysicalHashAgg in TiDB layer.
 type TiDBHashAggImpl struct {
 	baseImpl
@@ -0,0 +1,369 @@
+// Copyright 2024 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package indexadvisor
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/pingcap/tidb/pkg/infoschema"
+	"github.com/pingcap/tidb/pkg/parser/ast"
+	"github.com/pingcap/tidb/pkg/parser/model"
+	"github.com/pingcap/tidb/pkg/parser/mysql"
+	"github.com/pingcap/tidb/pkg/parser/opcode"
+	"github.com/pingcap/tidb/pkg/types"
+)
+
+// maxNumEqualColumns is the max number of columns in equal conditions of a table used as
+// the first column of the two-column candidates.
+const maxNumEqualColumns = 3
+
+// candidate is an index which may be recommended.
+type candidate struct {
+	dbName  model.CIStr
+	tblInfo *model.TableInfo
+	cols    []*model.ColumnInfo
+	// idxInfo is the hypothetical index used to estimate the costs.
+	idxInfo *model.IndexInfo
+}
+
+func (c *candidate) key() string {
+	var sb strings.Builder
+	sb.WriteString(c.dbName.L)
+	sb.WriteString(".")
+	sb.WriteString(c.tblInfo.Name.L)
+	for _, col := range c.cols {
+		sb.WriteString(",")
+		sb.WriteString(col.Name.L)
+	}
+	return sb.String()
+}
+
+func (c *candidate) tableKey() string {
+	return c.dbName.L + "." + c.tblInfo.Name.L
+}
+
+// tableRef is a table referenced by a query.
+type tableRef struct {
+	dbName  model.CIStr
+	tblInfo *model.TableInfo
+}
+
+func (t *tableRef) key() string {
+	return t.dbName.L + "." + t.tblInfo.Name.L
+}
+
+// indexableColumn is a column of a table used in the conditions, GROUP BY or ORDER BY of a query.
+type indexableColumn struct {
+	table *tableRef
+	col   *model.ColumnInfo
+	// equal indicates whether the column is used in an equal or IN condition.
+	equal bool
+}
+
+// queryAnalyzer collects the tables and indexable columns of a query.
+type queryAnalyzer struct {
+	is        infoschema.InfoSchema
+	defaultDB string
+
+	// tables maps the names and aliases of the tables to them.
+	tables map[string]*tableRef
+	// tableKeys is the keys of the tables referenced by the query.
+	tableKeys map[string]struct{}
+	cols      []*indexableColumn
+}
+
+func newQueryAnalyzer(is infoschema.InfoSchema, defaultDB string) *queryAnalyzer {
+	return &queryAnalyzer{
+		is:        is,
+		defaultDB: defaultDB,
+		tables:    make(map[string]*tableRef),
+		tableKeys: make(map[string]struct{}),
+	}
+}
+
+// Enter implements ast.Visitor interface.
+func (a *queryAnalyzer) Enter(n ast.Node) (ast.Node, bool) {
+	switch x := n.(type) {
+	case *ast.TableSource:
+		tn, ok := x.Source.(*ast.TableName)
+		if !ok {
+			return n, false
+		}
+		dbName := tn.Schema
+		if dbName.L == "" {
+			dbName = model.NewCIStr(a.defaultDB)
+		}
+		tblInfo, err := a.is.TableInfoByName(dbName, tn.Name)
+		if err != nil || tblInfo.IsView() || tblInfo.IsSequence() {
+			return n, true
+		}
+		ref := &tableRef{dbName: dbName, tblInfo: tblInfo}
+		a.tableKeys[ref.key()] = struct{}{}
+		if x.AsName.L != "" {
+			a.tables[x.AsName.L] = ref
+		} else {
+			a.tables[tn.Name.L] = ref
+			a.tables[dbName.L+"."+tn.Name.L] = ref
+		}
+		return n, true
+	}
+	return n, false
+}
+
+// Leave implements ast.Visitor interface.
+func (*queryAnalyzer) Leave(n ast.Node) (ast.Node, bool) {
+	return n, true
+}
+
+// analyze collects the tables and indexable columns of the statement.
+func (a *queryAnalyzer) analyze(stmt ast.StmtNode) {
+	stmt.Accept(a)
+	stmt.Accept(&selectCollector{analyzer: a})
+}
+
+// selectCollector visits the SELECT statements in a query, and collects the columns in their
+// WHERE, JOIN ON, GROUP BY and ORDER BY clauses.
+type selectCollector struct {
+	analyzer *queryAnalyzer
+}
+
+// Enter implements ast.Visitor interface.
+func (c *selectCollector) Enter(n ast.Node) (ast.Node, bool) {
+	sel, ok := n.(*ast.SelectStmt)
+	if !ok {
+		return n, false
+	}
+	cc := &columnCollector{analyzer: c.analyzer}
+	if sel.Where != nil {
+		sel.Where.Accept(cc)
+	}
+	if sel.From != nil {
+		collectJoinConditions(sel.From.TableRefs, cc)
+	}
+	if sel.GroupBy != nil {
+		for _, item := range sel.GroupBy.Items {
+			item.Expr.Accept(cc)
+		}
+	}
+	if sel.OrderBy != nil {
+		for _, item := range sel.OrderBy.Items {
+			item.Expr.Accept(cc)
+		}
+	}
+	return n, false
+}
+
+// Leave implements ast.Visitor interface.
+func (*selectCollector) Leave(n ast.Node) (ast.Node, bool) {
+	return n, true
+}
+
+func collectJoinConditions(join *ast.Join, cc *columnCollector) {
+	if join == nil {
+		return
+	}
+	if left, ok := join.Left.(*ast.Join); ok {
+		collectJoinConditions(left, cc)
+	}
+	if right, ok := join.Right.(*ast.Join); ok {
+		collectJoinConditions(right, cc)
+	}
+	if join.On != nil {
+		join.On.Expr.Accept(cc)
+	}
+}
+
+// columnCollector collects the columns in an expression. The subqueries are skipped since
+// they are visited by the selectCollector.
+type columnCollector struct {
+	analyzer *queryAnalyzer
+	// equalCols is the columns in the equal conditions visited by now.
+	equalCols map[*ast.ColumnNameExpr]struct{}
+}
+
+// Enter implements ast.Visitor interface.
+func (c *columnCollector) Enter(n ast.Node) (ast.Node, bool) {
+	switch x := n.(type) {
+	case *ast.SubqueryExpr:
+		return n, true
+	case *ast.BinaryOperationExpr:
+		if x.Op == opcode.EQ || x.Op == opcode.NullEQ {
+			c.markEqual(x.L)
+			c.markEqual(x.R)
+		}
+	case *ast.PatternInExpr:
+		if !x.Not && x.Sel == nil {
+			c.markEqual(x.Expr)
+		}
+	case *ast.ColumnNameExpr:
+		_, equal := c.equalCols[x]
+		c.analyzer.addColumn(x.Name, equal)
+		return n, true
+	}
+	return n, false
+}
+
+func (c *columnCollector) markEqual(expr ast.ExprNode) {
+	if col, ok := expr.(*ast.ColumnNameExpr); ok {
+		if c.equalCols == nil {
+			c.equalCols = make(map[*ast.ColumnNameExpr]struct{})
+		}
+		c.equalCols[col] = struct{}{}
+	}
+}
+
+// Leave implements ast.Visitor interface.
+func (*columnCollector) Leave(n ast.Node) (ast.Node, bool) {
+	return n, true
+}
+
+// addColumn resolves the column name to a column of the tables in the query.
+func (a *queryAnalyzer) addColumn(name *ast.ColumnName, equal bool) {
+	var ref *tableRef
+	var col *model.ColumnInfo
+	if name.Table.L != "" {
+		key := name.Table.L
+		if name.Schema.L != "" {
+			key = name.Schema.L + "." + key
+		}
+		ref = a.tables[key]
+		if ref == nil {
+			return
+		}
+		col = model.FindColumnInfo(ref.tblInfo.Columns, name.Name.L)
+	} else {
+		// The column without the table name is resolved only if it's unambiguous.
+		for _, t := range a.tables {
+			c := model.FindColumnInfo(t.tblInfo.Columns, name.Name.L)
+			if c == nil || t == ref {
+				continue
+			}
+			if ref != nil {
+				return
+			}
+			ref, col = t, c
+		}
+	}
+	if col == nil || col.State != model.StatePublic || !isIndexableColumn(col) {
+		return
+	}
+	for _, c := range a.cols {
+		if c.table == ref && c.col.ID == col.ID {
+			c.equal = c.equal || equal
+			return
+		}
+	}
+	a.cols = append(a.cols, &indexableColumn{table: ref, col: col, equal: equal})
+}
+
+func isIndexableColumn(col *model.ColumnInfo) bool {
+	switch col.GetType() {
+	case mysql.TypeJSON, mysql.TypeGeometry, mysql.TypeTinyBlob, mysql.TypeMediumBlob, mysql.TypeLongBlob, mysql.TypeBlob:
+		return false
+	}
+	return true
+}
+
+// candidates generates the candidate indexes from the indexable columns. A candidate is
+// either a single column, or two columns whose first one is used in an equal condition.
+func (a *queryAnalyzer) candidates() []*candidate {
+	result := make([]*candidate, 0, len(a.cols))
+	for _, c := range a.cols {
+		result = append(result, &candidate{dbName: c.table.dbName, tblInfo: c.table.tblInfo, cols: []*model.ColumnInfo{c.col}})
+	}
+	numEqualCols := make(map[*tableRef]int)
+	for _, first := range a.cols {
+		if !first.equal || numEqualCols[first.table] >= maxNumEqualColumns {
+			continue
+		}
+		numEqualCols[first.table]++
+		for _, second := range a.cols {
+			if second.table != first.table || second.col.ID == first.col.ID {
+				continue
+			}
+			result = append(result, &candidate{
+				dbName:  first.table.dbName,
+				tblInfo: first.table.tblInfo,
+				cols:    []*model.ColumnInfo{first.col, second.col},
+			})
+		}
+	}
+	return result
+}
+
+// coveredByExistingIndex checks whether the columns of the candidate are a prefix of an existing
+// index or the integer primary key, in which case the candidate is useless.
+func (c *candidate) coveredByExistingIndex() bool {
+	if c.tblInfo.PKIsHandle && mysql.HasPriKeyFlag(c.cols[0].GetFlag()) {
+		return true
+	}
+	for _, idx := range c.tblInfo.Indices {
+		if idx.State != model.StatePublic || len(idx.Columns) < len(c.cols) {
+			continue
+		}
+		covered := true
+		for i, col := range c.cols {
+			if idx.Columns[i].Offset != col.Offset || idx.Columns[i].Length != types.UnspecifiedLength {
+				covered = false
+				break
+			}
+		}
+		if covered {
+			return true
+		}
+	}
+	return false
+}
+
+// buildHypoIndex builds the hypothetical index of the candidate. The name and ID of the
+// index must not conflict with the existing indexes and the other candidates of the table.
+func (c *candidate) buildHypoIndex(idxID int64, usedNames map[string]struct{}) {
+	var sb strings.Builder
+	sb.WriteString("idx")
+	for _, col := range c.cols {
+		sb.WriteString("_")
+		sb.WriteString(col.Name.L)
+	}
+	base := sb.String()
+	if len(base) > mysql.MaxIndexIdentifierLen-4 {
+		base = base[:mysql.MaxIndexIdentifierLen-4]
+	}
+	name := base
+	for i := 1; ; i++ {
+		_, used := usedNames[strings.ToLower(name)]
+		if !used && c.tblInfo.FindIndexByName(strings.ToLower(name)) == nil {
+			break
+		}
+		name = fmt.Sprintf("%s_%d", base, i)
+	}
+	usedNames[strings.ToLower(name)] = struct{}{}
+	cols := make([]*model.IndexColumn, 0, len(c.cols))
+	for _, col := range c.cols {
+		cols = append(cols, &model.IndexColumn{
+			Name:   col.Name,
+			Offset: col.Offset,
+			Length: types.UnspecifiedLength,
+		})
+	}
+	c.idxInfo = &model.IndexInfo{
+		ID:      idxID,
+		Name:    model.NewCIStr(name),
+		Table:   c.tblInfo.Name,
+		Columns: cols,
+		State:   model.StatePublic,
+		Tp:      model.IndexTypeHypo,
+	}
+}
@@ -0,0 +1,356 @@
+// Copyright 2024 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package indexadvisor
+
+import (
+	"cmp"
+	"context"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/pingcap/errors"
+	"github.com/pingcap/tidb/pkg/infoschema"
+	"github.com/pingcap/tidb/pkg/kv"
+	"github.com/pingcap/tidb/pkg/parser"
+	"github.com/pingcap/tidb/pkg/parser/ast"
+	"github.com/pingcap/tidb/pkg/parser/format"
+	"github.com/pingcap/tidb/pkg/parser/model"
+	"github.com/pingcap/tidb/pkg/sessionctx"
+	"github.com/pingcap/tidb/pkg/util"
+	"github.com/pingcap/tidb/pkg/util/logutil"
+	"github.com/pingcap/tidb/pkg/util/sqlescape"
+	"github.com/pingcap/tidb/pkg/util/sqlexec"
+	stmtsummaryv2 "github.com/pingcap/tidb/pkg/util/stmtsummary/v2"
+	"go.uber.org/zap"
+)
+
+const (
+	// DefaultMaxNumIndexes is the default max number of the recommended indexes.
+	DefaultMaxNumIndexes = 5
+	// DefaultMaxNumQueries is the default max number of the statements in the workload.
+	DefaultMaxNumQueries = 50
+	// minCostImprovement is the min ratio of the cost reduction of the affected statements
+	// to recommend an index.
+	minCostImprovement = 0.01
+)
+
+// Query is a statement in the workload.
+type Query struct {
+	SchemaName string
+	Text       string
+	Digest     string
+	// Frequency is the execution count of the statement, which is used as the weight of its cost.
+	Frequency int64
+}
+
+// Recommendation is an index recommended by the index advisor.
+type Recommendation struct {
+	Database     string
+	Table        string
+	IndexName    string
+	IndexColumns []string
+	// CostImprovement is the ratio of the estimated cost reduction of the affected statements.
+	CostImprovement float64
+	// Digests is the digests of the affected statements, ordered by their cost reduction.
+	Digests         []string
+	CreateIndexStmt string
+}
+
+// WorkloadFromStmtSummary returns the select statements in the statement summary of the current
+// window, ordered by their total latency.
+func WorkloadFromStmtSummary(maxNumQueries int) []*Query {
+	stmts := stmtsummaryv2.GetWorkloadStmts()
+	type queryKey struct {
+		schema string
+		digest string
+	}
+	queries := make(map[queryKey]*Query, len(stmts))
+	latencies := make(map[*Query]int64, len(stmts))
+	for _, stmt := range stmts {
+		if stmt.Schema == "" || util.IsMemOrSysDB(strings.ToLower(stmt.Schema)) {
+			continue
+		}
+		// The statements with the same digest and different plans are merged.
+		key := queryKey{schema: stmt.Schema, digest: stmt.Digest}
+		q, ok := queries[key]
+		if !ok {
+			q = &Query{SchemaName: stmt.Schema, Text: stmt.Query, Digest: stmt.Digest}
+			queries[key] = q
+		}
+		q.Frequency += stmt.ExecCount
+		latencies[q] += int64(stmt.SumLatency)
+	}
+	result := make([]*Query, 0, len(queries))
+	for _, q := range queries {
+		result = append(result, q)
+	}
+	slices.SortFunc(result, func(a, b *Query) int {
+		if c := cmp.Compare(latencies[b], latencies[a]); c != 0 {
+			return c
+		}
+		return cmp.Compare(a.Digest, b.Digest)
+	})
+	if len(result) > maxNumQueries {
+		result = result[:maxNumQueries]
+	}
+	return result
+}
+
+// workloadQuery is a query in the workload which is able to be advised.
+type workloadQuery struct {
+	*Query
+	// explainSQL is the statement to estimate the cost of the query.
+	explainSQL string
+	// tableKeys is the keys of the tables referenced by the query.
+	tableKeys map[string]struct{}
+	// cost is the estimated cost with the recommended indexes by now.
+	cost float64
+}
+
+// planCost is the estimated cost of a query with some hypothetical indexes.
+type planCost struct {
+	cost float64
+	// usedIndexes is the names of the hypothetical indexes used by the plan.
+	usedIndexes map[string]struct{}
+}
+
+type advisor struct {
+	sctx       sessionctx.Context
+	queries    []*workloadQuery
+	candidates []*candidate
+	costCache  map[string]*planCost
+}
+
+// AdviseIndexes recommends indexes for the queries. It estimates the costs of the queries with
+// the hypothetical indexes by sctx, whose current database and hypothetical indexes are changed
+// during the advising, so sctx should not be the session of the user. The queries unable to be
+// advised are skipped, and the reasons are returned as warnings.
+func AdviseIndexes(ctx context.Context, sctx sessionctx.Context, is infoschema.InfoSchema, queries []*Query, maxNumIndexes int) (recommendations []*Recommendation, warns []error, err error) {
+	ctx = kv.WithInternalSourceType(ctx, kv.InternalTxnOthers)
+	vars := sctx.GetSessionVars()
+	originDB, originHypoIndexes := vars.CurrentDB, vars.HypoIndexes
+	defer func() {
+		vars.CurrentDB, vars.HypoIndexes = originDB, originHypoIndexes
+	}()
+
+	a := &advisor{sctx: sctx, costCache: make(map[string]*planCost)}
+	candidates := make(map[string]*candidate)
+	p := parser.New()
+	for _, q := range queries {
+		stmt, err := p.ParseOneStmt(q.Text, "", "")
+		if err != nil {
+			warns = append(warns, errors.NewNoStackErrorf("skip the statement %s failed to parse: %v", q.Digest, err))
+			continue
+		}
+		switch stmt.(type) {
+		case *ast.SelectStmt, *ast.SetOprStmt:
+		default:
+			warns = append(warns, errors.NewNoStackErrorf("skip the statement %s which is not a query", q.Digest))
+			continue
+		}
+		analyzer := newQueryAnalyzer(is, q.SchemaName)
+		analyzer.analyze(stmt)
+		if len(analyzer.tableKeys) == 0 {
+			continue
+		}
+		var sb strings.Builder
+		sb.WriteString("EXPLAIN FORMAT = 'verbose' ")
+		if err := stmt.Restore(format.NewRestoreCtx(format.DefaultRestoreFlags, &sb)); err != nil {
+			return nil, nil, errors.Trace(err)
+		}
+		wq := &workloadQuery{Query: q, explainSQL: sb.String(), tableKeys: analyzer.tableKeys}
+		cost, err := a.queryCost(ctx, wq, nil)
+		if err != nil {
+			logutil.BgLogger().Info("skip the statement failed to explain for index advise", zap.String("digest", q.Digest), zap.Error(err))
+			warns = append(warns, errors.NewNoStackErrorf("skip the statement %s failed to explain: %v", q.Digest, err))
+			continue
+		}
+		wq.cost = cost.cost
+		a.queries = append(a.queries, wq)
+		for _, c := range analyzer.candidates() {
+			if _, ok := candidates[c.key()]; !ok && !c.coveredByExistingIndex() {
+				candidates[c.key()] = c
+				a.candidates = append(a.candidates, c)
+			}
+		}
+	}
+	// The names of the candidates are unique among all the tables, so the plans using them
+	// can be told by the index names in the access objects.
+	usedNames := make(map[string]struct{})
+	nextIdxIDs := make(map[string]int64)
+	for _, c := range a.candidates {
+		key := c.tableKey()
+		if _, ok := nextIdxIDs[key]; !ok {
+			nextIdxIDs[key] = c.tblInfo.MaxIndexID + 1
+		}
+		c.buildHypoIndex(nextIdxIDs[key], usedNames)
+		nextIdxIDs[key]++
+	}
+	recommendations, err = a.selectIndexes(ctx, maxNumIndexes)
+	return recommendations, warns, err
+}
+
+// selectIndexes selects the candidates greedily. In every round, the candidate reducing the
+// weighted costs of the workload most with the selected indexes is selected.
+func (a *advisor) selectIndexes(ctx context.Context, maxNumIndexes int) ([]*Recommendation, error) {
+	var (
+		selected []*candidate
+		result   []*Recommendation
+	)
+	remained := a.candidates
+	for len(selected) < maxNumIndexes && len(remained) > 0 {
+		var (
+			best                   *candidate
+			bestBenefit, bestRatio float64
+			bestCosts              map[*workloadQuery]float64
+		)
+		for _, c := range remained {
+			indexes := append(slices.Clip(selected), c)
+			var benefit, affectedCost float64
+			costs := make(map[*workloadQuery]float64)
+			for _, q := range a.queries {
+				if _, ok := q.tableKeys[c.tableKey()]; !ok {
+					continue
+				}
+				cost, err := a.queryCost(ctx, q, indexes)
+				if err != nil {
+					return nil, err
+				}
+				if _, used := cost.usedIndexes[c.idxInfo.Name.L]; !used || cost.cost >= q.cost {
+					continue
+				}
+				benefit += float64(q.Frequency) * (q.cost - cost.cost)
+				affectedCost += float64(q.Frequency) * q.cost
+				costs[q] = cost.cost
+			}
+			if benefit <= 0 || benefit < affectedCost*minCostImprovement {
+				continue
+			}
+			if best == nil || benefit > bestBenefit {
+				best, bestBenefit, bestRatio, bestCosts = c, benefit, benefit/affectedCost, costs
+			}
+		}
+		if best == nil {
+			break
+		}
+		affected := make([]*workloadQuery, 0, len(bestCosts))
+		for q := range bestCosts {
+			affected = append(affected, q)
+		}
+		slices.SortFunc(affected, func(x, y *workloadQuery) int {
+			bx, by := float64(x.Frequency)*(x.cost-bestCosts[x]), float64(y.Frequency)*(y.cost-bestCosts[y])
+			if c := cmp.Compare(by, bx); c != 0 {
+				return c
+			}
+			return cmp.Compare(x.Digest, y.Digest)
+		})
+		digests := make([]string, 0, len(affected))
+		for _, q := range affected {
+			if !slices.Contains(digests, q.Digest) {
+				digests = append(digests, q.Digest)
+			}
+			q.cost = bestCosts[q]
+		}
+		selected = append(selected, best)
+		remained = slices.DeleteFunc(slices.Clone(remained), func(c *candidate) bool { return c == best })
+		result = append(result, best.recommendation(bestRatio, digests))
+	}
+	return result, nil
+}
+
+// queryCost estimates the cost of the query with the hypothetical indexes.
+func (a *advisor) queryCost(ctx context.Context, q *workloadQuery, indexes []*candidate) (*planCost, error) {
+	hypoIndexes := make(map[string]map[string]map[string]*model.IndexInfo)
+	names := make([]string, 0, len(indexes))
+	for _, c := range indexes {
+		if _, ok := q.tableKeys[c.tableKey()]; !ok {
+			continue
+		}
+		if hypoIndexes[c.dbName.L] == nil {
+			hypoIndexes[c.dbName.L] = make(map[string]map[string]*model.IndexInfo)
+		}
+		if hypoIndexes[c.dbName.L][c.tblInfo.Name.L] == nil {
+			hypoIndexes[c.dbName.L][c.tblInfo.Name.L] = make(map[string]*model.IndexInfo)
+		}
+		hypoIndexes[c.dbName.L][c.tblInfo.Name.L][c.idxInfo.Name.L] = c.idxInfo
+		names = append(names, c.key())
+	}
+	slices.Sort(names)
+	cacheKey := q.explainSQL + "\n" + strings.Join(names, ";")
+	if cost, ok := a.costCache[cacheKey]; ok {
+		return cost, nil
+	}
+
+	vars := a.sctx.GetSessionVars()
+	vars.CurrentDB = q.SchemaName
+	vars.HypoIndexes = hypoIndexes
+	rs, err := a.sctx.GetSQLExecutor().ExecuteInternal(ctx, q.explainSQL)
+	if err != nil {
+		return nil, err
+	}
+	if rs == nil {
+		return nil, errors.New("no result of explain")
+	}
+	defer func() {
+		_ = rs.Close()
+	}()
+	rows, err := sqlexec.DrainRecordSet(ctx, rs, vars.MaxChunkSize)
+	if err != nil {
+		return nil, err
+	}
+	if len(rows) == 0 {
+		return nil, errors.New("no result of explain")
+	}
+	// The columns of the result are id, estRows, estCost, task, access object and operator info.
+	cost := &planCost{usedIndexes: make(map[string]struct{})}
+	cost.cost, err = strconv.ParseFloat(rows[0].GetString(2), 64)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	for _, row := range rows {
+		accessObject := row.GetString(4)
+		for _, c := range indexes {
+			if strings.Contains(accessObject, "index:"+c.idxInfo.Name.O+"(") {
+				cost.usedIndexes[c.idxInfo.Name.L] = struct{}{}
+			}
+		}
+	}
+	a.costCache[cacheKey] = cost
+	return cost, nil
+}
+
+func (c *candidate) recommendation(costImprovement float64, digests []string) *Recommendation {
+	cols := make([]string, 0, len(c.cols))
+	var sb strings.Builder
+	sqlescape.MustFormatSQL(&sb, "CREATE INDEX %n ON %n.%n (", c.idxInfo.Name.O, c.dbName.O, c.tblInfo.Name.O)
+	for i, col := range c.cols {
+		cols = append(cols, col.Name.O)
+		if i > 0 {
+			sb.WriteString(", ")
+		}
+		sqlescape.MustFormatSQL(&sb, "%n", col.Name.O)
+	}
+	sb.WriteString(")")
+	return &Recommendation{
+		Database:        c.dbName.O,
+		Table:           c.tblInfo.Name.O,
+		IndexName:       c.idxInfo.Name.O,
+		IndexColumns:    cols,
+		CostImprovement: costImprovement,
+		Digests:         digests,
+		CreateIndexStmt: sb.String(),
+	}
+}
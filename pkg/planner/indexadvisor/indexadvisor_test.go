@@ -0,0 +1,137 @@
+// Copyright 2024 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package indexadvisor_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/pingcap/tidb/pkg/parser"
+	"github.com/pingcap/tidb/pkg/parser/auth"
+	"github.com/pingcap/tidb/pkg/testkit"
+	"github.com/pingcap/tidb/pkg/util/stmtsummary"
+	"github.com/stretchr/testify/require"
+)
+
+func checkRecommendedIndexes(t *testing.T, tk *testkit.TestKit, sql string, expected ...string) [][]any {
+	rows := tk.MustQuery(sql).Rows()
+	indexes := make([]string, 0, len(rows))
+	for _, row := range rows {
+		indexes = append(indexes, row[0].(string)+"."+row[1].(string)+"."+row[2].(string)+"("+row[3].(string)+")")
+	}
+	if len(expected) == 0 {
+		require.Empty(t, indexes)
+	} else {
+		require.Equal(t, expected, indexes)
+	}
+	return rows
+}
+
+func TestRecommendIndexForSQL(t *testing.T) {
+	store := testkit.CreateMockStore(t)
+	tk := testkit.NewTestKit(t, store)
+	tk.MustExec("use test")
+	tk.MustExec("create table t1 (a int, b int, c int, d json)")
+	tk.MustExec("create table t2 (a int, b int, c int, primary key (a))")
+
+	sql := "select * from t1 where a = 1"
+	rows := checkRecommendedIndexes(t, tk, "recommend index run for '"+sql+"'", "test.t1.idx_a(a)")
+	_, digest := parser.NormalizeDigest(sql)
+	require.Equal(t, digest.String(), rows[0][5])
+	require.Equal(t, "CREATE INDEX `idx_a` ON `test`.`t1` (`a`)", rows[0][6])
+
+	// The equal columns are the prefix of the two-column indexes.
+	checkRecommendedIndexes(t, tk, "recommend index run for 'select * from t1 where a = 1 and b = 1'", "test.t1.idx_a_b(a,b)")
+	// The hypothetical indexes have no statistics, and the pseudo estimation of the range (1 1, 1 +inf] on
+	// idx_a_b is more than the one of [1, 1] on idx_a, so idx_a is preferred for the range on b.
+	checkRecommendedIndexes(t, tk, "recommend index run for 'select * from t1 where a = 1 and b > 1'", "test.t1.idx_a(a)")
+	// The columns covered by the existing indexes or unable to be indexed are skipped.
+	checkRecommendedIndexes(t, tk, "recommend index run for 'select * from t2 where a = 1'")
+	checkRecommendedIndexes(t, tk, "recommend index run for 'select * from t1 where d = 1'")
+	tk.MustExec("create index idx_a on t1 (a)")
+	checkRecommendedIndexes(t, tk, "recommend index run for 'select * from t1 where a = 1'")
+	checkRecommendedIndexes(t, tk, "recommend index run for 'select * from t1 where b = 1'", "test.t1.idx_b(b)")
+
+	// The statements unable to be advised are skipped with warnings.
+	checkRecommendedIndexes(t, tk, "recommend index run for 'delete from t1 where a = 1'")
+	require.Len(t, tk.Session().GetSessionVars().StmtCtx.GetWarnings(), 1)
+	checkRecommendedIndexes(t, tk, "recommend index run for 'select * frm t1'")
+	require.Len(t, tk.Session().GetSessionVars().StmtCtx.GetWarnings(), 1)
+}
+
+func TestRecommendIndexForWorkload(t *testing.T) {
+	store := testkit.CreateMockStore(t)
+	tk := testkit.NewTestKit(t, store)
+	stmtsummary.StmtSummaryByDigestMap.Clear()
+	require.NoError(t, tk.Session().Auth(&auth.UserIdentity{Username: "root", Hostname: "%"}, nil, nil, nil))
+	tk.MustExec("use test")
+	tk.MustExec("create table t (a int, b int, c int)")
+	for i := 0; i < 3; i++ {
+		tk.MustQuery("select * from t where b = 1")
+		tk.MustQuery("select * from t where b = 2 and c = 3")
+		tk.MustQuery("select count(*) from t group by c")
+	}
+	tk.MustExec("insert into t values (1, 1, 1)")
+
+	rows := tk.MustQuery("recommend index run").Rows()
+	require.NotEmpty(t, rows)
+	_, digest := parser.NormalizeDigest("select * from t where b = 1")
+	require.Equal(t, "t", rows[0][1])
+	require.True(t, strings.HasPrefix(rows[0][3].(string), "b"))
+	require.Contains(t, rows[0][5], digest.String())
+	for _, row := range rows {
+		require.Equal(t, "test", row[0])
+	}
+
+	// The recommendation requires the PROCESS privilege.
+	tk.MustExec("create user u")
+	tk1 := testkit.NewTestKit(t, store)
+	require.NoError(t, tk1.Session().Auth(&auth.UserIdentity{Username: "u", Hostname: "%"}, nil, nil, nil))
+	require.ErrorContains(t, tk1.ExecToErr("recommend index run"), "PROCESS privilege(s)")
+}
+
+func TestRecommendIndexPrivilege(t *testing.T) {
+	store := testkit.CreateMockStore(t)
+	tk := testkit.NewTestKit(t, store)
+	stmtsummary.StmtSummaryByDigestMap.Clear()
+	require.NoError(t, tk.Session().Auth(&auth.UserIdentity{Username: "root", Hostname: "%"}, nil, nil, nil))
+	tk.MustExec("use test")
+	tk.MustExec("create table t1 (a int, b int)")
+	tk.MustExec("create table t2 (a int, b int)")
+	tk.MustExec("create user u")
+	tk.MustExec("grant process on *.* to u")
+	tk.MustExec("grant select on test.t2 to u")
+	for i := 0; i < 3; i++ {
+		tk.MustQuery("select * from t1 where a = 1")
+	}
+
+	tk1 := testkit.NewTestKit(t, store)
+	require.NoError(t, tk1.Session().Auth(&auth.UserIdentity{Username: "u", Hostname: "%"}, nil, nil, nil))
+	// The statements referencing the tables without the SELECT privilege are refused.
+	err := tk1.QueryToErr("recommend index run for 'select * from test.t1 where a = 1'")
+	require.ErrorContains(t, err, "SELECT command denied to user 'u'@'%' for table 't1'")
+	err = tk1.QueryToErr("recommend index run for 'select * from test.t2 where a = (select max(b) from test.t1)'")
+	require.ErrorContains(t, err, "for table 't1'")
+	// The common table expression shadows the table only in its scope.
+	err = tk1.QueryToErr("recommend index run for 'with t1 as (select * from test.t1) select * from t1 where a = 1'")
+	require.ErrorContains(t, err, "for table 't1'")
+	// The statements of the other users in the workload are skipped.
+	require.Empty(t, tk1.MustQuery("recommend index run").Rows())
+
+	tk.MustExec("grant select on test.t1 to u")
+	checkRecommendedIndexes(t, tk1, "recommend index run for 'select * from test.t1 where a = 1'", "test.t1.idx_a(a)")
+	checkRecommendedIndexes(t, tk1, "recommend index run for 'with t2 as (select * from test.t1) select * from t2 where a = 1'", "test.t1.idx_a(a)")
+	require.NotEmpty(t, tk1.MustQuery("recommend index run").Rows())
+}
@@ -55,7 +55,10 @@ type Group struct {
 	Fingerprints map[string]*list.Element
 
 	ImplMap map[string]Implementation
-	Prop    *property.LogicalProperty
+	// costLowerBounds records the cost limits under which no Implementation satisfying the physical
+	// property can be found. It's used to prune the search when the Group is implemented again.
+	costLowerBounds map[string]float64
+	Prop            *property.LogicalProperty
 
 	EngineType pattern.EngineType
 
@@ -183,6 +186,25 @@ func (g *Group) InsertImpl(prop *property.PhysicalProperty, impl Implementation)
 	g.ImplMap[string(key)] = impl
 }
 
+// GetCostLowerBound returns the lower bound of the cost of the Implementations satisfying the
+// physical property, the second return value is false if it's unknown.
+func (g *Group) GetCostLowerBound(prop *property.PhysicalProperty) (float64, bool) {
+	bound, ok := g.costLowerBounds[string(prop.HashCode())]
+	return bound, ok
+}
+
+// UpdateCostLowerBound records that no Implementation satisfying the physical property has a
+// cost lower than or equal to the bound.
+func (g *Group) UpdateCostLowerBound(prop *property.PhysicalProperty, bound float64) {
+	if g.costLowerBounds == nil {
+		g.costLowerBounds = make(map[string]float64)
+	}
+	key := string(prop.HashCode())
+	if old, ok := g.costLowerBounds[key]; !ok || old < bound {
+		g.costLowerBounds[key] = bound
+	}
+}
+
 // Convert2GroupExpr converts a logical plan to a GroupExpr.
 func Convert2GroupExpr(node base.LogicalPlan) *GroupExpr {
 	e := NewGroupExpr(node)
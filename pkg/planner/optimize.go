@@ -18,12 +18,15 @@ import (
 	"context"
 	"math"
 	"math/rand"
+	"slices"
+	"strings"
 	"sync"
 	"time"
 
 	"github.com/pingcap/errors"
 	"github.com/pingcap/failpoint"
 	"github.com/pingcap/tidb/pkg/bindinfo"
+	"github.com/pingcap/tidb/pkg/bindinfo/norm"
 	"github.com/pingcap/tidb/pkg/domain"
 	"github.com/pingcap/tidb/pkg/infoschema"
 	"github.com/pingcap/tidb/pkg/kv"
@@ -72,6 +75,7 @@ func getPlanFromNonPreparedPlanCache(ctx context.Context, sctx sessionctx.Contex
 		isExplain || // explain external
 		!sctx.GetSessionVars().DisableTxnAutoRetry || // txn-auto-retry
 		sctx.GetSessionVars().InMultiStmts || // in multi-stmt
+		sctx.GetSessionVars().ProcedureContext != nil || // in stored procedure
 		(stmtCtx.InExplainStmt && stmtCtx.ExplainFormat != types.ExplainFormatPlanCache) { // in explain internal
 		return nil, nil, false, nil
 	}
@@ -238,6 +242,12 @@ func Optimize(ctx context.Context, sctx sessionctx.Context, node ast.Node, is in
 
 	enableUseBinding := sessVars.UsePlanBaselines
 	stmtNode, isStmtNode := node.(ast.StmtNode)
+	if sessVars.EnableCardinalityFeedback && isStmtNode && !sessVars.InRestrictedSQL &&
+		sessVars.StmtCtx.CardFeedbackDigest == "" {
+		// The digest ignores EXPLAIN, so EXPLAIN shows the estimation corrected for the stmt. The stmt
+		// inside EXPLAIN is optimized again without text, it keeps the digest of the EXPLAIN.
+		_, sessVars.StmtCtx.CardFeedbackDigest = norm.NormalizeStmtForBinding(stmtNode, norm.WithFuzz(true))
+	}
 	binding, match, scope := bindinfo.MatchSQLBinding(sctx, stmtNode)
 	var bindings bindinfo.Bindings
 	if match {
@@ -409,6 +419,36 @@ func OptimizeForForeignKeyCascade(ctx context.Context, sctx pctx.PlanContext, no
 	return p, nil
 }
 
+// OptimizeForTrigger does optimization and creates a Plan for a statement in the body of a trigger.
+// Like OptimizeForForeignKeyCascade, the plan is built and executed inside the statement which
+// activates the trigger, so it doesn't consider plan cache and plan binding.
+func OptimizeForTrigger(ctx context.Context, sctx pctx.PlanContext, node ast.StmtNode, is infoschema.InfoSchema) (base.Plan, types.NameSlice, error) {
+	builder := planBuilderPool.Get().(*core.PlanBuilder)
+	defer planBuilderPool.Put(builder.ResetForReuse())
+	hintProcessor := hint.NewQBHintHandler(sctx.GetSessionVars().StmtCtx)
+	builder.Init(sctx, is, hintProcessor)
+	p, err := builder.Build(ctx, node)
+	if err != nil {
+		return nil, nil, err
+	}
+	if pm := privilege.GetPrivilegeManager(sctx); pm != nil {
+		visitInfo := core.VisitInfo4PrivCheck(ctx, is, node, builder.GetVisitInfo())
+		if err := core.CheckPrivilege(sctx.GetSessionVars().ActiveRoles, pm, visitInfo); err != nil {
+			return nil, nil, err
+		}
+	}
+	if err := core.CheckTableLock(sctx, is, builder.GetVisitInfo()); err != nil {
+		return nil, nil, err
+	}
+	names := p.OutputNames()
+	logic, isLogicalPlan := p.(base.LogicalPlan)
+	if !isLogicalPlan {
+		return p, names, nil
+	}
+	finalPlan, _, err := core.DoOptimize(ctx, sctx, builder.GetOptFlag(), logic)
+	return finalPlan, names, err
+}
+
 func allowInReadOnlyMode(sctx pctx.PlanContext, node ast.Node) (bool, error) {
 	pm := privilege.GetPrivilegeManager(sctx)
 	if pm == nil {
@@ -526,9 +566,128 @@ func optimize(ctx context.Context, sctx pctx.PlanContext, node ast.Node, is info
 	// TODO: capture plan replayer here if it matches sql and plan digest
 
 	sessVars.DurationOptimization = time.Since(beginOpt)
+	if err == nil && sessVars.EnableCascadesPlannerCompare {
+		diff, err := compareWithCascadesPlanner(ctx, sctx, node, is, finalPlan)
+		if err != nil {
+			sessVars.StmtCtx.AppendWarning(errors.NewNoStackErrorf("the cascades planner failed to optimize the query: %v", err))
+		} else if diff != nil {
+			failpoint.InjectCall("cascadesPlanDiff", diff)
+			sessVars.StmtCtx.AppendWarning(errors.NewNoStackErrorf("the cascades planner chose a different plan:\n%s", strings.Join(diff.Lines(), "\n")))
+		}
+	}
 	return finalPlan, names, cost, err
 }
 
+// CascadesPlanDiff is the difference between the plans chosen by the default planner and the
+// cascades planner for a statement. Each plan is rendered as the rows of `explain format='brief'`,
+// in which the columns are separated by a space.
+type CascadesPlanDiff struct {
+	Default  []string
+	Cascades []string
+}
+
+// Lines returns the unified diff of the two plans without the header. The rows which are only in
+// the plan of the default planner are prefixed by "-", the ones only in the plan of the cascades
+// planner are prefixed by "+", and the common rows are prefixed by a space.
+func (d *CascadesPlanDiff) Lines() []string {
+	m, n := len(d.Default), len(d.Cascades)
+	// lcs[i][j] is the length of the longest common subsequence of Default[i:] and Cascades[j:].
+	lcs := make([][]int, m+1)
+	for i := range lcs {
+		lcs[i] = make([]int, n+1)
+	}
+	for i := m - 1; i >= 0; i-- {
+		for j := n - 1; j >= 0; j-- {
+			if d.Default[i] == d.Cascades[j] {
+				lcs[i][j] = lcs[i+1][j+1] + 1
+			} else {
+				lcs[i][j] = max(lcs[i+1][j], lcs[i][j+1])
+			}
+		}
+	}
+	lines := make([]string, 0, m+n)
+	i, j := 0, 0
+	for i < m || j < n {
+		switch {
+		case i < m && j < n && d.Default[i] == d.Cascades[j]:
+			lines = append(lines, " "+d.Default[i])
+			i++
+			j++
+		case j == n || (i < m && lcs[i+1][j] >= lcs[i][j+1]):
+			lines = append(lines, "-"+d.Default[i])
+			i++
+		default:
+			lines = append(lines, "+"+d.Cascades[j])
+			j++
+		}
+	}
+	return lines
+}
+
+// compareWithCascadesPlanner optimizes the statement by the cascades planner again, and returns the
+// difference between its plan and the one chosen by the default planner. The returned diff is nil if
+// the plans are the same. It's used to check the cascades planner against the default one.
+func compareWithCascadesPlanner(ctx context.Context, sctx pctx.PlanContext, node ast.Node, is infoschema.InfoSchema, finalPlan base.PhysicalPlan) (*CascadesPlanDiff, error) {
+	sessVars := sctx.GetSessionVars()
+	stmtCtx := sessVars.StmtCtx
+	// The logical plan is rebuilt, which must not affect the plan chosen by the default planner.
+	warnings := stmtCtx.GetWarnings()
+	planID, planColumnID := sessVars.PlanID.Load(), sessVars.PlanColumnID.Load()
+	mapScalarSubQ, mapHashCode2UniqueID4ExtendedCol := sessVars.MapScalarSubQ, sessVars.MapHashCode2UniqueID4ExtendedCol
+	defer func() {
+		sessVars.PlanID.Store(planID)
+		sessVars.PlanColumnID.Store(planColumnID)
+		sessVars.MapScalarSubQ, sessVars.MapHashCode2UniqueID4ExtendedCol = mapScalarSubQ, mapHashCode2UniqueID4ExtendedCol
+	}()
+
+	cascadesPlan, err := func() (base.PhysicalPlan, error) {
+		sessVars.PlanID.Store(0)
+		sessVars.PlanColumnID.Store(0)
+		sessVars.MapScalarSubQ = nil
+		sessVars.MapHashCode2UniqueID4ExtendedCol = nil
+		hintProcessor := hint.NewQBHintHandler(stmtCtx)
+		node.Accept(hintProcessor)
+		builder := planBuilderPool.Get().(*core.PlanBuilder)
+		defer planBuilderPool.Put(builder.ResetForReuse())
+		builder.Init(sctx, is, hintProcessor)
+		p, err := builder.Build(ctx, node)
+		if err != nil {
+			return nil, err
+		}
+		logic, ok := p.(base.LogicalPlan)
+		if !ok {
+			return nil, errors.Errorf("unexpected plan type %T", p)
+		}
+		core.RecheckCTE(logic)
+		finalPlan, _, err := cascades.DefaultOptimizer.FindBestPlan(sctx, logic)
+		return finalPlan, err
+	}()
+	stmtCtx.SetWarnings(warnings)
+	if err != nil {
+		return nil, err
+	}
+	// The plan IDs of the two plans are different, so they are ignored in the comparison.
+	ignoreExplainIDSuffix := stmtCtx.IgnoreExplainIDSuffix
+	stmtCtx.IgnoreExplainIDSuffix = true
+	diff := &CascadesPlanDiff{
+		Default:  explainRowsToStrings(core.GetExplainRowsForPlan(finalPlan)),
+		Cascades: explainRowsToStrings(core.GetExplainRowsForPlan(cascadesPlan)),
+	}
+	stmtCtx.IgnoreExplainIDSuffix = ignoreExplainIDSuffix
+	if slices.Equal(diff.Default, diff.Cascades) {
+		return nil, nil
+	}
+	return diff, nil
+}
+
+func explainRowsToStrings(rows [][]string) []string {
+	strs := make([]string, 0, len(rows))
+	for _, row := range rows {
+		strs = append(strs, strings.Join(row, " "))
+	}
+	return strs
+}
+
 // OptimizeExecStmt to handle the "execute" statement
 func OptimizeExecStmt(ctx context.Context, sctx sessionctx.Context,
 	execAst *ast.ExecuteStmt, is infoschema.InfoSchema) (base.Plan, types.NameSlice, error) {
@@ -59,6 +59,8 @@ const (
 	OperandLimit
 	// OperandTiKVSingleGather is the operand for TiKVSingleGather.
 	OperandTiKVSingleGather
+	// OperandTiKVIndexMergeGather is the operand for TiKVIndexMergeGather.
+	OperandTiKVIndexMergeGather
 	// OperandMemTableScan is the operand for MemTableScan.
 	OperandMemTableScan
 	// OperandTableScan is the operand for TableScan.
@@ -94,7 +96,7 @@ func GetOperand(p base.LogicalPlan) Operand {
 		return OperandDataSource
 	case *plannercore.LogicalUnionScan:
 		return OperandUnionScan
-	case *plannercore.LogicalUnionAll:
+	case *plannercore.LogicalUnionAll, *plannercore.LogicalPartitionUnionAll:
 		return OperandUnionAll
 	case *plannercore.LogicalSort:
 		return OperandSort
@@ -106,6 +108,8 @@ func GetOperand(p base.LogicalPlan) Operand {
 		return OperandLimit
 	case *plannercore.TiKVSingleGather:
 		return OperandTiKVSingleGather
+	case *plannercore.TiKVIndexMergeGather:
+		return OperandTiKVIndexMergeGather
 	case *plannercore.LogicalTableScan:
 		return OperandTableScan
 	case *plannercore.LogicalMemTable:
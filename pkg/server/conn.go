@@ -1705,6 +1705,19 @@ func (cc *clientConn) handlePlanReplayerDump(ctx context.Context, e *executor.Pl
 	return e.DumpSQLsFromFile(ctx, data)
 }
 
+// handleProcedureResult writes the result sets produced by a stored procedure.
+// They are followed by the OK packet of the CALL statement.
+func (cc *clientConn) handleProcedureResult(ctx context.Context, info *executor.ProcedureResultInfo, status uint16) error {
+	for _, rs := range info.ResultSets {
+		_, err := cc.writeResultSet(ctx, resultset.New(rs, nil), false, status|mysql.ServerMoreResultsExists, 0)
+		terror.Log(rs.Close())
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 func (cc *clientConn) audit(eventType plugin.GeneralEvent) {
 	err := plugin.ForeachPlugin(plugin.Audit, func(p *plugin.Plugin) error {
 		audit := plugin.DeclareAuditManifest(p.Manifest)
@@ -2225,6 +2238,16 @@ func (cc *clientConn) handleFileTransInConn(ctx context.Context, status uint16)
 			return handled, err
 		}
 	}
+
+	procedureResult := cc.ctx.Value(executor.ProcedureResultVarKey)
+	if procedureResult != nil {
+		handled = true
+		defer cc.ctx.SetValue(executor.ProcedureResultVarKey, nil)
+		//nolint:forcetypeassert
+		if err := cc.handleProcedureResult(ctx, procedureResult.(*executor.ProcedureResultInfo), status); err != nil {
+			return handled, err
+		}
+	}
 	return handled, cc.writeOkWith(ctx, mysql.OKHeader, true, status)
 }
 
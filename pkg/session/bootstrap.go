@@ -709,6 +709,71 @@ const (
 		GROUP BY table_schema, table_name, index_name
 		HAVING
 			sum(last_access_time) is null;`
+
+	// CreateRoutinesTable stores the definitions of stored procedures.
+	CreateRoutinesTable = `CREATE TABLE IF NOT EXISTS mysql.routines (
+		db VARCHAR(64) NOT NULL,
+		name VARCHAR(64) NOT NULL,
+		type ENUM('FUNCTION','PROCEDURE') NOT NULL,
+		specific_name VARCHAR(64) NOT NULL,
+		param_list BLOB NOT NULL,
+		body LONGBLOB NOT NULL,
+		definer VARCHAR(288) NOT NULL,
+		security_type ENUM('INVOKER','DEFINER') NOT NULL DEFAULT 'DEFINER',
+		sql_mode VARCHAR(1024) NOT NULL DEFAULT '',
+		character_set_client VARCHAR(32) NOT NULL,
+		collation_connection VARCHAR(32) NOT NULL,
+		db_collation VARCHAR(32) NOT NULL,
+		comment TEXT,
+		created TIMESTAMP(6) NOT NULL DEFAULT CURRENT_TIMESTAMP(6),
+		modified TIMESTAMP(6) NOT NULL DEFAULT CURRENT_TIMESTAMP(6),
+		PRIMARY KEY (db, name, type)
+	);`
+
+	// CreateMViewRefreshTable stores the last refresh of materialized views.
+	CreateMViewRefreshTable = `CREATE TABLE IF NOT EXISTS mysql.tidb_mview_refresh (
+		mview_id BIGINT(64) NOT NULL,
+		last_refresh_method ENUM('COMPLETE','INCREMENTAL') NOT NULL,
+		last_refresh_tso BIGINT(64) UNSIGNED NOT NULL,
+		last_refresh_time TIMESTAMP(6) NOT NULL,
+		PRIMARY KEY (mview_id)
+	);`
+
+	// CreateBindEvolveResultTable stores the results of the baseline evolution of the bindings in mysql.bind_info.
+	CreateBindEvolveResultTable = `CREATE TABLE IF NOT EXISTS mysql.bind_evolve_result (
+		sql_digest VARCHAR(64) NOT NULL,
+		bind_sql TEXT NOT NULL,
+		reason TEXT NOT NULL,
+		update_time TIMESTAMP(3) NOT NULL,
+		INDEX sql_digest_index(sql_digest)
+	) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_bin;`
+
+	// CreateStatsIncrementalAnalyzeTable stores the max handle of the rows analyzed by the incremental analyze.
+	CreateStatsIncrementalAnalyzeTable = `CREATE TABLE IF NOT EXISTS mysql.stats_incremental_analyze (
+		table_id BIGINT(64) NOT NULL,
+		max_handle BIGINT(64) NOT NULL,
+		PRIMARY KEY (table_id)
+	);`
+
+	// CreateStatsExpressionUsageTable stores the expressions used in the filters, whose stats may be collected.
+	CreateStatsExpressionUsageTable = `CREATE TABLE IF NOT EXISTS mysql.stats_expression_usage (
+		table_id BIGINT(64) NOT NULL,
+		expr_hash BIGINT(64) NOT NULL,
+		expr TEXT NOT NULL,
+		last_used_at TIMESTAMP NOT NULL,
+		PRIMARY KEY (table_id, expr_hash)
+	);`
+
+	// CreateStatsExpressionsTable stores the stats of the expressions.
+	CreateStatsExpressionsTable = `CREATE TABLE IF NOT EXISTS mysql.stats_expressions (
+		table_id BIGINT(64) NOT NULL,
+		expr_hash BIGINT(64) NOT NULL,
+		version BIGINT(64) UNSIGNED NOT NULL DEFAULT 0,
+		null_count BIGINT(64) NOT NULL DEFAULT 0,
+		histogram LONGBLOB,
+		top_n LONGBLOB,
+		PRIMARY KEY (table_id, expr_hash)
+	);`
 )
 
 // CreateTimers is a table to store all timers for tidb
@@ -1107,11 +1172,29 @@ const (
 	version209 = 209
 	// version210 indicates that if TiDB is upgraded from a lower version(lower than 8.3.0), the tidb_analyze_column_options will be set to ALL.
 	version210 = 210
+
+	// version211 adds the mysql.routines table to store stored procedures.
+	version211 = 211
+
+	// version212 adds the mysql.tidb_mview_refresh table to store the refresh states of materialized views.
+	version212 = 212
+
+	// version213 adds the mysql.bind_evolve_result table to record the results of the baseline evolution.
+	version213 = 213
+
+	// version214 adds the mysql.stats_incremental_analyze table to store the positions of the incremental analyze.
+	version214 = 214
+
+	// version215 adds the mysql.stats_expression_usage and mysql.stats_expressions tables to store the stats of expressions.
+	version215 = 215
+
+	// version216 keeps event_scheduler OFF for the clusters upgraded from the versions where it's a noop variable.
+	version216 = 216
 )
 
 // currentBootstrapVersion is defined as a variable, so we can modify its value for testing.
 // please make sure this is the largest version
-var currentBootstrapVersion int64 = version210
+var currentBootstrapVersion int64 = version216
 
 // DDL owner key's expired time is ManagerSessionTTL seconds, we should wait the time and give more time to have a chance to finish it.
 var internalSQLTimeout = owner.ManagerSessionTTL + 15
@@ -1277,6 +1360,12 @@ var (
 		upgradeToVer198,
 		upgradeToVer209,
 		upgradeToVer210,
+		upgradeToVer211,
+		upgradeToVer212,
+		upgradeToVer213,
+		upgradeToVer214,
+		upgradeToVer215,
+		upgradeToVer216,
 	}
 )
 
@@ -3063,6 +3152,52 @@ func upgradeToVer210(s sessiontypes.Session, ver int64) {
 	initGlobalVariableIfNotExists(s, variable.TiDBAnalyzeColumnOptions, model.AllColumns.String())
 }
 
+func upgradeToVer211(s sessiontypes.Session, ver int64) {
+	if ver >= version211 {
+		return
+	}
+	doReentrantDDL(s, CreateRoutinesTable)
+}
+
+func upgradeToVer212(s sessiontypes.Session, ver int64) {
+	if ver >= version212 {
+		return
+	}
+	doReentrantDDL(s, CreateMViewRefreshTable)
+}
+
+func upgradeToVer213(s sessiontypes.Session, ver int64) {
+	if ver >= version213 {
+		return
+	}
+	doReentrantDDL(s, CreateBindEvolveResultTable)
+}
+
+func upgradeToVer214(s sessiontypes.Session, ver int64) {
+	if ver >= version214 {
+		return
+	}
+	doReentrantDDL(s, CreateStatsIncrementalAnalyzeTable)
+}
+
+func upgradeToVer215(s sessiontypes.Session, ver int64) {
+	if ver >= version215 {
+		return
+	}
+	doReentrantDDL(s, CreateStatsExpressionUsageTable)
+	doReentrantDDL(s, CreateStatsExpressionsTable)
+}
+
+func upgradeToVer216(s sessiontypes.Session, ver int64) {
+	if ver >= version216 {
+		return
+	}
+
+	// event_scheduler was a noop variable with default OFF, so the events created after the upgrade
+	// don't run until it's turned on explicitly.
+	initGlobalVariableIfNotExists(s, variable.EventScheduler, variable.Off)
+}
+
 // initGlobalVariableIfNotExists initialize a global variable with specific val if it does not exist.
 func initGlobalVariableIfNotExists(s sessiontypes.Session, name string, val any) {
 	ctx := kv.WithInternalSourceType(context.Background(), kv.InternalTxnBootstrap)
@@ -3211,6 +3346,18 @@ func doDDLWorks(s sessiontypes.Session) {
 	mustExecute(s, CreateSysSchema)
 	// create `sys.schema_unused_indexes` view
 	mustExecute(s, CreateSchemaUnusedIndexesView)
+	// create routines
+	mustExecute(s, CreateRoutinesTable)
+	// create tidb_mview_refresh
+	mustExecute(s, CreateMViewRefreshTable)
+	// create bind_evolve_result
+	mustExecute(s, CreateBindEvolveResultTable)
+	// create stats_incremental_analyze
+	mustExecute(s, CreateStatsIncrementalAnalyzeTable)
+	// create stats_expression_usage
+	mustExecute(s, CreateStatsExpressionUsageTable)
+	// create stats_expressions
+	mustExecute(s, CreateStatsExpressionsTable)
 }
 
 // doBootstrapSQLFile executes SQL commands in a file as the last stage of bootstrap.
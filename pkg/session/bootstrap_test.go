@@ -2321,3 +2321,59 @@ func TestTiDBUpgradeWithDistTaskRunning(t *testing.T) {
 		testTiDBUpgradeWithDistTask(t, "insert into mysql.tidb_global_task set id = 1, task_key = 'aaa', type= 'aaa', state = 'other'", true)
 	})
 }
+
+func TestTiDBUpgradeToVer216(t *testing.T) {
+	ctx := context.Background()
+	store, dom := CreateStoreAndBootstrap(t)
+	defer func() { require.NoError(t, store.Close()) }()
+	defer variable.EnableEventScheduler.Store(variable.DefEventScheduler)
+
+	// event_scheduler is ON for a new cluster.
+	se := CreateSessionAndSetID(t, store)
+	MustExec(t, se, "use test")
+	res := MustExecToRecodeSet(t, se, "select @@global.event_scheduler")
+	chk := res.NewChunk(nil)
+	require.NoError(t, res.Next(ctx, chk))
+	require.Equal(t, int64(1), chk.GetRow(0).GetInt64(0))
+	require.NoError(t, res.Close())
+
+	ver215 := version215
+	seV215 := CreateSessionAndSetID(t, store)
+	txn, err := store.Begin()
+	require.NoError(t, err)
+	m := meta.NewMeta(txn)
+	err = m.FinishBootstrap(int64(ver215))
+	require.NoError(t, err)
+	revertVersionAndVariables(t, seV215, ver215)
+	// simulate a real ver215 where `event_scheduler` is a noop variable which isn't stored
+	MustExec(t, seV215, "delete from mysql.GLOBAL_VARIABLES where variable_name='event_scheduler'")
+	err = txn.Commit(context.Background())
+	require.NoError(t, err)
+	unsetStoreBootstrapped(store.UUID())
+	dom.Close()
+
+	// upgrade to ver216
+	domCurVer, err := BootstrapSession(store)
+	require.NoError(t, err)
+	defer domCurVer.Close()
+	seCurVer := CreateSessionAndSetID(t, store)
+	ver, err := getBootstrapVersion(seCurVer)
+	require.NoError(t, err)
+	require.Equal(t, currentBootstrapVersion, ver)
+
+	// the value in the table is set to OFF automatically
+	res = MustExecToRecodeSet(t, seCurVer, "select variable_value from mysql.GLOBAL_VARIABLES where variable_name='event_scheduler'")
+	chk = res.NewChunk(nil)
+	require.NoError(t, res.Next(ctx, chk))
+	require.Equal(t, 1, chk.NumRows())
+	require.Equal(t, "OFF", chk.GetRow(0).GetString(0))
+	require.NoError(t, res.Close())
+
+	// the global variable is also OFF
+	res = MustExecToRecodeSet(t, seCurVer, "select @@global.event_scheduler")
+	chk = res.NewChunk(nil)
+	require.NoError(t, res.Next(ctx, chk))
+	require.Equal(t, int64(0), chk.GetRow(0).GetInt64(0))
+	require.NoError(t, res.Close())
+	require.False(t, variable.EnableEventScheduler.Load())
+}
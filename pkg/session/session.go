@@ -2232,6 +2232,7 @@ var fileTransInConnKeys = []fmt.Stringer{
 	executor.LoadStatsVarKey,
 	executor.IndexAdviseVarKey,
 	executor.PlanReplayerLoadVarKey,
+	executor.ProcedureResultVarKey,
 }
 
 func (s *session) hasFileTransInConn() bool {
@@ -3586,6 +3587,7 @@ func bootstrapSessionImpl(store kv.Storage, createSessionsImpl func(store kv.Sto
 		return s
 	}
 	dom.StartTTLJobManager()
+	dom.StartEventScheduler()
 
 	analyzeCtxs, err := createSessions(store, analyzeConcurrencyQuota)
 	if err != nil {
@@ -250,6 +250,9 @@ type StatementContext struct {
 	// BindSQL used to construct the key for plan cache. It records the binding used by the stmt.
 	// If the binding is not used by the stmt, the value is empty
 	BindSQL string
+	// CardFeedbackDigest is the digest of the stmt used to look up and record the cardinality feedback.
+	// It's empty if the cardinality feedback is disabled.
+	CardFeedbackDigest string
 
 	// The several fields below are mainly for some diagnostic features, like stmt summary and slow query.
 	// We cache the values here to avoid calculating them multiple times.
@@ -372,6 +375,9 @@ type StatementContext struct {
 	// InHandleForeignKeyTrigger indicates currently are handling foreign key trigger.
 	InHandleForeignKeyTrigger bool
 
+	// InHandleTrigger indicates currently are executing the body of a trigger activated by the statement.
+	InHandleTrigger bool
+
 	// ForeignKeyTriggerCtx is the contain information for foreign key cascade execution.
 	ForeignKeyTriggerCtx struct {
 		// The SavepointName is use to do rollback when handle foreign key cascade failed.
@@ -756,8 +762,9 @@ type TableEntry struct {
 
 // AddAffectedRows adds affected rows.
 func (sc *StatementContext) AddAffectedRows(rows uint64) {
-	if sc.InHandleForeignKeyTrigger {
-		// For compatibility with MySQL, not add the affected row cause by the foreign key trigger.
+	if sc.InHandleForeignKeyTrigger || sc.InHandleTrigger {
+		// For compatibility with MySQL, not add the affected row cause by the foreign key trigger
+		// or the statements in the triggers.
 		return
 	}
 	sc.mu.Lock()
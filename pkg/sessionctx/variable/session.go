@@ -694,6 +694,14 @@ type HookContext interface {
 	GetStore() kv.Storage
 }
 
+// ProcedureContext is the context of the stored procedure or trigger that is being executed in the session.
+type ProcedureContext interface {
+	// GetProcedureVariable returns the value and type of a local variable or parameter of the procedure.
+	GetProcedureVariable(name string) (types.Datum, *types.FieldType, bool)
+	// GetTriggerColumn returns the value and type of a column of the NEW or OLD row when a trigger is executed.
+	GetTriggerColumn(row, name string) (types.Datum, *types.FieldType, bool)
+}
+
 // SessionVarsProvider provides the session variables.
 type SessionVarsProvider interface {
 	GetSessionVars() *SessionVars
@@ -859,6 +867,11 @@ type SessionVars struct {
 	// InMultiStmts indicates whether the statement is a multi-statement like `update t set a=1; update t set b=2;`.
 	InMultiStmts bool
 
+	// ProcedureContext is not nil when the statement is executed inside a stored procedure or a trigger.
+	// It is used to resolve the local variables and parameters of the procedure, and the NEW and OLD
+	// rows of the trigger.
+	ProcedureContext ProcedureContext
+
 	// AllowWriteRowID variable is currently not recommended to be turned on.
 	AllowWriteRowID bool
 
@@ -1031,6 +1044,10 @@ type SessionVars struct {
 	// EnableCascadesPlanner enables the cascades planner.
 	EnableCascadesPlanner bool
 
+	// EnableCascadesPlannerCompare enables optimizing the queries by the cascades planner besides the
+	// default planner, and reporting a warning if the chosen plans are different.
+	EnableCascadesPlannerCompare bool
+
 	// EnableWindowFunction enables the window function.
 	EnableWindowFunction bool
 
@@ -1533,6 +1550,21 @@ type SessionVars struct {
 	// Enable late materialization: push down some selection condition to tablescan.
 	EnableLateMaterialization bool
 
+	// EnableMViewRewrite indicates whether the queries matching materialized views are rewritten to read the views.
+	EnableMViewRewrite bool
+
+	// EnableCardinalityFeedback indicates whether the cardinality feedback is collected and applied.
+	EnableCardinalityFeedback bool
+
+	// CardinalityFeedbackThreshold is the q-error above which the cardinality feedback is recorded.
+	CardinalityFeedbackThreshold float64
+
+	// EnableAdaptiveJoin indicates whether the index joins are prepared to switch to the hash joins at execution time.
+	EnableAdaptiveJoin bool
+
+	// AdaptiveJoinThreshold is the number of the outer rows above which an adaptive join switches to the hash join.
+	AdaptiveJoinThreshold int
+
 	// EnableRowLevelChecksum indicates whether row level checksum is enabled.
 	EnableRowLevelChecksum bool
 
@@ -1736,6 +1768,11 @@ func (s *SessionVars) GetPreparedStmtByID(stmtID uint32) (any, error) {
 
 // InitStatementContext initializes a StatementContext, the object is reused to reduce allocation.
 func (s *SessionVars) InitStatementContext() *stmtctx.StatementContext {
+	if s.ProcedureContext != nil {
+		// The statement context of the CALL statement is still in use, so the
+		// statements in the stored procedure can't reuse the cached ones.
+		return stmtctx.NewStmtCtx()
+	}
 	sc := &s.cachedStmtCtx[0]
 	if sc == s.StmtCtx {
 		sc = &s.cachedStmtCtx[1]
@@ -2127,6 +2164,11 @@ func NewSessionVars(hctx HookContext) *SessionVars {
 		mppExchangeCompressionMode:    DefaultExchangeCompressionMode,
 		mppVersion:                    kv.MppVersionUnspecified,
 		EnableLateMaterialization:     DefTiDBOptEnableLateMaterialization,
+		EnableMViewRewrite:            DefTiDBOptEnableMViewRewrite,
+		EnableCardinalityFeedback:     DefTiDBOptEnableCardinalityFeedback,
+		CardinalityFeedbackThreshold:  DefTiDBOptCardinalityFeedbackThreshold,
+		EnableAdaptiveJoin:            DefTiDBOptEnableAdaptiveJoin,
+		AdaptiveJoinThreshold:         DefTiDBAdaptiveJoinOuterRowsThreshold,
 		TiFlashComputeDispatchPolicy:  tiflashcompute.DispatchPolicyConsistentHash,
 		ResourceGroupName:             resourcegroup.DefaultResourceGroupName,
 		DefaultCollationForUTF8MB4:    mysql.DefaultCollationName,
@@ -439,6 +439,10 @@ const (
 	// TiDBEnableCascadesPlanner is used to control whether to enable the cascades planner.
 	TiDBEnableCascadesPlanner = "tidb_enable_cascades_planner"
 
+	// TiDBEnableCascadesPlannerCompare is used to control whether to optimize the queries by both the
+	// default planner and the cascades planner, and report the difference of the chosen plans as a warning.
+	TiDBEnableCascadesPlannerCompare = "tidb_enable_cascades_planner_compare"
+
 	// TiDBSkipUTF8Check skips the UTF8 validate process, validate UTF8 has performance cost, if we can make sure
 	// the input string values are valid, we can skip the check.
 	TiDBSkipUTF8Check = "tidb_skip_utf8_check"
@@ -903,6 +907,21 @@ const (
 
 	// TiDBOptEnableLateMaterialization indicates whether to enable late materialization
 	TiDBOptEnableLateMaterialization = "tidb_opt_enable_late_materialization"
+	// TiDBOptEnableMViewRewrite indicates whether the optimizer rewrites the queries matching
+	// materialized views to read the views, the result may be stale.
+	TiDBOptEnableMViewRewrite = "tidb_opt_enable_mview_rewrite"
+	// TiDBOptEnableCardinalityFeedback indicates whether the actual row counts of the executed plans are
+	// fed back to correct the estimation of the later optimizations.
+	TiDBOptEnableCardinalityFeedback = "tidb_opt_enable_cardinality_feedback"
+	// TiDBOptCardinalityFeedbackThreshold is the q-error of an estimation above which the cardinality
+	// feedback is recorded.
+	TiDBOptCardinalityFeedbackThreshold = "tidb_opt_cardinality_feedback_threshold"
+	// TiDBOptEnableAdaptiveJoin indicates whether the index joins can switch to the hash joins at execution
+	// time if the outer side has more rows than expected.
+	TiDBOptEnableAdaptiveJoin = "tidb_opt_enable_adaptive_join"
+	// TiDBAdaptiveJoinOuterRowsThreshold is the number of the outer rows above which an adaptive join
+	// switches from the index join to the hash join.
+	TiDBAdaptiveJoinOuterRowsThreshold = "tidb_adaptive_join_outer_rows_threshold"
 	// TiDBLoadBasedReplicaReadThreshold is the wait duration threshold to enable replica read automatically.
 	TiDBLoadBasedReplicaReadThreshold = "tidb_load_based_replica_read_threshold"
 
@@ -1009,6 +1028,12 @@ const (
 	TiDBEnableAutoAnalyze = "tidb_enable_auto_analyze"
 	// TiDBEnableAutoAnalyzePriorityQueue determines whether TiDB executes automatic analysis with priority queue.
 	TiDBEnableAutoAnalyzePriorityQueue = "tidb_enable_auto_analyze_priority_queue"
+	// TiDBEnableAutoAnalyzeIncremental determines whether the automatic analysis only samples the rows
+	// written since the last analysis and merges them into the existing statistics.
+	TiDBEnableAutoAnalyzeIncremental = "tidb_enable_auto_analyze_incremental"
+	// TiDBEnableExpressionStats determines whether the statistics of the expressions frequently used in the filters,
+	// like `json_extract(c, '$.a')`, are collected by ANALYZE and used to estimate the selectivity of the filters.
+	TiDBEnableExpressionStats = "tidb_enable_expression_stats"
 	// TiDBMemOOMAction indicates what operation TiDB perform when a single SQL statement exceeds
 	// the memory quota specified by tidb_mem_quota_query and cannot be spilled to disk.
 	TiDBMemOOMAction = "tidb_mem_oom_action"
@@ -1380,6 +1405,8 @@ const (
 	DefTiDBMemQuotaAnalyze                         = -1
 	DefTiDBEnableAutoAnalyze                       = true
 	DefTiDBEnableAutoAnalyzePriorityQueue          = true
+	DefTiDBEnableAutoAnalyzeIncremental            = false
+	DefTiDBEnableExpressionStats                   = false
 	DefTiDBAnalyzeColumnOptions                    = "PREDICATE"
 	DefTiDBMemOOMAction                            = "CANCEL"
 	DefTiDBMaxAutoAnalyzeTime                      = 12 * 60 * 60
@@ -1442,6 +1469,7 @@ const (
 	DefTiDBEnablePlanReplayerCapture                  = true
 	DefTiDBIndexMergeIntersectionConcurrency          = ConcurrencyUnset
 	DefTiDBTTLJobEnable                               = true
+	DefEventScheduler                                 = true
 	DefTiDBTTLScanBatchSize                           = 500
 	DefTiDBTTLScanBatchMaxSize                        = 10240
 	DefTiDBTTLScanBatchMinSize                        = 1
@@ -1468,6 +1496,11 @@ const (
 	DefTiDBEnablePlanCacheForSubquery                 = true
 	DefTiDBLoadBasedReplicaReadThreshold              = time.Second
 	DefTiDBOptEnableLateMaterialization               = true
+	DefTiDBOptEnableMViewRewrite                      = false
+	DefTiDBOptEnableCardinalityFeedback               = false
+	DefTiDBOptCardinalityFeedbackThreshold            = 10.0
+	DefTiDBOptEnableAdaptiveJoin                      = false
+	DefTiDBAdaptiveJoinOuterRowsThreshold             = 10000
 	DefTiDBOptOrderingIdxSelThresh                    = 0.0
 	DefTiDBOptOrderingIdxSelRatio                     = -1
 	DefTiDBOptEnableMPPSharedCTEExecution             = false
@@ -1510,6 +1543,8 @@ var (
 	ProcessGeneralLog              = atomic.NewBool(false)
 	RunAutoAnalyze                 = atomic.NewBool(DefTiDBEnableAutoAnalyze)
 	EnableAutoAnalyzePriorityQueue = atomic.NewBool(DefTiDBEnableAutoAnalyzePriorityQueue)
+	EnableAutoAnalyzeIncremental   = atomic.NewBool(DefTiDBEnableAutoAnalyzeIncremental)
+	EnableExpressionStats          = atomic.NewBool(DefTiDBEnableExpressionStats)
 	// AnalyzeColumnOptions is a global variable that indicates the default column choice for ANALYZE.
 	// The value of this variable is a string that can be one of the following values:
 	// "PREDICATE", "ALL".
@@ -1581,6 +1616,7 @@ var (
 	PasswordValidtaionNumberCount      = atomic.NewInt32(1)
 	PasswordValidationSpecialCharCount = atomic.NewInt32(1)
 	EnableTTLJob                       = atomic.NewBool(DefTiDBTTLJobEnable)
+	EnableEventScheduler               = atomic.NewBool(DefEventScheduler)
 	TTLScanBatchSize                   = atomic.NewInt64(DefTiDBTTLScanBatchSize)
 	TTLDeleteBatchSize                 = atomic.NewInt64(DefTiDBTTLDeleteBatchSize)
 	TTLDeleteRateLimit                 = atomic.NewInt64(DefTiDBTTLDeleteRateLimit)
@@ -109,6 +109,13 @@ type AnalyzeResults struct {
 	// In conclusion, when saving the analyze result for mv index, we need to store the index stats, as for the
 	// table-level fields, we only need to update the version.
 	ForMVIndex bool
+	// IncrementalMaxHandle is the max handle of the rows which have been analyzed. It's saved for the next
+	// incremental analyze, which only samples the rows whose handle is larger than it. It's nil if the table
+	// is not analyzed by the incremental analyze.
+	IncrementalMaxHandle *int64
+	// ExpressionStats is the stats of the expressions used in the filters. They replace all the existing
+	// expression stats of the table if it's not nil.
+	ExpressionStats []*ExpressionStats
 }
 
 // DestroyAndPutToPool destroys the result and put it to the pool.
@@ -17,12 +17,15 @@ package statistics
 import (
 	"context"
 	"encoding/json"
+	"slices"
 
 	"github.com/pingcap/errors"
 	"github.com/pingcap/tidb/pkg/kv"
 	"github.com/pingcap/tidb/pkg/parser/ast"
 	"github.com/pingcap/tidb/pkg/parser/model"
 	"github.com/pingcap/tidb/pkg/sessionctx"
+	"github.com/pingcap/tidb/pkg/types"
+	"github.com/pingcap/tidb/pkg/util/codec"
 	"github.com/pingcap/tidb/pkg/util/logutil"
 	"go.uber.org/zap"
 )
@@ -63,8 +66,10 @@ func BuildExtendedStats(sctx sessionctx.Context,
 
 func fillExtendedStatsItemVals(sctx sessionctx.Context, item *ExtendedStatsItem, cols []*model.ColumnInfo, collectors []*SampleCollector) *ExtendedStatsItem {
 	switch item.Tp {
-	case ast.StatsTypeCardinality, ast.StatsTypeDependency:
-		return nil
+	case ast.StatsTypeCardinality:
+		return fillExtStatsCardinalityVals(sctx, item, cols, collectors)
+	case ast.StatsTypeDependency:
+		return fillExtStatsDependencyVals(sctx, item, cols, collectors)
 	case ast.StatsTypeCorrelation:
 		return fillExtStatsCorrVals(sctx, item, cols, collectors)
 	}
@@ -140,3 +145,133 @@ func fillExtStatsCorrVals(sctx sessionctx.Context, item *ExtendedStatsItem, cols
 	item.ScalarVals = (itemsCount*corrXYSum - corrXSum*corrXSum) / (itemsCount*corrX2Sum - corrXSum*corrXSum)
 	return item
 }
+
+// extStatsSampleRows returns the values of the columns of the extended stats in
+// the sampled rows, and the total row count of the table. The samples of the
+// columns are joined by their Ordinals, so it only works for the row samples of
+// analyze version 2, in which a SampleItem.Ordinal is the position of the sampled
+// row. A column is NULL in a row if the row is missing in the samples of it. The
+// rows whose columns are all NULL are not in any samples, so they are ignored.
+func extStatsSampleRows(item *ExtendedStatsItem, cols []*model.ColumnInfo, collectors []*SampleCollector) (rows [][]types.Datum, rowCount int64, ok bool) {
+	colOffsets := make([]int, 0, len(item.ColIDs))
+	for _, id := range item.ColIDs {
+		for i, col := range cols {
+			if col.ID == id {
+				colOffsets = append(colOffsets, i)
+				break
+			}
+		}
+	}
+	if len(colOffsets) != len(item.ColIDs) || len(colOffsets) < 2 {
+		return nil, 0, false
+	}
+	ordinal2Row := make(map[int][]types.Datum)
+	for i, offset := range colOffsets {
+		if offset >= len(collectors) || collectors[offset] == nil {
+			return nil, 0, false
+		}
+		samples := collectors[offset].Samples
+		for _, sample := range samples {
+			row, ok := ordinal2Row[sample.Ordinal]
+			if !ok {
+				row = make([]types.Datum, len(colOffsets))
+				ordinal2Row[sample.Ordinal] = row
+			} else if !row[i].IsNull() {
+				// The samples are not from the same rows if the ordinals are duplicated.
+				return nil, 0, false
+			}
+			row[i] = sample.Value
+		}
+	}
+	ordinals := make([]int, 0, len(ordinal2Row))
+	for ordinal := range ordinal2Row {
+		ordinals = append(ordinals, ordinal)
+	}
+	slices.Sort(ordinals)
+	rows = make([][]types.Datum, 0, len(ordinals))
+	for _, ordinal := range ordinals {
+		rows = append(rows, ordinal2Row[ordinal])
+	}
+	c := collectors[colOffsets[0]]
+	return rows, c.Count + c.NullCount, true
+}
+
+// fillExtStatsCardinalityVals computes the NDV of the combinations of the columns,
+// the NDV of the table is estimated from the samples in the same way as the NDV of
+// a single column.
+func fillExtStatsCardinalityVals(sctx sessionctx.Context, item *ExtendedStatsItem, cols []*model.ColumnInfo, collectors []*SampleCollector) *ExtendedStatsItem {
+	rows, rowCount, ok := extStatsSampleRows(item, cols, collectors)
+	if !ok {
+		return nil
+	}
+	if len(rows) == 0 {
+		item.ScalarVals = 0
+		return item
+	}
+	loc := sctx.GetSessionVars().StmtCtx.TimeZone()
+	counts := make(map[string]uint64, len(rows))
+	var key []byte
+	var err error
+	for _, row := range rows {
+		key, err = codec.EncodeKey(loc, key[:0], row...)
+		if err != nil {
+			return nil
+		}
+		counts[string(key)]++
+	}
+	var onlyOnceItems uint64
+	for _, cnt := range counts {
+		if cnt == 1 {
+			onlyOnceItems++
+		}
+	}
+	sampleSize := uint64(len(rows))
+	item.ScalarVals = float64(estimateNDVFromSample(sampleSize, uint64(len(counts)), onlyOnceItems, max(uint64(rowCount), sampleSize)))
+	return item
+}
+
+// fillExtStatsDependencyVals computes the degree of the functional dependency from
+// the first column to the second column, which is the fraction of rows whose value
+// of the second column is the most common value of it among the rows with the same
+// value of the first column. The degree is 1 if the first column determines the
+// second column.
+func fillExtStatsDependencyVals(sctx sessionctx.Context, item *ExtendedStatsItem, cols []*model.ColumnInfo, collectors []*SampleCollector) *ExtendedStatsItem {
+	rows, _, ok := extStatsSampleRows(item, cols, collectors)
+	if !ok || len(item.ColIDs) != 2 {
+		return nil
+	}
+	if len(rows) == 0 {
+		item.ScalarVals = 0
+		return item
+	}
+	loc := sctx.GetSessionVars().StmtCtx.TimeZone()
+	groups := make(map[string]map[string]int, len(rows))
+	var keyX, keyY []byte
+	var err error
+	for _, row := range rows {
+		keyX, err = codec.EncodeKey(loc, keyX[:0], row[0])
+		if err != nil {
+			return nil
+		}
+		keyY, err = codec.EncodeKey(loc, keyY[:0], row[1])
+		if err != nil {
+			return nil
+		}
+		group, ok := groups[string(keyX)]
+		if !ok {
+			group = make(map[string]int)
+			groups[string(keyX)] = group
+		}
+		group[string(keyY)]++
+	}
+	supported := 0
+	for _, group := range groups {
+		maxCnt := 0
+		for _, cnt := range group {
+			maxCnt = max(maxCnt, cnt)
+		}
+		supported += maxCnt
+	}
+	item.ScalarVals = float64(supported) / float64(len(rows))
+	return item
+}
@@ -26,10 +26,20 @@ func calculateEstimateNDV(h *topNHelper, rowCount uint64) (ndv uint64, scaleRati
 	if onlyOnceItems == sampleSize {
 		// Assume this is a unique column, so do not scale up the count of elements
 		return rowCount, 1
+	}
+	return estimateNDVFromSample(sampleSize, sampleNDV, onlyOnceItems, rowCount), scaleRatio
+}
+
+// estimateNDVFromSample estimates the ndv of the data with rowCount rows from a sample
+// of it, onlyOnceItems is the number of values occurred only once in the sample.
+func estimateNDVFromSample(sampleSize, sampleNDV, onlyOnceItems, rowCount uint64) (ndv uint64) {
+	if onlyOnceItems == sampleSize {
+		// Assume this is a unique column, so do not scale up the count of elements
+		return rowCount
 	} else if onlyOnceItems == 0 {
 		// Assume data only consists of sampled data
 		// Nothing to do, no change with scale ratio
-		return sampleNDV, scaleRatio
+		return sampleNDV
 	}
 	// Charikar, Moses, et al. "Towards estimation error guarantees for distinct values."
 	// Proceedings of the nineteenth ACM SIGMOD-SIGACT-SIGART symposium on Principles of database systems. ACM, 2000.
@@ -45,5 +55,5 @@ func calculateEstimateNDV(h *topNHelper, rowCount uint64) (ndv uint64, scaleRati
 	ndv = uint64(math.Sqrt(rowCountN/n)*f1 + d - f1 + 0.5)
 	ndv = max(ndv, sampleNDV)
 	ndv = min(ndv, rowCount)
-	return ndv, scaleRatio
+	return ndv
 }
@@ -0,0 +1,365 @@
+// Copyright 2024 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package statistics
+
+import (
+	"hash/fnv"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/pingcap/tidb/pkg/expression"
+	"github.com/pingcap/tidb/pkg/parser/ast"
+	"github.com/pingcap/tidb/pkg/parser/model"
+	"github.com/pingcap/tidb/pkg/parser/mysql"
+	"github.com/pingcap/tidb/pkg/sessionctx"
+	"github.com/pingcap/tidb/pkg/types"
+	"github.com/pingcap/tidb/pkg/util/codec"
+	"github.com/pingcap/tidb/pkg/util/memory"
+	"github.com/pingcap/tidb/pkg/util/sqlescape"
+	"github.com/pingcap/tipb/go-tipb"
+)
+
+// ExpressionStats is the statistics of an expression on the columns of a table, e.g. `json_extract(c, '$.a')`.
+// They are collected by ANALYZE for the expressions frequently used in the filters, so that the filters on
+// the expressions can be estimated without creating generated columns for them.
+type ExpressionStats struct {
+	// Histogram is the histogram of the expression values. Its bounds are stored as bytes, they are converted
+	// to the type of the expression when being used.
+	Histogram *Histogram
+	TopN      *TopN
+	// Hash identifies the expression, see ExpressionStatsKey.
+	Hash int64
+}
+
+// ExpressionStatsColl is a collection of cached items for mysql.stats_expressions records.
+type ExpressionStatsColl struct {
+	// Stats maps the hash of the expression to its stats.
+	Stats map[int64]*ExpressionStats
+}
+
+// NewExpressionStatsColl allocate an ExpressionStatsColl struct.
+func NewExpressionStatsColl() *ExpressionStatsColl {
+	return &ExpressionStatsColl{Stats: make(map[int64]*ExpressionStats)}
+}
+
+// BuildExpressionStats builds the stats of an expression from its non-NULL values on the sampled rows. count is
+// the number of the rows in the table, and nullCount is the estimated number of the rows where the value is NULL.
+func BuildExpressionStats(
+	ctx sessionctx.Context,
+	numBuckets, numTopN int,
+	hash int64,
+	samples []*SampleItem,
+	count, nullCount int64,
+	tp *types.FieldType,
+	memTracker *memory.Tracker,
+) (*ExpressionStats, error) {
+	sc := ctx.GetSessionVars().StmtCtx
+	fms := NewFMSketch(MaxSketchSize)
+	valueCounts := make(map[string]uint64, len(samples))
+	for _, item := range samples {
+		if err := fms.InsertValue(sc, item.Value); err != nil {
+			return nil, err
+		}
+		key, err := codec.EncodeKey(sc.TimeZone(), nil, item.Value)
+		if err != nil {
+			return nil, err
+		}
+		valueCounts[string(key)]++
+	}
+	collector := &SampleCollector{
+		Samples:   samples,
+		NullCount: nullCount,
+		Count:     count - nullCount,
+		FMSketch:  fms,
+	}
+	hg, topN, err := BuildHistAndTopN(ctx, numBuckets, numTopN, hash, collector, tp, true, memTracker, false)
+	if err != nil {
+		return nil, err
+	}
+	// Unlike the columns, the FMSketch is built from the samples rather than all the rows, so the NDV is
+	// estimated from the samples instead.
+	if len(samples) > 0 && collector.Count > 0 {
+		onlyOnceItems := uint64(0)
+		for _, cnt := range valueCounts {
+			if cnt == 1 {
+				onlyOnceItems++
+			}
+		}
+		hg.NDV = int64(estimateNDVFromSample(uint64(len(samples)), uint64(len(valueCounts)), onlyOnceItems, uint64(collector.Count)))
+	}
+	return &ExpressionStats{Histogram: hg, TopN: topN, Hash: hash}, nil
+}
+
+// ToColumn returns the column stats of a column of type tp, which takes the place of the expression in the
+// filters, so the filters on the expression can be estimated in the same way as the ones on the columns.
+func (s *ExpressionStats) ToColumn(tp *types.FieldType) (*Column, error) {
+	hg := s.Histogram
+	// The bounds of the string values are the collate keys, see HistogramFromStorageWithPriority.
+	if tp.EvalType() != types.ETString || tp.GetType() == mysql.TypeEnum || tp.GetType() == mysql.TypeSet {
+		var err error
+		hg, err = hg.ConvertTo(UTCWithAllowInvalidDateCtx, tp)
+		if err != nil {
+			return nil, err
+		}
+		hg.PreCalculateScalar()
+	}
+	return &Column{
+		Histogram:         *hg,
+		TopN:              s.TopN,
+		Info:              &model.ColumnInfo{ID: s.Hash, FieldType: *tp},
+		StatsLoadedStatus: NewStatsFullLoadStatus(),
+		StatsVer:          Version2,
+	}, nil
+}
+
+// EncodeExpressionStats encodes the histogram and the TopN of the expression stats. The bounds of the histogram
+// are converted to bytes like the ones in mysql.stats_buckets.
+func EncodeExpressionStats(tctx types.Context, item *ExpressionStats) (histData, topNData []byte, err error) {
+	hg := NewHistogram(item.Hash, item.Histogram.NDV, item.Histogram.NullCount, 0,
+		types.NewFieldType(mysql.TypeBlob), item.Histogram.Len(), item.Histogram.TotColSize)
+	for i := 0; i < item.Histogram.Len(); i++ {
+		lower, err := item.Histogram.GetLower(i).ConvertTo(tctx, types.NewFieldType(mysql.TypeBlob))
+		if err != nil {
+			return nil, nil, err
+		}
+		upper, err := item.Histogram.GetUpper(i).ConvertTo(tctx, types.NewFieldType(mysql.TypeBlob))
+		if err != nil {
+			return nil, nil, err
+		}
+		bkt := item.Histogram.Buckets[i]
+		hg.AppendBucketWithNDV(&lower, &upper, bkt.Count, bkt.Repeat, bkt.NDV)
+	}
+	histData, err = HistogramToProto(hg).Marshal()
+	if err != nil {
+		return nil, nil, err
+	}
+	topNData, err = CMSketchToProto(nil, item.TopN).Marshal()
+	return histData, topNData, err
+}
+
+// DecodeExpressionStats decodes the expression stats encoded by EncodeExpressionStats.
+func DecodeExpressionStats(hash, nullCount int64, histData, topNData []byte) (*ExpressionStats, error) {
+	protoHg := &tipb.Histogram{}
+	if err := protoHg.Unmarshal(histData); err != nil {
+		return nil, err
+	}
+	hg := HistogramFromProto(protoHg)
+	hg.ID = hash
+	hg.NullCount = nullCount
+	hg.PreCalculateScalar()
+	protoTopN := &tipb.CMSketch{}
+	if err := protoTopN.Unmarshal(topNData); err != nil {
+		return nil, err
+	}
+	return &ExpressionStats{Histogram: hg, TopN: TopNFromProto(protoTopN.TopN), Hash: hash}, nil
+}
+
+// ExpressionComparedWithConstants returns the expression compared with the constants in the filter, like the
+// `json_extract(c, '$.a')` in `json_extract(c, '$.a') = 1` and `json_extract(c, '$.a') in (1, 2)`. pos is the position
+// of the expression in the arguments of the filter.
+func ExpressionComparedWithConstants(cond expression.Expression) (expr expression.Expression, pos int, ok bool) {
+	sf, isFunc := cond.(*expression.ScalarFunction)
+	if !isFunc {
+		return nil, 0, false
+	}
+	args := sf.GetArgs()
+	switch sf.FuncName.L {
+	case ast.EQ, ast.NE, ast.LT, ast.LE, ast.GT, ast.GE:
+		if _, isConst := args[1].(*expression.Constant); isConst {
+			pos = 0
+		} else if _, isConst := args[0].(*expression.Constant); isConst {
+			pos = 1
+		} else {
+			return nil, 0, false
+		}
+	case ast.In:
+		for _, arg := range args[1:] {
+			if _, isConst := arg.(*expression.Constant); !isConst {
+				return nil, 0, false
+			}
+		}
+	default:
+		return nil, 0, false
+	}
+	if _, isFunc := args[pos].(*expression.ScalarFunction); !isFunc {
+		return nil, 0, false
+	}
+	return args[pos], pos, true
+}
+
+// ExpressionStatsKey returns the hash which identifies the stats of the expression. ok is false if the stats
+// of the expression can't be collected, e.g. the expression is a column or it's not deterministic.
+func ExpressionStatsKey(expr expression.Expression) (hash int64, ok bool) {
+	_, hash, ok = ExpressionStatsSQL(expr, nil)
+	return hash, ok
+}
+
+// ExpressionStatsSQL returns the SQL text of the expression, which is used by ANALYZE to rebuild the expression,
+// and the hash which identifies the stats of the expression. The columns are named by tblInfo, only the hash is
+// returned if tblInfo is nil.
+// The hash is computed from the column IDs rather than the column names, so it isn't affected by renaming columns.
+func ExpressionStatsSQL(expr expression.Expression, tblInfo *model.TableInfo) (sql string, hash int64, ok bool) {
+	sf, isFunc := expr.(*expression.ScalarFunction)
+	if !isFunc || !expression.IsImmutableFunc(sf) || len(expression.ExtractColumns(sf)) == 0 {
+		return "", 0, false
+	}
+	var key, sqlText strings.Builder
+	w := &exprStatsWriter{key: &key, tblInfo: tblInfo}
+	if tblInfo != nil {
+		w.sql = &sqlText
+	}
+	if !w.write(sf) {
+		return "", 0, false
+	}
+	h := fnv.New64a()
+	_, _ = h.Write([]byte(key.String()))
+	return sqlText.String(), int64(h.Sum64()), true
+}
+
+type exprStatsWriter struct {
+	key     *strings.Builder
+	sql     *strings.Builder
+	tblInfo *model.TableInfo
+}
+
+func (w *exprStatsWriter) write(expr expression.Expression) bool {
+	switch x := expr.(type) {
+	case *expression.Column:
+		if x.ID <= 0 {
+			return false
+		}
+		w.key.WriteString("#" + strconv.FormatInt(x.ID, 10))
+		if w.sql != nil {
+			col := model.FindColumnInfoByID(w.tblInfo.Columns, x.ID)
+			if col == nil {
+				return false
+			}
+			w.sql.WriteString(sqlescape.MustEscapeSQL("%n", col.Name.O))
+		}
+	case *expression.Constant:
+		if x.ParamMarker != nil || x.DeferredExpr != nil {
+			return false
+		}
+		literal, ok := exprStatsLiteral(x.Value)
+		if !ok {
+			return false
+		}
+		w.key.WriteString(literal)
+		if w.sql != nil {
+			w.sql.WriteString(literal)
+		}
+	case *expression.ScalarFunction:
+		// The implicit casts can't be told from the explicit ones, so the expressions with casts are skipped.
+		if x.FuncName.L == ast.Cast {
+			return false
+		}
+		w.key.WriteString(x.FuncName.L + "(")
+		if w.sql != nil {
+			w.sql.WriteString(sqlescape.MustEscapeSQL("%n", x.FuncName.L) + "(")
+		}
+		for i, arg := range x.GetArgs() {
+			if i > 0 {
+				w.key.WriteString(",")
+				if w.sql != nil {
+					w.sql.WriteString(", ")
+				}
+			}
+			if !w.write(arg) {
+				return false
+			}
+		}
+		w.key.WriteString(")")
+		if w.sql != nil {
+			w.sql.WriteString(")")
+		}
+	default:
+		return false
+	}
+	return true
+}
+
+func exprStatsLiteral(d types.Datum) (string, bool) {
+	switch d.Kind() {
+	case types.KindNull:
+		return "NULL", true
+	case types.KindInt64:
+		return strconv.FormatInt(d.GetInt64(), 10), true
+	case types.KindUint64:
+		return strconv.FormatUint(d.GetUint64(), 10), true
+	case types.KindFloat32, types.KindFloat64:
+		// Use the exponent notation so that it's parsed as a float again.
+		return strconv.FormatFloat(d.GetFloat64(), 'e', -1, 64), true
+	case types.KindMysqlDecimal:
+		return d.GetMysqlDecimal().String(), true
+	case types.KindString, types.KindBytes:
+		return sqlescape.MustEscapeSQL("%?", d.GetString()), true
+	}
+	return "", false
+}
+
+// ExpressionItemID identifies an expression of a table whose stats may be collected.
+type ExpressionItemID struct {
+	TableID int64
+	Hash    int64
+}
+
+// ExpressionUsage records the SQL text of an expression used in the filters and the last time when it's used.
+type ExpressionUsage struct {
+	LastUsedAt time.Time
+	SQL        string
+}
+
+// ExpressionStatsUsage collects the expressions used in the filters. They are dumped to mysql.stats_expression_usage
+// periodically, and the stats of them are collected by the next ANALYZE.
+var ExpressionStatsUsage = &expressionStatsUsage{items: make(map[ExpressionItemID]ExpressionUsage)}
+
+type expressionStatsUsage struct {
+	items map[ExpressionItemID]ExpressionUsage
+	mu    sync.Mutex
+}
+
+// Insert records that the expression is used at t.
+func (u *expressionStatsUsage) Insert(id ExpressionItemID, sql string, t time.Time) {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+	u.merge(id, ExpressionUsage{LastUsedAt: t, SQL: sql})
+}
+
+// GetUsageAndReset returns the collected usage and resets the collector.
+func (u *expressionStatsUsage) GetUsageAndReset() map[ExpressionItemID]ExpressionUsage {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+	ret := u.items
+	u.items = make(map[ExpressionItemID]ExpressionUsage)
+	return ret
+}
+
+// Merge merges the usage back into the collector, it's used when the usage fails to be dumped.
+func (u *expressionStatsUsage) Merge(other map[ExpressionItemID]ExpressionUsage) {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+	for id, usage := range other {
+		u.merge(id, usage)
+	}
+}
+
+func (u *expressionStatsUsage) merge(id ExpressionItemID, usage ExpressionUsage) {
+	if old, ok := u.items[id]; ok && old.LastUsedAt.After(usage.LastUsedAt) {
+		return
+	}
+	u.items[id] = usage
+}
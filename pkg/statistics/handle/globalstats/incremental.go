@@ -0,0 +1,63 @@
+// Copyright 2024 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package globalstats
+
+import (
+	"github.com/pingcap/tidb/pkg/parser/ast"
+	"github.com/pingcap/tidb/pkg/sessionctx"
+	"github.com/pingcap/tidb/pkg/statistics"
+	"github.com/tiancaiamao/gp"
+)
+
+// MergeIncrementalStats merges the stats of the rows written since the last analyze into the existing stats
+// of a column or an index. They are merged in the same way as the stats of two partitions are merged into
+// the global stats. The FMSketch of the existing stats is merged into newFms.
+// Note that the histograms may be modified when merging the TopN.
+func MergeIncrementalStats(
+	sc sessionctx.Context,
+	gpool *gp.Pool,
+	opts map[ast.AnalyzeOptionType]uint64,
+	isIndex bool,
+	oldHg, newHg *statistics.Histogram,
+	oldTopN, newTopN *statistics.TopN,
+	oldFms, newFms *statistics.FMSketch,
+) (*statistics.Histogram, *statistics.TopN, error) {
+	newFms.MergeFMSketch(oldFms)
+	oldCount := oldHg.TotalRowCount() + float64(oldTopN.TotalCount())
+	newCount := newHg.TotalRowCount() + float64(newTopN.TotalCount())
+	correlation := oldHg.Correlation
+	if oldCount+newCount > 0 {
+		correlation = (oldHg.Correlation*oldCount + newHg.Correlation*newCount) / (oldCount + newCount)
+	}
+
+	wrapper := NewStatsWrapper([]*statistics.Histogram{oldHg, newHg}, []*statistics.TopN{oldTopN, newTopN})
+	topN, poppedTopN, hists, err := mergeGlobalStatsTopN(gpool, sc, wrapper,
+		sc.GetSessionVars().StmtCtx.TimeZone(), statistics.Version2, uint32(opts[ast.AnalyzeOptNumTopN]), isIndex)
+	if err != nil {
+		return nil, nil, err
+	}
+	hg, err := statistics.MergePartitionHist2GlobalHist(sc.GetSessionVars().StmtCtx, hists, poppedTopN,
+		int64(opts[ast.AnalyzeOptNumBuckets]), isIndex)
+	if err != nil {
+		return nil, nil, err
+	}
+	// NOTICE: after merging bucket NDVs have the trend to be underestimated, so for safe we don't use them.
+	for j := range hg.Buckets {
+		hg.Buckets[j].NDV = 0
+	}
+	hg.NDV = min(newFms.NDV(), int64(oldCount+newCount))
+	hg.Correlation = correlation
+	return hg, topN, nil
+}
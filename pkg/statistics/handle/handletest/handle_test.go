@@ -645,6 +645,102 @@ func TestCorrelationStatsCompute(t *testing.T) {
 	))
 }
 
+func TestCardinalityDependencyStatsCompute(t *testing.T) {
+	store, dom := testkit.CreateMockStoreAndDomain(t)
+	tk := testkit.NewTestKit(t, store)
+	tk.MustExec("set session tidb_enable_extended_stats = on")
+	tk.MustExec("set @@session.tidb_analyze_version=2")
+	tk.MustExec("use test")
+	tk.MustExec("create table t(a int, b int, c int)")
+	values := make([]string, 0, 100)
+	for i := 0; i < 100; i++ {
+		values = append(values, fmt.Sprintf("(%d, %d, %d)", i%10, i%10, i%7))
+	}
+	tk.MustExec("insert into t values " + strings.Join(values, ","))
+	err := tk.ExecToErr("alter table t add stats_extended s1 cardinality(a)")
+	require.EqualError(t, err, "Only support Cardinality statistics type on at least 2 columns")
+	err = tk.ExecToErr("alter table t add stats_extended s1 dependency(a,b,c)")
+	require.EqualError(t, err, "Only support Correlation and Dependency statistics types on 2 columns")
+	tk.MustExec("alter table t add stats_extended s1 cardinality(a,b)")
+	tk.MustExec("alter table t add stats_extended s2 cardinality(a,b,c)")
+	tk.MustExec("alter table t add stats_extended s3 dependency(a,b)")
+	tk.MustExec("alter table t add stats_extended s4 dependency(a,c)")
+	tk.MustExec("analyze table t")
+	tk.MustQuery("select name, type, column_ids, stats, status from mysql.stats_extended").Sort().Check(testkit.Rows(
+		"s1 0 [1,2] 10.000000 1",
+		"s2 0 [1,2,3] 70.000000 1",
+		"s3 1 [1,2] 1.000000 1",
+		"s4 1 [1,3] 0.200000 1",
+	))
+	require.NoError(t, dom.StatsHandle().Update(context.Background(), dom.InfoSchema()))
+	rows := tk.MustQuery("show stats_extended where db_name = 'test' and table_name = 't'").Sort().Rows()
+	require.Len(t, rows, 4)
+	require.Equal(t, []any{"s1", "[a,b]", "cardinality", "10.000000"}, rows[0][2:6])
+	require.Equal(t, []any{"s2", "[a,b,c]", "cardinality", "70.000000"}, rows[1][2:6])
+	require.Equal(t, []any{"s3", "[a,b]", "dependency", "1.000000"}, rows[2][2:6])
+	require.Equal(t, []any{"s4", "[a,c]", "dependency", "0.200000"}, rows[3][2:6])
+
+	// The correlated columns are not estimated as independent ones.
+	rows = tk.MustQuery("explain format = 'brief' select * from t where a = 1 and b = 1").Rows()
+	require.Equal(t, "10.00", rows[0][1])
+	rows = tk.MustQuery("explain format = 'brief' select * from t where a = 1 and c = 1").Rows()
+	require.Equal(t, "3.20", rows[0][1])
+	rows = tk.MustQuery("explain format = 'brief' select a, b, c from t group by a, b, c").Rows()
+	require.Equal(t, "70.00", rows[0][1])
+	rows = tk.MustQuery("explain format = 'brief' select a, c from t group by a, c").Rows()
+	require.Equal(t, "58.00", rows[0][1])
+	tk.MustExec("set session tidb_enable_extended_stats = off")
+	rows = tk.MustQuery("explain format = 'brief' select * from t where a = 1 and b = 1").Rows()
+	require.Equal(t, "1.00", rows[0][1])
+}
+
+func TestExpressionStats(t *testing.T) {
+	store, dom := testkit.CreateMockStoreAndDomain(t)
+	tk := testkit.NewTestKit(t, store)
+	h := dom.StatsHandle()
+	tk.MustExec("set @@global.tidb_enable_expression_stats = on")
+	defer tk.MustExec("set @@global.tidb_enable_expression_stats = default")
+	tk.MustExec("set @@session.tidb_analyze_version=2")
+	tk.MustExec("use test")
+	tk.MustExec("create table t(a int, b json)")
+	values := make([]string, 0, 100)
+	for i := 0; i < 100; i++ {
+		status := "paid"
+		if i%10 == 0 {
+			status = "new"
+		}
+		values = append(values, fmt.Sprintf(`(%d, '{"status": "%s"}')`, i, status))
+	}
+	tk.MustExec("insert into t values " + strings.Join(values, ","))
+	tk.MustExec("analyze table t")
+	require.NoError(t, h.Update(context.Background(), dom.InfoSchema()))
+
+	// Without the expression stats, the default selectivity is used.
+	rows := tk.MustQuery(`explain format = 'brief' select * from t where b->>'$.status' = 'new'`).Rows()
+	require.Equal(t, "80.00", rows[0][1])
+	require.NoError(t, h.DumpColStatsUsageToKV())
+	tk.MustQuery("select expr from mysql.stats_expression_usage").Check(testkit.Rows(
+		"`json_unquote`(`json_extract`(`b`, '$.status'))",
+	))
+
+	tk.MustExec("analyze table t")
+	tk.MustQuery("select count(*) from mysql.stats_expressions").Check(testkit.Rows("1"))
+	require.NoError(t, h.Update(context.Background(), dom.InfoSchema()))
+	rows = tk.MustQuery(`explain format = 'brief' select * from t where b->>'$.status' = 'new'`).Rows()
+	require.Equal(t, "10.00", rows[0][1])
+	rows = tk.MustQuery(`explain format = 'brief' select * from t where b->>'$.status' = 'paid'`).Rows()
+	require.Equal(t, "90.00", rows[0][1])
+
+	// The expression stats are not used once the variable is turned off.
+	tk.MustExec("set @@global.tidb_enable_expression_stats = off")
+	rows = tk.MustQuery(`explain format = 'brief' select * from t where b->>'$.status' = 'new'`).Rows()
+	require.Equal(t, "80.00", rows[0][1])
+
+	// The expression stats are removed with the table stats.
+	tk.MustExec("drop stats t")
+	tk.MustQuery("select count(*) from mysql.stats_expressions").Check(testkit.Rows("0"))
+}
+
 func TestSyncStatsExtendedRemoval(t *testing.T) {
 	store, dom := testkit.CreateMockStoreAndDomain(t)
 	tk := testkit.NewTestKit(t, store)
@@ -159,6 +159,15 @@ func DeleteTableStatsFromKV(sctx sessionctx.Context, statsIDs []int64) (err erro
 		if _, err = util.Exec(sctx, "delete from mysql.analyze_options where table_id = %?", statsID); err != nil {
 			return err
 		}
+		if _, err = util.Exec(sctx, "delete from mysql.stats_incremental_analyze where table_id = %?", statsID); err != nil {
+			return err
+		}
+		if _, err = util.Exec(sctx, "delete from mysql.stats_expressions where table_id = %?", statsID); err != nil {
+			return err
+		}
+		if _, err = util.Exec(sctx, "delete from mysql.stats_expression_usage where table_id = %?", statsID); err != nil {
+			return err
+		}
 		if _, err = util.Exec(sctx, lockstats.DeleteLockSQL, statsID); err != nil {
 			return err
 		}
@@ -252,7 +252,7 @@ func ExtendedStatsFromStorage(sctx sessionctx.Context, table *statistics.Table,
 				return nil, err
 			}
 			statsStr := row.GetString(4)
-			if item.Tp == ast.StatsTypeCardinality || item.Tp == ast.StatsTypeCorrelation {
+			if item.Tp == ast.StatsTypeCardinality || item.Tp == ast.StatsTypeCorrelation || item.Tp == ast.StatsTypeDependency {
 				if statsStr != "" {
 					item.ScalarVals, err = strconv.ParseFloat(statsStr, 64)
 					if err != nil {
@@ -270,6 +270,25 @@ func ExtendedStatsFromStorage(sctx sessionctx.Context, table *statistics.Table,
 	return table, nil
 }
 
+// ExpressionStatsFromStorage reads the stats of the expressions from storage.
+func ExpressionStatsFromStorage(sctx sessionctx.Context, table *statistics.Table, tableID int64) (*statistics.Table, error) {
+	rows, _, err := util.ExecRows(sctx, "select expr_hash, null_count, histogram, top_n from mysql.stats_expressions where table_id = %?", tableID)
+	if err != nil || len(rows) == 0 {
+		table.ExpressionStats = nil
+		return table, nil
+	}
+	table.ExpressionStats = statistics.NewExpressionStatsColl()
+	for _, row := range rows {
+		item, err := statistics.DecodeExpressionStats(row.GetInt64(0), row.GetInt64(1), row.GetBytes(2), row.GetBytes(3))
+		if err != nil {
+			statslogutil.StatsLogger().Error("decode expression stats failed", zap.Int64("table_id", tableID), zap.Error(err))
+			return nil, err
+		}
+		table.ExpressionStats.Stats[item.Hash] = item
+	}
+	return table, nil
+}
+
 func indexStatsFromStorage(sctx sessionctx.Context, row chunk.Row, table *statistics.Table, tableInfo *model.TableInfo, loadAll bool, lease time.Duration, tracker *memory.Tracker) error {
 	histID := row.GetInt64(2)
 	distinct := row.GetInt64(3)
@@ -528,7 +547,11 @@ func TableStatsFromStorage(sctx sessionctx.Context, snapshot uint64, tableInfo *
 			return nil, err
 		}
 	}
-	return ExtendedStatsFromStorage(sctx, table, tableID, loadAll)
+	table, err = ExtendedStatsFromStorage(sctx, table, tableID, loadAll)
+	if err != nil {
+		return nil, err
+	}
+	return ExpressionStatsFromStorage(sctx, table, tableID)
 }
 
 // LoadHistogram will load histogram from storage.
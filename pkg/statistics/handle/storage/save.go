@@ -123,7 +123,9 @@ func saveBucketsToStorage(sctx sessionctx.Context, tableID int64, isIndex int, h
 // SaveTableStatsToStorage saves the stats of a table to storage.
 func SaveTableStatsToStorage(sctx sessionctx.Context,
 	results *statistics.AnalyzeResults, analyzeSnapshot bool) (statsVer uint64, err error) {
-	needDumpFMS := results.TableID.IsPartitionTable()
+	// The FMSketch is needed to merge the partition stats into the global stats, and to merge the stats of
+	// the newly written rows by the next incremental analyze.
+	needDumpFMS := results.TableID.IsPartitionTable() || results.IncrementalMaxHandle != nil
 	tableID := results.TableID.GetStatisticsID()
 	ctx := util.StatsCtx
 	txn, err := sctx.Txn(true)
@@ -295,7 +297,24 @@ func SaveTableStatsToStorage(sctx sessionctx.Context,
 			}
 		}
 	}
-	// 3. Save extended statistics.
+	// 3. Save the position of the incremental analyze. A full analyze removes it, so the next incremental
+	// analyze of the table falls back to a full one, which doesn't miss the rows written in between.
+	if !results.ForMVIndex {
+		if results.IncrementalMaxHandle != nil {
+			if _, err = util.Exec(sctx, "replace into mysql.stats_incremental_analyze (table_id, max_handle) values (%?, %?)", tableID, *results.IncrementalMaxHandle); err != nil {
+				return 0, err
+			}
+		} else if _, err = util.Exec(sctx, "delete from mysql.stats_incremental_analyze where table_id = %?", tableID); err != nil {
+			return 0, err
+		}
+	}
+	// 4. Save the stats of the expressions.
+	if results.ExpressionStats != nil {
+		if err = saveExpressionStatsToStorage(sctx, tableID, version, results.ExpressionStats); err != nil {
+			return 0, err
+		}
+	}
+	// 5. Save extended statistics.
 	extStats := results.ExtStats
 	if extStats == nil || len(extStats.Stats) == 0 {
 		return
@@ -309,10 +328,8 @@ func SaveTableStatsToStorage(sctx sessionctx.Context,
 		}
 		strColIDs := string(bytes)
 		switch item.Tp {
-		case ast.StatsTypeCardinality, ast.StatsTypeCorrelation:
+		case ast.StatsTypeCardinality, ast.StatsTypeCorrelation, ast.StatsTypeDependency:
 			statsStr = fmt.Sprintf("%f", item.ScalarVals)
-		case ast.StatsTypeDependency:
-			statsStr = item.StringVals
 		}
 		if _, err = util.Exec(sctx, "replace into mysql.stats_extended values (%?, %?, %?, %?, %?, %?, %?)", name, item.Tp, tableID, strColIDs, statsStr, version, statistics.ExtendedStatsAnalyzed); err != nil {
 			return 0, err
@@ -321,6 +338,25 @@ func SaveTableStatsToStorage(sctx sessionctx.Context,
 	return
 }
 
+// saveExpressionStatsToStorage replaces the stats of the expressions of the table.
+func saveExpressionStatsToStorage(sctx sessionctx.Context, tableID int64, version uint64, exprStats []*statistics.ExpressionStats) error {
+	if _, err := util.Exec(sctx, "delete from mysql.stats_expressions where table_id = %?", tableID); err != nil {
+		return err
+	}
+	tctx := sctx.GetSessionVars().StmtCtx.TypeCtx()
+	for _, item := range exprStats {
+		histData, topNData, err := statistics.EncodeExpressionStats(tctx, item)
+		if err != nil {
+			return err
+		}
+		if _, err = util.Exec(sctx, "insert into mysql.stats_expressions (table_id, expr_hash, version, null_count, histogram, top_n) values (%?, %?, %?, %?, %?, %?)",
+			tableID, item.Hash, version, item.Histogram.NullCount, histData, topNData); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 // SaveStatsToStorage saves the stats to storage.
 // If count is negative, both count and modify count would not be used and not be written to the table. Unless, corresponding
 // fields in the stats_meta table will be updated.
@@ -27,6 +27,7 @@ import (
 	"github.com/pingcap/tidb/pkg/parser/model"
 	"github.com/pingcap/tidb/pkg/sessionctx"
 	"github.com/pingcap/tidb/pkg/sessionctx/variable"
+	"github.com/pingcap/tidb/pkg/statistics"
 	"github.com/pingcap/tidb/pkg/statistics/handle/storage"
 	utilstats "github.com/pingcap/tidb/pkg/statistics/handle/util"
 	"github.com/pingcap/tidb/pkg/types"
@@ -261,6 +262,49 @@ func (s *statsUsageImpl) DumpColStatsUsageToKV() error {
 			delete(colMap, pairs[j].tblColID)
 		}
 	}
+	return s.dumpExpressionStatsUsageToKV()
+}
+
+// dumpExpressionStatsUsageToKV dumps the usage of the expressions collected by statistics.ExpressionStatsUsage to KV.
+func (s *statsUsageImpl) dumpExpressionStatsUsageToKV() error {
+	exprMap := statistics.ExpressionStatsUsage.GetUsageAndReset()
+	defer func() {
+		statistics.ExpressionStatsUsage.Merge(exprMap)
+	}()
+	ids := make([]statistics.ExpressionItemID, 0, len(exprMap))
+	for id := range exprMap {
+		ids = append(ids, id)
+	}
+	slices.SortFunc(ids, func(i, j statistics.ExpressionItemID) int {
+		if i.TableID == j.TableID {
+			return cmp.Compare(i.Hash, j.Hash)
+		}
+		return cmp.Compare(i.TableID, j.TableID)
+	})
+	// Use batch insert to reduce cost.
+	for i := 0; i < len(ids); i += batchInsertSize {
+		end := min(i+batchInsertSize, len(ids))
+		sql := new(strings.Builder)
+		sqlescape.MustFormatSQL(sql, "INSERT INTO mysql.stats_expression_usage (table_id, expr_hash, expr, last_used_at) VALUES ")
+		for j := i; j < end; j++ {
+			usage := exprMap[ids[j]]
+			// See DumpColStatsUsageToKV for the time zone conversion.
+			sqlescape.MustFormatSQL(sql, "(%?, %?, %?, CONVERT_TZ(%?, '+00:00', @@TIME_ZONE))", ids[j].TableID, ids[j].Hash, usage.SQL, usage.LastUsedAt.UTC().Format(types.TimeFormat))
+			if j < end-1 {
+				sqlescape.MustFormatSQL(sql, ",")
+			}
+		}
+		sqlescape.MustFormatSQL(sql, " ON DUPLICATE KEY UPDATE expr = VALUES(expr), last_used_at = GREATEST(last_used_at, VALUES(last_used_at))")
+		if err := utilstats.CallWithSCtx(s.statsHandle.SPool(), func(sctx sessionctx.Context) error {
+			_, _, err := utilstats.ExecRows(sctx, sql.String())
+			return err
+		}); err != nil {
+			return errors.Trace(err)
+		}
+		for j := i; j < end; j++ {
+			delete(exprMap, ids[j])
+		}
+	}
 	return nil
 }
 
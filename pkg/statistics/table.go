@@ -58,6 +58,8 @@ var (
 // Table represents statistics for a table.
 type Table struct {
 	ExtendedStats *ExtendedStatsColl
+	// ExpressionStats is the stats of the expressions on the columns, see ExpressionStats.
+	ExpressionStats *ExpressionStatsColl
 
 	ColAndIdxExistenceMap *ColAndIdxExistenceMap
 	HistColl
@@ -246,6 +248,12 @@ type HistColl struct {
 	// For normal index, the column id is enough, as we already have in Idx2ColUniqueIDs. But currently, mv index needs more
 	// information to match the filter against the mv index columns, and we need this map to provide this information.
 	MVIdx2Columns map[int64][]*expression.Column
+	// ExtendedStats is the extended statistics of the table. It's used to adjust the selectivity of the correlated
+	// columns and the NDV of the column groups in planner.
+	ExtendedStats *ExtendedStatsColl
+	// ExpressionStats is the stats of the expressions on the columns. It's used to estimate the selectivity of
+	// the filters on the expressions in planner.
+	ExpressionStats *ExpressionStatsColl
 }
 
 // NewHistColl creates a new HistColl.
@@ -596,6 +604,14 @@ func (t *Table) Copy() *Table {
 		}
 		nt.ExtendedStats = newExtStatsColl
 	}
+	// The items are immutable once loaded, so only the map is copied.
+	if t.ExpressionStats != nil {
+		newExprStatsColl := NewExpressionStatsColl()
+		for hash, item := range t.ExpressionStats.Stats {
+			newExprStatsColl.Stats[hash] = item
+		}
+		nt.ExpressionStats = newExprStatsColl
+	}
 	if t.ColAndIdxExistenceMap != nil {
 		nt.ColAndIdxExistenceMap = t.ColAndIdxExistenceMap.Clone()
 	}
@@ -621,6 +637,7 @@ func (t *Table) ShallowCopy() *Table {
 		Version:               t.Version,
 		TblInfoUpdateTS:       t.TblInfoUpdateTS,
 		ExtendedStats:         t.ExtendedStats,
+		ExpressionStats:       t.ExpressionStats,
 		ColAndIdxExistenceMap: t.ColAndIdxExistenceMap,
 		LastAnalyzeVersion:    t.LastAnalyzeVersion,
 	}
@@ -961,6 +978,8 @@ func (coll *HistColl) GenerateHistCollFromColumnInfo(tblInfo *model.TableInfo, c
 		Idx2ColUniqueIDs:   idx2Columns,
 		UniqueID2colInfoID: uniqueID2colInfoID,
 		MVIdx2Columns:      mvIdx2Columns,
+		ExtendedStats:      coll.ExtendedStats,
+		ExpressionStats:    coll.ExpressionStats,
 	}
 	return newColl
 }
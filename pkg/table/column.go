@@ -339,6 +339,9 @@ func CastColumnValue(ctx expression.BuildContext, val types.Datum, col *model.Co
 // castColumnValue casts a value based on column type.
 func castColumnValue(tc types.Context, ec errctx.Context, sqlMode mysql.SQLMode, val types.Datum, col *model.ColumnInfo, connID uint64, returnErr, forceIgnoreTruncate bool) (casted types.Datum, err error) {
 	casted, err = val.ConvertTo(tc, &col.FieldType)
+	if err == nil && col.GetType() == mysql.TypeGeometry && !casted.IsNull() {
+		err = checkGeometrySRID(casted, col)
+	}
 	// TODO: make sure all truncate errors are handled by ConvertTo.
 	if returnErr && err != nil {
 		return casted, err
@@ -378,6 +381,22 @@ func castColumnValue(tc types.Context, ec errctx.Context, sqlMode mysql.SQLMode,
 	return casted, err
 }
 
+// checkGeometrySRID checks whether the SRID of the geometry is the same as the SRID of the column.
+func checkGeometrySRID(val types.Datum, col *model.ColumnInfo) error {
+	colSRID, ok := col.GetSRID()
+	if !ok {
+		return nil
+	}
+	g, err := types.DecodeGeometry(val.GetBytes())
+	if err != nil {
+		return err
+	}
+	if g.SRID != colSRID {
+		return types.ErrWrongSRIDForColumn.GenWithStackByArgs(col.Name.O, g.SRID, colSRID)
+	}
+	return nil
+}
+
 // ColDesc describes column information like MySQL desc and show columns do.
 type ColDesc struct {
 	Field string
@@ -714,7 +733,7 @@ func GetZeroValue(col *model.ColumnInfo) types.Datum {
 		} else {
 			d.SetString("", col.GetCollate())
 		}
-	case mysql.TypeVarString, mysql.TypeVarchar, mysql.TypeBlob, mysql.TypeTinyBlob, mysql.TypeMediumBlob, mysql.TypeLongBlob:
+	case mysql.TypeVarString, mysql.TypeVarchar, mysql.TypeBlob, mysql.TypeTinyBlob, mysql.TypeMediumBlob, mysql.TypeLongBlob, mysql.TypeGeometry:
 		d.SetString("", col.GetCollate())
 	case mysql.TypeDuration:
 		d.SetMysqlDuration(types.ZeroDuration)
@@ -732,6 +751,8 @@ func GetZeroValue(col *model.ColumnInfo) types.Datum {
 		d.SetMysqlEnum(types.Enum{}, col.GetCollate())
 	case mysql.TypeJSON:
 		d.SetMysqlJSON(types.CreateBinaryJSON(nil))
+	case mysql.TypeTiDBVectorFloat32:
+		d.SetVectorFloat32(types.ZeroVectorFloat32)
 	}
 	return d
 }
@@ -96,7 +96,8 @@ func (c *index) GenIndexKey(ec errctx.Context, loc *time.Location, indexedValues
 func (c *index) GenIndexValue(ec errctx.Context, loc *time.Location, distinct bool, indexedValues []types.Datum,
 	h kv.Handle, restoredData []types.Datum, buf []byte) ([]byte, error) {
 	c.initNeedRestoreData.Do(func() {
-		c.needRestoredData = NeedRestoredData(c.idxInfo.Columns, c.tblInfo.Columns)
+		// The tokens of the fulltext index and the cells of the spatial index can't restore the column values.
+		c.needRestoredData = !c.idxInfo.IsFulltextIndex() && !c.idxInfo.IsSpatialIndex() && NeedRestoredData(c.idxInfo.Columns, c.tblInfo.Columns)
 	})
 	idx, err := tablecodec.GenIndexValuePortal(loc, c.tblInfo, c.idxInfo, c.needRestoredData, distinct, false, indexedValues, h, c.phyTblID, restoredData, buf)
 	err = ec.HandleError(err)
@@ -104,11 +105,18 @@ func (c *index) GenIndexValue(ec errctx.Context, loc *time.Location, distinct bo
 }
 
 // getIndexedValue will produce the result like:
-// 1. If not multi-valued index, return directly.
+// 1. If not multi-valued index, return directly, except the fulltext and spatial indexes, see
+// tablecodec.GenFulltextIndexedValues and tablecodec.GenSpatialIndexedValues.
 // 2. (i1, [m1,m2], i2, ...) ==> [(i1, m1, i2, ...), (i1, m2, i2, ...)]
 // 3. (i1, null, i2, ...) ==> [(i1, null, i2, ...)]
 // 4. (i1, [], i2, ...) ==> nothing.
 func (c *index) getIndexedValue(indexedValues []types.Datum) [][]types.Datum {
+	if c.idxInfo.IsFulltextIndex() {
+		return tablecodec.GenFulltextIndexedValues(c.idxInfo, indexedValues)
+	}
+	if c.idxInfo.IsSpatialIndex() {
+		return tablecodec.GenSpatialIndexedValues(indexedValues)
+	}
 	if !c.idxInfo.MVIndex {
 		return [][]types.Datum{indexedValues}
 	}
@@ -236,7 +244,7 @@ func (c *index) Create(sctx table.MutateContext, txn kv.Transaction, indexedValu
 		// save the key buffer to reuse.
 		writeBufs.IndexKeyBuf = key
 		c.initNeedRestoreData.Do(func() {
-			c.needRestoredData = NeedRestoredData(c.idxInfo.Columns, c.tblInfo.Columns)
+			c.needRestoredData = !c.idxInfo.IsFulltextIndex() && !c.idxInfo.IsSpatialIndex() && NeedRestoredData(c.idxInfo.Columns, c.tblInfo.Columns)
 		})
 		idxVal, err := tablecodec.GenIndexValuePortal(sctx.GetSessionVars().StmtCtx.TimeZone(), c.tblInfo, c.idxInfo,
 			c.needRestoredData, distinct, opt.Untouched, value, h, c.phyTblID, handleRestoreData, nil)
@@ -475,7 +483,7 @@ func (c *index) Delete(ctx table.MutateContext, txn kv.Transaction, indexedValue
 func (c *index) GenIndexKVIter(ec errctx.Context, loc *time.Location, indexedValue []types.Datum,
 	h kv.Handle, handleRestoreData []types.Datum) table.IndexKVGenerator {
 	var mvIndexValues [][]types.Datum
-	if c.Meta().MVIndex {
+	if c.Meta().MVIndex || c.Meta().IsFulltextIndex() || c.Meta().IsSpatialIndex() {
 		mvIndexValues = c.getIndexedValue(indexedValue)
 		return table.NewMultiValueIndexKVGenerator(c, ec, loc, h, handleRestoreData, mvIndexValues)
 	}
@@ -222,6 +222,10 @@ func checkIndexKeys(
 		if !ok {
 			return errors.New("index not found")
 		}
+		// The tokens of the fulltext index and the cells of the spatial index can't be compared with the column values.
+		if indexInfo.IsFulltextIndex() || indexInfo.IsSpatialIndex() {
+			continue
+		}
 
 		var isTmpIdxValAndDeleted bool
 		// If this is temp index data, need remove last byte of index data.
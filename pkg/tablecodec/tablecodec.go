@@ -35,6 +35,7 @@ import (
 	"github.com/pingcap/tidb/pkg/util/codec"
 	"github.com/pingcap/tidb/pkg/util/collate"
 	"github.com/pingcap/tidb/pkg/util/dbterror"
+	"github.com/pingcap/tidb/pkg/util/fulltext"
 	"github.com/pingcap/tidb/pkg/util/rowcodec"
 	"github.com/pingcap/tidb/pkg/util/stringutil"
 	"github.com/tikv/client-go/v2/tikv"
@@ -648,7 +649,7 @@ func Unflatten(datum types.Datum, ft *types.FieldType, loc *time.Location) (type
 		datum.SetFloat32(float32(datum.GetFloat64()))
 		return datum, nil
 	case mysql.TypeVarchar, mysql.TypeString, mysql.TypeVarString, mysql.TypeTinyBlob,
-		mysql.TypeMediumBlob, mysql.TypeBlob, mysql.TypeLongBlob:
+		mysql.TypeMediumBlob, mysql.TypeBlob, mysql.TypeLongBlob, mysql.TypeGeometry:
 		datum.SetString(datum.GetString(), ft.GetCollate())
 	case mysql.TypeTiny, mysql.TypeShort, mysql.TypeYear, mysql.TypeInt24,
 		mysql.TypeLong, mysql.TypeLonglong, mysql.TypeDouble:
@@ -1661,6 +1662,51 @@ func TruncateIndexValue(v *types.Datum, idxCol *model.IndexColumn, tblCol *model
 	}
 }
 
+// GenFulltextIndexedValues generates the indexed values of a fulltext index for a row.
+// A fulltext index stores one key for every distinct token of the indexed columns. The first
+// value of the key is the token in bytes, and the rest are NULLs to keep the number of the
+// values the same as the index columns. It returns nothing if there are no tokens.
+func GenFulltextIndexedValues(idxInfo *model.IndexInfo, indexedValues []types.Datum) [][]types.Datum {
+	texts := make([]string, 0, len(indexedValues))
+	for _, v := range indexedValues {
+		if v.IsNull() {
+			continue
+		}
+		text, err := v.ToString()
+		if err != nil {
+			continue
+		}
+		texts = append(texts, text)
+	}
+	tokens := fulltext.Tokens(fulltext.NewTokenizer(idxInfo.ParserName.L), texts...)
+	vals := make([][]types.Datum, 0, len(tokens))
+	for _, token := range tokens {
+		val := make([]types.Datum, len(idxInfo.Columns))
+		val[0] = types.NewBytesDatum([]byte(token))
+		vals = append(vals, val)
+	}
+	return vals
+}
+
+// GenSpatialIndexedValues generates the indexed values of a spatial index for a row. A spatial
+// index stores one key for every cell covering the geometry, see types.GeometryIndexCells.
+// It returns nothing if the geometry is empty.
+func GenSpatialIndexedValues(indexedValues []types.Datum) [][]types.Datum {
+	if len(indexedValues) == 0 || indexedValues[0].IsNull() {
+		return nil
+	}
+	g, err := types.DecodeGeometry(indexedValues[0].GetBytes())
+	if err != nil {
+		return nil
+	}
+	cells := types.GeometryIndexCells(&g)
+	vals := make([][]types.Datum, 0, len(cells))
+	for _, cell := range cells {
+		vals = append(vals, []types.Datum{types.NewBytesDatum(cell)})
+	}
+	return vals
+}
+
 // EncodeHandleInUniqueIndexValue encodes handle in data.
 func EncodeHandleInUniqueIndexValue(h kv.Handle, isUntouched bool) []byte {
 	if h.IsInt() {
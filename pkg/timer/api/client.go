@@ -105,6 +105,13 @@ func WithSetSummaryData(summary []byte) UpdateTimerOption {
 	}
 }
 
+// WithSetData indicates to set the timer's data.
+func WithSetData(data []byte) UpdateTimerOption {
+	return func(update *TimerUpdate) {
+		update.Data.Set(data)
+	}
+}
+
 // WithSetTags indicates to set the timer's tags.
 func WithSetTags(tags []string) UpdateTimerOption {
 	return func(update *TimerUpdate) {
@@ -185,3 +185,109 @@ func TestCronPolicy(t *testing.T) {
 		require.Equal(t, !next.IsZero(), ok)
 	}
 }
+
+func TestOncePolicy(t *testing.T) {
+	for _, expr := range []string{"", "2023-01-01 00:00:00", "aaa"} {
+		p, err := CreateSchedEventPolicy(SchedEventOnce, expr)
+		require.Nil(t, p)
+		require.ErrorContains(t, err, fmt.Sprintf("invalid schedule event expr '%s'", expr))
+	}
+
+	p, err := CreateSchedEventPolicy(SchedEventOnce, "2023-06-01T10:00:00Z")
+	require.NoError(t, err)
+	require.IsType(t, &SchedOncePolicy{}, p)
+	at := time.Date(2023, 6, 1, 10, 0, 0, 0, time.UTC)
+
+	next, ok := p.NextEventTime(time.Time{})
+	require.True(t, ok)
+	require.Equal(t, at, next)
+	next, ok = p.NextEventTime(at.Add(-time.Second))
+	require.True(t, ok)
+	require.Equal(t, at, next)
+	next, ok = p.NextEventTime(at)
+	require.False(t, ok)
+	require.True(t, next.IsZero())
+	_, ok = p.NextEventTime(at.Add(time.Hour))
+	require.False(t, ok)
+}
+
+func TestEveryPolicy(t *testing.T) {
+	for _, expr := range []string{
+		"",
+		"10 SECOND",
+		"0 SECOND STARTS 2023-01-01T00:00:00Z",
+		"-1 MONTH STARTS 2023-01-01T00:00:00Z",
+		"10 DAY STARTS 2023-01-01T00:00:00Z",
+		"10 SECOND BEGIN 2023-01-01T00:00:00Z",
+		"10 SECOND STARTS 2023-01-01",
+		"10 SECOND STARTS 2023-01-01T00:00:00Z ENDS",
+		"10 SECOND STARTS 2023-01-01T00:00:00Z UNTIL 2023-01-02T00:00:00Z",
+	} {
+		p, err := CreateSchedEventPolicy(SchedEventEvery, expr)
+		require.Nil(t, p)
+		require.ErrorContains(t, err, fmt.Sprintf("invalid schedule event expr '%s'", expr))
+	}
+
+	cases := []struct {
+		expr string
+		tm   time.Time
+		next time.Time
+	}{
+		{
+			expr: "3600 SECOND STARTS 2023-01-01T00:00:00Z",
+			next: time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			expr: "3600 SECOND STARTS 2023-01-01T00:00:00Z",
+			tm:   time.Date(2022, 12, 31, 0, 0, 0, 0, time.UTC),
+			next: time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			expr: "3600 SECOND STARTS 2023-01-01T00:00:00Z",
+			tm:   time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC),
+			next: time.Date(2023, 1, 1, 1, 0, 0, 0, time.UTC),
+		},
+		{
+			expr: "3600 SECOND STARTS 2023-01-01T00:00:00Z",
+			tm:   time.Date(2023, 1, 3, 5, 21, 31, 0, time.UTC),
+			next: time.Date(2023, 1, 3, 6, 0, 0, 0, time.UTC),
+		},
+		{
+			expr: "3600 SECOND STARTS 2023-01-01T00:00:00Z ENDS 2023-01-01T05:00:00Z",
+			tm:   time.Date(2023, 1, 1, 4, 0, 0, 0, time.UTC),
+			next: time.Date(2023, 1, 1, 5, 0, 0, 0, time.UTC),
+		},
+		{
+			expr: "3600 SECOND STARTS 2023-01-01T00:00:00Z ENDS 2023-01-01T05:00:00Z",
+			tm:   time.Date(2023, 1, 1, 5, 0, 0, 0, time.UTC),
+		},
+		{
+			expr: "1 MONTH STARTS 2023-01-31T08:00:00Z",
+			tm:   time.Date(2023, 1, 31, 8, 0, 0, 0, time.UTC),
+			next: time.Date(2023, 3, 3, 8, 0, 0, 0, time.UTC),
+		},
+		{
+			expr: "1 MONTH STARTS 2023-01-31T08:00:00Z",
+			tm:   time.Date(2023, 3, 1, 0, 0, 0, 0, time.UTC),
+			next: time.Date(2023, 3, 3, 8, 0, 0, 0, time.UTC),
+		},
+		{
+			expr: "12 MONTH STARTS 2023-06-01T00:00:00Z",
+			tm:   time.Date(2025, 7, 1, 0, 0, 0, 0, time.UTC),
+			next: time.Date(2026, 6, 1, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			expr: "12 MONTH STARTS 2023-06-01T00:00:00Z ENDS 2025-12-31T00:00:00Z",
+			tm:   time.Date(2025, 7, 1, 0, 0, 0, 0, time.UTC),
+		},
+	}
+
+	for _, c := range cases {
+		p, err := CreateSchedEventPolicy(SchedEventEvery, c.expr)
+		require.NoError(t, err)
+		require.IsType(t, &SchedEveryPolicy{}, p)
+		next, ok := p.NextEventTime(c.tm)
+		require.Equal(t, c.next, next, c.expr)
+		require.Equal(t, !next.IsZero(), ok, c.expr)
+	}
+}
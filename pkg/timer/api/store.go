@@ -166,6 +166,8 @@ func (c *TimerCond) Clear() {
 type TimerUpdate struct {
 	// Tags indicates to set all tags for a timer.
 	Tags OptionalVal[[]string]
+	// Data indicates to set the timer's `Data` field.
+	Data OptionalVal[[]byte]
 	// Enable indicates to set the timer's `Enable` field.
 	Enable OptionalVal[bool]
 	// TimeZone indicates to set the timer's `TimeZone` field.
@@ -214,6 +216,10 @@ func (u *TimerUpdate) apply(record *TimerRecord) (*TimerRecord, error) {
 		record.Tags = v
 	}
 
+	if v, ok := u.Data.Get(); ok {
+		record.Data = v
+	}
+
 	if v, ok := u.Enable.Get(); ok {
 		record.Enable = v
 	}
@@ -15,6 +15,8 @@
 package api
 
 import (
+	"strconv"
+	"strings"
 	"time"
 
 	"github.com/pingcap/errors"
@@ -31,6 +33,10 @@ const (
 	SchedEventInterval SchedPolicyType = "INTERVAL"
 	// SchedEventCron indicates to schedule events by cron expression.
 	SchedEventCron SchedPolicyType = "CRON"
+	// SchedEventOnce indicates to schedule only one event at a fixed time.
+	SchedEventOnce SchedPolicyType = "ONCE"
+	// SchedEventEvery indicates to schedule events every fixed interval aligned to a start time.
+	SchedEventEvery SchedPolicyType = "EVERY"
 )
 
 // SchedEventPolicy is an interface to tell the runtime how to schedule a timer's events.
@@ -91,6 +97,121 @@ func (p *CronPolicy) NextEventTime(watermark time.Time) (time.Time, bool) {
 	return next, !next.IsZero()
 }
 
+// SchedOncePolicy implements SchedEventPolicy, it is the policy of type `SchedEventOnce`.
+// The expression is a time in RFC3339 format, and only one event is scheduled at that time.
+type SchedOncePolicy struct {
+	at time.Time
+}
+
+// NewSchedOncePolicy creates a new SchedOncePolicy.
+func NewSchedOncePolicy(expr string) (*SchedOncePolicy, error) {
+	at, err := time.Parse(time.RFC3339, expr)
+	if err != nil {
+		return nil, errors.Wrapf(err, "invalid schedule event expr '%s'", expr)
+	}
+	return &SchedOncePolicy{at: at}, nil
+}
+
+// NextEventTime returns the next time of the timer event.
+// There is no more event once the watermark reaches the scheduled time.
+func (p *SchedOncePolicy) NextEventTime(watermark time.Time) (time.Time, bool) {
+	if watermark.Before(p.at) {
+		return p.at, true
+	}
+	return time.Time{}, false
+}
+
+// SchedEveryPolicy implements SchedEventPolicy, it is the policy of type `SchedEventEvery`.
+// The expression looks like `<n> SECOND|MONTH STARTS <time> [ENDS <time>]` with times in RFC3339 format.
+// The events are scheduled at `STARTS + k * interval` and no event is scheduled after `ENDS`.
+type SchedEveryPolicy struct {
+	seconds int64
+	months  int64
+	starts  time.Time
+	ends    time.Time
+}
+
+// NewSchedEveryPolicy creates a new SchedEveryPolicy.
+func NewSchedEveryPolicy(expr string) (*SchedEveryPolicy, error) {
+	p, err := parseSchedEveryPolicy(expr)
+	if err != nil {
+		return nil, errors.Wrapf(err, "invalid schedule event expr '%s'", expr)
+	}
+	return p, nil
+}
+
+func parseSchedEveryPolicy(expr string) (*SchedEveryPolicy, error) {
+	fields := strings.Fields(expr)
+	if len(fields) != 4 && len(fields) != 6 {
+		return nil, errors.New("unexpected number of fields")
+	}
+
+	n, err := strconv.ParseInt(fields[0], 10, 64)
+	if err != nil {
+		return nil, err
+	}
+	if n <= 0 {
+		return nil, errors.New("interval should be positive")
+	}
+
+	p := &SchedEveryPolicy{}
+	switch strings.ToUpper(fields[1]) {
+	case "SECOND":
+		p.seconds = n
+	case "MONTH":
+		p.months = n
+	default:
+		return nil, errors.Errorf("unsupported interval unit '%s'", fields[1])
+	}
+
+	if !strings.EqualFold(fields[2], "STARTS") {
+		return nil, errors.New("missing STARTS")
+	}
+	if p.starts, err = time.Parse(time.RFC3339, fields[3]); err != nil {
+		return nil, err
+	}
+
+	if len(fields) == 6 {
+		if !strings.EqualFold(fields[4], "ENDS") {
+			return nil, errors.New("missing ENDS")
+		}
+		if p.ends, err = time.Parse(time.RFC3339, fields[5]); err != nil {
+			return nil, err
+		}
+	}
+	return p, nil
+}
+
+// NextEventTime returns the next time of the timer event.
+// It is the first time aligned to `STARTS` which is after the watermark.
+func (p *SchedEveryPolicy) NextEventTime(watermark time.Time) (time.Time, bool) {
+	next := p.starts
+	if !watermark.Before(p.starts) {
+		if p.months > 0 {
+			diff := int64(watermark.Year()-p.starts.Year())*12 + int64(watermark.Month()-p.starts.Month())
+			// AddDate normalizes overflowed days into the next month, so start from one interval
+			// earlier to make sure the first matched time is not skipped.
+			k := max(diff/p.months-1, 0)
+			for {
+				next = p.starts.AddDate(0, int(k*p.months), 0)
+				if next.After(watermark) {
+					break
+				}
+				k++
+			}
+		} else {
+			interval := time.Duration(p.seconds) * time.Second
+			k := watermark.Sub(p.starts)/interval + 1
+			next = p.starts.Add(k * interval)
+		}
+	}
+
+	if !p.ends.IsZero() && next.After(p.ends) {
+		return time.Time{}, false
+	}
+	return next, true
+}
+
 // ManualRequest is the request info to trigger timer manually.
 type ManualRequest struct {
 	// ManualRequestID is the id of manual request.
@@ -197,6 +318,10 @@ func CreateSchedEventPolicy(tp SchedPolicyType, expr string) (SchedEventPolicy,
 		return NewSchedIntervalPolicy(expr)
 	case SchedEventCron:
 		return NewCronPolicy(expr)
+	case SchedEventOnce:
+		return NewSchedOncePolicy(expr)
+	case SchedEventEvery:
+		return NewSchedEveryPolicy(expr)
 	default:
 		return nil, errors.Errorf("invalid schedule event type: '%s'", tp)
 	}
@@ -415,6 +415,14 @@ func TestBuildUpdateCriteria(t *testing.T) {
 			criteria: "ENABLE = %?, VERSION = VERSION + 1",
 			args:     []any{true},
 		},
+		{
+			update: &api.TimerUpdate{
+				Enable: api.NewOptionalVal(true),
+				Data:   api.NewOptionalVal([]byte("data1")),
+			},
+			criteria: "ENABLE = %?, TIMER_DATA = %?, VERSION = VERSION + 1",
+			args:     []any{true, []byte("data1")},
+		},
 		{
 			update: &api.TimerUpdate{
 				Enable:          api.NewOptionalVal(false),
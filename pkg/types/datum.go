@@ -59,6 +59,7 @@ const (
 	KindMaxValue      byte = 16
 	KindRaw           byte = 17
 	KindMysqlJSON     byte = 18
+	KindVectorFloat32 byte = 19
 )
 
 // Datum is a data box holds different kind of data.
@@ -405,6 +406,21 @@ func (d *Datum) SetMysqlJSON(b BinaryJSON) {
 	d.b = b.Value
 }
 
+// GetVectorFloat32 gets VectorFloat32 value
+func (d *Datum) GetVectorFloat32() VectorFloat32 {
+	v, _, err := ZeroCopyDeserializeVectorFloat32(d.b)
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
+
+// SetVectorFloat32 sets VectorFloat32 value
+func (d *Datum) SetVectorFloat32(vec VectorFloat32) {
+	d.k = KindVectorFloat32
+	d.b = vec.ZeroCopySerialize()
+}
+
 // GetMysqlTime gets types.Time value
 func (d *Datum) GetMysqlTime() Time {
 	return d.x.(Time)
@@ -479,6 +495,8 @@ func (d Datum) String() string {
 		t = "KindRaw"
 	case KindMysqlJSON:
 		t = "KindMysqlJSON"
+	case KindVectorFloat32:
+		t = "KindVectorFloat32"
 	default:
 		t = "Unknown"
 	}
@@ -524,6 +542,8 @@ func (d *Datum) GetValue() any {
 		return d.GetMysqlSet()
 	case KindMysqlJSON:
 		return d.GetMysqlJSON()
+	case KindVectorFloat32:
+		return d.GetVectorFloat32()
 	case KindMysqlTime:
 		return d.GetMysqlTime()
 	default:
@@ -572,6 +592,8 @@ func (d *Datum) SetValueWithDefaultCollation(val any) {
 		d.SetMysqlSet(x, mysql.DefaultCollationName)
 	case BinaryJSON:
 		d.SetMysqlJSON(x)
+	case VectorFloat32:
+		d.SetVectorFloat32(x)
 	case Time:
 		d.SetMysqlTime(x)
 	default:
@@ -620,6 +642,8 @@ func (d *Datum) SetValue(val any, tp *types.FieldType) {
 		d.SetMysqlSet(x, tp.GetCollate())
 	case BinaryJSON:
 		d.SetMysqlJSON(x)
+	case VectorFloat32:
+		d.SetVectorFloat32(x)
 	case Time:
 		d.SetMysqlTime(x)
 	default:
@@ -634,6 +658,10 @@ func (d *Datum) Compare(ctx Context, ad *Datum, comparer collate.Collator) (int,
 		cmp, err := ad.Compare(ctx, d, comparer)
 		return cmp * -1, errors.Trace(err)
 	}
+	if d.k == KindVectorFloat32 && ad.k != KindVectorFloat32 {
+		cmp, err := ad.Compare(ctx, d, comparer)
+		return cmp * -1, errors.Trace(err)
+	}
 	switch ad.k {
 	case KindNull:
 		if d.k == KindNull {
@@ -674,6 +702,8 @@ func (d *Datum) Compare(ctx Context, ad *Datum, comparer collate.Collator) (int,
 		return d.compareMysqlSet(ctx, ad.GetMysqlSet(), comparer)
 	case KindMysqlJSON:
 		return d.compareMysqlJSON(ad.GetMysqlJSON())
+	case KindVectorFloat32:
+		return d.compareVectorFloat32(ad.GetVectorFloat32())
 	case KindMysqlTime:
 		return d.compareMysqlTime(ctx, ad.GetMysqlTime())
 	default:
@@ -881,6 +911,26 @@ func (d *Datum) compareMysqlJSON(target BinaryJSON) (int, error) {
 	return CompareBinaryJSON(origin, target), nil
 }
 
+func (d *Datum) compareVectorFloat32(vec VectorFloat32) (int, error) {
+	switch d.k {
+	case KindNull, KindMinNotNull:
+		return -1, nil
+	case KindMaxValue:
+		return 1, nil
+	case KindVectorFloat32:
+		return d.GetVectorFloat32().Compare(vec), nil
+	// Note: We expect BinaryLiteral is not compared with vectors.
+	case KindString, KindBytes:
+		datumVec, err := ParseVectorFloat32(d.GetString())
+		if err != nil {
+			return 0, errors.Trace(err)
+		}
+		return datumVec.Compare(vec), nil
+	default:
+		return 0, errors.Errorf("cannot compare vector and non-vector, vector is %s, non-vector is %s", vec.TruncatedString(), d.String())
+	}
+}
+
 func (d *Datum) compareMysqlTime(ctx Context, time Time) (int, error) {
 	switch d.k {
 	case KindNull, KindMinNotNull:
@@ -937,6 +987,10 @@ func (d *Datum) ConvertTo(ctx Context, target *FieldType) (Datum, error) {
 		return d.convertToMysqlSet(ctx, target)
 	case mysql.TypeJSON:
 		return d.convertToMysqlJSON(target)
+	case mysql.TypeTiDBVectorFloat32:
+		return d.convertToVectorFloat32(ctx, target)
+	case mysql.TypeGeometry:
+		return d.convertToGeometry(target)
 	case mysql.TypeNull:
 		return Datum{}, nil
 	default:
@@ -1073,6 +1127,8 @@ func (d *Datum) convertToString(ctx Context, target *FieldType) (Datum, error) {
 		}
 	case KindMysqlJSON:
 		s = d.GetMysqlJSON().String()
+	case KindVectorFloat32:
+		s = d.GetVectorFloat32().String()
 	default:
 		return invalidConv(d, target.GetType())
 	}
@@ -1730,6 +1786,8 @@ func (d *Datum) convertToMysqlJSON(_ *FieldType) (ret Datum, err error) {
 		}
 	case KindMysqlJSON:
 		ret = *d
+	case KindVectorFloat32:
+		ret.SetMysqlJSON(d.GetVectorFloat32().ToJSON())
 	case KindMysqlTime:
 		tm := d.GetMysqlTime()
 		ret.SetMysqlJSON(CreateBinaryJSON(tm))
@@ -1750,6 +1808,28 @@ func (d *Datum) convertToMysqlJSON(_ *FieldType) (ret Datum, err error) {
 	return ret, errors.Trace(err)
 }
 
+func (d *Datum) convertToVectorFloat32(_ Context, target *FieldType) (ret Datum, err error) {
+	var vec VectorFloat32
+	switch d.k {
+	case KindVectorFloat32:
+		vec = d.GetVectorFloat32()
+	case KindString, KindBytes:
+		vec, err = ParseVectorFloat32(d.GetString())
+	case KindMysqlJSON:
+		vec, err = ConvertJSONToVectorFloat32(d.GetMysqlJSON())
+	default:
+		return invalidConv(d, target.GetType())
+	}
+	if err != nil {
+		return ret, errors.Trace(err)
+	}
+	if err = vec.CheckDimsFitColumn(target.GetFlen()); err != nil {
+		return ret, errors.Trace(err)
+	}
+	ret.SetVectorFloat32(vec)
+	return ret, nil
+}
+
 // ToBool converts to a bool.
 // We will use 1 for true, and 0 for false.
 func (d *Datum) ToBool(ctx Context) (int64, error) {
@@ -2000,6 +2080,8 @@ func (d *Datum) ToString() (string, error) {
 		return d.GetMysqlSet().String(), nil
 	case KindMysqlJSON:
 		return d.GetMysqlJSON().String(), nil
+	case KindVectorFloat32:
+		return d.GetVectorFloat32().String(), nil
 	case KindBinaryLiteral, KindMysqlBit:
 		return d.GetBinaryLiteral().ToString(), nil
 	case KindNull:
@@ -2045,6 +2127,9 @@ func (d *Datum) ToMysqlJSON() (j BinaryJSON, err error) {
 	case KindMysqlJSON:
 		j = d.GetMysqlJSON()
 		return
+	case KindVectorFloat32:
+		j = d.GetVectorFloat32().ToJSON()
+		return
 	case KindInt64:
 		in = d.GetInt64()
 	case KindUint64:
@@ -2217,6 +2302,12 @@ func NewJSONDatum(j BinaryJSON) (d Datum) {
 	return d
 }
 
+// NewVectorFloat32Datum creates a new Datum from a VectorFloat32 value
+func NewVectorFloat32Datum(v VectorFloat32) (d Datum) {
+	d.SetVectorFloat32(v)
+	return d
+}
+
 // NewBinaryLiteralDatum creates a new BinaryLiteral Datum for a BinaryLiteral value.
 func NewBinaryLiteralDatum(b BinaryLiteral) (d Datum) {
 	d.SetBinaryLiteral(b)
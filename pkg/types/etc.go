@@ -33,6 +33,9 @@ import (
 // IsTypeBlob returns a boolean indicating whether the tp is a blob type.
 var IsTypeBlob = ast.IsTypeBlob
 
+// IsTypeVector returns whether tp is a vector type.
+var IsTypeVector = ast.IsTypeVector
+
 // IsTypeChar returns a boolean indicating
 // whether the tp is the char type like a string type or a varchar type.
 var IsTypeChar = ast.IsTypeChar
@@ -159,6 +162,7 @@ var kind2Str = map[byte]string{
 	KindMaxValue:      "max_value",
 	KindRaw:           "raw",
 	KindMysqlJSON:     "json",
+	KindVectorFloat32: "vector",
 }
 
 // TypeStr converts tp to a string.
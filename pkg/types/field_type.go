@@ -337,6 +337,11 @@ func DefaultTypeForValue(value any, tp *FieldType, char string, collate string)
 		tp.SetDecimal(0)
 		tp.SetCharset(charset.CharsetUTF8MB4)
 		tp.SetCollate(charset.CollationUTF8MB4)
+	case VectorFloat32:
+		tp.SetType(mysql.TypeTiDBVectorFloat32)
+		tp.SetFlen(UnspecifiedLength)
+		tp.SetDecimal(0)
+		SetBinChsClnFlag(tp)
 	default:
 		tp.SetType(mysql.TypeUnspecified)
 		tp.SetFlen(UnspecifiedLength)
@@ -377,11 +382,31 @@ func DefaultCharsetForType(tp byte) (defaultCharset string, defaultCollation str
 // the result should be longlong. However, this function returns long for this case. Please use `AggFieldType`
 // function if you need to handle the range bump.
 func mergeFieldType(a byte, b byte) byte {
+	if a == mysql.TypeTiDBVectorFloat32 || b == mysql.TypeTiDBVectorFloat32 {
+		return mergeVectorFieldType(a, b)
+	}
 	ia := getFieldTypeIndex(a)
 	ib := getFieldTypeIndex(b)
 	return fieldTypeMergeRules[ia][ib]
 }
 
+// mergeVectorFieldType merges the vector type with another type. The vector type
+// is not in the MySQL merge rules, so it is merged like JSON: it is kept only
+// when merged with itself or NULL, otherwise it becomes a string type.
+func mergeVectorFieldType(a byte, b byte) byte {
+	other := a
+	if a == mysql.TypeTiDBVectorFloat32 {
+		other = b
+	}
+	switch other {
+	case mysql.TypeTiDBVectorFloat32, mysql.TypeNull:
+		return mysql.TypeTiDBVectorFloat32
+	case mysql.TypeJSON:
+		return mysql.TypeLongBlob
+	}
+	return mergeFieldType(mysql.TypeJSON, other)
+}
+
 // mergeTypeFlag merges two MySQL type flag to a new one
 // currently only NotNullFlag and UnsignedFlag is checked
 // todo more flag need to be checked
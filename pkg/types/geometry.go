@@ -0,0 +1,725 @@
+// Copyright 2024 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package types
+
+import (
+	"encoding/binary"
+	"math"
+	"strconv"
+	"strings"
+
+	"github.com/pingcap/errors"
+	"github.com/pingcap/tidb/pkg/parser/mysql"
+)
+
+const (
+	// SRIDCartesian is the SRID of the Cartesian plane, which is the default SRID of geometries.
+	SRIDCartesian uint32 = 0
+	// SRIDWGS84 is the SRID of the WGS 84 geographic spatial reference system.
+	SRIDWGS84 uint32 = 4326
+)
+
+const (
+	wkbBigEndian    = 0
+	wkbLittleEndian = 1
+
+	// geometrySRIDLen is the length of the SRID before the WKB in the internal format.
+	geometrySRIDLen = 4
+	// maxGeometryDepth is the maximum nesting depth of the geometry collections.
+	maxGeometryDepth = 64
+)
+
+var errInvalidGeometry = errors.New("invalid geometry")
+
+// IsSupportedSRID returns whether the spatial reference system is supported. Only the Cartesian
+// plane and WGS 84 are supported.
+func IsSupportedSRID(srid uint32) bool {
+	return srid == SRIDCartesian || srid == SRIDWGS84
+}
+
+// CheckSRID returns an error if the spatial reference system isn't supported.
+func CheckSRID(srid uint32) error {
+	if !IsSupportedSRID(srid) {
+		return ErrSRSNotFound.GenWithStackByArgs(srid)
+	}
+	return nil
+}
+
+// Point is a point of the geometry. For the geographic spatial reference system, X is the
+// latitude and Y is the longitude, which is the axis order of EPSG 4326.
+type Point struct {
+	X, Y float64
+}
+
+// Geometry is a decoded geometry value.
+//
+// The value of a geometry column is stored in the internal format of MySQL, which is the
+// 4 bytes little-endian SRID followed by the WKB of the geometry.
+type Geometry struct {
+	// Type is the geometry type, e.g. mysql.GeometryTypePoint.
+	Type byte
+	SRID uint32
+	// Points are the point of a POINT, or the points of a LINESTRING.
+	Points []Point
+	// Rings are the rings of a POLYGON, the first of which is the exterior ring.
+	Rings [][]Point
+	// Geoms are the members of a MULTIPOINT, MULTILINESTRING, MULTIPOLYGON or GEOMETRYCOLLECTION.
+	Geoms []Geometry
+}
+
+// NewPointGeometry creates a POINT.
+func NewPointGeometry(x, y float64, srid uint32) Geometry {
+	return Geometry{Type: mysql.GeometryTypePoint, SRID: srid, Points: []Point{{X: x, Y: y}}}
+}
+
+// IsEmpty returns whether the geometry doesn't contain any point, which is only possible
+// for an empty geometry collection.
+func (g *Geometry) IsEmpty() bool {
+	switch g.Type {
+	case mysql.GeometryTypePoint, mysql.GeometryTypeLineString:
+		return len(g.Points) == 0
+	case mysql.GeometryTypePolygon:
+		return len(g.Rings) == 0
+	}
+	for i := range g.Geoms {
+		if !g.Geoms[i].IsEmpty() {
+			return false
+		}
+	}
+	return true
+}
+
+// SetSRID sets the SRID of the geometry and all its members.
+func (g *Geometry) SetSRID(srid uint32) {
+	g.SRID = srid
+	for i := range g.Geoms {
+		g.Geoms[i].SetSRID(srid)
+	}
+}
+
+// forEachPoint calls fn for every point of the geometry until it returns false.
+func (g *Geometry) forEachPoint(fn func(p Point) bool) bool {
+	for _, p := range g.Points {
+		if !fn(p) {
+			return false
+		}
+	}
+	for _, ring := range g.Rings {
+		for _, p := range ring {
+			if !fn(p) {
+				return false
+			}
+		}
+	}
+	for i := range g.Geoms {
+		if !g.Geoms[i].forEachPoint(fn) {
+			return false
+		}
+	}
+	return true
+}
+
+// Validate checks whether the geometry is well-formed: the coordinates are finite numbers, a
+// LINESTRING has at least 2 points, and every ring of a POLYGON is closed and has at least 4 points.
+func (g *Geometry) Validate() error {
+	valid := g.forEachPoint(func(p Point) bool {
+		return !math.IsNaN(p.X) && !math.IsInf(p.X, 0) && !math.IsNaN(p.Y) && !math.IsInf(p.Y, 0)
+	})
+	if !valid {
+		return errInvalidGeometry
+	}
+	return g.validateShape()
+}
+
+func (g *Geometry) validateShape() error {
+	switch g.Type {
+	case mysql.GeometryTypePoint:
+		if len(g.Points) != 1 {
+			return errInvalidGeometry
+		}
+	case mysql.GeometryTypeLineString:
+		if len(g.Points) < 2 {
+			return errInvalidGeometry
+		}
+	case mysql.GeometryTypePolygon:
+		if len(g.Rings) == 0 {
+			return errInvalidGeometry
+		}
+		for _, ring := range g.Rings {
+			if len(ring) < 4 || ring[0] != ring[len(ring)-1] {
+				return errInvalidGeometry
+			}
+		}
+	case mysql.GeometryTypeMultiPoint, mysql.GeometryTypeMultiLineString, mysql.GeometryTypeMultiPolygon:
+		if len(g.Geoms) == 0 {
+			return errInvalidGeometry
+		}
+		for i := range g.Geoms {
+			if g.Geoms[i].Type != g.Type-3 {
+				return errInvalidGeometry
+			}
+			if err := g.Geoms[i].validateShape(); err != nil {
+				return err
+			}
+		}
+	case mysql.GeometryTypeGeometryCollection:
+		for i := range g.Geoms {
+			if err := g.Geoms[i].validateShape(); err != nil {
+				return err
+			}
+		}
+	default:
+		return errInvalidGeometry
+	}
+	return nil
+}
+
+// CheckGeographicCoordinates checks whether the coordinates are in the range of latitudes and
+// longitudes if the geometry is in the geographic spatial reference system.
+func (g *Geometry) CheckGeographicCoordinates(funcName string) error {
+	if g.SRID != SRIDWGS84 {
+		return nil
+	}
+	var err error
+	g.forEachPoint(func(p Point) bool {
+		if p.X < -90 || p.X > 90 {
+			err = ErrLatitudeOutOfRange.GenWithStackByArgs(p.X, funcName, -90.0, 90.0)
+		} else if p.Y <= -180 || p.Y > 180 {
+			err = ErrLongitudeOutOfRange.GenWithStackByArgs(p.Y, funcName, -180.0, 180.0)
+		}
+		return err == nil
+	})
+	return err
+}
+
+// TypeName returns the name of the geometry type, e.g. POINT.
+func (g *Geometry) TypeName() string {
+	switch g.Type {
+	case mysql.GeometryTypePoint:
+		return "POINT"
+	case mysql.GeometryTypeLineString:
+		return "LINESTRING"
+	case mysql.GeometryTypePolygon:
+		return "POLYGON"
+	case mysql.GeometryTypeMultiPoint:
+		return "MULTIPOINT"
+	case mysql.GeometryTypeMultiLineString:
+		return "MULTILINESTRING"
+	case mysql.GeometryTypeMultiPolygon:
+		return "MULTIPOLYGON"
+	case mysql.GeometryTypeGeometryCollection:
+		return "GEOMETRYCOLLECTION"
+	}
+	return "GEOMETRY"
+}
+
+// IsGeometrySubtype returns whether the geometry can be stored in the column of the geometry type.
+// A GEOMETRY column accepts all the geometries, and a GEOMETRYCOLLECTION column accepts the
+// multi geometries as well.
+func IsGeometrySubtype(geomType, columnType byte) bool {
+	switch columnType {
+	case mysql.GeometryTypeGeometry:
+		return true
+	case mysql.GeometryTypeGeometryCollection:
+		return geomType >= mysql.GeometryTypeMultiPoint
+	}
+	return geomType == columnType
+}
+
+// ******************** WKT ********************
+
+// WKT returns the well-known text of the geometry, e.g. POINT(1 2).
+func (g *Geometry) WKT() string {
+	var sb strings.Builder
+	g.writeWKT(&sb, true)
+	return sb.String()
+}
+
+func (g *Geometry) writeWKT(sb *strings.Builder, withName bool) {
+	if withName {
+		sb.WriteString(g.TypeName())
+	}
+	switch g.Type {
+	case mysql.GeometryTypePoint, mysql.GeometryTypeLineString:
+		writeWKTPoints(sb, g.Points)
+		return
+	case mysql.GeometryTypePolygon:
+		writeWKTRings(sb, g.Rings)
+		return
+	}
+	if g.Type == mysql.GeometryTypeGeometryCollection && len(g.Geoms) == 0 {
+		sb.WriteString(" EMPTY")
+		return
+	}
+	sb.WriteByte('(')
+	for i := range g.Geoms {
+		if i > 0 {
+			sb.WriteByte(',')
+		}
+		g.Geoms[i].writeWKT(sb, g.Type == mysql.GeometryTypeGeometryCollection)
+	}
+	sb.WriteByte(')')
+}
+
+func writeWKTPoints(sb *strings.Builder, points []Point) {
+	sb.WriteByte('(')
+	for i, p := range points {
+		if i > 0 {
+			sb.WriteByte(',')
+		}
+		sb.WriteString(formatGeometryCoordinate(p.X))
+		sb.WriteByte(' ')
+		sb.WriteString(formatGeometryCoordinate(p.Y))
+	}
+	sb.WriteByte(')')
+}
+
+func writeWKTRings(sb *strings.Builder, rings [][]Point) {
+	sb.WriteByte('(')
+	for i, ring := range rings {
+		if i > 0 {
+			sb.WriteByte(',')
+		}
+		writeWKTPoints(sb, ring)
+	}
+	sb.WriteByte(')')
+}
+
+// formatGeometryCoordinate formats the coordinate in the shortest representation. The scientific
+// notation is only used for very large or small numbers, e.g. 1e20.
+func formatGeometryCoordinate(f float64) string {
+	if f == 0 {
+		return "0"
+	}
+	if abs := math.Abs(f); abs >= 1e-5 && abs < 1e15 {
+		return strconv.FormatFloat(f, 'f', -1, 64)
+	}
+	s := strconv.FormatFloat(f, 'e', -1, 64)
+	mantissa, exp, _ := strings.Cut(s, "e")
+	exp = strings.TrimPrefix(exp, "+")
+	neg := strings.HasPrefix(exp, "-")
+	exp = strings.TrimLeft(strings.TrimPrefix(exp, "-"), "0")
+	if neg {
+		exp = "-" + exp
+	}
+	return mantissa + "e" + exp
+}
+
+// ParseGeometryWKT parses the well-known text of a geometry.
+func ParseGeometryWKT(wkt string, srid uint32) (Geometry, error) {
+	p := &wktParser{text: wkt}
+	g, err := p.parseGeometry(0)
+	if err != nil {
+		return Geometry{}, err
+	}
+	p.skipSpaces()
+	if p.pos != len(p.text) {
+		return Geometry{}, errInvalidGeometry
+	}
+	g.SetSRID(srid)
+	if err = g.Validate(); err != nil {
+		return Geometry{}, err
+	}
+	return g, nil
+}
+
+type wktParser struct {
+	text string
+	pos  int
+}
+
+func (p *wktParser) skipSpaces() {
+	for p.pos < len(p.text) {
+		switch p.text[p.pos] {
+		case ' ', '\t', '\n', '\r':
+			p.pos++
+		default:
+			return
+		}
+	}
+}
+
+func (p *wktParser) peek() byte {
+	p.skipSpaces()
+	if p.pos >= len(p.text) {
+		return 0
+	}
+	return p.text[p.pos]
+}
+
+func (p *wktParser) expect(c byte) error {
+	if p.peek() != c {
+		return errInvalidGeometry
+	}
+	p.pos++
+	return nil
+}
+
+func (p *wktParser) word() string {
+	p.skipSpaces()
+	start := p.pos
+	for p.pos < len(p.text) {
+		c := p.text[p.pos]
+		if !(c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z') {
+			break
+		}
+		p.pos++
+	}
+	return strings.ToUpper(p.text[start:p.pos])
+}
+
+func (p *wktParser) number() (float64, error) {
+	p.skipSpaces()
+	start := p.pos
+	for p.pos < len(p.text) {
+		c := p.text[p.pos]
+		if !(c >= '0' && c <= '9' || c == '.' || c == '-' || c == '+' || c == 'e' || c == 'E') {
+			break
+		}
+		p.pos++
+	}
+	f, err := strconv.ParseFloat(p.text[start:p.pos], 64)
+	if err != nil {
+		return 0, errInvalidGeometry
+	}
+	return f, nil
+}
+
+func (p *wktParser) parseGeometry(depth int) (Geometry, error) {
+	if depth > maxGeometryDepth {
+		return Geometry{}, errInvalidGeometry
+	}
+	var g Geometry
+	var err error
+	switch p.word() {
+	case "POINT":
+		g.Type = mysql.GeometryTypePoint
+		if err = p.expect('('); err != nil {
+			return g, err
+		}
+		var pt Point
+		if pt, err = p.parsePoint(); err != nil {
+			return g, err
+		}
+		g.Points = []Point{pt}
+		err = p.expect(')')
+	case "LINESTRING":
+		g.Type = mysql.GeometryTypeLineString
+		g.Points, err = p.parsePoints()
+	case "POLYGON":
+		g.Type = mysql.GeometryTypePolygon
+		g.Rings, err = p.parseRings()
+	case "MULTIPOINT":
+		g.Type = mysql.GeometryTypeMultiPoint
+		err = p.parseList(false, func() error {
+			// Both MULTIPOINT(1 1,2 2) and MULTIPOINT((1 1),(2 2)) are accepted.
+			parenthesized := p.peek() == '('
+			if parenthesized {
+				p.pos++
+			}
+			pt, err := p.parsePoint()
+			if err != nil {
+				return err
+			}
+			if parenthesized {
+				if err = p.expect(')'); err != nil {
+					return err
+				}
+			}
+			g.Geoms = append(g.Geoms, Geometry{Type: mysql.GeometryTypePoint, Points: []Point{pt}})
+			return nil
+		})
+	case "MULTILINESTRING":
+		g.Type = mysql.GeometryTypeMultiLineString
+		err = p.parseList(false, func() error {
+			points, err := p.parsePoints()
+			g.Geoms = append(g.Geoms, Geometry{Type: mysql.GeometryTypeLineString, Points: points})
+			return err
+		})
+	case "MULTIPOLYGON":
+		g.Type = mysql.GeometryTypeMultiPolygon
+		err = p.parseList(false, func() error {
+			rings, err := p.parseRings()
+			g.Geoms = append(g.Geoms, Geometry{Type: mysql.GeometryTypePolygon, Rings: rings})
+			return err
+		})
+	case "GEOMETRYCOLLECTION", "GEOMCOLLECTION":
+		g.Type = mysql.GeometryTypeGeometryCollection
+		if p.peek() != '(' {
+			if p.word() != "EMPTY" {
+				return g, errInvalidGeometry
+			}
+			return g, nil
+		}
+		err = p.parseList(true, func() error {
+			member, err := p.parseGeometry(depth + 1)
+			g.Geoms = append(g.Geoms, member)
+			return err
+		})
+	default:
+		return g, errInvalidGeometry
+	}
+	return g, err
+}
+
+func (p *wktParser) parsePoint() (Point, error) {
+	x, err := p.number()
+	if err != nil {
+		return Point{}, err
+	}
+	y, err := p.number()
+	return Point{X: x, Y: y}, err
+}
+
+// parseList parses the comma separated items in parentheses.
+func (p *wktParser) parseList(allowEmpty bool, parseItem func() error) error {
+	if err := p.expect('('); err != nil {
+		return err
+	}
+	if allowEmpty && p.peek() == ')' {
+		p.pos++
+		return nil
+	}
+	for {
+		if err := parseItem(); err != nil {
+			return err
+		}
+		if p.peek() != ',' {
+			break
+		}
+		p.pos++
+	}
+	return p.expect(')')
+}
+
+func (p *wktParser) parsePoints() ([]Point, error) {
+	var points []Point
+	err := p.parseList(false, func() error {
+		pt, err := p.parsePoint()
+		points = append(points, pt)
+		return err
+	})
+	return points, err
+}
+
+func (p *wktParser) parseRings() ([][]Point, error) {
+	var rings [][]Point
+	err := p.parseList(false, func() error {
+		ring, err := p.parsePoints()
+		rings = append(rings, ring)
+		return err
+	})
+	return rings, err
+}
+
+// ******************** WKB ********************
+
+// WKB returns the well-known binary of the geometry in little-endian.
+func (g *Geometry) WKB() []byte {
+	return g.appendWKB(nil)
+}
+
+func (g *Geometry) appendWKB(buf []byte) []byte {
+	buf = append(buf, wkbLittleEndian)
+	buf = binary.LittleEndian.AppendUint32(buf, uint32(g.Type))
+	switch g.Type {
+	case mysql.GeometryTypePoint:
+		return appendWKBPoint(buf, g.Points[0])
+	case mysql.GeometryTypeLineString:
+		return appendWKBPoints(buf, g.Points)
+	case mysql.GeometryTypePolygon:
+		buf = binary.LittleEndian.AppendUint32(buf, uint32(len(g.Rings)))
+		for _, ring := range g.Rings {
+			buf = appendWKBPoints(buf, ring)
+		}
+		return buf
+	}
+	buf = binary.LittleEndian.AppendUint32(buf, uint32(len(g.Geoms)))
+	for i := range g.Geoms {
+		buf = g.Geoms[i].appendWKB(buf)
+	}
+	return buf
+}
+
+func appendWKBPoint(buf []byte, p Point) []byte {
+	buf = binary.LittleEndian.AppendUint64(buf, math.Float64bits(p.X))
+	return binary.LittleEndian.AppendUint64(buf, math.Float64bits(p.Y))
+}
+
+func appendWKBPoints(buf []byte, points []Point) []byte {
+	buf = binary.LittleEndian.AppendUint32(buf, uint32(len(points)))
+	for _, p := range points {
+		buf = appendWKBPoint(buf, p)
+	}
+	return buf
+}
+
+// ParseGeometryWKB parses the well-known binary of a geometry in either byte order.
+func ParseGeometryWKB(wkb []byte, srid uint32) (Geometry, error) {
+	r := &wkbReader{data: wkb}
+	g, err := r.readGeometry(0)
+	if err != nil {
+		return Geometry{}, err
+	}
+	if len(r.data) != 0 {
+		return Geometry{}, errInvalidGeometry
+	}
+	g.SetSRID(srid)
+	if err = g.Validate(); err != nil {
+		return Geometry{}, err
+	}
+	return g, nil
+}
+
+type wkbReader struct {
+	data  []byte
+	order binary.ByteOrder
+}
+
+func (r *wkbReader) readUint32() (uint32, error) {
+	if len(r.data) < 4 {
+		return 0, errInvalidGeometry
+	}
+	v := r.order.Uint32(r.data)
+	r.data = r.data[4:]
+	return v, nil
+}
+
+// readCount reads the number of the items, each of which takes at least minSize bytes.
+func (r *wkbReader) readCount(minSize int) (int, error) {
+	n, err := r.readUint32()
+	if err != nil {
+		return 0, err
+	}
+	if uint64(n)*uint64(minSize) > uint64(len(r.data)) {
+		return 0, errInvalidGeometry
+	}
+	return int(n), nil
+}
+
+func (r *wkbReader) readPoint() (Point, error) {
+	if len(r.data) < 16 {
+		return Point{}, errInvalidGeometry
+	}
+	p := Point{
+		X: math.Float64frombits(r.order.Uint64(r.data)),
+		Y: math.Float64frombits(r.order.Uint64(r.data[8:])),
+	}
+	r.data = r.data[16:]
+	return p, nil
+}
+
+func (r *wkbReader) readPoints() ([]Point, error) {
+	n, err := r.readCount(16)
+	if err != nil {
+		return nil, err
+	}
+	points := make([]Point, n)
+	for i := range points {
+		if points[i], err = r.readPoint(); err != nil {
+			return nil, err
+		}
+	}
+	return points, nil
+}
+
+func (r *wkbReader) readGeometry(depth int) (Geometry, error) {
+	var g Geometry
+	if depth > maxGeometryDepth || len(r.data) < 1 {
+		return g, errInvalidGeometry
+	}
+	switch r.data[0] {
+	case wkbLittleEndian:
+		r.order = binary.LittleEndian
+	case wkbBigEndian:
+		r.order = binary.BigEndian
+	default:
+		return g, errInvalidGeometry
+	}
+	r.data = r.data[1:]
+	tp, err := r.readUint32()
+	if err != nil {
+		return g, err
+	}
+	if tp < uint32(mysql.GeometryTypePoint) || tp > uint32(mysql.GeometryTypeGeometryCollection) {
+		return g, errInvalidGeometry
+	}
+	g.Type = byte(tp)
+	switch g.Type {
+	case mysql.GeometryTypePoint:
+		var p Point
+		p, err = r.readPoint()
+		g.Points = []Point{p}
+	case mysql.GeometryTypeLineString:
+		g.Points, err = r.readPoints()
+	case mysql.GeometryTypePolygon:
+		var n int
+		if n, err = r.readCount(4); err != nil {
+			return g, err
+		}
+		g.Rings = make([][]Point, n)
+		for i := range g.Rings {
+			if g.Rings[i], err = r.readPoints(); err != nil {
+				return g, err
+			}
+		}
+	default:
+		var n int
+		// The smallest member is an empty geometry collection.
+		if n, err = r.readCount(9); err != nil {
+			return g, err
+		}
+		g.Geoms = make([]Geometry, n)
+		for i := range g.Geoms {
+			if g.Geoms[i], err = r.readGeometry(depth + 1); err != nil {
+				return g, err
+			}
+		}
+	}
+	return g, err
+}
+
+// ******************** internal format ********************
+
+// EncodeGeometry encodes the geometry into the internal format, which is the value of the geometry column.
+func EncodeGeometry(g *Geometry) []byte {
+	buf := make([]byte, 0, 32)
+	buf = binary.LittleEndian.AppendUint32(buf, g.SRID)
+	return g.appendWKB(buf)
+}
+
+// DecodeGeometry decodes the geometry from the internal format.
+func DecodeGeometry(data []byte) (Geometry, error) {
+	if len(data) < geometrySRIDLen {
+		return Geometry{}, errInvalidGeometry
+	}
+	return ParseGeometryWKB(data[geometrySRIDLen:], binary.LittleEndian.Uint32(data))
+}
+
+// convertToGeometry converts the datum to the value of the geometry column. Only the values in the
+// internal format of the geometries, which are returned by the spatial functions, can be stored.
+func (d *Datum) convertToGeometry(target *FieldType) (ret Datum, err error) {
+	switch d.k {
+	case KindString, KindBytes:
+	default:
+		return ret, ErrCantCreateGeometryObject.GenWithStackByArgs()
+	}
+	g, err := DecodeGeometry(d.GetBytes())
+	if err != nil || !IsGeometrySubtype(g.Type, target.GetGeometryType()) {
+		return ret, ErrCantCreateGeometryObject.GenWithStackByArgs()
+	}
+	ret.SetBytes(d.GetBytes())
+	return ret, nil
+}
@@ -0,0 +1,511 @@
+// Copyright 2024 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package types
+
+import (
+	"math"
+	"math/bits"
+	"slices"
+
+	"github.com/pingcap/tidb/pkg/parser/mysql"
+)
+
+// The spatial predicates are computed on the plane, including the geometries in the geographic
+// spatial reference system whose latitudes and longitudes are treated as the planar coordinates.
+
+// DefaultSphereRadius is the default radius of ST_Distance_Sphere in meters, which is the
+// mean radius of the earth.
+const DefaultSphereRadius = 6370986.0
+
+// geometryLocation is the location of a point relative to a geometry.
+type geometryLocation int
+
+const (
+	locationExterior geometryLocation = iota
+	locationBoundary
+	locationInterior
+)
+
+type segment struct {
+	a, b Point
+}
+
+// geometryParts is the flattened components of a geometry.
+type geometryParts struct {
+	points   []Point
+	lines    [][]Point
+	polygons [][][]Point
+}
+
+func (g *Geometry) parts() *geometryParts {
+	p := &geometryParts{}
+	p.collect(g)
+	return p
+}
+
+func (p *geometryParts) collect(g *Geometry) {
+	switch g.Type {
+	case mysql.GeometryTypePoint:
+		p.points = append(p.points, g.Points...)
+	case mysql.GeometryTypeLineString:
+		p.lines = append(p.lines, g.Points)
+	case mysql.GeometryTypePolygon:
+		p.polygons = append(p.polygons, g.Rings)
+	}
+	for i := range g.Geoms {
+		p.collect(&g.Geoms[i])
+	}
+}
+
+// segments returns the segments of the linestrings, and the edges of the polygons if withPolygons is true.
+func (p *geometryParts) segments(withPolygons bool) []segment {
+	var segs []segment
+	for _, line := range p.lines {
+		segs = appendSegments(segs, line)
+	}
+	if withPolygons {
+		for _, rings := range p.polygons {
+			for _, ring := range rings {
+				segs = appendSegments(segs, ring)
+			}
+		}
+	}
+	return segs
+}
+
+func appendSegments(segs []segment, points []Point) []segment {
+	for i := 1; i < len(points); i++ {
+		segs = append(segs, segment{a: points[i-1], b: points[i]})
+	}
+	return segs
+}
+
+// vertices returns all the points of the geometry.
+func (p *geometryParts) vertices() []Point {
+	vertices := slices.Clone(p.points)
+	for _, line := range p.lines {
+		vertices = append(vertices, line...)
+	}
+	for _, rings := range p.polygons {
+		for _, ring := range rings {
+			vertices = append(vertices, ring...)
+		}
+	}
+	return vertices
+}
+
+// locate returns the location of the point relative to the geometry. The point is in the interior of
+// the geometry if it's in the interior of any component.
+func (p *geometryParts) locate(pt Point) geometryLocation {
+	loc := locationExterior
+	for _, q := range p.points {
+		if q == pt {
+			return locationInterior
+		}
+	}
+	for _, line := range p.lines {
+		for i := 1; i < len(line); i++ {
+			if !onSegment(pt, line[i-1], line[i]) {
+				continue
+			}
+			closed := line[0] == line[len(line)-1]
+			if !closed && (pt == line[0] || pt == line[len(line)-1]) {
+				loc = locationBoundary
+				continue
+			}
+			return locationInterior
+		}
+	}
+	for _, rings := range p.polygons {
+		switch locatePolygon(pt, rings) {
+		case locationInterior:
+			return locationInterior
+		case locationBoundary:
+			loc = locationBoundary
+		}
+	}
+	return loc
+}
+
+func locatePolygon(pt Point, rings [][]Point) geometryLocation {
+	switch locateRing(pt, rings[0]) {
+	case locationExterior:
+		return locationExterior
+	case locationBoundary:
+		return locationBoundary
+	}
+	for _, hole := range rings[1:] {
+		switch locateRing(pt, hole) {
+		case locationInterior:
+			return locationExterior
+		case locationBoundary:
+			return locationBoundary
+		}
+	}
+	return locationInterior
+}
+
+// locateRing returns the location of the point relative to the area enclosed by the ring.
+func locateRing(pt Point, ring []Point) geometryLocation {
+	inside := false
+	for i := 1; i < len(ring); i++ {
+		a, b := ring[i-1], ring[i]
+		if onSegment(pt, a, b) {
+			return locationBoundary
+		}
+		if (a.Y > pt.Y) != (b.Y > pt.Y) && pt.X < (b.X-a.X)*(pt.Y-a.Y)/(b.Y-a.Y)+a.X {
+			inside = !inside
+		}
+	}
+	if inside {
+		return locationInterior
+	}
+	return locationExterior
+}
+
+func orientation(a, b, c Point) float64 {
+	return (b.X-a.X)*(c.Y-a.Y) - (b.Y-a.Y)*(c.X-a.X)
+}
+
+func inBox(p, a, b Point) bool {
+	return p.X >= math.Min(a.X, b.X) && p.X <= math.Max(a.X, b.X) &&
+		p.Y >= math.Min(a.Y, b.Y) && p.Y <= math.Max(a.Y, b.Y)
+}
+
+// onSegment returns whether the point is on the segment ab.
+func onSegment(p, a, b Point) bool {
+	return orientation(a, b, p) == 0 && inBox(p, a, b)
+}
+
+func sign(f float64) int {
+	switch {
+	case f > 0:
+		return 1
+	case f < 0:
+		return -1
+	}
+	return 0
+}
+
+func segmentsIntersect(s, t segment) bool {
+	d1 := sign(orientation(t.a, t.b, s.a))
+	d2 := sign(orientation(t.a, t.b, s.b))
+	d3 := sign(orientation(s.a, s.b, t.a))
+	d4 := sign(orientation(s.a, s.b, t.b))
+	if d1*d2 < 0 && d3*d4 < 0 {
+		return true
+	}
+	return (d1 == 0 && inBox(s.a, t.a, t.b)) || (d2 == 0 && inBox(s.b, t.a, t.b)) ||
+		(d3 == 0 && inBox(t.a, s.a, s.b)) || (d4 == 0 && inBox(t.b, s.a, s.b))
+}
+
+// Envelope returns the minimum bounding rectangle of the geometry.
+func (g *Geometry) Envelope() (minPt, maxPt Point) {
+	minPt = Point{X: math.Inf(1), Y: math.Inf(1)}
+	maxPt = Point{X: math.Inf(-1), Y: math.Inf(-1)}
+	g.forEachPoint(func(p Point) bool {
+		minPt.X, minPt.Y = math.Min(minPt.X, p.X), math.Min(minPt.Y, p.Y)
+		maxPt.X, maxPt.Y = math.Max(maxPt.X, p.X), math.Max(maxPt.Y, p.Y)
+		return true
+	})
+	return minPt, maxPt
+}
+
+func envelopesIntersect(a, b *Geometry) bool {
+	aMin, aMax := a.Envelope()
+	bMin, bMax := b.Envelope()
+	return aMin.X <= bMax.X && bMin.X <= aMax.X && aMin.Y <= bMax.Y && bMin.Y <= aMax.Y
+}
+
+// GeometryIntersects returns whether the two geometries have at least one point in common.
+func GeometryIntersects(a, b *Geometry) bool {
+	if a.IsEmpty() || b.IsEmpty() || !envelopesIntersect(a, b) {
+		return false
+	}
+	pa, pb := a.parts(), b.parts()
+	for _, segA := range pa.segments(true) {
+		for _, segB := range pb.segments(true) {
+			if segmentsIntersect(segA, segB) {
+				return true
+			}
+		}
+	}
+	// The geometries don't cross each other, so they intersect only if a component of
+	// one geometry is entirely inside the other one.
+	return anyComponentInside(pa, pb) || anyComponentInside(pb, pa)
+}
+
+func anyComponentInside(inner, outer *geometryParts) bool {
+	for _, p := range inner.points {
+		if outer.locate(p) != locationExterior {
+			return true
+		}
+	}
+	for _, line := range inner.lines {
+		if outer.locate(line[0]) != locationExterior {
+			return true
+		}
+	}
+	for _, rings := range inner.polygons {
+		if outer.locate(rings[0][0]) != locationExterior {
+			return true
+		}
+	}
+	return false
+}
+
+// GeometryWithin returns whether the geometry a is within the geometry b, i.e. no point of a is in
+// the exterior of b, and at least one point of the interior of a is in the interior of b.
+func GeometryWithin(a, b *Geometry) bool {
+	if a.IsEmpty() || b.IsEmpty() {
+		return false
+	}
+	aMin, aMax := a.Envelope()
+	bMin, bMax := b.Envelope()
+	if aMin.X < bMin.X || aMin.Y < bMin.Y || aMax.X > bMax.X || aMax.Y > bMax.Y {
+		return false
+	}
+	pa, pb := a.parts(), b.parts()
+	interior := false
+	for _, p := range pa.points {
+		switch pb.locate(p) {
+		case locationExterior:
+			return false
+		case locationInterior:
+			interior = true
+		}
+	}
+	segsB := pb.segments(true)
+	for _, seg := range pa.segments(true) {
+		for _, pt := range splitSegment(seg, segsB, pb.points) {
+			switch pb.locate(pt) {
+			case locationExterior:
+				return false
+			case locationInterior:
+				interior = true
+			}
+		}
+	}
+	if len(pa.polygons) > 0 {
+		if len(pb.polygons) == 0 {
+			return false
+		}
+		// The boundary of b mustn't go through the interior of a, otherwise a contains some
+		// points in the exterior of b.
+		polygonsA := &geometryParts{polygons: pa.polygons}
+		segsA := polygonsA.segments(true)
+		for _, seg := range (&geometryParts{polygons: pb.polygons}).segments(true) {
+			for _, pt := range splitSegment(seg, segsA, nil) {
+				if polygonsA.locate(pt) == locationInterior {
+					return false
+				}
+			}
+		}
+		// A polygon within the other polygons always shares some interior points with them.
+		interior = true
+	}
+	return interior
+}
+
+// splitSegment splits the segment at the points where it meets the other segments and points, and
+// returns the endpoints and the midpoints of the pieces. Every piece is either entirely in the
+// interior, on the boundary, or in the exterior of the geometry formed by the other segments.
+func splitSegment(seg segment, others []segment, points []Point) []Point {
+	dx, dy := seg.b.X-seg.a.X, seg.b.Y-seg.a.Y
+	param := func(p Point) float64 {
+		if math.Abs(dx) >= math.Abs(dy) {
+			return (p.X - seg.a.X) / dx
+		}
+		return (p.Y - seg.a.Y) / dy
+	}
+	ts := []float64{0, 1}
+	addPoint := func(p Point) {
+		if onSegment(p, seg.a, seg.b) {
+			ts = append(ts, param(p))
+		}
+	}
+	for _, p := range points {
+		addPoint(p)
+	}
+	for _, other := range others {
+		if !segmentsIntersect(seg, other) {
+			continue
+		}
+		denom := dx*(other.b.Y-other.a.Y) - dy*(other.b.X-other.a.X)
+		if denom == 0 {
+			// The segments are collinear and overlap.
+			addPoint(other.a)
+			addPoint(other.b)
+			continue
+		}
+		t := ((other.a.X-seg.a.X)*(other.b.Y-other.a.Y) - (other.a.Y-seg.a.Y)*(other.b.X-other.a.X)) / denom
+		ts = append(ts, math.Max(0, math.Min(1, t)))
+	}
+	slices.Sort(ts)
+	ts = slices.Compact(ts)
+	at := func(t float64) Point {
+		return Point{X: seg.a.X + dx*t, Y: seg.a.Y + dy*t}
+	}
+	pts := make([]Point, 0, len(ts)*2)
+	pts = append(pts, seg.a, seg.b)
+	for i := 1; i < len(ts); i++ {
+		pts = append(pts, at((ts[i-1]+ts[i])/2))
+	}
+	return pts
+}
+
+// GeometryDistance returns the minimum Cartesian distance between the two geometries.
+func GeometryDistance(a, b *Geometry) float64 {
+	if GeometryIntersects(a, b) {
+		return 0
+	}
+	pa, pb := a.parts(), b.parts()
+	return math.Min(minVertexDistance(pa, pb), minVertexDistance(pb, pa))
+}
+
+// minVertexDistance returns the minimum distance from the vertices of a to the segments and points
+// of b. The distance between two disjoint geometries is always reached at a vertex of one of them.
+func minVertexDistance(a, b *geometryParts) float64 {
+	segs := b.segments(true)
+	for _, p := range b.points {
+		segs = append(segs, segment{a: p, b: p})
+	}
+	dist := math.Inf(1)
+	for _, p := range a.vertices() {
+		for _, seg := range segs {
+			dist = math.Min(dist, pointSegmentDistance(p, seg))
+		}
+	}
+	return dist
+}
+
+func pointSegmentDistance(p Point, seg segment) float64 {
+	dx, dy := seg.b.X-seg.a.X, seg.b.Y-seg.a.Y
+	t := 0.0
+	if l := dx*dx + dy*dy; l > 0 {
+		t = math.Max(0, math.Min(1, ((p.X-seg.a.X)*dx+(p.Y-seg.a.Y)*dy)/l))
+	}
+	return math.Hypot(p.X-seg.a.X-t*dx, p.Y-seg.a.Y-t*dy)
+}
+
+// GeometryDistanceSphere returns the minimum spherical distance between two points or multipoints
+// on a sphere with the radius. The coordinates are the longitudes and latitudes in degrees.
+func GeometryDistanceSphere(a, b *Geometry, radius float64, funcName string) (float64, error) {
+	if radius <= 0 {
+		return 0, ErrNonPositiveRadius.GenWithStackByArgs(funcName)
+	}
+	pa, pb := a.parts(), b.parts()
+	for _, g := range []*Geometry{a, b} {
+		if g.Type != mysql.GeometryTypePoint && g.Type != mysql.GeometryTypeMultiPoint {
+			return 0, ErrGISUnsupportedArgument.GenWithStackByArgs(funcName)
+		}
+	}
+	toLngLat := func(g *Geometry, p Point) (lng, lat float64, err error) {
+		lng, lat = p.X, p.Y
+		if g.SRID == SRIDWGS84 {
+			lng, lat = p.Y, p.X
+		}
+		if lng <= -180 || lng > 180 {
+			return 0, 0, ErrLongitudeOutOfRange.GenWithStackByArgs(lng, funcName, -180.0, 180.0)
+		}
+		if lat < -90 || lat > 90 {
+			return 0, 0, ErrLatitudeOutOfRange.GenWithStackByArgs(lat, funcName, -90.0, 90.0)
+		}
+		return lng * math.Pi / 180, lat * math.Pi / 180, nil
+	}
+	dist := math.Inf(1)
+	for _, p := range pa.points {
+		lng1, lat1, err := toLngLat(a, p)
+		if err != nil {
+			return 0, err
+		}
+		for _, q := range pb.points {
+			lng2, lat2, err := toLngLat(b, q)
+			if err != nil {
+				return 0, err
+			}
+			// The haversine formula.
+			h := math.Pow(math.Sin((lat2-lat1)/2), 2) + math.Cos(lat1)*math.Cos(lat2)*math.Pow(math.Sin((lng2-lng1)/2), 2)
+			dist = math.Min(dist, 2*radius*math.Asin(math.Min(1, math.Sqrt(h))))
+		}
+	}
+	return dist, nil
+}
+
+// ******************** spatial index ********************
+
+// The spatial index is a quadtree over the planar coordinates. The coordinates are mapped to 64 bits
+// keys which keep their order, and a cell of the quadtree is identified by the interleaved leading
+// bits of the keys of its points. The cell is stored as a string of the quadrant digits 0-3, so the
+// cells inside a cell are exactly the ones prefixed by it.
+//
+// A geometry is indexed by at most 4 cells covering its envelope. Two geometries can intersect only
+// if a cell of one of them is the same as, or inside, a cell of the other one.
+
+// MaxGeometryCellLevel is the maximum level of the cells of the spatial index.
+const MaxGeometryCellLevel = 64
+
+func geometryCoordKey(f float64) uint64 {
+	if f == 0 {
+		// Both 0 and -0 are mapped to the same key.
+		f = 0
+	}
+	u := math.Float64bits(f)
+	if u>>63 == 1 {
+		return ^u
+	}
+	return u | 1<<63
+}
+
+// GeometryIndexCells returns the cells of the spatial index covering the geometry. An empty
+// geometry isn't covered by any cell.
+func GeometryIndexCells(g *Geometry) [][]byte {
+	if g.IsEmpty() {
+		return nil
+	}
+	minPt, maxPt := g.Envelope()
+	x0, x1 := geometryCoordKey(minPt.X), geometryCoordKey(maxPt.X)
+	y0, y1 := geometryCoordKey(minPt.Y), geometryCoordKey(maxPt.Y)
+	// The smallest cell covering the envelope is at the level of the common leading bits.
+	level := min(bits.LeadingZeros64(x0^x1), bits.LeadingZeros64(y0^y1))
+	prefix := make([]byte, level, MaxGeometryCellLevel)
+	for i := range prefix {
+		prefix[i] = cellDigit(x0, y0, i)
+	}
+	if level == MaxGeometryCellLevel {
+		return [][]byte{prefix}
+	}
+	// Use the children of the smallest cell which overlap the envelope.
+	xs := []uint64{x0}
+	if (x0^x1)>>(63-level)&1 == 1 {
+		xs = append(xs, x1)
+	}
+	ys := []uint64{y0}
+	if (y0^y1)>>(63-level)&1 == 1 {
+		ys = append(ys, y1)
+	}
+	cells := make([][]byte, 0, len(xs)*len(ys))
+	for _, x := range xs {
+		for _, y := range ys {
+			cells = append(cells, append(slices.Clone(prefix), cellDigit(x, y, level)))
+		}
+	}
+	return cells
+}
+
+func cellDigit(x, y uint64, level int) byte {
+	shift := 63 - level
+	return byte((x>>shift&1)<<1 | y>>shift&1)
+}
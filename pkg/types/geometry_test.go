@@ -0,0 +1,249 @@
+// Copyright 2024 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package types
+
+import (
+	"bytes"
+	"encoding/hex"
+	"testing"
+
+	"github.com/pingcap/tidb/pkg/parser/mysql"
+	"github.com/stretchr/testify/require"
+)
+
+func mustParseGeometry(t *testing.T, wkt string) Geometry {
+	g, err := ParseGeometryWKT(wkt, 0)
+	require.NoError(t, err, wkt)
+	return g
+}
+
+func TestGeometryWKT(t *testing.T) {
+	tests := []struct {
+		input  string
+		output string
+	}{
+		{"POINT(1 2)", "POINT(1 2)"},
+		{" point ( -1.5  2e3 ) ", "POINT(-1.5 2000)"},
+		{"POINT(1e20 0.000001)", "POINT(1e20 1e-6)"},
+		{"LINESTRING(0 0, 1 1, 2 0)", "LINESTRING(0 0,1 1,2 0)"},
+		{"POLYGON((0 0,10 0,10 10,0 10,0 0),(2 2,2 4,4 4,2 2))", "POLYGON((0 0,10 0,10 10,0 10,0 0),(2 2,2 4,4 4,2 2))"},
+		{"MULTIPOINT(1 1, 2 2)", "MULTIPOINT((1 1),(2 2))"},
+		{"MULTIPOINT((1 1),(2 2))", "MULTIPOINT((1 1),(2 2))"},
+		{"MULTILINESTRING((0 0,1 1),(2 2,3 3))", "MULTILINESTRING((0 0,1 1),(2 2,3 3))"},
+		{"MULTIPOLYGON(((0 0,1 0,1 1,0 0)),((2 2,3 2,3 3,2 2)))", "MULTIPOLYGON(((0 0,1 0,1 1,0 0)),((2 2,3 2,3 3,2 2)))"},
+		{"GEOMCOLLECTION(POINT(1 1),LINESTRING(0 0,1 1))", "GEOMETRYCOLLECTION(POINT(1 1),LINESTRING(0 0,1 1))"},
+		{"GEOMETRYCOLLECTION EMPTY", "GEOMETRYCOLLECTION EMPTY"},
+		{"GEOMETRYCOLLECTION()", "GEOMETRYCOLLECTION EMPTY"},
+		{"GEOMETRYCOLLECTION(GEOMETRYCOLLECTION EMPTY,POINT(0 0))", "GEOMETRYCOLLECTION(GEOMETRYCOLLECTION EMPTY,POINT(0 0))"},
+	}
+	for _, tt := range tests {
+		g := mustParseGeometry(t, tt.input)
+		require.Equal(t, tt.output, g.WKT(), tt.input)
+
+		// The geometry is the same after the round trip of WKB and the internal format.
+		decoded, err := ParseGeometryWKB(g.WKB(), 0)
+		require.NoError(t, err)
+		require.Equal(t, tt.output, decoded.WKT())
+		g.SetSRID(SRIDWGS84)
+		decoded, err = DecodeGeometry(EncodeGeometry(&g))
+		require.NoError(t, err)
+		require.Equal(t, SRIDWGS84, decoded.SRID)
+		require.Equal(t, tt.output, decoded.WKT())
+	}
+
+	invalid := []string{
+		"", "POINT", "POINT()", "POINT(1)", "POINT(1 2 3)", "POINT(1 2", "POINT(1 2) x", "POINT EMPTY",
+		"LINESTRING(0 0)", "POLYGON((0 0,1 0,1 1))", "POLYGON((0 0,1 0,1 1,0 1))", "MULTIPOINT()",
+		"CIRCLE(0 0)", "POINT(a b)",
+	}
+	for _, wkt := range invalid {
+		_, err := ParseGeometryWKT(wkt, 0)
+		require.Error(t, err, wkt)
+	}
+}
+
+func TestGeometryWKB(t *testing.T) {
+	// POINT(1 2) in big-endian.
+	wkb, err := hex.DecodeString("00000000013ff00000000000004000000000000000")
+	require.NoError(t, err)
+	g, err := ParseGeometryWKB(wkb, 0)
+	require.NoError(t, err)
+	require.Equal(t, "POINT(1 2)", g.WKT())
+	require.Equal(t, "0101000000000000000000f03f0000000000000040", hex.EncodeToString(g.WKB()))
+	require.Equal(t, "e61000000101000000000000000000f03f0000000000000040", hex.EncodeToString(EncodeGeometry(&Geometry{
+		Type: mysql.GeometryTypePoint, SRID: SRIDWGS84, Points: g.Points,
+	})))
+
+	for _, data := range []string{"", "01", "0101000000000000000000f03f", "0201000000000000000000f03f0000000000000040", "0108000000", "01020000000100000000"} {
+		wkb, err := hex.DecodeString(data)
+		require.NoError(t, err)
+		_, err = ParseGeometryWKB(wkb, 0)
+		require.Error(t, err, data)
+	}
+	_, err = DecodeGeometry([]byte{0, 0})
+	require.Error(t, err)
+}
+
+func TestGeometryConvert(t *testing.T) {
+	point := mustParseGeometry(t, "POINT(1 1)")
+	line := mustParseGeometry(t, "LINESTRING(0 0,1 1)")
+	multi := mustParseGeometry(t, "MULTIPOINT(1 1,2 2)")
+	ft := NewFieldType(mysql.TypeGeometry)
+
+	d := NewBytesDatum(EncodeGeometry(&point))
+	_, err := d.ConvertTo(DefaultStmtNoWarningContext, ft)
+	require.NoError(t, err)
+	ft.SetGeometryType(mysql.GeometryTypeLineString)
+	_, err = d.ConvertTo(DefaultStmtNoWarningContext, ft)
+	require.True(t, ErrCantCreateGeometryObject.Equal(err))
+	d = NewBytesDatum(EncodeGeometry(&line))
+	_, err = d.ConvertTo(DefaultStmtNoWarningContext, ft)
+	require.NoError(t, err)
+	ft.SetGeometryType(mysql.GeometryTypeGeometryCollection)
+	d = NewBytesDatum(EncodeGeometry(&multi))
+	_, err = d.ConvertTo(DefaultStmtNoWarningContext, ft)
+	require.NoError(t, err)
+
+	for _, d := range []Datum{NewStringDatum("POINT(1 1)"), NewIntDatum(1)} {
+		_, err = d.ConvertTo(DefaultStmtNoWarningContext, ft)
+		require.True(t, ErrCantCreateGeometryObject.Equal(err))
+	}
+}
+
+func TestGeometryPredicates(t *testing.T) {
+	const (
+		square = "POLYGON((0 0,10 0,10 10,0 10,0 0))"
+		donut  = "POLYGON((0 0,10 0,10 10,0 10,0 0),(4 4,6 4,6 6,4 6,4 4))"
+	)
+	tests := []struct {
+		a, b       string
+		intersects bool
+		within     bool
+	}{
+		{"POINT(5 5)", square, true, true},
+		{"POINT(0 5)", square, true, false},
+		{"POINT(11 5)", square, false, false},
+		{"POINT(5 5)", donut, false, false},
+		{"POINT(3 3)", donut, true, true},
+		{"POINT(1 1)", "POINT(1 1)", true, true},
+		{"POINT(0 0)", "LINESTRING(0 0,2 2)", true, false},
+		{"POINT(1 1)", "LINESTRING(0 0,2 2)", true, true},
+		{"LINESTRING(0 0,2 2)", "LINESTRING(0 2,2 0)", true, false},
+		{"LINESTRING(0 0,1 1)", "LINESTRING(0 0,2 2)", true, true},
+		{"LINESTRING(0 0,1 1)", "LINESTRING(2 2,3 3)", false, false},
+		{"LINESTRING(1 1,9 9)", square, true, true},
+		{"LINESTRING(0 0,10 0)", square, true, false},
+		{"LINESTRING(1 1,11 11)", square, true, false},
+		{"LINESTRING(1 1,9 9)", donut, true, false},
+		{"POLYGON((1 1,2 1,2 2,1 2,1 1))", square, true, true},
+		{"POLYGON((1 1,2 1,2 2,1 2,1 1))", donut, true, true},
+		{"POLYGON((3 3,7 3,7 7,3 7,3 3))", donut, true, false},
+		{square, square, true, true},
+		{donut, square, true, true},
+		{square, donut, true, false},
+		{"POLYGON((5 5,15 5,15 15,5 15,5 5))", square, true, false},
+		{"POLYGON((20 20,30 20,30 30,20 20))", square, false, false},
+		{"MULTIPOINT(1 1,20 20)", square, true, false},
+		{"GEOMETRYCOLLECTION(POINT(1 1),LINESTRING(2 2,3 3))", square, true, true},
+		{"GEOMETRYCOLLECTION EMPTY", square, false, false},
+	}
+	for _, tt := range tests {
+		a, b := mustParseGeometry(t, tt.a), mustParseGeometry(t, tt.b)
+		require.Equal(t, tt.intersects, GeometryIntersects(&a, &b), "%s intersects %s", tt.a, tt.b)
+		require.Equal(t, tt.intersects, GeometryIntersects(&b, &a), "%s intersects %s", tt.b, tt.a)
+		require.Equal(t, tt.within, GeometryWithin(&a, &b), "%s within %s", tt.a, tt.b)
+	}
+}
+
+func TestGeometryDistance(t *testing.T) {
+	tests := []struct {
+		a, b string
+		dist float64
+	}{
+		{"POINT(0 0)", "POINT(3 4)", 5},
+		{"POINT(0 5)", "LINESTRING(-1 0,1 0)", 5},
+		{"POINT(5 5)", "POLYGON((0 0,10 0,10 10,0 10,0 0))", 0},
+		{"LINESTRING(0 0,0 10)", "POLYGON((3 0,10 0,10 10,3 10,3 0))", 3},
+		{"MULTIPOINT(0 0,9 9)", "POINT(10 10)", 1.4142135623730951},
+	}
+	for _, tt := range tests {
+		a, b := mustParseGeometry(t, tt.a), mustParseGeometry(t, tt.b)
+		require.InDelta(t, tt.dist, GeometryDistance(&a, &b), 1e-9, "%s, %s", tt.a, tt.b)
+	}
+
+	// The distance between Beijing and Shanghai.
+	a, b := NewPointGeometry(116.4, 39.9, 0), NewPointGeometry(121.47, 31.23, 0)
+	dist, err := GeometryDistanceSphere(&a, &b, DefaultSphereRadius, "st_distance_sphere")
+	require.NoError(t, err)
+	require.InDelta(t, 1067000, dist, 1000)
+	a.Points[0], b.Points[0] = Point{X: 39.9, Y: 116.4}, Point{X: 31.23, Y: 121.47}
+	a.SRID, b.SRID = SRIDWGS84, SRIDWGS84
+	dist2, err := GeometryDistanceSphere(&a, &b, DefaultSphereRadius, "st_distance_sphere")
+	require.NoError(t, err)
+	require.InDelta(t, dist, dist2, 1e-6)
+
+	_, err = GeometryDistanceSphere(&a, &b, 0, "st_distance_sphere")
+	require.True(t, ErrNonPositiveRadius.Equal(err))
+	a = NewPointGeometry(200, 0, 0)
+	_, err = GeometryDistanceSphere(&a, &b, DefaultSphereRadius, "st_distance_sphere")
+	require.True(t, ErrLongitudeOutOfRange.Equal(err))
+	a = NewPointGeometry(0, -91, 0)
+	_, err = GeometryDistanceSphere(&a, &b, DefaultSphereRadius, "st_distance_sphere")
+	require.True(t, ErrLatitudeOutOfRange.Equal(err))
+	line := mustParseGeometry(t, "LINESTRING(0 0,1 1)")
+	_, err = GeometryDistanceSphere(&line, &b, DefaultSphereRadius, "st_distance_sphere")
+	require.True(t, ErrGISUnsupportedArgument.Equal(err))
+}
+
+func TestGeometryIndexCells(t *testing.T) {
+	isOverlapped := func(a, b [][]byte) bool {
+		for _, x := range a {
+			for _, y := range b {
+				if bytes.HasPrefix(x, y) || bytes.HasPrefix(y, x) {
+					return true
+				}
+			}
+		}
+		return false
+	}
+	point := mustParseGeometry(t, "POINT(1 2)")
+	cells := GeometryIndexCells(&point)
+	require.Len(t, cells, 1)
+	require.Len(t, cells[0], MaxGeometryCellLevel)
+	zero, negZero := NewPointGeometry(0, 0, 0), NewPointGeometry(0, 0, 0)
+	negZero.Points[0].X = -negZero.Points[0].X
+	require.Equal(t, GeometryIndexCells(&zero), GeometryIndexCells(&negZero))
+
+	empty := mustParseGeometry(t, "GEOMETRYCOLLECTION EMPTY")
+	require.Empty(t, GeometryIndexCells(&empty))
+
+	geoms := []string{
+		"POINT(1 2)", "POINT(-1 -2)", "POINT(0 0)", "LINESTRING(-5 -5,5 5)", "LINESTRING(1 1,1.5 1.5)",
+		"POLYGON((0 0,10 0,10 10,0 10,0 0))", "POLYGON((100 100,101 100,101 101,100 100))",
+		"MULTIPOINT(1 1,1000 1000)",
+	}
+	for _, a := range geoms {
+		ga := mustParseGeometry(t, a)
+		cellsA := GeometryIndexCells(&ga)
+		require.LessOrEqual(t, len(cellsA), 4)
+		for _, b := range geoms {
+			gb := mustParseGeometry(t, b)
+			// The cells of the intersecting geometries must overlap.
+			if GeometryIntersects(&ga, &gb) {
+				require.True(t, isOverlapped(cellsA, GeometryIndexCells(&gb)), "%s, %s", a, b)
+			}
+		}
+	}
+}
@@ -115,6 +115,29 @@ func TestBinaryJSONExtract(t *testing.T) {
 	}
 }
 
+func TestBinaryJSONExtractAll(t *testing.T) {
+	bj := mustParseBinaryFromString(t, `{"a": [1, [2, 3], {"b": 4}], "c": [5]}`)
+	var tests = []struct {
+		pathExpr string
+		expected []string
+	}{
+		{"$.a[*]", []string{"1", "[2, 3]", `{"b": 4}`}},
+		{"$.a", []string{`[1, [2, 3], {"b": 4}]`}},
+		{"$.c[0]", []string{"5"}},
+		{"$.*[1 to last]", []string{"[2, 3]", `{"b": 4}`}},
+		{"$.d", nil},
+	}
+	for _, test := range tests {
+		pe, err := ParseJSONPathExpr(test.pathExpr)
+		require.NoError(t, err)
+		var result []string
+		for _, v := range bj.ExtractAll(pe) {
+			result = append(result, v.String())
+		}
+		require.Equal(t, test.expected, result, test.pathExpr)
+	}
+}
+
 func TestBinaryJSONType(t *testing.T) {
 	var tests = []struct {
 		in  string
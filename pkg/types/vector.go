@@ -0,0 +1,281 @@
+// Copyright 2024 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package types
+
+import (
+	"encoding/binary"
+	"encoding/json"
+	"math"
+	"strconv"
+	"unsafe"
+
+	"github.com/pingcap/errors"
+	"github.com/pingcap/tidb/pkg/util/hack"
+)
+
+// MaxVectorDimension is the maximum number of dimensions of a VECTOR value.
+const MaxVectorDimension = 16383
+
+// CheckVectorDimValid checks whether the dimension of a VECTOR(dim) type is valid.
+func CheckVectorDimValid(dim int) error {
+	if dim < 1 {
+		return errors.Errorf("dimensions for type vector must be at least 1")
+	}
+	if dim > MaxVectorDimension {
+		return errors.Errorf("vector cannot have more than %d dimensions", MaxVectorDimension)
+	}
+	return nil
+}
+
+// VectorFloat32 represents a vector of float32 elements.
+//
+// The value is stored in a single byte slice so that it can be (de)serialized
+// without copying: a 4 byte little-endian element count followed by the
+// elements, each encoded as a little-endian IEEE 754 float32.
+type VectorFloat32 struct {
+	data []byte
+}
+
+// ZeroVectorFloat32 is a vector with zero dimensions.
+var ZeroVectorFloat32 = InitVectorFloat32(0)
+
+// InitVectorFloat32 initializes a vector with the given number of dimensions. All elements are zero.
+func InitVectorFloat32(dims int) VectorFloat32 {
+	data := make([]byte, 4+dims*4)
+	binary.LittleEndian.PutUint32(data, uint32(dims))
+	return VectorFloat32{data: data}
+}
+
+// CreateVectorFloat32 creates a vector from the given elements.
+func CreateVectorFloat32(elements []float32) VectorFloat32 {
+	v := InitVectorFloat32(len(elements))
+	copy(v.Elements(), elements)
+	return v
+}
+
+// Len returns the number of dimensions of the vector.
+func (v VectorFloat32) Len() int {
+	return int(binary.LittleEndian.Uint32(v.data))
+}
+
+// Elements returns the elements of the vector. The returned slice shares the
+// memory of the vector, so it must not be modified unless the vector is owned
+// by the caller.
+func (v VectorFloat32) Elements() []float32 {
+	l := v.Len()
+	if l == 0 {
+		return nil
+	}
+	return unsafe.Slice((*float32)(unsafe.Pointer(&v.data[4])), l)
+}
+
+// IsZeroValue returns true if the vector does not have any element.
+func (v VectorFloat32) IsZeroValue() bool {
+	return len(v.data) <= 4 || v.Len() == 0
+}
+
+// String returns the text representation of the vector, e.g. [1,2.5,3].
+func (v VectorFloat32) String() string {
+	elements := v.Elements()
+	buf := make([]byte, 0, 2+v.Len()*2)
+	buf = append(buf, '[')
+	for i, el := range elements {
+		if i > 0 {
+			buf = append(buf, ',')
+		}
+		buf = strconv.AppendFloat(buf, float64(el), 'g', -1, 32)
+	}
+	buf = append(buf, ']')
+	return string(buf)
+}
+
+// TruncatedString returns a string representation of the vector which only
+// contains a few leading elements. It is used in places where a full vector
+// would be too verbose, e.g. EXPLAIN results.
+func (v VectorFloat32) TruncatedString() string {
+	const maxDisplayElements = 5
+	elements := v.Elements()
+	if len(elements) <= maxDisplayElements {
+		return v.String()
+	}
+	buf := make([]byte, 0, 64)
+	buf = append(buf, '[')
+	for i := 0; i < maxDisplayElements; i++ {
+		if i > 0 {
+			buf = append(buf, ',')
+		}
+		buf = strconv.AppendFloat(buf, float64(elements[i]), 'g', 2, 32)
+	}
+	buf = append(buf, ",("...)
+	buf = strconv.AppendInt(buf, int64(len(elements)-maxDisplayElements), 10)
+	buf = append(buf, " more)...]"...)
+	return string(buf)
+}
+
+// CheckDimsFitColumn checks whether the vector fits a VECTOR(flen) column.
+func (v VectorFloat32) CheckDimsFitColumn(flen int) error {
+	if flen != UnspecifiedLength && v.Len() != flen {
+		return errors.Errorf("vector has %d dimensions, does not fit VECTOR(%d)", v.Len(), flen)
+	}
+	return nil
+}
+
+// CheckDimsMatch checks whether two vectors have the same number of dimensions.
+func (v VectorFloat32) CheckDimsMatch(b VectorFloat32) error {
+	if v.Len() != b.Len() {
+		return errors.Errorf("vectors have different dimensions: %d and %d", v.Len(), b.Len())
+	}
+	return nil
+}
+
+// Compare returns an integer comparing two vectors element by element.
+// When all shared elements are equal, the shorter vector is the smaller one.
+func (v VectorFloat32) Compare(b VectorFloat32) int {
+	la, lb := v.Len(), b.Len()
+	va, vb := v.Elements(), b.Elements()
+	for i := 0; i < min(la, lb); i++ {
+		if va[i] < vb[i] {
+			return -1
+		} else if va[i] > vb[i] {
+			return 1
+		}
+	}
+	if la < lb {
+		return -1
+	} else if la > lb {
+		return 1
+	}
+	return 0
+}
+
+// Clone returns a deep copy of the vector.
+func (v VectorFloat32) Clone() VectorFloat32 {
+	if v.data == nil {
+		return v
+	}
+	data := make([]byte, len(v.data))
+	copy(data, v.data)
+	return VectorFloat32{data: data}
+}
+
+// SerializedSize returns the size of the serialized vector in bytes.
+func (v VectorFloat32) SerializedSize() int {
+	return len(v.data)
+}
+
+// EstimatedMemUsage returns the estimated memory usage of the vector in bytes.
+func (v VectorFloat32) EstimatedMemUsage() int {
+	return int(unsafe.Sizeof(v)) + len(v.data)
+}
+
+// ZeroCopySerialize returns the serialized vector. The returned slice shares
+// the memory of the vector.
+func (v VectorFloat32) ZeroCopySerialize() []byte {
+	return v.data
+}
+
+// SerializeTo appends the serialized vector to the given buffer.
+func (v VectorFloat32) SerializeTo(buf []byte) []byte {
+	return append(buf, v.data...)
+}
+
+// ZeroCopyDeserializeVectorFloat32 deserializes a vector from the head of the
+// buffer without copying, and returns the remaining buffer.
+func ZeroCopyDeserializeVectorFloat32(buf []byte) (VectorFloat32, []byte, error) {
+	size, err := peekVectorFloat32Size(buf)
+	if err != nil {
+		return ZeroVectorFloat32, buf, err
+	}
+	return VectorFloat32{data: buf[:size]}, buf[size:], nil
+}
+
+// PeekBytesAsVectorFloat32 returns the length of the serialized vector at the head of the buffer.
+func PeekBytesAsVectorFloat32(buf []byte) (n int, err error) {
+	return peekVectorFloat32Size(buf)
+}
+
+func peekVectorFloat32Size(buf []byte) (int, error) {
+	if len(buf) < 4 {
+		return 0, errors.Errorf("bad VectorFloat32 value header (len=%d)", len(buf))
+	}
+	elements := binary.LittleEndian.Uint32(buf)
+	size := 4 + int(elements)*4
+	if len(buf) < size {
+		return 0, errors.Errorf("bad VectorFloat32 value (len=%d, expected=%d)", len(buf), size)
+	}
+	return size, nil
+}
+
+// ParseVectorFloat32 parses a vector from its text representation, e.g. [1,2.5,3].
+func ParseVectorFloat32(s string) (VectorFloat32, error) {
+	var values []float32
+	if err := json.Unmarshal(hack.Slice(s), &values); err != nil {
+		return ZeroVectorFloat32, errors.Errorf("Invalid vector text: %s", s)
+	}
+	if values == nil {
+		return ZeroVectorFloat32, errors.Errorf("Invalid vector text: %s", s)
+	}
+	return createCheckedVectorFloat32(values)
+}
+
+// ConvertJSONToVectorFloat32 converts a JSON array of numbers into a vector.
+func ConvertJSONToVectorFloat32(j BinaryJSON) (VectorFloat32, error) {
+	if j.TypeCode != JSONTypeCodeArray {
+		return ZeroVectorFloat32, errors.Errorf("Invalid vector: expect a JSON array, got %s", j.Type())
+	}
+	n := j.GetElemCount()
+	values := make([]float32, 0, n)
+	for i := 0; i < n; i++ {
+		elem := j.ArrayGetElem(i)
+		var f float64
+		switch elem.TypeCode {
+		case JSONTypeCodeInt64:
+			f = float64(elem.GetInt64())
+		case JSONTypeCodeUint64:
+			f = float64(elem.GetUint64())
+		case JSONTypeCodeFloat64:
+			f = elem.GetFloat64()
+		default:
+			return ZeroVectorFloat32, errors.Errorf("Invalid vector: element %d is not a number", i)
+		}
+		values = append(values, float32(f))
+	}
+	return createCheckedVectorFloat32(values)
+}
+
+func createCheckedVectorFloat32(values []float32) (VectorFloat32, error) {
+	if len(values) > MaxVectorDimension {
+		return ZeroVectorFloat32, errors.Errorf("vector has %d dimensions, exceeds the maximum of %d", len(values), MaxVectorDimension)
+	}
+	for _, v := range values {
+		if math.IsNaN(float64(v)) {
+			return ZeroVectorFloat32, errors.Errorf("NaN not allowed in vector")
+		}
+		if math.IsInf(float64(v), 0) {
+			return ZeroVectorFloat32, errors.Errorf("infinite value not allowed in vector")
+		}
+	}
+	return CreateVectorFloat32(values), nil
+}
+
+// ToJSON converts the vector to a JSON array of numbers.
+func (v VectorFloat32) ToJSON() BinaryJSON {
+	elements := v.Elements()
+	values := make([]any, 0, len(elements))
+	for _, el := range elements {
+		values = append(values, float64(el))
+	}
+	return CreateBinaryJSON(values)
+}
@@ -0,0 +1,114 @@
+// Copyright 2024 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package types
+
+import (
+	"math"
+)
+
+// L2SquaredDistance returns the squared L2 distance between two vectors.
+// This saves a sqrt calculation compared to L2Distance.
+func (v VectorFloat32) L2SquaredDistance(b VectorFloat32) (float64, error) {
+	if err := v.CheckDimsMatch(b); err != nil {
+		return 0, err
+	}
+	va, vb := v.Elements(), b.Elements()
+	var distance float32
+	for i, x := range va {
+		diff := x - vb[i]
+		distance += diff * diff
+	}
+	return float64(distance), nil
+}
+
+// L2Distance returns the L2 (Euclidean) distance between two vectors.
+func (v VectorFloat32) L2Distance(b VectorFloat32) (float64, error) {
+	d, err := v.L2SquaredDistance(b)
+	if err != nil {
+		return 0, err
+	}
+	return math.Sqrt(d), nil
+}
+
+// InnerProduct returns the inner product of two vectors.
+func (v VectorFloat32) InnerProduct(b VectorFloat32) (float64, error) {
+	if err := v.CheckDimsMatch(b); err != nil {
+		return 0, err
+	}
+	va, vb := v.Elements(), b.Elements()
+	var distance float32
+	for i, x := range va {
+		distance += x * vb[i]
+	}
+	return float64(distance), nil
+}
+
+// NegativeInnerProduct returns the negative inner product of two vectors, so
+// that a smaller value means two vectors are closer.
+func (v VectorFloat32) NegativeInnerProduct(b VectorFloat32) (float64, error) {
+	d, err := v.InnerProduct(b)
+	if err != nil {
+		return 0, err
+	}
+	return -d, nil
+}
+
+// CosineDistance returns the cosine distance between two vectors.
+// The result is NaN when any of the vectors has a zero norm.
+func (v VectorFloat32) CosineDistance(b VectorFloat32) (float64, error) {
+	if err := v.CheckDimsMatch(b); err != nil {
+		return 0, err
+	}
+	va, vb := v.Elements(), b.Elements()
+	var distance, normA, normB float32
+	for i, x := range va {
+		distance += x * vb[i]
+		normA += x * x
+		normB += vb[i] * vb[i]
+	}
+	similarity := float64(distance) / math.Sqrt(float64(normA)*float64(normB))
+	if math.IsNaN(similarity) {
+		return math.NaN(), nil
+	}
+	// Clamp the similarity to [-1, 1] to absorb floating point errors.
+	similarity = max(min(similarity, 1.0), -1.0)
+	return 1.0 - similarity, nil
+}
+
+// L1Distance returns the L1 (Manhattan) distance between two vectors.
+func (v VectorFloat32) L1Distance(b VectorFloat32) (float64, error) {
+	if err := v.CheckDimsMatch(b); err != nil {
+		return 0, err
+	}
+	va, vb := v.Elements(), b.Elements()
+	var distance float32
+	for i, x := range va {
+		diff := x - vb[i]
+		if diff < 0 {
+			diff = -diff
+		}
+		distance += diff
+	}
+	return float64(distance), nil
+}
+
+// L2Norm returns the L2 norm of the vector.
+func (v VectorFloat32) L2Norm() float64 {
+	var norm float32
+	for _, x := range v.Elements() {
+		norm += x * x
+	}
+	return math.Sqrt(float64(norm))
+}
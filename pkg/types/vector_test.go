@@ -0,0 +1,138 @@
+// Copyright 2024 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package types
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestVectorParse(t *testing.T) {
+	v, err := ParseVectorFloat32("[1, 2.5, -3]")
+	require.NoError(t, err)
+	require.Equal(t, 3, v.Len())
+	require.Equal(t, []float32{1, 2.5, -3}, v.Elements())
+	require.Equal(t, "[1,2.5,-3]", v.String())
+
+	v, err = ParseVectorFloat32("[]")
+	require.NoError(t, err)
+	require.True(t, v.IsZeroValue())
+	require.Equal(t, "[]", v.String())
+
+	for _, s := range []string{"", "abc", "[1,2", "null", `["a"]`, "{}"} {
+		_, err = ParseVectorFloat32(s)
+		require.ErrorContains(t, err, "Invalid vector text", s)
+	}
+	_, err = ParseVectorFloat32("[1e100]")
+	require.Error(t, err)
+}
+
+func TestVectorSerialize(t *testing.T) {
+	v := CreateVectorFloat32([]float32{1, 2, 3})
+	buf := v.SerializeTo(nil)
+	require.Equal(t, v.SerializedSize(), len(buf))
+	buf = append(buf, 0xff)
+
+	n, err := PeekBytesAsVectorFloat32(buf)
+	require.NoError(t, err)
+	require.Equal(t, 16, n)
+
+	v2, remain, err := ZeroCopyDeserializeVectorFloat32(buf)
+	require.NoError(t, err)
+	require.Equal(t, []byte{0xff}, remain)
+	require.Equal(t, 0, v.Compare(v2))
+
+	_, _, err = ZeroCopyDeserializeVectorFloat32(buf[:3])
+	require.ErrorContains(t, err, "bad VectorFloat32 value header")
+	_, _, err = ZeroCopyDeserializeVectorFloat32(buf[:10])
+	require.ErrorContains(t, err, "bad VectorFloat32 value")
+}
+
+func TestVectorCompare(t *testing.T) {
+	cases := []struct {
+		a, b   []float32
+		result int
+	}{
+		{[]float32{1, 2}, []float32{1, 2}, 0},
+		{[]float32{1, 2}, []float32{1, 3}, -1},
+		{[]float32{2}, []float32{1, 3}, 1},
+		{[]float32{1}, []float32{1, 0}, -1},
+		{nil, nil, 0},
+	}
+	for _, c := range cases {
+		a, b := CreateVectorFloat32(c.a), CreateVectorFloat32(c.b)
+		require.Equal(t, c.result, a.Compare(b))
+		require.Equal(t, -c.result, b.Compare(a))
+	}
+}
+
+func TestVectorDistance(t *testing.T) {
+	a := CreateVectorFloat32([]float32{1, 2, 3})
+	b := CreateVectorFloat32([]float32{4, 6, 3})
+
+	d, err := a.L2Distance(b)
+	require.NoError(t, err)
+	require.Equal(t, 5.0, d)
+	d, err = a.L1Distance(b)
+	require.NoError(t, err)
+	require.Equal(t, 7.0, d)
+	d, err = a.NegativeInnerProduct(b)
+	require.NoError(t, err)
+	require.Equal(t, -25.0, d)
+	d, err = a.CosineDistance(a)
+	require.NoError(t, err)
+	require.InDelta(t, 0.0, d, 1e-6)
+	require.Equal(t, math.Sqrt(14), a.L2Norm())
+
+	d, err = a.CosineDistance(CreateVectorFloat32([]float32{0, 0, 0}))
+	require.NoError(t, err)
+	require.True(t, math.IsNaN(d))
+
+	_, err = a.L2Distance(CreateVectorFloat32([]float32{1}))
+	require.ErrorContains(t, err, "vectors have different dimensions")
+}
+
+func TestVectorJSON(t *testing.T) {
+	j, err := ParseBinaryJSONFromString("[1, 2.5, 3]")
+	require.NoError(t, err)
+	v, err := ConvertJSONToVectorFloat32(j)
+	require.NoError(t, err)
+	require.Equal(t, "[1,2.5,3]", v.String())
+	require.Equal(t, "[1, 2.5, 3]", v.ToJSON().String())
+
+	j, err = ParseBinaryJSONFromString(`{"a": 1}`)
+	require.NoError(t, err)
+	_, err = ConvertJSONToVectorFloat32(j)
+	require.ErrorContains(t, err, "expect a JSON array")
+
+	j, err = ParseBinaryJSONFromString(`[1, "a"]`)
+	require.NoError(t, err)
+	_, err = ConvertJSONToVectorFloat32(j)
+	require.ErrorContains(t, err, "element 1 is not a number")
+}
+
+func TestVectorDimValid(t *testing.T) {
+	require.NoError(t, CheckVectorDimValid(1))
+	require.NoError(t, CheckVectorDimValid(MaxVectorDimension))
+	require.Error(t, CheckVectorDimValid(0))
+	require.Error(t, CheckVectorDimValid(MaxVectorDimension+1))
+
+	v := CreateVectorFloat32([]float32{1, 2})
+	require.NoError(t, v.CheckDimsFitColumn(UnspecifiedLength))
+	require.NoError(t, v.CheckDimsFitColumn(2))
+	require.ErrorContains(t, v.CheckDimsFitColumn(3), "does not fit VECTOR(3)")
+}
@@ -17,6 +17,7 @@ package admin
 import (
 	"context"
 	"math"
+	"slices"
 	"strings"
 
 	"github.com/pingcap/errors"
@@ -68,7 +69,8 @@ const (
 // It returns the count greater type, the index offset and an error.
 // It returns nil if the count from the index is equal to the count from the table columns,
 // otherwise it returns an error and the corresponding index's offset.
-func CheckIndicesCount(ctx sessionctx.Context, dbName, tableName string, indices []string) (byte, int, error) {
+// Only the rows in the partitions are counted if partitions is not empty.
+func CheckIndicesCount(ctx sessionctx.Context, dbName, tableName string, partitions []string, indices []string) (byte, int, error) {
 	// Here we need check all indexes, includes invisible index
 	originOptUseInvisibleIdx := ctx.GetSessionVars().OptimizerUseInvisibleIndexes
 	ctx.GetSessionVars().OptimizerUseInvisibleIndexes = true
@@ -90,12 +92,20 @@ func CheckIndicesCount(ctx sessionctx.Context, dbName, tableName string, indices
 
 	// Add `` for some names like `table name`.
 	exec := ctx.GetRestrictedSQLExecutor()
-	tblCnt, err := getCount(exec, snapshot, "SELECT COUNT(*) FROM %n.%n USE INDEX()", dbName, tableName)
+	from := "SELECT COUNT(*) FROM %n.%n"
+	args := []any{dbName, tableName}
+	if len(partitions) > 0 {
+		from += " PARTITION(" + strings.Repeat("%n,", len(partitions)-1) + "%n)"
+		for _, partition := range partitions {
+			args = append(args, partition)
+		}
+	}
+	tblCnt, err := getCount(exec, snapshot, from+" USE INDEX()", args...)
 	if err != nil {
 		return 0, 0, errors.Trace(err)
 	}
 	for i, idx := range indices {
-		idxCnt, err := getCount(exec, snapshot, "SELECT COUNT(*) FROM %n.%n USE INDEX(%n)", dbName, tableName, idx)
+		idxCnt, err := getCount(exec, snapshot, from+" USE INDEX(%n)", append(slices.Clip(args), idx)...)
 		if err != nil {
 			return 0, i, errors.Trace(err)
 		}
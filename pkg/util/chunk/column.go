@@ -53,6 +53,12 @@ func (c *Column) AppendJSON(j types.BinaryJSON) {
 	c.finishAppendVar()
 }
 
+// AppendVectorFloat32 appends a VectorFloat32 value into this Column.
+func (c *Column) AppendVectorFloat32(v types.VectorFloat32) {
+	c.data = v.SerializeTo(c.data)
+	c.finishAppendVar()
+}
+
 // AppendSet appends a Set value into this Column.
 func (c *Column) AppendSet(set types.Set) {
 	c.appendNameValue(set.Name, set.Value)
@@ -161,6 +167,8 @@ func (c *Column) Reset(eType types.EvalType) {
 		c.ResizeGoDuration(0, false)
 	case types.ETJson:
 		c.ReserveJSON(0)
+	case types.ETVectorFloat32:
+		c.ReserveVectorFloat32(0)
 	default:
 		panic(fmt.Sprintf("invalid EvalType %v", eType))
 	}
@@ -546,6 +554,11 @@ func (c *Column) ReserveJSON(n int) {
 	c.reserve(n, 8)
 }
 
+// ReserveVectorFloat32 changes the column capacity to store n vectorFloat32 elements and set the length to zero.
+func (c *Column) ReserveVectorFloat32(n int) {
+	c.reserve(n, 8)
+}
+
 // ReserveSet changes the column capacity to store n set elements and set the length to zero.
 func (c *Column) ReserveSet(n int) {
 	c.reserve(n, 8)
@@ -648,6 +661,16 @@ func (c *Column) GetJSON(rowID int) types.BinaryJSON {
 	return types.BinaryJSON{TypeCode: c.data[start], Value: c.data[start+1 : c.offsets[rowID+1]]}
 }
 
+// GetVectorFloat32 returns the VectorFloat32 in the specific row.
+func (c *Column) GetVectorFloat32(rowID int) types.VectorFloat32 {
+	data := c.data[c.offsets[rowID]:c.offsets[rowID+1]]
+	v, _, err := types.ZeroCopyDeserializeVectorFloat32(data)
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
+
 // GetBytes returns the byte slice in the specific row.
 func (c *Column) GetBytes(rowID int) []byte {
 	return c.data[c.offsets[rowID]:c.offsets[rowID+1]]
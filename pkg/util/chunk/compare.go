@@ -39,7 +39,7 @@ func GetCompareFunc(tp *types.FieldType) CompareFunc {
 	case mysql.TypeDouble:
 		return cmpFloat64
 	case mysql.TypeString, mysql.TypeVarString, mysql.TypeVarchar,
-		mysql.TypeBlob, mysql.TypeTinyBlob, mysql.TypeMediumBlob, mysql.TypeLongBlob:
+		mysql.TypeBlob, mysql.TypeTinyBlob, mysql.TypeMediumBlob, mysql.TypeLongBlob, mysql.TypeGeometry:
 		return genCmpStringFunc(tp.GetCollate())
 	case mysql.TypeDate, mysql.TypeDatetime, mysql.TypeTimestamp:
 		return cmpTime
@@ -53,6 +53,8 @@ func GetCompareFunc(tp *types.FieldType) CompareFunc {
 		return cmpBit
 	case mysql.TypeJSON:
 		return cmpJSON
+	case mysql.TypeTiDBVectorFloat32:
+		return cmpVectorFloat32
 	case mysql.TypeNull:
 		return cmpNullConst
 	}
@@ -171,6 +173,14 @@ func cmpJSON(l Row, lCol int, r Row, rCol int) int {
 	return types.CompareBinaryJSON(lJ, rJ)
 }
 
+func cmpVectorFloat32(l Row, lCol int, r Row, rCol int) int {
+	lNull, rNull := l.IsNull(lCol), r.IsNull(rCol)
+	if lNull || rNull {
+		return cmpNull(lNull, rNull)
+	}
+	return l.GetVectorFloat32(lCol).Compare(r.GetVectorFloat32(rCol))
+}
+
 func cmpNullConst(_ Row, _ int, _ Row, _ int) int {
 	return 0
 }
@@ -218,6 +228,9 @@ func Compare(row Row, colIdx int, ad *types.Datum) int {
 	case types.KindMysqlJSON:
 		l, r := row.GetJSON(colIdx), ad.GetMysqlJSON()
 		return types.CompareBinaryJSON(l, r)
+	case types.KindVectorFloat32:
+		l, r := row.GetVectorFloat32(colIdx), ad.GetVectorFloat32()
+		return l.Compare(r)
 	case types.KindMysqlTime:
 		l, r := row.GetTime(colIdx), ad.GetMysqlTime()
 		return l.Compare(r)
@@ -36,18 +36,19 @@ import (
 
 // First byte in the encoded value which specifies the encoding type.
 const (
-	NilFlag          byte = 0
-	bytesFlag        byte = 1
-	compactBytesFlag byte = 2
-	intFlag          byte = 3
-	uintFlag         byte = 4
-	floatFlag        byte = 5
-	decimalFlag      byte = 6
-	durationFlag     byte = 7
-	varintFlag       byte = 8
-	uvarintFlag      byte = 9
-	jsonFlag         byte = 10
-	maxFlag          byte = 250
+	NilFlag           byte = 0
+	bytesFlag         byte = 1
+	compactBytesFlag  byte = 2
+	intFlag           byte = 3
+	uintFlag          byte = 4
+	floatFlag         byte = 5
+	decimalFlag       byte = 6
+	durationFlag      byte = 7
+	varintFlag        byte = 8
+	uvarintFlag       byte = 9
+	jsonFlag          byte = 10
+	vectorFloat32Flag byte = 20
+	maxFlag           byte = 250
 )
 
 // IntHandleFlag is only used to encode int handle key.
@@ -72,6 +73,8 @@ func preRealloc(b []byte, vals []types.Datum, comparable1 bool) []byte {
 			size++
 		case types.KindMysqlJSON:
 			size += 2 + len(vals[i].GetBytes())
+		case types.KindVectorFloat32:
+			size += 1 + vals[i].GetVectorFloat32().SerializedSize()
 		case types.KindMysqlDecimal:
 			size += 1 + types.MyDecimalStructSize
 		default:
@@ -126,6 +129,9 @@ func encode(loc *time.Location, b []byte, vals []types.Datum, comparable1 bool)
 			j := vals[i].GetMysqlJSON()
 			b = append(b, j.TypeCode)
 			b = append(b, j.Value...)
+		case types.KindVectorFloat32:
+			b = append(b, vectorFloat32Flag)
+			b = vals[i].GetVectorFloat32().SerializeTo(b)
 		case types.KindNull:
 			b = append(b, NilFlag)
 		case types.KindMinNotNull:
@@ -169,6 +175,8 @@ func EstimateValueSize(typeCtx types.Context, val types.Datum) (int, error) {
 		l = valueSizeOfUnsignedInt(val)
 	case types.KindMysqlJSON:
 		l = 2 + len(val.GetMysqlJSON().Value)
+	case types.KindVectorFloat32:
+		l = 1 + val.GetVectorFloat32().SerializedSize()
 	case types.KindNull, types.KindMinNotNull, types.KindMaxValue:
 		l = 1
 	default:
@@ -327,7 +335,7 @@ func encodeHashChunkRowIdx(typeCtx types.Context, row chunk.Row, tp *types.Field
 			f = 0
 		}
 		b = unsafe.Slice((*byte)(unsafe.Pointer(&f)), unsafe.Sizeof(f))
-	case mysql.TypeVarchar, mysql.TypeVarString, mysql.TypeString, mysql.TypeBlob, mysql.TypeTinyBlob, mysql.TypeMediumBlob, mysql.TypeLongBlob:
+	case mysql.TypeVarchar, mysql.TypeVarString, mysql.TypeString, mysql.TypeBlob, mysql.TypeTinyBlob, mysql.TypeMediumBlob, mysql.TypeLongBlob, mysql.TypeGeometry:
 		flag = compactBytesFlag
 		b = row.GetBytes(idx)
 		b = ConvertByCollation(b, tp)
@@ -385,6 +393,10 @@ func encodeHashChunkRowIdx(typeCtx types.Context, row chunk.Row, tp *types.Field
 		flag = jsonFlag
 		json := row.GetJSON(idx)
 		b = json.HashValue(b)
+	case mysql.TypeTiDBVectorFloat32:
+		flag = vectorFloat32Flag
+		v := row.GetVectorFloat32(idx)
+		b = v.SerializeTo(b)
 	default:
 		return 0, nil, errors.Errorf("unsupport column type for encode %d", tp.GetType())
 	}
@@ -459,7 +471,7 @@ func SerializeKeys(typeCtx types.Context, chk *chunk.Chunk, tp *types.FieldType,
 			}
 			serializedKeysVector[logicalRowIndex] = append(serializedKeysVector[logicalRowIndex], unsafe.Slice((*byte)(unsafe.Pointer(&f)), sizeFloat64)...)
 		}
-	case mysql.TypeVarchar, mysql.TypeVarString, mysql.TypeString, mysql.TypeBlob, mysql.TypeTinyBlob, mysql.TypeMediumBlob, mysql.TypeLongBlob:
+	case mysql.TypeVarchar, mysql.TypeVarString, mysql.TypeString, mysql.TypeBlob, mysql.TypeTinyBlob, mysql.TypeMediumBlob, mysql.TypeLongBlob, mysql.TypeGeometry:
 		for logicalRowIndex, physicalRowIndex := range usedRows {
 			if canSkip(physicalRowIndex) {
 				continue
@@ -557,6 +569,13 @@ func SerializeKeys(typeCtx types.Context, chk *chunk.Chunk, tp *types.FieldType,
 			}
 			serializedKeysVector[logicalRowIndex] = column.GetJSON(physicalRowindex).HashValue(serializedKeysVector[logicalRowIndex])
 		}
+	case mysql.TypeTiDBVectorFloat32:
+		for logicalRowIndex, physicalRowindex := range usedRows {
+			if canSkip(physicalRowindex) {
+				continue
+			}
+			serializedKeysVector[logicalRowIndex] = column.GetVectorFloat32(physicalRowindex).SerializeTo(serializedKeysVector[logicalRowIndex])
+		}
 	case mysql.TypeNull:
 		for _, physicalRowindex := range usedRows {
 			if canSkip(physicalRowindex) {
@@ -654,7 +673,7 @@ func HashChunkSelected(typeCtx types.Context, h []hash.Hash64, chk *chunk.Chunk,
 			_, _ = h[i].Write(buf)
 			_, _ = h[i].Write(b)
 		}
-	case mysql.TypeVarchar, mysql.TypeVarString, mysql.TypeString, mysql.TypeBlob, mysql.TypeTinyBlob, mysql.TypeMediumBlob, mysql.TypeLongBlob:
+	case mysql.TypeVarchar, mysql.TypeVarString, mysql.TypeString, mysql.TypeBlob, mysql.TypeTinyBlob, mysql.TypeMediumBlob, mysql.TypeLongBlob, mysql.TypeGeometry:
 		for i := 0; i < rows; i++ {
 			if sel != nil && !sel[i] {
 				continue
@@ -826,6 +845,25 @@ func HashChunkSelected(typeCtx types.Context, h []hash.Hash64, chk *chunk.Chunk,
 				b = json.HashValue(b)
 			}
 
+			// As the golang doc described, `Hash.Write` never returns an error..
+			// See https://golang.org/pkg/hash/#Hash
+			_, _ = h[i].Write(buf)
+			_, _ = h[i].Write(b)
+		}
+	case mysql.TypeTiDBVectorFloat32:
+		for i := 0; i < rows; i++ {
+			if sel != nil && !sel[i] {
+				continue
+			}
+			if column.IsNull(i) {
+				buf[0], b = NilFlag, nil
+				isNull[i] = !ignoreNull
+			} else {
+				buf[0] = vectorFloat32Flag
+				v := column.GetVectorFloat32(i)
+				b = v.ZeroCopySerialize()
+			}
+
 			// As the golang doc described, `Hash.Write` never returns an error..
 			// See https://golang.org/pkg/hash/#Hash
 			_, _ = h[i].Write(buf)
@@ -1037,6 +1075,13 @@ func DecodeOne(b []byte) (remain []byte, d types.Datum, err error) {
 		j := types.BinaryJSON{TypeCode: b[0], Value: b[1:size]}
 		d.SetMysqlJSON(j)
 		b = b[size:]
+	case vectorFloat32Flag:
+		v, remaining, err := types.ZeroCopyDeserializeVectorFloat32(b)
+		if err != nil {
+			return b, d, errors.Trace(err)
+		}
+		d.SetVectorFloat32(v)
+		b = remaining
 	case NilFlag:
 	default:
 		return b, d, errors.Errorf("invalid encoded key flag %v", flag)
@@ -1166,6 +1211,8 @@ func peek(b []byte) (length int, err error) {
 		l, err = peekUvarint(b)
 	case jsonFlag:
 		l, err = types.PeekBytesAsJSON(b)
+	case vectorFloat32Flag:
+		l, err = types.PeekBytesAsVectorFloat32(b)
 	default:
 		return 0, errors.Errorf("invalid encoded key flag %v", flag)
 	}
@@ -1338,6 +1385,13 @@ func (decoder *Decoder) DecodeOne(b []byte, colIdx int, ft *types.FieldType) (re
 		}
 		chk.AppendJSON(colIdx, types.BinaryJSON{TypeCode: b[0], Value: b[1:size]})
 		b = b[size:]
+	case vectorFloat32Flag:
+		v, remaining, err := types.ZeroCopyDeserializeVectorFloat32(b)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		chk.AppendVectorFloat32(colIdx, v)
+		b = remaining
 	case NilFlag:
 		chk.AppendNull(colIdx)
 	default:
@@ -1474,6 +1528,15 @@ func HashGroupKey(loc *time.Location, n int, col *chunk.Column, buf [][]byte, ft
 				buf[i] = col.GetJSON(i).HashValue(buf[i])
 			}
 		}
+	case types.ETVectorFloat32:
+		for i := 0; i < n; i++ {
+			if col.IsNull(i) {
+				buf[i] = append(buf[i], NilFlag)
+			} else {
+				buf[i] = append(buf[i], vectorFloat32Flag)
+				buf[i] = col.GetVectorFloat32(i).SerializeTo(buf[i])
+			}
+		}
 	case types.ETString:
 		for i := 0; i < n; i++ {
 			if col.IsNull(i) {
@@ -1539,6 +1602,9 @@ func HashCode(b []byte, d types.Datum) []byte {
 		j := d.GetMysqlJSON()
 		b = append(b, j.TypeCode)
 		b = append(b, j.Value...)
+	case types.KindVectorFloat32:
+		b = append(b, vectorFloat32Flag)
+		b = d.GetVectorFloat32().SerializeTo(b)
 	case types.KindNull:
 		b = append(b, NilFlag)
 	case types.KindMinNotNull:
@@ -74,6 +74,7 @@ func TestError(t *testing.T) {
 		ErrDBaccessDenied,
 		ErrTableaccessDenied,
 		ErrSpecificAccessDenied,
+		ErrProcaccessDenied,
 		ErrViewNoExplain,
 		ErrWrongValueCountOnRow,
 		ErrViewInvalid,
@@ -83,6 +84,8 @@ func TestError(t *testing.T) {
 		ErrStmtNotFound,
 		ErrAmbiguous,
 		ErrKeyPart0,
+		ErrFtMatchingKeyNotFound,
+		ErrTFMustHaveAlias,
 	}
 	for _, err := range kvErrs {
 		code := terror.ToSQLError(err).Code
@@ -918,6 +918,8 @@ const (
 	TpFKCascadeRuntimeStats
 	// TpRURuntimeStats is the tp for RURuntimeStats
 	TpRURuntimeStats
+	// TpAdaptiveJoinRuntimeStats is the tp for AdaptiveJoinRuntimeStats
+	TpAdaptiveJoinRuntimeStats
 )
 
 // RuntimeStats is used to express the executor runtime information.
@@ -1171,6 +1173,11 @@ func (e *BasicRuntimeStats) GetActRows() int64 {
 	return e.rows.Load()
 }
 
+// GetLoop returns the number of times the executor is called.
+func (e *BasicRuntimeStats) GetLoop() int32 {
+	return e.loop.Load()
+}
+
 // Clone implements the RuntimeStats interface.
 func (e *BasicRuntimeStats) Clone() RuntimeStats {
 	result := &BasicRuntimeStats{
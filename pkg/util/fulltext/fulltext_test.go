@@ -0,0 +1,120 @@
+// Copyright 2024 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package fulltext
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestTokenizer(t *testing.T) {
+	require.True(t, IsSupportedParser(""))
+	require.True(t, IsSupportedParser("NGRAM"))
+	require.False(t, IsSupportedParser("mecab"))
+
+	standard := NewTokenizer("")
+	require.Equal(t, []string{"quick", "brown", "fox", "tidb_server", "quick"},
+		standard.Tokenize("The quick, brown fox in TiDB_Server: QUICK! go"))
+	require.Equal(t, []string{"数据库"}, standard.Tokenize("数据库"))
+	require.Empty(t, standard.Tokenize(""))
+
+	ngram := NewTokenizer("ngram")
+	require.Equal(t, []string{"数据", "据库", "ti", "id", "db"}, ngram.Tokenize("数据库, TiDB a"))
+	require.Equal(t, []string{"brown", "fox", "quick"}, Tokens(standard, "quick brown", "fox quick"))
+}
+
+func TestNaturalLanguageQuery(t *testing.T) {
+	tk := NewTokenizer("")
+	q := ParseQuery("database systems", false, tk)
+	require.False(t, q.IsBoolean())
+	terms, intersection := q.IndexTerms()
+	require.False(t, intersection)
+	require.Equal(t, []IndexTerm{{Token: "database"}, {Token: "systems"}}, terms)
+
+	require.Equal(t, 0.0, q.Relevance(NewDocument(tk, "nothing to see")))
+	require.Equal(t, 1.0, q.Relevance(NewDocument(tk, "a database")))
+	require.Equal(t, 2.0, q.Relevance(NewDocument(tk, "database", "systems")))
+	require.InDelta(t, 2+math.Log(2), q.Relevance(NewDocument(tk, "database systems database")), 1e-9)
+
+	terms, _ = ParseQuery("the a", false, tk).IndexTerms()
+	require.Empty(t, terms)
+}
+
+func TestBooleanQuery(t *testing.T) {
+	tk := NewTokenizer("")
+	docs := []*Document{
+		NewDocument(tk, "MySQL is a database"),
+		NewDocument(tk, "TiDB is a distributed database"),
+		NewDocument(tk, "distributed systems"),
+		NewDocument(tk, "database systems", "distributed"),
+	}
+	matches := func(query string) []int {
+		q := ParseQuery(query, true, tk)
+		var res []int
+		for i, doc := range docs {
+			if q.Relevance(doc) > 0 {
+				res = append(res, i)
+			}
+		}
+		return res
+	}
+	require.Equal(t, []int{0, 1, 3}, matches("database"))
+	require.Equal(t, []int{1, 3}, matches("+database +distributed"))
+	require.Equal(t, []int{0}, matches("+database -distributed"))
+	require.Equal(t, []int{0, 2}, matches("mysql systems -tidb -(+database +systems)"))
+	require.Equal(t, []int{1, 2, 3}, matches("distrib*"))
+	require.Equal(t, []int{1}, matches(`"distributed database"`))
+	require.Equal(t, []int{1, 3}, matches(`+database +(tidb <systems)`))
+	require.Nil(t, matches("-database"))
+	require.Nil(t, matches(`"the"`))
+
+	q := ParseQuery("+database ~mysql", true, tk)
+	require.Greater(t, q.Relevance(docs[1]), q.Relevance(docs[0]))
+	q = ParseQuery("~mysql", true, tk)
+	require.Equal(t, minRelevance, q.Relevance(docs[0]))
+
+	terms, intersection := ParseQuery(`+database +"distributed systems" tidb`, true, tk).IndexTerms()
+	require.True(t, intersection)
+	require.Equal(t, []IndexTerm{{Token: "database"}, {Token: "distributed"}, {Token: "systems"}}, terms)
+	terms, intersection = ParseQuery(`mysql (+dist* systems) -tidb`, true, tk).IndexTerms()
+	require.False(t, intersection)
+	require.Equal(t, []IndexTerm{{Token: "mysql"}, {Token: "dist", Prefix: true}}, terms)
+	terms, intersection = ParseQuery(`+dist* +database`, true, tk).IndexTerms()
+	require.True(t, intersection)
+	require.Equal(t, []IndexTerm{{Token: "database"}}, terms)
+	terms, intersection = ParseQuery(`+dist*`, true, tk).IndexTerms()
+	require.False(t, intersection)
+	require.Equal(t, []IndexTerm{{Token: "dist", Prefix: true}}, terms)
+	terms, _ = ParseQuery(`-tidb`, true, tk).IndexTerms()
+	require.Empty(t, terms)
+}
+
+func TestNgramQuery(t *testing.T) {
+	tk := NewTokenizer("ngram")
+	doc := NewDocument(tk, "分布式数据库")
+	require.Greater(t, ParseQuery("数据库", false, tk).Relevance(doc), 0.0)
+	require.Greater(t, ParseQuery("+数据库", true, tk).Relevance(doc), 0.0)
+	require.Greater(t, ParseQuery("数*", true, tk).Relevance(doc), 0.0)
+	require.Equal(t, 0.0, ParseQuery("+数库", true, tk).Relevance(doc))
+	// A long prefix is searched as a phrase.
+	require.Greater(t, ParseQuery("分布式*", true, tk).Relevance(doc), 0.0)
+	require.Equal(t, 0.0, ParseQuery("布分式*", true, tk).Relevance(doc))
+
+	terms, intersection := ParseQuery("+数据库", true, tk).IndexTerms()
+	require.True(t, intersection)
+	require.Equal(t, []IndexTerm{{Token: "数据"}, {Token: "据库"}}, terms)
+}
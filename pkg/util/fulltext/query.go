@@ -0,0 +1,352 @@
+// Copyright 2024 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package fulltext
+
+import (
+	"math"
+	"strings"
+	"unicode/utf8"
+)
+
+const (
+	opNone     = 0
+	opMust     = '+'
+	opMustNot  = '-'
+	opNegate   = '~'
+	opIncrease = '>'
+	opDecrease = '<'
+
+	// minRelevance is the relevance of a row which matches a boolean query but whose
+	// clauses contribute a non-positive score, e.g. it only matches the `~` clauses.
+	minRelevance = 1e-6
+)
+
+// clause is a term, a phrase or a group of the query.
+type clause struct {
+	op byte
+	// tokens is the token of a term, or the tokens of a phrase.
+	tokens []string
+	// prefix indicates the term matches all the tokens starting with it.
+	prefix bool
+	group  []*clause
+}
+
+// Query is a parsed fulltext search query.
+//
+// The relevance of a row is the sum of the weighted scores of the clauses it matches,
+// where the score of a term or phrase is 1 + ln(the number of occurrences). The inverse
+// document frequency is not taken into account, so the relevance of a row doesn't depend
+// on the other rows of the table.
+type Query struct {
+	clauses []*clause
+	boolean bool
+}
+
+// ParseQuery parses the search string of MATCH ... AGAINST. In the natural language mode,
+// every distinct token of the search string is an optional term. In the boolean mode, the
+// search string supports the operators `+ - ~ > < ( ) *` and the double-quoted phrases.
+func ParseQuery(text string, boolean bool, tk Tokenizer) *Query {
+	q := &Query{boolean: boolean}
+	if !boolean {
+		for _, token := range Tokens(tk, text) {
+			q.clauses = append(q.clauses, &clause{tokens: []string{token}})
+		}
+		return q
+	}
+	p := &queryParser{text: text, tk: tk}
+	q.clauses = p.parseGroup()
+	return q
+}
+
+// IsBoolean returns whether the query is in the boolean mode.
+func (q *Query) IsBoolean() bool {
+	return q.boolean
+}
+
+type queryParser struct {
+	text string
+	pos  int
+	tk   Tokenizer
+}
+
+func isOperator(c byte) bool {
+	switch c {
+	case opMust, opMustNot, opNegate, opIncrease, opDecrease:
+		return true
+	}
+	return false
+}
+
+func isSpace(c byte) bool {
+	return c == ' ' || c == '\t' || c == '\n' || c == '\r'
+}
+
+// parseGroup parses the clauses until the end of the text or the closing parenthesis.
+func (p *queryParser) parseGroup() []*clause {
+	var clauses []*clause
+	for {
+		for p.pos < len(p.text) && isSpace(p.text[p.pos]) {
+			p.pos++
+		}
+		if p.pos >= len(p.text) {
+			return clauses
+		}
+		if p.text[p.pos] == ')' {
+			p.pos++
+			return clauses
+		}
+		c := &clause{}
+		// Only the last one takes effect if there are several operators.
+		for p.pos < len(p.text) && isOperator(p.text[p.pos]) {
+			c.op = p.text[p.pos]
+			p.pos++
+		}
+		if p.pos >= len(p.text) {
+			return clauses
+		}
+		switch p.text[p.pos] {
+		case '(':
+			p.pos++
+			c.group = p.parseGroup()
+			if len(c.group) == 0 {
+				continue
+			}
+		case '"':
+			p.pos++
+			end := strings.IndexByte(p.text[p.pos:], '"')
+			if end < 0 {
+				end = len(p.text) - p.pos
+			}
+			c.tokens = p.tk.Tokenize(p.text[p.pos : p.pos+end])
+			p.pos = min(p.pos+end+1, len(p.text))
+			if len(c.tokens) == 0 {
+				continue
+			}
+		default:
+			start := p.pos
+			for p.pos < len(p.text) {
+				ch := p.text[p.pos]
+				if isSpace(ch) || ch == '(' || ch == ')' || ch == '"' {
+					break
+				}
+				p.pos++
+			}
+			if !p.parseTerm(c, p.text[start:p.pos]) {
+				continue
+			}
+		}
+		clauses = append(clauses, c)
+	}
+}
+
+// parseTerm fills the tokens of the term clause. It returns false if the term is ignored,
+// e.g. it's a stopword or too short.
+func (p *queryParser) parseTerm(c *clause, word string) bool {
+	word, c.prefix = strings.CutSuffix(word, "*")
+	if !c.prefix {
+		c.tokens = p.tk.Tokenize(word)
+		return len(c.tokens) > 0
+	}
+	fields := strings.FieldsFunc(word, isDelimiter)
+	if len(fields) != 1 {
+		// The prefix search only applies to a single word.
+		c.prefix = false
+		c.tokens = p.tk.Tokenize(word)
+		return len(c.tokens) > 0
+	}
+	prefix := strings.ToLower(fields[0])
+	// The prefix longer than the ngram token size is searched as a phrase.
+	if ngram, ok := p.tk.(ngramTokenizer); ok && utf8.RuneCountInString(prefix) > ngram.n {
+		c.prefix = false
+		c.tokens = ngram.Tokenize(prefix)
+		return true
+	}
+	c.tokens = []string{prefix}
+	return true
+}
+
+// Document is the tokenized text of a row to compute the relevance against a query.
+type Document struct {
+	columns [][]string
+	tf      map[string]int
+}
+
+// NewDocument tokenizes the texts of the columns in the fulltext index. A phrase can't
+// span more than one column.
+func NewDocument(tk Tokenizer, texts ...string) *Document {
+	doc := &Document{columns: make([][]string, 0, len(texts)), tf: make(map[string]int)}
+	for _, text := range texts {
+		tokens := tk.Tokenize(text)
+		for _, token := range tokens {
+			doc.tf[token]++
+		}
+		doc.columns = append(doc.columns, tokens)
+	}
+	return doc
+}
+
+// termFreq returns the number of the occurrences of the term or phrase in the document.
+func (doc *Document) termFreq(c *clause) int {
+	if len(c.tokens) == 1 {
+		if !c.prefix {
+			return doc.tf[c.tokens[0]]
+		}
+		cnt := 0
+		for token, n := range doc.tf {
+			if strings.HasPrefix(token, c.tokens[0]) {
+				cnt += n
+			}
+		}
+		return cnt
+	}
+	cnt := 0
+	for _, tokens := range doc.columns {
+	outer:
+		for i := 0; i+len(c.tokens) <= len(tokens); i++ {
+			for j, token := range c.tokens {
+				if tokens[i+j] != token {
+					continue outer
+				}
+			}
+			cnt++
+		}
+	}
+	return cnt
+}
+
+func (doc *Document) evalClause(c *clause) (bool, float64) {
+	if c.group != nil {
+		return doc.evalGroup(c.group)
+	}
+	tf := doc.termFreq(c)
+	if tf == 0 {
+		return false, 0
+	}
+	return true, 1 + math.Log(float64(tf))
+}
+
+// evalGroup returns whether the document matches the group of clauses and its score. The
+// document matches the group if it matches all the `+` clauses and none of the `-` clauses,
+// and matches at least one optional clause if there isn't any `+` clause.
+func (doc *Document) evalGroup(clauses []*clause) (bool, float64) {
+	hasMust, matchAny := false, false
+	score := 0.0
+	for _, c := range clauses {
+		matched, s := doc.evalClause(c)
+		switch c.op {
+		case opMust:
+			if !matched {
+				return false, 0
+			}
+			hasMust = true
+			score += s
+		case opMustNot:
+			if matched {
+				return false, 0
+			}
+		default:
+			if !matched {
+				continue
+			}
+			matchAny = true
+			switch c.op {
+			case opNegate:
+				score -= s * 0.5
+			case opIncrease:
+				score += s * 1.5
+			case opDecrease:
+				score += s * 0.5
+			default:
+				score += s
+			}
+		}
+	}
+	return hasMust || matchAny, score
+}
+
+// Relevance returns the relevance of the document to the query. It returns 0 if the
+// document doesn't match the query, and a positive number otherwise.
+func (q *Query) Relevance(doc *Document) float64 {
+	matched, score := doc.evalGroup(q.clauses)
+	if !matched {
+		return 0
+	}
+	return max(score, minRelevance)
+}
+
+// IndexTerm is a token, or a prefix of tokens, to look up in the fulltext index.
+type IndexTerm struct {
+	Token  string
+	Prefix bool
+}
+
+// IndexTerms returns the terms to look up in the fulltext index to find all the rows that
+// may match the query. Such rows contain all the terms if intersection is true, or any of
+// the terms otherwise. It returns no terms if the query can't be answered by the index.
+// The prefix terms are never intersected since a row may be found several times by them.
+func (q *Query) IndexTerms() (terms []IndexTerm, intersection bool) {
+	for _, c := range q.clauses {
+		if c.op != opMust || c.group != nil || c.prefix {
+			continue
+		}
+		for _, token := range c.tokens {
+			terms = appendTerm(terms, IndexTerm{Token: token})
+		}
+	}
+	if len(terms) > 0 {
+		return terms, true
+	}
+	terms, _ = anyTerms(q.clauses, nil)
+	return terms, false
+}
+
+// anyTerms appends the terms of which at least one is contained by the rows matching the
+// group. It returns false if there are no such terms.
+func anyTerms(group []*clause, terms []IndexTerm) ([]IndexTerm, bool) {
+	for _, c := range group {
+		if c.op == opMust {
+			return anyTermsOfClause(c, terms)
+		}
+	}
+	found := false
+	for _, c := range group {
+		if c.op == opMustNot {
+			continue
+		}
+		var ok bool
+		if terms, ok = anyTermsOfClause(c, terms); !ok {
+			return nil, false
+		}
+		found = true
+	}
+	return terms, found
+}
+
+func anyTermsOfClause(c *clause, terms []IndexTerm) ([]IndexTerm, bool) {
+	if c.group != nil {
+		return anyTerms(c.group, terms)
+	}
+	// A row matching the phrase must contain its first token.
+	return appendTerm(terms, IndexTerm{Token: c.tokens[0], Prefix: c.prefix}), true
+}
+
+func appendTerm(terms []IndexTerm, term IndexTerm) []IndexTerm {
+	for _, t := range terms {
+		if t == term {
+			return terms
+		}
+	}
+	return append(terms, term)
+}
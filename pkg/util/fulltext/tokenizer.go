@@ -0,0 +1,127 @@
+// Copyright 2024 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package fulltext
+
+import (
+	"slices"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+const (
+	// ParserStandard is the name of the default parser, which splits the text by the delimiters.
+	ParserStandard = "standard"
+	// ParserNgram is the name of the ngram parser, which is used for the CJK text.
+	ParserNgram = "ngram"
+
+	// MinTokenSize is the minimum length of the tokens of the standard parser, same as
+	// the default value of innodb_ft_min_token_size.
+	MinTokenSize = 3
+	// MaxTokenSize is the maximum length of the tokens, same as the default value of
+	// innodb_ft_max_token_size.
+	MaxTokenSize = 84
+	// NgramTokenSize is the length of the tokens of the ngram parser, same as the default
+	// value of ngram_token_size.
+	NgramTokenSize = 2
+)
+
+// stopwords is the default stopword list of InnoDB, see INFORMATION_SCHEMA.INNODB_FT_DEFAULT_STOPWORD.
+var stopwords = map[string]struct{}{
+	"a": {}, "about": {}, "an": {}, "are": {}, "as": {}, "at": {}, "be": {}, "by": {}, "com": {},
+	"de": {}, "en": {}, "for": {}, "from": {}, "how": {}, "i": {}, "in": {}, "is": {}, "it": {},
+	"la": {}, "of": {}, "on": {}, "or": {}, "that": {}, "the": {}, "this": {}, "to": {}, "was": {},
+	"what": {}, "when": {}, "where": {}, "who": {}, "will": {}, "with": {}, "und": {}, "www": {},
+}
+
+// Tokenizer splits a text into the tokens stored in the fulltext index.
+type Tokenizer interface {
+	// Tokenize returns the tokens of the text in order, the duplicated tokens are kept.
+	Tokenize(text string) []string
+}
+
+// IsSupportedParser returns whether the parser can be used by the fulltext index.
+// An empty name means the default parser.
+func IsSupportedParser(name string) bool {
+	switch strings.ToLower(name) {
+	case "", ParserStandard, ParserNgram:
+		return true
+	}
+	return false
+}
+
+// NewTokenizer returns the tokenizer of the parser. It returns the standard tokenizer
+// for an empty or unknown name, the name should be checked by IsSupportedParser first.
+func NewTokenizer(parser string) Tokenizer {
+	if strings.EqualFold(parser, ParserNgram) {
+		return ngramTokenizer{n: NgramTokenSize}
+	}
+	return standardTokenizer{}
+}
+
+// Tokens returns the sorted distinct tokens of the texts, which are the keys written into
+// the fulltext index for a row.
+func Tokens(tk Tokenizer, texts ...string) []string {
+	var tokens []string
+	for _, text := range texts {
+		tokens = append(tokens, tk.Tokenize(text)...)
+	}
+	slices.Sort(tokens)
+	return slices.Compact(tokens)
+}
+
+func isDelimiter(r rune) bool {
+	return !unicode.IsLetter(r) && !unicode.IsDigit(r) && r != '_'
+}
+
+// standardTokenizer splits the text into words by the delimiters. The words that are too
+// short, too long or in the stopword list are skipped.
+type standardTokenizer struct{}
+
+// Tokenize implements the Tokenizer interface.
+func (standardTokenizer) Tokenize(text string) []string {
+	words := strings.FieldsFunc(text, isDelimiter)
+	tokens := make([]string, 0, len(words))
+	for _, w := range words {
+		n := utf8.RuneCountInString(w)
+		if n < MinTokenSize || n > MaxTokenSize {
+			continue
+		}
+		w = strings.ToLower(w)
+		if _, ok := stopwords[w]; ok {
+			continue
+		}
+		tokens = append(tokens, w)
+	}
+	return tokens
+}
+
+// ngramTokenizer splits the text into the contiguous sequences of n characters. The
+// delimiters are not part of any token, and the words shorter than n are skipped.
+type ngramTokenizer struct {
+	n int
+}
+
+// Tokenize implements the Tokenizer interface.
+func (t ngramTokenizer) Tokenize(text string) []string {
+	var tokens []string
+	for _, w := range strings.FieldsFunc(text, isDelimiter) {
+		runes := []rune(strings.ToLower(w))
+		for i := 0; i+t.n <= len(runes); i++ {
+			tokens = append(tokens, string(runes[i:i+t.n]))
+		}
+	}
+	return tokens
+}
@@ -165,6 +165,18 @@ func DeserializeBinaryJSON(posAndBuf *PosAndBuf) types.BinaryJSON {
 	return retValue
 }
 
+// DeserializeVectorFloat32 deserializes VectorFloat32 type
+func DeserializeVectorFloat32(posAndBuf *PosAndBuf) types.VectorFloat32 {
+	buf := deserializeBuffer(posAndBuf)
+	data := make([]byte, len(buf))
+	copy(data, buf)
+	retValue, _, err := types.ZeroCopyDeserializeVectorFloat32(data)
+	if err != nil {
+		panic(err)
+	}
+	return retValue
+}
+
 // DeserializeSet deserializes Set type
 func DeserializeSet(posAndBuf *PosAndBuf) types.Set {
 	retValue := types.Set{}
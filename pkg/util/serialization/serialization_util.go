@@ -143,6 +143,11 @@ func SerializeBinaryJSON(value *types.BinaryJSON, buf []byte) []byte {
 	return serializeBuffer(value.Value, buf)
 }
 
+// SerializeVectorFloat32 serializes VectorFloat32 type
+func SerializeVectorFloat32(value types.VectorFloat32, buf []byte) []byte {
+	return serializeBuffer(value.ZeroCopySerialize(), buf)
+}
+
 // SerializeSet serializes Set type
 func SerializeSet(value *types.Set, buf []byte) []byte {
 	buf = SerializeUint64(value.Value, buf)
@@ -439,6 +439,51 @@ func (ssMap *stmtSummaryByDigestMap) GetMoreThanCntBindableStmt(cnt int64) []*Bi
 	return stmts
 }
 
+// WorkloadStmt is a statement in the workload, which is used to recommend indexes.
+type WorkloadStmt struct {
+	Schema     string
+	Digest     string
+	Query      string
+	ExecCount  int64
+	SumLatency time.Duration
+}
+
+// GetWorkloadStmts gets users' select SQLs in the current window.
+func (ssMap *stmtSummaryByDigestMap) GetWorkloadStmts() []*WorkloadStmt {
+	ssMap.Lock()
+	values := ssMap.summaryMap.Values()
+	ssMap.Unlock()
+
+	stmts := make([]*WorkloadStmt, 0, len(values))
+	for _, value := range values {
+		ssbd := value.(*stmtSummaryByDigest)
+		func() {
+			ssbd.Lock()
+			defer ssbd.Unlock()
+			if !ssbd.initialized || ssbd.stmtType != "Select" || ssbd.history.Len() == 0 {
+				return
+			}
+			ssElement := ssbd.history.Back().Value.(*stmtSummaryByDigestElement)
+			ssElement.Lock()
+			defer ssElement.Unlock()
+			// Empty auth users means that it is an internal queries. The sample SQL of the
+			// prepared statements has no parameters, so they are skipped.
+			if len(ssElement.authUsers) == 0 || ssElement.execCount == 0 || ssElement.prepared {
+				return
+			}
+			stmt := &WorkloadStmt{
+				Schema:     ssbd.schemaName,
+				Digest:     ssbd.digest,
+				Query:      ssElement.sampleSQL,
+				ExecCount:  ssElement.execCount,
+				SumLatency: ssElement.sumLatency,
+			}
+			stmts = append(stmts, stmt)
+		}()
+	}
+	return stmts
+}
+
 // SetEnabled enables or disables statement summary
 func (ssMap *stmtSummaryByDigestMap) SetEnabled(value bool) error {
 	// `optEnabled` and `ssMap` don't need to be strictly atomically updated.
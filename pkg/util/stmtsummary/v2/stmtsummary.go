@@ -378,6 +378,35 @@ func (s *StmtSummary) GetMoreThanCntBindableStmt(cnt int64) []*stmtsummary.Binda
 	return stmts
 }
 
+// GetWorkloadStmts is used to get the select statements to recommend indexes.
+// Like GetMoreThanCntBindableStmt, we only refer to the statistics data of
+// the current window in memory.
+func (s *StmtSummary) GetWorkloadStmts() []*stmtsummary.WorkloadStmt {
+	s.windowLock.Lock()
+	values := s.window.lru.Values()
+	s.windowLock.Unlock()
+	stmts := make([]*stmtsummary.WorkloadStmt, 0, len(values))
+	for _, value := range values {
+		record := value.(*lockedStmtRecord)
+		func() {
+			record.Lock()
+			defer record.Unlock()
+			if record.StmtType != "Select" || len(record.AuthUsers) == 0 || record.ExecCount == 0 || record.Prepared {
+				return
+			}
+			stmt := &stmtsummary.WorkloadStmt{
+				Schema:     record.SchemaName,
+				Digest:     record.Digest,
+				Query:      record.SampleSQL,
+				ExecCount:  record.ExecCount,
+				SumLatency: record.SumLatency,
+			}
+			stmts = append(stmts, stmt)
+		}()
+	}
+	return stmts
+}
+
 func (s *StmtSummary) rotateLoop() {
 	tick := time.NewTicker(defaultRotateCheckInterval * time.Second)
 	defer tick.Stop()
@@ -615,3 +644,12 @@ func GetMoreThanCntBindableStmt(frequency int64) []*stmtsummary.BindableStmt {
 	}
 	return stmtsummary.StmtSummaryByDigestMap.GetMoreThanCntBindableStmt(frequency)
 }
+
+// GetWorkloadStmts wraps GlobalStmtSummary.GetWorkloadStmts and
+// stmtsummary.StmtSummaryByDigestMap.GetWorkloadStmts.
+func GetWorkloadStmts() []*stmtsummary.WorkloadStmt {
+	if config.GetGlobalConfig().Instance.StmtSummaryEnablePersistent {
+		return GlobalStmtSummary.GetWorkloadStmts()
+	}
+	return stmtsummary.StmtSummaryByDigestMap.GetWorkloadStmts()
+}
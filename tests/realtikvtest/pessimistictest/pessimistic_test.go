@@ -3602,3 +3602,40 @@ func TestEndTxnOnLockExpire(t *testing.T) {
 		})
 	}
 }
+
+func TestSelectForUpdateSkipLocked(t *testing.T) {
+	store := realtikvtest.CreateMockStoreAndSetup(t)
+
+	tk := testkit.NewTestKit(t, store)
+	tk.MustExec("use test")
+	tk1 := testkit.NewTestKit(t, store)
+	tk1.MustExec("use test")
+
+	tk.MustExec("drop table if exists t")
+	tk.MustExec("create table t (id int primary key, c int, unique key uk(c))")
+	tk.MustExec("insert t values (1, 1), (2, 2), (3, 3), (4, 4), (5, 5)")
+
+	// The limit is applied after the rows are locked.
+	rows := tk.MustQuery("explain format = 'brief' select * from t where c > 0 limit 2 for update skip locked").Rows()
+	require.True(t, strings.HasPrefix(rows[0][0].(string), "Limit"), fmt.Sprintf("plan %v", rows))
+	require.True(t, strings.HasPrefix(rows[1][0].(string), "SelectLock"), fmt.Sprintf("plan %v", rows))
+
+	tk1.MustExec("begin pessimistic")
+	tk1.MustQuery("select * from t where id in (1, 3) for update").Check(testkit.Rows("1 1", "3 3"))
+
+	tk.MustExec("begin pessimistic")
+	tk.MustQuery("select * from t where c > 0 order by id limit 2 for update skip locked").Check(testkit.Rows("2 2", "4 4"))
+	// Point get.
+	tk.MustQuery("select * from t where id = 1 for update skip locked").Check(testkit.Rows())
+	tk.MustQuery("select * from t where id = 5 for update skip locked").Check(testkit.Rows("5 5"))
+	// Batch point get by handles and by the unique index.
+	tk.MustQuery("select * from t where id in (1, 2, 3) for update skip locked").Check(testkit.Rows("2 2"))
+	tk.MustQuery("select * from t where c in (3, 4) for update skip locked").Check(testkit.Rows("4 4"))
+
+	err := tk1.ExecToErr("select * from t where id = 5 for update nowait")
+	require.True(t, storeerr.ErrLockAcquireFailAndNoWaitSet.Equal(err), fmt.Sprintf("err %v", err))
+	tk1.MustExec("rollback")
+
+	tk.MustQuery("select * from t where c > 0 order by id for update skip locked").Check(testkit.Rows("1 1", "2 2", "3 3", "4 4", "5 5"))
+	tk.MustExec("rollback")
+}